@@ -75,6 +75,10 @@ type AccountRegisterInput struct {
 	Channel      *string           `json:"channel"`
 }
 
+type AccountRequestDataExport struct {
+	ExportFile *ExportFile `json:"exportFile"`
+}
+
 type AccountRequestDeletion struct {
 	Ok bool `json:"ok"`
 }
@@ -83,6 +87,10 @@ type AccountSetDefaultAddress struct {
 	User *User `json:"user"`
 }
 
+type AccountSetFavoriteCategories struct {
+	User *User `json:"user"`
+}
+
 type AccountUpdate struct {
 	User *User `json:"user"`
 }
@@ -573,6 +581,82 @@ type AttributeValueDelete struct {
 	AttributeValue *AttributeValue   `json:"attributeValue"`
 }
 
+type AttributeFilterOperator = model_helper.AttributeFilterOperator
+
+// AttributeWhereInput combines attribute value filters and nested groups of them with AND (default) or OR
+type AttributeWhereInput struct {
+	Operator *AttributeFilterOperator    `json:"operator"`
+	Filters  []*AttributeValueWhereInput `json:"filters"`
+	Groups   []*AttributeWhereInput      `json:"groups"`
+}
+
+type AttributeValueWhereInput struct {
+	Slug         string                      `json:"slug"`
+	Values       []string                    `json:"values"` // slugs of values
+	ValuesRange  *AttributeNumericRangeInput `json:"valuesRange"`
+	Boolean      *bool                       `json:"boolean"`
+	Date         *DateRangeInput             `json:"date"`
+	DateTime     *DateTimeRangeInput         `json:"dateTime"`
+	ReferenceIds []UUID                      `json:"referenceIds"`
+}
+
+type AttributeNumericRangeInput struct {
+	Gte  *float64              `json:"gte"`
+	Lte  *float64              `json:"lte"`
+	Unit *MeasurementUnitsEnum `json:"unit"`
+}
+
+// toSystem converts given input into system attribute filter
+func (a *AttributeWhereInput) toSystem() *model_helper.AttributeWhereInput {
+	if a == nil {
+		return nil
+	}
+
+	res := &model_helper.AttributeWhereInput{
+		Operator: lo.FromPtr(a.Operator),
+		Groups:   lo.Map(a.Groups, func(group *AttributeWhereInput, _ int) *model_helper.AttributeWhereInput { return group.toSystem() }),
+	}
+	for _, filter := range a.Filters {
+		if filter == nil {
+			continue
+		}
+
+		systemFilter := &model_helper.AttributeValueFilter{
+			Slug:         filter.Slug,
+			Values:       filter.Values,
+			Boolean:      filter.Boolean,
+			ReferenceIDs: lo.Map(filter.ReferenceIds, func(id UUID, _ int) string { return string(id) }),
+		}
+		if filter.ValuesRange != nil {
+			systemFilter.ValuesRange = &model_helper.AttributeNumericRange{
+				Gte:  filter.ValuesRange.Gte,
+				Lte:  filter.ValuesRange.Lte,
+				Unit: (*string)(filter.ValuesRange.Unit),
+			}
+		}
+		if filter.Date != nil {
+			systemFilter.Date = &model_helper.AttributeTimeRange{}
+			if filter.Date.Gte != nil {
+				systemFilter.Date.Gte = &filter.Date.Gte.Time
+			}
+			if filter.Date.Lte != nil {
+				systemFilter.Date.Lte = &filter.Date.Lte.Time
+			}
+		}
+		if filter.DateTime != nil {
+			systemFilter.DateTime = &model_helper.AttributeTimeRange{}
+			if filter.DateTime.Gte != nil {
+				systemFilter.DateTime.Gte = &filter.DateTime.Gte.Time
+			}
+			if filter.DateTime.Lte != nil {
+				systemFilter.DateTime.Lte = &filter.DateTime.Lte.Time
+			}
+		}
+		res.Filters = append(res.Filters, systemFilter)
+	}
+	return res
+}
+
 type AttributeValueFilterInput struct {
 	Search *string `json:"search"` // find attribute values with Name ILIKE %...% OR Slug ILIKE %...%
 }
@@ -619,6 +703,12 @@ type AttributeValueUpdate struct {
 	AttributeValue *AttributeValue   `json:"attributeValue"`
 }
 
+type BackInStockSubscribeInput struct {
+	Variant UUID    `json:"variant"`
+	Channel *UUID   `json:"channel"`
+	Email   *string `json:"email"`
+}
+
 type BulkAttributeValueInput struct {
 	ID      *string  `json:"id"`
 	Values  []string `json:"values"`
@@ -934,6 +1024,11 @@ type CheckoutPaymentCreate struct {
 	Errors   []*PaymentError `json:"errors"`
 }
 
+type CheckoutRecover struct {
+	Checkout *Checkout        `json:"checkout"`
+	Errors   []*CheckoutError `json:"errors"`
+}
+
 type CheckoutRemovePromoCode struct {
 	Checkout *Checkout        `json:"checkout"`
 	Errors   []*CheckoutError `json:"errors"`
@@ -1408,7 +1503,7 @@ func (d *DraftOrderCreateInput) patchOrder(embedCtx *web.Context, order *model.O
 		for _, line := range d.Lines {
 			variant := variantMap[line.VariantID]
 			if variant != nil {
-				addedOrderLine, insufErr, appErr := embedCtx.App.Srv().OrderService().AddVariantToOrder(*order, *variant, int(line.Quantity), user, nil, pluginMng, []*model_helper.DiscountInfo{}, false)
+				addedOrderLine, insufErr, appErr := embedCtx.App.Srv().OrderService().AddVariantToOrder(nil, *order, *variant, int(line.Quantity), user, nil, pluginMng, []*model_helper.DiscountInfo{}, false)
 				if appErr != nil {
 					return appErr
 				}
@@ -1671,25 +1766,6 @@ type DraftOrderUpdate struct {
 	Order  *Order        `json:"order"`
 }
 
-type ExportEvent struct {
-	ID      string           `json:"id"`
-	Date    DateTime         `json:"date"`
-	Type    ExportEventsEnum `json:"type"`
-	User    *User            `json:"user"`
-	Message string           `json:"message"`
-}
-
-type ExportFile struct {
-	ID        string         `json:"id"`
-	User      *User          `json:"user"`
-	Status    JobStatusEnum  `json:"status"`
-	CreatedAt DateTime       `json:"createdAt"`
-	UpdatedAt DateTime       `json:"updatedAt"`
-	Message   *string        `json:"message"`
-	URL       *string        `json:"url"`
-	Events    []*ExportEvent `json:"events"`
-}
-
 type ExportFileCountableConnection struct {
 	PageInfo   *PageInfo                  `json:"pageInfo"`
 	Edges      []*ExportFileCountableEdge `json:"edges"`
@@ -1720,6 +1796,39 @@ type ExportInfoInput struct {
 	Fields     []ProductFieldEnum `json:"fields"`
 }
 
+type ExportCustomers struct {
+	ExportFile *ExportFile `json:"exportFile"`
+}
+
+type ExportCustomersInput struct {
+	Scope    ExportScope          `json:"scope"`
+	Filter   *CustomerFilterInput `json:"filter"`
+	Ids      []string             `json:"ids"`
+	FileType FileTypesEnum        `json:"fileType"`
+}
+
+type ExportGiftCards struct {
+	ExportFile *ExportFile `json:"exportFile"`
+}
+
+type ExportGiftCardsInput struct {
+	Scope    ExportScope          `json:"scope"`
+	Filter   *GiftCardFilterInput `json:"filter"`
+	Ids      []string             `json:"ids"`
+	FileType FileTypesEnum        `json:"fileType"`
+}
+
+type ExportOrders struct {
+	ExportFile *ExportFile `json:"exportFile"`
+}
+
+type ExportOrdersInput struct {
+	Scope    ExportScope       `json:"scope"`
+	Filter   *OrderFilterInput `json:"filter"`
+	Ids      []string          `json:"ids"`
+	FileType FileTypesEnum     `json:"fileType"`
+}
+
 type ExportProducts struct {
 	ExportFile *ExportFile `json:"exportFile"`
 }
@@ -2030,6 +2139,15 @@ type Image struct {
 	Alt *string `json:"alt"`
 }
 
+type ImportProducts struct {
+	JobID string `json:"jobId"`
+}
+
+type ImportProductsInput struct {
+	File   UUID `json:"file"`
+	DryRun bool `json:"dryRun"`
+}
+
 type IntRangeInput struct {
 	Gte *int32 `json:"gte"`
 	Lte *int32 `json:"lte"`
@@ -2580,6 +2698,23 @@ func (ip *OrderDraftFilterInput) parse(where string) (*model.OrderFilterOption,
 	return &res, nil
 }
 
+type OrderEdit struct {
+	Order  *Order        `json:"order"`
+	Errors []*OrderError `json:"errors"`
+}
+
+type OrderEditInput struct {
+	AddLines       []*OrderLineCreateInput     `json:"addLines"`
+	UpdateLines    []*OrderEditLineUpdateInput `json:"updateLines"`
+	RemoveLines    []UUID                      `json:"removeLines"`
+	ShippingMethod *UUID                       `json:"shippingMethod"`
+}
+
+type OrderEditLineUpdateInput struct {
+	LineID   UUID  `json:"lineId"`
+	Quantity int32 `json:"quantity"`
+}
+
 type OrderError struct {
 	Field       *string                       `json:"field"`
 	Message     *string                       `json:"message"`
@@ -3333,6 +3468,12 @@ type ProductError struct {
 
 type AttributeInputTypeEnum = model.AttributeInputType
 
+type ProductFeed struct {
+	Products   []*Product `json:"products"`
+	NextCursor *string    `json:"nextCursor"`
+	ColdStart  bool       `json:"coldStart"`
+}
+
 type ProductFilterInput struct {
 	IsPublished           *bool                    `json:"isPublished"`
 	Collections           []string                 `json:"collections"`
@@ -3349,6 +3490,7 @@ type ProductFilterInput struct {
 	MinimalPrice          *PriceRangeInput         `json:"minimalPrice"`
 	GiftCard              *bool                    `json:"giftCard"`
 	HasPreorderedVariants *bool                    `json:"hasPreorderedVariants"`
+	AttributesWhere       *AttributeWhereInput     `json:"attributesWhere"`
 	// Channel               *string                  `json:"channel"`
 }
 
@@ -3368,6 +3510,9 @@ func (p *ProductFilterInput) validate(where string) *model_helper.AppError {
 			return appErr
 		}
 	}
+	if err := p.AttributesWhere.toSystem().Validate(); err != nil {
+		return model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "attributes where"}, err.Error(), http.StatusBadRequest)
+	}
 	if p.StockAvailability != nil && !p.StockAvailability.IsValid() {
 		return model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fieds": "stock availability"}, "please provide valid stock availability", http.StatusBadRequest)
 	}
@@ -3395,69 +3540,68 @@ func (p *ProductFilterInput) validate(where string) *model_helper.AppError {
 }
 
 // NOTE: make sure to call me after caling validate
-func (p *ProductFilterInput) toSystemProductFilterInput() *model.ProductFilterInput {
-	systemAttributeFilter := lo.Map(p.Attributes, func(item *AttributeInput, _ int) *model.AttributeFilter {
-		res := &model.AttributeFilter{
-			Slug:   item.Slug,
-			Values: item.Values,
-			ValuesRange: &struct {
+func (p *ProductFilterInput) toSystemProductFilterInput() *model_helper.ProductFilterInput {
+	systemAttributeFilter := lo.Map(p.Attributes, func(item *AttributeInput, _ int) *model_helper.AttributeFilter {
+		res := &model_helper.AttributeFilter{
+			Slug:    item.Slug,
+			Values:  item.Values,
+			Boolean: item.Boolean,
+		}
+		if item.ValuesRange != nil {
+			res.ValuesRange = &struct {
 				Gte *int32
 				Lte *int32
 			}{
 				Gte: item.ValuesRange.Gte,
 				Lte: item.ValuesRange.Lte,
-			},
-			Boolean: item.Boolean,
-		}
-
-		if item.DateTime != nil && item.DateTime.Gte != nil {
-			res.DateTime.Gte = &item.DateTime.Gte.Time
-		}
-		if item.DateTime != nil && item.DateTime.Lte != nil {
-			res.DateTime.Lte = &item.DateTime.Lte.Time
-		}
-
-		if item.Date != nil && item.Date.Gte != nil {
-			res.Date.Gte = &item.Date.Gte.Time
+			}
 		}
-		if item.Date != nil && item.Date.Lte != nil {
-			res.Date.Lte = &item.Date.Lte.Time
+		if item.DateTime != nil {
+			res.DateTime = &struct {
+				Gte *time.Time
+				Lte *time.Time
+			}{}
+			if item.DateTime.Gte != nil {
+				res.DateTime.Gte = &item.DateTime.Gte.Time
+			}
+			if item.DateTime.Lte != nil {
+				res.DateTime.Lte = &item.DateTime.Lte.Time
+			}
 		}
-		return res
-	})
-
-	metadata := lo.Map(p.Metadata, func(m *MetadataInput, _ int) *struct {
-		Key   string
-		Value string
-	} {
-		res := &struct {
-			Key   string
-			Value string
-		}{}
-		if m != nil {
-			res.Key = m.Key
-			res.Value = m.Value
+		if item.Date != nil {
+			res.Date = &struct {
+				Gte *time.Time
+				Lte *time.Time
+			}{}
+			if item.Date.Gte != nil {
+				res.Date.Gte = &item.Date.Gte.Time
+			}
+			if item.Date.Lte != nil {
+				res.Date.Lte = &item.Date.Lte.Time
+			}
 		}
 		return res
 	})
 
-	res := &model.ProductFilterInput{
+	res := &model_helper.ProductFilterInput{
 		IsPublished:           p.IsPublished,
 		Collections:           p.Collections,
 		Categories:            p.Categories,
 		HasCategory:           p.HasCategory,
 		Attributes:            systemAttributeFilter,
 		Search:                p.Search,
-		Metadata:              metadata,
-		ProductTypes:          p.ProductTypes,
+		Metadata:              metadataInputsToSystem(p.Metadata),
 		GiftCard:              p.GiftCard,
 		Ids:                   p.Ids,
 		HasPreorderedVariants: p.HasPreorderedVariants,
-		StockAvailability:     p.StockAvailability,
+		AttributesWhere:       p.AttributesWhere.toSystem(),
 
 		// Channel:               p.Channel,
 	}
 
+	if p.StockAvailability != nil {
+		res.StockAvailability = model_helper.GetPointerOfValue(model_helper.StockAvailability(*p.StockAvailability))
+	}
 	if p.Stocks != nil {
 		res.Stocks = &struct {
 			WarehouseIds []string
@@ -3530,6 +3674,7 @@ type ProductMediaCreateInput struct {
 	Image    *Upload `json:"image"`
 	Product  string  `json:"product"`
 	MediaURL *string `json:"mediaUrl"`
+	Ppoi     *string `json:"ppoi"` // primary point of interest, e.g "0.5x0.5"
 }
 
 type ProductMediaDelete struct {
@@ -3590,6 +3735,65 @@ type ProductReorderAttributeValues struct {
 	Errors  []*ProductError `json:"errors"`
 }
 
+type ProductSearchAttributeFacet struct {
+	Attribute string                     `json:"attribute"`
+	Values    []*ProductSearchValueFacet `json:"values"`
+}
+
+type ProductSearchAttributeInput struct {
+	Slug   string   `json:"slug"`
+	Values []string `json:"values"`
+}
+
+type ProductSearchFilterInput struct {
+	Attributes        []*ProductSearchAttributeInput `json:"attributes"`
+	Categories        []UUID                         `json:"categories"`
+	Collections       []UUID                         `json:"collections"`
+	StockAvailability *StockAvailability             `json:"stockAvailability"`
+	Price             *PriceRangeInput               `json:"price"`
+}
+
+func (p *ProductSearchFilterInput) validate(where string) *model_helper.AppError {
+	for _, attribute := range p.Attributes {
+		if attribute == nil || attribute.Slug == "" || len(attribute.Values) == 0 {
+			return model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "attributes"}, "please provide attribute slugs along with their values", http.StatusBadRequest)
+		}
+	}
+	if p.StockAvailability != nil && !model_helper.StockAvailability(strings.ToLower(string(*p.StockAvailability))).IsValid() {
+		return model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "stockAvailability"}, "", http.StatusBadRequest)
+	}
+	if p.Price != nil {
+		return p.Price.validate(where)
+	}
+
+	return nil
+}
+
+type ProductSearchPriceFacet struct {
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+	Count int32    `json:"count"`
+}
+
+type ProductSearchResult struct {
+	Products        []*Product                     `json:"products"`
+	TotalCount      int32                          `json:"totalCount"`
+	AttributeFacets []*ProductSearchAttributeFacet `json:"attributeFacets"`
+	PriceFacets     []*ProductSearchPriceFacet     `json:"priceFacets"`
+	InStockCount    int32                          `json:"inStockCount"`
+	OutOfStockCount int32                          `json:"outOfStockCount"`
+}
+
+type ProductSearchSortingInput struct {
+	Direction OrderDirection         `json:"direction"`
+	Field     ProductSearchSortField `json:"field"`
+}
+
+type ProductSearchValueFacet struct {
+	Value string `json:"value"`
+	Count int32  `json:"count"`
+}
+
 type ProductStockFilterInput struct {
 	WarehouseIds []string       `json:"warehouseIds"`
 	Quantity     *IntRangeInput `json:"quantity"`
@@ -3609,6 +3813,13 @@ func (p *ProductStockFilterInput) validate(where string) *model_helper.AppError
 	return nil
 }
 
+// ProductStructuredData holds JSON-LD documents of a product's storefront page, to be embedded in
+// <script type="application/ld+json"> tags
+type ProductStructuredData struct {
+	Product     JSONString `json:"product"`
+	Breadcrumbs JSONString `json:"breadcrumbs"`
+}
+
 type ProductTranslatableContent struct {
 	ID             string              `json:"id"`
 	SeoTitle       *string             `json:"seoTitle"`
@@ -3792,15 +4003,26 @@ type ProductUpdate struct {
 	Product *Product        `json:"product"`
 }
 
+type ProductVariantBackInStockSubscribe struct {
+	ProductVariant *ProductVariant `json:"productVariant"`
+	Errors         []*ProductError `json:"errors"`
+}
+
 type ProductVariantBulkCreate struct {
 	Count           int32               `json:"count"`
 	ProductVariants []*ProductVariant   `json:"productVariants"`
 	Errors          []*BulkProductError `json:"errors"`
 }
 
+type ProductVariantBulkUpdate struct {
+	Count           int32               `json:"count"`
+	ProductVariants []*ProductVariant   `json:"productVariants"`
+	Errors          []*BulkProductError `json:"errors"`
+}
+
 type ProductVariantBulkDelete struct {
-	Count  int32           `json:"count"`
-	Errors []*ProductError `json:"errors"`
+	Count  int32               `json:"count"`
+	Errors []*BulkProductError `json:"errors"`
 }
 
 type PreorderThreshold struct {
@@ -3842,12 +4064,26 @@ type PreorderSettingsInput struct {
 }
 
 type ProductVariantBulkCreateInput struct {
-	Attributes      []*BulkAttributeValueInput              `json:"attributes"`
-	Sku             *string                                 `json:"sku"`
-	TrackInventory  *bool                                   `json:"trackInventory"`
-	Weight          *WeightScalar                           `json:"weight"`
-	Stocks          []*StockInput                           `json:"stocks"`
-	ChannelListings []*ProductVariantChannelListingAddInput `json:"channelListings"`
+	Attributes        []*BulkAttributeValueInput              `json:"attributes"`
+	Sku               *string                                 `json:"sku"`
+	TrackInventory    *bool                                   `json:"trackInventory"`
+	Weight            *WeightScalar                           `json:"weight"`
+	Stocks            []*StockInput                           `json:"stocks"`
+	ChannelListings   []*ProductVariantChannelListingAddInput `json:"channelListings"`
+	LowStockThreshold *int32                                  `json:"lowStockThreshold"`
+}
+
+type ProductVariantBulkUpdateInput struct {
+	ID                       UUID                                    `json:"id"`
+	Attributes               []*BulkAttributeValueInput              `json:"attributes"`
+	Sku                      *string                                 `json:"sku"`
+	Name                     *string                                 `json:"name"`
+	TrackInventory           *bool                                   `json:"trackInventory"`
+	Weight                   *WeightScalar                           `json:"weight"`
+	QuantityLimitPerCustomer *int32                                  `json:"quantityLimitPerCustomer"`
+	LowStockThreshold        *int32                                  `json:"lowStockThreshold"`
+	Stocks                   []*StockInput                           `json:"stocks"`
+	ChannelListings          []*ProductVariantChannelListingAddInput `json:"channelListings"`
 }
 
 type ProductVariantCreateInput struct {
@@ -3858,11 +4094,12 @@ type ProductVariantCreateInput struct {
 }
 
 type ProductVariantInput struct {
-	Attributes     []*AttributeValueInput `json:"attributes"`
-	Sku            *string                `json:"sku"`
-	TrackInventory *bool                  `json:"trackInventory"`
-	Weight         *WeightScalar          `json:"weight"`
-	PreOrder       *PreorderSettingsInput `json:"preorder"`
+	Attributes        []*AttributeValueInput `json:"attributes"`
+	Sku               *string                `json:"sku"`
+	TrackInventory    *bool                  `json:"trackInventory"`
+	Weight            *WeightScalar          `json:"weight"`
+	PreOrder          *PreorderSettingsInput `json:"preorder"`
+	LowStockThreshold *int32                 `json:"lowStockThreshold"`
 }
 
 func (p *ProductVariantInput) validate(where string, ctx *web.Context, instance *model.ProductVariant) *model_helper.AppError {
@@ -4715,8 +4952,9 @@ type StockFilterInput struct {
 }
 
 type StockInput struct {
-	Warehouse UUID  `json:"warehouse"`
-	Quantity  int32 `json:"quantity"`
+	Warehouse         UUID   `json:"warehouse"`
+	Quantity          int32  `json:"quantity"`
+	LowStockThreshold *int32 `json:"lowStockThreshold"`
 }
 
 type AddressTypeEnum = model_helper.AddressTypeEnum
@@ -4791,6 +5029,23 @@ type UpdatePrivateMetadata struct {
 	Item   ObjectWithMetadata `json:"item"`
 }
 
+type UploadSessionComplete struct {
+	FileID       *UUID          `json:"fileId"`
+	UploadedFile *File          `json:"uploadedFile"`
+	Errors       []*UploadError `json:"errors"`
+}
+
+type UploadSessionCreate struct {
+	UploadSessionID *UUID          `json:"uploadSessionId"`
+	UploadURL       *string        `json:"uploadUrl"`
+	Errors          []*UploadError `json:"errors"`
+}
+
+type UploadSessionCreateInput struct {
+	FileName string `json:"fileName"`
+	FileSize int32  `json:"fileSize"`
+}
+
 type UploadError struct {
 	Field   *string         `json:"field"`
 	Message *string         `json:"message"`
@@ -5897,6 +6152,8 @@ type DiscountValueTypeEnum = model.DiscountValueType
 
 type DistanceUnitsEnum = measurement.DistanceUnit
 
+type ErrorPolicyEnum = model_helper.ProductVariantBulkErrorPolicy
+
 type ExportEventsEnum = model.ExportEventType
 
 type ExportFileSortField string
@@ -6593,6 +6850,39 @@ type ProductMediaType = model.ProductMediaType
 
 type ProductOrderField = model_helper.ProductOrderField
 
+type ProductRecommendationKind string
+
+const (
+	ProductRecommendationKindCoPurchase ProductRecommendationKind = "CO_PURCHASE"
+	ProductRecommendationKindSimilar    ProductRecommendationKind = "SIMILAR"
+)
+
+func (e ProductRecommendationKind) IsValid() bool {
+	switch e {
+	case ProductRecommendationKindCoPurchase, ProductRecommendationKindSimilar:
+		return true
+	}
+	return false
+}
+
+type ProductSearchSortField string
+
+const (
+	ProductSearchSortFieldRelevance ProductSearchSortField = "RELEVANCE"
+	ProductSearchSortFieldName      ProductSearchSortField = "NAME"
+	ProductSearchSortFieldPrice     ProductSearchSortField = "PRICE"
+	ProductSearchSortFieldCreatedAt ProductSearchSortField = "CREATED_AT"
+	ProductSearchSortFieldRating    ProductSearchSortField = "RATING"
+)
+
+func (e ProductSearchSortField) IsValid() bool {
+	switch e {
+	case ProductSearchSortFieldRelevance, ProductSearchSortFieldName, ProductSearchSortFieldPrice, ProductSearchSortFieldCreatedAt, ProductSearchSortFieldRating:
+		return true
+	}
+	return false
+}
+
 type ProductTypeConfigurable string
 
 const (
@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"unsafe"
 
 	"github.com/mattermost/squirrel"
 	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
 	"github.com/sitename/sitename/web"
 )
 
@@ -28,14 +30,18 @@ func (r *Resolver) AccountAddressCreate(ctx context.Context, args struct {
 		return nil, appErr
 	}
 
-	// TODO: consider adding validation for specific country
-
 	// construct address
 	address := model.Address{}
 	args.Input.PatchAddress(&address)
 
+	// validate address against its country's rules
+	normalizedAddress, appErr := embedContext.App.AccountService().ValidateAddress(address)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	// insert address
-	savedAddress, appErr := embedContext.App.AccountService().UpsertAddress(nil, address)
+	savedAddress, appErr := embedContext.App.AccountService().UpsertAddress(nil, *normalizedAddress)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -96,8 +102,14 @@ func (r *Resolver) AccountAddressUpdate(ctx context.Context, args struct {
 
 	args.Input.PatchAddress(address)
 
+	// validate address against its country's rules
+	normalizedAddress, appErr := embedCtx.App.AccountService().ValidateAddress(*address)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	// update address
-	savedAddress, appErr := embedCtx.App.AccountService().UpsertAddress(nil, address)
+	savedAddress, appErr := embedCtx.App.AccountService().UpsertAddress(nil, *normalizedAddress)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -161,6 +173,22 @@ func (r *Resolver) AccountAddressDelete(ctx context.Context, args struct{ Id str
 	}, nil
 }
 
+// NOTE: Refer to ./schemas/account.graphqls for details on directive used
+func (r *Resolver) AccountSetFavoriteCategories(ctx context.Context, args struct{ CategoryIds []UUID }) (*AccountSetFavoriteCategories, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	currentSession := embedCtx.AppContext.Session()
+
+	categoryIDs := *(*[]string)(unsafe.Pointer(&args.CategoryIds))
+	appErr := embedCtx.App.AccountService().SetFavoriteCategoriesOfUser(currentSession.UserID, categoryIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &AccountSetFavoriteCategories{
+		User: &User{ID: currentSession.UserID},
+	}, nil
+}
+
 // NOTE: Refer to ./schemas/account.graphqls for details on directive used
 func (r *Resolver) AccountSetDefaultAddress(ctx context.Context, args struct {
 	Id   UUID
@@ -270,10 +298,45 @@ func (r *Resolver) AccountUpdate(ctx context.Context, args struct{ Input Account
 // NOTE: Refer to ./schemas/account.graphqls for details on directive used.
 // This creates a link (with a token attached), sends to user's email address
 func (r *Resolver) AccountRequestDeletion(ctx context.Context, args struct {
-	Channel     *string
 	RedirectURL string
 }) (*AccountRequestDeletion, error) {
-	panic(fmt.Errorf("not implemented"))
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	// validate url
+	appErr := model_helper.ValidateStoreFrontUrl(embedCtx.App.Config(), args.RedirectURL)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := embedCtx.App.AccountService().UserById(ctx, embedCtx.AppContext.Session().UserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	appErr = embedCtx.App.AccountService().RequestDataErasure(*user, args.RedirectURL)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &AccountRequestDeletion{Ok: true}, nil
+}
+
+// NOTE: Refer to ./schemas/account.graphqls for details on directive used.
+// This schedules an export of current user's personal data, the user is emailed a link to download it when done
+func (r *Resolver) AccountRequestDataExport(ctx context.Context) (*AccountRequestDataExport, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	user, appErr := embedCtx.App.AccountService().UserById(ctx, embedCtx.AppContext.Session().UserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	exportFile, appErr := embedCtx.App.Srv().Csv.StartUserDataExport(*user)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &AccountRequestDataExport{ExportFile: SystemExportFileToGraphqlExportFile(exportFile)}, nil
 }
 
 // NOTE: Refer to ./schemas/account.graphqls for details on directive used
@@ -281,11 +344,15 @@ func (r *Resolver) AccountDelete(ctx context.Context, args struct{ Token string
 	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
 	currentSession := embedCtx.AppContext.Session()
 
-	// validate if token is valid
-	_, appErr := embedCtx.App.Srv().ValidateTokenByToken(args.Token, model_helper.TokenTypeDeactivateAccount, nil)
+	// validate if token is valid and was issued to current user
+	var tokenExtra model_helper.DataErasureTokenExtra
+	token, appErr := embedCtx.App.Srv().ValidateTokenByToken(args.Token, model_helper.TokenTypeDataErasure, &tokenExtra)
 	if appErr != nil {
 		return nil, appErr
 	}
+	if tokenExtra.UserID != currentSession.UserID {
+		return nil, model_helper.NewAppError("AccountDelete", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "token"}, "token was not issued to current user", http.StatusBadRequest)
+	}
 
 	user, appErr := embedCtx.App.AccountService().UserById(ctx, currentSession.UserID)
 	if appErr != nil {
@@ -297,8 +364,15 @@ func (r *Resolver) AccountDelete(ctx context.Context, args struct{ Token string
 		return nil, model_helper.NewAppError("AccountDelete", "app.account.administrator_cannot_self_deactivate.app_error", nil, "administration members cannot deactivate themself", http.StatusNotAcceptable)
 	}
 
-	user.IsActive = false
-	updatedUser, appErr := embedCtx.App.AccountService().UpdateUser(*user, false)
+	appErr = embedCtx.App.AccountService().EraseUserData(*user)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if err := embedCtx.App.Srv().Store.Token().Delete(token.Token); err != nil {
+		slog.Warn("Failed to delete data erasure token", slog.String("user_id", user.ID), slog.Err(err))
+	}
+
+	updatedUser, appErr := embedCtx.App.AccountService().UserById(ctx, user.ID)
 	if appErr != nil {
 		return nil, appErr
 	}
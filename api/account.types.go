@@ -317,6 +317,33 @@ func (u *User) Addresses(ctx context.Context) ([]*Address, error) {
 	return nil, MakeUnauthorizedError("User.Addresses")
 }
 
+// NOTE: Refer to ./schemas/user.graphqls for directive used.
+func (u *User) FavoriteCategories(ctx context.Context) ([]*Category, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	currentSession := embedCtx.AppContext.Session()
+
+	if currentSession.UserID != u.ID &&
+		currentSession.
+			GetUserRoles().
+			InterSection([]string{model.ShopStaffRoleId, model.ShopAdminRoleId}).
+			Len() == 0 {
+		return nil, MakeUnauthorizedError("User.FavoriteCategories")
+	}
+
+	categoryIDs, appErr := embedCtx.App.AccountService().FavoriteCategoryIDsOfUser(u.ID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	categories, errs := CategoryByIdLoader.LoadMany(ctx, categoryIDs)()
+	if len(errs) > 0 && errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	// categories deleted after being marked as favourite are skipped
+	return systemRecordsToGraphql(lo.Compact(categories), systemCategoryToGraphqlCategory), nil
+}
+
 // NOTE: Refer to ./schemas/user.graphqls for directive used.
 // NOTE: giftcards are ordering by code
 func (u *User) GiftCards(ctx context.Context, args GraphqlParams) (*GiftCardCountableConnection, error) {
@@ -592,7 +619,7 @@ func (s *StaffNotificationRecipient) Email(ctx context.Context) (*string, error)
 }
 
 // validate check given `phone` and `country` field are valid. If not returns according error.
-// TODO: in the future we should validate by specific country name.
+// Country specific rules are applied by account service's ValidateAddress.
 func (a *AddressInput) validate(where string) *model_helper.AppError {
 	// validate input country
 	if country := a.Country; country == nil || country.IsValid() != nil {
@@ -33,7 +33,12 @@ func (r *Resolver) AddressCreate(ctx context.Context, args struct {
 	var address model.Address
 	args.Input.PatchAddress(&address)
 
-	savedAddress, appErr := embedCtx.App.AccountService().UpsertAddress(nil, address)
+	normalizedAddress, appErr := embedCtx.App.AccountService().ValidateAddress(address)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	savedAddress, appErr := embedCtx.App.AccountService().UpsertAddress(nil, *normalizedAddress)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -88,7 +93,12 @@ func (r *Resolver) AddressUpdate(ctx context.Context, args struct {
 
 	args.Input.PatchAddress(address)
 
-	updatedAddress, appErr := embedCtx.App.AccountService().UpsertAddress(nil, *address)
+	normalizedAddress, appErr := embedCtx.App.AccountService().ValidateAddress(*address)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	updatedAddress, appErr := embedCtx.App.AccountService().UpsertAddress(nil, *normalizedAddress)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -106,6 +106,12 @@ func (r *Resolver) CheckoutBillingAddressUpdate(ctx context.Context, args struct
 	var billingAddress model.Address
 	args.BillingAddress.PatchAddress(&billingAddress)
 
+	// field errors of the address are returned here rather than at checkout completion
+	normalizedBillingAddress, appErr := embedCtx.App.Srv().AccountService().ValidateAddress(billingAddress)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	// create transaction
 	transaction := embedCtx.App.Srv().Store.GetMaster().Begin()
 	if transaction.Error != nil {
@@ -113,7 +119,7 @@ func (r *Resolver) CheckoutBillingAddressUpdate(ctx context.Context, args struct
 	}
 	defer embedCtx.App.Srv().Store.FinalizeTransaction(transaction)
 
-	savedBillingAddress, appErr := embedCtx.App.Srv().AccountService().UpsertAddress(transaction, &billingAddress)
+	savedBillingAddress, appErr := embedCtx.App.Srv().AccountService().UpsertAddress(transaction, normalizedBillingAddress)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -309,7 +315,11 @@ func (r *Resolver) CheckoutCreate(ctx context.Context, args struct{ Input Checko
 
 			var addr model.Address
 			addressInput.PatchAddress(&addr)
-			savedAddress, appErr := embedCtx.App.Srv().AccountService().UpsertAddress(nil, &addr)
+			normalizedAddress, appErr := embedCtx.App.Srv().AccountService().ValidateAddress(addr)
+			if appErr != nil {
+				return nil, appErr
+			}
+			savedAddress, appErr := embedCtx.App.Srv().AccountService().UpsertAddress(nil, normalizedAddress)
 			if appErr != nil {
 				return nil, appErr
 			}
@@ -416,6 +426,10 @@ func (r *Resolver) CheckoutCreate(ctx context.Context, args struct{ Input Checko
 		return nil, model_helper.NewAppError("CheckoutCreate", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if metrics := embedCtx.App.Metrics(); metrics != nil {
+		metrics.IncrementCheckoutCreated(channel.Id)
+	}
+
 	pluginMng := embedCtx.App.Srv().PluginService().GetPluginManager()
 	_, appErr = pluginMng.CheckoutCreated(*savedCheckout)
 	if appErr != nil {
@@ -798,6 +812,12 @@ func (r *Resolver) CheckoutShippingAddressUpdate(ctx context.Context, args struc
 	var shippingAddress model.Address
 	args.ShippingAddress.PatchAddress(&shippingAddress)
 
+	// field errors of the address are returned here rather than at checkout completion
+	normalizedShippingAddress, appErr := embedCtx.App.Srv().AccountService().ValidateAddress(shippingAddress)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	pluginMng := embedCtx.App.Srv().PluginService().GetPluginManager()
 	discounts, appErr := embedCtx.App.Srv().DiscountService().FetchDiscounts(time.Now())
 	if appErr != nil {
@@ -810,7 +830,7 @@ func (r *Resolver) CheckoutShippingAddressUpdate(ctx context.Context, args struc
 	}
 
 	// change checkout's country to a new one
-	checkout.Country = *args.ShippingAddress.Country
+	checkout.Country = normalizedShippingAddress.Country
 
 	// Resolve and process the lines, validating variants quantities
 	if len(lines) > 0 {
@@ -820,7 +840,7 @@ func (r *Resolver) CheckoutShippingAddressUpdate(ctx context.Context, args struc
 		if appErr != nil {
 			return nil, appErr
 		}
-		appErr = embedCtx.App.Srv().CheckoutService().CheckLinesQuantity(variants, lines.CheckoutLines().Quantities(), *args.ShippingAddress.Country, checkoutInfo.Channel.Slug, false, model_helper.CheckoutLineInfos{}, false)
+		appErr = embedCtx.App.Srv().CheckoutService().CheckLinesQuantity(variants, lines.CheckoutLines().Quantities(), normalizedShippingAddress.Country, checkoutInfo.Channel.Slug, false, model_helper.CheckoutLineInfos{}, false)
 		if appErr != nil {
 			return nil, appErr
 		}
@@ -844,7 +864,7 @@ func (r *Resolver) CheckoutShippingAddressUpdate(ctx context.Context, args struc
 	}
 	savedCheckout := checkouts[0]
 
-	savedShippingAddress, appErr := embedCtx.App.Srv().AccountService().UpsertAddress(tran, &shippingAddress)
+	savedShippingAddress, appErr := embedCtx.App.Srv().AccountService().UpsertAddress(tran, normalizedShippingAddress)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -1024,6 +1044,26 @@ func (r *Resolver) CheckoutLanguageCodeUpdate(ctx context.Context, args struct {
 	}, nil
 }
 
+// CheckoutRecover restores a checkout from the link of an abandoned checkout reminder email
+func (r *Resolver) CheckoutRecover(ctx context.Context, args struct {
+	Token     string
+	Signature string
+}) (*CheckoutRecover, error) {
+	if !model_helper.IsValidId(args.Token) {
+		return nil, model_helper.NewAppError("CheckoutRecover", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "token"}, "please provide valid checkout token", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	checkout, appErr := embedCtx.App.Srv().CheckoutService().RecoverAbandonedCheckout(args.Token, args.Signature)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &CheckoutRecover{
+		Checkout: SystemCheckoutToGraphqlCheckout(checkout),
+	}, nil
+}
+
 func (r *Resolver) Checkout(ctx context.Context, args struct{ Token string }) (*Checkout, error) {
 	if !model_helper.IsValidId(args.Token) {
 		return nil, model_helper.NewAppError("Checkout", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "token"}, "please provide valid checkout token", http.StatusBadRequest)
@@ -6,10 +6,97 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/web"
 )
 
+// NOTE: Refer to ./schemas/csv.graphqls for details on directive used.
 func (r *Resolver) ExportProducts(ctx context.Context, args struct{ Input ExportProductsInput }) (*ExportProducts, error) {
-	panic(fmt.Errorf("not implemented"))
+	if args.Input.Filter != nil {
+		appErr := args.Input.Filter.validate("ExportProducts")
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	exportFile, appErr := embedCtx.App.Srv().Csv.StartProductsExport(args.Input.toSystem(), embedCtx.AppContext.Session().UserID, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ExportProducts{ExportFile: SystemExportFileToGraphqlExportFile(exportFile)}, nil
+}
+
+// NOTE: Refer to ./schemas/csv.graphqls for details on directive used.
+func (r *Resolver) ExportOrders(ctx context.Context, args struct{ Input ExportOrdersInput }) (*ExportOrders, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	exportFile, appErr := embedCtx.App.Srv().Csv.StartOrdersExport(args.Input.toSystem(), embedCtx.AppContext.Session().UserID, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ExportOrders{ExportFile: SystemExportFileToGraphqlExportFile(exportFile)}, nil
+}
+
+// NOTE: Refer to ./schemas/csv.graphqls for details on directive used.
+func (r *Resolver) ExportCustomers(ctx context.Context, args struct{ Input ExportCustomersInput }) (*ExportCustomers, error) {
+	if args.Input.Filter != nil {
+		appErr := args.Input.Filter.validate("ExportCustomers")
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	exportFile, appErr := embedCtx.App.Srv().Csv.StartCustomersExport(args.Input.toSystem(), embedCtx.AppContext.Session().UserID, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ExportCustomers{ExportFile: SystemExportFileToGraphqlExportFile(exportFile)}, nil
+}
+
+// NOTE: Refer to ./schemas/csv.graphqls for details on directive used.
+func (r *Resolver) ExportGiftCards(ctx context.Context, args struct{ Input ExportGiftCardsInput }) (*ExportGiftCards, error) {
+	if args.Input.Filter != nil {
+		appErr := args.Input.Filter.validate()
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	exportFile, appErr := embedCtx.App.Srv().Csv.StartGiftcardsExport(args.Input.toSystem(), embedCtx.AppContext.Session().UserID, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ExportGiftCards{ExportFile: SystemExportFileToGraphqlExportFile(exportFile)}, nil
+}
+
+// NOTE: Refer to ./schemas/csv.graphqls for details on directive used.
+// ImportProducts schedules an import of products from an uploaded csv or xlsx file
+func (r *Resolver) ImportProducts(ctx context.Context, args struct{ Input ImportProductsInput }) (*ImportProducts, error) {
+	if !model_helper.IsValidId(string(args.Input.File)) {
+		return nil, model_helper.NewAppError("ImportProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "file"}, "please provide valid file id", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	fileInfo, appErr := embedCtx.App.Srv().File.GetFileInfo(args.Input.File.String())
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	job, appErr := embedCtx.App.Srv().Csv.StartProductsImport(fileInfo.Path, strings.ToLower(fileInfo.Extension), args.Input.DryRun, embedCtx.AppContext.Session().UserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ImportProducts{JobID: job.ID}, nil
 }
 
 func (r *Resolver) ExportFile(ctx context.Context, args struct{ Id string }) (*ExportFile, error) {
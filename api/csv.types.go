@@ -0,0 +1,335 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/util"
+	"github.com/sitename/sitename/web"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ------------------- ExportFile ---------------
+
+type ExportFile struct {
+	ID        string   `json:"id"`
+	CreatedAt DateTime `json:"createdAt"`
+	UpdatedAt DateTime `json:"updatedAt"`
+
+	// User      *User          `json:"user"`
+	// Status    JobStatusEnum  `json:"status"`
+	// Message   *string        `json:"message"`
+	// URL       *string        `json:"url"`
+	// Events    []*ExportEvent `json:"events"`
+
+	file *model.ExportFile
+}
+
+func SystemExportFileToGraphqlExportFile(f *model.ExportFile) *ExportFile {
+	if f == nil {
+		return nil
+	}
+
+	return &ExportFile{
+		ID:        f.ID,
+		CreatedAt: DateTime{util.TimeFromMillis(f.CreatedAt)},
+		UpdatedAt: DateTime{util.TimeFromMillis(f.UpdatedAt)},
+		file:      f,
+	}
+}
+
+func (e *ExportFile) User(ctx context.Context) (*User, error) {
+	if e.file.UserID.IsNil() {
+		return nil, nil
+	}
+
+	user, err := UserByUserIdLoader.Load(ctx, *e.file.UserID.String)()
+	if err != nil {
+		return nil, err
+	}
+	return SystemUserToGraphqlUser(user), nil
+}
+
+// events returns events of the export file, oldest first
+func (e *ExportFile) events(ctx context.Context) (model.ExportEventSlice, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	events, appErr := embedCtx.App.Srv().Csv.ExportEventsByOption(model_helper.ExportEventFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ExportEventWhere.ExportFileID.EQ(e.ID),
+			qm.OrderBy(model.ExportEventColumns.Date),
+		),
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return events, nil
+}
+
+// Status is derived from the latest pending, success or failed event of the export
+func (e *ExportFile) Status(ctx context.Context) (JobStatusEnum, error) {
+	events, err := e.events(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	status := JobStatusEnumPending
+	for _, event := range events {
+		switch event.Type {
+		case model.ExportEventTypeExportSuccess:
+			status = JobStatusEnumSuccess
+		case model.ExportEventTypeExportFailed:
+			status = JobStatusEnumFailed
+		case model.ExportEventTypeExportDeleted:
+			status = JobStatusEnumDeleted
+		}
+	}
+	return status, nil
+}
+
+func (e *ExportFile) Message(ctx context.Context) (*string, error) {
+	events, err := e.events(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Type == model.ExportEventTypeExportFailed {
+			message := exportEventMessage(events[i])
+			return &message, nil
+		}
+	}
+	return nil, nil
+}
+
+// URL is a presigned download url of the exported content, it is empty until the export succeeds
+func (e *ExportFile) URL(ctx context.Context) (*string, error) {
+	if e.file.ContentFile.IsNil() {
+		return nil, nil
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	url, appErr := embedCtx.App.Srv().File.PresignedDownloadURL(*e.file.ContentFile.String)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &url, nil
+}
+
+func (e *ExportFile) Events(ctx context.Context) ([]*ExportEvent, error) {
+	events, err := e.events(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return systemRecordsToGraphql(events, SystemExportEventToGraphqlExportEvent), nil
+}
+
+// ------------------- ExportEvent ---------------
+
+type ExportEvent struct {
+	ID      string           `json:"id"`
+	Date    DateTime         `json:"date"`
+	Type    ExportEventsEnum `json:"type"`
+	Message string           `json:"message"`
+
+	// User    *User            `json:"user"`
+
+	event *model.ExportEvent
+}
+
+func SystemExportEventToGraphqlExportEvent(e *model.ExportEvent) *ExportEvent {
+	if e == nil {
+		return nil
+	}
+
+	return &ExportEvent{
+		ID:      e.ID,
+		Date:    DateTime{util.TimeFromMillis(e.Date)},
+		Type:    e.Type,
+		Message: exportEventMessage(e),
+		event:   e,
+	}
+}
+
+func (e *ExportEvent) User(ctx context.Context) (*User, error) {
+	if e.event.UserID.IsNil() {
+		return nil, nil
+	}
+
+	user, err := UserByUserIdLoader.Load(ctx, *e.event.UserID.String)()
+	if err != nil {
+		return nil, err
+	}
+	return SystemUserToGraphqlUser(user), nil
+}
+
+// exportEventMessage returns the message parameter recorded with given export event
+func exportEventMessage(e *model.ExportEvent) string {
+	if e.Parameters.IsNil() {
+		return ""
+	}
+
+	var parameters map[string]string
+	if err := json.Unmarshal([]byte(*e.Parameters.String), &parameters); err != nil {
+		return ""
+	}
+	return parameters["message"]
+}
+
+// ------------------- export inputs ---------------
+
+// exportScope converts given scope to scope of export inputs of csv service
+func exportScope(scope ExportScope) string {
+	return strings.ToLower(string(scope))
+}
+
+func metadataInputsToSystem(metadata []*MetadataInput) []*struct {
+	Key   string
+	Value string
+} {
+	return lo.FilterMap(metadata, func(item *MetadataInput, _ int) (*struct {
+		Key   string
+		Value string
+	}, bool) {
+		if item == nil {
+			return nil, false
+		}
+		return &struct {
+			Key   string
+			Value string
+		}{Key: item.Key, Value: item.Value}, true
+	})
+}
+
+func dateRangeInputToSystem(input *DateRangeInput) *struct {
+	Gte *time.Time
+	Lte *time.Time
+} {
+	if input == nil {
+		return nil
+	}
+
+	res := &struct {
+		Gte *time.Time
+		Lte *time.Time
+	}{}
+	if input.Gte != nil {
+		res.Gte = &input.Gte.Time
+	}
+	if input.Lte != nil {
+		res.Lte = &input.Lte.Time
+	}
+	return res
+}
+
+func priceRangeInputToSystem(input *PriceRangeInput) *struct {
+	Gte *float64
+	Lte *float64
+} {
+	if input == nil {
+		return nil
+	}
+	return &struct {
+		Gte *float64
+		Lte *float64
+	}{Gte: input.Gte, Lte: input.Lte}
+}
+
+func (i *ExportProductsInput) toSystem() model_helper.ExportProductsFilterOptions {
+	res := model_helper.ExportProductsFilterOptions{
+		Scope:    exportScope(i.Scope),
+		Ids:      i.Ids,
+		FileType: string(i.FileType),
+	}
+	if i.Filter != nil {
+		res.Filter = i.Filter.toSystemProductFilterInput()
+	}
+	if i.ExportInfo != nil {
+		res.ExportInfo = &struct {
+			Attributes []string
+			Warehouses []string
+			Channels   []string
+			Fields     []string
+		}{
+			Attributes: i.ExportInfo.Attributes,
+			Warehouses: i.ExportInfo.Warehouses,
+			Channels:   i.ExportInfo.Channels,
+			Fields:     lo.Map(i.ExportInfo.Fields, func(field ProductFieldEnum, _ int) string { return string(field) }),
+		}
+	}
+	return res
+}
+
+func (i *ExportOrdersInput) toSystem() model_helper.ExportOrdersFilterOptions {
+	res := model_helper.ExportOrdersFilterOptions{
+		Scope:    exportScope(i.Scope),
+		Ids:      i.Ids,
+		FileType: string(i.FileType),
+	}
+	if i.Filter != nil {
+		res.Filter = &model_helper.OrderFilterInput{
+			PaymentStatus: i.Filter.PaymentStatus,
+			Status: lo.Map(i.Filter.Status, func(status OrderStatusFilter, _ int) model_helper.OrderFilterStatus {
+				return model_helper.OrderFilterStatus(status)
+			}),
+			Customer: i.Filter.Customer,
+			Created:  dateRangeInputToSystem(i.Filter.Created),
+			Search:   i.Filter.Search,
+			Metadata: metadataInputsToSystem(i.Filter.Metadata),
+			Channels: i.Filter.Channels.ToStrings(),
+		}
+	}
+	return res
+}
+
+func (i *ExportCustomersInput) toSystem() model_helper.ExportCustomersFilterOptions {
+	res := model_helper.ExportCustomersFilterOptions{
+		Scope:    exportScope(i.Scope),
+		Ids:      i.Ids,
+		FileType: string(i.FileType),
+	}
+	if i.Filter != nil {
+		res.Filter = &model_helper.CustomerFilterInput{
+			DateJoined:   dateRangeInputToSystem(i.Filter.DateJoined),
+			PlacedOrders: dateRangeInputToSystem(i.Filter.PlacedOrders),
+			Search:       i.Filter.Search,
+			Metadata:     metadataInputsToSystem(i.Filter.Metadata),
+		}
+		if i.Filter.NumberOfOrders != nil {
+			res.Filter.NumberOfOrders = &struct {
+				Gte *int32
+				Lte *int32
+			}{Gte: i.Filter.NumberOfOrders.Gte, Lte: i.Filter.NumberOfOrders.Lte}
+		}
+	}
+	return res
+}
+
+func (i *ExportGiftCardsInput) toSystem() model_helper.ExportGiftcardsFilterOptions {
+	res := model_helper.ExportGiftcardsFilterOptions{
+		Scope:    exportScope(i.Scope),
+		Ids:      i.Ids,
+		FileType: string(i.FileType),
+	}
+	if i.Filter != nil {
+		tags := i.Filter.Tags
+		if i.Filter.Tag != nil {
+			tags = append(tags, *i.Filter.Tag)
+		}
+		res.Filter = &model_helper.GiftcardFilterInput{
+			IsActive:       i.Filter.IsActive,
+			Tags:           tags,
+			Products:       i.Filter.Products,
+			UsedBy:         i.Filter.UsedBy,
+			Currency:       i.Filter.Currency,
+			CurrentBalance: priceRangeInputToSystem(i.Filter.CurrentBalance),
+			InitialBalance: priceRangeInputToSystem(i.Filter.InitialBalance),
+		}
+	}
+	return res
+}
@@ -107,7 +107,7 @@ func (r *Resolver) DraftOrderComplete(ctx context.Context, args struct{ Id strin
 				channelSlug = channel.Slug
 			}
 
-			inSufStockErr, appErr := embedCtx.App.Srv().WarehouseService().AllocateStocks(model.OrderLineDatas{lineData}, country, channelSlug, pluginMng, model_types.JSONString{})
+			inSufStockErr, appErr := embedCtx.App.Srv().WarehouseService().AllocateStocks(nil, model.OrderLineDatas{lineData}, country, channelSlug, pluginMng, model_types.JSONString{})
 			if appErr != nil {
 				return nil, appErr
 			}
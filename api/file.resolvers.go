@@ -0,0 +1,113 @@
+package api
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/web"
+)
+
+// NOTE: Refer to ./schemas/file.graphql for details on directives used.
+// UploadSessionCreate starts an upload session, whose data is uploaded by the client with a PUT request to the returned url.
+func (r *Resolver) UploadSessionCreate(ctx context.Context, args struct{ Input UploadSessionCreateInput }) (*UploadSessionCreate, error) {
+	if args.Input.FileName == "" || args.Input.FileSize <= 0 {
+		return nil, model_helper.NewAppError("UploadSessionCreate", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "fileName, fileSize"}, "please provide file name and size", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	embedCtx.SessionRequired()
+	if embedCtx.Err != nil {
+		return nil, embedCtx.Err
+	}
+
+	session, appErr := embedCtx.App.Srv().File.CreateUploadSession(&model.UploadSession{
+		Type:     model.UploadTypeAttachment,
+		UserID:   embedCtx.AppContext.Session().UserID,
+		FileName: args.Input.FileName,
+		FileSize: int64(args.Input.FileSize),
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	uploadURL, appErr := embedCtx.App.Srv().File.PresignedUploadURL(session)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &UploadSessionCreate{
+		UploadSessionID: (*UUID)(&session.ID),
+		UploadURL:       &uploadURL,
+	}, nil
+}
+
+// NOTE: Refer to ./schemas/file.graphql for details on directives used.
+// UploadSessionComplete finishes an upload session once its data is uploaded to the presigned url.
+func (r *Resolver) UploadSessionComplete(ctx context.Context, args struct{ Id UUID }) (*UploadSessionComplete, error) {
+	if !model_helper.IsValidId(string(args.Id)) {
+		return nil, model_helper.NewAppError("UploadSessionComplete", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "id"}, "please provide valid upload session id", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	embedCtx.SessionRequired()
+	if embedCtx.Err != nil {
+		return nil, embedCtx.Err
+	}
+
+	session, appErr := embedCtx.App.Srv().File.GetUploadSession(args.Id.String())
+	if appErr != nil {
+		return nil, appErr
+	}
+	if session.UserID != embedCtx.AppContext.Session().UserID {
+		return nil, model_helper.NewAppError("UploadSessionComplete", "app.upload.complete.forbidden.app_error", nil, "", http.StatusForbidden)
+	}
+
+	info, appErr := embedCtx.App.Srv().File.CompleteUploadSession(embedCtx.AppContext, session)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	downloadURL, appErr := embedCtx.App.Srv().File.PresignedDownloadURL(info.Path)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &UploadSessionComplete{
+		FileID:       (*UUID)(&info.ID),
+		UploadedFile: &File{URL: downloadURL, ContentType: &info.MimeType},
+	}, nil
+}
+
+// NOTE: Refer to ./schemas/file.graphql for details on directives used.
+// File returns a presigned url of an uploaded file, so the client downloads it directly from the file backend.
+func (r *Resolver) File(ctx context.Context, args struct{ Id UUID }) (*File, error) {
+	if !model_helper.IsValidId(string(args.Id)) {
+		return nil, model_helper.NewAppError("File", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "id"}, "please provide valid file id", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	embedCtx.SessionRequired()
+	if embedCtx.Err != nil {
+		return nil, embedCtx.Err
+	}
+
+	info, appErr := embedCtx.App.Srv().File.GetFileInfo(args.Id.String())
+	if appErr != nil {
+		return nil, appErr
+	}
+	if info.CreatorID != embedCtx.AppContext.Session().UserID {
+		return nil, model_helper.NewAppError("File", "api.file.get_file.forbidden.app_error", nil, "", http.StatusForbidden)
+	}
+
+	downloadURL, appErr := embedCtx.App.Srv().File.PresignedDownloadURL(info.Path)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &File{URL: downloadURL, ContentType: &info.MimeType}, nil
+}
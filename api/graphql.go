@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"regexp"
+	"time"
 
 	graphql "github.com/graph-gophers/graphql-go"
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
@@ -21,6 +23,21 @@ type graphQLInput struct {
 
 type Resolver struct{}
 
+// operationDefinitionRegex matches named operation definitions of graphql documents, e.g "mutation CheckoutCreate"
+var operationDefinitionRegex = regexp.MustCompile(`\b(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// graphqlOperationType returns type of the operation with given name defined in given query document,
+// "other" if the document does not define it. Operation names are picked by clients so they are not
+// used as metric labels, the type is.
+func graphqlOperationType(query, operationName string) string {
+	for _, match := range operationDefinitionRegex.FindAllStringSubmatch(query, -1) {
+		if match[2] == operationName {
+			return match[1]
+		}
+	}
+	return "other"
+}
+
 func (api *API) InitGraphql() error {
 	schemaString, err := constructSchema()
 	if err != nil {
@@ -96,7 +113,11 @@ func (api *API) graphql(c *web.Context, w http.ResponseWriter, r *http.Request)
 	reqCtx := r.Context()
 	reqCtx = context.WithValue(reqCtx, WebCtx, c)
 
+	start := time.Now()
 	response = api.schema.Exec(reqCtx, params.Query, params.OperationName, params.Variables)
+	if metrics := c.App.Metrics(); metrics != nil {
+		metrics.ObserveGraphQLOperationDuration(graphqlOperationType(params.Query, params.OperationName), len(response.Errors) == 0, time.Since(start).Seconds())
+	}
 
 	if len(response.Errors) > 0 {
 		logFunc := slog.Error
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphqlOperationType(t *testing.T) {
+	document := `
+		fragment CheckoutFields on Checkout { id }
+
+		query CheckoutDetail($id: UUID!) {
+			checkout(id: $id) { ...CheckoutFields }
+		}
+
+		mutation CheckoutCreate($input: CheckoutCreateInput!) {
+			checkoutCreate(input: $input) { checkout { ...CheckoutFields } }
+		}
+
+		subscription
+			OrderUpdated { event { id } }
+	`
+
+	for operationName, expected := range map[string]string{
+		"CheckoutDetail": "query",
+		"CheckoutCreate": "mutation",
+		"OrderUpdated":   "subscription",
+		"Checkout":       "other", // prefix of defined operations
+		"CheckoutFields": "other", // fragment
+		"a8f3c2-random":  "other",
+	} {
+		require.Equal(t, expected, graphqlOperationType(document, operationName), operationName)
+	}
+
+	require.Equal(t, "other", graphqlOperationType(`{ shop { name } }`, "Shop"))
+}
@@ -919,6 +919,57 @@ func (r *Resolver) OrderUpdateShipping(ctx context.Context, args struct {
 	}, nil
 }
 
+// NOTE: Please refer to ./graphql/schemas/order.graphqls for details on directives used
+// OrderEdit adds, updates and removes lines and changes shipping method of a draft or unconfirmed order at once
+func (r *Resolver) OrderEdit(ctx context.Context, args struct {
+	Id    UUID
+	Input OrderEditInput
+}) (*OrderEdit, error) {
+	input := model_helper.OrderEditInput{
+		UpdateLines: make(map[string]int, len(args.Input.UpdateLines)),
+	}
+	for _, line := range args.Input.AddLines {
+		appErr := line.validate("OrderEdit")
+		if appErr != nil {
+			return nil, appErr
+		}
+		input.AddLines = append(input.AddLines, model_helper.OrderEditLineInput{VariantID: line.VariantID, Quantity: int(line.Quantity)})
+	}
+	for _, line := range args.Input.UpdateLines {
+		input.UpdateLines[line.LineID.String()] = int(line.Quantity)
+	}
+	for _, lineID := range args.Input.RemoveLines {
+		input.RemoveLines = append(input.RemoveLines, lineID.String())
+	}
+	if args.Input.ShippingMethod != nil {
+		input.ShippingMethodID = model_helper.GetPointerOfValue(args.Input.ShippingMethod.String())
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	order, appErr := embedCtx.App.Srv().OrderService().OrderById(args.Id.String())
+	if appErr != nil {
+		return nil, appErr
+	}
+	requester, appErr := embedCtx.App.AccountService().UserById(ctx, embedCtx.AppContext.Session().UserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	pluginMng := embedCtx.App.Srv().PluginService().GetPluginManager()
+	editedOrder, insufficientStock, appErr := embedCtx.App.Srv().OrderService().EditOrder(*order, input, requester, pluginMng)
+	if insufficientStock != nil {
+		return nil, insufficientStock.ToAppError("OrderEdit")
+	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &OrderEdit{
+		Order: SystemOrderToGraphqlOrder(editedOrder),
+	}, nil
+}
+
 // NOTE: Please refer to ./graphql/schemas/order.graphqls for details on directives used
 func (r *Resolver) OrderVoid(ctx context.Context, args struct{ Id UUID }) (*OrderVoid, error) {
 	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
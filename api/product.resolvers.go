@@ -6,6 +6,12 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/web"
 )
 
 func (r *Resolver) ProductAttributeAssign(ctx context.Context, args struct {
@@ -81,6 +87,86 @@ func (r *Resolver) Products(ctx context.Context, args struct {
 	panic(fmt.Errorf("not implemented"))
 }
 
+// ProductFeed returns products of given channel ranked by interest of the current user, anonymous users get popular products
+func (r *Resolver) ProductFeed(ctx context.Context, args struct {
+	Channel string
+	First   *int32
+	After   *string
+}) (*ProductFeed, error) {
+	// the feed caps page sizes itself
+	first := lo.FromPtr(args.First)
+	if first < 0 {
+		return nil, model_helper.NewAppError("ProductFeed", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "first"}, "first must not be negative", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	feed, appErr := embedCtx.App.Srv().ProductService().ProductFeedForUser(embedCtx.AppContext.Session().UserID, args.Channel, lo.FromPtr(args.After), int(first))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ProductFeed{
+		Products: lo.Map(feed.Items, func(item *model_helper.ProductFeedItem, _ int) *Product {
+			return SystemProductToGraphqlProduct(&item.Product)
+		}),
+		NextCursor: lo.EmptyableToPtr(feed.NextCursor),
+		ColdStart:  feed.ColdStart,
+	}, nil
+}
+
+func (r *Resolver) SearchProducts(ctx context.Context, args struct {
+	Channel string
+	Query   *string
+	Filter  *ProductSearchFilterInput
+	SortBy  *ProductSearchSortingInput
+	Page    *int32
+	PerPage *int32
+}) (*ProductSearchResult, error) {
+	params := &model_helper.ProductSearchParams{
+		Terms:   lo.FromPtr(args.Query),
+		Page:    int(lo.FromPtr(args.Page)),
+		PerPage: int(lo.FromPtr(args.PerPage)),
+	}
+	if params.Page < 0 || params.PerPage < 0 || params.PerPage > 100 {
+		return nil, model_helper.NewAppError("SearchProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "page, perPage"}, "page must not be negative, perPage must be between 0 and 100", http.StatusBadRequest)
+	}
+	if args.Filter != nil {
+		if appErr := args.Filter.validate("SearchProducts"); appErr != nil {
+			return nil, appErr
+		}
+		args.Filter.toSystem(params)
+	}
+	if args.SortBy != nil {
+		if !args.SortBy.Field.IsValid() {
+			return nil, model_helper.NewAppError("SearchProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "sortBy"}, "", http.StatusBadRequest)
+		}
+		params.SortBy = model_helper.ProductSearchSortField(strings.ToLower(string(args.SortBy.Field)))
+		params.Direction = model_helper.OrderDirection(args.SortBy.Direction)
+	}
+
+	channel, err := ChannelBySlugLoader.Load(ctx, args.Channel)()
+	if err != nil {
+		return nil, err
+	}
+	if channel == nil {
+		return nil, model_helper.NewAppError("SearchProducts", "api.product.search_products.channel_not_found.app_error", map[string]any{"Slug": args.Channel}, "", http.StatusNotFound)
+	}
+	params.ChannelID = channel.ID
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	result, appErr := embedCtx.App.Srv().ProductService().SearchProducts(params)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	products, errs := ProductByIdLoader.LoadMany(ctx, result.ProductIDs)()
+	if len(errs) > 0 && errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	return systemProductSearchResultToGraphql(result, products), nil
+}
+
 func (r *Resolver) ReportProductSales(ctx context.Context, args struct {
 	Period  ReportingPeriod
 	Channel string
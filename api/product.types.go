@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -11,7 +14,9 @@ import (
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/modules/util"
+	"github.com/sitename/sitename/store"
 	"github.com/sitename/sitename/web"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 type Product struct {
@@ -183,8 +188,37 @@ func (p *Product) ChannelListings(ctx context.Context) ([]*ProductChannelListing
 	return systemRecordsToGraphql(channelListings, systemProductChannelListingToGraphqlProductChannelListing), nil
 }
 
-func (p *Product) Thumbnail(ctx context.Context, args struct{ Size *int32 }) (*Image, error) {
-	panic("not implemented")
+func (p *Product) Thumbnail(ctx context.Context, args struct {
+	Size   *int32
+	Format *ThumbnailFormatEnum
+}) (*Image, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	medias, err := embedCtx.App.Srv().Store.ProductMedia().FilterByOption(model_helper.ProductMediaFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ProductMediumWhere.ProductID.EQ(p.ID),
+			model.ProductMediumWhere.Type.EQ(model.ProductMediaTypeIMAGE),
+			qm.OrderBy(model.ProductMediumColumns.SortOrder+" ASC"),
+			qm.Limit(1),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("Product.Thumbnail", "app.product.error_finding_product_medias_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(medias) == 0 {
+		return nil, nil
+	}
+
+	url, err := productMediaThumbnailURL(embedCtx, medias[0], args.Size, args.Format)
+	if err != nil || url == "" {
+		return nil, err
+	}
+
+	res := &Image{URL: url}
+	if alt := medias[0].Alt; alt != "" {
+		res.Alt = &alt
+	}
+	return res, nil
 }
 
 func (p *Product) DefaultVariant(ctx context.Context) (*ProductVariant, error) {
@@ -378,6 +412,75 @@ func (p *Product) Media(ctx context.Context) ([]*ProductMedia, error) {
 	return systemRecordsToGraphql(medias, systemProductMediaToGraphqlProductMedia), nil
 }
 
+// Recommendations returns products recommended for the product, visible and in stock in given channel
+func (p *Product) Recommendations(ctx context.Context, args struct {
+	Channel string
+	Kind    *ProductRecommendationKind
+	Limit   *int32
+}) ([]*Product, error) {
+	var kind string
+	if args.Kind != nil {
+		if !args.Kind.IsValid() {
+			return nil, model_helper.NewAppError("Product.Recommendations", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "kind"}, "", http.StatusBadRequest)
+		}
+		kind = strings.ToLower(string(*args.Kind))
+	}
+	limit := lo.FromPtr(args.Limit)
+	if limit < 0 {
+		return nil, model_helper.NewAppError("Product.Recommendations", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "limit"}, "limit must not be negative", http.StatusBadRequest)
+	}
+
+	channel, err := ChannelBySlugLoader.Load(ctx, args.Channel)()
+	if err != nil {
+		return nil, err
+	}
+	if channel == nil {
+		return nil, model_helper.NewAppError("Product.Recommendations", "api.product.recommendations.channel_not_found.app_error", map[string]any{"Slug": args.Channel}, "", http.StatusNotFound)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+	products, appErr := embedCtx.App.Srv().ProductService().ProductRecommendations(p.ID, channel.ID, kind, int(limit))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return lo.Map(products, func(item *model_helper.RecommendedProduct, _ int) *Product {
+		return SystemProductToGraphqlProduct(&item.Product)
+	}), nil
+}
+
+// StructuredData returns JSON-LD documents of the product's page in storefront of given channel
+func (p *Product) StructuredData(ctx context.Context, args struct{ Channel string }) (*ProductStructuredData, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	data, appErr := embedCtx.App.Srv().Seo.ProductStructuredData(p.ID, args.Channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return systemProductStructuredDataToGraphql(data)
+}
+
+func systemProductStructuredDataToGraphql(data *model_helper.ProductStructuredData) (*ProductStructuredData, error) {
+	res := &ProductStructuredData{}
+	for _, item := range []struct {
+		document any
+		dest     *JSONString
+	}{
+		{data.Product, &res.Product},
+		{data.Breadcrumbs, &res.Breadcrumbs},
+	} {
+		raw, err := json.Marshal(item.document)
+		if err != nil {
+			return nil, model_helper.NewAppError("systemProductStructuredDataToGraphql", model_helper.ErrorMarshallingDataID, nil, err.Error(), http.StatusInternalServerError)
+		}
+		if err = json.Unmarshal(raw, item.dest); err != nil {
+			return nil, model_helper.NewAppError("systemProductStructuredDataToGraphql", model_helper.ErrorUnMarshallingDataID, nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	return res, nil
+}
+
 func (p *Product) Variants(ctx context.Context) ([]*ProductVariant, error) {
 	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
 	embedCtx.CheckAuthenticatedAndHasRoleAny("Product.variants", model.ShopStaffRoleId, model.ShopAdminRoleId)
@@ -569,8 +672,74 @@ func systemProductMediaToGraphqlProductMedia(p *model.ProductMedia) *ProductMedi
 	return res
 }
 
-func (p *ProductMedia) URL(ctx context.Context, args struct{ Size *int32 }) (string, error) {
-	panic("not implemented")
+// ThumbnailFormatEnum is format of product image thumbnails
+type ThumbnailFormatEnum string
+
+const (
+	ThumbnailFormatEnumOriginal ThumbnailFormatEnum = "ORIGINAL"
+	ThumbnailFormatEnumWebp     ThumbnailFormatEnum = "WEBP"
+)
+
+func (e ThumbnailFormatEnum) IsValid() bool {
+	return e == ThumbnailFormatEnumOriginal || e == ThumbnailFormatEnumWebp
+}
+
+func (p *ProductMedia) URL(ctx context.Context, args struct {
+	Size   *int32
+	Format *ThumbnailFormatEnum
+}) (string, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	media, err := embedCtx.App.Srv().Store.ProductMedia().Get(p.ID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if _, ok := err.(*store.ErrNotFound); ok {
+			statusCode = http.StatusNotFound
+		}
+		return "", model_helper.NewAppError("ProductMedia.URL", "app.product.error_finding_product_medias_by_option.app_error", nil, err.Error(), statusCode)
+	}
+
+	return productMediaThumbnailURL(embedCtx, media, args.Size, args.Format)
+}
+
+// productMediaThumbnailURL returns absolute url of given media.
+// When neither size nor format is given, the uploaded image is returned. Otherwise the url points to
+// the smallest size variant at least as wide as size, which is generated if it does not exist yet.
+// External media urls are returned as is.
+func productMediaThumbnailURL(embedCtx *web.Context, media *model.ProductMedium, size *int32, format *ThumbnailFormatEnum) (string, error) {
+	if !media.ExternalURL.IsNil() && *media.ExternalURL.String != "" {
+		return *media.ExternalURL.String, nil
+	}
+	if media.Image == "" {
+		return "", nil
+	}
+
+	imagePath := media.Image
+	if size != nil || format != nil {
+		if size != nil && *size <= 0 {
+			return "", model_helper.NewAppError("productMediaThumbnailURL", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "size"}, "size must be positive", http.StatusBadRequest)
+		}
+		if format != nil && !format.IsValid() {
+			return "", model_helper.NewAppError("productMediaThumbnailURL", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "format"}, "", http.StatusBadRequest)
+		}
+
+		imageSize := model_helper.ProductMediaImageSizeLarge
+		if size != nil {
+			imageSize = model_helper.ProductMediaImageSizeForWidth(int(*size))
+		}
+		imageFormat := model_helper.ProductMediaImageFormatOriginal
+		if format != nil && *format == ThumbnailFormatEnumWebp {
+			imageFormat = model_helper.ProductMediaImageFormatWebp
+		}
+
+		var appErr *model_helper.AppError
+		imagePath, appErr = embedCtx.App.Srv().ProductService().ProductMediaThumbnail(media, imageSize, imageFormat)
+		if appErr != nil {
+			return "", appErr
+		}
+	}
+
+	return model_helper.ProductMediaImageURL(*embedCtx.App.Config().ServiceSettings.SiteURL, imagePath), nil
 }
 
 // ProductVariantStockUpsertInput is used in "productVariantCreate" and "productVariantUpdate" methods
@@ -578,3 +747,51 @@ type ProductVariantStockUpsertInput struct {
 	Stocks    []StockInput
 	VariantID UUID
 }
+
+func (p *ProductSearchFilterInput) toSystem(params *model_helper.ProductSearchParams) {
+	if len(p.Attributes) > 0 {
+		params.Attributes = make(map[string][]string, len(p.Attributes))
+		for _, attribute := range p.Attributes {
+			params.Attributes[attribute.Slug] = append(params.Attributes[attribute.Slug], attribute.Values...)
+		}
+	}
+	params.CategoryIDs = *(*[]string)(unsafe.Pointer(&p.Categories))
+	params.CollectionIDs = *(*[]string)(unsafe.Pointer(&p.Collections))
+
+	if p.StockAvailability != nil {
+		params.StockAvailability = model_helper.GetPointerOfValue(model_helper.StockAvailability(strings.ToLower(string(*p.StockAvailability))))
+	}
+	if p.Price != nil {
+		params.Price = &struct {
+			Gte *float64
+			Lte *float64
+		}{Gte: p.Price.Gte, Lte: p.Price.Lte}
+	}
+}
+
+// systemProductSearchResultToGraphql converts given search result, products are the ones found by ids of the result.
+// Products deleted after being indexed are skipped.
+func systemProductSearchResultToGraphql(result *model_helper.ProductSearchResult, products []*model.Product) *ProductSearchResult {
+	res := &ProductSearchResult{
+		Products:        lo.Map(lo.Compact(products), func(p *model.Product, _ int) *Product { return SystemProductToGraphqlProduct(p) }),
+		TotalCount:      int32(result.TotalCount),
+		InStockCount:    int32(result.AvailabilityFacet[model_helper.StockAvailabilityInStock]),
+		OutOfStockCount: int32(result.AvailabilityFacet[model_helper.StockAvailabilityOutOfStock]),
+		PriceFacets: lo.Map(result.PriceFacets, func(facet *model_helper.ProductSearchPriceFacet, _ int) *ProductSearchPriceFacet {
+			return &ProductSearchPriceFacet{Min: facet.Min, Max: facet.Max, Count: int32(facet.Count)}
+		}),
+	}
+
+	attributeSlugs := lo.Keys(result.AttributeFacets)
+	sort.Strings(attributeSlugs)
+	for _, slug := range attributeSlugs {
+		res.AttributeFacets = append(res.AttributeFacets, &ProductSearchAttributeFacet{
+			Attribute: slug,
+			Values: lo.Map(result.AttributeFacets[slug], func(facet *model_helper.ProductSearchTermFacet, _ int) *ProductSearchValueFacet {
+				return &ProductSearchValueFacet{Value: facet.Term, Count: int32(facet.Count)}
+			}),
+		})
+	}
+
+	return res
+}
@@ -5,7 +5,6 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"unsafe"
 
@@ -19,7 +18,42 @@ import (
 func (r *Resolver) ProductMediaCreate(ctx context.Context, args struct {
 	Input ProductMediaCreateInput
 }) (*ProductMediaCreate, error) {
-	panic(fmt.Errorf("not implemented"))
+	if args.Input.Image == nil || args.Input.Image.File == nil {
+		return nil, model_helper.NewAppError("ProductMediaCreate", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "image"}, "please provide an image", http.StatusBadRequest)
+	}
+
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	var alt, ppoi string
+	if args.Input.Alt != nil {
+		alt = *args.Input.Alt
+	}
+	if args.Input.Ppoi != nil {
+		ppoi = *args.Input.Ppoi
+	}
+	media, appErr := embedCtx.App.Srv().ProductService().UploadProductMediaImage(args.Input.Product, args.Input.Image.File, alt, ppoi)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	product, err := ProductByIdLoader.Load(ctx, media.ProductID)()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ProductMediaCreate{
+		Product: SystemProductToGraphqlProduct(product),
+		Media: &ProductMedia{
+			ID:         media.ID,
+			Alt:        media.Alt,
+			Type:       media.Type,
+			OembedData: JSONString(media.OembedData),
+		},
+	}
+	if !media.SortOrder.IsNil() {
+		res.Media.SortOrder = model_helper.GetPointerOfValue(int32(*media.SortOrder.Int))
+	}
+	return res, nil
 }
 
 // NOTE: Please refer to ./graphql/schemas/product_media.graphqls for details on directives used
@@ -14,6 +14,7 @@ import (
 	"github.com/site-name/decimal"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/web"
 )
 
@@ -111,25 +112,66 @@ func (r *Resolver) ProductVariantDelete(ctx context.Context, args struct{ Id UUI
 
 // NOTE: Refer to ./graphql/schemas/product_variant.graphqls for details on directives used.
 func (r *Resolver) ProductVariantBulkCreate(ctx context.Context, args struct {
-	Product  UUID
-	Variants []*ProductVariantBulkCreateInput
+	Product     UUID
+	Variants    []*ProductVariantBulkCreateInput
+	ErrorPolicy *ErrorPolicyEnum
 }) (*ProductVariantBulkCreate, error) {
-	panic(fmt.Errorf("not implemented"))
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	inputs := lo.Map(args.Variants, func(variant *ProductVariantBulkCreateInput, _ int) *model_helper.ProductVariantBulkInput {
+		return variant.toSystem()
+	})
+	result, appErr := embedCtx.App.Srv().ProductService().ProductVariantsBulkCreate(args.Product.String(), inputs, lo.FromPtr(args.ErrorPolicy))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ProductVariantBulkCreate{
+		Count:           int32(result.Count),
+		ProductVariants: systemRecordsToGraphql(lo.Compact(result.Variants), SystemProductVariantToGraphqlProductVariant),
+		Errors:          lo.Map(result.Errors, systemProductVariantBulkErrorToGraphql),
+	}, nil
 }
 
 // NOTE: Refer to ./graphql/schemas/product_variant.graphqls for details on directives used.
-func (r *Resolver) ProductVariantBulkDelete(ctx context.Context, args struct{ Ids []UUID }) (*ProductVariantBulkDelete, error) {
+func (r *Resolver) ProductVariantBulkUpdate(ctx context.Context, args struct {
+	Product     UUID
+	Variants    []*ProductVariantBulkUpdateInput
+	ErrorPolicy *ErrorPolicyEnum
+}) (*ProductVariantBulkUpdate, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	inputs := lo.Map(args.Variants, func(variant *ProductVariantBulkUpdateInput, _ int) *model_helper.ProductVariantBulkInput {
+		return variant.toSystem()
+	})
+	result, appErr := embedCtx.App.Srv().ProductService().ProductVariantsBulkUpdate(args.Product.String(), inputs, lo.FromPtr(args.ErrorPolicy))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ProductVariantBulkUpdate{
+		Count:           int32(result.Count),
+		ProductVariants: systemRecordsToGraphql(lo.Compact(result.Variants), SystemProductVariantToGraphqlProductVariant),
+		Errors:          lo.Map(result.Errors, systemProductVariantBulkErrorToGraphql),
+	}, nil
+}
+
+// NOTE: Refer to ./graphql/schemas/product_variant.graphqls for details on directives used.
+func (r *Resolver) ProductVariantBulkDelete(ctx context.Context, args struct {
+	Ids         []UUID
+	ErrorPolicy *ErrorPolicyEnum
+}) (*ProductVariantBulkDelete, error) {
 	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
 
-	// delete variants, delete related draft order lines, create order events
 	stringIds := *(*[]string)(unsafe.Pointer(&args.Ids))
-	numDeleted, appErr := embedCtx.App.Srv().ProductService().DeleteProductVariants(stringIds, embedCtx.AppContext.Session().UserId)
+	result, appErr := embedCtx.App.Srv().ProductService().ProductVariantsBulkDelete(stringIds, lo.FromPtr(args.ErrorPolicy))
 	if appErr != nil {
 		return nil, appErr
 	}
 
 	return &ProductVariantBulkDelete{
-		Count: int32(numDeleted),
+		Count:  int32(result.Count),
+		Errors: lo.Map(result.Errors, systemProductVariantBulkErrorToGraphql),
 	}, nil
 }
 
@@ -176,12 +218,19 @@ func (r *Resolver) ProductVariantStocksCreate(ctx context.Context, args struct {
 			return nil, model_helper.NewAppError("ProductVariantStocksCreate", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "Stocks"}, "please provide unique warehouse ids", http.StatusBadRequest)
 		}
 
+		if stockInput.LowStockThreshold != nil && *stockInput.LowStockThreshold < 0 {
+			return nil, model_helper.NewAppError("ProductVariantStocksCreate", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "lowStockThreshold"}, "low stock threshold must not be negative", http.StatusBadRequest)
+		}
+
 		warehouseIdsForStocksMap[strWarehouseID] = true
 		stocksToCreate[idx] = &model.Stock{
 			WarehouseID:      strWarehouseID,
 			ProductVariantID: strVariantID,
 			Quantity:         int(stockInput.Quantity),
 		}
+		if stockInput.LowStockThreshold != nil {
+			stocksToCreate[idx].LowStockThreshold = model_types.NewNullInt(int(*stockInput.LowStockThreshold))
+		}
 	}
 
 	// check if given variant does already have stocks that belong to any of given warehouses
@@ -239,6 +288,54 @@ func (r *Resolver) ProductVariantStocksUpdate(ctx context.Context, args struct {
 	panic(fmt.Errorf("not implemented"))
 }
 
+// ProductVariantBackInStockSubscribe subscribes a customer to be emailed when given variant is back in stock
+// in given channel. Signed in customers are subscribed with their account email, others must provide an email.
+func (r *Resolver) ProductVariantBackInStockSubscribe(ctx context.Context, args struct {
+	Input BackInStockSubscribeInput
+}) (*ProductVariantBackInStockSubscribe, error) {
+	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
+
+	variant, appErr := embedCtx.App.Srv().ProductService().ProductVariantById(args.Input.Variant.String())
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var channelID *string
+	if args.Input.Channel != nil {
+		channelID = model_helper.GetPointerOfValue(args.Input.Channel.String())
+	}
+	channel, appErr := embedCtx.App.Srv().ChannelService().CleanChannel(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	subscription := model.BackInStockSubscription{
+		ProductVariantID: variant.ID,
+		ChannelID:        channel.ID,
+	}
+	if userID := embedCtx.AppContext.Session().UserID; userID != "" {
+		user, appErr := embedCtx.App.Srv().AccountService().UserById(ctx, userID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		subscription.Email = user.Email
+		subscription.UserID = model_types.NewNullString(user.ID)
+	} else if args.Input.Email != nil {
+		subscription.Email = *args.Input.Email
+	} else {
+		return nil, model_helper.NewAppError("ProductVariantBackInStockSubscribe", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "email"}, "please provide an email to be notified at", http.StatusBadRequest)
+	}
+
+	_, appErr = embedCtx.App.Srv().WarehouseService().SubscribeBackInStock(subscription)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &ProductVariantBackInStockSubscribe{
+		ProductVariant: SystemProductVariantToGraphqlProductVariant(variant),
+	}, nil
+}
+
 // NOTE: Refer to ./graphql/schemas/product_variant.graphqls for details on directives used.
 func (r *Resolver) ProductVariantSetDefault(ctx context.Context, args struct {
 	ProductID UUID
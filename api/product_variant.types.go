@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/mattermost/squirrel"
@@ -438,3 +439,105 @@ func (p *ProductVariantChannelListingUpdateInput) validate(where string, ctx *we
 
 	return nil
 }
+
+func bulkAttributeValueInputsToSystem(attributes []*BulkAttributeValueInput) []*model_helper.CustomProductAttributeValuesInput {
+	var res []*model_helper.CustomProductAttributeValuesInput
+	for _, attribute := range attributes {
+		// variants use custom attributes of their product, identified by id
+		if attribute != nil {
+			res = append(res, &model_helper.CustomProductAttributeValuesInput{ID: lo.FromPtr(attribute.ID), Values: attribute.Values})
+		}
+	}
+	return res
+}
+
+func bulkChannelListingInputsToSystem(listings []*ProductVariantChannelListingAddInput) []*model_helper.ProductVariantBulkChannelListingInput {
+	res := make([]*model_helper.ProductVariantBulkChannelListingInput, 0, len(listings))
+	for _, listing := range listings {
+		listingInput := &model_helper.ProductVariantBulkChannelListingInput{
+			ChannelID: listing.ChannelID.String(),
+			Price:     model_helper.GetPointerOfValue(listing.Price.ToDecimal()),
+		}
+		if listing.CostPrice != nil {
+			listingInput.CostPrice = model_helper.GetPointerOfValue(listing.CostPrice.ToDecimal())
+		}
+		if listing.PreorderThreshold != nil {
+			listingInput.PreorderQuantityThreshold = model_helper.GetPointerOfValue(int(*listing.PreorderThreshold))
+		}
+		res = append(res, listingInput)
+	}
+	return res
+}
+
+func bulkStockInputsToSystem(stocks []*StockInput) []*model_helper.ProductVariantBulkStockInput {
+	return lo.Map(stocks, func(stock *StockInput, _ int) *model_helper.ProductVariantBulkStockInput {
+		res := &model_helper.ProductVariantBulkStockInput{WarehouseID: stock.Warehouse.String(), Quantity: int(stock.Quantity)}
+		if stock.LowStockThreshold != nil {
+			res.LowStockThreshold = model_helper.GetPointerOfValue(int(*stock.LowStockThreshold))
+		}
+		return res
+	})
+}
+
+func (p *ProductVariantBulkCreateInput) toSystem() *model_helper.ProductVariantBulkInput {
+	if p == nil {
+		return nil
+	}
+
+	res := &model_helper.ProductVariantBulkInput{
+		Sku:             p.Sku,
+		TrackInventory:  p.TrackInventory,
+		Attributes:      bulkAttributeValueInputsToSystem(p.Attributes),
+		ChannelListings: bulkChannelListingInputsToSystem(p.ChannelListings),
+		Stocks:          bulkStockInputsToSystem(p.Stocks),
+	}
+	if p.Weight != nil {
+		res.Weight = model_helper.GetPointerOfValue(float32(p.Weight.Value))
+		res.WeightUnit = model_helper.GetPointerOfValue(p.Weight.Unit.String())
+	}
+	if p.LowStockThreshold != nil {
+		res.LowStockThreshold = model_helper.GetPointerOfValue(int(*p.LowStockThreshold))
+	}
+	return res
+}
+
+func (p *ProductVariantBulkUpdateInput) toSystem() *model_helper.ProductVariantBulkInput {
+	if p == nil {
+		return nil
+	}
+
+	res := &model_helper.ProductVariantBulkInput{
+		ID:              p.ID.String(),
+		Sku:             p.Sku,
+		Name:            p.Name,
+		TrackInventory:  p.TrackInventory,
+		Attributes:      bulkAttributeValueInputsToSystem(p.Attributes),
+		ChannelListings: bulkChannelListingInputsToSystem(p.ChannelListings),
+		Stocks:          bulkStockInputsToSystem(p.Stocks),
+	}
+	if p.Weight != nil {
+		res.Weight = model_helper.GetPointerOfValue(float32(p.Weight.Value))
+		res.WeightUnit = model_helper.GetPointerOfValue(p.Weight.Unit.String())
+	}
+	if p.QuantityLimitPerCustomer != nil {
+		res.QuantityLimitPerCustomer = model_helper.GetPointerOfValue(int(*p.QuantityLimitPerCustomer))
+	}
+	if p.LowStockThreshold != nil {
+		res.LowStockThreshold = model_helper.GetPointerOfValue(int(*p.LowStockThreshold))
+	}
+	return res
+}
+
+func systemProductVariantBulkErrorToGraphql(err *model_helper.ProductVariantBulkError, _ int) *BulkProductError {
+	code := ProductErrorCode(strings.ToUpper(err.Code))
+	if !code.IsValid() {
+		code = ProductErrorCodeInvalid
+	}
+
+	return &BulkProductError{
+		Field:   lo.Ternary(err.Field != "", &err.Field, nil),
+		Message: &err.Message,
+		Code:    code,
+		Index:   model_helper.GetPointerOfValue(int32(err.Index)),
+	}
+}
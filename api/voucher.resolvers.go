@@ -33,7 +33,7 @@ func (r *Resolver) VoucherCreate(ctx context.Context, args struct{ Input Voucher
 	args.Input.PatchVoucher(voucher)
 
 	embedCtx := GetContextValue[*web.Context](ctx, WebCtx)
-	newVoucher, appErr := embedCtx.App.Srv().DiscountService().UpsertVoucher(voucher)
+	newVoucher, appErr := embedCtx.App.Srv().DiscountService().UpsertVoucher(nil, voucher)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -104,7 +104,7 @@ func (r *Resolver) VoucherUpdate(ctx context.Context, args struct {
 	// update voucher in database
 	args.Input.PatchVoucher(voucher)
 
-	voucher, appErr = embedCtx.App.Srv().DiscountService().UpsertVoucher(voucher)
+	voucher, appErr = embedCtx.App.Srv().DiscountService().UpsertVoucher(nil, voucher)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -31,6 +31,7 @@ func init() {
 
 		sessionCache, err := s.CacheProvider.NewCache(&cache.CacheOptions{
 			Size:           model_helper.SESSION_CACHE_SIZE,
+			Name:           "Session",
 			Striped:        true,
 			StripedBuckets: max(runtime.NumCPU()-1, 1),
 		})
@@ -40,6 +41,7 @@ func init() {
 
 		statusCache, err := s.CacheProvider.NewCache(&cache.CacheOptions{
 			Size:           model_helper.STATUS_CACHE_SIZE,
+			Name:           "Status",
 			Striped:        true,
 			StripedBuckets: max(runtime.NumCPU()-1, 1),
 		})
@@ -131,3 +131,14 @@ func (s *ServiceAccount) StoreUserAddress(user model.User, address model.Address
 
 	return nil
 }
+
+// ValidateAddress checks given address against the address rules of its country and returns a normalized copy of it.
+// Returned app error wraps a model_helper.AddressFieldErrors so callers can report errors per field.
+func (s *ServiceAccount) ValidateAddress(address model.Address) (*model.Address, *model_helper.AppError) {
+	normalizedAddress, fieldErrors := model_helper.NormalizeAddress(address)
+	if len(fieldErrors) > 0 {
+		return nil, model_helper.NewAppError("ValidateAddress", "app.account.invalid_address.app_error", map[string]any{"Fields": strings.Join(fieldErrors.Fields(), ", ")}, fieldErrors.Error(), http.StatusBadRequest).Wrap(fieldErrors)
+	}
+
+	return normalizedAddress, nil
+}
@@ -0,0 +1,225 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/modules/util"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// RecordDataPrivacyEvent records given data privacy step of given user both as a customer event
+// and as an audit record of the user, so the step stays traceable after the user is anonymized.
+func (a *ServiceAccount) RecordDataPrivacyEvent(userID string, eventType model.CustomerEventType, params model_types.JSONString) *model_helper.AppError {
+	_, appErr := a.CommonCustomerCreateEvent(nil, &userID, nil, eventType, params)
+	if appErr != nil {
+		return appErr
+	}
+
+	var extraInfo string
+	if len(params) > 0 {
+		data, _ := json.Marshal(params)
+		extraInfo = string(data)
+	}
+
+	err := a.srv.Store.Audit().Save(model.Audit{
+		ID:        model_helper.NewId(),
+		CreatedAt: model_helper.GetMillis(),
+		UserID:    userID,
+		Action:    "data_privacy/" + strings.ToLower(eventType.String()),
+		ExtraInfo: extraInfo,
+	})
+	if err != nil {
+		return model_helper.NewAppError("RecordDataPrivacyEvent", "app.audit.save.saving.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// RequestDataErasure saves a token confirming erasure of given user's personal data, then emails
+// the user a link to given redirect url with the token attached.
+func (a *ServiceAccount) RequestDataErasure(user model.User, redirectURL string) *model_helper.AppError {
+	if isAdministrator(user) {
+		return model_helper.NewAppError("RequestDataErasure", "app.account.administrator_cannot_erase_data.app_error", nil, "", http.StatusNotAcceptable)
+	}
+
+	extra, _ := json.Marshal(model_helper.DataErasureTokenExtra{UserID: user.ID})
+	token, err := a.srv.Store.Token().Save(*model_helper.NewToken(model_helper.TokenTypeDataErasure, string(extra)))
+	if err != nil {
+		return model_helper.NewAppError("RequestDataErasure", "app.server.error_saving_token.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	confirmURL, err := util.PrepareUrl(url.Values{"token": []string{token.Token}}, redirectURL)
+	if err != nil {
+		return model_helper.NewAppError("RequestDataErasure", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "redirectURL"}, err.Error(), http.StatusBadRequest)
+	}
+
+	err = a.srv.EmailService.SendDataErasureRequestEmail(user.Email, confirmURL, user.Locale.String(), a.srv.GetSiteURL())
+	if err != nil {
+		return model_helper.NewAppError("RequestDataErasure", "app.account.error_sending_data_erasure_email.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return a.RecordDataPrivacyEvent(user.ID, model.CustomerEventTypeDATA_ERASURE_REQUESTED, nil)
+}
+
+// EraseUserData anonymizes given user, the user's addresses and personal details of the user's orders
+// and payments, and removes the user's sessions, access tokens, wishlist items and checkouts in one transaction.
+// Export files of the user are deleted after the transaction is committed.
+// Orders, their lines and totals are kept so accounting stays correct.
+func (a *ServiceAccount) EraseUserData(user model.User) *model_helper.AppError {
+	if isAdministrator(user) {
+		return model_helper.NewAppError("EraseUserData", "app.account.administrator_cannot_erase_data.app_error", nil, "", http.StatusNotAcceptable)
+	}
+
+	anonymizedEmail := util.AnonymizeEmail(user.ID)
+
+	addresses, err := a.srv.Store.Address().FilterByOption(model_helper.AddressFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.AddressWhere.UserID.EQ(user.ID)),
+	})
+	if err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.account.error_finding_user_address_relations.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	orders, err := a.srv.Store.Order().FilterByOption(model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.OrderWhere.UserID.EQ(model_types.NewNullString(user.ID))),
+	})
+	if err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.order.error_finding_orders_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	addressIDs := lo.Map(addresses, func(address *model.Address, _ int) string { return address.ID })
+	orderIDs := make([]string, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+		if order.BillingAddressID.String != nil {
+			addressIDs = append(addressIDs, *order.BillingAddressID.String)
+		}
+		if order.ShippingAddressID.String != nil {
+			addressIDs = append(addressIDs, *order.ShippingAddressID.String)
+		}
+	}
+
+	transaction, err := a.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return model_helper.NewAppError("EraseUserData", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer a.srv.Store.FinalizeTransaction(transaction)
+
+	if len(addressIDs) > 0 {
+		if err = a.srv.Store.Address().Anonymize(transaction, lo.Uniq(addressIDs)); err != nil {
+			return model_helper.NewAppError("EraseUserData", "app.account.error_anonymizing_user_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	if len(orderIDs) > 0 {
+		if err = a.srv.Store.Order().AnonymizeByUser(transaction, user.ID, anonymizedEmail); err != nil {
+			return model_helper.NewAppError("EraseUserData", "app.account.error_anonymizing_user_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if err = a.srv.Store.Payment().AnonymizeByOrders(transaction, orderIDs, anonymizedEmail); err != nil {
+			return model_helper.NewAppError("EraseUserData", "app.account.error_anonymizing_user_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	appErr := a.srv.Checkout.DeleteCheckoutsByOption(transaction, model_helper.CheckoutFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CheckoutWhere.UserID.EQ(model_types.NewNullString(user.ID))),
+	})
+	if appErr != nil {
+		return appErr
+	}
+	recoveries, appErr := a.srv.Checkout.CheckoutRecoveriesByOptions(model_helper.CheckoutRecoveryFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CheckoutRecoveryWhere.Email.EQ(user.Email)),
+	})
+	if appErr != nil {
+		return appErr
+	}
+	if len(recoveries) > 0 {
+		err = a.srv.Store.CheckoutRecovery().Delete(transaction, lo.Map(recoveries, func(r *model.CheckoutRecovery, _ int) string { return r.ID }))
+		if err != nil {
+			return model_helper.NewAppError("EraseUserData", "app.checkout.error_deleting_checkout_recoveries.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if appErr = a.deleteWishlistItemsOfUser(transaction, user.ID); appErr != nil {
+		return appErr
+	}
+
+	// credentials go together with the personal data, so a failed erasure leaves the user able to retry it
+	if err = a.srv.Store.User().Anonymize(transaction, user.ID, anonymizedEmail); err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.account.error_anonymizing_user_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if err = a.srv.Store.Session().PermanentDeleteSessionsByUser(transaction, user.ID); err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.session.permanent_delete_sessions_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if err = a.srv.Store.UserAccessToken().DeleteAllForUser(transaction, user.ID); err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.user_access_token.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return model_helper.NewAppError("EraseUserData", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	a.InvalidateCacheForUser(user.ID)
+
+	// previous exports contain personal data too. They are removed only once the erasure is committed,
+	// and failures are just logged since the data itself is already erased
+	a.deleteExportFilesOfUser(user.ID)
+
+	slog.Info("Erased personal data of user", slog.String("user_id", user.ID), slog.Int("orders", len(orderIDs)))
+
+	return a.RecordDataPrivacyEvent(user.ID, model.CustomerEventTypeDATA_ERASED, model_types.JSONString{
+		"orders":    len(orderIDs),
+		"addresses": len(addressIDs),
+	})
+}
+
+func (a *ServiceAccount) deleteExportFilesOfUser(userID string) {
+	exportFiles, appErr := a.srv.Csv.ExportFilesByOption(model_helper.ExportFileFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ExportFileWhere.UserID.EQ(model_types.NewNullString(userID))),
+	})
+	if appErr != nil {
+		slog.Error("Failed to find export files of erased user", slog.String("user_id", userID), slog.Err(appErr))
+		return
+	}
+	if appErr = a.srv.Csv.DeleteExportFiles(exportFiles); appErr != nil {
+		slog.Error("Failed to delete export files of erased user", slog.String("user_id", userID), slog.Err(appErr))
+	}
+}
+
+func (a *ServiceAccount) deleteWishlistItemsOfUser(transaction boil.ContextTransactor, userID string) *model_helper.AppError {
+	wishlist, err := a.srv.Store.Wishlist().GetByOption(model_helper.WishlistFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.WishlistWhere.UserID.EQ(userID)),
+	})
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return nil
+		}
+		return model_helper.NewAppError("EraseUserData", "app.wishlist.error_finding_wishlist.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	items, err := a.srv.Store.WishlistItem().FilterByOption(model_helper.WishlistItemFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.WishlistItemWhere.WishlistID.EQ(wishlist.ID)),
+	})
+	if err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.wishlist.error_finding_wishlist.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	_, err = a.srv.Store.WishlistItem().Delete(transaction, lo.Map(items, func(item *model.WishlistItem, _ int) string { return item.ID }))
+	if err != nil {
+		return model_helper.NewAppError("EraseUserData", "app.wishlist.error_deleting_wishlist_items_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// isAdministrator checks if given user manages the shop, such users cannot erase their own data
+func isAdministrator(user model.User) bool {
+	return model_helper.IsInRole(user.Roles, model_helper.SystemAdminRoleId) ||
+		model_helper.IsInRole(user.Roles, model_helper.SystemManagerRoleId)
+}
@@ -58,6 +58,18 @@ func (a *ServiceAccount) AuthenticateUserForLogin(c *request.Context, id, loginI
 		return nil, model_helper.NewAppError("AuthenticateUserForLogin", "api.user.login.blank_pwd.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	// LDAP only logins skip local users, users of the directory are created on their first login
+	if ldapOnly {
+		if !*a.srv.Config().LdapSettings.Enable {
+			return nil, model_helper.NewAppError("AuthenticateUserForLogin", "api.user.login_ldap.not_available.app_error", nil, "", http.StatusNotImplemented)
+		}
+		if user, err = a.checkLdapUserPasswordAndAllCriteria(&loginId, password, mfaToken); err != nil {
+			err.StatusCode = http.StatusUnauthorized
+			return nil, err
+		}
+		return user, nil
+	}
+
 	// get the sn user we are trying to login
 	if user, err = a.GetUserForLogin(id, loginId); err != nil {
 		return nil, err
@@ -2,13 +2,18 @@ package account
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
 )
 
+// MaxFavoriteCategories is the maximum number of product categories a user can mark as favourite
+const MaxFavoriteCategories = 20
+
 func (a *ServiceAccount) GetPreferencesForUser(userID string) (model.PreferenceSlice, *model_helper.AppError) {
 	preferences, err := a.srv.Store.Preference().GetAll(userID)
 	if err != nil {
@@ -80,3 +85,57 @@ func (a *ServiceAccount) DeletePreferences(userID string, preferences model.Pref
 
 	return nil
 }
+
+// FavoriteCategoryIDsOfUser returns ids of product categories the user marked as favourite
+func (a *ServiceAccount) FavoriteCategoryIDsOfUser(userID string) ([]string, *model_helper.AppError) {
+	preferences, appErr := a.GetPreferenceByCategoryForUser(userID, model_helper.PREFERENCE_CATEGORY_FAVORITE_PRODUCT_CATEGORY)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	categoryIDs := make([]string, 0, len(preferences))
+	for _, preference := range preferences {
+		if preference.Value == "true" {
+			categoryIDs = append(categoryIDs, preference.Name)
+		}
+	}
+	return categoryIDs, nil
+}
+
+// SetFavoriteCategoriesOfUser replaces favourite product categories of the user with given ones
+func (a *ServiceAccount) SetFavoriteCategoriesOfUser(userID string, categoryIDs []string) *model_helper.AppError {
+	categoryIDs = lo.Uniq(categoryIDs)
+	if len(categoryIDs) > MaxFavoriteCategories {
+		return model_helper.NewAppError("SetFavoriteCategoriesOfUser", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "categoryIDs"}, fmt.Sprintf("at most %d favourite categories are allowed", MaxFavoriteCategories), http.StatusBadRequest)
+	}
+
+	if len(categoryIDs) > 0 {
+		categories, err := a.srv.Store.Category().FilterByOption(model_helper.CategoryFilterOption{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CategoryWhere.ID.IN(categoryIDs)),
+		})
+		if err != nil {
+			return model_helper.NewAppError("SetFavoriteCategoriesOfUser", "app.product.error_finding_categories_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(categories) != len(categoryIDs) {
+			return model_helper.NewAppError("SetFavoriteCategoriesOfUser", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "categoryIDs"}, "some categories do not exist", http.StatusBadRequest)
+		}
+	}
+
+	if err := a.srv.Store.Preference().DeleteCategory(userID, model_helper.PREFERENCE_CATEGORY_FAVORITE_PRODUCT_CATEGORY); err != nil {
+		return model_helper.NewAppError("SetFavoriteCategoriesOfUser", "app.preference.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	preferences := make(model.PreferenceSlice, 0, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		preferences = append(preferences, &model.Preference{
+			UserID:   userID,
+			Category: model_helper.PREFERENCE_CATEGORY_FAVORITE_PRODUCT_CATEGORY,
+			Name:     categoryID,
+			Value:    "true",
+		})
+	}
+	return a.UpdatePreferences(userID, preferences)
+}
@@ -906,10 +906,10 @@ func (a *ServiceAccount) PermanentDeleteUser(c *request.Context, user model.User
 	if _, err := a.UpdateActive(c, user, false); err != nil {
 		return err
 	}
-	if err := a.srv.Store.Session().PermanentDeleteSessionsByUser(user.ID); err != nil {
+	if err := a.srv.Store.Session().PermanentDeleteSessionsByUser(nil, user.ID); err != nil {
 		return model_helper.NewAppError("PermanentDeleteUser", "app.session.permanent_delete_sessions_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
-	if err := a.srv.Store.UserAccessToken().DeleteAllForUser(user.ID); err != nil {
+	if err := a.srv.Store.UserAccessToken().DeleteAllForUser(nil, user.ID); err != nil {
 		return model_helper.NewAppError("PermanentDeleteUser", "app.user_access_token.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
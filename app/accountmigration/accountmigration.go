@@ -0,0 +1,173 @@
+/*
+NOTE: This package is initialized during server startup (modules/imports does that)
+so the init() function get the chance to register a function to create the account migration interface
+*/
+package accountmigration
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/einterfaces"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+// AccountMigration moves users from one authentication service to the directory or the SAML identity provider.
+// Users keep their accounts, only their auth service and auth data change.
+type AccountMigration struct {
+	srv *app.Server
+}
+
+func init() {
+	app.RegisterAccountMigrationInterface(func(a *app.App) einterfaces.AccountMigrationInterface {
+		return New(a.Srv())
+	})
+}
+
+func New(s *app.Server) *AccountMigration {
+	return &AccountMigration{srv: s}
+}
+
+var _ einterfaces.AccountMigrationInterface = (*AccountMigration)(nil)
+
+// usersOfAuthService returns active and deactivated users of given auth service, email users have an empty one
+func (m *AccountMigration) usersOfAuthService(authService string) (model.UserSlice, *model_helper.AppError) {
+	return m.srv.Account.FindUsersByOptions(model_helper.UserFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.UserWhere.AuthService.EQ(authService),
+		),
+	})
+}
+
+// migrate switches given user to given auth service, nothing is saved on dry runs
+func (m *AccountMigration) migrate(user *model.User, authService, authData string, dryRun bool) *model_helper.AppError {
+	if dryRun {
+		slog.Info("Would migrate user", slog.String("user_id", user.ID), slog.String("email", user.Email), slog.String("auth_service", authService), slog.String("auth_data", authData))
+		return nil
+	}
+
+	if _, err := m.srv.Store.User().UpdateAuthData(user.ID, authService, &authData, "", false); err != nil {
+		return model_helper.NewAppError("migrate", "ent.account_migration.update_auth_data.app_error", map[string]any{"UserId": user.ID}, err.Error(), http.StatusInternalServerError)
+	}
+	m.srv.Account.InvalidateCacheForUser(user.ID)
+	return nil
+}
+
+// MigrateToLdap switches users of given auth service to directory users having the same email or username,
+// depending on given field. Directory users already bound to other accounts fail the migration, unless forced
+// in which case they are skipped.
+func (m *AccountMigration) MigrateToLdap(fromAuthService string, forignUserFieldNameToMatch string, force bool, dryRun bool) *model_helper.AppError {
+	if m.srv.Ldap == nil || !*m.srv.Config().LdapSettings.Enable {
+		return model_helper.NewAppError("MigrateToLdap", "ent.ldap.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	ldapUsers, appErr := m.srv.Ldap.GetAllLdapUsers()
+	if appErr != nil {
+		return appErr
+	}
+	ldapUsersByField := make(map[string]*model.User, len(ldapUsers))
+	for _, ldapUser := range ldapUsers {
+		key := ldapUser.Email
+		if forignUserFieldNameToMatch == "username" {
+			key = ldapUser.Username
+		}
+		if key != "" {
+			ldapUsersByField[strings.ToLower(key)] = ldapUser
+		}
+	}
+
+	boundUsers, appErr := m.usersOfAuthService(model_helper.USER_AUTH_SERVICE_LDAP)
+	if appErr != nil {
+		return appErr
+	}
+	boundAuthData := make(map[string]bool, len(boundUsers))
+	for _, user := range boundUsers {
+		if !user.AuthData.IsNil() {
+			boundAuthData[*user.AuthData.String] = true
+		}
+	}
+
+	users, appErr := m.usersOfAuthService(fromAuthService)
+	if appErr != nil {
+		return appErr
+	}
+
+	var migrated int
+	for _, user := range users {
+		key := user.Email
+		if forignUserFieldNameToMatch == "username" {
+			key = user.Username
+		}
+		ldapUser, ok := ldapUsersByField[strings.ToLower(key)]
+		if !ok || ldapUser.AuthData.IsNil() || *ldapUser.AuthData.String == "" {
+			slog.Warn("Directory user not found while migrating user", slog.String("user_id", user.ID), slog.String(forignUserFieldNameToMatch, key))
+			continue
+		}
+
+		authData := *ldapUser.AuthData.String
+		if boundAuthData[authData] {
+			if !force {
+				return model_helper.NewAppError("MigrateToLdap", "ent.account_migration.duplicate_user.app_error", map[string]any{"Value": key}, "user_id="+user.ID, http.StatusConflict)
+			}
+			slog.Warn("Skipped user whose directory user is bound to another account", slog.String("user_id", user.ID), slog.String(forignUserFieldNameToMatch, key))
+			continue
+		}
+
+		if appErr := m.migrate(user, model_helper.USER_AUTH_SERVICE_LDAP, authData, dryRun); appErr != nil {
+			return appErr
+		}
+		boundAuthData[authData] = true
+		migrated++
+	}
+
+	slog.Info("Migrated users to the directory", slog.String("from", fromAuthService), slog.Int("migrated", migrated), slog.Int("total", len(users)))
+	return nil
+}
+
+// MigrateToSaml switches users of given auth service to SAML. Given map has emails of users as keys and ids the
+// identity provider asserts for them as values. When auto is set, users are expected to be identified by their email
+// by the identity provider and the map is ignored.
+func (m *AccountMigration) MigrateToSaml(fromAuthService string, usersMap map[string]string, auto bool, dryRun bool) *model_helper.AppError {
+	settings := m.srv.Config().SamlSettings
+	if m.srv.Saml == nil || !*settings.Enable {
+		return model_helper.NewAppError("MigrateToSaml", "ent.saml.not_configured.app_error", nil, "", http.StatusNotImplemented)
+	}
+	if auto && *settings.IdAttribute != "" && *settings.IdAttribute != *settings.EmailAttribute {
+		return model_helper.NewAppError("MigrateToSaml", "ent.account_migration.saml_auto.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	emailsMap := make(map[string]string, len(usersMap))
+	for email, authData := range usersMap {
+		emailsMap[strings.ToLower(email)] = authData
+	}
+
+	users, appErr := m.usersOfAuthService(fromAuthService)
+	if appErr != nil {
+		return appErr
+	}
+
+	var migrated int
+	for _, user := range users {
+		authData := user.Email
+		if !auto {
+			var ok bool
+			if authData, ok = emailsMap[strings.ToLower(user.Email)]; !ok || authData == "" {
+				slog.Warn("User missing from the users file while migrating to SAML", slog.String("user_id", user.ID), slog.String("email", user.Email))
+				continue
+			}
+		}
+		// logins look users up by normalized auth data
+		authData = model_helper.NormalizeSamlAuthData(authData)
+
+		if appErr := m.migrate(user, model_helper.USER_AUTH_SERVICE_SAML, authData, dryRun); appErr != nil {
+			return appErr
+		}
+		migrated++
+	}
+
+	slog.Info("Migrated users to SAML", slog.String("from", fromAuthService), slog.Int("migrated", migrated), slog.Int("total", len(users)))
+	return nil
+}
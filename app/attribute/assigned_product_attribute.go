@@ -36,7 +36,7 @@ func (a *ServiceAttribute) GetOrCreateAssignedProductAttribute(assignedProductAt
 		return nil, appErr
 	}
 	// create new one
-	assignedProductAttr, err := a.srv.Store.AssignedProductAttribute().Save(assignedProductAttribute)
+	assignedProductAttr, err := a.srv.Store.AssignedProductAttribute().Save(nil, assignedProductAttribute)
 	if err != nil {
 		if appErr, ok := err.(*model_helper.AppError); ok {
 			return nil, appErr
@@ -0,0 +1,384 @@
+package checkout
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app/email"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/modules/util"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// checkoutRecoveryConversionWindow is how long after the last reminder an order
+// placed from the checkout is still counted as a conversion of that reminder.
+const checkoutRecoveryConversionWindow = 30 * 24 * time.Hour
+
+// checkoutRecoveryLinkLifetime is how long recovery links sent in reminders stay valid
+const checkoutRecoveryLinkLifetime = 7 * 24 * time.Hour
+
+// checkoutRecoverySignaturePurpose is signed along with the checkout token, so that recovery signatures
+// are never valid for other features sharing the same secret.
+const checkoutRecoverySignaturePurpose = "checkout_recovery"
+
+// anonymousCheckoutPurgeBatchSize is the number of anonymous checkouts deleted per transaction
+const anonymousCheckoutPurgeBatchSize = 1000
+
+// CheckoutRecoveriesByOptions returns a list of checkout recoveries filtered using given options
+func (s *ServiceCheckout) CheckoutRecoveriesByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, *model_helper.AppError) {
+	recoveries, err := s.srv.Store.CheckoutRecovery().FilterByOptions(options)
+	if err != nil {
+		return nil, model_helper.NewAppError("CheckoutRecoveriesByOptions", "app.checkout.error_finding_checkout_recoveries_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return recoveries, nil
+}
+
+// UpsertCheckoutRecovery inserts or updates given checkout recovery
+func (s *ServiceCheckout) UpsertCheckoutRecovery(transaction boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, *model_helper.AppError) {
+	savedRecovery, err := s.srv.Store.CheckoutRecovery().Upsert(transaction, recovery)
+	if err != nil {
+		if appErr, ok := err.(*model_helper.AppError); ok {
+			return nil, appErr
+		}
+		statusCode := http.StatusInternalServerError
+		if _, ok := err.(*store.ErrInvalidInput); ok {
+			statusCode = http.StatusBadRequest
+		}
+		return nil, model_helper.NewAppError("UpsertCheckoutRecovery", "app.checkout.error_upserting_checkout_recovery.app_error", nil, err.Error(), statusCode)
+	}
+	return savedRecovery, nil
+}
+
+// checkoutRecoverySignature signs given checkout token and expiry time of its recovery link, so that recovery links
+// can neither be forged nor used after they expire. The signature is in "<expiry millis>.<hmac>" format.
+func (s *ServiceCheckout) checkoutRecoverySignature(checkoutToken string, expiresAt int64) string {
+	expires := strconv.FormatInt(expiresAt, 10)
+
+	mac := hmac.New(sha256.New, s.srv.PostActionCookieSecret())
+	mac.Write([]byte(checkoutRecoverySignaturePurpose + ":" + checkoutToken + ":" + expires))
+	return expires + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verifyCheckoutRecoverySignature tells if given signature was made for given checkout token and has not expired yet
+func (s *ServiceCheckout) verifyCheckoutRecoverySignature(checkoutToken, signature string) bool {
+	expires, _, ok := strings.Cut(signature, ".")
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || expiresAt <= model_helper.GetMillis() {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.checkoutRecoverySignature(checkoutToken, expiresAt)))
+}
+
+// CheckoutRecoveryURL returns a signed link that restores given checkout, valid for checkoutRecoveryLinkLifetime
+func (s *ServiceCheckout) CheckoutRecoveryURL(checkoutToken string) (string, error) {
+	recoveryURL := *s.srv.Config().ShopSettings.AbandonedCheckoutRecoveryURL
+	if recoveryURL == "" {
+		recoveryURL = strings.TrimSuffix(*s.srv.Config().ServiceSettings.SiteURL, "/") + "/checkout/recover"
+	}
+
+	expiresAt := model_helper.GetMillis() + checkoutRecoveryLinkLifetime.Milliseconds()
+	return util.PrepareUrl(url.Values{
+		"token":     []string{checkoutToken},
+		"signature": []string{s.checkoutRecoverySignature(checkoutToken, expiresAt)},
+	}, recoveryURL)
+}
+
+// RecoverAbandonedCheckout checks given signature against given checkout token, marks the checkout as recovered
+// and returns it. Expired signatures are rejected.
+func (s *ServiceCheckout) RecoverAbandonedCheckout(checkoutToken, signature string) (*model.Checkout, *model_helper.AppError) {
+	if !s.verifyCheckoutRecoverySignature(checkoutToken, signature) {
+		return nil, model_helper.NewAppError("RecoverAbandonedCheckout", "app.checkout.invalid_recovery_signature.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	checkout, appErr := s.CheckoutByOption(model_helper.CheckoutFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CheckoutWhere.Token.EQ(checkoutToken)),
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	recoveries, appErr := s.CheckoutRecoveriesByOptions(model_helper.CheckoutRecoveryFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CheckoutRecoveryWhere.CheckoutToken.EQ(checkoutToken)),
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(recoveries) > 0 && recoveries[0].RecoveredAt.IsNil() {
+		recovery := *recoveries[0]
+		recovery.RecoveredAt = model_types.NewNullInt64(model_helper.GetMillis())
+		if _, appErr := s.UpsertCheckoutRecovery(nil, recovery); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return checkout, nil
+}
+
+// ProcessAbandonedCheckouts is run periodically by the abandoned checkouts job. It:
+//
+//  1. records orders placed from checkouts that were sent recovery emails,
+//  2. emails customers whose checkouts have been inactive for the configured number of hours,
+//  3. purges anonymous checkouts older than the configured retention period.
+//
+// The data retention job purges old anonymous checkouts too, so they are purged whichever job is enabled.
+func (s *ServiceCheckout) ProcessAbandonedCheckouts() *model_helper.AppError {
+	shopSettings := s.srv.Config().ShopSettings
+
+	if appErr := s.trackCheckoutRecoveryConversions(); appErr != nil {
+		return appErr
+	}
+
+	if *shopSettings.EnableAbandonedCheckoutEmails {
+		if appErr := s.sendAbandonedCheckoutReminders(); appErr != nil {
+			return appErr
+		}
+	}
+
+	if days := *shopSettings.AnonymousCheckoutRetentionDays; days > 0 {
+		return s.purgeAnonymousCheckouts(days)
+	}
+	return nil
+}
+
+// purgeAnonymousCheckouts deletes anonymous checkouts not updated for given number of days in batches
+func (s *ServiceCheckout) purgeAnonymousCheckouts(retentionDays int) *model_helper.AppError {
+	endTime := model_helper.GetMillis() - (time.Duration(retentionDays) * 24 * time.Hour).Milliseconds()
+	for {
+		deleted, appErr := s.PurgeAnonymousCheckouts(endTime, anonymousCheckoutPurgeBatchSize)
+		if appErr != nil {
+			return appErr
+		}
+		if deleted < anonymousCheckoutPurgeBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *ServiceCheckout) trackCheckoutRecoveryConversions() *model_helper.AppError {
+	recoveries, appErr := s.CheckoutRecoveriesByOptions(model_helper.CheckoutRecoveryFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.CheckoutRecoveryWhere.OrderID.IsNull(),
+			model.CheckoutRecoveryWhere.LastReminderAt.GTE(model_helper.GetMillis()-checkoutRecoveryConversionWindow.Milliseconds()),
+		),
+	})
+	if appErr != nil || len(recoveries) == 0 {
+		return appErr
+	}
+
+	recoveriesByToken := lo.SliceToMap(recoveries, func(r *model.CheckoutRecovery) (string, *model.CheckoutRecovery) { return r.CheckoutToken, r })
+	orders, err := s.srv.Store.Order().FilterByOption(model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.OrderWhere.CheckoutToken.IN(lo.Keys(recoveriesByToken))),
+	})
+	if err != nil {
+		return model_helper.NewAppError("trackCheckoutRecoveryConversions", "app.order.error_finding_orders_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, order := range orders {
+		recovery, ok := recoveriesByToken[order.CheckoutToken]
+		if !ok || !recovery.OrderID.IsNil() {
+			continue
+		}
+		recovery.OrderID = model_types.NewNullString(order.ID)
+		recovery.ConvertedAt = model_types.NewNullInt64(order.CreatedAt)
+		if _, appErr := s.UpsertCheckoutRecovery(nil, *recovery); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
+
+func (s *ServiceCheckout) sendAbandonedCheckoutReminders() *model_helper.AppError {
+	var (
+		shopSettings = s.srv.Config().ShopSettings
+		siteURL      = *s.srv.Config().ServiceSettings.SiteURL
+		maxReminders = *shopSettings.AbandonedCheckoutMaxReminders
+		inactivity   = (time.Duration(*shopSettings.AbandonedCheckoutInactivityHours) * time.Hour).Milliseconds()
+		now          = model_helper.GetMillis()
+	)
+	if maxReminders <= 0 || inactivity <= 0 {
+		return nil
+	}
+
+	checkouts, err := s.srv.Store.Checkout().FilterByOption(model_helper.CheckoutFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.CheckoutWhere.Email.NEQ(""),
+			model.CheckoutWhere.CompletingStartedAt.IsNull(),
+			model.CheckoutWhere.UpdatedAt.LTE(now-inactivity),
+			// checkouts abandoned before the last possible reminder window are not worth reminding about anymore
+			model.CheckoutWhere.UpdatedAt.GT(now-inactivity*int64(maxReminders+1)),
+			qm.Where(fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM %s WHERE %s = %s)",
+				model.TableNames.CheckoutLines,
+				model.CheckoutLineTableColumns.CheckoutID,
+				model.CheckoutTableColumns.Token,
+			)),
+			qm.Load(fmt.Sprintf("%s.%s.%s", model.CheckoutRels.CheckoutLines, model.CheckoutLineRels.Variant, model.ProductVariantRels.Product)),
+		),
+	})
+	if err != nil {
+		return model_helper.NewAppError("sendAbandonedCheckoutReminders", "app.checkout.error_finding_checkouts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(checkouts) == 0 {
+		return nil
+	}
+
+	recoveries, appErr := s.CheckoutRecoveriesByOptions(model_helper.CheckoutRecoveryFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.CheckoutRecoveryWhere.CheckoutToken.IN(lo.Map(checkouts, func(c *model.Checkout, _ int) string { return c.Token })),
+		),
+	})
+	if appErr != nil {
+		return appErr
+	}
+	recoveriesByToken := lo.SliceToMap(recoveries, func(r *model.CheckoutRecovery) (string, *model.CheckoutRecovery) { return r.CheckoutToken, r })
+
+	for _, checkout := range checkouts {
+		recovery, ok := recoveriesByToken[checkout.Token]
+		if !ok {
+			recovery = &model.CheckoutRecovery{CheckoutToken: checkout.Token}
+		} else if recovery.RemindersSent >= maxReminders || recovery.LastReminderAt > now-inactivity || !recovery.OrderID.IsNil() {
+			continue
+		}
+
+		var lines []email.AbandonedCheckoutLine
+		for _, line := range checkout.R.GetCheckoutLines() {
+			variant := line.R.GetVariant()
+			if variant == nil {
+				continue
+			}
+			name := variant.Name
+			if product := variant.R.GetProduct(); product != nil {
+				if name == "" {
+					name = product.Name
+				} else {
+					name = product.Name + " - " + name
+				}
+			}
+			lines = append(lines, email.AbandonedCheckoutLine{Name: name, Quantity: line.Quantity})
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		recoveryURL, err := s.CheckoutRecoveryURL(checkout.Token)
+		if err != nil {
+			slog.Error("Failed to build checkout recovery url", slog.String("checkout_token", checkout.Token), slog.Err(err))
+			continue
+		}
+
+		locale := checkout.LanguageCode.String()
+		if locale == "" {
+			locale = s.srv.Config().LocalizationSettings.DefaultClientLocale.String()
+		}
+
+		// the reminder is recorded before it is sent, so that a failing upsert can never make the next run
+		// send it again
+		previous := *recovery
+		recovery.Email = checkout.Email
+		recovery.RemindersSent++
+		recovery.LastReminderAt = now
+		savedRecovery, appErr := s.UpsertCheckoutRecovery(nil, *recovery)
+		if appErr != nil {
+			return appErr
+		}
+
+		err = s.srv.EmailService.SendAbandonedCheckoutEmail(checkout.Email, lines, recoveryURL, savedRecovery.RemindersSent, locale, siteURL)
+		if err != nil {
+			slog.Error("Failed to send abandoned checkout email", slog.String("checkout_token", checkout.Token), slog.Err(err))
+
+			// retry on next run
+			savedRecovery.RemindersSent = previous.RemindersSent
+			savedRecovery.LastReminderAt = previous.LastReminderAt
+			if _, appErr := s.UpsertCheckoutRecovery(nil, *savedRecovery); appErr != nil {
+				return appErr
+			}
+		}
+	}
+
+	return nil
+}
+
+// anonymousCheckoutConditions selects checkouts without users, last updated before given time
+func anonymousCheckoutConditions(endTime int64) []qm.QueryMod {
+	return []qm.QueryMod{
+		model.CheckoutWhere.UserID.IsNull(),
+		model.CheckoutWhere.UpdatedAt.LT(endTime),
+	}
+}
+
+// CountAnonymousCheckouts counts checkouts without users last updated before given time
+func (s *ServiceCheckout) CountAnonymousCheckouts(endTime int64) (int64, *model_helper.AppError) {
+	count, err := s.srv.Store.Checkout().CountCheckouts(model_helper.CheckoutFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(anonymousCheckoutConditions(endTime)...),
+	})
+	if err != nil {
+		return 0, model_helper.NewAppError("CountAnonymousCheckouts", "app.checkout.error_finding_checkouts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return count, nil
+}
+
+// PurgeAnonymousCheckouts deletes up to limit checkouts without users last updated before given time, along with
+// their recoveries. It returns the number of deleted checkouts.
+func (s *ServiceCheckout) PurgeAnonymousCheckouts(endTime int64, limit int) (int64, *model_helper.AppError) {
+	checkouts, err := s.srv.Store.Checkout().FilterByOption(model_helper.CheckoutFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			append(anonymousCheckoutConditions(endTime), qm.Limit(limit))...,
+		),
+	})
+	if err != nil {
+		return 0, model_helper.NewAppError("PurgeAnonymousCheckouts", "app.checkout.error_finding_checkouts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(checkouts) == 0 {
+		return 0, nil
+	}
+	tokens := lo.Map(checkouts, func(c *model.Checkout, _ int) string { return c.Token })
+
+	// converted recoveries are kept for conversion reporting
+	recoveries, appErr := s.CheckoutRecoveriesByOptions(model_helper.CheckoutRecoveryFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.CheckoutRecoveryWhere.CheckoutToken.IN(tokens),
+			model.CheckoutRecoveryWhere.OrderID.IsNull(),
+		),
+	})
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	transaction, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return 0, model_helper.NewAppError("PurgeAnonymousCheckouts", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(transaction)
+
+	if len(recoveries) > 0 {
+		err = s.srv.Store.CheckoutRecovery().Delete(transaction, lo.Map(recoveries, func(r *model.CheckoutRecovery, _ int) string { return r.ID }))
+		if err != nil {
+			return 0, model_helper.NewAppError("PurgeAnonymousCheckouts", "app.checkout.error_deleting_checkout_recoveries.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	if err = s.srv.Store.Checkout().Delete(transaction, tokens); err != nil {
+		return 0, model_helper.NewAppError("PurgeAnonymousCheckouts", "app.checkout.error_deleting_checkouts_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, model_helper.NewAppError("PurgeAnonymousCheckouts", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	return int64(len(tokens)), nil
+}
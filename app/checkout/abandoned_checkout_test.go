@@ -0,0 +1,125 @@
+package checkout
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func newTestService(t *testing.T, mockStore *mocks.Store) *ServiceCheckout {
+	t.Helper()
+
+	memoryStore, err := config.NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := config.NewStoreFromBacking(memoryStore, nil, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { configStore.Close() })
+
+	cfg := configStore.Get().Clone()
+	cfg.ServiceSettings.SiteURL = model_helper.GetPointerOfValue("https://shop.example.com")
+	_, _, err = configStore.Set(cfg)
+	require.NoError(t, err)
+
+	return &ServiceCheckout{srv: &app.Server{Store: mockStore, ConfigStore: configStore}}
+}
+
+func TestRecoverAbandonedCheckout(t *testing.T) {
+	checkout := &model.Checkout{Token: model_helper.NewId(), Email: "buyer@example.com"}
+	recovery := &model.CheckoutRecovery{ID: model_helper.NewId(), CheckoutToken: checkout.Token, Email: checkout.Email, RemindersSent: 1}
+
+	checkouts := &mocks.CheckoutStore{}
+	checkouts.On("GetByOption", mock.Anything).Return(checkout, nil)
+	recoveries := &mocks.CheckoutRecoveryStore{}
+	recoveries.On("FilterByOptions", mock.Anything).Return(model.CheckoutRecoverySlice{recovery}, nil)
+	var upserted model.CheckoutRecovery
+	recoveries.On("Upsert", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, r model.CheckoutRecovery) (*model.CheckoutRecovery, error) {
+		upserted = r
+		return &r, nil
+	})
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Checkout").Return(checkouts)
+	mockStore.On("CheckoutRecovery").Return(recoveries)
+	service := newTestService(t, mockStore)
+
+	recoveryURL, err := service.CheckoutRecoveryURL(checkout.Token)
+	require.NoError(t, err)
+	parsed, err := url.Parse(recoveryURL)
+	require.NoError(t, err)
+	require.Equal(t, "/checkout/recover", parsed.Path)
+	require.Equal(t, checkout.Token, parsed.Query().Get("token"))
+	signature := parsed.Query().Get("signature")
+
+	recovered, appErr := service.RecoverAbandonedCheckout(checkout.Token, signature)
+	require.Nil(t, appErr)
+	require.Equal(t, checkout, recovered)
+	require.Equal(t, recovery.ID, upserted.ID)
+	require.False(t, upserted.RecoveredAt.IsNil())
+
+	for name, test := range map[string]struct {
+		token     string
+		signature string
+	}{
+		"signature of another checkout": {model_helper.NewId(), signature},
+		"expired signature":             {checkout.Token, service.checkoutRecoverySignature(checkout.Token, model_helper.GetMillis()-1)},
+		"extended expiry":               {checkout.Token, "9" + signature},
+		"signature without expiry":      {checkout.Token, "abc"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, appErr := service.RecoverAbandonedCheckout(test.token, test.signature)
+			require.NotNil(t, appErr)
+			require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+}
+
+func TestSendAbandonedCheckoutRemindersRecordsReminderFirst(t *testing.T) {
+	checkout := &model.Checkout{Token: model_helper.NewId(), Email: "buyer@example.com"}
+	checkout.R = checkout.R.NewStruct()
+	line := &model.CheckoutLine{Quantity: 2}
+	line.R = line.R.NewStruct()
+	line.R.Variant = &model.ProductVariant{Name: "Red Shirt"}
+	checkout.R.CheckoutLines = model.CheckoutLineSlice{line}
+
+	checkouts := &mocks.CheckoutStore{}
+	checkouts.On("FilterByOption", mock.Anything).Return(model.CheckoutSlice{checkout}, nil)
+	recoveries := &mocks.CheckoutRecoveryStore{}
+	recoveries.On("FilterByOptions", mock.Anything).Return(model.CheckoutRecoverySlice{}, nil)
+	recoveries.On("Upsert", mock.Anything, mock.Anything).Return(nil, errors.New("connection lost"))
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Checkout").Return(checkouts)
+	mockStore.On("CheckoutRecovery").Return(recoveries)
+	service := newTestService(t, mockStore)
+
+	// the server has no email service, so sending the email before recording it would panic
+	appErr := service.sendAbandonedCheckoutReminders()
+	require.NotNil(t, appErr)
+
+	recoveries.AssertNumberOfCalls(t, "Upsert", 1)
+	recorded := recoveries.Calls[len(recoveries.Calls)-1].Arguments.Get(1).(model.CheckoutRecovery)
+	require.Equal(t, checkout.Token, recorded.CheckoutToken)
+	require.Equal(t, checkout.Email, recorded.Email)
+	require.Equal(t, 1, recorded.RemindersSent)
+}
+
+func TestPurgeAnonymousCheckoutsStopsWhenNothingIsLeft(t *testing.T) {
+	checkouts := &mocks.CheckoutStore{}
+	checkouts.On("FilterByOption", mock.Anything).Return(model.CheckoutSlice{}, nil)
+	mockStore := &mocks.Store{}
+	mockStore.On("Checkout").Return(checkouts)
+	service := newTestService(t, mockStore)
+
+	require.Nil(t, service.purgeAnonymousCheckouts(30))
+	checkouts.AssertNumberOfCalls(t, "FilterByOption", 1)
+}
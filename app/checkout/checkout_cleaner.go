@@ -2,6 +2,7 @@ package checkout
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/samber/lo"
 	"github.com/sitename/sitename/app/plugin/interfaces"
@@ -35,6 +36,12 @@ func (a *ServiceCheckout) CleanCheckoutShipping(checkoutInfo model_helper.Checko
 			}
 			return model_helper.NewAppError("CleanCheckoutShipping", "app.discount.shipping_method_not_valid_for_shipping_address.app_error", nil, "", http.StatusNotImplemented)
 		}
+
+		if checkoutInfo.ShippingMethod != nil && checkoutInfo.ShippingAddress != nil {
+			if appErr := validateCheckoutAddress("CleanCheckoutShipping", *checkoutInfo.ShippingAddress, model_helper.ADDRESS_TYPE_SHIPPING); appErr != nil {
+				return appErr
+			}
+		}
 	}
 
 	return nil
@@ -45,7 +52,22 @@ func (a *ServiceCheckout) CleanBillingAddress(checkoutInfo model_helper.Checkout
 		return model_helper.NewAppError("CleanBillingAddress", "app.discount.billing_address_not_set.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return nil
+	return validateCheckoutAddress("CleanBillingAddress", *checkoutInfo.BillingAddress, model_helper.ADDRESS_TYPE_BILLING)
+}
+
+// validateCheckoutAddress checks given address against the address rules of its country.
+// Returned app error wraps a model_helper.AddressFieldErrors describing invalid fields.
+func validateCheckoutAddress(where string, address model.Address, addressType model_helper.AddressTypeEnum) *model_helper.AppError {
+	_, fieldErrors := model_helper.NormalizeAddress(address)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	params := map[string]any{
+		"AddressType": addressType,
+		"Fields":      strings.Join(fieldErrors.Fields(), ", "),
+	}
+	return model_helper.NewAppError(where, "app.checkout.invalid_address.app_error", params, fieldErrors.Error(), http.StatusBadRequest).Wrap(fieldErrors)
 }
 
 func (a *ServiceCheckout) CleanCheckoutPayment(tx boil.ContextTransactor, manager interfaces.PluginManagerInterface, checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos, discounts []*model_helper.DiscountInfo, lastPayment *model.Payment) (*model_helper.PaymentError, *model_helper.AppError) {
@@ -34,7 +34,7 @@ func (s *ServiceCheckout) getVoucherDataForOrder(checkoutInfo model_helper.Check
 	}
 
 	if !voucher.UsageLimit.IsNil() {
-		voucher, appErr = s.srv.Discount.AlterVoucherUsage(*voucher, 1)
+		voucher, appErr = s.srv.Discount.AlterVoucherUsage(nil, *voucher, 1)
 		if appErr != nil {
 			return nil, nil, appErr
 		}
@@ -590,7 +590,7 @@ func (s *ServiceCheckout) createOrder(transaction boil.ContextTransactor, checko
 		additionalWarehouseLookup = checkoutInfo.DeliveryMethodInfo.GetWarehouseFilterLookup()
 	)
 
-	insufficientStockErr, appErr := s.srv.Warehouse.AllocateStocks(orderLinesInfo, countryCode, checkoutInfo.Channel.Slug, manager, additionalWarehouseLookup)
+	insufficientStockErr, appErr := s.srv.Warehouse.AllocateStocks(transaction, orderLinesInfo, countryCode, checkoutInfo.Channel.Slug, manager, additionalWarehouseLookup)
 	if insufficientStockErr != nil || appErr != nil {
 		return nil, insufficientStockErr, appErr
 	}
@@ -696,7 +696,7 @@ func (s *ServiceCheckout) prepareCheckout(transaction boil.ContextTransactor, ma
 }
 
 // ReleaseVoucherUsage
-func (s *ServiceCheckout) ReleaseVoucherUsage(orderData map[string]any) *model_helper.AppError {
+func (s *ServiceCheckout) ReleaseVoucherUsage(transaction boil.ContextTransactor, orderData map[string]any) *model_helper.AppError {
 	if iface, ok := orderData["voucher"]; ok && iface != nil {
 		var voucher model.Voucher
 
@@ -711,13 +711,13 @@ func (s *ServiceCheckout) ReleaseVoucherUsage(orderData map[string]any) *model_h
 		}
 
 		if !voucher.UsageLimit.IsNil() && *voucher.UsageLimit.Int != 0 {
-			savedVoucher, appErr := s.srv.Discount.AlterVoucherUsage(voucher, -1)
+			savedVoucher, appErr := s.srv.Discount.AlterVoucherUsage(transaction, voucher, -1)
 			if appErr != nil {
 				return appErr
 			}
 
 			if userEmail, ok := orderData["user_email"]; ok {
-				appErr = s.srv.Discount.RemoveVoucherUsageByCustomer(*savedVoucher, userEmail.(string))
+				appErr = s.srv.Discount.RemoveVoucherUsageByCustomer(transaction, *savedVoucher, userEmail.(string))
 				if appErr != nil {
 					return appErr
 				}
@@ -780,7 +780,7 @@ func (s *ServiceCheckout) processPayment(dbTransaction boil.ContextTransactor, p
 		return nil, nil, appErr
 	}
 	if paymentErr != nil {
-		appErr = s.ReleaseVoucherUsage(orderData)
+		appErr = s.ReleaseVoucherUsage(nil, orderData)
 		if appErr != nil {
 			return nil, nil, appErr
 		}
@@ -908,7 +908,7 @@ func (s *ServiceCheckout) CompleteCheckout(
 		}
 
 		if insufficientStockErr != nil {
-			appErr = s.ReleaseVoucherUsage(orderData)
+			appErr = s.ReleaseVoucherUsage(nil, orderData)
 			if appErr != nil {
 				return nil, false, nil, nil, appErr
 			}
@@ -931,6 +931,10 @@ func (s *ServiceCheckout) CompleteCheckout(
 		if appErr != nil {
 			return nil, false, nil, nil, appErr
 		}
+
+		if s.srv.Metrics != nil {
+			s.srv.Metrics.IncrementCheckoutCompleted(checkoutInfo.Channel.ID)
+		}
 	}
 
 	return orDer, transaction.ActionRequired, actionData, nil, nil
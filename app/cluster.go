@@ -31,3 +31,17 @@ func (s *Server) InvokeClusterLeaderChangedListeners() {
 		})
 	})
 }
+
+// ClusterStats returns stats of this server, which cluster peers gather
+func (s *Server) ClusterStats() *model_helper.ClusterStats {
+	stats := &model_helper.ClusterStats{}
+	if s.Cluster != nil {
+		stats.Id = s.Cluster.GetClusterId()
+	}
+	if s.sqlStore != nil {
+		stats.TotalMasterDbConnections = s.sqlStore.TotalMasterDbConnections()
+		stats.TotalReadDbConnections = s.sqlStore.TotalReadDbConnections()
+	}
+
+	return stats
+}
@@ -0,0 +1,412 @@
+/*
+NOTE: This package is initialized during server startup (modules/imports does that)
+so the init() function get the chance to register a function to create the cluster
+*/
+package cluster
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/pkg/errors"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/einterfaces"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/store"
+)
+
+const (
+	// rejoinInterval is how often nodes look for peers they are not connected to in cluster discoveries
+	rejoinInterval = app.DiscoveryServiceWritePing
+	// leaveTimeout is how long a leaving node waits for its leave message to spread
+	leaveTimeout = 5 * time.Second
+	// updateMetaTimeout is how long a node waits for updates of its meta data to spread
+	updateMetaTimeout = 10 * time.Second
+)
+
+// Cluster is a gossip based cluster of app servers. Peers find each other through cluster discoveries
+// saved in the database and the longest running node is the leader.
+type Cluster struct {
+	srv *app.Server
+	id  string
+
+	startStopMutex sync.Mutex
+
+	mutex      sync.RWMutex // guards fields below
+	list       *memberlist.Memberlist
+	discovery  *app.ClusterDiscoveryService
+	startedAt  int64
+	leaderID   string
+	stopRejoin chan struct{}
+	encrypted  bool // whether gossip of the memberlist is encrypted, which authenticates messages of other nodes
+
+	handlersMutex sync.RWMutex
+	handlers      map[model_helper.ClusterEvent]einterfaces.ClusterMessageHandler
+
+	pendingRequests sync.Map // keys are request ids, values are chan *model_helper.ClusterMessage
+}
+
+// nodeMeta is meta data every node gossips about itself
+type nodeMeta struct {
+	model_helper.ClusterInfo
+	StartedAt int64 `json:"started_at"`
+}
+
+func init() {
+	app.RegisterClusterInterface(func(s *app.Server) einterfaces.ClusterInterface {
+		return New(s)
+	})
+}
+
+func New(s *app.Server) *Cluster {
+	return &Cluster{
+		srv:      s,
+		id:       model_helper.NewId(),
+		handlers: map[model_helper.ClusterEvent]einterfaces.ClusterMessageHandler{},
+	}
+}
+
+var _ einterfaces.ClusterInterface = (*Cluster)(nil)
+var _ memberlist.Delegate = (*Cluster)(nil)
+var _ memberlist.EventDelegate = (*Cluster)(nil)
+
+// StartInterNodeCommunication creates the local gossip node, advertises it in cluster discoveries
+// and joins peers found there. It does nothing when clustering is disabled.
+func (c *Cluster) StartInterNodeCommunication() {
+	settings := c.srv.Config().ClusterSettings
+	if !*settings.Enable {
+		slog.Info("Clustering is disabled, skipped starting inter node communication")
+		return
+	}
+
+	c.startStopMutex.Lock()
+	defer c.startStopMutex.Unlock()
+
+	if c.memberlist() != nil {
+		return
+	}
+
+	discovery := c.srv.NewClusterDiscoveryService()
+	discovery.ID = c.id
+	discovery.Type = model_helper.CDS_TYPE_APP
+	discovery.ClusterName = *settings.ClusterName
+	discovery.GossipPort = *settings.GossipPort
+	discovery.Port = *settings.StreamingPort
+	if *settings.OverrideHostname != "" {
+		discovery.HostName = *settings.OverrideHostname
+	} else if *settings.UseIpAddress {
+		model_helper.ClusterDiscoveryAutoFillIpAddress(&discovery.ClusterDiscovery, *settings.NetworkInterface, *settings.AdvertiseAddress)
+	} else {
+		model_helper.ClusterDiscoveryAutoFillHostname(&discovery.ClusterDiscovery)
+	}
+
+	// meta data of the local node is read while creating the memberlist
+	c.mutex.Lock()
+	c.discovery = discovery
+	c.startedAt = model_helper.GetMillis()
+	c.mutex.Unlock()
+
+	conf := memberlist.DefaultLANConfig()
+	conf.Name = c.id
+	conf.BindPort = *settings.GossipPort
+	conf.AdvertisePort = *settings.GossipPort
+	if *settings.BindAddress != "" {
+		conf.BindAddr = *settings.BindAddress
+	}
+	if *settings.AdvertiseAddress != "" {
+		conf.AdvertiseAddr = *settings.AdvertiseAddress
+	}
+	conf.EnableCompression = *settings.EnableGossipCompression
+	conf.Delegate = c
+	conf.Events = c
+	conf.Logger = c.srv.Log.StdLogger(slog.LvlDebug)
+
+	if *settings.EnableExperimentalGossipEncryption {
+		key, err := c.encryptionKey()
+		if err != nil {
+			slog.Error("Failed to get cluster encryption key, inter node communication is not started", slog.Err(err))
+			return
+		}
+		conf.SecretKey = key
+	}
+
+	list, err := memberlist.Create(conf)
+	if err != nil {
+		slog.Error("Failed to start inter node communication", slog.Err(err))
+		return
+	}
+
+	stopRejoin := make(chan struct{})
+	c.mutex.Lock()
+	c.list = list
+	c.stopRejoin = stopRejoin
+	c.encrypted = conf.SecretKey != nil
+	c.mutex.Unlock()
+
+	discovery.Start()
+	c.electLeader()
+
+	c.srv.Go(func() {
+		c.joinPeers()
+
+		ticker := time.NewTicker(rejoinInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.joinPeers()
+			case <-stopRejoin:
+				return
+			}
+		}
+	})
+
+	slog.Info("Started inter node communication",
+		slog.String("cluster_id", c.id),
+		slog.String("cluster_name", *settings.ClusterName),
+		slog.String("hostname", discovery.HostName),
+		slog.Int("gossip_port", *settings.GossipPort),
+	)
+}
+
+// StopInterNodeCommunication leaves the cluster and removes the node from cluster discoveries
+func (c *Cluster) StopInterNodeCommunication() {
+	c.startStopMutex.Lock()
+	defer c.startStopMutex.Unlock()
+
+	c.mutex.Lock()
+	list, discovery := c.list, c.discovery
+	if list == nil {
+		c.mutex.Unlock()
+		return
+	}
+	close(c.stopRejoin)
+	c.list = nil
+	c.leaderID = ""
+	c.encrypted = false
+	c.mutex.Unlock()
+
+	// the discovery's ping writer is not running if saving the discovery failed, so don't wait for it
+	go discovery.Stop()
+
+	if err := list.Leave(leaveTimeout); err != nil {
+		slog.Warn("Failed to leave the cluster gracefully", slog.Err(err))
+	}
+	if err := list.Shutdown(); err != nil {
+		slog.Warn("Failed to shutdown inter node communication", slog.Err(err))
+	}
+
+	slog.Info("Stopped inter node communication", slog.String("cluster_id", c.id))
+}
+
+// joinPeers joins nodes of the cluster found in cluster discoveries, which the local node does not know yet
+func (c *Cluster) joinPeers() {
+	list := c.memberlist()
+	if list == nil {
+		return
+	}
+
+	discoveries, err := c.srv.Store.ClusterDiscovery().GetAll(model_helper.CDS_TYPE_APP, *c.srv.Config().ClusterSettings.ClusterName)
+	if err != nil {
+		slog.Error("Failed to find cluster discoveries", slog.Err(err))
+		return
+	}
+
+	known := map[string]bool{}
+	for _, member := range list.Members() {
+		known[member.Name] = true
+	}
+
+	var addresses []string
+	for _, discovery := range discoveries {
+		if known[discovery.ID] {
+			continue
+		}
+		addresses = append(addresses, net.JoinHostPort(discovery.HostName, strconv.Itoa(discovery.GossipPort)))
+	}
+	if len(addresses) == 0 {
+		return
+	}
+
+	joined, err := list.Join(addresses)
+	if err != nil {
+		slog.Warn("Failed to join some cluster nodes", slog.Int("joined", joined), slog.Any("addresses", addresses), slog.Err(err))
+	}
+}
+
+// encryptionKey returns the key encrypting gossip of the cluster, creating it on first use
+func (c *Cluster) encryptionKey() ([]byte, error) {
+	system, err := c.srv.Store.System().GetByName(model_helper.SystemClusterEncryptionKey)
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); !ok {
+			return nil, errors.Wrap(err, "failed to find cluster encryption key")
+		}
+
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, errors.Wrap(err, "failed to generate cluster encryption key")
+		}
+
+		// other nodes may be creating the key at the same time, the first one wins
+		system, err = c.srv.Store.System().InsertIfExists(model.System{
+			Name:  model_helper.SystemClusterEncryptionKey,
+			Value: base64.StdEncoding.EncodeToString(key),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to save cluster encryption key")
+		}
+	}
+
+	return base64.StdEncoding.DecodeString(system.Value)
+}
+
+func (c *Cluster) memberlist() *memberlist.Memberlist {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.list
+}
+
+// otherMembers returns alive nodes of the cluster, except the local node
+func (c *Cluster) otherMembers() []*memberlist.Node {
+	list := c.memberlist()
+	if list == nil {
+		return nil
+	}
+
+	var nodes []*memberlist.Node
+	for _, member := range list.Members() {
+		if member.Name != c.id {
+			nodes = append(nodes, member)
+		}
+	}
+	return nodes
+}
+
+func (c *Cluster) GetClusterId() string {
+	return c.id
+}
+
+// IsLeader tells if the local node is the leader of the cluster. With clustering disabled the node leads itself,
+// otherwise a node not communicating with peers can't know who leads, so it is not the leader.
+func (c *Cluster) IsLeader() bool {
+	if !*c.srv.Config().ClusterSettings.Enable {
+		return true
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.list != nil && c.leaderID == c.id
+}
+
+// electLeader makes the longest running alive node the leader, and notifies leader changed listeners
+// when the leader is different than before.
+func (c *Cluster) electLeader() {
+	list := c.memberlist()
+	if list == nil {
+		return
+	}
+
+	var (
+		leaderID        string
+		leaderStartedAt int64
+	)
+	for _, member := range list.Members() {
+		meta, err := decodeNodeMeta(member.Meta)
+		if err != nil {
+			slog.Warn("Failed to decode meta data of cluster node", slog.String("node", member.Name), slog.Err(err))
+			continue
+		}
+		if leaderID == "" || meta.StartedAt < leaderStartedAt || (meta.StartedAt == leaderStartedAt && member.Name < leaderID) {
+			leaderID = member.Name
+			leaderStartedAt = meta.StartedAt
+		}
+	}
+
+	c.mutex.Lock()
+	changed := c.leaderID != leaderID
+	c.leaderID = leaderID
+	c.mutex.Unlock()
+
+	if changed {
+		slog.Info("Cluster leader elected", slog.String("leader_id", leaderID), slog.Any("is_leader", leaderID == c.id))
+		c.srv.InvokeClusterLeaderChangedListeners()
+	}
+}
+
+func (c *Cluster) HealthScore() int {
+	list := c.memberlist()
+	if list == nil {
+		return 0
+	}
+	return list.GetHealthScore()
+}
+
+func (c *Cluster) GetMyClusterInfo() *model_helper.ClusterInfo {
+	info := &model_helper.ClusterInfo{
+		Id:         c.id,
+		Version:    model_helper.CurrentVersion,
+		ConfigHash: configHash(c.srv.Config()),
+		IpAddress:  model_helper.GetServerIpAddress(*c.srv.Config().ClusterSettings.NetworkInterface),
+	}
+
+	c.mutex.RLock()
+	if c.discovery != nil {
+		info.Hostname = c.discovery.HostName
+	}
+	c.mutex.RUnlock()
+
+	return info
+}
+
+// GetClusterInfos returns infos of all alive nodes of the cluster, including the local node
+func (c *Cluster) GetClusterInfos() []*model_helper.ClusterInfo {
+	list := c.memberlist()
+	if list == nil {
+		return []*model_helper.ClusterInfo{c.GetMyClusterInfo()}
+	}
+
+	var infos []*model_helper.ClusterInfo
+	for _, member := range list.Members() {
+		if member.Name == c.id {
+			infos = append(infos, c.GetMyClusterInfo())
+			continue
+		}
+
+		meta, err := decodeNodeMeta(member.Meta)
+		if err != nil {
+			slog.Warn("Failed to decode meta data of cluster node", slog.String("node", member.Name), slog.Err(err))
+			continue
+		}
+		info := meta.ClusterInfo
+		info.IpAddress = member.Addr.String()
+		infos = append(infos, &info)
+	}
+	return infos
+}
+
+func decodeNodeMeta(data []byte) (*nodeMeta, error) {
+	var meta nodeMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// configHash lets nodes tell if they are running the same config
+func configHash(cfg *model_helper.Config) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", md5.Sum(data))
+}
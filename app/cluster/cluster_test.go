@@ -0,0 +1,162 @@
+package cluster
+
+import (
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecretKey = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestServer(t *testing.T) *app.Server {
+	t.Helper()
+
+	memoryStore, err := config.NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := config.NewStoreFromBacking(memoryStore, nil, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { configStore.Close() })
+
+	cfg := configStore.Get().Clone()
+	cfg.ClusterSettings.Enable = model_helper.GetPointerOfValue(true)
+	cfg.ClusterSettings.ReadOnlyConfig = model_helper.GetPointerOfValue(false)
+	_, _, err = configStore.Set(cfg)
+	require.NoError(t, err)
+
+	return &app.Server{
+		ConfigStore: configStore,
+		Log:         slog.CreateConsoleTestLogger(false, slog.LvlError),
+	}
+}
+
+// newTestNode starts gossip of a node on the loopback interface, without cluster discoveries
+func newTestNode(t *testing.T, startedAt int64, secretKey []byte) *Cluster {
+	t.Helper()
+
+	c := New(newTestServer(t))
+
+	conf := memberlist.DefaultLocalConfig()
+	conf.Name = c.id
+	conf.BindAddr = "127.0.0.1"
+	conf.BindPort = 0
+	conf.Delegate = c
+	conf.Events = c
+	conf.SecretKey = secretKey
+	conf.Logger = log.New(io.Discard, "", 0)
+
+	c.mutex.Lock()
+	c.startedAt = startedAt
+	c.mutex.Unlock()
+
+	list, err := memberlist.Create(conf)
+	require.NoError(t, err)
+	t.Cleanup(func() { list.Shutdown() })
+
+	c.mutex.Lock()
+	c.list = list
+	c.stopRejoin = make(chan struct{})
+	c.encrypted = secretKey != nil
+	c.mutex.Unlock()
+	c.electLeader()
+
+	return c
+}
+
+func join(t *testing.T, c, other *Cluster) {
+	t.Helper()
+
+	node := other.memberlist().LocalNode()
+	_, err := c.memberlist().Join([]string{net.JoinHostPort(node.Addr.String(), strconv.Itoa(int(node.Port)))})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(c.otherMembers()) == 1 && len(other.otherMembers()) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestIsLeader(t *testing.T) {
+	t.Run("clustering disabled", func(t *testing.T) {
+		c := New(newTestServer(t))
+		cfg := c.srv.Config().Clone()
+		cfg.ClusterSettings.Enable = model_helper.GetPointerOfValue(false)
+		_, _, err := c.srv.ConfigStore.Set(cfg)
+		require.NoError(t, err)
+		require.True(t, c.IsLeader())
+	})
+
+	t.Run("gossip not started", func(t *testing.T) {
+		c := New(newTestServer(t))
+		require.False(t, c.IsLeader())
+	})
+
+	t.Run("single node", func(t *testing.T) {
+		c := newTestNode(t, 1, nil)
+		require.True(t, c.IsLeader())
+	})
+}
+
+func TestElectLeader(t *testing.T) {
+	oldest := newTestNode(t, 1, nil)
+	newest := newTestNode(t, 2, nil)
+	require.True(t, newest.IsLeader())
+
+	join(t, newest, oldest)
+	require.Eventually(t, func() bool {
+		return oldest.IsLeader() && !newest.IsLeader()
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, oldest.memberlist().Leave(time.Second))
+	require.NoError(t, oldest.memberlist().Shutdown())
+	require.Eventually(t, newest.IsLeader, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestConfigChanged(t *testing.T) {
+	siteURL := "http://changed.example.com"
+
+	t.Run("unencrypted gossip", func(t *testing.T) {
+		sender := newTestNode(t, 1, nil)
+		receiver := newTestNode(t, 2, nil)
+		join(t, sender, receiver)
+
+		cfg := sender.srv.Config().Clone()
+		cfg.ServiceSettings.SiteURL = &siteURL
+		require.Nil(t, sender.ConfigChanged(sender.srv.Config(), cfg, true))
+		require.NotEqual(t, siteURL, *receiver.srv.Config().ServiceSettings.SiteURL)
+
+		appErr := receiver.saveConfigFromNode([]byte(`{}`))
+		require.NotNil(t, appErr)
+		require.Equal(t, "ent.cluster.save_config.unencrypted.error", appErr.Id)
+	})
+
+	t.Run("encrypted gossip", func(t *testing.T) {
+		sender := newTestNode(t, 1, testSecretKey)
+		receiver := newTestNode(t, 2, testSecretKey)
+		join(t, sender, receiver)
+
+		cfg := sender.srv.Config().Clone()
+		cfg.ServiceSettings.SiteURL = &siteURL
+		require.Nil(t, sender.ConfigChanged(sender.srv.Config(), cfg, true))
+		require.Equal(t, siteURL, *receiver.srv.Config().ServiceSettings.SiteURL)
+	})
+}
+
+func TestRequest(t *testing.T) {
+	c := newTestNode(t, 1, nil)
+	other := newTestNode(t, 2, nil)
+	join(t, c, other)
+
+	responses := c.request(model_helper.ClusterGossipEventRequestSaveConfig, []byte(`{}`), nil)
+	require.Len(t, responses, 1)
+	require.Equal(t, model_helper.ClusterGossipEventResponseSaveConfig, responses[0].Event)
+	require.Equal(t, other.id, responses[0].Props[propSenderID])
+	require.NotEmpty(t, responses[0].Props[propError])
+}
@@ -0,0 +1,149 @@
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/sitename/sitename/einterfaces"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+// maxBestEffortMessageSize is the largest message sent over UDP, larger best effort messages
+// are sent reliably since they would not fit into a single packet.
+const maxBestEffortMessageSize = 1024
+
+var errNotCommunicating = errors.New("inter node communication is not started")
+
+func (c *Cluster) RegisterClusterMessageHandler(event model_helper.ClusterEvent, crm einterfaces.ClusterMessageHandler) {
+	c.handlersMutex.Lock()
+	defer c.handlersMutex.Unlock()
+	c.handlers[event] = crm
+}
+
+// SendClusterMessage sends given message to all other nodes of the cluster. Reliable messages are sent over TCP
+// and best effort ones over UDP. The message is sent in background unless msg.WaitForAllToSend is true.
+func (c *Cluster) SendClusterMessage(msg *model_helper.ClusterMessage) {
+	nodes := c.otherMembers()
+	if len(nodes) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("Failed to encode cluster message", slog.String("event", string(msg.Event)), slog.Err(err))
+		return
+	}
+	reliable := msg.SendType == model_helper.ClusterSendReliable || len(data) > maxBestEffortMessageSize
+
+	send := func() {
+		for _, node := range nodes {
+			if err := c.sendToNode(node, data, reliable); err != nil {
+				slog.Warn("Failed to send cluster message", slog.String("event", string(msg.Event)), slog.String("node", node.Name), slog.Err(err))
+			}
+		}
+	}
+
+	if msg.WaitForAllToSend {
+		send()
+		return
+	}
+	c.srv.Go(send)
+}
+
+func (c *Cluster) sendToNode(node *memberlist.Node, data []byte, reliable bool) error {
+	list := c.memberlist()
+	if list == nil {
+		return errNotCommunicating
+	}
+
+	if reliable {
+		return list.SendReliable(node, data)
+	}
+	return list.SendBestEffort(node, data)
+}
+
+// NotifyMsg is called when a message from other nodes is received
+func (c *Cluster) NotifyMsg(buf []byte) {
+	var msg model_helper.ClusterMessage
+	if err := json.Unmarshal(buf, &msg); err != nil {
+		slog.Warn("Failed to decode cluster message", slog.Err(err))
+		return
+	}
+
+	if c.srv.Metrics != nil {
+		c.srv.Metrics.IncrementClusterEventType(string(msg.Event))
+	}
+
+	switch msg.Event {
+	case model_helper.ClusterGossipEventRequestGetClusterStats,
+		model_helper.ClusterGossipEventRequestGetLogs,
+		model_helper.ClusterGossipEventRequestGetPluginStatuses,
+		model_helper.ClusterGossipEventRequestSaveConfig:
+		c.srv.Go(func() {
+			c.handleRequest(&msg)
+		})
+
+	case model_helper.ClusterGossipEventResponseGetClusterStats,
+		model_helper.ClusterGossipEventResponseGetLogs,
+		model_helper.ClusterGossipEventResponseGetPluginStatuses,
+		model_helper.ClusterGossipEventResponseSaveConfig:
+		c.handleResponse(&msg)
+
+	default:
+		c.handlersMutex.RLock()
+		handler, ok := c.handlers[msg.Event]
+		c.handlersMutex.RUnlock()
+
+		if !ok {
+			slog.Debug("No handler registered for cluster message", slog.String("event", string(msg.Event)))
+			return
+		}
+
+		// handlers must not block the gossip
+		c.srv.Go(func() {
+			handler(&msg)
+		})
+	}
+}
+
+// NodeMeta returns meta data of the local node, gossiped to other nodes
+func (c *Cluster) NodeMeta(limit int) []byte {
+	c.mutex.RLock()
+	meta := nodeMeta{StartedAt: c.startedAt}
+	c.mutex.RUnlock()
+	meta.ClusterInfo = *c.GetMyClusterInfo()
+
+	data, err := json.Marshal(meta)
+	if err == nil && len(data) > limit {
+		meta.Hostname = ""
+		data, err = json.Marshal(meta)
+	}
+	if err != nil || len(data) > limit {
+		slog.Error("Failed to encode meta data of the cluster node", slog.Int("limit", limit), slog.Err(err))
+		return nil
+	}
+
+	return data
+}
+
+func (c *Cluster) GetBroadcasts(overhead, limit int) [][]byte { return nil }
+
+func (c *Cluster) LocalState(join bool) []byte { return nil }
+
+func (c *Cluster) MergeRemoteState(buf []byte, join bool) {}
+
+// memberlist holds its locks while notifying events, so leaders are elected in background
+
+func (c *Cluster) NotifyJoin(node *memberlist.Node) {
+	slog.Info("Node joined the cluster", slog.String("node", node.Name), slog.String("address", node.Address()))
+	c.srv.Go(c.electLeader)
+}
+
+func (c *Cluster) NotifyLeave(node *memberlist.Node) {
+	slog.Info("Node left the cluster", slog.String("node", node.Name), slog.String("address", node.Address()))
+	c.srv.Go(c.electLeader)
+}
+
+func (c *Cluster) NotifyUpdate(node *memberlist.Node) {}
@@ -0,0 +1,277 @@
+package cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+// requestTimeout is how long a node waits for other nodes to answer its requests.
+// Answers of nodes not responding in time are left out.
+const requestTimeout = 15 * time.Second
+
+const (
+	propRequestID = "request_id"
+	propSenderID  = "sender_id"
+	propError     = "error"
+	propPage      = "page"
+	propPerPage   = "per_page"
+)
+
+var requestResponseEvents = map[model_helper.ClusterEvent]model_helper.ClusterEvent{
+	model_helper.ClusterGossipEventRequestGetClusterStats:   model_helper.ClusterGossipEventResponseGetClusterStats,
+	model_helper.ClusterGossipEventRequestGetLogs:           model_helper.ClusterGossipEventResponseGetLogs,
+	model_helper.ClusterGossipEventRequestGetPluginStatuses: model_helper.ClusterGossipEventResponseGetPluginStatuses,
+	model_helper.ClusterGossipEventRequestSaveConfig:        model_helper.ClusterGossipEventResponseSaveConfig,
+}
+
+// GetClusterStats returns stats of other nodes of the cluster
+func (c *Cluster) GetClusterStats() ([]*model_helper.ClusterStats, *model_helper.AppError) {
+	responses := c.request(model_helper.ClusterGossipEventRequestGetClusterStats, nil, nil)
+
+	var stats []*model_helper.ClusterStats
+	for _, response := range responses {
+		var nodeStats model_helper.ClusterStats
+		if err := json.Unmarshal(response.Data, &nodeStats); err != nil {
+			return nil, model_helper.NewAppError("GetClusterStats", "ent.cluster.json_decode.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		stats = append(stats, &nodeStats)
+	}
+	return stats, nil
+}
+
+// GetLogs returns given page of log lines of other nodes of the cluster
+func (c *Cluster) GetLogs(page, perPage int) ([]string, *model_helper.AppError) {
+	responses := c.request(model_helper.ClusterGossipEventRequestGetLogs, nil, map[string]string{
+		propPage:    strconv.Itoa(page),
+		propPerPage: strconv.Itoa(perPage),
+	})
+
+	var lines []string
+	for _, response := range responses {
+		var nodeLines []string
+		if err := json.Unmarshal(response.Data, &nodeLines); err != nil {
+			return nil, model_helper.NewAppError("GetLogs", "ent.cluster.json_decode.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		lines = append(lines, nodeLines...)
+	}
+	return lines, nil
+}
+
+// GetPluginStatuses returns statuses of plugins installed on other nodes of the cluster
+func (c *Cluster) GetPluginStatuses() (model_helper.PluginStatuses, *model_helper.AppError) {
+	responses := c.request(model_helper.ClusterGossipEventRequestGetPluginStatuses, nil, nil)
+
+	var statuses model_helper.PluginStatuses
+	for _, response := range responses {
+		var nodeStatuses model_helper.PluginStatuses
+		if err := json.Unmarshal(response.Data, &nodeStatuses); err != nil {
+			return nil, model_helper.NewAppError("GetPluginStatuses", "ent.cluster.json_decode.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		statuses = append(statuses, nodeStatuses...)
+	}
+	return statuses, nil
+}
+
+// ConfigChanged gossips the new config to other nodes of the cluster, which save it too.
+// The config can't be changed from a node when the cluster is configured read only.
+func (c *Cluster) ConfigChanged(previousConfig *model_helper.Config, newConfig *model_helper.Config, sendToOtherServer bool) *model_helper.AppError {
+	list := c.memberlist()
+	if list == nil {
+		return nil
+	}
+
+	// other nodes see the new config hash in meta data of this node
+	c.srv.Go(func() {
+		if err := list.UpdateNode(updateMetaTimeout); err != nil {
+			slog.Warn("Failed to update meta data of the cluster node", slog.Err(err))
+		}
+	})
+
+	if !sendToOtherServer {
+		return nil
+	}
+	if *newConfig.ClusterSettings.ReadOnlyConfig {
+		return model_helper.NewAppError("ConfigChanged", "ent.cluster.save_config.error", nil, "", http.StatusForbidden)
+	}
+
+	data, err := json.Marshal(newConfig)
+	if err != nil {
+		return model_helper.NewAppError("ConfigChanged", "ent.cluster.json_encode.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, response := range c.request(model_helper.ClusterGossipEventRequestSaveConfig, data, nil) {
+		if response.Props[propError] != "" {
+			slog.Error("Failed to save config on cluster node", slog.String("node", response.Props[propSenderID]), slog.String("error", response.Props[propError]))
+		}
+	}
+	return nil
+}
+
+// request sends given request to other nodes of the cluster, then waits for their responses
+func (c *Cluster) request(event model_helper.ClusterEvent, data []byte, props map[string]string) []*model_helper.ClusterMessage {
+	nodes := c.otherMembers()
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	requestID := model_helper.NewId()
+	responsesChan := make(chan *model_helper.ClusterMessage, len(nodes))
+	c.pendingRequests.Store(requestID, responsesChan)
+	defer c.pendingRequests.Delete(requestID)
+
+	msg := &model_helper.ClusterMessage{
+		Event: event,
+		Data:  data,
+		Props: map[string]string{
+			propRequestID: requestID,
+			propSenderID:  c.id,
+		},
+	}
+	for key, value := range props {
+		msg.Props[key] = value
+	}
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("Failed to encode cluster request", slog.String("event", string(event)), slog.Err(err))
+		return nil
+	}
+
+	if c.srv.Metrics != nil {
+		c.srv.Metrics.IncrementClusterRequest()
+		start := time.Now()
+		defer func() {
+			c.srv.Metrics.ObserveClusterRequestDuration(time.Since(start).Seconds())
+		}()
+	}
+
+	waitFor := 0
+	for _, node := range nodes {
+		if err := c.sendToNode(node, buf, true); err != nil {
+			slog.Warn("Failed to send cluster request", slog.String("event", string(event)), slog.String("node", node.Name), slog.Err(err))
+			continue
+		}
+		waitFor++
+	}
+
+	timeout := time.NewTimer(requestTimeout)
+	defer timeout.Stop()
+
+	var responses []*model_helper.ClusterMessage
+	for len(responses) < waitFor {
+		select {
+		case response := <-responsesChan:
+			if response.Props[propError] != "" {
+				slog.Warn("Cluster node failed to answer request", slog.String("event", string(event)), slog.String("node", response.Props[propSenderID]), slog.String("error", response.Props[propError]))
+				waitFor--
+				continue
+			}
+			responses = append(responses, response)
+		case <-timeout.C:
+			slog.Warn("Timed out waiting for cluster nodes to answer request", slog.String("event", string(event)), slog.Int("answered", len(responses)), slog.Int("asked", waitFor))
+			return responses
+		}
+	}
+	return responses
+}
+
+// handleRequest answers requests from other nodes
+func (c *Cluster) handleRequest(msg *model_helper.ClusterMessage) {
+	var (
+		data   any
+		appErr *model_helper.AppError
+	)
+
+	switch msg.Event {
+	case model_helper.ClusterGossipEventRequestGetClusterStats:
+		data = c.srv.ClusterStats()
+
+	case model_helper.ClusterGossipEventRequestGetLogs:
+		page, _ := strconv.Atoi(msg.Props[propPage])
+		perPage, _ := strconv.Atoi(msg.Props[propPerPage])
+		data, appErr = c.srv.GetLogsSkipSend(page, perPage)
+
+	case model_helper.ClusterGossipEventRequestGetPluginStatuses:
+		data, appErr = c.srv.Plugin.GetPluginStatuses()
+
+	case model_helper.ClusterGossipEventRequestSaveConfig:
+		appErr = c.saveConfigFromNode(msg.Data)
+	}
+
+	response := &model_helper.ClusterMessage{
+		Event: requestResponseEvents[msg.Event],
+		Props: map[string]string{
+			propRequestID: msg.Props[propRequestID],
+			propSenderID:  c.id,
+		},
+	}
+	if appErr != nil {
+		response.Props[propError] = appErr.Error()
+	} else if data != nil {
+		buf, err := json.Marshal(data)
+		if err != nil {
+			response.Props[propError] = err.Error()
+		}
+		response.Data = buf
+	}
+
+	buf, err := json.Marshal(response)
+	if err != nil {
+		slog.Error("Failed to encode cluster response", slog.String("event", string(response.Event)), slog.Err(err))
+		return
+	}
+
+	for _, node := range c.otherMembers() {
+		if node.Name != msg.Props[propSenderID] {
+			continue
+		}
+		if err := c.sendToNode(node, buf, true); err != nil {
+			slog.Warn("Failed to send cluster response", slog.String("event", string(response.Event)), slog.String("node", node.Name), slog.Err(err))
+		}
+		return
+	}
+}
+
+// saveConfigFromNode applies config saved on another node. Configs stored in the database are shared by nodes,
+// so they are reloaded from there and the sent config is ignored. Other configs are saved from the message only
+// when gossip is encrypted, since messages of unencrypted gossip may come from anyone reaching the gossip port.
+func (c *Cluster) saveConfigFromNode(data []byte) *model_helper.AppError {
+	if config.IsDatabaseDSN(c.srv.ConfigStore.String()) {
+		if err := c.srv.ReloadConfig(); err != nil {
+			return model_helper.NewAppError("saveConfigFromNode", "ent.cluster.reload_config.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return nil
+	}
+
+	c.mutex.RLock()
+	encrypted := c.encrypted
+	c.mutex.RUnlock()
+	if !encrypted {
+		return model_helper.NewAppError("saveConfigFromNode", "ent.cluster.save_config.unencrypted.error", nil, "", http.StatusForbidden)
+	}
+
+	var cfg model_helper.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return model_helper.NewAppError("saveConfigFromNode", "ent.cluster.json_decode.error", nil, err.Error(), http.StatusBadRequest)
+	}
+	_, _, appErr := c.srv.SaveConfig(&cfg, false)
+	return appErr
+}
+
+// handleResponse passes responses from other nodes to the requests waiting for them
+func (c *Cluster) handleResponse(msg *model_helper.ClusterMessage) {
+	value, ok := c.pendingRequests.Load(msg.Props[propRequestID])
+	if !ok {
+		return // the request timed out
+	}
+
+	select {
+	case value.(chan *model_helper.ClusterMessage) <- msg:
+	default:
+	}
+}
@@ -1,41 +1,222 @@
 package csv
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"time"
 
 	"github.com/mattermost/squirrel"
 	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	// exportFilesDir is directory of the file store that exported files are written into
+	exportFilesDir = "export_files"
+
+	defaultExportDelimiter = ";"
 )
 
 var (
 	productFetchBatchSize uint64 = 10000
 )
 
-func (s *ServiceCsv) ExportProducts(input model_helper.ExportProductsFilterOptions, delimeter string) *model_helper.AppError {
-	// if delimeter == "" {
-	// 	delimeter = ";"
-	// }
+// StartProductsExport validates given input, creates an export file with a pending event for it,
+// then schedules a job that exports the products. Either userID or appID should be provided.
+func (s *ServiceCsv) StartProductsExport(input model_helper.ExportProductsFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError) {
+	appErr := validateExportProductsInput(input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.startExport("products", input, userID, appID)
+}
+
+// StartOrdersExport validates given input, then schedules a job that exports orders with their lines,
+// payments and fulfillments. Either userID or appID should be provided.
+func (s *ServiceCsv) StartOrdersExport(input model_helper.ExportOrdersFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError) {
+	appErr := validateExportOrdersInput(input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.startExport("orders", input, userID, appID)
+}
+
+// StartCustomersExport validates given input, then schedules a job that exports customers with their
+// addresses and order counts. Either userID or appID should be provided.
+func (s *ServiceCsv) StartCustomersExport(input model_helper.ExportCustomersFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError) {
+	appErr := validateExportCustomersInput(input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.startExport("customers", input, userID, appID)
+}
+
+// StartGiftcardsExport validates given input, then schedules a job that exports giftcards.
+// Either userID or appID should be provided.
+func (s *ServiceCsv) StartGiftcardsExport(input model_helper.ExportGiftcardsFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError) {
+	appErr := validateExportGiftcardsInput(input)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.startExport("giftcards", input, userID, appID)
+}
+
+func (s *ServiceCsv) startExport(dataType string, input any, userID, appID string) (*model.ExportFile, *model_helper.AppError) {
+	jsonInput, err := json.Marshal(input)
+	if err != nil {
+		return nil, model_helper.NewAppError("startExport", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "input"}, err.Error(), http.StatusBadRequest)
+	}
+
+	exportFile := model.ExportFile{}
+	if userID != "" {
+		exportFile.UserID = model_types.NewNullString(userID)
+	}
+	if appID != "" {
+		exportFile.AppID = model_types.NewNullString(appID)
+	}
+
+	createdFile, appErr := s.CreateExportFile(exportFile)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	appErr = s.recordExportEvent(*createdFile, model.ExportEventTypeExportPending, nil)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	_, appErr = s.srv.Jobs.CreateJob(model.JobTypeExportProcess, map[string]any{
+		"export_file_id": createdFile.ID,
+		"data_type":      dataType,
+		"input":          string(jsonInput),
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return createdFile, nil
+}
+
+// ProcessExportJob runs the export scheduled by given export job
+func (s *ServiceCsv) ProcessExportJob(job model.Job) *model_helper.AppError {
+	exportFileID, _ := job.Data.Get("export_file_id", "").(string)
+	dataType, _ := job.Data.Get("data_type", "").(string)
+	input, _ := job.Data.Get("input", "").(string)
 
-	// productFilterQuery := s.srv.Store.Product().AdvancedFilterQueryBuilder(input)
+	exportFile, appErr := s.ExportFileById(exportFileID)
+	if appErr != nil {
+		return appErr
+	}
+
+	switch dataType {
+	case "products":
+		var options model_helper.ExportProductsFilterOptions
+		err := json.Unmarshal([]byte(input), &options)
+		if err != nil {
+			return model_helper.NewAppError("ProcessExportJob", "app.csv.invalid_export_job_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return s.ExportProducts(*exportFile, options, defaultExportDelimiter)
+
+	case "orders":
+		var options model_helper.ExportOrdersFilterOptions
+		err := json.Unmarshal([]byte(input), &options)
+		if err != nil {
+			return model_helper.NewAppError("ProcessExportJob", "app.csv.invalid_export_job_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return s.ExportOrders(*exportFile, options, defaultExportDelimiter)
+
+	case "customers":
+		var options model_helper.ExportCustomersFilterOptions
+		err := json.Unmarshal([]byte(input), &options)
+		if err != nil {
+			return model_helper.NewAppError("ProcessExportJob", "app.csv.invalid_export_job_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return s.ExportCustomers(*exportFile, options, defaultExportDelimiter)
+
+	case "giftcards":
+		var options model_helper.ExportGiftcardsFilterOptions
+		err := json.Unmarshal([]byte(input), &options)
+		if err != nil {
+			return model_helper.NewAppError("ProcessExportJob", "app.csv.invalid_export_job_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return s.ExportGiftcards(*exportFile, options, defaultExportDelimiter)
+
+	case userDataExportType:
+		return s.ExportUserData(*exportFile)
+
+	default:
+		return model_helper.NewAppError("ProcessExportJob", "app.csv.invalid_export_job_data.app_error", nil, "unknown export data type: "+dataType, http.StatusInternalServerError)
+	}
+}
+
+// ExportProducts is called by export job, it writes products selected by given input into the export file,
+// then records export events and notifies requestor of the export about the result.
+func (s *ServiceCsv) ExportProducts(exportFile model.ExportFile, input model_helper.ExportProductsFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := s.exportProducts(exportFile, input, delimiter)
+	if appErr != nil {
+		s.handleExportFailure(exportFile, "products", appErr)
+		return appErr
+	}
+	return nil
+}
+
+func (s *ServiceCsv) exportProducts(exportFile model.ExportFile, input model_helper.ExportProductsFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := validateExportProductsInput(input)
+	if appErr != nil {
+		return appErr
+	}
+
+	exportInfo := lo.FromPtr(input.ExportInfo)
+	exportFields, fileHeaders, dataHeaders, appErr := s.GetExportFieldsAndHeadersInfo(exportInfo)
+	if appErr != nil {
+		return appErr
+	}
 
-	// exportFields, fileHeaders, dataHeaders, appErr := s.GetExportFieldsAndHeadersInfo(*input.ExportInfo)
-	// if appErr != nil {
-	// 	return appErr
-	// }
+	productQuery, err := s.srv.Store.Product().AdvancedFilterQueryBuilder(input)
+	if err != nil {
+		return model_helper.NewAppError("ExportProducts", "app.csv.filter_products.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	productQuery = productQuery.OrderBy(model.ProductTableColumns.CreatedAt, model.ProductTableColumns.ID)
+
+	filePath := filepath.Join(exportFilesDir, getFileName("product", input.FileType))
+	writer := s.newExportWriter(input.FileType, filePath, delimiter)
+	defer writer.Discard()
+
+	appErr = writer.WriteRows([][]string{fileHeaders})
+	if appErr != nil {
+		return appErr
+	}
 
-	// getFileName("product", strings.ToLower(string(input.FileType)))
+	appErr = s.exportProductsInBatches(productQuery, exportInfo, exportFields, dataHeaders, writer)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = writer.Close()
+	if appErr != nil {
+		return appErr
+	}
 
-	panic("not implemented")
+	return s.finishExport(exportFile, "products", filePath)
 }
 
-// NOTE: ordering by created_at should be applied to `productQuery`
-func (s *ServiceCsv) ExportProductsInBatches(
+// exportProductsInBatches fetches products selected by given query in batches and writes their data rows
+// into given writer. Values of each row are ordered by given headers.
+//
+// NOTE: ordering by created_at, id should be applied to `productQuery`
+func (s *ServiceCsv) exportProductsInBatches(
 	productQuery squirrel.SelectBuilder,
-	ExportInfo struct {
+	exportInfo struct {
 		Attributes []string
 		Warehouses []string
 		Channels   []string
@@ -43,64 +224,154 @@ func (s *ServiceCsv) ExportProductsInBatches(
 	},
 	exportFields []string,
 	headers []string,
-	delimiter string,
-	fileType string,
+	writer exportWriter,
 ) *model_helper.AppError {
-	var createAtGt int64 = 0
+	relations := productExportRelations(exportFields, exportInfo.Attributes, exportInfo.Warehouses, exportInfo.Channels)
+
+	var (
+		lastCreatedAt int64
+		lastID        string
+	)
 
 	for {
-		products, err := s.srv.Store.
-			Product().
-			FilterByQuery(
-				productQuery.
-					Where(squirrel.Gt{
-						model.ProductTableColumns.CreatedAt: createAtGt,
-					}).
-					Limit(productFetchBatchSize),
+		query := productQuery.Limit(productFetchBatchSize)
+		if lastID != "" {
+			query = query.Where(
+				fmt.Sprintf("(%s, %s) > (?, ?)", model.ProductTableColumns.CreatedAt, model.ProductTableColumns.ID),
+				lastCreatedAt, lastID,
 			)
+		}
+
+		products, err := s.srv.Store.Product().FilterByQuery(query)
 		if err != nil {
 			return model_helper.NewAppError("ExportProductsInBatches", "app.csv.error_finding_products_by_query.app_error", nil, err.Error(), http.StatusInternalServerError)
 		}
-
 		if len(products) == 0 {
-			break
+			return nil
+		}
+
+		lastCreatedAt = products[len(products)-1].CreatedAt
+		lastID = products[len(products)-1].ID
+
+		conditions := []qm.QueryMod{
+			model.ProductWhere.ID.IN(lo.Map(products, func(p *model.Product, _ int) string { return p.ID })),
+			qm.OrderBy(model.ProductColumns.CreatedAt + ", " + model.ProductColumns.ID),
+		}
+		products, err = s.srv.Store.Product().FilterByOption(model_helper.ProductFilterOption{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(append(conditions, relations...)...),
+		})
+		if err != nil {
+			return model_helper.NewAppError("ExportProductsInBatches", "app.csv.error_finding_products_by_query.app_error", nil, err.Error(), http.StatusInternalServerError)
 		}
 
-		// reset for later loop(s)
-		createAtGt = products[len(products)-1].CreatedAt
-
-		productIDs := lo.Map(products, func(p *model.Product, _ int) string { return p.ID })
-		products, appErr := s.srv.Product.ProductsByOption(model_helper.ProductFilterOption{
-			// Conditions: squirrel.Eq{model.ProductTableName + ".Id": products.IDs()},
-			// Preloads: []string{
-			// 	"ProductMedias",
-			// 	"ProductType",
-			// 	"Category",
-			// 	"Collections",
-			// 	"ProductVariants",
-			// 	"Attributes",
-			// },
-			CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductWhere.ID.IN(productIDs)),
-			Preloads: []string{
-				model.ProductRels.ProductMedia,
-				model.ProductRels.Category,
-				model.ProductRels.ProductCollections + "." + model.ProductCollectionRels.Collection,
-				model.ProductRels.ProductVariants,
-				// model.ProductRels.AssignedProductAttributes + "." + model.AssignedProductAttributeRels.Attribute,
-			},
+		data := s.GetProductsData(products, exportFields, exportInfo.Attributes, exportInfo.Warehouses, exportInfo.Channels)
+		rows := lo.Map(data, func(row model_helper.StringMap, _ int) []string {
+			return lo.Map(headers, func(header string, _ int) string { return row[header] })
 		})
-		// model.ProductCollection
+
+		appErr := writer.WriteRows(rows)
 		if appErr != nil {
-			if appErr.StatusCode == http.StatusInternalServerError {
-				return appErr
-			}
-			break
+			return appErr
+		}
+
+		if uint64(len(products)) < productFetchBatchSize {
+			return nil
+		}
+	}
+}
+
+// finishExport saves path of exported file, records success event and sends download link to requestor
+func (s *ServiceCsv) finishExport(exportFile model.ExportFile, dataType, filePath string) *model_helper.AppError {
+	exportFile.ContentFile = model_types.NewNullString(filePath)
+	updatedFile, err := s.srv.Store.CsvExportFile().Upsert(exportFile)
+	if err != nil {
+		if appErr, ok := err.(*model_helper.AppError); ok {
+			return appErr
 		}
+		return model_helper.NewAppError("finishExport", "app.csv.error_updating_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	appErr := s.recordExportEvent(*updatedFile, model.ExportEventTypeExportSuccess, nil)
+	if appErr != nil {
+		return appErr
+	}
+
+	if dataType == userDataExportType {
+		appErr = s.SendUserDataExportLink(*updatedFile)
+	} else {
+		appErr = s.SendExportDownloadLinkNotification(*updatedFile, dataType)
+	}
+	if appErr != nil {
+		// file has been exported successfully, requestor can still find it in export file list
+		slog.Error("failed to send export download link", slog.String("export_file_id", updatedFile.ID), slog.Err(appErr))
+	}
+	return nil
+}
+
+func (s *ServiceCsv) handleExportFailure(exportFile model.ExportFile, dataType string, exportErr *model_helper.AppError) {
+	appErr := s.recordExportEvent(exportFile, model.ExportEventTypeExportFailed, map[string]string{
+		"message":    exportErr.Error(),
+		"error_type": exportErr.Id,
+	})
+	if appErr != nil {
+		slog.Error("failed to record export failed event", slog.String("export_file_id", exportFile.ID), slog.Err(appErr))
+	}
+
+	appErr = s.SendExportFailedInfo(exportFile, dataType, exportErr.Error())
+	if appErr != nil {
+		slog.Error("failed to send export failed info", slog.String("export_file_id", exportFile.ID), slog.Err(appErr))
+	}
+}
 
-		s.GetProductsData(products, exportFields, ExportInfo.Attributes, ExportInfo.Warehouses, ExportInfo.Channels)
+// recordExportEvent creates an event of given type for given export file, on behalf of the file's requestor
+func (s *ServiceCsv) recordExportEvent(exportFile model.ExportFile, eventType model.ExportEventType, parameters map[string]string) *model_helper.AppError {
+	event := model.ExportEvent{
+		Type:         eventType,
+		ExportFileID: exportFile.ID,
+		UserID:       exportFile.UserID,
+		AppID:        exportFile.AppID,
+	}
+	if len(parameters) > 0 {
+		jsonParams, _ := json.Marshal(parameters)
+		event.Parameters = model_types.NewNullString(string(jsonParams))
 	}
 
-	panic("not implt")
+	_, appErr := s.CommonCreateExportEvent(event)
+	return appErr
+}
+
+func validateExportProductsInput(input model_helper.ExportProductsFilterOptions) *model_helper.AppError {
+	appErr := validateExportInput("ExportProducts", input.Scope, input.Ids, input.Filter != nil, input.FileType)
+	if appErr != nil {
+		return appErr
+	}
+	if input.Scope == "filter" {
+		if err := input.Filter.AttributesWhere.Validate(); err != nil {
+			return model_helper.NewAppError("ExportProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "attributesWhere"}, err.Error(), http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
+// validateExportInput checks common fields of export inputs
+func validateExportInput(where, scope string, ids []string, hasFilter bool, fileType string) *model_helper.AppError {
+	var invalidField string
+
+	switch {
+	case scope != "all" && scope != "ids" && scope != "filter":
+		invalidField = "scope"
+	case scope == "ids" && len(ids) == 0:
+		invalidField = "ids"
+	case scope == "filter" && !hasFilter:
+		invalidField = "filter"
+	case fileType != model_helper.ExportFileTypeCsv && fileType != model_helper.ExportFileTypeXlsx:
+		invalidField = "file_type"
+	}
+
+	if invalidField != "" {
+		return model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": invalidField}, "", http.StatusBadRequest)
+	}
+	return nil
 }
 
 // getFileName returns a file name for exported file
@@ -113,3 +384,66 @@ func getFileName(modelName string, fileType string) string {
 		fileType,
 	)
 }
+
+// exportMetadataConditions returns conditions that match rows whose metadata stored in given column
+// has all given keys. Pairs having a value must also match the value.
+func exportMetadataConditions(column string, metadata []*struct {
+	Key   string
+	Value string
+}) []squirrel.Sqlizer {
+	var res []squirrel.Sqlizer
+
+	for _, pair := range metadata {
+		if pair == nil || pair.Key == "" {
+			continue
+		}
+		if pair.Value == "" {
+			res = append(res, squirrel.Expr(fmt.Sprintf("%s::jsonb -> ? IS NOT NULL", column), pair.Key))
+			continue
+		}
+		jsonPair, _ := json.Marshal(map[string]string{pair.Key: pair.Value})
+		res = append(res, squirrel.Expr(fmt.Sprintf("%s::jsonb @> ?::jsonb", column), string(jsonPair)))
+	}
+
+	return res
+}
+
+// exportTimeRangeConditions returns conditions that limit given column, which holds milliseconds, to given time range
+func exportTimeRangeConditions(column string, timeRange *struct {
+	Gte *time.Time
+	Lte *time.Time
+}) []squirrel.Sqlizer {
+	var res []squirrel.Sqlizer
+
+	if timeRange == nil {
+		return res
+	}
+	if timeRange.Gte != nil {
+		res = append(res, squirrel.GtOrEq{column: timeRange.Gte.UnixMilli()})
+	}
+	if timeRange.Lte != nil {
+		res = append(res, squirrel.LtOrEq{column: timeRange.Lte.UnixMilli()})
+	}
+
+	return res
+}
+
+// exportAmountRangeConditions returns conditions that limit given amount column to given range
+func exportAmountRangeConditions(column string, amountRange *struct {
+	Gte *float64
+	Lte *float64
+}) []squirrel.Sqlizer {
+	var res []squirrel.Sqlizer
+
+	if amountRange == nil {
+		return res
+	}
+	if amountRange.Gte != nil {
+		res = append(res, squirrel.GtOrEq{column: *amountRange.Gte})
+	}
+	if amountRange.Lte != nil {
+		res = append(res, squirrel.LtOrEq{column: *amountRange.Lte})
+	}
+
+	return res
+}
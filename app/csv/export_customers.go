@@ -0,0 +1,253 @@
+package csv
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/squirrel"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var (
+	customerFetchBatchSize = 1000
+)
+
+// customerExportHeaders are headers of exported customer files. Each row represents one customer
+var customerExportHeaders = []string{
+	"id",
+	"email",
+	"first_name",
+	"last_name",
+	"is_active",
+	"date_joined",
+	"last_activity",
+	"note",
+	"orders_count",
+	"default_billing_address",
+	"default_shipping_address",
+	"addresses",
+}
+
+// ExportCustomers is called by export job, it writes customers selected by given input into the export file,
+// then records export events and notifies requestor of the export about the result.
+func (s *ServiceCsv) ExportCustomers(exportFile model.ExportFile, input model_helper.ExportCustomersFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := s.exportCustomers(exportFile, input, delimiter)
+	if appErr != nil {
+		s.handleExportFailure(exportFile, "customers", appErr)
+		return appErr
+	}
+	return nil
+}
+
+func (s *ServiceCsv) exportCustomers(exportFile model.ExportFile, input model_helper.ExportCustomersFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := validateExportCustomersInput(input)
+	if appErr != nil {
+		return appErr
+	}
+
+	filePath := filepath.Join(exportFilesDir, getFileName("customer", input.FileType))
+	writer := s.newExportWriter(input.FileType, filePath, delimiter)
+	defer writer.Discard()
+
+	appErr = writer.WriteRows([][]string{customerExportHeaders})
+	if appErr != nil {
+		return appErr
+	}
+
+	var (
+		lastCreatedAt int64
+		lastID        string
+	)
+
+	for {
+		conditions := exportCustomersConditions(input)
+		if lastID != "" {
+			conditions = append(conditions, qm.Where(
+				fmt.Sprintf("(%s, %s) > (?, ?)", model.UserTableColumns.CreatedAt, model.UserTableColumns.ID),
+				lastCreatedAt, lastID,
+			))
+		}
+		conditions = append(
+			conditions,
+			qm.Load(model.UserRels.Addresses, qm.OrderBy(model.AddressColumns.CreatedAt)),
+			qm.OrderBy(model.UserTableColumns.CreatedAt+", "+model.UserTableColumns.ID),
+			qm.Limit(customerFetchBatchSize),
+		)
+
+		users, err := s.srv.Store.User().Find(model_helper.UserFilterOptions{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(conditions...),
+		})
+		if err != nil {
+			return model_helper.NewAppError("ExportCustomers", "app.account.error_finding_users_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		lastCreatedAt = users[len(users)-1].CreatedAt
+		lastID = users[len(users)-1].ID
+
+		orderCounts, err := s.srv.Store.Order().CountByUserIDs(lo.Map(users, func(user *model.User, _ int) string { return user.ID }))
+		if err != nil {
+			return model_helper.NewAppError("ExportCustomers", "app.order.error_counting_orders.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		orderCountMap := lo.SliceToMap(orderCounts, func(count *model_helper.OrderCountByUserID) (string, uint64) { return count.UserID, count.OrderCount })
+
+		rows := lo.Map(users, func(user *model.User, _ int) []string {
+			return getCustomerRow(user, orderCountMap[user.ID])
+		})
+		appErr = writer.WriteRows(rows)
+		if appErr != nil {
+			return appErr
+		}
+
+		if len(users) < customerFetchBatchSize {
+			break
+		}
+	}
+
+	appErr = writer.Close()
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.finishExport(exportFile, "customers", filePath)
+}
+
+// getCustomerRow returns export row of given user, ordered by customerExportHeaders.
+//
+// NOTE: user must be loaded with its addresses
+func getCustomerRow(user *model.User, orderCount uint64) []string {
+	var defaultBillingAddress, defaultShippingAddress string
+	addresses := lo.Map(user.R.GetAddresses(), func(address *model.Address, _ int) string {
+		formatted := formatExportAddress(address)
+		if address.ID == lo.FromPtr(user.DefaultBillingAddressID.String) {
+			defaultBillingAddress = formatted
+		}
+		if address.ID == lo.FromPtr(user.DefaultShippingAddressID.String) {
+			defaultShippingAddress = formatted
+		}
+		return formatted
+	})
+
+	return []string{
+		user.ID,
+		user.Email,
+		user.FirstName,
+		user.LastName,
+		strconv.FormatBool(user.IsActive),
+		formatExportMillis(&user.CreatedAt),
+		formatExportMillis(&user.LastActivityAt),
+		lo.FromPtr(user.Note.String),
+		strconv.FormatUint(orderCount, 10),
+		defaultBillingAddress,
+		defaultShippingAddress,
+		joinExportValues(lo.Map(addresses, func(address string, _ int) string { return "[" + address + "]" })),
+	}
+}
+
+// formatExportAddress formats given address into a single line
+func formatExportAddress(address *model.Address) string {
+	return joinExportValues([]string{
+		joinExportWords(address.FirstName, address.LastName),
+		address.CompanyName,
+		address.StreetAddress1,
+		address.StreetAddress2,
+		joinExportWords(address.PostalCode, address.City),
+		address.CityArea,
+		address.CountryArea,
+		address.Country.String(),
+		address.Phone,
+	})
+}
+
+// joinExportWords joins given words with a space, skipping empty ones
+func joinExportWords(words ...string) string {
+	return strings.Join(lo.Compact(words), " ")
+}
+
+// exportCustomersConditions returns conditions selecting customers to export.
+// Staff members and deleted users are never exported.
+func exportCustomersConditions(input model_helper.ExportCustomersFilterOptions) []qm.QueryMod {
+	conditions := model_helper.And{
+		squirrel.Eq{model.UserTableColumns.DeleteAt: 0},
+		squirrel.Expr(fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM %s WHERE %s = %s)",
+			model.TableNames.ShopStaffs,
+			model.ShopStaffTableColumns.StaffID,
+			model.UserTableColumns.ID,
+		)),
+	}
+
+	switch input.Scope {
+	case "ids":
+		conditions = append(conditions, squirrel.Eq{model.UserTableColumns.ID: input.Ids})
+
+	case "filter":
+		filter := input.Filter
+
+		conditions = append(conditions, exportTimeRangeConditions(model.UserTableColumns.CreatedAt, filter.DateJoined)...)
+
+		if filter.NumberOfOrders != nil {
+			orderCount := fmt.Sprintf(
+				"(SELECT COUNT(*) FROM %s WHERE %s = %s AND %s <> '%s')",
+				model.TableNames.Orders,
+				model.OrderTableColumns.UserID,
+				model.UserTableColumns.ID,
+				model.OrderTableColumns.Status,
+				model.OrderStatusDraft,
+			)
+			if filter.NumberOfOrders.Gte != nil {
+				conditions = append(conditions, squirrel.Expr(orderCount+" >= ?", *filter.NumberOfOrders.Gte))
+			}
+			if filter.NumberOfOrders.Lte != nil {
+				conditions = append(conditions, squirrel.Expr(orderCount+" <= ?", *filter.NumberOfOrders.Lte))
+			}
+		}
+
+		if placedConditions := exportTimeRangeConditions(model.OrderTableColumns.CreatedAt, filter.PlacedOrders); len(placedConditions) > 0 {
+			query, args, _ := squirrel.
+				Select("1").
+				From(model.TableNames.Orders).
+				Where(squirrel.Expr(fmt.Sprintf("%s = %s", model.OrderTableColumns.UserID, model.UserTableColumns.ID))).
+				Where(squirrel.And(placedConditions)).
+				ToSql()
+			conditions = append(conditions, squirrel.Expr("EXISTS ("+query+")", args...))
+		}
+
+		if filter.Search != nil && *filter.Search != "" {
+			pattern := "%" + *filter.Search + "%"
+			conditions = append(conditions, squirrel.Or{
+				squirrel.ILike{model.UserTableColumns.Email: pattern},
+				squirrel.ILike{model.UserTableColumns.FirstName: pattern},
+				squirrel.ILike{model.UserTableColumns.LastName: pattern},
+				squirrel.Expr(
+					fmt.Sprintf(
+						"EXISTS (SELECT 1 FROM %s WHERE %s = %s AND (%s ILIKE ? OR %s ILIKE ? OR %s ILIKE ?))",
+						model.TableNames.Addresses,
+						model.AddressTableColumns.UserID,
+						model.UserTableColumns.ID,
+						model.AddressTableColumns.Phone,
+						model.AddressTableColumns.City,
+						model.AddressTableColumns.PostalCode,
+					),
+					pattern, pattern, pattern,
+				),
+			})
+		}
+
+		conditions = append(conditions, exportMetadataConditions(model.UserTableColumns.Metadata, filter.Metadata)...)
+	}
+
+	return []qm.QueryMod{conditions}
+}
+
+func validateExportCustomersInput(input model_helper.ExportCustomersFilterOptions) *model_helper.AppError {
+	return validateExportInput("ExportCustomers", input.Scope, input.Ids, input.Filter != nil, input.FileType)
+}
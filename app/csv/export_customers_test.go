@@ -0,0 +1,65 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCustomers(t *testing.T) {
+	ts := newExportTestServer(t)
+
+	home := &model.Address{ID: model_helper.NewId(), FirstName: "Jane", LastName: "Doe", StreetAddress1: "1 Main St", PostalCode: "10001", City: "New York", Country: model.CountryCodeUS}
+	office := &model.Address{ID: model_helper.NewId(), CompanyName: "Acme", StreetAddress1: "5 Side St", City: "Boston", Country: model.CountryCodeUS}
+	customer := &model.User{
+		ID:                       model_helper.NewId(),
+		Email:                    "jane@example.com",
+		FirstName:                "Jane",
+		LastName:                 "Doe",
+		IsActive:                 true,
+		DefaultShippingAddressID: model_types.NewNullString(home.ID),
+	}
+	customer.R = customer.R.NewStruct()
+	customer.R.Addresses = model.AddressSlice{home, office}
+
+	userStore := &mocks.UserStore{}
+	userStore.On("Find", mock.Anything).Return(model.UserSlice{customer}, nil)
+	orderStore := &mocks.OrderStore{}
+	orderStore.On("CountByUserIDs", []string{customer.ID}).Return([]*model_helper.OrderCountByUserID{{UserID: customer.ID, OrderCount: 3}}, nil)
+	ts.store.On("User").Return(userStore)
+	ts.store.On("Order").Return(orderStore)
+
+	s := &ServiceCsv{srv: ts.Server}
+	require.Nil(t, s.ExportCustomers(ts.exportFile(), model_helper.ExportCustomersFilterOptions{Scope: "all", FileType: model_helper.ExportFileTypeCsv}, ";"))
+
+	content, _ := ts.exportedContent(t, model_helper.CSV_EXPORT_SUCCESS)
+	require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportSuccess, model.ExportEventTypeExportedFileSent}, ts.eventTypes())
+
+	records := exportedRecords(t, content, ';')
+	require.Len(t, records, 1)
+	require.Equal(t, "jane@example.com", records[0]["email"])
+	require.Equal(t, "true", records[0]["is_active"])
+	require.Equal(t, "3", records[0]["orders_count"])
+	require.Equal(t, "Jane Doe, 1 Main St, 10001 New York, US", records[0]["default_shipping_address"])
+	require.Empty(t, records[0]["default_billing_address"])
+	require.Equal(t, "[Jane Doe, 1 Main St, 10001 New York, US], [Acme, 5 Side St, Boston, US]", records[0]["addresses"])
+
+	t.Run("upload failure", func(t *testing.T) {
+		ts := newExportTestServer(t)
+		ts.files.writeErr = errors.New("connection reset")
+		ts.store.On("User").Return(userStore)
+		ts.store.On("Order").Return(orderStore)
+		s := &ServiceCsv{srv: ts.Server}
+
+		appErr := s.ExportCustomers(ts.exportFile(), model_helper.ExportCustomersFilterOptions{Scope: "all", FileType: model_helper.ExportFileTypeCsv}, ";")
+		require.NotNil(t, appErr)
+		require.Empty(t, ts.saved)
+		require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportFailed, model.ExportEventTypeExportFailedInfoSent}, ts.eventTypes())
+	})
+}
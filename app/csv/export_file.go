@@ -3,13 +3,14 @@ package csv
 import (
 	"net/http"
 
+	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
 )
 
 func (s *ServiceCsv) CreateExportFile(file model.ExportFile) (*model.ExportFile, *model_helper.AppError) {
-	createdFile, err := s.srv.Store.CsvExportFile().Save(file)
+	createdFile, err := s.srv.Store.CsvExportFile().Upsert(file)
 	if err != nil {
 		if appErr, ok := err.(*model_helper.AppError); ok {
 			return nil, appErr
@@ -32,3 +33,42 @@ func (s *ServiceCsv) ExportFileById(id string) (*model.ExportFile, *model_helper
 
 	return file, nil
 }
+
+func (s *ServiceCsv) ExportFilesByOption(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, *model_helper.AppError) {
+	files, err := s.srv.Store.CsvExportFile().FilterByOptions(options)
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportFilesByOption", "app.csv.error_finding_export_files.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return files, nil
+}
+
+// DeleteExportFiles removes exported contents of given export files from the file store, then deletes them
+// along with their events
+func (s *ServiceCsv) DeleteExportFiles(files model.ExportFileSlice) *model_helper.AppError {
+	if len(files) == 0 {
+		return nil
+	}
+
+	for _, file := range files {
+		if file.ContentFile.IsNil() {
+			continue
+		}
+		exists, appErr := s.srv.File.FileExists(*file.ContentFile.String)
+		if appErr != nil {
+			return appErr
+		}
+		if exists {
+			if appErr := s.srv.File.RemoveFile(*file.ContentFile.String); appErr != nil {
+				return appErr
+			}
+		}
+	}
+
+	ids := lo.Map(files, func(file *model.ExportFile, _ int) string { return file.ID })
+	if err := s.srv.Store.CsvExportFile().Delete(nil, ids); err != nil {
+		return model_helper.NewAppError("DeleteExportFiles", "app.csv.error_deleting_export_files.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
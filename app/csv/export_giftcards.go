@@ -0,0 +1,209 @@
+package csv
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mattermost/squirrel"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var (
+	giftcardFetchBatchSize = 1000
+)
+
+// giftcardExportHeaders are headers of exported giftcard files. Each row represents one giftcard
+var giftcardExportHeaders = []string{
+	"code",
+	"is_active",
+	"currency",
+	"initial_balance_amount",
+	"current_balance_amount",
+	"tags",
+	"product",
+	"used_by_email",
+	"created_by_email",
+	"expiry_date",
+	"last_used_on",
+	"created_at",
+}
+
+// ExportGiftcards is called by export job, it writes giftcards selected by given input into the export file,
+// then records export events and notifies requestor of the export about the result.
+func (s *ServiceCsv) ExportGiftcards(exportFile model.ExportFile, input model_helper.ExportGiftcardsFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := s.exportGiftcards(exportFile, input, delimiter)
+	if appErr != nil {
+		s.handleExportFailure(exportFile, "giftcards", appErr)
+		return appErr
+	}
+	return nil
+}
+
+func (s *ServiceCsv) exportGiftcards(exportFile model.ExportFile, input model_helper.ExportGiftcardsFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := validateExportGiftcardsInput(input)
+	if appErr != nil {
+		return appErr
+	}
+
+	filePath := filepath.Join(exportFilesDir, getFileName("giftcard", input.FileType))
+	writer := s.newExportWriter(input.FileType, filePath, delimiter)
+	defer writer.Discard()
+
+	appErr = writer.WriteRows([][]string{giftcardExportHeaders})
+	if appErr != nil {
+		return appErr
+	}
+
+	var (
+		lastCreatedAt int64
+		lastID        string
+	)
+
+	for {
+		conditions := exportGiftcardsConditions(input)
+		if lastID != "" {
+			conditions = append(conditions, qm.Where(
+				fmt.Sprintf("(%s, %s) > (?, ?)", model.GiftcardTableColumns.CreatedAt, model.GiftcardTableColumns.ID),
+				lastCreatedAt, lastID,
+			))
+		}
+		conditions = append(
+			conditions,
+			qm.Load(model.GiftcardRels.Product),
+			qm.Load(model.GiftcardRels.GiftcardTagGiftcards+"."+model.GiftcardTagGiftcardRels.Tag),
+			qm.OrderBy(model.GiftcardTableColumns.CreatedAt+", "+model.GiftcardTableColumns.ID),
+			qm.Limit(giftcardFetchBatchSize),
+		)
+
+		giftcards, err := s.srv.Store.GiftCard().FilterByOption(model_helper.GiftcardFilterOption{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(conditions...),
+		})
+		if err != nil {
+			return model_helper.NewAppError("ExportGiftcards", "app.giftcard.error_finding_giftcards_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(giftcards) == 0 {
+			break
+		}
+
+		lastCreatedAt = giftcards[len(giftcards)-1].CreatedAt
+		lastID = giftcards[len(giftcards)-1].ID
+
+		appErr = writer.WriteRows(lo.Map(giftcards, func(giftcard *model.Giftcard, _ int) []string { return getGiftcardRow(giftcard) }))
+		if appErr != nil {
+			return appErr
+		}
+
+		if len(giftcards) < giftcardFetchBatchSize {
+			break
+		}
+	}
+
+	appErr = writer.Close()
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.finishExport(exportFile, "giftcards", filePath)
+}
+
+// getGiftcardRow returns export row of given giftcard, ordered by giftcardExportHeaders.
+//
+// NOTE: giftcard must be loaded with its product and tags
+func getGiftcardRow(giftcard *model.Giftcard) []string {
+	var initialBalance, currentBalance, expiryDate, product string
+	if !giftcard.InitialBalanceAmount.IsNil() {
+		initialBalance = giftcard.InitialBalanceAmount.Decimal.String()
+	}
+	if !giftcard.CurrentBalanceAmount.IsNil() {
+		currentBalance = giftcard.CurrentBalanceAmount.Decimal.String()
+	}
+	if giftcard.ExpiryDate.Time != nil {
+		expiryDate = giftcard.ExpiryDate.Time.Format("2006-01-02")
+	}
+	if relatedProduct := giftcard.R.GetProduct(); relatedProduct != nil {
+		product = relatedProduct.Slug
+	}
+
+	tags := lo.FilterMap(giftcard.R.GetGiftcardTagGiftcards(), func(item *model.GiftcardTagGiftcard, _ int) (string, bool) {
+		tag := item.R.GetTag()
+		return lo.FromPtr(tag).Name, tag != nil
+	})
+
+	return []string{
+		giftcard.Code,
+		strconv.FormatBool(lo.FromPtr(giftcard.IsActive.Bool)),
+		giftcard.Currency.String(),
+		initialBalance,
+		currentBalance,
+		joinExportValues(tags),
+		product,
+		lo.FromPtr(giftcard.UsedByEmail.String),
+		lo.FromPtr(giftcard.CreatedByEmail.String),
+		expiryDate,
+		formatExportMillis(giftcard.LastUsedOn.Int64),
+		formatExportMillis(&giftcard.CreatedAt),
+	}
+}
+
+// exportGiftcardsConditions returns conditions selecting giftcards to export
+func exportGiftcardsConditions(input model_helper.ExportGiftcardsFilterOptions) []qm.QueryMod {
+	conditions := model_helper.And{}
+
+	switch input.Scope {
+	case "ids":
+		conditions = append(conditions, squirrel.Eq{model.GiftcardTableColumns.ID: input.Ids})
+
+	case "filter":
+		filter := input.Filter
+
+		if filter.IsActive != nil {
+			conditions = append(conditions, squirrel.Eq{model.GiftcardTableColumns.IsActive: *filter.IsActive})
+		}
+		if len(filter.Tags) > 0 {
+			query, args, _ := squirrel.
+				Select("1").
+				From(model.TableNames.GiftcardTagGiftcards).
+				InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.GiftcardTags, model.GiftcardTagTableColumns.ID, model.GiftcardTagGiftcardTableColumns.TagID)).
+				Where(squirrel.Expr(fmt.Sprintf("%s = %s", model.GiftcardTagGiftcardTableColumns.GiftcardID, model.GiftcardTableColumns.ID))).
+				Where(squirrel.Eq{model.GiftcardTagTableColumns.Name: filter.Tags}).
+				ToSql()
+			conditions = append(conditions, squirrel.Expr("EXISTS ("+query+")", args...))
+		}
+		if len(filter.Products) > 0 {
+			conditions = append(conditions, squirrel.Eq{model.GiftcardTableColumns.ProductID: filter.Products})
+		}
+		if len(filter.UsedBy) > 0 {
+			conditions = append(conditions, squirrel.Eq{model.GiftcardTableColumns.UsedByID: filter.UsedBy})
+		}
+		if filter.Currency != nil {
+			conditions = append(conditions, squirrel.Eq{model.GiftcardTableColumns.Currency: *filter.Currency})
+		}
+		conditions = append(conditions, exportAmountRangeConditions(model.GiftcardTableColumns.CurrentBalanceAmount, filter.CurrentBalance)...)
+		conditions = append(conditions, exportAmountRangeConditions(model.GiftcardTableColumns.InitialBalanceAmount, filter.InitialBalance)...)
+	}
+
+	if len(conditions) == 0 {
+		return []qm.QueryMod{}
+	}
+	return []qm.QueryMod{conditions}
+}
+
+func validateExportGiftcardsInput(input model_helper.ExportGiftcardsFilterOptions) *model_helper.AppError {
+	appErr := validateExportInput("ExportGiftcards", input.Scope, input.Ids, input.Filter != nil, input.FileType)
+	if appErr != nil {
+		return appErr
+	}
+
+	// balances of different currencies are not comparable
+	if input.Scope == "filter" &&
+		(input.Filter.CurrentBalance != nil || input.Filter.InitialBalance != nil) &&
+		(input.Filter.Currency == nil || *input.Filter.Currency == "") {
+		return model_helper.NewAppError("ExportGiftcards", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "filter.currency"}, "currency is required when filtering by balance", http.StatusBadRequest)
+	}
+	return nil
+}
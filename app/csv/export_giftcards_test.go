@@ -0,0 +1,78 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/site-name/decimal"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportGiftcards(t *testing.T) {
+	ts := newExportTestServer(t)
+
+	tagged := &model.Giftcard{
+		ID:                   model_helper.NewId(),
+		Code:                 "GIFT-0001",
+		IsActive:             model_types.NewNullBool(true),
+		Currency:             model.CurrencyUSD,
+		InitialBalanceAmount: model_types.NewNullDecimal(decimal.NewFromInt(50)),
+		CurrentBalanceAmount: model_types.NewNullDecimal(decimal.NewFromInt(20)),
+		UsedByEmail:          model_types.NewNullString("buyer@example.com"),
+	}
+	tagged.R = tagged.R.NewStruct()
+	for _, name := range []string{"birthday", "promo"} {
+		tag := &model.GiftcardTagGiftcard{}
+		tag.R = tag.R.NewStruct()
+		tag.R.Tag = &model.GiftcardTag{Name: name}
+		tagged.R.GiftcardTagGiftcards = append(tagged.R.GiftcardTagGiftcards, tag)
+	}
+	inactive := &model.Giftcard{ID: model_helper.NewId(), Code: "GIFT-0002", IsActive: model_types.NewNullBool(false), Currency: model.CurrencyUSD}
+
+	giftcardStore := &mocks.GiftCardStore{}
+	giftcardStore.On("FilterByOption", mock.Anything).Return(model.GiftcardSlice{tagged, inactive}, nil)
+	ts.store.On("GiftCard").Return(giftcardStore)
+
+	s := &ServiceCsv{srv: ts.Server}
+	require.Nil(t, s.ExportGiftcards(ts.exportFile(), model_helper.ExportGiftcardsFilterOptions{Scope: "all", FileType: model_helper.ExportFileTypeCsv}, ","))
+
+	content, _ := ts.exportedContent(t, model_helper.CSV_EXPORT_SUCCESS)
+	require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportSuccess, model.ExportEventTypeExportedFileSent}, ts.eventTypes())
+
+	records := exportedRecords(t, content, ',')
+	require.Len(t, records, 2)
+	require.Equal(t, map[string]string{
+		"code":                   "GIFT-0001",
+		"is_active":              "true",
+		"currency":               "USD",
+		"initial_balance_amount": "50",
+		"current_balance_amount": "20",
+		"tags":                   "birthday, promo",
+		"product":                "",
+		"used_by_email":          "buyer@example.com",
+		"created_by_email":       "",
+		"expiry_date":            "",
+		"last_used_on":           "",
+		"created_at":             "1970-01-01 00:00:00",
+	}, records[0])
+	require.Equal(t, "GIFT-0002", records[1]["code"])
+	require.Equal(t, "false", records[1]["is_active"])
+	require.Empty(t, records[1]["tags"])
+
+	t.Run("upload failure", func(t *testing.T) {
+		ts := newExportTestServer(t)
+		ts.files.writeErr = errors.New("connection reset")
+		ts.store.On("GiftCard").Return(giftcardStore)
+		s := &ServiceCsv{srv: ts.Server}
+
+		appErr := s.ExportGiftcards(ts.exportFile(), model_helper.ExportGiftcardsFilterOptions{Scope: "all", FileType: model_helper.ExportFileTypeCsv}, ",")
+		require.NotNil(t, appErr)
+		require.Empty(t, ts.saved)
+		require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportFailed, model.ExportEventTypeExportFailedInfoSent}, ts.eventTypes())
+	})
+}
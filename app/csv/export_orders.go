@@ -0,0 +1,306 @@
+package csv
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/squirrel"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var (
+	orderFetchBatchSize = 1000
+)
+
+// orderExportHeaders are headers of exported order files.
+// Each row represents one order line, values of the order are repeated for every line of it.
+var orderExportHeaders = []string{
+	"id",
+	"created_at",
+	"status",
+	"channel",
+	"user_email",
+	"currency",
+	"shipping_method",
+	"voucher_code",
+	"subtotal_net",
+	"subtotal_gross",
+	"shipping_price_gross",
+	"total_net",
+	"total_gross",
+	"total_charged",
+	"line_product_name",
+	"line_variant_name",
+	"line_sku",
+	"line_quantity",
+	"line_quantity_fulfilled",
+	"line_unit_price_net",
+	"line_unit_price_gross",
+	"line_total_price_gross",
+	"payments",
+	"fulfillments",
+}
+
+// ExportOrders is called by export job, it writes orders selected by given input into the export file,
+// then records export events and notifies requestor of the export about the result.
+func (s *ServiceCsv) ExportOrders(exportFile model.ExportFile, input model_helper.ExportOrdersFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := s.exportOrders(exportFile, input, delimiter)
+	if appErr != nil {
+		s.handleExportFailure(exportFile, "orders", appErr)
+		return appErr
+	}
+	return nil
+}
+
+func (s *ServiceCsv) exportOrders(exportFile model.ExportFile, input model_helper.ExportOrdersFilterOptions, delimiter string) *model_helper.AppError {
+	appErr := validateExportOrdersInput(input)
+	if appErr != nil {
+		return appErr
+	}
+
+	channels, err := s.srv.Store.Channel().FilterByOptions(model_helper.ChannelFilterOptions{})
+	if err != nil {
+		return model_helper.NewAppError("ExportOrders", "app.channel.error_finding_channels_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	channelSlugs := lo.SliceToMap(channels, func(channel *model.Channel) (string, string) { return channel.ID, channel.Slug })
+
+	filePath := filepath.Join(exportFilesDir, getFileName("order", input.FileType))
+	writer := s.newExportWriter(input.FileType, filePath, delimiter)
+	defer writer.Discard()
+
+	appErr = writer.WriteRows([][]string{orderExportHeaders})
+	if appErr != nil {
+		return appErr
+	}
+
+	var (
+		lastCreatedAt int64
+		lastID        string
+	)
+
+	for {
+		option := exportOrdersFilterOption(input)
+		if lastID != "" {
+			option.Conditions = append(option.Conditions, qm.Where(
+				fmt.Sprintf("(%s, %s) > (?, ?)", model.OrderTableColumns.CreatedAt, model.OrderTableColumns.ID),
+				lastCreatedAt, lastID,
+			))
+		}
+		option.Conditions = append(
+			option.Conditions,
+			qm.OrderBy(model.OrderTableColumns.CreatedAt+", "+model.OrderTableColumns.ID),
+			qm.Limit(orderFetchBatchSize),
+		)
+
+		orders, err := s.srv.Store.Order().FilterByOption(option)
+		if err != nil {
+			return model_helper.NewAppError("ExportOrders", "app.order.error_finding_orders_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(orders) == 0 {
+			break
+		}
+
+		lastCreatedAt = orders[len(orders)-1].CreatedAt
+		lastID = orders[len(orders)-1].ID
+
+		rows, appErr := s.getOrdersRows(orders, channelSlugs)
+		if appErr != nil {
+			return appErr
+		}
+
+		appErr = writer.WriteRows(rows)
+		if appErr != nil {
+			return appErr
+		}
+
+		if len(orders) < orderFetchBatchSize {
+			break
+		}
+	}
+
+	appErr = writer.Close()
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.finishExport(exportFile, "orders", filePath)
+}
+
+// getOrdersRows returns export rows of given orders, ordered by orderExportHeaders
+func (s *ServiceCsv) getOrdersRows(orders model_helper.CustomOrderSlice, channelSlugs map[string]string) ([][]string, *model_helper.AppError) {
+	orderIDs := lo.Map(orders, func(order *model_helper.CustomOrder, _ int) string { return order.ID })
+
+	lines, err := s.srv.Store.OrderLine().FilterbyOption(model_helper.OrderLineFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderLineWhere.OrderID.IN(orderIDs),
+			qm.OrderBy(model.OrderLineColumns.CreatedAt+", "+model.OrderLineColumns.ID),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportOrders", "app.order.error_finding_order_lines_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	payments, err := s.srv.Store.Payment().FilterByOption(model_helper.PaymentFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model_helper.And{
+				squirrel.Eq{model.PaymentTableColumns.OrderID: orderIDs},
+			},
+			qm.OrderBy(model.PaymentColumns.CreatedAt),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportOrders", "app.payment.error_finding_payments_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	fulfillments, err := s.srv.Store.Fulfillment().FilterByOption(model_helper.FulfillmentFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.FulfillmentWhere.OrderID.IN(orderIDs),
+			qm.OrderBy(model.FulfillmentColumns.FulfillmentOrder),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportOrders", "app.order.error_finding_fulfillment_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	linesByOrder := lo.GroupBy(lines, func(line *model.OrderLine) string { return line.OrderID })
+	paymentsByOrder := lo.GroupBy(payments, func(payment *model.Payment) string { return lo.FromPtr(payment.OrderID.String) })
+	fulfillmentsByOrder := lo.GroupBy(fulfillments, func(fulfillment *model.Fulfillment) string { return fulfillment.OrderID })
+
+	var rows [][]string
+	for _, order := range orders {
+		orderRow := []string{
+			order.ID,
+			formatExportMillis(&order.CreatedAt),
+			order.Status.String(),
+			channelSlugs[order.ChannelID],
+			order.UserEmail,
+			order.Currency.String(),
+			lo.FromPtr(order.ShippingMethodName.String),
+			lo.FromPtr(order.VoucherCode.String),
+			order.SubtotalNetAmount.String(),
+			order.SubtotalGrossAmount.String(),
+			order.ShippingPriceGrossAmount.String(),
+			order.TotalNetAmount.String(),
+			order.TotalGrossAmount.String(),
+			order.TotalChargedAmount.String(),
+		}
+
+		paymentsValue := joinExportValues(lo.Map(paymentsByOrder[order.ID], func(payment *model.Payment, _ int) string {
+			return strings.TrimSpace(fmt.Sprintf(
+				"%s %s %s/%s %s",
+				payment.Gateway,
+				payment.ChargeStatus,
+				payment.CapturedAmount.String(),
+				payment.Total.String(),
+				lo.FromPtr(payment.PSPReference.String),
+			))
+		}))
+		fulfillmentsValue := joinExportValues(lo.Map(fulfillmentsByOrder[order.ID], func(fulfillment *model.Fulfillment, _ int) string {
+			return strings.TrimSpace(fmt.Sprintf("#%d %s %s", fulfillment.FulfillmentOrder, fulfillment.Status, fulfillment.TrackingNumber))
+		}))
+
+		orderLines := linesByOrder[order.ID]
+		if len(orderLines) == 0 {
+			row := append(orderRow, make([]string, 8)...)
+			rows = append(rows, append(row, paymentsValue, fulfillmentsValue))
+			continue
+		}
+
+		for _, line := range orderLines {
+			var totalPriceGross string
+			if !line.TotalPriceGrossAmount.IsNil() {
+				totalPriceGross = line.TotalPriceGrossAmount.Decimal.String()
+			}
+
+			rows = append(rows, append(
+				append([]string{}, orderRow...),
+				line.ProductName,
+				line.VariantName,
+				lo.FromPtr(line.ProductSku.String),
+				strconv.Itoa(line.Quantity),
+				strconv.Itoa(line.QuantityFulfilled),
+				line.UnitPriceNetAmount.String(),
+				line.UnitPriceGrossAmount.String(),
+				totalPriceGross,
+				paymentsValue,
+				fulfillmentsValue,
+			))
+		}
+	}
+
+	return rows, nil
+}
+
+// exportOrdersFilterOption returns an option selecting orders to export. Draft orders are never exported
+func exportOrdersFilterOption(input model_helper.ExportOrdersFilterOptions) model_helper.OrderFilterOption {
+	option := model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderWhere.Status.NEQ(model.OrderStatusDraft),
+		),
+	}
+
+	switch input.Scope {
+	case "ids":
+		option.Conditions = append(option.Conditions, model.OrderWhere.ID.IN(input.Ids))
+
+	case "filter":
+		filter := input.Filter
+		conditions := model_helper.And{}
+
+		if filter.Customer != nil && *filter.Customer != "" {
+			option.Customer = *filter.Customer
+		}
+		if filter.Search != nil && *filter.Search != "" {
+			option.Search = *filter.Search
+		}
+		if len(filter.Status) > 0 {
+			option.Statuses = filter.Status
+		}
+		if len(filter.Channels) > 0 {
+			conditions = append(conditions, squirrel.Eq{model.OrderTableColumns.ChannelID: filter.Channels})
+		}
+		if len(filter.PaymentStatus) > 0 {
+			// EXISTS keeps one row per order, joining payments would not
+			query, args, _ := squirrel.
+				Select("1").
+				From(model.TableNames.Payments).
+				Where(squirrel.Expr(fmt.Sprintf("%s = %s", model.PaymentTableColumns.OrderID, model.OrderTableColumns.ID))).
+				Where(squirrel.Eq{
+					model.PaymentTableColumns.IsActive:     true,
+					model.PaymentTableColumns.ChargeStatus: filter.PaymentStatus,
+				}).
+				ToSql()
+			conditions = append(conditions, squirrel.Expr("EXISTS ("+query+")", args...))
+		}
+		conditions = append(conditions, exportTimeRangeConditions(model.OrderTableColumns.CreatedAt, filter.Created)...)
+		conditions = append(conditions, exportMetadataConditions(model.OrderTableColumns.Metadata, filter.Metadata)...)
+
+		if len(conditions) > 0 {
+			option.Conditions = append(option.Conditions, conditions)
+		}
+	}
+
+	return option
+}
+
+func validateExportOrdersInput(input model_helper.ExportOrdersFilterOptions) *model_helper.AppError {
+	appErr := validateExportInput("ExportOrders", input.Scope, input.Ids, input.Filter != nil, input.FileType)
+	if appErr != nil {
+		return appErr
+	}
+
+	if input.Scope == "filter" {
+		for _, status := range input.Filter.PaymentStatus {
+			if status.IsValid() != nil {
+				return model_helper.NewAppError("ExportOrders", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "filter.payment_status"}, "", http.StatusBadRequest)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/site-name/decimal"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportOrders(t *testing.T) {
+	ts := newExportTestServer(t)
+
+	channel := &model.Channel{ID: model_helper.NewId(), Slug: "default-channel"}
+	order := &model_helper.CustomOrder{Order: model.Order{
+		ID:                model_helper.NewId(),
+		Status:            model.OrderStatusUnfulfilled,
+		ChannelID:         channel.ID,
+		UserEmail:         "buyer@example.com",
+		Currency:          model.CurrencyUSD,
+		TotalGrossAmount:  decimal.NewFromInt(30),
+		SubtotalNetAmount: decimal.NewFromInt(25),
+	}}
+
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice{channel}, nil)
+	orderStore := &mocks.OrderStore{}
+	orderStore.On("FilterByOption", mock.Anything).Return(model_helper.CustomOrderSlice{order}, nil)
+	lineStore := &mocks.OrderLineStore{}
+	lineStore.On("FilterbyOption", mock.Anything).Return(model.OrderLineSlice{
+		{OrderID: order.ID, ProductName: "Green tea", VariantName: "50g", ProductSku: model_types.NewNullString("tea-50g"), Quantity: 2},
+		{OrderID: order.ID, ProductName: "Green tea", VariantName: "100g", Quantity: 1},
+	}, nil)
+	paymentStore := &mocks.PaymentStore{}
+	paymentStore.On("FilterByOption", mock.Anything).Return(model.PaymentSlice{
+		{OrderID: model_types.NewNullString(order.ID), Gateway: "stripe", ChargeStatus: model.PaymentChargeStatusFullyCharged, Total: decimal.NewFromInt(30), CapturedAmount: decimal.NewFromInt(30)},
+	}, nil)
+	fulfillmentStore := &mocks.FulfillmentStore{}
+	fulfillmentStore.On("FilterByOption", mock.Anything).Return(model.FulfillmentSlice{
+		{OrderID: order.ID, FulfillmentOrder: 1, Status: model.FulfillmentStatusFulfilled, TrackingNumber: "TRACK-1"},
+	}, nil)
+
+	ts.store.On("Channel").Return(channelStore)
+	ts.store.On("Order").Return(orderStore)
+	ts.store.On("OrderLine").Return(lineStore)
+	ts.store.On("Payment").Return(paymentStore)
+	ts.store.On("Fulfillment").Return(fulfillmentStore)
+
+	s := &ServiceCsv{srv: ts.Server}
+	require.Nil(t, s.ExportOrders(ts.exportFile(), model_helper.ExportOrdersFilterOptions{Scope: "all", FileType: model_helper.ExportFileTypeCsv}, ","))
+
+	content, link := ts.exportedContent(t, model_helper.CSV_EXPORT_SUCCESS)
+	require.NotEmpty(t, link)
+	require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportSuccess, model.ExportEventTypeExportedFileSent}, ts.eventTypes())
+
+	// one row per order line, values of the order are repeated on every row
+	records := exportedRecords(t, content, ',')
+	require.Len(t, records, 2)
+	for _, record := range records {
+		require.Equal(t, order.ID, record["id"])
+		require.Equal(t, "default-channel", record["channel"])
+		require.Equal(t, "buyer@example.com", record["user_email"])
+		require.Equal(t, "30", record["total_gross"])
+		require.Equal(t, "stripe fully_charged 30/30", record["payments"])
+		require.Equal(t, "#1 fulfilled TRACK-1", record["fulfillments"])
+	}
+	require.Equal(t, "tea-50g", records[0]["line_sku"])
+	require.Equal(t, "2", records[0]["line_quantity"])
+	require.Equal(t, "100g", records[1]["line_variant_name"])
+
+	t.Run("upload failure", func(t *testing.T) {
+		ts := newExportTestServer(t)
+		ts.files.writeErr = errors.New("connection reset")
+		ts.store.On("Channel").Return(channelStore)
+		ts.store.On("Order").Return(orderStore)
+		ts.store.On("OrderLine").Return(lineStore)
+		ts.store.On("Payment").Return(paymentStore)
+		ts.store.On("Fulfillment").Return(fulfillmentStore)
+		s := &ServiceCsv{srv: ts.Server}
+
+		appErr := s.ExportOrders(ts.exportFile(), model_helper.ExportOrdersFilterOptions{Scope: "all", FileType: model_helper.ExportFileTypeCsv}, ",")
+		require.NotNil(t, appErr)
+		require.Empty(t, ts.saved)
+		require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportFailed, model.ExportEventTypeExportFailedInfoSent}, ts.eventTypes())
+	})
+}
@@ -0,0 +1,291 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/squirrel"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/modules/filestore"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// s3MinPartSize is the smallest part S3 accepts before the last part of a composed object
+const s3MinPartSize = 5 << 20
+
+// memoryFiles is a file service keeping written files in memory. Like S3, it refuses to append
+// to files smaller than s3MinPartSize.
+type memoryFiles struct {
+	sub_app_iface.FileService
+
+	files map[string][]byte
+	// writeErr makes WriteFile fail after reading the whole file
+	writeErr error
+}
+
+func (f *memoryFiles) WriteFile(fr io.Reader, path string) (int64, *model_helper.AppError) {
+	data, err := io.ReadAll(fr)
+	if err == nil {
+		err = f.writeErr
+	}
+	if err != nil {
+		return 0, model_helper.NewAppError("WriteFile", "app.file.write_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	f.files[path] = data
+	return int64(len(data)), nil
+}
+
+func (f *memoryFiles) AppendFile(fr io.Reader, path string) (int64, *model_helper.AppError) {
+	if len(f.files[path]) < s3MinPartSize {
+		return 0, model_helper.NewAppError("AppendFile", "app.file.append_file.app_error", nil, "source "+path+" is smaller than the minimum part size", http.StatusInternalServerError)
+	}
+
+	data, err := io.ReadAll(fr)
+	if err != nil {
+		return 0, model_helper.NewAppError("AppendFile", "app.file.append_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	f.files[path] = append(f.files[path], data...)
+	return int64(len(data)), nil
+}
+
+func (f *memoryFiles) ReadFile(path string) ([]byte, *model_helper.AppError) {
+	data, ok := f.files[path]
+	if !ok {
+		return nil, model_helper.NewAppError("ReadFile", "app.file.read_file.app_error", nil, path, http.StatusNotFound)
+	}
+	return data, nil
+}
+
+func (f *memoryFiles) FileReader(path string) (filestore.ReadCloseSeeker, *model_helper.AppError) {
+	data, appErr := f.ReadFile(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return memoryFileReader{bytes.NewReader(data)}, nil
+}
+
+type memoryFileReader struct{ *bytes.Reader }
+
+func (memoryFileReader) Close() error { return nil }
+
+func (f *memoryFiles) FileExists(path string) (bool, *model_helper.AppError) {
+	_, ok := f.files[path]
+	return ok, nil
+}
+
+func (f *memoryFiles) PresignedDownloadURL(path string) (string, *model_helper.AppError) {
+	return "https://files.example.com/" + path + "?signature=test", nil
+}
+
+// recordingManager records notifications sent through the plugin manager
+type recordingManager struct {
+	interfaces.PluginManagerInterface
+
+	notified map[string]model_types.JSONString
+}
+
+func (m *recordingManager) Notify(event string, payload model_types.JSONString, _ string, _ string) (any, *model_helper.AppError) {
+	m.notified[event] = payload
+	return nil, nil
+}
+
+type recordingPlugins struct {
+	sub_app_iface.PluginService
+
+	manager *recordingManager
+}
+
+func (p *recordingPlugins) GetPluginManager() interfaces.PluginManagerInterface {
+	return p.manager
+}
+
+type fakeAccounts struct {
+	sub_app_iface.AccountService
+
+	user *model.User
+}
+
+func (a *fakeAccounts) UserById(_ context.Context, userID string) (*model.User, *model_helper.AppError) {
+	if a.user == nil || a.user.ID != userID {
+		return nil, model_helper.NewAppError("UserById", "app.account.missing_user.app_error", nil, "", http.StatusNotFound)
+	}
+	return a.user, nil
+}
+
+type exportTestServer struct {
+	*app.Server
+
+	store   *mocks.Store
+	files   *memoryFiles
+	plugins *recordingPlugins
+	user    *model.User
+	events  []model.ExportEvent
+	saved   []model.ExportFile
+}
+
+// newExportTestServer returns a server with in-memory files and notifications, which records
+// saved export files and export events of the export requested by returned user
+func newExportTestServer(t *testing.T) *exportTestServer {
+	t.Helper()
+
+	memoryStore, err := config.NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := config.NewStoreFromBacking(memoryStore, nil, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { configStore.Close() })
+
+	ts := &exportTestServer{
+		store:   &mocks.Store{},
+		files:   &memoryFiles{files: map[string][]byte{}},
+		plugins: &recordingPlugins{manager: &recordingManager{notified: map[string]model_types.JSONString{}}},
+		user:    &model.User{ID: model_helper.NewId(), Email: "staff@example.com"},
+	}
+
+	exportFileStore := &mocks.CsvExportFileStore{}
+	exportFileStore.On("Upsert", mock.Anything).Return(func(file model.ExportFile) (*model.ExportFile, error) {
+		ts.saved = append(ts.saved, file)
+		return &file, nil
+	})
+	exportEventStore := &mocks.CsvExportEventStore{}
+	exportEventStore.On("Save", mock.Anything).Return(func(event model.ExportEvent) (*model.ExportEvent, error) {
+		ts.events = append(ts.events, event)
+		return &event, nil
+	})
+	ts.store.On("CsvExportFile").Return(exportFileStore)
+	ts.store.On("CsvExportEvent").Return(exportEventStore)
+
+	ts.Server = &app.Server{
+		ConfigStore: configStore,
+		Store:       ts.store,
+		File:        ts.files,
+		Plugin:      ts.plugins,
+		Account:     &fakeAccounts{user: ts.user},
+	}
+	return ts
+}
+
+func (ts *exportTestServer) exportFile() model.ExportFile {
+	return model.ExportFile{ID: model_helper.NewId(), UserID: model_types.NewNullString(ts.user.ID)}
+}
+
+// exportedContent returns content of the file saved last as export content, with the download link sent for it
+func (ts *exportTestServer) exportedContent(t *testing.T, eventType string) (string, string) {
+	t.Helper()
+
+	require.NotEmpty(t, ts.saved)
+	exportFile := ts.saved[len(ts.saved)-1]
+	require.False(t, exportFile.ContentFile.IsNil())
+
+	content, ok := ts.files.files[*exportFile.ContentFile.String]
+	require.True(t, ok)
+
+	payload, ok := ts.plugins.manager.notified[eventType]
+	require.True(t, ok)
+	link, _ := payload["csv_link"].(string)
+	return string(content), link
+}
+
+// exportedRecords parses given exported csv content into records mapping headers to values
+func exportedRecords(t *testing.T, content string, delimiter rune) []map[string]string {
+	t.Helper()
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = delimiter
+	rows, err := reader.ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+
+	records := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := map[string]string{}
+		for idx, header := range rows[0] {
+			record[header] = row[idx]
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (ts *exportTestServer) eventTypes() []model.ExportEventType {
+	types := make([]model.ExportEventType, len(ts.events))
+	for idx, event := range ts.events {
+		types[idx] = event.Type
+	}
+	return types
+}
+
+func TestExportProducts(t *testing.T) {
+	ts := newExportTestServer(t)
+
+	product := &model.Product{ID: model_helper.NewId(), Name: "Green tea"}
+	product.R = product.R.NewStruct()
+	product.R.ProductVariants = model.ProductVariantSlice{
+		{ID: model_helper.NewId(), ProductID: product.ID, Sku: "tea-50g"},
+		{ID: model_helper.NewId(), ProductID: product.ID, Sku: "tea-100g"},
+	}
+
+	productStore := &mocks.ProductStore{}
+	productStore.On("AdvancedFilterQueryBuilder", mock.Anything).Return(squirrel.Select("*").From(model.TableNames.Products), nil)
+	productStore.On("FilterByQuery", mock.Anything).Return(model.ProductSlice{{ID: product.ID}}, nil)
+	productStore.On("FilterByOption", mock.Anything).Return(model.ProductSlice{product}, nil)
+	ts.store.On("Product").Return(productStore)
+
+	s := &ServiceCsv{srv: ts.Server}
+	input := model_helper.ExportProductsFilterOptions{
+		Scope:    "all",
+		FileType: model_helper.ExportFileTypeCsv,
+		ExportInfo: &struct {
+			Attributes []string
+			Warehouses []string
+			Channels   []string
+			Fields     []string
+		}{Fields: []string{"NAME", "VARIANT_SKU"}},
+	}
+
+	require.Nil(t, s.ExportProducts(ts.exportFile(), input, ","))
+
+	content, link := ts.exportedContent(t, model_helper.CSV_EXPORT_SUCCESS)
+	require.Equal(t, "id,name,variant_sku\n"+
+		product.ID+",Green tea,tea-50g\n"+
+		product.ID+",Green tea,tea-100g\n", content)
+	require.Equal(t, "https://files.example.com/"+*ts.saved[0].ContentFile.String+"?signature=test", link)
+	require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportSuccess, model.ExportEventTypeExportedFileSent}, ts.eventTypes())
+
+	t.Run("invalid input", func(t *testing.T) {
+		ts := newExportTestServer(t)
+		s := &ServiceCsv{srv: ts.Server}
+
+		appErr := s.ExportProducts(ts.exportFile(), model_helper.ExportProductsFilterOptions{Scope: "ids", FileType: model_helper.ExportFileTypeCsv}, ",")
+		require.NotNil(t, appErr)
+		require.Empty(t, ts.saved)
+		require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportFailed, model.ExportEventTypeExportFailedInfoSent}, ts.eventTypes())
+		require.Contains(t, ts.plugins.manager.notified, model_helper.CSV_EXPORT_FAILED)
+	})
+
+	t.Run("upload failure", func(t *testing.T) {
+		ts := newExportTestServer(t)
+		ts.files.writeErr = errors.New("connection reset")
+		ts.store.On("Product").Return(productStore)
+		s := &ServiceCsv{srv: ts.Server}
+
+		appErr := s.ExportProducts(ts.exportFile(), input, ",")
+		require.NotNil(t, appErr)
+		require.Empty(t, ts.files.files)
+		require.Empty(t, ts.saved)
+		require.Equal(t, []model.ExportEventType{model.ExportEventTypeExportFailed, model.ExportEventTypeExportFailedInfoSent}, ts.eventTypes())
+	})
+}
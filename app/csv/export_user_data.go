@@ -0,0 +1,273 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"github.com/mattermost/squirrel"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// userDataExportType is the data type of exports bundling personal data of the requesting customer
+const userDataExportType = "user_data"
+
+// StartUserDataExport schedules a job that bundles personal data of given user into a zip archive,
+// then emails the user a link to download it.
+func (s *ServiceCsv) StartUserDataExport(user model.User) (*model.ExportFile, *model_helper.AppError) {
+	exportFile, appErr := s.startExport(userDataExportType, map[string]string{"user_id": user.ID}, user.ID, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	appErr = s.srv.Account.RecordDataPrivacyEvent(user.ID, model.CustomerEventTypeDATA_EXPORT_REQUESTED, model_types.JSONString{"export_file_id": exportFile.ID})
+	if appErr != nil {
+		return nil, appErr
+	}
+	return exportFile, nil
+}
+
+// ExportUserData is called by export job, it writes personal data of the user who requested given export file
+// into a zip archive, then records export events and emails the user a link to download the archive.
+func (s *ServiceCsv) ExportUserData(exportFile model.ExportFile) *model_helper.AppError {
+	appErr := s.exportUserData(exportFile)
+	if appErr != nil {
+		s.handleExportFailure(exportFile, userDataExportType, appErr)
+		return appErr
+	}
+	return nil
+}
+
+func (s *ServiceCsv) exportUserData(exportFile model.ExportFile) *model_helper.AppError {
+	if exportFile.UserID.IsNil() {
+		return model_helper.NewAppError("ExportUserData", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "user_id"}, "user data export must be requested by a user", http.StatusBadRequest)
+	}
+
+	files, appErr := s.getUserDataFiles(*exportFile.UserID.String)
+	if appErr != nil {
+		return appErr
+	}
+
+	archive, appErr := writeUserDataArchive(files)
+	if appErr != nil {
+		return appErr
+	}
+
+	filePath := filepath.Join(exportFilesDir, getFileName("user", "zip"))
+	if _, appErr = s.srv.File.WriteFile(archive, filePath); appErr != nil {
+		return appErr
+	}
+
+	return s.finishExport(exportFile, userDataExportType, filePath)
+}
+
+// userDataFile is a json file of user data archives
+type userDataFile struct {
+	name    string
+	content any
+}
+
+// writeUserDataArchive zips given files, each one is written as indented json
+func writeUserDataArchive(files []userDataFile) (*bytes.Buffer, *model_helper.AppError) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.content, "", "  ")
+		if err != nil {
+			return nil, model_helper.NewAppError("ExportUserData", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		writer, err := archive.Create(file.name)
+		if err == nil {
+			_, err = writer.Write(data)
+		}
+		if err != nil {
+			return nil, model_helper.NewAppError("ExportUserData", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &buf, nil
+}
+
+// getUserDataFiles returns every file of the data archive of given user.
+// Passwords, authentication data and private metadata are never exported.
+func (s *ServiceCsv) getUserDataFiles(userID string) ([]userDataFile, *model_helper.AppError) {
+	user, err := s.srv.Store.User().Get(context.Background(), userID)
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.account.error_finding_users_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	addresses, err := s.srv.Store.Address().FilterByOption(model_helper.AddressFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.AddressWhere.UserID.EQ(userID),
+			qm.OrderBy(model.AddressColumns.CreatedAt),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.account.error_finding_user_address_relations.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	orders, appErr := s.getUserDataOrders(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	orderIDs := lo.Map(orders, func(order map[string]any, _ int) string { return order["id"].(string) })
+
+	payments, err := s.srv.Store.Payment().FilterByOption(model_helper.PaymentFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model_helper.And{
+				squirrel.Eq{model.PaymentTableColumns.OrderID: orderIDs},
+			},
+			qm.OrderBy(model.PaymentColumns.CreatedAt),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.payment.error_finding_payments_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	wishlists, appErr := s.getUserDataWishlists(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	events, err := s.srv.Store.CustomerEvent().FilterByOptions(model_helper.CustomerEventFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.CustomerEventWhere.UserID.EQ(model_types.NewNullString(userID)),
+			qm.OrderBy(model.CustomerEventColumns.Date),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.customer_event.filter_by_opions.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return []userDataFile{
+		{"profile.json", map[string]any{
+			"id":            user.ID,
+			"email":         user.Email,
+			"username":      user.Username,
+			"first_name":    user.FirstName,
+			"last_name":     user.LastName,
+			"nickname":      user.Nickname,
+			"locale":        user.Locale,
+			"timezone":      user.Timezone,
+			"is_active":     user.IsActive,
+			"date_joined":   user.CreatedAt,
+			"last_activity": user.LastActivityAt,
+			"metadata":      user.Metadata,
+		}},
+		{"addresses.json", addresses},
+		{"orders.json", orders},
+		{"payments.json", lo.Map(payments, func(payment *model.Payment, _ int) map[string]any {
+			return map[string]any{
+				"id":              payment.ID,
+				"order_id":        payment.OrderID.String,
+				"gateway":         payment.Gateway,
+				"charge_status":   payment.ChargeStatus,
+				"total":           payment.Total,
+				"captured_amount": payment.CapturedAmount,
+				"currency":        payment.Currency,
+				"cc_brand":        payment.CCBrand,
+				"cc_last_digits":  payment.CCLastDigits,
+				"billing_email":   payment.BillingEmail,
+				"created_at":      payment.CreatedAt,
+				"metadata":        payment.Metadata,
+			}
+		})},
+		{"wishlists.json", wishlists},
+		{"customer_events.json", lo.Map(events, func(event *model.CustomerEvent, _ int) map[string]any {
+			return map[string]any{
+				"date":       event.Date,
+				"type":       event.Type,
+				"order_id":   event.OrderID.String,
+				"parameters": event.Parameters,
+			}
+		})},
+	}, nil
+}
+
+// getUserDataOrders returns orders placed by given user with their lines
+func (s *ServiceCsv) getUserDataOrders(userID string) ([]map[string]any, *model_helper.AppError) {
+	orders, err := s.srv.Store.Order().FilterByOption(model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderWhere.UserID.EQ(model_types.NewNullString(userID)),
+			qm.OrderBy(model.OrderTableColumns.CreatedAt),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.order.error_finding_orders_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(orders) == 0 {
+		return []map[string]any{}, nil
+	}
+
+	lines, err := s.srv.Store.OrderLine().FilterbyOption(model_helper.OrderLineFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderLineWhere.OrderID.IN(lo.Map(orders, func(order *model_helper.CustomOrder, _ int) string { return order.ID })),
+			qm.OrderBy(model.OrderLineColumns.CreatedAt),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.order.error_finding_order_lines_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	linesByOrder := lo.GroupBy(lines, func(line *model.OrderLine) string { return line.OrderID })
+
+	return lo.Map(orders, func(order *model_helper.CustomOrder, _ int) map[string]any {
+		return map[string]any{
+			"id":                  order.ID,
+			"created_at":          order.CreatedAt,
+			"status":              order.Status,
+			"user_email":          order.UserEmail,
+			"currency":            order.Currency,
+			"billing_address_id":  order.BillingAddressID.String,
+			"shipping_address_id": order.ShippingAddressID.String,
+			"shipping_method":     order.ShippingMethodName.String,
+			"customer_note":       order.CustomerNote,
+			"total_net":           order.TotalNetAmount,
+			"total_gross":         order.TotalGrossAmount,
+			"metadata":            order.Metadata,
+			"lines": lo.Map(linesByOrder[order.ID], func(line *model.OrderLine, _ int) map[string]any {
+				return map[string]any{
+					"product_name":     line.ProductName,
+					"variant_name":     line.VariantName,
+					"product_sku":      line.ProductSku.String,
+					"quantity":         line.Quantity,
+					"unit_price_gross": line.UnitPriceGrossAmount,
+				}
+			}),
+		}
+	}), nil
+}
+
+// getUserDataWishlists returns wishlist of given user with products in it
+func (s *ServiceCsv) getUserDataWishlists(userID string) ([]map[string]any, *model_helper.AppError) {
+	wishlist, err := s.srv.Store.Wishlist().GetByOption(model_helper.WishlistFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.WishlistWhere.UserID.EQ(userID)),
+	})
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return []map[string]any{}, nil
+		}
+		return nil, model_helper.NewAppError("ExportUserData", "app.wishlist.error_finding_wishlist.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	items, err := s.srv.Store.WishlistItem().FilterByOption(model_helper.WishlistItemFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.WishlistItemWhere.WishlistID.EQ(wishlist.ID)),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ExportUserData", "app.wishlist.error_finding_wishlist.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return []map[string]any{{
+		"id":          wishlist.ID,
+		"product_ids": lo.Map(items, func(item *model.WishlistItem, _ int) string { return item.ProductID }),
+	}}, nil
+}
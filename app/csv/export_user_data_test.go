@@ -0,0 +1,116 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDataArchive(t *testing.T) {
+	user := &model.User{
+		ID:        model_helper.NewId(),
+		Email:     "jane@example.com",
+		Username:  "jane",
+		FirstName: "Jane",
+		Password:  "hashed-password",
+		AuthData:  model_types.NewNullString("auth-data"),
+	}
+	order := &model_helper.CustomOrder{Order: model.Order{ID: model_helper.NewId(), UserEmail: user.Email}}
+	wishlist := &model.Wishlist{ID: model_helper.NewId(), UserID: user.ID}
+
+	userStore := &mocks.UserStore{}
+	userStore.On("Get", mock.Anything, user.ID).Return(user, nil)
+	addressStore := &mocks.AddressStore{}
+	addressStore.On("FilterByOption", mock.Anything).Return(model.AddressSlice{{ID: model_helper.NewId(), UserID: user.ID, City: "Hanoi"}}, nil)
+	orderStore := &mocks.OrderStore{}
+	orderStore.On("FilterByOption", mock.Anything).Return(model_helper.CustomOrderSlice{order}, nil)
+	orderLineStore := &mocks.OrderLineStore{}
+	orderLineStore.On("FilterbyOption", mock.Anything).Return(model.OrderLineSlice{{ID: model_helper.NewId(), OrderID: order.ID, ProductName: "Tea", Quantity: 2}}, nil)
+	paymentStore := &mocks.PaymentStore{}
+	paymentStore.On("FilterByOption", mock.Anything).Return(model.PaymentSlice{}, nil)
+	wishlistStore := &mocks.WishlistStore{}
+	wishlistStore.On("GetByOption", mock.Anything).Return(wishlist, nil)
+	wishlistItemStore := &mocks.WishlistItemStore{}
+	wishlistItemStore.On("FilterByOption", mock.Anything).Return(model.WishlistItemSlice{{ID: model_helper.NewId(), WishlistID: wishlist.ID, ProductID: "product"}}, nil)
+	customerEventStore := &mocks.CustomerEventStore{}
+	customerEventStore.On("FilterByOptions", mock.Anything).Return(model.CustomerEventSlice{}, nil)
+
+	mockStore := &mocks.Store{}
+	mockStore.On("User").Return(userStore)
+	mockStore.On("Address").Return(addressStore)
+	mockStore.On("Order").Return(orderStore)
+	mockStore.On("OrderLine").Return(orderLineStore)
+	mockStore.On("Payment").Return(paymentStore)
+	mockStore.On("Wishlist").Return(wishlistStore)
+	mockStore.On("WishlistItem").Return(wishlistItemStore)
+	mockStore.On("CustomerEvent").Return(customerEventStore)
+
+	s := &ServiceCsv{srv: &app.Server{Store: mockStore}}
+
+	files, appErr := s.getUserDataFiles(user.ID)
+	require.Nil(t, appErr)
+	buf, appErr := writeUserDataArchive(files)
+	require.Nil(t, appErr)
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	contents := map[string][]byte{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		contents[file.Name] = data
+	}
+	require.ElementsMatch(t, []string{
+		"profile.json",
+		"addresses.json",
+		"orders.json",
+		"payments.json",
+		"wishlists.json",
+		"customer_events.json",
+	}, lo.Keys(contents))
+
+	var profile map[string]any
+	require.NoError(t, json.Unmarshal(contents["profile.json"], &profile))
+	require.Equal(t, user.Email, profile["email"])
+	require.Equal(t, "Jane", profile["first_name"])
+	require.NotContains(t, string(contents["profile.json"]), "hashed-password")
+	require.NotContains(t, string(contents["profile.json"]), "auth-data")
+
+	var orders []map[string]any
+	require.NoError(t, json.Unmarshal(contents["orders.json"], &orders))
+	require.Len(t, orders, 1)
+	require.Equal(t, order.ID, orders[0]["id"])
+	require.Len(t, orders[0]["lines"], 1)
+
+	var wishlists []map[string]any
+	require.NoError(t, json.Unmarshal(contents["wishlists.json"], &wishlists))
+	require.Equal(t, []any{"product"}, wishlists[0]["product_ids"])
+
+	t.Run("user without wishlist", func(t *testing.T) {
+		wishlistStore := &mocks.WishlistStore{}
+		wishlistStore.On("GetByOption", mock.Anything).Return(nil, store.NewErrNotFound("Wishlist", user.ID))
+		mockStore := &mocks.Store{}
+		mockStore.On("Wishlist").Return(wishlistStore)
+		s := &ServiceCsv{srv: &app.Server{Store: mockStore}}
+
+		wishlists, appErr := s.getUserDataWishlists(user.ID)
+		require.Nil(t, appErr)
+		require.Empty(t, wishlists)
+	})
+}
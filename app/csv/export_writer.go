@@ -0,0 +1,172 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/xuri/excelize/v2"
+)
+
+// exportWriter writes exported rows into a file of the file store
+type exportWriter interface {
+	// WriteRows writes given rows to the end of the file
+	WriteRows(rows [][]string) *model_helper.AppError
+	// Close finishes writing the file. The file is complete only after Close returns
+	Close() *model_helper.AppError
+	// Discard stops writing the file without completing it. It does nothing after Close
+	Discard()
+}
+
+var errExportDiscarded = errors.New("export discarded")
+
+func (s *ServiceCsv) newExportWriter(fileType, path, delimiter string) exportWriter {
+	if fileType == model_helper.ExportFileTypeXlsx {
+		return &xlsxExportWriter{files: s.srv.File, path: path}
+	}
+
+	comma, _ := utf8.DecodeRuneInString(delimiter)
+	if comma == utf8.RuneError {
+		comma, _ = utf8.DecodeRuneInString(defaultExportDelimiter)
+	}
+	return &csvExportWriter{files: s.srv.File, path: path, comma: comma}
+}
+
+// csvExportWriter streams rows through a pipe into a single upload to the file store, so only one
+// batch is kept in memory. Backends such as S3 can't append small parts to a file, so the file is
+// never appended to.
+type csvExportWriter struct {
+	files  sub_app_iface.FileService
+	path   string
+	comma  rune
+	pipe   *io.PipeWriter
+	writer *csv.Writer
+	done   chan *model_helper.AppError
+}
+
+func (w *csvExportWriter) WriteRows(rows [][]string) *model_helper.AppError {
+	if w.pipe == nil {
+		reader, pipe := io.Pipe()
+		w.pipe = pipe
+		w.writer = csv.NewWriter(pipe)
+		w.writer.Comma = w.comma
+		w.done = make(chan *model_helper.AppError, 1)
+
+		go func() {
+			_, appErr := w.files.WriteFile(reader, w.path)
+			reader.Close()
+			w.done <- appErr
+		}()
+	}
+
+	err := w.writer.WriteAll(rows)
+	if err != nil {
+		w.pipe.CloseWithError(err)
+		w.pipe = nil
+		if appErr := <-w.done; appErr != nil {
+			return appErr
+		}
+		return model_helper.NewAppError("csvExportWriter.WriteRows", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (w *csvExportWriter) Close() *model_helper.AppError {
+	if w.done == nil {
+		return nil
+	}
+	if w.pipe == nil {
+		return model_helper.NewAppError("csvExportWriter.Close", "app.csv.error_writing_export_file.app_error", nil, "export file is already discarded", http.StatusInternalServerError)
+	}
+
+	w.pipe.Close()
+	w.pipe = nil
+	return <-w.done
+}
+
+func (w *csvExportWriter) Discard() {
+	if w.pipe == nil {
+		return
+	}
+
+	w.pipe.CloseWithError(errExportDiscarded)
+	w.pipe = nil
+	<-w.done
+}
+
+// xlsxExportWriter streams rows into a worksheet, excelize keeps written rows in a temporary file
+// which is uploaded to the file store on Close
+type xlsxExportWriter struct {
+	files  sub_app_iface.FileService
+	path   string
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rowIdx int
+}
+
+func (w *xlsxExportWriter) WriteRows(rows [][]string) *model_helper.AppError {
+	if w.stream == nil {
+		w.file = excelize.NewFile()
+		stream, err := w.file.NewStreamWriter(w.file.GetSheetName(0))
+		if err != nil {
+			return model_helper.NewAppError("xlsxExportWriter.WriteRows", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		w.stream = stream
+	}
+
+	for _, row := range rows {
+		w.rowIdx++
+		cell, err := excelize.CoordinatesToCellName(1, w.rowIdx)
+		if err != nil {
+			return model_helper.NewAppError("xlsxExportWriter.WriteRows", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		values := make([]any, len(row))
+		for idx, value := range row {
+			values[idx] = value
+		}
+		err = w.stream.SetRow(cell, values)
+		if err != nil {
+			return model_helper.NewAppError("xlsxExportWriter.WriteRows", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+func (w *xlsxExportWriter) Close() *model_helper.AppError {
+	if w.file == nil {
+		return nil
+	}
+	defer w.Discard()
+
+	err := w.stream.Flush()
+	if err != nil {
+		return model_helper.NewAppError("xlsxExportWriter.Close", "app.csv.error_writing_export_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	file := w.file
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := file.WriteTo(writer)
+		writer.CloseWithError(err)
+	}()
+
+	_, appErr := w.files.WriteFile(reader, w.path)
+	reader.Close()
+	return appErr
+}
+
+func (w *xlsxExportWriter) Discard() {
+	if w.file == nil {
+		return
+	}
+
+	w.file.Close()
+	w.file = nil
+}
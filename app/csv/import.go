@@ -0,0 +1,170 @@
+package csv
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+)
+
+const (
+	// importFilesDir is directory of the file store that error reports of imports are written into
+	importFilesDir = "import_files"
+)
+
+var (
+	productImportBatchSize = 50
+)
+
+// StartProductsImport schedules a job that imports products from given file of the file store.
+// The file must have the same layout as files written by product export.
+// In dry-run mode the file is only validated, nothing is saved.
+func (s *ServiceCsv) StartProductsImport(filePath, fileType string, dryRun bool, userID string) (*model.Job, *model_helper.AppError) {
+	var invalidField string
+
+	switch {
+	case filePath == "":
+		invalidField = "file_path"
+	case fileType != model_helper.ExportFileTypeCsv && fileType != model_helper.ExportFileTypeXlsx:
+		invalidField = "file_type"
+	}
+	if invalidField != "" {
+		return nil, model_helper.NewAppError("StartProductsImport", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": invalidField}, "", http.StatusBadRequest)
+	}
+
+	exists, appErr := s.srv.File.FileExists(filePath)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !exists {
+		return nil, model_helper.NewAppError("StartProductsImport", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "file_path"}, "file does not exist", http.StatusBadRequest)
+	}
+
+	return s.srv.Jobs.CreateJob(model.JobTypeImportProcess, map[string]any{
+		"data_type": "products",
+		"file_path": filePath,
+		"file_type": fileType,
+		"dry_run":   strconv.FormatBool(dryRun),
+		"user_id":   userID,
+	})
+}
+
+// ProcessImportJob runs the import scheduled by given import job
+func (s *ServiceCsv) ProcessImportJob(job model.Job) *model_helper.AppError {
+	dataType, _ := job.Data.Get("data_type", "").(string)
+	filePath, _ := job.Data.Get("file_path", "").(string)
+	fileType, _ := job.Data.Get("file_type", "").(string)
+	dryRun, _ := job.Data.Get("dry_run", "").(string)
+
+	switch dataType {
+	case "products":
+		return s.ImportProducts(job, filePath, fileType, dryRun == "true")
+
+	default:
+		return model_helper.NewAppError("ProcessImportJob", "app.csv.invalid_import_job_data.app_error", nil, "unknown import data type: "+dataType, http.StatusInternalServerError)
+	}
+}
+
+// ImportProducts is called by import job. It upserts products, variants, attribute values, channel listings,
+// stocks and media urls read from given file in transactional batches.
+//
+// Rows that can't be imported are skipped and reported in an error report file, path of the report is saved into
+// "error_report" of the job data. Progress of the import is saved into the job after every batch.
+func (s *ServiceCsv) ImportProducts(job model.Job, filePath, fileType string, dryRun bool) *model_helper.AppError {
+	rows, appErr := s.readImportRows(fileType, filePath, defaultExportDelimiter)
+	if appErr != nil {
+		return appErr
+	}
+	if len(rows) < 2 {
+		return model_helper.NewAppError("ImportProducts", "app.csv.import_file_empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	importer, headerErrs, appErr := s.newProductImporter(rows[0], dryRun)
+	if appErr != nil {
+		return appErr
+	}
+	if len(headerErrs) > 0 {
+		appErr = s.finishImport(job, filePath, headerErrs)
+		if appErr != nil {
+			return appErr
+		}
+		return model_helper.NewAppError("ImportProducts", "app.csv.invalid_import_headers.app_error", nil, fmt.Sprintf("%d invalid columns", len(headerErrs)), http.StatusBadRequest)
+	}
+
+	groups := groupProductImportRows(rows)
+
+	var (
+		totalRows        int
+		processedRows    int
+		failedRows       int
+		importedProducts int
+		errs             []importError
+	)
+	for _, group := range groups {
+		totalRows += len(group.rows)
+	}
+	job.Data.Set("total_rows", totalRows)
+
+	for start := 0; start < len(groups); start += productImportBatchSize {
+		batch := groups[start:min(start+productImportBatchSize, len(groups))]
+
+		failed, appErr := importer.importBatch(batch)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, group := range batch {
+			processedRows += len(group.rows)
+			if groupErrs, ok := failed[group]; ok {
+				failedRows += len(group.rows)
+				errs = append(errs, groupErrs...)
+				continue
+			}
+			importedProducts++
+		}
+
+		job.Data.Set("processed_rows", processedRows)
+		job.Data.Set("failed_rows", failedRows)
+		job.Data.Set("imported_products", importedProducts)
+		job.LastActivityAt = model_helper.GetMillis()
+		appErr = s.srv.Jobs.SetJobProgress(job, int64(processedRows*100/totalRows))
+		if appErr != nil {
+			return appErr
+		}
+	}
+
+	return s.finishImport(job, filePath, errs)
+}
+
+// finishImport writes given errors into an error report file and saves path of the report into data of given job
+func (s *ServiceCsv) finishImport(job model.Job, filePath string, errs []importError) *model_helper.AppError {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	fileName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	reportPath := filepath.Join(importFilesDir, fmt.Sprintf("%s_%s_errors.%s", fileName, job.ID, model_helper.ExportFileTypeCsv))
+
+	rows := [][]string{{"row", "column", "message"}}
+	for _, err := range errs {
+		rows = append(rows, []string{strconv.Itoa(err.Line), err.Column, err.Message})
+	}
+
+	writer := s.newExportWriter(model_helper.ExportFileTypeCsv, reportPath, defaultExportDelimiter)
+	defer writer.Discard()
+	appErr := writer.WriteRows(rows)
+	if appErr != nil {
+		return appErr
+	}
+	appErr = writer.Close()
+	if appErr != nil {
+		return appErr
+	}
+
+	job.Data.Set("error_report", reportPath)
+	return s.srv.Jobs.UpdateInProgressJobData(job)
+}
@@ -0,0 +1,975 @@
+package csv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/site-name/decimal"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/measurement"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// importHeaderPattern matches attribute, warehouse and channel headers produced by product export.
+// E.g "color (product attribute)", "main-warehouse (warehouse quantity)", "default-channel (channel published)"
+var importHeaderPattern = regexp.MustCompile(`^(.+) \((product attribute|warehouse quantity|channel ([a-z ]+))\)$`)
+
+// importError describes a problem of one cell of an import file. Column is empty for problems of a whole row
+type importError struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// importColumn describes what a column of an import file holds
+type importColumn struct {
+	header    string
+	field     string // export field of product and variant columns, listing field of channel columns
+	attribute *model.Attribute
+	warehouse *model.Warehouse
+	channel   *model.Channel
+}
+
+type productImportRow struct {
+	line   int                    // line number in the import file, the header is line 1
+	values model_helper.StringMap // maps headers to trimmed cell values
+}
+
+// productImportGroup holds all rows of one product
+type productImportGroup struct {
+	productID string
+	rows      []productImportRow
+}
+
+// productImportPlan holds everything an import group writes, it is built without touching the database
+type productImportPlan struct {
+	group           *productImportGroup
+	product         *model.Product // product ID is empty for new products
+	attributes      []attributeImportPlan
+	media           model.ProductMediumSlice
+	channelListings model.ProductChannelListingSlice
+	variants        []variantImportPlan
+}
+
+type attributeImportPlan struct {
+	assignment *model.CategoryAttribute
+	assigned   *model.AssignedProductAttribute // nil if the attribute is not assigned to the product yet
+	values     model.AttributeValueSlice       // values with empty ID are created on import
+}
+
+type variantImportPlan struct {
+	line            int
+	variant         *model.ProductVariant // variant ID is empty for new variants
+	stocks          model.StockSlice
+	channelListings model.ProductVariantChannelListingSlice
+}
+
+// productImporter imports products from rows laid out the same way product export writes them.
+//
+// NOTE: empty cells leave values unchanged, collections and variant media columns are not imported.
+type productImporter struct {
+	s         *ServiceCsv
+	dryRun    bool
+	columns   []importColumn
+	relations []qm.QueryMod
+
+	categories    map[string]*model.Category       // categories by slug, nil values cache missing slugs
+	createdValues map[string]*model.AttributeValue // attribute values created by current transaction
+}
+
+var productImportChannelFields = []string{"product_currency_code", "published", "publication_date", "searchable", "available_for_purchase"}
+
+var variantImportChannelFields = []string{"price_amount", "variant_cost_price", "variant_preorder_quantity_threshold", "variant_currency_code"}
+
+// newProductImporter resolves given headers of an import file. Problems of the headers are returned as import errors of line 1.
+func (s *ServiceCsv) newProductImporter(headers []string, dryRun bool) (*productImporter, []importError, *model_helper.AppError) {
+	fieldsMapping := map[string]string{"id": "id"}
+	for _, aMap := range ProductExportFields.HEADERS_TO_FIELDS_MAPPING {
+		for header, field := range aMap {
+			fieldsMapping[header] = field
+		}
+	}
+
+	var (
+		errs           []importError
+		columns        = make([]importColumn, len(headers))
+		seen           = map[string]bool{}
+		attributeSlugs []string
+		warehouseSlugs []string
+		channelSlugs   []string
+	)
+
+	for idx, header := range headers {
+		column := importColumn{header: header}
+
+		if seen[header] {
+			errs = append(errs, importError{Line: 1, Column: header, Message: "duplicated column"})
+		}
+		seen[header] = true
+
+		if field, ok := fieldsMapping[header]; ok {
+			column.field = field
+			columns[idx] = column
+			continue
+		}
+
+		match := importHeaderPattern.FindStringSubmatch(header)
+		switch {
+		case match == nil:
+			errs = append(errs, importError{Line: 1, Column: header, Message: "unknown column"})
+		case match[2] == "product attribute":
+			attributeSlugs = append(attributeSlugs, match[1])
+		case match[2] == "warehouse quantity":
+			warehouseSlugs = append(warehouseSlugs, match[1])
+		default:
+			column.field = strings.ReplaceAll(match[3], " ", "_")
+			if !lo.Contains(productImportChannelFields, column.field) && !lo.Contains(variantImportChannelFields, column.field) {
+				errs = append(errs, importError{Line: 1, Column: header, Message: "unknown channel field"})
+			}
+			channelSlugs = append(channelSlugs, match[1])
+		}
+		columns[idx] = column
+	}
+
+	if !seen["id"] {
+		errs = append(errs, importError{Line: 1, Column: "id", Message: "missing column"})
+	}
+
+	attributes, appErr := s.srv.Attribute.AttributesByOption(model_helper.AttributeFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.AttributeWhere.Slug.IN(attributeSlugs),
+			model.AttributeWhere.IsVariantOnly.EQ(false),
+			qm.Load(model.AttributeRels.AttributeValues),
+			qm.Load(model.AttributeRels.CategoryAttributes),
+		),
+	})
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	warehouses, appErr := s.srv.Warehouse.WarehousesByOption(model_helper.WarehouseFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.WarehouseWhere.Slug.IN(warehouseSlugs)),
+	})
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	channels, appErr := s.srv.Channel.ChannelsByOption(model_helper.ChannelFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ChannelWhere.Slug.IN(channelSlugs)),
+	})
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	attributesBySlug := lo.KeyBy(attributes, func(a *model.Attribute) string { return a.Slug })
+	warehousesBySlug := lo.KeyBy(warehouses, func(w *model.Warehouse) string { return w.Slug })
+	channelsBySlug := lo.KeyBy(channels, func(c *model.Channel) string { return c.Slug })
+
+	for idx, column := range columns {
+		match := importHeaderPattern.FindStringSubmatch(column.header)
+		if match == nil {
+			continue
+		}
+
+		switch match[2] {
+		case "product attribute":
+			column.attribute = attributesBySlug[match[1]]
+			if column.attribute == nil {
+				errs = append(errs, importError{Line: 1, Column: column.header, Message: fmt.Sprintf("product attribute %q does not exist", match[1])})
+			}
+		case "warehouse quantity":
+			column.warehouse = warehousesBySlug[match[1]]
+			if column.warehouse == nil {
+				errs = append(errs, importError{Line: 1, Column: column.header, Message: fmt.Sprintf("warehouse %q does not exist", match[1])})
+			}
+		default:
+			column.channel = channelsBySlug[match[1]]
+			if column.channel == nil {
+				errs = append(errs, importError{Line: 1, Column: column.header, Message: fmt.Sprintf("channel %q does not exist", match[1])})
+			}
+		}
+		columns[idx] = column
+	}
+
+	importer := &productImporter{
+		s:       s,
+		dryRun:  dryRun,
+		columns: columns,
+		relations: productExportRelations(
+			[]string{"media__image"},
+			lo.Map(attributes, func(a *model.Attribute, _ int) string { return a.ID }),
+			lo.Map(warehouses, func(w *model.Warehouse, _ int) string { return w.ID }),
+			lo.Map(channels, func(c *model.Channel, _ int) string { return c.ID }),
+		),
+		categories: map[string]*model.Category{},
+	}
+	return importer, errs, nil
+}
+
+// groupProductImportRows groups data rows of an import file by product. Rows of existing products are told apart by
+// product id, rows of new products by product name.
+func groupProductImportRows(rows [][]string) []*productImportGroup {
+	headers := rows[0]
+
+	var (
+		groups []*productImportGroup
+		byKey  = map[string]*productImportGroup{}
+	)
+	for idx, row := range rows[1:] {
+		if isEmptyImportRow(row) {
+			continue
+		}
+
+		values := model_helper.StringMap{}
+		for col, header := range headers {
+			values[header] = strings.TrimSpace(row[col])
+		}
+
+		key := values["id"]
+		if key == "" {
+			key = "name:" + values["name"]
+		}
+
+		group, ok := byKey[key]
+		if !ok {
+			group = &productImportGroup{productID: values["id"]}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.rows = append(group.rows, productImportRow{line: idx + 2, values: values})
+	}
+
+	return groups
+}
+
+// importBatch imports given groups in one transaction. Groups that can't be imported are left out and the rest of
+// the batch is retried, so one bad product doesn't fail the whole batch. In dry-run mode the transaction is always rolled back.
+func (imp *productImporter) importBatch(groups []*productImportGroup) (map[*productImportGroup][]importError, *model_helper.AppError) {
+	failed := map[*productImportGroup][]importError{}
+
+	productIDs := lo.Uniq(lo.FilterMap(groups, func(g *productImportGroup, _ int) (string, bool) { return g.productID, g.productID != "" }))
+	products, err := imp.s.srv.Store.Product().FilterByOption(model_helper.ProductFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(append([]qm.QueryMod{model.ProductWhere.ID.IN(productIDs)}, imp.relations...)...),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("importBatch", "app.csv.error_finding_products_by_query.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	existing := lo.KeyBy(products, func(p *model.Product) string { return p.ID })
+
+	var plans []*productImportPlan
+	for _, group := range groups {
+		plan, errs, appErr := imp.planProduct(group, existing)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if len(errs) > 0 {
+			failed[group] = errs
+			continue
+		}
+		plans = append(plans, plan)
+	}
+
+	for len(plans) > 0 {
+		failedIdx, importErr, appErr := imp.applyPlans(plans)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if importErr == nil {
+			break
+		}
+
+		failed[plans[failedIdx].group] = []importError{*importErr}
+		plans = append(plans[:failedIdx], plans[failedIdx+1:]...)
+	}
+
+	return failed, nil
+}
+
+// applyPlans writes given plans in a transaction. If a plan fails, the transaction is rolled back and
+// index of the failed plan is returned with the error.
+func (imp *productImporter) applyPlans(plans []*productImportPlan) (int, *importError, *model_helper.AppError) {
+	tx, err := imp.s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return 0, nil, model_helper.NewAppError("applyPlans", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer imp.s.srv.Store.FinalizeTransaction(tx)
+
+	imp.createdValues = map[string]*model.AttributeValue{}
+
+	productIDs := make([]string, 0, len(plans))
+	for idx, plan := range plans {
+		productID, importErr := imp.applyPlan(tx, plan)
+		if importErr != nil {
+			return idx, importErr, nil
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	if imp.dryRun {
+		return 0, nil, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, nil, model_helper.NewAppError("applyPlans", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// products are already saved, so a failure to index them doesn't fail the import
+	if appErr := imp.s.srv.Product.IndexProducts(productIDs); appErr != nil {
+		slog.Error("failed to index imported products", slog.Int("products", len(productIDs)), slog.Err(appErr))
+	}
+	return 0, nil, nil
+}
+
+// applyPlan writes given plan and returns id of the saved product. Records of the plan are copied before saving,
+// so the plan can be applied again after a rollback.
+func (imp *productImporter) applyPlan(tx boil.ContextTransactor, plan *productImportPlan) (string, *importError) {
+	var (
+		store    = imp.s.srv.Store
+		line     = plan.group.rows[0].line
+		rowError = func(line int, err error) *importError {
+			return &importError{Line: line, Message: err.Error()}
+		}
+	)
+
+	productToSave := *plan.product
+	productToSave.SearchIndexDirty = model_types.NewNullBool(true)
+	product, err := store.Product().Save(tx, productToSave)
+	if err != nil {
+		return "", rowError(line, err)
+	}
+
+	for _, attributePlan := range plan.attributes {
+		assigned := attributePlan.assigned
+		if assigned == nil {
+			assigned, err = store.AssignedProductAttribute().Save(tx, model.AssignedProductAttribute{ProductID: product.ID, AssignmentID: attributePlan.assignment.ID})
+			if err != nil {
+				return "", rowError(line, err)
+			}
+		} else if assignedValues := assigned.R.GetAssignmentAssignedProductAttributeValues(); len(assignedValues) > 0 {
+			_, err = store.AssignedProductAttributeValue().Delete(tx, lo.Map(assignedValues, func(v *model.AssignedProductAttributeValue, _ int) string { return v.ID }))
+			if err != nil {
+				return "", rowError(line, err)
+			}
+		}
+
+		assignedValues := model.AssignedProductAttributeValueSlice{}
+		for idx, value := range attributePlan.values {
+			valueID, err := imp.saveAttributeValue(tx, value)
+			if err != nil {
+				return "", rowError(line, err)
+			}
+			assignedValues = append(assignedValues, &model.AssignedProductAttributeValue{
+				ValueID:      valueID,
+				AssignmentID: assigned.ID,
+				SortOrder:    model_types.NewNullInt(idx),
+			})
+		}
+		_, err = store.AssignedProductAttributeValue().Save(tx, assignedValues)
+		if err != nil {
+			return "", rowError(line, err)
+		}
+	}
+
+	if len(plan.media) > 0 {
+		media := lo.Map(plan.media, func(m *model.ProductMedium, _ int) *model.ProductMedium {
+			res := *m
+			res.ProductID = product.ID
+			return &res
+		})
+		_, err = store.ProductMedia().Upsert(tx, media)
+		if err != nil {
+			return "", rowError(line, err)
+		}
+	}
+
+	if len(plan.channelListings) > 0 {
+		listings := lo.Map(plan.channelListings, func(l *model.ProductChannelListing, _ int) *model.ProductChannelListing {
+			res := *l
+			res.ProductID = product.ID
+			return &res
+		})
+		_, err = store.ProductChannelListing().Upsert(tx, listings)
+		if err != nil {
+			return "", rowError(line, err)
+		}
+	}
+
+	var firstVariantID string
+	for _, variantPlan := range plan.variants {
+		variantToSave := *variantPlan.variant
+		variantToSave.ProductID = product.ID
+		variant, err := store.ProductVariant().Upsert(tx, variantToSave)
+		if err != nil {
+			return "", rowError(variantPlan.line, err)
+		}
+		if firstVariantID == "" {
+			firstVariantID = variant.ID
+		}
+
+		if len(variantPlan.stocks) > 0 {
+			stocks := lo.Map(variantPlan.stocks, func(s *model.Stock, _ int) *model.Stock {
+				res := *s
+				res.ProductVariantID = variant.ID
+				return &res
+			})
+			_, err = store.Stock().Upsert(tx, stocks)
+			if err != nil {
+				return "", rowError(variantPlan.line, err)
+			}
+		}
+
+		if len(variantPlan.channelListings) > 0 {
+			listings := lo.Map(variantPlan.channelListings, func(l *model.ProductVariantChannelListing, _ int) *model.ProductVariantChannelListing {
+				res := *l
+				res.VariantID = variant.ID
+				return &res
+			})
+			_, err = store.ProductVariantChannelListing().Upsert(tx, listings)
+			if err != nil {
+				return "", rowError(variantPlan.line, err)
+			}
+		}
+	}
+
+	if product.DefaultVariantID.IsNil() && firstVariantID != "" {
+		product.DefaultVariantID = model_types.NewNullString(firstVariantID)
+		_, err = store.Product().Save(tx, *product)
+		if err != nil {
+			return "", rowError(line, err)
+		}
+	}
+
+	return product.ID, nil
+}
+
+// saveAttributeValue returns id of given attribute value, creating the value if it does not exist yet
+func (imp *productImporter) saveAttributeValue(tx boil.ContextTransactor, value *model.AttributeValue) (string, error) {
+	if value.ID != "" {
+		return value.ID, nil
+	}
+
+	key := value.AttributeID + "|" + strings.ToLower(value.Name)
+	if created, ok := imp.createdValues[key]; ok {
+		return created.ID, nil
+	}
+
+	valueToSave := *value
+	values, err := imp.s.srv.Store.AttributeValue().Upsert(tx, model.AttributeValueSlice{&valueToSave})
+	if err != nil {
+		return "", err
+	}
+
+	imp.createdValues[key] = values[0]
+	return values[0].ID, nil
+}
+
+// planProduct validates rows of given group and prepares records to be written. Product fields, attributes, media and
+// product channel listings are read from the first row of the group.
+func (imp *productImporter) planProduct(group *productImportGroup, existing map[string]*model.Product) (*productImportPlan, []importError, *model_helper.AppError) {
+	var (
+		errs     []importError
+		head     = group.rows[0]
+		addError = func(line int, column, format string, args ...any) {
+			errs = append(errs, importError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+		}
+	)
+
+	plan := &productImportPlan{group: group, product: &model.Product{}}
+	if group.productID != "" {
+		product, ok := existing[group.productID]
+		if !ok {
+			addError(head.line, "id", "product %q does not exist", group.productID)
+			return nil, errs, nil
+		}
+		plan.product = product
+	}
+	product := plan.product
+
+	for _, column := range imp.columns {
+		value := head.values[column.header]
+		if value == "" {
+			continue
+		}
+
+		switch column.field {
+		case "name":
+			product.Name = value
+		case "description_as_str":
+			description := model_types.JSONString{}
+			if err := description.Scan([]byte(value)); err != nil {
+				addError(head.line, column.header, "description must be a JSON object")
+				continue
+			}
+			product.Description = description
+		case "category__slug":
+			category, appErr := imp.categoryBySlug(value)
+			if appErr != nil {
+				return nil, nil, appErr
+			}
+			if category == nil {
+				addError(head.line, column.header, "category %q does not exist", value)
+				continue
+			}
+			product.CategoryID = category.ID
+		case "charge_taxes":
+			chargeTaxes, err := strconv.ParseBool(value)
+			if err != nil {
+				addError(head.line, column.header, "invalid boolean %q", value)
+				continue
+			}
+			product.ChargeTaxes = model_types.NewNullBool(chargeTaxes)
+		case "product_weight":
+			weight, unit, err := parseImportWeight(value)
+			if err != nil {
+				addError(head.line, column.header, "%s", err)
+				continue
+			}
+			product.Weight = model_types.NewNullFloat32(weight)
+			if unit != "" {
+				product.WeightUnit = unit
+			}
+		case "media__image":
+			plan.media = imp.planMedia(product, strings.Split(value, ","), func(format string, args ...any) {
+				addError(head.line, column.header, format, args...)
+			})
+		}
+	}
+	if product.ID == "" && product.Name == "" {
+		addError(head.line, "name", "value is required for new products")
+	}
+	if product.ID == "" && product.CategoryID == "" && head.values["category"] == "" {
+		addError(head.line, "category", "value is required for new products")
+	}
+
+	for _, column := range imp.columns {
+		value := head.values[column.header]
+		if column.attribute == nil || value == "" || product.CategoryID == "" {
+			continue
+		}
+
+		attributePlan, err := imp.planAttribute(product, column.attribute, value)
+		if err != nil {
+			addError(head.line, column.header, "%s", err)
+			continue
+		}
+		plan.attributes = append(plan.attributes, *attributePlan)
+	}
+
+	listings := map[string]*model.ProductChannelListing{}
+	for _, column := range imp.columns {
+		value := head.values[column.header]
+		if column.channel == nil || value == "" || !lo.Contains(productImportChannelFields, column.field) {
+			continue
+		}
+
+		listing, ok := listings[column.channel.ID]
+		if !ok {
+			listing = findOrNewProductChannelListing(product, column.channel)
+			listings[column.channel.ID] = listing
+			plan.channelListings = append(plan.channelListings, listing)
+		}
+		if err := setProductChannelListingField(listing, column.field, value); err != nil {
+			addError(head.line, column.header, "%s", err)
+		}
+	}
+
+	seenVariants := map[*model.ProductVariant]bool{}
+	for _, row := range group.rows {
+		if !imp.hasVariantData(row) {
+			continue
+		}
+
+		variantPlan, rowErrs := imp.planVariant(product, row)
+		errs = append(errs, rowErrs...)
+		if variantPlan == nil {
+			continue
+		}
+		if variantPlan.variant.ID != "" && seenVariants[variantPlan.variant] {
+			addError(row.line, "", "variant %q appears more than once", variantPlan.variant.ID)
+			continue
+		}
+		seenVariants[variantPlan.variant] = true
+		plan.variants = append(plan.variants, *variantPlan)
+	}
+
+	return plan, errs, nil
+}
+
+func (imp *productImporter) hasVariantData(row productImportRow) bool {
+	return lo.ContainsBy(imp.columns, func(column importColumn) bool {
+		if row.values[column.header] == "" {
+			return false
+		}
+		return column.warehouse != nil ||
+			column.channel != nil && lo.Contains(variantImportChannelFields, column.field) ||
+			strings.HasPrefix(column.field, "variants__") && column.field != "variants__media__image" ||
+			column.field == "variant_weight"
+	})
+}
+
+func (imp *productImporter) planVariant(product *model.Product, row productImportRow) (*variantImportPlan, []importError) {
+	var (
+		errs     []importError
+		addError = func(column, format string, args ...any) {
+			errs = append(errs, importError{Line: row.line, Column: column, Message: fmt.Sprintf(format, args...)})
+		}
+		variantID = row.values["variant_id"]
+		sku       = row.values["variant_sku"]
+		variants  = product.R.GetProductVariants()
+		variant   *model.ProductVariant
+	)
+
+	switch {
+	case variantID != "":
+		variant, _ = lo.Find(variants, func(v *model.ProductVariant) bool { return v.ID == variantID })
+		if variant == nil {
+			addError("variant_id", "variant %q does not belong to the product", variantID)
+			return nil, errs
+		}
+	case sku != "":
+		variant, _ = lo.Find(variants, func(v *model.ProductVariant) bool { return v.Sku == sku })
+	}
+	if variant == nil {
+		variant = &model.ProductVariant{Name: lo.Ternary(sku != "", sku, product.Name)}
+	}
+
+	plan := &variantImportPlan{line: row.line, variant: variant}
+	listings := map[string]*model.ProductVariantChannelListing{}
+
+	for _, column := range imp.columns {
+		value := row.values[column.header]
+		if value == "" {
+			continue
+		}
+
+		switch {
+		case column.warehouse != nil:
+			quantity, err := strconv.Atoi(value)
+			if err != nil || quantity < 0 {
+				addError(column.header, "quantity must be a non negative integer")
+				continue
+			}
+			stock, _ := lo.Find(variant.R.GetStocks(), func(s *model.Stock) bool { return s.WarehouseID == column.warehouse.ID })
+			if stock == nil {
+				stock = &model.Stock{WarehouseID: column.warehouse.ID}
+			}
+			stock.Quantity = quantity
+			plan.stocks = append(plan.stocks, stock)
+
+		case column.channel != nil:
+			if !lo.Contains(variantImportChannelFields, column.field) {
+				continue
+			}
+			listing, ok := listings[column.channel.ID]
+			if !ok {
+				listing = findOrNewVariantChannelListing(variant, column.channel)
+				listings[column.channel.ID] = listing
+				plan.channelListings = append(plan.channelListings, listing)
+			}
+			if err := setVariantChannelListingField(listing, column.field, value); err != nil {
+				addError(column.header, "%s", err)
+			}
+
+		default:
+			if err := setVariantField(variant, column.field, value); err != nil {
+				addError(column.header, "%s", err)
+			}
+		}
+	}
+
+	return plan, errs
+}
+
+func setVariantField(variant *model.ProductVariant, field, value string) error {
+	switch field {
+	case "variants__sku":
+		variant.Sku = value
+	case "variant_weight":
+		weight, unit, err := parseImportWeight(value)
+		if err != nil {
+			return err
+		}
+		variant.Weight = model_types.NewNullFloat32(weight)
+		if unit != "" {
+			variant.WeightUnit = unit
+		}
+	case "variants__is_preorder":
+		isPreorder, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		variant.IsPreorder = isPreorder
+	case "variants__preorder_global_threshold":
+		threshold, err := strconv.Atoi(value)
+		if err != nil || threshold < 0 {
+			return fmt.Errorf("threshold must be a non negative integer")
+		}
+		variant.PreorderGlobalThreshold = model_types.NewNullInt(threshold)
+	case "variants__preorder_end_date":
+		endDate, err := parseImportTime(value)
+		if err != nil {
+			return err
+		}
+		variant.PreorderEndDate = model_types.NewNullInt64(endDate.UnixMilli())
+	}
+	return nil
+}
+
+func findOrNewProductChannelListing(product *model.Product, channel *model.Channel) *model.ProductChannelListing {
+	listing, _ := lo.Find(product.R.GetProductChannelListings(), func(l *model.ProductChannelListing) bool { return l.ChannelID == channel.ID })
+	if listing == nil {
+		listing = &model.ProductChannelListing{ChannelID: channel.ID, Currency: channel.Currency}
+	}
+	return listing
+}
+
+func setProductChannelListingField(listing *model.ProductChannelListing, field, value string) error {
+	switch field {
+	case "product_currency_code":
+		currency := model.Currency(strings.ToUpper(value))
+		if currency.IsValid() != nil {
+			return fmt.Errorf("invalid currency code %q", value)
+		}
+		listing.Currency = currency
+	case "published", "searchable":
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		if field == "published" {
+			listing.IsPublished = boolValue
+		} else {
+			listing.VisibleInListings = boolValue
+		}
+	case "publication_date", "available_for_purchase":
+		date, err := parseImportTime(value)
+		if err != nil {
+			return err
+		}
+		millis := model_types.NewNullInt64(date.UnixMilli())
+		if field == "publication_date" {
+			listing.PublicationDate = millis
+		} else {
+			listing.AvailableForPurchaseAt = millis
+		}
+	}
+	return nil
+}
+
+func findOrNewVariantChannelListing(variant *model.ProductVariant, channel *model.Channel) *model.ProductVariantChannelListing {
+	listing, _ := lo.Find(variant.R.GetVariantProductVariantChannelListings(), func(l *model.ProductVariantChannelListing) bool { return l.ChannelID == channel.ID })
+	if listing == nil {
+		listing = &model.ProductVariantChannelListing{
+			ChannelID: channel.ID,
+			Currency:  model.NullCurrency{Val: channel.Currency, Valid: true},
+		}
+	}
+	return listing
+}
+
+func setVariantChannelListingField(listing *model.ProductVariantChannelListing, field, value string) error {
+	switch field {
+	case "price_amount", "variant_cost_price":
+		amount, err := decimal.NewFromString(value)
+		if err != nil || amount.IsNegative() {
+			return fmt.Errorf("invalid amount %q", value)
+		}
+		if field == "price_amount" {
+			listing.PriceAmount = model_types.NewNullDecimal(amount)
+		} else {
+			listing.CostPriceAmount = model_types.NewNullDecimal(amount)
+		}
+	case "variant_preorder_quantity_threshold":
+		threshold, err := strconv.Atoi(value)
+		if err != nil || threshold < 0 {
+			return fmt.Errorf("threshold must be a non negative integer")
+		}
+		listing.PreorderQuantityThreshold = model_types.NewNullInt(threshold)
+	case "variant_currency_code":
+		currency := model.Currency(strings.ToUpper(value))
+		if currency.IsValid() != nil {
+			return fmt.Errorf("invalid currency code %q", value)
+		}
+		listing.Currency = model.NullCurrency{Val: currency, Valid: true}
+	}
+	return nil
+}
+
+// planMedia returns new external media of given product for given urls. Urls the product already has are skipped,
+// existing media of the product are kept.
+func (imp *productImporter) planMedia(product *model.Product, urls []string, addError func(format string, args ...any)) model.ProductMediumSlice {
+	existingURLs := lo.Map(product.R.GetProductMedia(), func(m *model.ProductMedium, _ int) string { return imp.s.getMediaURL(m) })
+
+	var res model.ProductMediumSlice
+	for _, rawURL := range urls {
+		rawURL = strings.TrimSpace(rawURL)
+		if rawURL == "" || lo.Contains(existingURLs, rawURL) {
+			continue
+		}
+
+		parsedURL, err := url.ParseRequestURI(rawURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+			addError("invalid media url %q", rawURL)
+			continue
+		}
+
+		res = append(res, &model.ProductMedium{
+			Type:        model.ProductMediaTypeIMAGE,
+			ExternalURL: model_types.NewNullString(rawURL),
+			SortOrder:   model_types.NewNullInt(len(existingURLs) + len(res)),
+		})
+		existingURLs = append(existingURLs, rawURL)
+	}
+
+	return res
+}
+
+// planAttribute resolves values of given product attribute. The attribute must be assigned to category of the product.
+func (imp *productImporter) planAttribute(product *model.Product, attribute *model.Attribute, value string) (*attributeImportPlan, error) {
+	assignment, _ := lo.Find(attribute.R.GetCategoryAttributes(), func(ca *model.CategoryAttribute) bool { return ca.CategoryID == product.CategoryID })
+	if assignment == nil {
+		return nil, fmt.Errorf("attribute %q is not assigned to category of the product", attribute.Slug)
+	}
+
+	values, err := planAttributeValues(attribute, value)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned, _ := lo.Find(product.R.GetAssignedProductAttributes(), func(a *model.AssignedProductAttribute) bool { return a.AssignmentID == assignment.ID })
+	return &attributeImportPlan{
+		assignment: assignment,
+		assigned:   assigned,
+		values:     values,
+	}, nil
+}
+
+// planAttributeValues parses given cell value based on input type of given attribute.
+// Existing values are matched by name, values that don't exist yet are returned with empty ID.
+func planAttributeValues(attribute *model.Attribute, cellValue string) (model.AttributeValueSlice, error) {
+	findOrNew := func(name string, match func(v *model.AttributeValue) bool) *model.AttributeValue {
+		value, _ := lo.Find(attribute.R.GetAttributeValues(), match)
+		if value == nil {
+			value = &model.AttributeValue{AttributeID: attribute.ID, Name: name}
+		}
+		return value
+	}
+	findOrNewByName := func(name string) *model.AttributeValue {
+		return findOrNew(name, func(v *model.AttributeValue) bool { return strings.EqualFold(v.Name, name) || v.Slug == name })
+	}
+
+	switch attribute.InputType {
+	case model.AttributeInputTypeDropdown, model.AttributeInputTypeSwatch:
+		return model.AttributeValueSlice{findOrNewByName(cellValue)}, nil
+
+	case model.AttributeInputTypeMultiselect:
+		var res model.AttributeValueSlice
+		for _, name := range strings.Split(cellValue, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				res = append(res, findOrNewByName(name))
+			}
+		}
+		return res, nil
+
+	case model.AttributeInputTypeNumeric:
+		fields := strings.Fields(cellValue)
+		number, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", cellValue)
+		}
+		return model.AttributeValueSlice{findOrNewByName(strconv.FormatFloat(number, 'f', -1, 64))}, nil
+
+	case model.AttributeInputTypeBoolean:
+		boolValue, err := strconv.ParseBool(cellValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", cellValue)
+		}
+		value := findOrNew(
+			fmt.Sprintf("%s: %s", attribute.Name, lo.Ternary(boolValue, "Yes", "No")),
+			func(v *model.AttributeValue) bool { return !v.Boolean.IsNil() && *v.Boolean.Bool == boolValue },
+		)
+		value.Boolean = model_types.NewNullBool(boolValue)
+		return model.AttributeValueSlice{value}, nil
+
+	case model.AttributeInputTypeDate, model.AttributeInputTypeDateTime:
+		date, err := parseImportTime(cellValue)
+		if err != nil {
+			return nil, err
+		}
+		name := date.Format("2006-01-02 15:04:05")
+		if attribute.InputType == model.AttributeInputTypeDate {
+			name = date.Format("2006-01-02")
+		}
+		value := findOrNewByName(name)
+		value.Datetime = model_types.NewNullTime(date)
+		return model.AttributeValueSlice{value}, nil
+
+	default:
+		return nil, fmt.Errorf("attributes of %s input type can not be imported", attribute.InputType)
+	}
+}
+
+// categoryBySlug returns category with given slug, or nil if there is no such category
+func (imp *productImporter) categoryBySlug(slug string) (*model.Category, *model_helper.AppError) {
+	if category, ok := imp.categories[slug]; ok {
+		return category, nil
+	}
+
+	categories, err := imp.s.srv.Store.Category().FilterByOption(model_helper.CategoryFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CategoryWhere.Slug.EQ(slug)),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("categoryBySlug", "app.product.error_finding_categories_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var category *model.Category
+	if len(categories) > 0 {
+		category = categories[0]
+	}
+	imp.categories[slug] = category
+	return category, nil
+}
+
+// parseImportWeight parses weights written by product export, e.g "1.5 kg". Unit is optional
+func parseImportWeight(value string) (float32, string, error) {
+	fields := strings.Fields(value)
+	if len(fields) > 2 {
+		return 0, "", fmt.Errorf("invalid weight %q", value)
+	}
+
+	amount, err := strconv.ParseFloat(fields[0], 32)
+	if err != nil || amount < 0 {
+		return 0, "", fmt.Errorf("invalid weight %q", value)
+	}
+
+	var unit string
+	if len(fields) == 2 {
+		unit = strings.ToLower(fields[1])
+		if measurement.WEIGHT_UNIT_STRINGS[measurement.WeightUnit(unit)] == "" {
+			return 0, "", measurement.ErrInvalidWeightUnit
+		}
+	}
+	return float32(amount), unit, nil
+}
+
+var importTimeLayouts = []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02"}
+
+// parseImportTime parses dates and times written by product export. Values without time zone are in UTC
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if res, err := time.Parse(layout, value); err == nil {
+			return res.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected format is YYYY-MM-DD HH:MM:SS", value)
+}
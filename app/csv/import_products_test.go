@@ -0,0 +1,180 @@
+package csv
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// importTestTransaction is the transaction of every batch, it tells if any batch was committed
+type importTestTransaction struct {
+	store.ContextRunner
+	committed bool
+}
+
+func (t *importTestTransaction) BeginTx(context.Context, *sql.TxOptions) (store.ContextRunner, error) {
+	return t, nil
+}
+
+func (t *importTestTransaction) Commit() error {
+	t.committed = true
+	return nil
+}
+
+func (t *importTestTransaction) Rollback() error {
+	return nil
+}
+
+// noAttributes, noWarehouses and noChannels resolve none of attribute, warehouse and channel columns
+type noAttributes struct{ sub_app_iface.AttributeService }
+
+func (noAttributes) AttributesByOption(model_helper.AttributeFilterOption) (model.AttributeSlice, *model_helper.AppError) {
+	return model.AttributeSlice{}, nil
+}
+
+type noWarehouses struct{ sub_app_iface.WarehouseService }
+
+func (noWarehouses) WarehousesByOption(model_helper.WarehouseFilterOption) (model.WarehouseSlice, *model_helper.AppError) {
+	return model.WarehouseSlice{}, nil
+}
+
+type noChannels struct{ sub_app_iface.ChannelService }
+
+func (noChannels) ChannelsByOption(model_helper.ChannelFilterOptions) (model.ChannelSlice, *model_helper.AppError) {
+	return model.ChannelSlice{}, nil
+}
+
+// indexingProducts records products indexed after import
+type indexingProducts struct {
+	sub_app_iface.ProductService
+
+	indexed []string
+}
+
+func (p *indexingProducts) IndexProducts(productIDs []string) *model_helper.AppError {
+	p.indexed = append(p.indexed, productIDs...)
+	return nil
+}
+
+func TestImportProductsDryRun(t *testing.T) {
+	ts := newExportTestServer(t)
+	drinks := &model.Category{ID: model_helper.NewId(), Slug: "drinks"}
+	missingProductID := model_helper.NewId()
+
+	ts.files.files["imports/products.csv"] = []byte("id;name;category\n" +
+		";Green tea;drinks\n" +
+		";Black tea;snacks\n" +
+		missingProductID + ";Oolong;drinks\n")
+
+	productStore := &mocks.ProductStore{}
+	productStore.On("FilterByOption", mock.Anything).Return(model.ProductSlice{}, nil)
+	var saved []model.Product
+	productStore.On("Save", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, product model.Product) (*model.Product, error) {
+		saved = append(saved, product)
+		product.ID = model_helper.NewId()
+		return &product, nil
+	})
+	// categories are looked up in order of rows
+	categoryStore := &mocks.CategoryStore{}
+	categoryStore.On("FilterByOption", mock.Anything).Return(model.CategorySlice{drinks}, nil).Once()
+	categoryStore.On("FilterByOption", mock.Anything).Return(model.CategorySlice{}, nil).Once()
+	var progress []model.Job
+	jobStore := &mocks.JobStore{}
+	jobStore.On("UpdateOptimistically", mock.Anything, model.JobStatusInProgress).Return(func(job model.Job, _ model.JobStatus) (bool, error) {
+		progress = append(progress, job)
+		return true, nil
+	})
+
+	transaction := &importTestTransaction{}
+	ts.store.On("Product").Return(productStore)
+	ts.store.On("Category").Return(categoryStore)
+	ts.store.On("Job").Return(jobStore)
+	ts.store.On("GetMaster").Return(transaction)
+	ts.store.On("FinalizeTransaction", mock.Anything).Return()
+
+	ts.Server.Attribute = noAttributes{}
+	ts.Server.Warehouse = noWarehouses{}
+	ts.Server.Channel = noChannels{}
+	ts.Server.Jobs = &jobs.JobServer{Store: ts.store}
+	products := &indexingProducts{}
+	ts.Server.Product = products
+
+	s := &ServiceCsv{srv: ts.Server}
+	job := model.Job{ID: model_helper.NewId(), Data: model_types.JSONString{}}
+	require.Nil(t, s.ImportProducts(job, "imports/products.csv", model_helper.ExportFileTypeCsv, true))
+
+	// the valid product is written then rolled back
+	require.Len(t, saved, 1)
+	require.Equal(t, "Green tea", saved[0].Name)
+	require.Equal(t, drinks.ID, saved[0].CategoryID)
+	require.False(t, transaction.committed)
+
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	require.EqualValues(t, 3, last.Data.Get("total_rows"))
+	require.EqualValues(t, 3, last.Data.Get("processed_rows"))
+	require.EqualValues(t, 2, last.Data.Get("failed_rows"))
+	require.EqualValues(t, 1, last.Data.Get("imported_products"))
+
+	reportPath, _ := last.Data.Get("error_report").(string)
+	require.NotEmpty(t, reportPath)
+	require.Equal(t, []map[string]string{
+		{"row": "3", "column": "category", "message": `category "snacks" does not exist`},
+		{"row": "4", "column": "id", "message": `product "` + missingProductID + `" does not exist`},
+	}, exportedRecords(t, string(ts.files.files[reportPath]), ';'))
+	require.Empty(t, products.indexed)
+}
+
+func TestImportProductsIndexesSavedProducts(t *testing.T) {
+	ts := newExportTestServer(t)
+	drinks := &model.Category{ID: model_helper.NewId(), Slug: "drinks"}
+
+	ts.files.files["imports/products.csv"] = []byte("id;name;category\n" +
+		";Green tea;drinks\n")
+
+	productStore := &mocks.ProductStore{}
+	productStore.On("FilterByOption", mock.Anything).Return(model.ProductSlice{}, nil)
+	var savedID string
+	productStore.On("Save", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, product model.Product) (*model.Product, error) {
+		product.ID = model_helper.NewId()
+		savedID = product.ID
+		return &product, nil
+	})
+	categoryStore := &mocks.CategoryStore{}
+	categoryStore.On("FilterByOption", mock.Anything).Return(model.CategorySlice{drinks}, nil)
+	jobStore := &mocks.JobStore{}
+	jobStore.On("UpdateOptimistically", mock.Anything, model.JobStatusInProgress).Return(true, nil)
+
+	transaction := &importTestTransaction{}
+	ts.store.On("Product").Return(productStore)
+	ts.store.On("Category").Return(categoryStore)
+	ts.store.On("Job").Return(jobStore)
+	ts.store.On("GetMaster").Return(transaction)
+	ts.store.On("FinalizeTransaction", mock.Anything).Return()
+
+	ts.Server.Attribute = noAttributes{}
+	ts.Server.Warehouse = noWarehouses{}
+	ts.Server.Channel = noChannels{}
+	ts.Server.Jobs = &jobs.JobServer{Store: ts.store}
+	products := &indexingProducts{}
+	ts.Server.Product = products
+
+	s := &ServiceCsv{srv: ts.Server}
+	job := model.Job{ID: model_helper.NewId(), Data: model_types.JSONString{}}
+	require.Nil(t, s.ImportProducts(job, "imports/products.csv", model_helper.ExportFileTypeCsv, false))
+
+	require.True(t, transaction.committed)
+	require.NotEmpty(t, savedID)
+	require.Equal(t, []string{savedID}, products.indexed)
+}
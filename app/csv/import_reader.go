@@ -0,0 +1,104 @@
+package csv
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sitename/sitename/model_helper"
+	"github.com/xuri/excelize/v2"
+)
+
+// readImportRows reads all rows of given import file of the file store.
+// Every returned row has the same length as the header row (first row).
+func (s *ServiceCsv) readImportRows(fileType, path, delimiter string) ([][]string, *model_helper.AppError) {
+	reader, appErr := s.srv.File.FileReader(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer reader.Close()
+
+	var (
+		rows [][]string
+		err  error
+	)
+	if fileType == model_helper.ExportFileTypeXlsx {
+		rows, err = readXlsxRows(reader)
+	} else {
+		rows, err = readCsvRows(reader, delimiter)
+	}
+	if err != nil {
+		return nil, model_helper.NewAppError("readImportRows", "app.csv.error_reading_import_file.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	// drop empty trailing rows, spreadsheet editors often leave them behind
+	for len(rows) > 0 && isEmptyImportRow(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	if len(rows) == 0 {
+		return rows, nil
+	}
+
+	for idx, header := range rows[0] {
+		rows[0][idx] = strings.TrimSpace(header)
+	}
+	width := len(rows[0])
+	for idx, row := range rows {
+		if len(row) < width {
+			rows[idx] = append(row, make([]string, width-len(row))...)
+		} else {
+			rows[idx] = row[:width]
+		}
+	}
+
+	return rows, nil
+}
+
+func readCsvRows(reader io.Reader, delimiter string) ([][]string, error) {
+	comma, _ := utf8.DecodeRuneInString(delimiter)
+	if comma == utf8.RuneError {
+		comma, _ = utf8.DecodeRuneInString(defaultExportDelimiter)
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = comma
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	return csvReader.ReadAll()
+}
+
+func readXlsxRows(reader io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rowIter, err := file.Rows(file.GetSheetName(0))
+	if err != nil {
+		return nil, err
+	}
+	defer rowIter.Close()
+
+	var rows [][]string
+	for rowIter.Next() {
+		columns, err := rowIter.Columns()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, columns)
+	}
+
+	return rows, rowIter.Error()
+}
+
+func isEmptyImportRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -21,7 +21,6 @@ var ProductExportFields = &Fields{
 			"name":                              "name",
 			"description":                       "description_as_str",
 			"category":                          "category__slug",
-			"product_type":                      "product_type__name",
 			"charge_taxes":                      "charge_taxes",
 			"product_weight":                    "product_weight",
 			"variant_id":                        "variants__id",
@@ -2,32 +2,126 @@ package csv
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
 )
 
 // GetDefaultExportPayload returns a map for mapping
 func (a *ServiceCsv) GetDefaultExportPayload(exportFile model.ExportFile) (map[string]any, *model_helper.AppError) {
-	var (
-		user   *model.User
-		appErr *model_helper.AppError
-	)
+	payload := map[string]any{
+		"user_id":    nil,
+		"user_email": nil,
+		"app_id":     exportFile.AppID.String,
+		"id":         exportFile.ID,
+		"status":     nil,
+		"message":    nil,
+		"created_at": exportFile.CreatedAt,
+		"updated_at": exportFile.UpdatedAt,
+	}
 
 	if !exportFile.UserID.IsNil() {
-		user, appErr = a.srv.Account.UserById(context.Background(), *exportFile.UserID.String)
+		user, appErr := a.srv.Account.UserById(context.Background(), *exportFile.UserID.String)
+		if appErr != nil {
+			return nil, appErr
+		}
+		payload["user_id"] = user.ID
+		payload["user_email"] = user.Email
 	}
+
+	return payload, nil
+}
+
+// SendExportDownloadLinkNotification sends a link to download given export file to the user who requested the export.
+// Exports requested by apps are not notified.
+func (a *ServiceCsv) SendExportDownloadLinkNotification(exportFile model.ExportFile, dataType string) *model_helper.AppError {
+	if exportFile.UserID.IsNil() || exportFile.ContentFile.IsNil() {
+		return nil
+	}
+
+	exportPayload, appErr := a.GetDefaultExportPayload(exportFile)
 	if appErr != nil {
-		return nil, appErr
+		return appErr
 	}
+	exportPayload["status"] = model.ExportEventTypeExportSuccess
 
-	return map[string]any{
-		"user_id":    user.ID,
-		"user_email": user.Email,
-		"id":         exportFile.ID,
-		"status":     nil,
-		"message":    nil,
-		"created_at": exportFile.CreatedAt,
-		"updated_at": exportFile.UpdatedAt,
-	}, nil
+	link, appErr := a.srv.File.PresignedDownloadURL(*exportFile.ContentFile.String)
+	if appErr != nil {
+		return appErr
+	}
+
+	payload := model_types.JSONString{
+		"export":          exportPayload,
+		"csv_link":        link,
+		"recipient_email": exportPayload["user_email"],
+		"data_type":       dataType,
+	}
+	payload.Merge(a.srv.GetSiteContext())
+
+	_, appErr = a.srv.Plugin.GetPluginManager().Notify(model_helper.CSV_EXPORT_SUCCESS, payload, "", "")
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.recordExportEvent(exportFile, model.ExportEventTypeExportedFileSent, map[string]string{"user_email": exportPayload["user_email"].(string)})
+}
+
+// SendExportFailedInfo notifies the user who requested given export that the export has failed.
+// Exports requested by apps are not notified.
+func (a *ServiceCsv) SendExportFailedInfo(exportFile model.ExportFile, dataType, message string) *model_helper.AppError {
+	if exportFile.UserID.IsNil() {
+		return nil
+	}
+
+	exportPayload, appErr := a.GetDefaultExportPayload(exportFile)
+	if appErr != nil {
+		return appErr
+	}
+	exportPayload["status"] = model.ExportEventTypeExportFailed
+	exportPayload["message"] = message
+
+	payload := model_types.JSONString{
+		"export":          exportPayload,
+		"recipient_email": exportPayload["user_email"],
+		"data_type":       dataType,
+	}
+	payload.Merge(a.srv.GetSiteContext())
+
+	_, appErr = a.srv.Plugin.GetPluginManager().Notify(model_helper.CSV_EXPORT_FAILED, payload, "", "")
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.recordExportEvent(exportFile, model.ExportEventTypeExportFailedInfoSent, map[string]string{"user_email": exportPayload["user_email"].(string)})
+}
+
+// SendUserDataExportLink emails the customer who requested given user data export a link to download the archive
+func (a *ServiceCsv) SendUserDataExportLink(exportFile model.ExportFile) *model_helper.AppError {
+	if exportFile.UserID.IsNil() || exportFile.ContentFile.IsNil() {
+		return nil
+	}
+
+	user, appErr := a.srv.Account.UserById(context.Background(), *exportFile.UserID.String)
+	if appErr != nil {
+		return appErr
+	}
+
+	link, appErr := a.srv.File.PresignedDownloadURL(*exportFile.ContentFile.String)
+	if appErr != nil {
+		return appErr
+	}
+
+	err := a.srv.EmailService.SendUserDataExportEmail(user.Email, link, user.Locale.String(), a.srv.GetSiteURL())
+	if err != nil {
+		return model_helper.NewAppError("SendUserDataExportLink", "app.csv.error_sending_user_data_export_email.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	appErr = a.recordExportEvent(exportFile, model.ExportEventTypeExportedFileSent, map[string]string{"user_email": user.Email})
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.srv.Account.RecordDataPrivacyEvent(user.ID, model.CustomerEventTypeDATA_EXPORTED, model_types.JSONString{"export_file_id": exportFile.ID})
 }
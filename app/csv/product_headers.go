@@ -1,10 +1,10 @@
 package csv
 
 import (
-	"fmt"
+	"sort"
 	"strings"
-	"sync/atomic"
 
+	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -50,9 +50,9 @@ func (a *ServiceCsv) GetExportFieldsAndHeadersInfo(
 }
 
 // Get headers for exported attributes.
-// Headers are build from slug and contains information if it's a product or variant
-// attribute. Respectively for product: "slug-value (product attribute)"
-// and for variant: "slug-value (variant attribute)".
+// Headers are build from slug. Example: "slug-value (product attribute)".
+//
+// NOTE: variants carry custom attributes of their products, so variant only attributes are not exported.
 func (a *ServiceCsv) GetAttributeHeaders(exportInfo struct {
 	Attributes []string
 	Warehouses []string
@@ -63,67 +63,23 @@ func (a *ServiceCsv) GetAttributeHeaders(exportInfo struct {
 		return []string{}, nil
 	}
 
-	var (
-		attributes_01 []*model.Attribute
-		attributes_02 []*model.Attribute
-		atomicValue   atomic.Int32
-		appErrChan    = make(chan *model_helper.AppError)
-	)
-	defer close(appErrChan)
-
-	atomicValue.Add(2) //
-
-	go func() {
-		defer atomicValue.Add(-1)
-
-		attributes, appErr := a.srv.Attribute.AttributesByOption(model_helper.AttributeFilterOption{
-			CommonQueryOptions: model_helper.NewCommonQueryOptions(
-				qm.Distinct(model.AttributeColumns.ID),
-				model.AttributeWhere.ID.IN(exportInfo.Attributes),
-			),
-		})
-		if appErr != nil {
-			appErrChan <- appErr
-			return
-		}
-		attributes_01 = attributes
-	}()
-
-	go func() {
-		defer atomicValue.Add(-1)
-
-		attributes, appErr := a.srv.Attribute.AttributesByOption(model_helper.AttributeFilterOption{
-			CommonQueryOptions: model_helper.NewCommonQueryOptions(
-				qm.Distinct(model.AttributeColumns.ID),
-				model.AttributeWhere.ID.IN(exportInfo.Attributes),
-			),
-		})
-		if appErr != nil {
-			appErrChan <- appErr
-			return
-		}
-		attributes_02 = attributes
-	}()
-
-	for atomicValue.Load() != 0 {
-		select {
-		case appErr := <-appErrChan:
-			return nil, appErr
-		default:
-		}
+	attributes, appErr := a.srv.Attribute.AttributesByOption(model_helper.AttributeFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.AttributeWhere.ID.IN(exportInfo.Attributes),
+			model.AttributeWhere.IsVariantOnly.EQ(false),
+			qm.OrderBy(model.AttributeColumns.Slug),
+		),
+	})
+	if appErr != nil {
+		return nil, appErr
 	}
 
-	productHeaders := []string{}
-	variantHeaders := []string{}
-
-	for _, attr := range attributes_01 {
-		productHeaders = append(productHeaders, attr.Slug+" (product attribute)")
-	}
-	for _, attr := range attributes_02 {
-		variantHeaders = append(variantHeaders, attr.Slug+" (variant attribute)")
+	headers := []string{}
+	for _, attr := range attributes {
+		headers = append(headers, attr.Slug+" (product attribute)")
 	}
 
-	return append(productHeaders, variantHeaders...), nil
+	return headers, nil
 }
 
 // Get headers for exported warehouses.
@@ -141,6 +97,7 @@ func (a *ServiceCsv) GetWarehousesHeaders(exportInfo struct {
 	warehouses, appErr := a.srv.Warehouse.WarehousesByOption(model_helper.WarehouseFilterOption{
 		CommonQueryOptions: model_helper.NewCommonQueryOptions(
 			model.WarehouseWhere.ID.IN(exportInfo.Warehouses),
+			qm.OrderBy(model.WarehouseColumns.Slug),
 		),
 	})
 	if appErr != nil {
@@ -176,25 +133,21 @@ func (a *ServiceCsv) GetChannelsHeaders(exportInfo struct {
 	channels, appErr := a.srv.Channel.ChannelsByOption(model_helper.ChannelFilterOptions{
 		CommonQueryOptions: model_helper.NewCommonQueryOptions(
 			model.ChannelWhere.ID.IN(exportInfo.Channels),
+			qm.OrderBy(model.ChannelColumns.Slug),
 		),
 	})
 	if appErr != nil {
 		return nil, appErr
 	}
 
-	fields := []string{}
-	for k := range ProductExportFields.PRODUCT_CHANNEL_LISTING_FIELDS {
-		fields = append(fields, k)
-	}
-	for k := range ProductExportFields.VARIANT_CHANNEL_LISTING_FIELDS {
-		fields = append(fields, k)
-	}
+	fields := append(lo.Keys(ProductExportFields.PRODUCT_CHANNEL_LISTING_FIELDS), lo.Keys(ProductExportFields.VARIANT_CHANNEL_LISTING_FIELDS)...)
+	sort.Strings(fields)
 
 	channelsHeaders := []string{}
 	for _, channel := range channels {
 		for _, field := range fields {
 			if field != "slug" && field != "channel_pk" {
-				channelsHeaders = append(channelsHeaders, fmt.Sprintf("%s (channel %s)", channel.Slug, strings.ReplaceAll(field, "_", " ")))
+				channelsHeaders = append(channelsHeaders, channelHeader(channel.Slug, field))
 			}
 		}
 	}
@@ -230,7 +183,11 @@ func GetProductExportFieldsAndHeaders(exportInfo struct {
 	for _, field := range exportInfo.Fields {
 		actualField := strings.ToLower(string(field))
 
-		exportFields = append(exportFields, fieldsMapping[actualField])
+		lookup, ok := fieldsMapping[actualField]
+		if !ok || lookup == "id" {
+			continue
+		}
+		exportFields = append(exportFields, lookup)
 		fileHeaders = append(fileHeaders, actualField)
 	}
 
@@ -1,418 +1,324 @@
 package csv
 
 import (
+	"encoding/json"
 	"fmt"
-	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
-	"github.com/sitename/sitename/modules/model_types"
-	"github.com/sitename/sitename/modules/slog"
 	"github.com/sitename/sitename/modules/util"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
-// GetProductsData Create data list of products and their variants with fields values.
-//
-// It return list with product and variant data which can be used as import to
-// csv writer and list of attribute and warehouse headers.
+// productExportRelations returns eager loads needed to build export data of products.
 //
-// TODO: consider improving me
-func (a *ServiceCsv) GetProductsData(products model.ProductSlice, exportFields, attributeIDs, warehouseIDs, channelIDs util.AnyArray[string]) []model_types.JSONString {
-	var (
-		exportVariantID     = exportFields.Contains("variants__id")
-		productFields       = lo.Values(ProductExportFields.HEADERS_TO_FIELDS_MAPPING["fields"])
-		productExportFields = exportFields.InterSection(productFields)
-	)
-
-	if !exportVariantID {
-		productExportFields = append(productExportFields, "variants__id")
+// NOTE: filtered relations must be loaded before their nested relations, since
+// already loaded relations are not reloaded.
+func productExportRelations(exportFields, attributeIDs, warehouseIDs, channelIDs util.AnyArray[string]) []qm.QueryMod {
+	res := []qm.QueryMod{
+		qm.Load(model.ProductRels.ProductVariants, qm.OrderBy(model.ProductVariantColumns.SortOrder+", "+model.ProductVariantColumns.ID)),
 	}
 
-	productsRelationsData := a.getProductsRelationsData(products, exportFields, attributeIDs, channelIDs)
-	variantsRelationsData := a.getVariantsRelationsData(products, exportFields, attributeIDs, warehouseIDs, channelIDs)
-
-	res := []model_types.JSONString{}
-
-	for _, productData := range products.Flat() {
-		var pk = productData["id"].(string)
-		var variantPK string
-
-		if exportVariantID {
-			variantPK = productData.Get("variants__id", "").(string)
-		} else {
-			variantPK = productData.Pop("variants__id", "").(string)
-		}
-
-		productRelationsData := productsRelationsData[pk]
-		if productRelationsData == nil {
-			productRelationsData = model_helper.StringMap{}
-		}
-
-		variantRelationsData := variantsRelationsData[variantPK]
-		if variantRelationsData == nil {
-			variantRelationsData = model_helper.StringMap{}
-		}
+	if exportFields.Contains("category__slug") {
+		res = append(res, qm.Load(model.ProductRels.Category))
+	}
+	if exportFields.Contains("collections__slug") {
+		res = append(res, qm.Load(model.ProductRels.ProductCollections+"."+model.ProductCollectionRels.Collection))
+	}
+	if exportFields.Contains("media__image") {
+		res = append(res, qm.Load(model.ProductRels.ProductMedia, qm.OrderBy(model.ProductMediumColumns.SortOrder)))
+	}
+	if exportFields.Contains("variants__media__image") {
+		res = append(res, qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.VariantVariantMedia+"."+model.VariantMediumRels.Medium))
+	}
 
-		if exportVariantID {
-			productData["variants__id"] = variantPK
-		}
+	if len(attributeIDs) > 0 {
+		res = append(
+			res,
+			qm.Load(model.ProductRels.AssignedProductAttributes+"."+model.AssignedProductAttributeRels.Assignment+"."+model.CategoryAttributeRels.Attribute),
+			qm.Load(model.ProductRels.AssignedProductAttributes+"."+model.AssignedProductAttributeRels.AssignmentAssignedProductAttributeValues+"."+model.AssignedProductAttributeValueRels.Value),
+		)
+	}
 
-		data := model_types.JSONString{}
-		data.Merge(productData)
-		for k, v := range productRelationsData {
-			data[k] = v
-		}
-		for k, v := range variantRelationsData {
-			data[k] = v
-		}
+	if len(warehouseIDs) > 0 {
+		res = append(
+			res,
+			qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.Stocks, model.StockWhere.WarehouseID.IN(warehouseIDs)),
+			qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.Stocks+"."+model.StockRels.Warehouse),
+		)
+	}
 
-		res = append(res, data)
+	if len(channelIDs) > 0 {
+		res = append(
+			res,
+			qm.Load(model.ProductRels.ProductChannelListings, model.ProductChannelListingWhere.ChannelID.IN(channelIDs)),
+			qm.Load(model.ProductRels.ProductChannelListings+"."+model.ProductChannelListingRels.Channel),
+			qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.VariantProductVariantChannelListings, model.ProductVariantChannelListingWhere.ChannelID.IN(channelIDs)),
+			qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.VariantProductVariantChannelListings+"."+model.ProductVariantChannelListingRels.Channel),
+		)
 	}
 
 	return res
 }
 
-// Get data about product relations fields.
-// If any many to many fields are in export_fields or some attribute_ids exists then
-// dict with product relations fields is returned.
-// Otherwise it returns empty di`ct.
-func (s *ServiceCsv) getProductsRelationsData(products model.ProductSlice, exportFields, attributeIDs, channelIDs util.AnyArray[string]) map[string]model_helper.StringMap {
-	var (
-		manyToManyFields = lo.Values(ProductExportFields.HEADERS_TO_FIELDS_MAPPING["product_many_to_many"])
-		relationFields   = exportFields.InterSection(manyToManyFields)
-	)
-
-	if len(relationFields) > 0 || len(attributeIDs) > 0 || len(channelIDs) > 0 {
-		return s.prepareProductsRelationsData(products, relationFields, attributeIDs, channelIDs)
-	}
-
-	return map[string]model_helper.StringMap{}
-}
+// GetProductsData Create data list of products and their variants with fields values.
+//
+// Each returned row represents one product variant (or a product without variants) and
+// maps data headers (export fields, attribute, warehouse and channel headers) to cell values.
+//
+// NOTE: products must be loaded with relations returned by productExportRelations
+func (s *ServiceCsv) GetProductsData(products model.ProductSlice, exportFields, attributeIDs, warehouseIDs, channelIDs util.AnyArray[string]) []model_helper.StringMap {
+	res := []model_helper.StringMap{}
 
-func (s *ServiceCsv) prepareProductsRelationsData(products model.ProductSlice, fields util.AnyArray[string], attributeIDs, channelIDs []string) map[string]model_helper.StringMap {
-	var (
-		channelFields = ProductExportFields.PRODUCT_CHANNEL_LISTING_FIELDS.DeepCopy()
-		resultData    = map[string]map[string][]any{}
-	)
-
-	// fields = append(fields, "id")
-	// if len(attributeIDs) > 0 {
-	// 	fields = fields.AddNoDup(ProductExportFields.PRODUCT_ATTRIBUTE_FIELDS.Values()...)
-	// }
-	// if len(channelIDs) > 0 {
-	// 	fields = fields.AddNoDup(channelFields.Values()...)
-	// }
-
-	var (
-		channelPkLookup   = channelFields.Pop("channel_pk")
-		channelSlugLookup = channelFields.Pop("slug")
-	)
-
-	for _, data := range products.Flat() {
-		var (
-			pk         = data["id"].(string)
-			collection = data["collections__slug"]
-			image      = data.Pop("media__image", "")
-		)
+	for _, product := range products {
+		productData := s.prepareProductData(product, exportFields, attributeIDs, channelIDs)
 
-		if image != nil {
-			resultData[pk]["media__image"] = append(
-				resultData[pk]["media__image"],
-				filepath.Join(*s.srv.Config().ServiceSettings.SiteURL, image.(string)),
-			)
+		variants := product.R.GetProductVariants()
+		if len(variants) == 0 {
+			res = append(res, productData)
+			continue
 		}
 
-		if collection != nil {
-			resultData[pk]["collections__slug"] = append(
-				resultData[pk]["collections__slug"],
-				collection.(string),
-			)
-		}
-
-		resultData, data = s.handleAttributeData(pk, data, attributeIDs, resultData, ProductExportFields.PRODUCT_ATTRIBUTE_FIELDS, "product attribute")
-		resultData, data = s.handleChannelData(pk, data, channelIDs, resultData, channelPkLookup, channelSlugLookup, channelFields)
-	}
-
-	result := map[string]model_helper.StringMap{}
-	for pk, data := range resultData {
-		result[pk] = model_helper.StringMap{}
-
-		for header, values := range data {
-			var str string
-
-			for idx, value := range values {
-				if idx < len(values)-1 {
-					str += fmt.Sprintf("%v, ", value)
-					continue
-				}
-				str += fmt.Sprintf("%v", value)
+		for _, variant := range variants {
+			data := productData.DeepCopy()
+			for header, value := range s.prepareVariantData(variant, exportFields, warehouseIDs, channelIDs) {
+				data[header] = value
 			}
-
-			result[pk][header] = str
+			res = append(res, data)
 		}
 	}
 
-	return result
-}
-
-func (s *ServiceCsv) getVariantsRelationsData(products model.ProductSlice, exportFields, attributeIDs, warehouseIDs, channelIDs util.AnyArray[string]) map[string]model_helper.StringMap {
-	manyToManyFields := lo.Values(ProductExportFields.HEADERS_TO_FIELDS_MAPPING["variant_many_to_many"])
-	relationsFields := exportFields.InterSection(manyToManyFields)
-
-	if len(relationsFields) > 0 || len(attributeIDs) > 0 || len(channelIDs) > 0 {
-		return s.prepareVariantsRelationsData(products, relationsFields, attributeIDs, warehouseIDs, channelIDs)
-	}
-
-	return map[string]model_helper.StringMap{}
+	return res
 }
 
-func (s *ServiceCsv) prepareVariantsRelationsData(products model.ProductSlice, fields util.AnyArray[string], attributeIDs, warehouseIDs, channelIDs []string) map[string]model_helper.StringMap {
-	var channelFields = ProductExportFields.VARIANT_CHANNEL_LISTING_FIELDS.DeepCopy()
-
-	// fields = append(fields, "variants__id")
-	// if len(attributeIDs) > 0 {
-	// 	fields = fields.AddNoDup(ProductExportFields.VARIANT_ATTRIBUTE_FIELDS.Values()...)
-	// }
-	// if len(warehouseIDs) > 0 {
-	// 	fields = fields.AddNoDup(ProductExportFields.WAREHOUSE_FIELDS.Values()...)
-	// }
-	// if len(channelIDs) > 0 {
-	// 	fields = fields.AddNoDup(channelFields.Values()...)
-	// }
-
-	var (
-		resultData        = map[string]map[string][]any{}
-		channelPKLookup   = channelFields.Pop("channel_pk")
-		channelSlugLookup = channelFields.Pop("slug")
-	)
-
-	for _, data := range products.Flat() {
-		pk := data.Get("variants__id").(string)
-		image := data.Pop("variants__media__image", nil)
-
-		if image != nil {
-			resultData[pk]["variants__media__image"] = append(resultData[pk]["variants__media__image"], filepath.Join(*s.srv.Config().ServiceSettings.SiteURL, image.(string)))
+func (s *ServiceCsv) prepareProductData(product *model.Product, exportFields, attributeIDs, channelIDs util.AnyArray[string]) model_helper.StringMap {
+	data := model_helper.StringMap{"id": product.ID}
+
+	for _, field := range exportFields {
+		switch field {
+		case "name":
+			data[field] = product.Name
+		case "description_as_str":
+			if len(product.Description) > 0 {
+				description, _ := json.Marshal(product.Description)
+				data[field] = string(description)
+			}
+		case "category__slug":
+			if category := product.R.GetCategory(); category != nil {
+				data[field] = category.Slug
+			}
+		case "charge_taxes":
+			if !product.ChargeTaxes.IsNil() {
+				data[field] = strconv.FormatBool(*product.ChargeTaxes.Bool)
+			}
+		case "product_weight":
+			if !product.Weight.IsNil() {
+				data[field] = fmt.Sprintf("%v %s", *product.Weight.Float32, product.WeightUnit)
+			}
+		case "collections__slug":
+			data[field] = joinExportValues(lo.FilterMap(product.R.GetProductCollections(), func(pc *model.ProductCollection, _ int) (string, bool) {
+				if collection := pc.R.GetCollection(); collection != nil {
+					return collection.Slug, true
+				}
+				return "", false
+			}))
+		case "media__image":
+			data[field] = joinExportValues(lo.Map(product.R.GetProductMedia(), func(media *model.ProductMedium, _ int) string {
+				return s.getMediaURL(media)
+			}))
 		}
-
-		resultData, data = s.handleAttributeData(pk, data, attributeIDs, resultData, ProductExportFields.VARIANT_ATTRIBUTE_FIELDS, "variant attribute")
-		resultData, data = s.handleChannelData(pk, data, channelIDs, resultData, channelPKLookup, channelSlugLookup, channelFields)
-		resultData, data = s.handleWarehouseData(pk, data, warehouseIDs, resultData, ProductExportFields.WAREHOUSE_FIELDS)
 	}
 
-	result := map[string]model_helper.StringMap{}
-	for pk, data := range resultData {
-		result[pk] = model_helper.StringMap{}
-
-		for header, values := range data {
-			var str string
+	if len(attributeIDs) > 0 {
+		attributeValues := map[string][]string{}
+		for _, assignedAttribute := range product.R.GetAssignedProductAttributes() {
+			assignment := assignedAttribute.R.GetAssignment()
+			if assignment == nil {
+				continue
+			}
+			attribute := assignment.R.GetAttribute()
+			if attribute == nil || !attributeIDs.Contains(attribute.ID) {
+				continue
+			}
 
-			for idx, item := range values {
-				if idx < len(values)-1 {
-					str += fmt.Sprintf("%v, ", item)
-					continue
+			header := attribute.Slug + " (product attribute)"
+			for _, assignedValue := range assignedAttribute.R.GetAssignmentAssignedProductAttributeValues() {
+				if value := assignedValue.R.GetValue(); value != nil {
+					attributeValues[header] = append(attributeValues[header], s.prepareAttributeValue(*attribute, *value))
 				}
-				str += fmt.Sprintf("%v", item)
 			}
-
-			result[pk][header] = str
 		}
-	}
-
-	return result
-}
-
-func (s *ServiceCsv) handleWarehouseData(pk string, data model_types.JSONString, warehouseIDs util.AnyArray[string], resultData map[string]map[string][]any, warehouseFields model_helper.StringMap) (map[string]map[string][]any, model_types.JSONString) {
-	warehousePK := data.Pop(warehouseFields["warehouse_pk"], "").(string)
-	warehouseData := model_types.JSONString{
-		"slug": data.Pop(warehouseFields["slug"], nil),
-		"qty":  data.Pop(warehouseFields["quantity"], nil),
-	}
-
-	if warehouseIDs.Contains(warehousePK) {
-		resultData = s.addWarehouseInfoToData(pk, warehouseData, resultData)
-	}
-
-	return resultData, data
-}
-
-func (s *ServiceCsv) addWarehouseInfoToData(pk string, warehouseData model_types.JSONString, resultData map[string]map[string][]any) map[string]map[string][]any {
-	slug, ok := warehouseData["slug"]
-	if ok && slug != nil {
-		warehouseQtyHeader := fmt.Sprintf("%v (warehouse quantity)", slug)
-		if _, ok := resultData[pk][warehouseQtyHeader]; !ok {
-			resultData[pk][warehouseQtyHeader] = []any{warehouseData["qty"]}
+		for header, values := range attributeValues {
+			data[header] = joinExportValues(values)
 		}
 	}
 
-	return resultData
-}
-
-type AttributeData struct {
-	Slug       any
-	InputType  any
-	EntityType any
-	Unit       any
-	ValueSlug  any
-	ValueName  any
-	Value      any
-	FileUrl    any
-	RichText   any
-	Boolean    any
-	DateTime   any
-}
-
-func (s *ServiceCsv) handleAttributeData(pk string, data model_types.JSONString, attributeIDs util.AnyArray[string], resultData map[string]map[string][]any, attributeFields model_helper.StringMap, attributeOwner string) (map[string]map[string][]any, model_types.JSONString) {
-	attributePK := data.Pop(attributeFields["attribute_pk"], "").(string)
-
-	attributeData := AttributeData{
-		Slug:       data.Pop(attributeFields["slug"], nil),
-		InputType:  data.Pop(attributeFields["input_type"], nil),
-		FileUrl:    data.Pop(attributeFields["file_url"], nil),
-		ValueSlug:  data.Pop(attributeFields["value_slug"], ""),
-		ValueName:  data.Pop(attributeFields["value_name"], nil),
-		Value:      data.Pop(attributeFields["value"], nil),
-		EntityType: data.Pop(attributeFields["entity_type"], nil),
-		Unit:       data.Pop(attributeFields["unit"], nil),
-		RichText:   data.Pop(attributeFields["rich_text"], nil),
-		Boolean:    data.Pop(attributeFields["boolean"], nil),
-		DateTime:   data.Pop(attributeFields["date_time"], nil),
-	}
+	for _, listing := range product.R.GetProductChannelListings() {
+		channel := listing.R.GetChannel()
+		if channel == nil || !channelIDs.Contains(channel.ID) {
+			continue
+		}
 
-	if attributeIDs.Contains(attributePK) {
-		resultData = s.addAttributeInfoToData(pk, attributeData, attributeOwner, resultData)
+		data[channelHeader(channel.Slug, "product_currency_code")] = listing.Currency.String()
+		data[channelHeader(channel.Slug, "published")] = strconv.FormatBool(listing.IsPublished)
+		data[channelHeader(channel.Slug, "publication_date")] = formatExportMillis(listing.PublicationDate.Int64)
+		data[channelHeader(channel.Slug, "searchable")] = strconv.FormatBool(listing.VisibleInListings)
+		data[channelHeader(channel.Slug, "available_for_purchase")] = formatExportMillis(listing.AvailableForPurchaseAt.Int64)
 	}
 
-	return resultData, data
+	return data
 }
 
-func (s *ServiceCsv) handleChannelData(pk string, data model_types.JSONString, channelIDs util.AnyArray[string], resultData map[string]map[string][]any, pkLookup, slugLookup string, fields model_helper.StringMap) (map[string]map[string][]any, model_types.JSONString) {
-	channelPK := data.Pop(pkLookup, "").(string)
-	channelData := model_types.JSONString{
-		"slug": data.Pop(slugLookup, nil),
-	}
-
-	for field, lookup := range fields {
-		channelData[field] = data.Pop(lookup, nil)
+func (s *ServiceCsv) prepareVariantData(variant *model.ProductVariant, exportFields, warehouseIDs, channelIDs util.AnyArray[string]) model_helper.StringMap {
+	data := model_helper.StringMap{}
+
+	for _, field := range exportFields {
+		switch field {
+		case "variants__id":
+			data[field] = variant.ID
+		case "variants__sku":
+			data[field] = variant.Sku
+		case "variant_weight":
+			if !variant.Weight.IsNil() {
+				data[field] = fmt.Sprintf("%v %s", *variant.Weight.Float32, variant.WeightUnit)
+			}
+		case "variants__is_preorder":
+			data[field] = strconv.FormatBool(variant.IsPreorder)
+		case "variants__preorder_global_threshold":
+			if !variant.PreorderGlobalThreshold.IsNil() {
+				data[field] = strconv.Itoa(*variant.PreorderGlobalThreshold.Int)
+			}
+		case "variants__preorder_end_date":
+			data[field] = formatExportMillis(variant.PreorderEndDate.Int64)
+		case "variants__media__image":
+			data[field] = joinExportValues(lo.FilterMap(variant.R.GetVariantVariantMedia(), func(variantMedia *model.VariantMedium, _ int) (string, bool) {
+				if media := variantMedia.R.GetMedium(); media != nil {
+					return s.getMediaURL(media), true
+				}
+				return "", false
+			}))
+		}
 	}
 
-	if channelIDs.Contains(channelPK) {
-		resultData = s.addChannelInfoToData(pk, channelData, resultData, lo.Keys(fields))
+	for _, stock := range variant.R.GetStocks() {
+		warehouse := stock.R.GetWarehouse()
+		if warehouse == nil || !warehouseIDs.Contains(warehouse.ID) {
+			continue
+		}
+		data[warehouse.Slug+" (warehouse quantity)"] = strconv.Itoa(stock.Quantity)
 	}
 
-	return resultData, data
-}
+	for _, listing := range variant.R.GetVariantProductVariantChannelListings() {
+		channel := listing.R.GetChannel()
+		if channel == nil || !channelIDs.Contains(channel.ID) {
+			continue
+		}
 
-func (s *ServiceCsv) addAttributeInfoToData(pk string, attributeData AttributeData, attributeOwner string, resultData map[string]map[string][]any) map[string]map[string][]any {
-	if attributeData.Slug == nil {
-		return resultData
+		if !listing.PriceAmount.IsNil() {
+			data[channelHeader(channel.Slug, "price_amount")] = listing.PriceAmount.Decimal.String()
+		}
+		if !listing.CostPriceAmount.IsNil() {
+			data[channelHeader(channel.Slug, "variant_cost_price")] = listing.CostPriceAmount.Decimal.String()
+		}
+		if !listing.PreorderQuantityThreshold.IsNil() {
+			data[channelHeader(channel.Slug, "variant_preorder_quantity_threshold")] = strconv.Itoa(*listing.PreorderQuantityThreshold.Int)
+		}
+		if listing.Currency.Valid {
+			data[channelHeader(channel.Slug, "variant_currency_code")] = listing.Currency.Val.String()
+		}
 	}
 
-	var (
-		header = fmt.Sprintf("%v (%s)", attributeData.Slug, attributeOwner)
-		value  = s.prepareAttributeValue(attributeData)
-	)
-
-	resultData[pk][header] = append(resultData[pk][header], value)
-
-	return resultData
+	return data
 }
 
-func (s *ServiceCsv) prepareAttributeValue(attributeData AttributeData) string {
-
-	t, ok := attributeData.InputType.(model.AttributeInputType)
-	if !ok {
-		return ""
-	}
-
-	switch t {
+// prepareAttributeValue returns export representation of given attribute value,
+// based on input type of its attribute.
+func (s *ServiceCsv) prepareAttributeValue(attribute model.Attribute, value model.AttributeValue) string {
+	switch attribute.InputType {
 	case model.AttributeInputTypeFile:
-		if url := attributeData.FileUrl; url != nil {
-			if strURL, ok := url.(string); ok {
-				return filepath.Join(*s.srv.Config().ServiceSettings.SiteURL, strURL)
-			}
+		if value.FileURL.IsNil() {
 			return ""
 		}
-		return ""
+		return s.getFileURL(*value.FileURL.String)
 
 	case model.AttributeInputTypeReference:
-		if slug := attributeData.ValueSlug; slug != nil {
-			if strSlug, ok := slug.(string); ok {
-				return fmt.Sprintf("%v_%s", attributeData.EntityType, strings.Split(strSlug, "_")[1])
-			}
-			return ""
-		}
-		return ""
+		// reference values have slug in form of "<attribute value id>_<referenced entity id>"
+		_, referenceID, _ := strings.Cut(value.Slug, "_")
+		return fmt.Sprintf("%s_%s", attribute.EntityType.Val.String(), referenceID)
 
 	case model.AttributeInputTypeNumeric:
-		value := fmt.Sprintf("%v", attributeData.ValueName)
-		if attributeData.Unit != nil {
-			value += fmt.Sprintf(" %v", attributeData.Unit)
+		if attribute.Unit.IsNil() {
+			return value.Name
 		}
-		return value
+		return value.Name + " " + *attribute.Unit.String
 
 	case model.AttributeInputTypeRichText:
-		slog.Warn("this case is not implemented yet")
-		return ""
+		if value.PlainText.IsNil() {
+			return ""
+		}
+		return *value.PlainText.String
 
 	case model.AttributeInputTypeBoolean:
-		if attributeData.Boolean != nil {
-			if b, ok := attributeData.Boolean.(bool); ok {
-				return strconv.FormatBool(b)
-			}
+		if value.Boolean.IsNil() {
 			return ""
 		}
-		return ""
+		return strconv.FormatBool(*value.Boolean.Bool)
 
 	case model.AttributeInputTypeDate:
-		if tim, ok := attributeData.DateTime.(time.Time); ok {
-			return tim.Format("2006-01-02")
+		if value.Datetime.IsNil() {
+			return ""
 		}
-		return ""
+		return value.Datetime.Time.UTC().Format("2006-01-02")
 
 	case model.AttributeInputTypeDateTime:
-		if tim, ok := attributeData.DateTime.(time.Time); ok {
-			return tim.Format("2006-01-02 15:04:05")
+		if value.Datetime.IsNil() {
+			return ""
 		}
-		return ""
+		return value.Datetime.Time.UTC().Format("2006-01-02 15:04:05")
 
 	case model.AttributeInputTypeSwatch:
-		if attributeData.FileUrl != nil {
-			if strURL, ok := attributeData.FileUrl.(string); ok {
-				return filepath.Join(*s.srv.Config().ServiceSettings.SiteURL, strURL)
-			}
-			return ""
-		}
-		if attributeData.Value != nil {
-			return fmt.Sprintf("%v", attributeData.Value)
+		if !value.FileURL.IsNil() {
+			return s.getFileURL(*value.FileURL.String)
 		}
+		return value.Value
 
 	default:
-		if attributeData.ValueName != nil {
-			return fmt.Sprintf("%v", attributeData.ValueName)
-		} else if attributeData.ValueSlug != nil {
-			return fmt.Sprintf("%v", attributeData.ValueSlug)
+		if value.Name != "" {
+			return value.Name
 		}
-		return ""
+		return value.Slug
 	}
+}
 
-	return ""
+// getMediaURL returns absolute url of given product media, external media urls are returned as is
+func (s *ServiceCsv) getMediaURL(media *model.ProductMedium) string {
+	if !media.ExternalURL.IsNil() && *media.ExternalURL.String != "" {
+		return *media.ExternalURL.String
+	}
+	return model_helper.ProductMediaImageURL(*s.srv.Config().ServiceSettings.SiteURL, media.Image)
 }
 
-func (s *ServiceCsv) addChannelInfoToData(pk string, channelData model_types.JSONString, resultData map[string]map[string][]any, fields []string) map[string]map[string][]any {
-	slug, ok := channelData["slug"]
-	if ok && slug != nil {
-		for _, field := range fields {
-			header := fmt.Sprintf("%v (channel %s)", slug, strings.ReplaceAll(field, "_", " "))
+// getFileURL returns absolute url of given media file path, the same way media urls are served by the api
+func (s *ServiceCsv) getFileURL(path string) string {
+	return strings.TrimRight(*s.srv.Config().ServiceSettings.SiteURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
 
-			if _, ok := resultData[header]; !ok {
-				resultData[pk][header] = append(resultData[pk][header], channelData[field])
-			}
-		}
-	}
+// channelHeader returns header of given channel listing field. E.g "default-channel (channel published)"
+func channelHeader(channelSlug, field string) string {
+	return fmt.Sprintf("%s (channel %s)", channelSlug, strings.ReplaceAll(field, "_", " "))
+}
 
-	return resultData
+func joinExportValues(values []string) string {
+	values = lo.Filter(values, func(value string, _ int) bool { return value != "" })
+	return strings.Join(values, ", ")
+}
+
+func formatExportMillis(millis *int64) string {
+	if millis == nil {
+		return ""
+	}
+	return model_helper.GetTimeForMillis(*millis).UTC().Format("2006-01-02 15:04:05")
 }
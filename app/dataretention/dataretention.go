@@ -0,0 +1,262 @@
+/*
+NOTE: This package is initialized during server startup (modules/imports does that)
+so the init() function get the chance to register a function to create the data retention job interface
+*/
+package dataretention
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sitename/sitename/app"
+	ejobs "github.com/sitename/sitename/einterfaces/jobs"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	jobName = "DataRetention"
+
+	// deleteBatchSize is the maximum number of rows deleted by one query
+	deleteBatchSize = 1000
+)
+
+// DataRetentionJob builds the worker and scheduler of jobs deleting data older than its configured retention
+type DataRetentionJob struct {
+	srv *app.Server
+}
+
+func init() {
+	app.RegisterJobsDataRetentionJobInterface(func(s *app.Server) ejobs.DataRetentionJobInterface {
+		return New(s)
+	})
+}
+
+func New(s *app.Server) *DataRetentionJob {
+	return &DataRetentionJob{srv: s}
+}
+
+var _ ejobs.DataRetentionJobInterface = (*DataRetentionJob)(nil)
+
+// policy is the retention of one kind of data
+type policy struct {
+	name        string // key of the counts saved into job data
+	endTime     int64  // data older than this is deleted
+	count       func(endTime int64) (int64, *model_helper.AppError)
+	deleteBatch func(endTime int64, limit int) (int64, *model_helper.AppError)
+}
+
+// storeCount wraps given store count function to return app errors
+func storeCount(name string, count func(endTime int64) (int64, error)) func(int64) (int64, *model_helper.AppError) {
+	return func(endTime int64) (int64, *model_helper.AppError) {
+		total, err := count(endTime)
+		if err != nil {
+			return 0, model_helper.NewAppError("DataRetention", "ent.data_retention.count.app_error", map[string]any{"Name": name}, err.Error(), http.StatusInternalServerError)
+		}
+		return total, nil
+	}
+}
+
+// storeDeleteBatch wraps given store batch deletion function to return app errors
+func storeDeleteBatch(name string, deleteBatch func(endTime int64, limit int64) (int64, error)) func(int64, int) (int64, *model_helper.AppError) {
+	return func(endTime int64, limit int) (int64, *model_helper.AppError) {
+		deleted, err := deleteBatch(endTime, int64(limit))
+		if err != nil {
+			return 0, model_helper.NewAppError("DataRetention", "ent.data_retention.delete.app_error", map[string]any{"Name": name}, err.Error(), http.StatusInternalServerError)
+		}
+		return deleted, nil
+	}
+}
+
+// retentionEndTime returns the time data kept for given number of days must be created after
+func retentionEndTime(now int64, days int) int64 {
+	return now - (time.Duration(days) * 24 * time.Hour).Milliseconds()
+}
+
+// policies returns retention policies enabled in given config
+func (j *DataRetentionJob) policies(cfg *model_helper.Config, now int64) []policy {
+	var (
+		settings = cfg.DataRetentionSettings
+		store    = j.srv.Store
+		policies []policy
+	)
+
+	if days := *cfg.ShopSettings.AnonymousCheckoutRetentionDays; days > 0 {
+		policies = append(policies, policy{
+			name:        "anonymous_checkouts",
+			endTime:     retentionEndTime(now, days),
+			count:       j.srv.Checkout.CountAnonymousCheckouts,
+			deleteBatch: j.srv.Checkout.PurgeAnonymousCheckouts,
+		})
+	}
+	if *settings.EnableCustomerEventDeletion {
+		policies = append(policies, policy{
+			name:        "customer_events",
+			endTime:     retentionEndTime(now, *settings.CustomerEventRetentionDays),
+			count:       storeCount("customer_events", store.CustomerEvent().CountBefore),
+			deleteBatch: storeDeleteBatch("customer_events", store.CustomerEvent().PermanentDeleteBatch),
+		})
+	}
+	if *settings.EnableOrderEventDeletion {
+		policies = append(policies, policy{
+			name:        "order_events",
+			endTime:     retentionEndTime(now, *settings.OrderEventRetentionDays),
+			count:       storeCount("order_events", store.OrderEvent().CountBefore),
+			deleteBatch: storeDeleteBatch("order_events", store.OrderEvent().PermanentDeleteBatch),
+		})
+	}
+	if *settings.EnableAuditDeletion {
+		policies = append(policies, policy{
+			name:        "audits",
+			endTime:     retentionEndTime(now, *settings.AuditRetentionDays),
+			count:       storeCount("audits", store.Audit().CountBefore),
+			deleteBatch: storeDeleteBatch("audits", store.Audit().PermanentDeleteBatch),
+		})
+	}
+	if *settings.EnableExpiredSessionDeletion {
+		endTime := retentionEndTime(now, *settings.ExpiredSessionRetentionDays)
+		policies = append(policies, policy{
+			name:        "expired_sessions",
+			endTime:     endTime,
+			count:       storeCount("expired_sessions", store.Session().CountExpiredBefore),
+			deleteBatch: storeDeleteBatch("expired_sessions", store.Session().PermanentDeleteExpiredBatch),
+		})
+		// tokens are only deleted once they expired
+		policies = append(policies, policy{
+			name:        "expired_tokens",
+			endTime:     min(endTime, now-model_helper.MAX_TOKEN_EXIPRY_TIME),
+			count:       storeCount("expired_tokens", store.Token().CountBefore),
+			deleteBatch: storeDeleteBatch("expired_tokens", store.Token().PermanentDeleteBatch),
+		})
+	}
+	if *settings.EnableFileDeletion {
+		policies = append(policies, policy{
+			name:        "export_files",
+			endTime:     retentionEndTime(now, *settings.FileRetentionDays),
+			count:       storeCount("export_files", store.CsvExportFile().CountBefore),
+			deleteBatch: j.deleteExportFiles,
+		})
+	}
+
+	return policies
+}
+
+func (j *DataRetentionJob) deleteExportFiles(endTime int64, limit int) (int64, *model_helper.AppError) {
+	files, appErr := j.srv.Csv.ExportFilesByOption(model_helper.ExportFileFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ExportFileWhere.CreatedAt.LT(endTime),
+			qm.Limit(limit),
+		),
+	})
+	if appErr != nil {
+		return 0, appErr
+	}
+	if appErr := j.srv.Csv.DeleteExportFiles(files); appErr != nil {
+		return 0, appErr
+	}
+	return int64(len(files)), nil
+}
+
+// isEnabled checks whether at least one retention policy is enabled
+func isEnabled(cfg *model_helper.Config) bool {
+	settings := cfg.DataRetentionSettings
+	return *cfg.ShopSettings.AnonymousCheckoutRetentionDays > 0 ||
+		*settings.EnableCustomerEventDeletion ||
+		*settings.EnableOrderEventDeletion ||
+		*settings.EnableAuditDeletion ||
+		*settings.EnableExpiredSessionDeletion ||
+		*settings.EnableFileDeletion
+}
+
+// run counts data of every enabled policy older than its retention, saving counts into job data as "<policy>_count".
+// Unless "dry_run" of job data is "true", it then deletes the data in batches and saves the deleted numbers into job
+// data as "<policy>_deleted", updating progress of the job after every batch.
+func (j *DataRetentionJob) run(job model.Job) *model_helper.AppError {
+	if job.Data == nil {
+		job.Data = model_types.JSONString{}
+	}
+	dryRun, _ := job.Data.Get("dry_run", "").(string)
+
+	now := model_helper.GetMillis()
+	policies := j.policies(j.srv.Config(), now)
+
+	var total int64
+	counts := make([]int64, len(policies))
+	for i, policy := range policies {
+		count, appErr := policy.count(policy.endTime)
+		if appErr != nil {
+			return appErr
+		}
+		counts[i] = count
+		total += count
+		job.Data.Set(policy.name+"_count", count)
+	}
+
+	if dryRun == "true" {
+		return j.srv.Jobs.UpdateInProgressJobData(job)
+	}
+
+	var deleted int64
+	for i, policy := range policies {
+		var policyDeleted int64
+		for policyDeleted < counts[i] {
+			batchDeleted, appErr := policy.deleteBatch(policy.endTime, deleteBatchSize)
+			if appErr != nil {
+				return appErr
+			}
+			if batchDeleted == 0 {
+				break
+			}
+			policyDeleted += batchDeleted
+			deleted += batchDeleted
+
+			job.Data.Set(policy.name+"_deleted", policyDeleted)
+			if appErr := j.srv.Jobs.SetJobProgress(job, min(deleted*100/max(total, 1), 100)); appErr != nil {
+				return appErr
+			}
+		}
+		slog.Info("Deleted data older than its retention", slog.String("policy", policy.name), slog.Int("deleted", int(policyDeleted)))
+	}
+
+	// indexes only lose documents every policy agrees are expired
+	if len(policies) > 0 {
+		cutoff := policies[0].endTime
+		for _, policy := range policies[1:] {
+			cutoff = min(cutoff, policy.endTime)
+		}
+		for _, engine := range j.srv.SearchEngine.GetActiveEngines() {
+			if appErr := engine.DataRetentionDeleteIndexes(time.UnixMilli(cutoff)); appErr != nil {
+				slog.Error("Failed to delete expired documents from search indexes", slog.String("engine", engine.GetName()), slog.Err(appErr))
+			}
+		}
+	}
+
+	return j.srv.Jobs.UpdateInProgressJobData(job)
+}
+
+func (j *DataRetentionJob) MakeWorker() model_helper.Worker {
+	execute := func(job model.Job) error {
+		if appErr := j.run(job); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+	return jobs.NewSimpleWorker(jobName, j.srv.Jobs, execute, isEnabled)
+}
+
+// MakeScheduler returns a scheduler running the job every day at DataRetentionSettings.DeletionJobStartTime
+func (j *DataRetentionJob) MakeScheduler() model_helper.Scheduler {
+	startTime := func(cfg *model_helper.Config) *time.Time {
+		parsed, err := time.Parse("15:04", *cfg.DataRetentionSettings.DeletionJobStartTime)
+		if err != nil {
+			slog.Error("Cannot parse data retention job start time", slog.Err(err))
+			return nil
+		}
+		return &parsed
+	}
+	return jobs.NewDailyScheduler(j.srv.Jobs, model.JobTypeDataRetention, startTime, isEnabled)
+}
@@ -0,0 +1,130 @@
+package dataretention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/modules/jobs"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/services/searchengine"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsEnabled(t *testing.T) {
+	cfg := &model_helper.Config{}
+	cfg.SetDefaults()
+	require.True(t, isEnabled(cfg), "anonymous checkouts are purged by default")
+
+	cfg.ShopSettings.AnonymousCheckoutRetentionDays = model_helper.GetPointerOfValue(0)
+	require.False(t, isEnabled(cfg))
+
+	cfg.DataRetentionSettings.EnableAuditDeletion = model_helper.GetPointerOfValue(true)
+	require.True(t, isEnabled(cfg))
+}
+
+func TestRetentionEndTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, now.AddDate(0, 0, -30).UnixMilli(), retentionEndTime(now.UnixMilli(), 30))
+}
+
+// newTestJob returns a data retention job of a server whose config is changed by given function
+func newTestJob(t *testing.T, mockStore *mocks.Store, configure func(cfg *model_helper.Config)) *DataRetentionJob {
+	t.Helper()
+
+	memoryStore, err := config.NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := config.NewStoreFromBacking(memoryStore, nil, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { configStore.Close() })
+
+	cfg := configStore.Get().Clone()
+	cfg.ShopSettings.AnonymousCheckoutRetentionDays = model_helper.GetPointerOfValue(0)
+	configure(cfg)
+	_, _, err = configStore.Set(cfg)
+	require.NoError(t, err)
+
+	return New(&app.Server{
+		Store:        mockStore,
+		ConfigStore:  configStore,
+		Jobs:         &jobs.JobServer{Store: mockStore},
+		SearchEngine: searchengine.NewBroker(cfg),
+	})
+}
+
+func TestRunDryRun(t *testing.T) {
+	audits := &mocks.AuditStore{}
+	audits.On("CountBefore", mock.Anything).Return(int64(12), nil)
+	exportFiles := &mocks.CsvExportFileStore{}
+	exportFiles.On("CountBefore", mock.Anything).Return(int64(3), nil)
+	var saved []model.Job
+	jobStore := &mocks.JobStore{}
+	jobStore.On("UpdateOptimistically", mock.Anything, model.JobStatusInProgress).Return(func(job model.Job, _ model.JobStatus) (bool, error) {
+		saved = append(saved, job)
+		return true, nil
+	})
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Audit").Return(audits)
+	mockStore.On("CsvExportFile").Return(exportFiles)
+	mockStore.On("Job").Return(jobStore)
+
+	job := newTestJob(t, mockStore, func(cfg *model_helper.Config) {
+		cfg.DataRetentionSettings.EnableAuditDeletion = model_helper.GetPointerOfValue(true)
+		cfg.DataRetentionSettings.EnableFileDeletion = model_helper.GetPointerOfValue(true)
+	})
+
+	appErr := job.run(model.Job{ID: model_helper.NewId(), Data: model_types.JSONString{"dry_run": "true"}})
+	require.Nil(t, appErr)
+
+	require.Len(t, saved, 1)
+	require.EqualValues(t, 12, saved[0].Data.Get("audits_count"))
+	require.EqualValues(t, 3, saved[0].Data.Get("export_files_count"))
+	require.Nil(t, saved[0].Data.Get("audits_deleted"))
+
+	// export files are counted without loading them, and nothing is deleted
+	exportFiles.AssertNotCalled(t, "FilterByOptions", mock.Anything)
+	audits.AssertNotCalled(t, "PermanentDeleteBatch", mock.Anything, mock.Anything)
+}
+
+func TestRunDeletesInBatches(t *testing.T) {
+	audits := &mocks.AuditStore{}
+	audits.On("CountBefore", mock.Anything).Return(int64(2500), nil)
+	audits.On("PermanentDeleteBatch", mock.Anything, int64(deleteBatchSize)).Return(int64(deleteBatchSize), nil).Twice()
+	audits.On("PermanentDeleteBatch", mock.Anything, int64(deleteBatchSize)).Return(int64(500), nil).Once()
+	var saved []model.Job
+	jobStore := &mocks.JobStore{}
+	jobStore.On("UpdateOptimistically", mock.Anything, model.JobStatusInProgress).Return(func(job model.Job, _ model.JobStatus) (bool, error) {
+		saved = append(saved, job)
+		return true, nil
+	})
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Audit").Return(audits)
+	mockStore.On("Job").Return(jobStore)
+
+	job := newTestJob(t, mockStore, func(cfg *model_helper.Config) {
+		cfg.DataRetentionSettings.EnableAuditDeletion = model_helper.GetPointerOfValue(true)
+		cfg.DataRetentionSettings.AuditRetentionDays = model_helper.GetPointerOfValue(30)
+	})
+
+	before := model_helper.GetMillis()
+	appErr := job.run(model.Job{ID: model_helper.NewId()})
+	require.Nil(t, appErr)
+
+	audits.AssertNumberOfCalls(t, "PermanentDeleteBatch", 3)
+	endTime := audits.Calls[len(audits.Calls)-1].Arguments.Get(0).(int64)
+	require.InDelta(t, retentionEndTime(before, 30), endTime, float64(time.Minute.Milliseconds()))
+
+	// progress is saved after every batch, then the final job data
+	require.Len(t, saved, 4)
+	require.Equal(t, []int64{40, 80, 100}, []int64{saved[0].Progress, saved[1].Progress, saved[2].Progress})
+	last := saved[len(saved)-1]
+	require.EqualValues(t, 2500, last.Data.Get("audits_count"))
+	require.EqualValues(t, 2500, last.Data.Get("audits_deleted"))
+}
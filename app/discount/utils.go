@@ -14,12 +14,13 @@ import (
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/modules/slog"
 	"github.com/sitename/sitename/modules/util"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
-func (a *ServiceDiscount) AlterVoucherUsage(voucher model.Voucher, usageDelta int) (*model.Voucher, *model_helper.AppError) {
+func (a *ServiceDiscount) AlterVoucherUsage(transaction boil.ContextTransactor, voucher model.Voucher, usageDelta int) (*model.Voucher, *model_helper.AppError) {
 	voucher.Used += usageDelta
-	return a.UpsertVoucher(voucher)
+	return a.UpsertVoucher(transaction, voucher)
 }
 
 func (a *ServiceDiscount) AddVoucherUsageByCustomer(voucher model.Voucher, customerEmail string) (*model_helper.NotApplicable, *model_helper.AppError) {
@@ -44,7 +45,7 @@ func (a *ServiceDiscount) AddVoucherUsageByCustomer(voucher model.Voucher, custo
 	return nil, nil
 }
 
-func (a *ServiceDiscount) RemoveVoucherUsageByCustomer(voucher model.Voucher, customerEmail string) *model_helper.AppError {
+func (a *ServiceDiscount) RemoveVoucherUsageByCustomer(transaction boil.ContextTransactor, voucher model.Voucher, customerEmail string) *model_helper.AppError {
 	voucherCustomers, appErr := a.VoucherCustomersByOption(model_helper.VoucherCustomerFilterOption{
 		CommonQueryOptions: model_helper.NewCommonQueryOptions(
 			model.VoucherCustomerWhere.VoucherID.EQ(voucher.ID),
@@ -57,7 +58,7 @@ func (a *ServiceDiscount) RemoveVoucherUsageByCustomer(voucher model.Voucher, cu
 
 	ids := lo.Map(voucherCustomers, func(vc *model.VoucherCustomer, _ int) string { return vc.ID })
 
-	err := a.srv.Store.VoucherCustomer().Delete(ids)
+	err := a.srv.Store.VoucherCustomer().Delete(transaction, ids)
 	if err != nil {
 		return model_helper.NewAppError("RemoveVoucherUsageByCustomer", "app.discount.error_delating_voucher_customer_relations.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -19,8 +19,8 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-func (a *ServiceDiscount) UpsertVoucher(voucher model.Voucher) (*model.Voucher, *model_helper.AppError) {
-	upserdVoucher, err := a.srv.Store.DiscountVoucher().Upsert(voucher)
+func (a *ServiceDiscount) UpsertVoucher(transaction boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, *model_helper.AppError) {
+	upserdVoucher, err := a.srv.Store.DiscountVoucher().Upsert(transaction, voucher)
 	if err != nil {
 		if appErr, ok := err.(*model_helper.AppError); ok {
 			return nil, appErr
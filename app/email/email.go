@@ -396,6 +396,127 @@ func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, site
 	return nil
 }
 
+// BackInStockProduct is an item listed in back in stock emails
+type BackInStockProduct struct {
+	Name string
+	URL  string
+}
+
+// SendBackInStockEmail sends one email to given address, listing all given products that are available again
+func (es *Service) SendBackInStockEmail(email string, products []BackInStockProduct, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T(
+		"api.templates.back_in_stock_subject",
+		map[string]any{
+			"SiteName": *es.config().ServiceSettings.SiteName,
+		},
+	)
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.back_in_stock_body.title")
+	data.Props["Info"] = T("api.templates.back_in_stock_body.info")
+	data.Props["Products"] = products
+	data.Props["Footnote"] = T("api.templates.back_in_stock_body.footnote")
+
+	body, err := es.templatesContainer.RenderToString("back_in_stock_body", data)
+	if err != nil {
+		return err
+	}
+
+	return es.SendNotificationMail(email, subject, body)
+}
+
+// AbandonedCheckoutLine is a checkout line listed in abandoned checkout reminder emails
+type AbandonedCheckoutLine struct {
+	Name     string
+	Quantity int
+}
+
+// SendAbandonedCheckoutEmail sends a reminder about an abandoned checkout, with a link restoring it
+func (es *Service) SendAbandonedCheckoutEmail(email string, lines []AbandonedCheckoutLine, recoveryURL string, reminderNumber int, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T(
+		"api.templates.abandoned_checkout_subject",
+		map[string]any{
+			"SiteName": *es.config().ServiceSettings.SiteName,
+		},
+	)
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	if reminderNumber > 1 {
+		data.Props["Title"] = T("api.templates.abandoned_checkout_body.title_reminder")
+	} else {
+		data.Props["Title"] = T("api.templates.abandoned_checkout_body.title")
+	}
+	data.Props["Info"] = T("api.templates.abandoned_checkout_body.info")
+	data.Props["Lines"] = lines
+	data.Props["ButtonURL"] = recoveryURL
+	data.Props["Button"] = T("api.templates.abandoned_checkout_body.button")
+
+	body, err := es.templatesContainer.RenderToString("abandoned_checkout_body", data)
+	if err != nil {
+		return err
+	}
+
+	return es.SendNotificationMail(email, subject, body)
+}
+
+// SendUserDataExportEmail sends a link to download the archive of personal data requested by the customer
+func (es *Service) SendUserDataExportEmail(email string, downloadURL string, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T(
+		"api.templates.user_data_export_subject",
+		map[string]any{
+			"SiteName": *es.config().ServiceSettings.SiteName,
+		},
+	)
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.user_data_export_body.title")
+	data.Props["Info"] = T("api.templates.user_data_export_body.info")
+	data.Props["ButtonURL"] = downloadURL
+	data.Props["Button"] = T("api.templates.user_data_export_body.button")
+
+	body, err := es.templatesContainer.RenderToString("user_data_export_body", data)
+	if err != nil {
+		return err
+	}
+
+	return es.SendNotificationMail(email, subject, body)
+}
+
+// SendDataErasureRequestEmail sends a link confirming the erasure of the customer's personal data
+func (es *Service) SendDataErasureRequestEmail(email string, confirmURL string, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T(
+		"api.templates.data_erasure_request_subject",
+		map[string]any{
+			"SiteName": *es.config().ServiceSettings.SiteName,
+		},
+	)
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.data_erasure_request_body.title")
+	data.Props["Info"] = T("api.templates.data_erasure_request_body.info")
+	data.Props["ButtonURL"] = confirmURL
+	data.Props["Button"] = T("api.templates.data_erasure_request_body.button")
+
+	body, err := es.templatesContainer.RenderToString("data_erasure_request_body", data)
+	if err != nil {
+		return err
+	}
+
+	return es.SendNotificationMail(email, subject, body)
+}
+
 func (es *Service) CreateVerifyEmailToken(userID string, newEmail string) (*model.Token, error) {
 	tokenExtra := struct {
 		UserId string
@@ -3,7 +3,9 @@ package app
 import (
 	"github.com/sitename/sitename/einterfaces"
 	ejobs "github.com/sitename/sitename/einterfaces/jobs"
+	"github.com/sitename/sitename/model_helper"
 	tjobs "github.com/sitename/sitename/modules/jobs/interfaces"
+	"github.com/sitename/sitename/modules/slog"
 	"github.com/sitename/sitename/services/searchengine"
 )
 
@@ -84,6 +86,8 @@ var (
 	complianceInterface    func(*Server) einterfaces.ComplianceInterface
 	elasticsearchInterface func(*Server) searchengine.SearchEngineInterface
 	clusterInterface       func(*Server) einterfaces.ClusterInterface
+	ldapInterface          func(*Server) einterfaces.LdapInterface
+	samlInterface          func(*Server) einterfaces.SamlInterface
 	dataRetentionInterface func(*Server) einterfaces.DataRetentionInterface
 	metricsInterface       func(*Server) einterfaces.MetricsInterface
 )
@@ -100,6 +104,14 @@ func RegisterClusterInterface(f func(*Server) einterfaces.ClusterInterface) {
 	clusterInterface = f
 }
 
+func RegisterLdapInterface(f func(*Server) einterfaces.LdapInterface) {
+	ldapInterface = f
+}
+
+func RegisterSamlInterface(f func(*Server) einterfaces.SamlInterface) {
+	samlInterface = f
+}
+
 func RegisterDataRetentionInterface(f func(*Server) einterfaces.DataRetentionInterface) {
 	dataRetentionInterface = f
 }
@@ -124,6 +136,23 @@ func (s *Server) initEnterprise() {
 	if clusterInterface != nil {
 		s.Cluster = clusterInterface(s)
 	}
+	if ldapInterface != nil {
+		s.Ldap = ldapInterface(s)
+	}
+	if samlInterface != nil {
+		s.Saml = samlInterface(s)
+		if err := s.Saml.ConfigureSP(); err != nil {
+			slog.Error("Failed to configure SAML service provider", slog.Err(err))
+		}
+		s.AddConfigListener(func(_, _ *model_helper.Config) {
+			if err := s.Saml.ConfigureSP(); err != nil {
+				slog.Error("Failed to configure SAML service provider", slog.Err(err))
+			}
+		})
+	}
+	if accountMigrationInterface != nil {
+		s.AccountMigration = accountMigrationInterface(New(ServerConnector(s)))
+	}
 	if elasticsearchInterface != nil {
 		s.SearchEngine.RegisterElasticsearchEngine(elasticsearchInterface(s))
 	}
@@ -122,7 +122,9 @@ func parseOldFilenames(filenames []string, channelID, userID string) [][]string
 
 // FileBackend returns filebackend of the system
 func (a *ServiceFile) FileBackend() (filestore.FileBackend, *model_helper.AppError) {
-	backend, err := filestore.NewFileBackend(a.srv.Config().FileSettings.ToFileBackendSettings(true))
+	settings := a.srv.Config().FileSettings.ToFileBackendSettings(true)
+	settings.LocalSignedURLBase = *a.srv.Config().ServiceSettings.SiteURL + SignedFilesURLPath
+	backend, err := filestore.NewFileBackend(settings)
 	if err != nil {
 		return nil, model_helper.NewAppError("FileBackend", "app.file.no_driver.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -145,6 +147,10 @@ func connectionTestErrorToAppError(connTestErr error) *model_helper.AppError {
 		return model_helper.NewAppError("TestConnection", "api.file.test_connection_s3_auth.app_error", nil, err.Error(), http.StatusInternalServerError)
 	case *filestore.S3FileBackendNoBucketError:
 		return model_helper.NewAppError("TestConnection", "api.file.test_connection_s3_bucket_does_not_exist.app_error", nil, err.Error(), http.StatusInternalServerError)
+	case *filestore.GCSFileBackendNoBucketError:
+		return model_helper.NewAppError("TestConnection", "api.file.test_connection_gcs_bucket_does_not_exist.app_error", nil, err.Error(), http.StatusInternalServerError)
+	case *filestore.AzureFileBackendNoContainerError:
+		return model_helper.NewAppError("TestConnection", "api.file.test_connection_azure_container_does_not_exist.app_error", nil, err.Error(), http.StatusInternalServerError)
 	default:
 		return model_helper.NewAppError("TestConnection", "api.file.test_connection.app_error", nil, connTestErr.Error(), http.StatusInternalServerError)
 	}
@@ -0,0 +1,104 @@
+package file
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sitename/sitename/app/request"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/filestore"
+)
+
+const (
+	// SignedFilesURLPath is path of the endpoint serving presigned urls of the local file backend.
+	SignedFilesURLPath = "/files/signed"
+	// presignedURLExpiry is lifetime of presigned urls. It is long enough for large product videos
+	// and digital contents to be uploaded in one request.
+	presignedURLExpiry = 6 * time.Hour
+)
+
+// PresignedUploadURL returns an url which allows the client to upload the data of given upload session
+// directly to the file backend with a PUT request. Once uploaded, the session is finished with CompleteUploadSession.
+func (a *ServiceFile) PresignedUploadURL(us *model.UploadSession) (string, *model_helper.AppError) {
+	backend, appErr := a.FileBackend()
+	if appErr != nil {
+		return "", appErr
+	}
+
+	signed, err := backend.PresignedPutURL(uploadSessionDataPath(us), presignedURLExpiry)
+	if err != nil {
+		return "", model_helper.NewAppError("PresignedUploadURL", "app.file.presign.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return signed, nil
+}
+
+// PresignedDownloadURL returns an url which allows the client to download the file at given path
+// directly from the file backend.
+func (a *ServiceFile) PresignedDownloadURL(path string) (string, *model_helper.AppError) {
+	backend, appErr := a.FileBackend()
+	if appErr != nil {
+		return "", appErr
+	}
+
+	signed, err := backend.PresignedGetURL(path, presignedURLExpiry)
+	if err != nil {
+		return "", model_helper.NewAppError("PresignedDownloadURL", "app.file.presign.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return signed, nil
+}
+
+// VerifyLocalSignedURL checks that a request with given method to path of the local file backend
+// carries a valid, unexpired signature in its query.
+func (a *ServiceFile) VerifyLocalSignedURL(method, path string, query url.Values) *model_helper.AppError {
+	err := filestore.VerifyLocalSignedURL(model_helper.GetValueOfPointerOrZero(a.srv.Config().FileSettings.PresignedURLSigningKey), method, path, query)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, filestore.ErrLocalSignedURLExpired) {
+		return model_helper.NewAppError("VerifyLocalSignedURL", "app.file.signed_url.expired.app_error", nil, err.Error(), http.StatusForbidden)
+	}
+	return model_helper.NewAppError("VerifyLocalSignedURL", "app.file.signed_url.invalid.app_error", nil, err.Error(), http.StatusForbidden)
+}
+
+// CompleteUploadSession finishes given upload session whose data was uploaded with a presigned url.
+// It verifies the uploaded object exists and has the size of the session before creating its FileInfo.
+func (a *ServiceFile) CompleteUploadSession(c *request.Context, us *model.UploadSession) (*model.FileInfo, *model_helper.AppError) {
+	unlock, appErr := a.lockUploadSession("CompleteUploadSession", us)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer unlock()
+
+	uploadPath := uploadSessionDataPath(us)
+
+	exists, appErr := a.FileExists(uploadPath)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !exists {
+		return nil, model_helper.NewAppError("CompleteUploadSession", "app.upload.complete.not_found.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	size, appErr := a.FileSize(uploadPath)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if size != us.FileSize {
+		return nil, model_helper.NewAppError("CompleteUploadSession", "app.upload.complete.size_mismatch.app_error", map[string]any{"Expected": us.FileSize, "Actual": size}, "", http.StatusBadRequest)
+	}
+
+	if us.FileOffset != us.FileSize {
+		us.FileOffset = us.FileSize
+		var err error
+		us, err = a.srv.Store.UploadSession().Upsert(*us)
+		if err != nil {
+			return nil, model_helper.NewAppError("CompleteUploadSession", "app.upload.upload_data.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return a.completeUpload(c, us, uploadPath)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/sitename/sitename/app/request"
 	"github.com/sitename/sitename/model"
@@ -57,79 +58,42 @@ func (a *ServiceFile) GetUploadSession(uploadId string) (*model.UploadSession, *
 	return us, nil
 }
 
-// func (a *ServiceFile) CreateUploadSession(us *model.UploadSession) (*model.UploadSession, *model_helper.AppError) {
-// 	if us.FileSize > *a.srv.Config().FileSettings.MaxFileSize {
-// 		return nil, model_helper.NewAppError(
-// 			"CreateUploadSession",
-// 			"app.upload.create.upload_too_large.app_error",
-// 			nil, "", http.StatusRequestEntityTooLarge,
-// 		)
-// 	}
-
-// 	us.FileOffset = 0
-// 	now := time.Now()
-// 	us.CreateAt = model.GetMillisForTime(now)
-// 	if us.Type == model.UploadTypeAttachment {
-// 		us.Path = now.Format("20060102") + "/teams/noteam/channels/" + us.ChannelId + "/users/" + us.UserID + "/" + us.Id + "/" + filepath.Base(us.FileName)
-// 	} else if us.Type == model.UploadTypeImport {
-// 		us.Path = filepath.Clean(*a.srv.Config().ImportSettings.Directory) + "/" + us.Id + "_" + filepath.Base(us.FileName)
-// 	}
-// 	if err := us.IsValid(); err != nil {
-// 		return nil, err
-// 	}
-
-// 	if us.Type == model.UploadTypeAttachment {
-// 		channel, err := a.GetChannel(us.ChannelId)
-// 		if err != nil {
-// 			return nil, model_helper.NewAppError("CreateUploadSession", "app.upload.create.incorrect_channel_id.app_error",
-// 				map[string]any{"channelId": us.ChannelId}, "", http.StatusBadRequest)
-// 		}
-// 		if channel.DeleteAt != 0 {
-// 			return nil, model_helper.NewAppError("CreateUploadSession", "app.upload.create.cannot_upload_to_deleted_channel.app_error",
-// 				map[string]any{"channelId": us.ChannelId}, "", http.StatusBadRequest)
-// 		}
-// 	}
-
-// 	us, storeErr := a.srv.Store.UploadSession().Save(us)
-// 	if storeErr != nil {
-// 		return nil, model_helper.NewAppError("CreateUploadSession", "app.upload.create.save.app_error", nil, storeErr.Error(), http.StatusInternalServerError)
-// 	}
-
-// 	return us, nil
-// }
-
-func (a *ServiceFile) UploadData(c *request.Context, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model_helper.AppError) {
-	// prevent more than one caller to upload data at the same time for a given upload session.
-	// This is to avoid possible inconsistencies.
-	a.uploadLockMapMut.Lock()
-	locked := a.uploadLockMap[us.ID]
-	if locked {
-		// session lock is already taken, return error.
-		a.uploadLockMapMut.Unlock()
-		return nil, model_helper.NewAppError("UploadData", "app.upload.upload_data.concurrent.app_error", nil, "", http.StatusBadRequest)
+// CreateUploadSession saves given upload session, its data is written to the import directory for imports,
+// otherwise to a directory of the uploading user.
+func (a *ServiceFile) CreateUploadSession(us *model.UploadSession) (*model.UploadSession, *model_helper.AppError) {
+	if us.FileSize > *a.srv.Config().FileSettings.MaxFileSize {
+		return nil, model_helper.NewAppError("CreateUploadSession", "app.upload.create.upload_too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
 	}
-	// grab the session lock.
-	a.uploadLockMap[us.ID] = true
-	a.uploadLockMapMut.Unlock()
 
-	// reset the session lock on exit.
-	defer func() {
-		a.uploadLockMapMut.Lock()
-		delete(a.uploadLockMap, us.ID)
-		a.uploadLockMapMut.Unlock()
-	}()
+	us.ID = ""
+	us.FileOffset = 0
+	now := time.Now()
+	us.CreatedAt = now.UnixMilli()
+	if us.Type == model.UploadTypeImport {
+		us.Path = filepath.Clean(*a.srv.Config().ImportSettings.Directory) + "/" + model_helper.NewId() + "_" + filepath.Base(us.FileName)
+	} else {
+		us.Path = now.Format("20060102") + "/users/" + us.UserID + "/" + model_helper.NewId() + "/" + filepath.Base(us.FileName)
+	}
 
-	// fetch the session from store to check for inconsistencies.
-	if storedSession, err := a.GetUploadSession(us.ID); err != nil {
-		return nil, err
-	} else if us.FileOffset != storedSession.FileOffset {
-		return nil, model_helper.NewAppError("UploadData", "app.upload.upload_data.concurrent.app_error", nil, "FileOffset mismatch", http.StatusBadRequest)
+	saved, err := a.srv.Store.UploadSession().Upsert(*us)
+	if err != nil {
+		if appErr, ok := err.(*model_helper.AppError); ok {
+			return nil, appErr
+		}
+		return nil, model_helper.NewAppError("CreateUploadSession", "app.upload.create.save.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	uploadPath := us.Path
-	if us.Type == model.UploadTypeImport {
-		uploadPath += IncompleteUploadSuffix
+	return saved, nil
+}
+
+func (a *ServiceFile) UploadData(c *request.Context, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model_helper.AppError) {
+	unlock, appErr := a.lockUploadSession("UploadData", us)
+	if appErr != nil {
+		return nil, appErr
 	}
+	defer unlock()
+
+	uploadPath := uploadSessionDataPath(us)
 
 	// make sure it's not possible to upload more data than what is expected.
 	lr := &io.LimitedReader{
@@ -173,7 +137,53 @@ func (a *ServiceFile) UploadData(c *request.Context, us *model.UploadSession, rd
 		return nil, nil
 	}
 
-	// upload is done, create FileInfo
+	return a.completeUpload(c, us, uploadPath)
+}
+
+// lockUploadSession prevents more than one caller to upload data at the same time for a given upload session.
+// This is to avoid possible inconsistencies. Returned function releases the lock.
+func (a *ServiceFile) lockUploadSession(where string, us *model.UploadSession) (func(), *model_helper.AppError) {
+	a.uploadLockMapMut.Lock()
+	locked := a.uploadLockMap[us.ID]
+	if locked {
+		// session lock is already taken, return error.
+		a.uploadLockMapMut.Unlock()
+		return nil, model_helper.NewAppError(where, "app.upload.upload_data.concurrent.app_error", nil, "", http.StatusBadRequest)
+	}
+	// grab the session lock.
+	a.uploadLockMap[us.ID] = true
+	a.uploadLockMapMut.Unlock()
+
+	unlock := func() {
+		a.uploadLockMapMut.Lock()
+		delete(a.uploadLockMap, us.ID)
+		a.uploadLockMapMut.Unlock()
+	}
+
+	// fetch the session from store to check for inconsistencies.
+	if storedSession, err := a.GetUploadSession(us.ID); err != nil {
+		unlock()
+		return nil, err
+	} else if us.FileOffset != storedSession.FileOffset {
+		unlock()
+		return nil, model_helper.NewAppError(where, "app.upload.upload_data.concurrent.app_error", nil, "FileOffset mismatch", http.StatusBadRequest)
+	}
+
+	return unlock, nil
+}
+
+// uploadSessionDataPath returns path the data of given upload session is written to.
+// Imports are written to a temporary path until they are complete.
+func uploadSessionDataPath(us *model.UploadSession) string {
+	if us.Type == model.UploadTypeImport {
+		return us.Path + IncompleteUploadSuffix
+	}
+	return us.Path
+}
+
+// completeUpload creates FileInfo of the fully uploaded data of given upload session at uploadPath
+// and deletes the session.
+func (a *ServiceFile) completeUpload(c *request.Context, us *model.UploadSession, uploadPath string) (*model.FileInfo, *model_helper.AppError) {
 	f, err := a.FileReader(uploadPath)
 	if err != nil {
 		return nil, model_helper.NewAppError("UploadData", "app.upload.upload_data.read_file.app_error", nil, err.Error(), http.StatusInternalServerError)
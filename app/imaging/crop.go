@@ -0,0 +1,42 @@
+package imaging
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// CropAroundFocalPoint scales the given image down to cover width x height, then crops
+// it to that size keeping the focal point as close to the center as possible.
+// The focal point is given as fractions of the image width and height, e.g (0.5, 0.5) is the center.
+// Images smaller than the requested size are not upscaled, they are cropped to the requested aspect ratio instead.
+func CropAroundFocalPoint(img image.Image, width, height int, focalX, focalY float64) image.Image {
+	w := img.Bounds().Dx()
+	h := img.Bounds().Dy()
+	if w == 0 || h == 0 || width <= 0 || height <= 0 {
+		return img
+	}
+
+	scale := math.Max(float64(width)/float64(w), float64(height)/float64(h))
+	if scale < 1 {
+		img = imaging.Resize(img, int(math.Round(float64(w)*scale)), int(math.Round(float64(h)*scale)), imaging.Lanczos)
+		w = img.Bounds().Dx()
+		h = img.Bounds().Dy()
+	} else {
+		// keep the requested aspect ratio within the original size
+		ratio := math.Min(float64(w)/float64(width), float64(h)/float64(height))
+		width = int(math.Round(float64(width) * ratio))
+		height = int(math.Round(float64(height) * ratio))
+	}
+	width = min(max(width, 1), w)
+	height = min(max(height, 1), h)
+
+	focalX = math.Min(math.Max(focalX, 0), 1)
+	focalY = math.Min(math.Max(focalY, 0), 1)
+	left := min(max(int(math.Round(focalX*float64(w)))-width/2, 0), w-width)
+	top := min(max(int(math.Round(focalY*float64(h)))-height/2, 0), h-height)
+
+	origin := img.Bounds().Min
+	return imaging.Crop(img, image.Rect(left, top, left+width, top+height).Add(origin))
+}
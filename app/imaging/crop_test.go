@@ -0,0 +1,44 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCropAroundFocalPoint(t *testing.T) {
+	// left half is red, right half is blue
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 200; y++ {
+			if x < 200 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	t.Run("scaled down and cropped to the focal point", func(t *testing.T) {
+		cropped := CropAroundFocalPoint(img, 100, 100, 0.9, 0.5)
+		require.Equal(t, 100, cropped.Bounds().Dx())
+		require.Equal(t, 100, cropped.Bounds().Dy())
+
+		r, _, b, _ := cropped.At(50, 50).RGBA()
+		require.Zero(t, r)
+		require.NotZero(t, b)
+
+		cropped = CropAroundFocalPoint(img, 100, 100, 0, 0.5)
+		r, _, b, _ = cropped.At(50, 50).RGBA()
+		require.NotZero(t, r)
+		require.Zero(t, b)
+	})
+
+	t.Run("not upscaled", func(t *testing.T) {
+		cropped := CropAroundFocalPoint(img, 1000, 1000, 0.5, 0.5)
+		require.Equal(t, 200, cropped.Bounds().Dx())
+		require.Equal(t, 200, cropped.Bounds().Dy())
+	})
+}
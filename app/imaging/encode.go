@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"image/draw"
 	"io"
 
 	"image/jpeg"
 	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
 )
 
 // EncoderOptions holds configuration options for an image encoder.
@@ -81,3 +84,52 @@ func (e *Encoder) EncodePNG(wr io.Writer, img image.Image) error {
 
 	return nil
 }
+
+// EncodeWebP encodes the given image in WebP format and writes the data to the
+// passed writer. quality ranges from 1 to 100 like for JPEG, 100 is lossless.
+// Below 100, the precision of colors is lowered before encoding, the lower the
+// quality the more: images get much smaller at the cost of some color banding.
+func (e *Encoder) EncodeWebP(wr io.Writer, img image.Image, quality int) error {
+	if e.opts.ConcurrencyLevel > 0 {
+		e.sem <- struct{}{}
+		defer func() {
+			<-e.sem
+		}()
+	}
+
+	if bits := webpDroppedBits(quality); bits > 0 {
+		img = reduceColorPrecision(img, bits)
+	}
+
+	if err := nativewebp.Encode(wr, img, nil); err != nil {
+		return fmt.Errorf("imaging: failed to encode webp: %w", err)
+	}
+
+	return nil
+}
+
+// maxWebpDroppedBits is the most bits of precision dropped from color channels, at the lowest quality
+const maxWebpDroppedBits = 5
+
+// webpDroppedBits returns the number of low bits of color channels dropped when encoding WebP images
+// with given quality, from 0 at quality 100 to maxWebpDroppedBits.
+func webpDroppedBits(quality int) int {
+	quality = min(max(quality, 1), 100)
+	return min((100-quality+19)/20, maxWebpDroppedBits)
+}
+
+// reduceColorPrecision rounds red, green and blue channels of every pixel of given image to multiples
+// of 1<<bits. Alpha is kept as is. Fewer distinct colors make lossless WebP compression much better.
+func reduceColorPrecision(img image.Image, bits int) image.Image {
+	bounds := img.Bounds()
+	res := image.NewNRGBA(bounds)
+	draw.Draw(res, bounds, img, bounds.Min, draw.Src)
+
+	half := 1 << (bits - 1)
+	for i := 0; i < len(res.Pix); i += 4 {
+		for c := i; c < i+3; c++ {
+			res.Pix[c] = uint8(min((int(res.Pix[c])+half)>>bits<<bits, 255))
+		}
+	}
+	return res
+}
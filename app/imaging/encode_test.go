@@ -3,10 +3,13 @@ package imaging
 import (
 	"bytes"
 	"image"
+	"image/color"
+	"math/rand"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
 )
 
 func TestNewEncoder(t *testing.T) {
@@ -51,6 +54,12 @@ func TestEncoderEncode(t *testing.T) {
 		err = e.EncodeJPEG(&buf, rawImg, 50)
 		require.NoError(t, err)
 		require.NotEmpty(t, buf)
+
+		buf.Reset()
+		err = e.EncodeWebP(&buf, rawImg, 80)
+		require.NoError(t, err)
+		require.Equal(t, "RIFF", buf.String()[:4])
+		require.Equal(t, "WEBP", buf.String()[8:12])
 	})
 
 	t.Run("concurrency bounded", func(t *testing.T) {
@@ -85,3 +94,57 @@ func TestEncoderEncode(t *testing.T) {
 		require.Empty(t, e.sem)
 	})
 }
+
+func TestEncodeWebP(t *testing.T) {
+	e, err := NewEncoder(EncoderOptions{})
+	require.NoError(t, err)
+
+	// a gradient with sensor-like noise, which lossless compression handles poorly
+	rnd := rand.New(rand.NewSource(1))
+	noise := func() uint8 { return uint8(rnd.Intn(8)) }
+	rawImg := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+	for y := range 256 {
+		for x := range 256 {
+			rawImg.SetNRGBA(x, y, color.NRGBA{R: uint8(x) + noise(), G: uint8(y) + noise(), B: uint8(x+y) + noise(), A: 255})
+		}
+	}
+
+	encode := func(quality int) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, e.EncodeWebP(&buf, rawImg, quality))
+		return buf.Bytes()
+	}
+
+	t.Run("quality 100 is lossless", func(t *testing.T) {
+		decoded, err := webp.Decode(bytes.NewReader(encode(100)))
+		require.NoError(t, err)
+		for _, point := range []image.Point{{0, 0}, {17, 200}, {255, 255}} {
+			r, g, b, a := decoded.At(point.X, point.Y).RGBA()
+			wantR, wantG, wantB, wantA := rawImg.At(point.X, point.Y).RGBA()
+			require.Equal(t, []uint32{wantR, wantG, wantB, wantA}, []uint32{r, g, b, a})
+		}
+	})
+
+	t.Run("lower qualities make smaller images", func(t *testing.T) {
+		lossless, high, low := encode(100), encode(80), encode(20)
+		require.Less(t, len(high), len(lossless))
+		require.Less(t, len(low), len(high))
+
+		// colors stay close to the original ones
+		decoded, err := webp.Decode(bytes.NewReader(high))
+		require.NoError(t, err)
+		r, _, _, _ := decoded.At(100, 100).RGBA()
+		wantR, _, _, _ := rawImg.At(100, 100).RGBA()
+		require.InDelta(t, wantR>>8, r>>8, 2)
+	})
+}
+
+func TestWebpDroppedBits(t *testing.T) {
+	require.Equal(t, 0, webpDroppedBits(100))
+	require.Equal(t, 1, webpDroppedBits(90))
+	require.Equal(t, 1, webpDroppedBits(80))
+	require.Equal(t, 2, webpDroppedBits(79))
+	require.Equal(t, maxWebpDroppedBits, webpDroppedBits(1))
+	require.Equal(t, maxWebpDroppedBits, webpDroppedBits(-10))
+	require.Equal(t, 0, webpDroppedBits(200))
+}
@@ -0,0 +1,57 @@
+package ldap
+
+import (
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/app/request"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+)
+
+// accounts is the part of the account service used to save directory users, tests replace it with an in-memory one
+type accounts interface {
+	userByAuthData(authData string) (*model.User, *model_helper.AppError)
+	ldapUsers() (model.UserSlice, *model_helper.AppError)
+	createUser(user model.User) (*model.User, *model_helper.AppError)
+	updateUser(user model.User) (*model.User, *model_helper.AppError)
+	updateUserRoles(user model.User, roles string) (*model.User, *model_helper.AppError)
+	updateActive(user model.User, active bool) (*model.User, *model_helper.AppError)
+}
+
+// serverAccounts saves directory users with the account service of the server
+type serverAccounts struct {
+	srv *app.Server
+}
+
+func (a *serverAccounts) userByAuthData(authData string) (*model.User, *model_helper.AppError) {
+	return a.srv.Account.GetUserByOptions(model_helper.UserFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.UserWhere.AuthData.EQ(model_types.NewNullString(authData)),
+			model.UserWhere.AuthService.EQ(model_helper.USER_AUTH_SERVICE_LDAP),
+		),
+	})
+}
+
+func (a *serverAccounts) ldapUsers() (model.UserSlice, *model_helper.AppError) {
+	return a.srv.Account.FindUsersByOptions(model_helper.UserFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.UserWhere.AuthService.EQ(model_helper.USER_AUTH_SERVICE_LDAP),
+		),
+	})
+}
+
+func (a *serverAccounts) createUser(user model.User) (*model.User, *model_helper.AppError) {
+	return a.srv.Account.CreateUser(*request.EmptyContext(), user)
+}
+
+func (a *serverAccounts) updateUser(user model.User) (*model.User, *model_helper.AppError) {
+	return a.srv.Account.UpdateUser(user, false)
+}
+
+func (a *serverAccounts) updateUserRoles(user model.User, roles string) (*model.User, *model_helper.AppError) {
+	return a.srv.Account.UpdateUserRolesWithUser(user, roles, false)
+}
+
+func (a *serverAccounts) updateActive(user model.User, active bool) (*model.User, *model_helper.AppError) {
+	return a.srv.Account.UpdateActive(request.EmptyContext(), user, active)
+}
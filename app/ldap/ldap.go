@@ -0,0 +1,219 @@
+/*
+NOTE: This package is initialized during server startup (modules/imports does that)
+so the init() function get the chance to register functions to create the ldap interface and its sync job
+*/
+package ldap
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	goldap "github.com/mattermost/ldap"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/einterfaces"
+	ejobs "github.com/sitename/sitename/einterfaces/jobs"
+	"github.com/sitename/sitename/model_helper"
+)
+
+// conn is the part of an LDAP connection used here, tests replace it with an in-process directory
+type conn interface {
+	Bind(username, password string) error
+	Search(searchRequest *goldap.SearchRequest) (*goldap.SearchResult, error)
+	SearchWithPaging(searchRequest *goldap.SearchRequest, pagingSize uint32) (*goldap.SearchResult, error)
+	Close()
+}
+
+// Ldap authenticates users against an LDAP directory and keeps their accounts in sync with it.
+// Users are found by the attributes configured in LdapSettings.
+type Ldap struct {
+	srv      *app.Server
+	settings func() *model_helper.LdapSettings
+	dial     func(settings *model_helper.LdapSettings) (conn, error)
+	accounts accounts
+}
+
+func init() {
+	app.RegisterLdapInterface(func(s *app.Server) einterfaces.LdapInterface {
+		return New(s)
+	})
+	app.RegisterJobsLdapSyncInterface(func(a *app.App) ejobs.LdapSyncInterface {
+		return &SyncJob{
+			ldap: New(a.Srv()),
+			srv:  a.Srv(),
+		}
+	})
+}
+
+func New(s *app.Server) *Ldap {
+	return &Ldap{
+		srv: s,
+		settings: func() *model_helper.LdapSettings {
+			return &s.Config().LdapSettings
+		},
+		dial:     dialServer,
+		accounts: &serverAccounts{srv: s},
+	}
+}
+
+var _ einterfaces.LdapInterface = (*Ldap)(nil)
+
+func dialServer(settings *model_helper.LdapSettings) (conn, error) {
+	address := net.JoinHostPort(*settings.LdapServer, strconv.Itoa(*settings.LdapPort))
+	tlsConfig := &tls.Config{
+		ServerName:         *settings.LdapServer,
+		InsecureSkipVerify: *settings.SkipCertificateVerification,
+	}
+	if *settings.PublicCertificateFile != "" && *settings.PrivateKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(*settings.PublicCertificateFile, *settings.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	var (
+		c   *goldap.Conn
+		err error
+	)
+	if *settings.ConnectionSecurity == model_helper.CONN_SECURITY_TLS {
+		c, err = goldap.DialTLS("tcp", address, tlsConfig)
+	} else {
+		c, err = goldap.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if *settings.ConnectionSecurity == model_helper.CONN_SECURITY_STARTTLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	c.SetTimeout(time.Duration(*settings.QueryTimeout) * time.Second)
+	if *settings.Trace {
+		c.Debug = true
+	}
+	return c, nil
+}
+
+// connect opens a connection bound as the configured bind user. Callers must close it.
+func (l *Ldap) connect(settings *model_helper.LdapSettings) (conn, *model_helper.AppError) {
+	if !*settings.Enable {
+		return nil, model_helper.NewAppError("connect", "ent.ldap.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	c, err := l.dial(settings)
+	if err != nil {
+		return nil, model_helper.NewAppError("connect", "ent.ldap.connect.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if *settings.BindUsername != "" {
+		if err := c.Bind(*settings.BindUsername, *settings.BindPassword); err != nil {
+			c.Close()
+			return nil, model_helper.NewAppError("connect", "ent.ldap.connect.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return c, nil
+}
+
+// checkEntryPassword binds as given entry on a separate connection, so the bind user stays bound on other ones
+func (l *Ldap) checkEntryPassword(settings *model_helper.LdapSettings, entry *goldap.Entry, password string) *model_helper.AppError {
+	// servers accept binds without password as unauthenticated ones
+	if password == "" {
+		return model_helper.NewAppError("checkEntryPassword", "ent.ldap.invalid_credentials.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	c, err := l.dial(settings)
+	if err != nil {
+		return model_helper.NewAppError("checkEntryPassword", "ent.ldap.connect.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer c.Close()
+
+	if err := c.Bind(entry.DN, password); err != nil {
+		return model_helper.NewAppError("checkEntryPassword", "ent.ldap.invalid_credentials.app_error", nil, err.Error(), http.StatusUnauthorized)
+	}
+	return nil
+}
+
+// search finds entries under the base DN matching given filter. Results are paged when MaxPageSize is set.
+func search(c conn, settings *model_helper.LdapSettings, baseDN string, scope int, filter string, attributes []string) ([]*goldap.Entry, *model_helper.AppError) {
+	request := goldap.NewSearchRequest(
+		baseDN,
+		scope,
+		goldap.NeverDerefAliases,
+		0,
+		*settings.QueryTimeout,
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+
+	var (
+		result *goldap.SearchResult
+		err    error
+	)
+	if *settings.MaxPageSize > 0 {
+		result, err = c.SearchWithPaging(request, uint32(*settings.MaxPageSize))
+	} else {
+		result, err = c.Search(request)
+	}
+	if err != nil {
+		return nil, model_helper.NewAppError("search", "ent.ldap.search.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return result.Entries, nil
+}
+
+// userFilter returns filter matching users having given value in one of given attributes, among users of UserFilter
+func userFilter(settings *model_helper.LdapSettings, value string, attributes ...string) string {
+	var conditions []string
+	for _, attribute := range attributes {
+		if attribute == "" {
+			continue
+		}
+		condition := "(" + attribute + "=" + escapeAttributeValue(attribute, value) + ")"
+		if !lo.Contains(conditions, condition) {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	filter := strings.Join(conditions, "")
+	if len(conditions) > 1 {
+		filter = "(|" + filter + ")"
+	}
+	return andFilter(filter, *settings.UserFilter)
+}
+
+// allUsersFilter returns filter matching every user of the directory
+func allUsersFilter(settings *model_helper.LdapSettings) string {
+	return andFilter("("+*settings.IdAttribute+"=*)", *settings.UserFilter)
+}
+
+// andFilter combines given filters, filters may be configured without their enclosing parentheses
+func andFilter(filters ...string) string {
+	var conditions []string
+	for _, filter := range filters {
+		filter = strings.TrimSpace(filter)
+		if filter == "" {
+			continue
+		}
+		if !strings.HasPrefix(filter, "(") {
+			filter = "(" + filter + ")"
+		}
+		conditions = append(conditions, filter)
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(&" + strings.Join(conditions, "") + ")"
+}
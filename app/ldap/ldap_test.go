@@ -0,0 +1,248 @@
+package ldap
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	goldap "github.com/mattermost/ldap"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/require"
+	ber "gopkg.in/asn1-ber.v1"
+)
+
+// directory is an in-process stand-in of an LDAP server. It supports binds and searches with
+// and, or, not, equality and presence filters.
+type directory struct {
+	entries   []*goldap.Entry
+	passwords map[string]string // keys are DNs
+}
+
+func (d *directory) Bind(username, password string) error {
+	if expected, ok := d.passwords[username]; !ok || expected != password {
+		return goldap.NewError(goldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (d *directory) Search(request *goldap.SearchRequest) (*goldap.SearchResult, error) {
+	filter, err := goldap.CompileFilter(request.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &goldap.SearchResult{}
+	for _, entry := range d.entries {
+		dn := strings.ToLower(entry.DN)
+		base := strings.ToLower(request.BaseDN)
+		inScope := dn == base
+		if request.Scope == goldap.ScopeWholeSubtree {
+			inScope = inScope || strings.HasSuffix(dn, ","+base)
+		}
+		if inScope && matches(entry, filter) {
+			result.Entries = append(result.Entries, entry)
+		}
+	}
+	return result, nil
+}
+
+func (d *directory) SearchWithPaging(request *goldap.SearchRequest, pagingSize uint32) (*goldap.SearchResult, error) {
+	return d.Search(request)
+}
+
+func (d *directory) Close() {}
+
+func matches(entry *goldap.Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(entry, child) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			if matches(entry, child) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return !matches(entry, filter.Children[0])
+	case goldap.FilterEqualityMatch:
+		value := filter.Children[1].Data.String()
+		for _, candidate := range entry.GetAttributeValues(filter.Children[0].Data.String()) {
+			if strings.EqualFold(candidate, value) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterPresent:
+		return len(entry.GetAttributeValues(filter.Data.String())) > 0
+	}
+	return false
+}
+
+func newTestLdap(t *testing.T) *Ldap {
+	t.Helper()
+
+	settings := &model_helper.LdapSettings{}
+	settings.SetDefaults()
+	settings.Enable = model_helper.GetPointerOfValue(true)
+	settings.BaseDN = model_helper.GetPointerOfValue("dc=example,dc=com")
+	settings.BindUsername = model_helper.GetPointerOfValue("cn=admin,dc=example,dc=com")
+	settings.BindPassword = model_helper.GetPointerOfValue("admin-password")
+	settings.UserFilter = model_helper.GetPointerOfValue("(objectClass=person)")
+	settings.IdAttribute = model_helper.GetPointerOfValue("employeeNumber")
+	settings.LoginIdAttribute = model_helper.GetPointerOfValue("uid")
+	settings.EmailAttribute = model_helper.GetPointerOfValue("mail")
+	settings.UsernameAttribute = model_helper.GetPointerOfValue("uid")
+	settings.FirstNameAttribute = model_helper.GetPointerOfValue("givenName")
+	settings.LastNameAttribute = model_helper.GetPointerOfValue("sn")
+	settings.GroupFilter = model_helper.GetPointerOfValue("(objectClass=groupOfNames)")
+	settings.GroupIdAttribute = model_helper.GetPointerOfValue("cn")
+	settings.GroupRoles = map[string]string{
+		"catalogue": model_helper.ShopStaffRoleId,
+		"managers":  model_helper.ShopStaffRoleId + " " + model_helper.ShopAdminRoleId,
+	}
+	settings.EnableAdminFilter = model_helper.GetPointerOfValue(true)
+	settings.AdminFilter = model_helper.GetPointerOfValue("(employeeType=admin)")
+
+	dir := &directory{
+		entries: []*goldap.Entry{
+			goldap.NewEntry("uid=jdoe,ou=people,dc=example,dc=com", map[string][]string{
+				"objectClass":    {"person"},
+				"uid":            {"jdoe"},
+				"employeeNumber": {"1001"},
+				"mail":           {"John.Doe@Example.com"},
+				"givenName":      {"John"},
+				"sn":             {"Doe"},
+				"employeeType":   {"admin"},
+			}),
+			goldap.NewEntry("uid=asmith,ou=people,dc=example,dc=com", map[string][]string{
+				"objectClass":    {"person"},
+				"uid":            {"asmith"},
+				"employeeNumber": {"1002"},
+				"mail":           {"asmith@example.com"},
+			}),
+			goldap.NewEntry("cn=catalogue,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"catalogue"},
+				"member":      {"uid=jdoe,ou=people,dc=example,dc=com", "uid=asmith,ou=people,dc=example,dc=com"},
+			}),
+			goldap.NewEntry("cn=managers,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"managers"},
+				"member":      {"uid=jdoe,ou=people,dc=example,dc=com"},
+			}),
+		},
+		passwords: map[string]string{
+			"cn=admin,dc=example,dc=com":             "admin-password",
+			"uid=jdoe,ou=people,dc=example,dc=com":   "jdoe-password",
+			"uid=asmith,ou=people,dc=example,dc=com": "asmith-password",
+		},
+	}
+
+	return &Ldap{
+		settings: func() *model_helper.LdapSettings { return settings },
+		dial: func(*model_helper.LdapSettings) (conn, error) {
+			return dir, nil
+		},
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	l := newTestLdap(t)
+
+	t.Run("by login id", func(t *testing.T) {
+		user, appErr := l.GetUser("jdoe")
+		require.Nil(t, appErr)
+		require.Equal(t, model_helper.USER_AUTH_SERVICE_LDAP, user.AuthService)
+		require.Equal(t, "1001", *user.AuthData.String)
+		require.Equal(t, "john.doe@example.com", user.Email)
+		require.Equal(t, "jdoe", user.Username)
+		require.Equal(t, "John", user.FirstName)
+		require.Equal(t, "Doe", user.LastName)
+	})
+
+	t.Run("by auth data", func(t *testing.T) {
+		user, appErr := l.GetUser("1002")
+		require.Nil(t, appErr)
+		require.Equal(t, "asmith", user.Username)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, appErr := l.GetUser("nobody")
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("groups are not users", func(t *testing.T) {
+		users, appErr := l.GetAllLdapUsers()
+		require.Nil(t, appErr)
+		require.Len(t, users, 2)
+	})
+}
+
+func TestCheckPassword(t *testing.T) {
+	l := newTestLdap(t)
+
+	require.Nil(t, l.CheckPassword("jdoe", "jdoe-password"))
+	require.Nil(t, l.CheckPasswordAuthData("1001", "jdoe-password"))
+
+	appErr := l.CheckPassword("jdoe", "asmith-password")
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+
+	appErr = l.CheckPassword("jdoe", "")
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+}
+
+func TestRolesForEntry(t *testing.T) {
+	l := newTestLdap(t)
+	settings := l.settings()
+
+	c, appErr := l.connect(settings)
+	require.Nil(t, appErr)
+
+	roles := func(loginID string) []string {
+		entry, appErr := findLoginEntry(c, settings, loginID, userAttributes(settings))
+		require.Nil(t, appErr)
+		roles, appErr := rolesForEntry(c, settings, entry)
+		require.Nil(t, appErr)
+		return roles
+	}
+
+	require.ElementsMatch(t, []string{
+		model_helper.SystemUserRoleId,
+		model_helper.SystemAdminRoleId,
+		model_helper.ShopStaffRoleId,
+		model_helper.ShopAdminRoleId,
+	}, roles("jdoe"))
+	require.ElementsMatch(t, []string{
+		model_helper.SystemUserRoleId,
+		model_helper.ShopStaffRoleId,
+	}, roles("asmith"))
+}
+
+func TestMergeRoles(t *testing.T) {
+	managed := []string{model_helper.SystemAdminRoleId, model_helper.ShopStaffRoleId, model_helper.ShopAdminRoleId}
+
+	// roles granted outside of the directory are kept
+	require.Equal(t, "system_user custom shop_staff", mergeRoles("system_user custom system_admin", []string{model_helper.SystemUserRoleId, model_helper.ShopStaffRoleId}, managed))
+	require.Equal(t, "system_user", mergeRoles("system_user shop_admin", []string{model_helper.SystemUserRoleId}, managed))
+}
+
+func TestObjectGUID(t *testing.T) {
+	l := newTestLdap(t)
+	l.settings().IdAttribute = model_helper.GetPointerOfValue(objectGUIDAttribute)
+
+	samlID := "1b2c3d4e-5f60-7182-93a4-b5c6d7e8f901"
+	ldapID := l.GetADLdapIdFromSAMLId(samlID)
+	require.Equal(t, "4e3d2c1b605f8271"+"93a4b5c6d7e8f901", ldapID)
+	require.Equal(t, samlID, l.GetSAMLIdFromADLdapId(ldapID))
+	require.Equal(t, `\4e\3d`, escapeAttributeValue(objectGUIDAttribute, "4e3d"))
+}
@@ -0,0 +1,155 @@
+package ldap
+
+import (
+	"net/http"
+	"time"
+
+	goldap "github.com/mattermost/ldap"
+	"github.com/sitename/sitename/app"
+	ejobs "github.com/sitename/sitename/einterfaces/jobs"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+const syncJobName = "LdapSync"
+
+// SyncJob builds the worker and scheduler of jobs synchronizing users with the directory
+type SyncJob struct {
+	ldap *Ldap
+	srv  *app.Server
+}
+
+var _ ejobs.LdapSyncInterface = (*SyncJob)(nil)
+
+func isSyncEnabled(cfg *model_helper.Config) bool {
+	return *cfg.LdapSettings.Enable && *cfg.LdapSettings.EnableSync
+}
+
+func (j *SyncJob) MakeWorker() model_helper.Worker {
+	execute := func(job model.Job) error {
+		if appErr := j.ldap.Synchronize(); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+	return jobs.NewSimpleWorker(syncJobName, j.srv.Jobs, execute, isSyncEnabled)
+}
+
+func (j *SyncJob) MakeScheduler() model_helper.Scheduler {
+	return &syncScheduler{jobs: j.srv.Jobs}
+}
+
+// syncScheduler schedules synchronizations every LdapSettings.SyncIntervalMinutes
+type syncScheduler struct {
+	jobs *jobs.JobServer
+}
+
+func (s *syncScheduler) Enabled(cfg *model_helper.Config) bool {
+	return isSyncEnabled(cfg)
+}
+
+func (s *syncScheduler) NextScheduleTime(cfg *model_helper.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	nextTime := now.Add(time.Duration(*cfg.LdapSettings.SyncIntervalMinutes) * time.Minute)
+	return &nextTime
+}
+
+func (s *syncScheduler) ScheduleJob(cfg *model_helper.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model_helper.AppError) {
+	return s.jobs.CreateJob(model.JobTypeLdapSync, nil)
+}
+
+// Synchronize updates saved directory users with attributes and roles of their entries. Users removed from the
+// directory, or not matching UserFilter anymore, are deactivated and users coming back are reactivated.
+//
+// Nobody is deactivated when the search finds no users, or when it would deactivate more than
+// SyncMaxDeactivationRatio of the active directory users, since a misconfigured or cut off search looks the same.
+func (l *Ldap) Synchronize() *model_helper.AppError {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return appErr
+	}
+	defer c.Close()
+
+	entries, appErr := search(c, settings, *settings.BaseDN, goldap.ScopeWholeSubtree, allUsersFilter(settings), userAttributes(settings))
+	if appErr != nil {
+		return appErr
+	}
+	entriesByID := make(map[string]*goldap.Entry, len(entries))
+	for _, entry := range entries {
+		if id := entryID(settings, entry); id != "" {
+			entriesByID[id] = entry
+		}
+	}
+
+	users, appErr := l.accounts.ldapUsers()
+	if appErr != nil {
+		return appErr
+	}
+
+	var (
+		removed []*model.User
+		active  int
+	)
+	for _, user := range users {
+		if user.DeleteAt > 0 {
+			continue
+		}
+		active++
+		if user.AuthData.IsNil() || entriesByID[*user.AuthData.String] == nil {
+			removed = append(removed, user)
+		}
+	}
+
+	var deactivationErr *model_helper.AppError
+	switch {
+	case len(removed) == 0:
+	case len(entriesByID) == 0:
+		deactivationErr = model_helper.NewAppError("Synchronize", "ent.ldap.synchronize.no_users_found.app_error", nil, "", http.StatusInternalServerError)
+	case float64(len(removed)) > *settings.SyncMaxDeactivationRatio*float64(active):
+		deactivationErr = model_helper.NewAppError("Synchronize", "ent.ldap.synchronize.deactivation_limit.app_error", map[string]any{"Count": len(removed), "Active": active}, "", http.StatusInternalServerError)
+	}
+
+	var deactivated, updated int
+	for _, user := range users {
+		var entry *goldap.Entry
+		if !user.AuthData.IsNil() {
+			entry = entriesByID[*user.AuthData.String]
+		}
+
+		if entry == nil {
+			if user.DeleteAt > 0 || deactivationErr != nil {
+				continue
+			}
+			if _, appErr := l.accounts.updateActive(*user, false); appErr != nil {
+				slog.Error("Failed to deactivate user removed from the directory", slog.String("user_id", user.ID), slog.Err(appErr))
+				continue
+			}
+			deactivated++
+			continue
+		}
+
+		if user.DeleteAt > 0 {
+			reactivated, appErr := l.accounts.updateActive(*user, true)
+			if appErr != nil {
+				slog.Error("Failed to reactivate user back in the directory", slog.String("user_id", user.ID), slog.Err(appErr))
+				continue
+			}
+			user = reactivated
+		}
+
+		if _, appErr := l.updateUser(c, settings, entry, user); appErr != nil {
+			slog.Error("Failed to synchronize user with the directory", slog.String("user_id", user.ID), slog.Err(appErr))
+			continue
+		}
+		updated++
+	}
+
+	slog.Info("Synchronized users with the directory", slog.Int("synchronized", updated), slog.Int("deactivated", deactivated))
+	if deactivationErr != nil {
+		slog.Error("Skipped deactivation of users missing from the directory", slog.Int("missing", len(removed)), slog.Err(deactivationErr))
+		return deactivationErr
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package ldap
+
+import (
+	"net/http"
+	"testing"
+
+	goldap "github.com/mattermost/ldap"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAccounts is an in-memory stand-in of the account service
+type memoryAccounts struct {
+	users map[string]*model.User
+}
+
+func (a *memoryAccounts) userByAuthData(authData string) (*model.User, *model_helper.AppError) {
+	for _, user := range a.users {
+		if !user.AuthData.IsNil() && *user.AuthData.String == authData {
+			saved := *user
+			return &saved, nil
+		}
+	}
+	return nil, model_helper.NewAppError("userByAuthData", "app.user.missing_account.const", nil, "", http.StatusNotFound)
+}
+
+func (a *memoryAccounts) ldapUsers() (model.UserSlice, *model_helper.AppError) {
+	var users model.UserSlice
+	for _, user := range a.users {
+		saved := *user
+		users = append(users, &saved)
+	}
+	return users, nil
+}
+
+func (a *memoryAccounts) createUser(user model.User) (*model.User, *model_helper.AppError) {
+	user.ID = model_helper.NewId()
+	return a.save(user)
+}
+
+func (a *memoryAccounts) updateUser(user model.User) (*model.User, *model_helper.AppError) {
+	return a.save(user)
+}
+
+func (a *memoryAccounts) updateUserRoles(user model.User, roles string) (*model.User, *model_helper.AppError) {
+	user.Roles = roles
+	return a.save(user)
+}
+
+func (a *memoryAccounts) updateActive(user model.User, active bool) (*model.User, *model_helper.AppError) {
+	user.DeleteAt = 0
+	if !active {
+		user.DeleteAt = model_helper.GetMillis()
+	}
+	return a.save(user)
+}
+
+func (a *memoryAccounts) save(user model.User) (*model.User, *model_helper.AppError) {
+	a.users[user.ID] = &user
+	saved := user
+	return &saved, nil
+}
+
+func (a *memoryAccounts) byUsername(t *testing.T, username string) *model.User {
+	t.Helper()
+	for _, user := range a.users {
+		if user.Username == username {
+			return user
+		}
+	}
+	require.Failf(t, "user not saved", "username %s", username)
+	return nil
+}
+
+func newTestSync(t *testing.T) (*Ldap, *directory, *memoryAccounts) {
+	t.Helper()
+
+	l := newTestLdap(t)
+	accounts := &memoryAccounts{users: map[string]*model.User{}}
+	l.accounts = accounts
+	c, err := l.dial(l.settings())
+	require.NoError(t, err)
+
+	// users are created on their first login
+	for id, password := range map[string]string{"jdoe": "jdoe-password", "asmith": "asmith-password"} {
+		_, appErr := l.DoLogin(id, password)
+		require.Nil(t, appErr)
+	}
+	require.Len(t, accounts.users, 2)
+
+	return l, c.(*directory), accounts
+}
+
+func removeEntry(dir *directory, dn string) *goldap.Entry {
+	for i, entry := range dir.entries {
+		if entry.DN == dn {
+			dir.entries = append(dir.entries[:i], dir.entries[i+1:]...)
+			return entry
+		}
+	}
+	return nil
+}
+
+func TestSynchronize(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		_, _, accounts := newTestSync(t)
+
+		jdoe := accounts.byUsername(t, "jdoe")
+		require.Equal(t, model_helper.USER_AUTH_SERVICE_LDAP, jdoe.AuthService)
+		require.Equal(t, "1001", *jdoe.AuthData.String)
+		require.Contains(t, jdoe.Roles, model_helper.ShopAdminRoleId)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		l, dir, accounts := newTestSync(t)
+
+		entry := removeEntry(dir, "uid=asmith,ou=people,dc=example,dc=com")
+		dir.entries = append(dir.entries, goldap.NewEntry(entry.DN, map[string][]string{
+			"objectClass":    {"person"},
+			"uid":            {"asmith"},
+			"employeeNumber": {"1002"},
+			"mail":           {"alice.smith@example.com"},
+			"givenName":      {"Alice"},
+		}))
+
+		require.Nil(t, l.Synchronize())
+		asmith := accounts.byUsername(t, "asmith")
+		require.Equal(t, "alice.smith@example.com", asmith.Email)
+		require.Equal(t, "Alice", asmith.FirstName)
+		require.Zero(t, asmith.DeleteAt)
+	})
+
+	t.Run("deactivate and reactivate", func(t *testing.T) {
+		l, dir, accounts := newTestSync(t)
+
+		entry := removeEntry(dir, "uid=asmith,ou=people,dc=example,dc=com")
+		require.Nil(t, l.Synchronize())
+		require.NotZero(t, accounts.byUsername(t, "asmith").DeleteAt)
+		require.Zero(t, accounts.byUsername(t, "jdoe").DeleteAt)
+
+		dir.entries = append(dir.entries, entry)
+		require.Nil(t, l.Synchronize())
+		require.Zero(t, accounts.byUsername(t, "asmith").DeleteAt)
+	})
+
+	t.Run("empty search result deactivates nobody", func(t *testing.T) {
+		l, _, accounts := newTestSync(t)
+
+		l.settings().UserFilter = model_helper.GetPointerOfValue("(objectClass=nobody)")
+		appErr := l.Synchronize()
+		require.NotNil(t, appErr)
+		require.Equal(t, "ent.ldap.synchronize.no_users_found.app_error", appErr.Id)
+		for _, user := range accounts.users {
+			require.Zero(t, user.DeleteAt)
+		}
+	})
+
+	t.Run("deactivations above ratio are skipped", func(t *testing.T) {
+		l, dir, accounts := newTestSync(t)
+
+		l.settings().SyncMaxDeactivationRatio = model_helper.GetPointerOfValue(0.4)
+		removeEntry(dir, "uid=asmith,ou=people,dc=example,dc=com")
+		for _, attribute := range dir.entries[0].Attributes {
+			if attribute.Name == "sn" {
+				attribute.Values = []string{"Doe-Smith"}
+			}
+		}
+
+		appErr := l.Synchronize()
+		require.NotNil(t, appErr)
+		require.Equal(t, "ent.ldap.synchronize.deactivation_limit.app_error", appErr.Id)
+		require.Zero(t, accounts.byUsername(t, "asmith").DeleteAt)
+		// remaining users are still synchronized
+		require.Equal(t, "Doe-Smith", accounts.byUsername(t, "jdoe").LastName)
+	})
+}
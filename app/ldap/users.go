@@ -0,0 +1,615 @@
+package ldap
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	goldap "github.com/mattermost/ldap"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+// objectGUIDAttribute is the binary id attribute of Active Directory, its values are kept hex encoded in users' auth data
+const objectGUIDAttribute = "objectGUID"
+
+// waitForJobInterval is how often StartSynchronizeJob checks whether the job it waits for is finished
+const waitForJobInterval = time.Second
+
+func isObjectGUID(attribute string) bool {
+	return strings.EqualFold(attribute, objectGUIDAttribute)
+}
+
+// escapeAttributeValue escapes given value to be matched against given attribute in filters
+func escapeAttributeValue(attribute, value string) string {
+	if isObjectGUID(attribute) {
+		if raw, err := hex.DecodeString(value); err == nil {
+			var escaped strings.Builder
+			for _, b := range raw {
+				fmt.Fprintf(&escaped, "\\%02x", b)
+			}
+			return escaped.String()
+		}
+	}
+	return goldap.EscapeFilter(value)
+}
+
+// entryID returns value of the id attribute of given entry, which is saved as auth data of the user
+func entryID(settings *model_helper.LdapSettings, entry *goldap.Entry) string {
+	if isObjectGUID(*settings.IdAttribute) {
+		return hex.EncodeToString(entry.GetRawAttributeValue(*settings.IdAttribute))
+	}
+	return entry.GetAttributeValue(*settings.IdAttribute)
+}
+
+// userAttributes returns names of attributes mapped to users
+func userAttributes(settings *model_helper.LdapSettings) []string {
+	attributes := []string{
+		*settings.IdAttribute,
+		*settings.LoginIdAttribute,
+		*settings.EmailAttribute,
+		*settings.UsernameAttribute,
+		*settings.FirstNameAttribute,
+		*settings.LastNameAttribute,
+		*settings.NicknameAttribute,
+	}
+	return lo.Uniq(lo.Compact(attributes))
+}
+
+// userFromEntry maps attributes of given entry to a user. The user is not saved.
+func userFromEntry(settings *model_helper.LdapSettings, entry *goldap.Entry) *model.User {
+	attribute := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return strings.TrimSpace(entry.GetAttributeValue(name))
+	}
+
+	user := &model.User{
+		AuthService:   model_helper.USER_AUTH_SERVICE_LDAP,
+		AuthData:      model_types.NewNullString(entryID(settings, entry)),
+		Email:         strings.ToLower(attribute(*settings.EmailAttribute)),
+		FirstName:     attribute(*settings.FirstNameAttribute),
+		LastName:      attribute(*settings.LastNameAttribute),
+		Nickname:      attribute(*settings.NicknameAttribute),
+		EmailVerified: true,
+	}
+	if username := attribute(*settings.UsernameAttribute); username != "" {
+		user.Username = model_helper.CleanUsername(username)
+	}
+	return user
+}
+
+// findEntry finds the user entry having given value in one of given attributes
+func findEntry(c conn, settings *model_helper.LdapSettings, value string, attributes []string, idAttributes ...string) (*goldap.Entry, *model_helper.AppError) {
+	entries, appErr := search(c, settings, *settings.BaseDN, goldap.ScopeWholeSubtree, userFilter(settings, value, idAttributes...), attributes)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	switch len(entries) {
+	case 0:
+		return nil, model_helper.NewAppError("findEntry", "ent.ldap.user_not_found.app_error", nil, "value="+value, http.StatusNotFound)
+	case 1:
+		return entries[0], nil
+	default:
+		return nil, model_helper.NewAppError("findEntry", "ent.ldap.multiple_users.app_error", map[string]any{"Value": value}, "", http.StatusConflict)
+	}
+}
+
+// findLoginEntry finds the user entry of given id, ids are either login ids users enter or ids saved as their auth data
+func findLoginEntry(c conn, settings *model_helper.LdapSettings, id string, attributes []string) (*goldap.Entry, *model_helper.AppError) {
+	return findEntry(c, settings, id, attributes, *settings.LoginIdAttribute, *settings.IdAttribute)
+}
+
+// entryMatches checks whether given entry matches given filter
+func entryMatches(c conn, settings *model_helper.LdapSettings, entry *goldap.Entry, filter string) (bool, *model_helper.AppError) {
+	entries, appErr := search(c, settings, entry.DN, goldap.ScopeBaseObject, andFilter(filter), []string{"dn"})
+	if appErr != nil {
+		return false, appErr
+	}
+	return len(entries) > 0, nil
+}
+
+// groupsOfEntry returns ids of groups given entry is a member of
+func groupsOfEntry(c conn, settings *model_helper.LdapSettings, entry *goldap.Entry) ([]string, *model_helper.AppError) {
+	filter := andFilter(*settings.GroupFilter, "("+*settings.GroupMemberAttribute+"="+goldap.EscapeFilter(entry.DN)+")")
+	groups, appErr := search(c, settings, *settings.BaseDN, goldap.ScopeWholeSubtree, filter, []string{*settings.GroupIdAttribute})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	groupIDs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if id := group.GetAttributeValue(*settings.GroupIdAttribute); id != "" {
+			groupIDs = append(groupIDs, id)
+		}
+	}
+	return groupIDs, nil
+}
+
+// rolesForEntry returns roles the directory grants to given entry. Guests matching GuestFilter get the guest role
+// instead of the user one, admins matching AdminFilter get the system admin role and members of groups in GroupRoles
+// get roles mapped to their groups.
+func rolesForEntry(c conn, settings *model_helper.LdapSettings, entry *goldap.Entry) ([]string, *model_helper.AppError) {
+	roles := []string{model_helper.SystemUserRoleId}
+
+	if *settings.GuestFilter != "" {
+		isGuest, appErr := entryMatches(c, settings, entry, *settings.GuestFilter)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if isGuest {
+			roles = []string{model_helper.SystemGuestRoleId}
+		}
+	}
+
+	if *settings.EnableAdminFilter && *settings.AdminFilter != "" {
+		isAdmin, appErr := entryMatches(c, settings, entry, *settings.AdminFilter)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if isAdmin {
+			roles = append(roles, model_helper.SystemAdminRoleId)
+		}
+	}
+
+	if len(settings.GroupRoles) > 0 {
+		groupIDs, appErr := groupsOfEntry(c, settings, entry)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, groupID := range groupIDs {
+			roles = append(roles, strings.Fields(settings.GroupRoles[groupID])...)
+		}
+	}
+
+	return lo.Uniq(roles), nil
+}
+
+// managedRoles returns roles granted by the directory. Users not granted them anymore lose them on their next
+// login or synchronization, other roles of users are left untouched.
+func managedRoles(settings *model_helper.LdapSettings) []string {
+	var roles []string
+	if *settings.GuestFilter != "" {
+		roles = append(roles, model_helper.SystemUserRoleId, model_helper.SystemGuestRoleId)
+	}
+	if *settings.EnableAdminFilter && *settings.AdminFilter != "" {
+		roles = append(roles, model_helper.SystemAdminRoleId)
+	}
+	for _, groupRoles := range settings.GroupRoles {
+		roles = append(roles, strings.Fields(groupRoles)...)
+	}
+	return roles
+}
+
+// mergeRoles replaces managed roles of given space separated roles with granted ones
+func mergeRoles(roles string, granted, managed []string) string {
+	merged := lo.Filter(strings.Fields(roles), func(role string, _ int) bool {
+		return !lo.Contains(managed, role)
+	})
+	return strings.Join(lo.Uniq(append(merged, granted...)), " ")
+}
+
+// saveUser creates the user of given entry on its first login, otherwise updates it with attributes and roles of the entry
+func (l *Ldap) saveUser(c conn, settings *model_helper.LdapSettings, entry *goldap.Entry) (*model.User, *model_helper.AppError) {
+	ldapUser := userFromEntry(settings, entry)
+	if ldapUser.AuthData.IsNil() || *ldapUser.AuthData.String == "" {
+		return nil, model_helper.NewAppError("saveUser", "ent.ldap.missing_attribute.app_error", map[string]any{"Attribute": *settings.IdAttribute}, "dn="+entry.DN, http.StatusBadRequest)
+	}
+
+	user, appErr := l.accounts.userByAuthData(*ldapUser.AuthData.String)
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	if user == nil {
+		if ldapUser.Email == "" {
+			return nil, model_helper.NewAppError("saveUser", "ent.ldap.missing_attribute.app_error", map[string]any{"Attribute": *settings.EmailAttribute}, "dn="+entry.DN, http.StatusBadRequest)
+		}
+		if ldapUser.Username == "" {
+			ldapUser.Username = model_helper.CleanUsername(strings.Split(ldapUser.Email, "@")[0])
+		}
+
+		user, appErr = l.accounts.createUser(*ldapUser)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return l.updateUser(c, settings, entry, user)
+}
+
+// updateUser updates given user with attributes and roles of given entry
+func (l *Ldap) updateUser(c conn, settings *model_helper.LdapSettings, entry *goldap.Entry, user *model.User) (*model.User, *model_helper.AppError) {
+	ldapUser := userFromEntry(settings, entry)
+
+	changed := false
+	for _, field := range []struct{ current, ldap *string }{
+		{&user.Email, &ldapUser.Email},
+		{&user.FirstName, &ldapUser.FirstName},
+		{&user.LastName, &ldapUser.LastName},
+		{&user.Nickname, &ldapUser.Nickname},
+	} {
+		if *field.ldap != "" && *field.current != *field.ldap {
+			*field.current = *field.ldap
+			changed = true
+		}
+	}
+
+	var appErr *model_helper.AppError
+	if changed {
+		user, appErr = l.accounts.updateUser(*user)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	granted, appErr := rolesForEntry(c, settings, entry)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if roles := mergeRoles(user.Roles, granted, managedRoles(settings)); roles != user.Roles {
+		user, appErr = l.accounts.updateUserRoles(*user, roles)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return user, nil
+}
+
+// DoLogin checks given password of the directory user of given id, then returns the user saved with its latest
+// attributes and roles. Users are created on their first login.
+func (l *Ldap) DoLogin(id string, password string) (*model.User, *model_helper.AppError) {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer c.Close()
+
+	entry, appErr := findLoginEntry(c, settings, id, userAttributes(settings))
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := l.checkEntryPassword(settings, entry, password); appErr != nil {
+		return nil, appErr
+	}
+
+	return l.saveUser(c, settings, entry)
+}
+
+// GetUser returns the directory user of given id. The user is not saved.
+func (l *Ldap) GetUser(id string) (*model.User, *model_helper.AppError) {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer c.Close()
+
+	entry, appErr := findLoginEntry(c, settings, id, userAttributes(settings))
+	if appErr != nil {
+		return nil, appErr
+	}
+	return userFromEntry(settings, entry), nil
+}
+
+// GetUserAttributes returns values of given attributes of the directory user of given id
+func (l *Ldap) GetUserAttributes(id string, attributes []string) (map[string]string, *model_helper.AppError) {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer c.Close()
+
+	entry, appErr := findLoginEntry(c, settings, id, attributes)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	values := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		values[attribute] = entry.GetAttributeValue(attribute)
+	}
+	return values, nil
+}
+
+// CheckPassword checks given password of the directory user having given login id
+func (l *Ldap) CheckPassword(id string, password string) *model_helper.AppError {
+	return l.checkPassword(id, password, *l.settings().LoginIdAttribute)
+}
+
+// CheckPasswordAuthData checks given password of the directory user saved with given auth data
+func (l *Ldap) CheckPasswordAuthData(authData string, password string) *model_helper.AppError {
+	return l.checkPassword(authData, password, *l.settings().IdAttribute)
+}
+
+func (l *Ldap) checkPassword(id, password, attribute string) *model_helper.AppError {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return appErr
+	}
+	defer c.Close()
+
+	entry, appErr := findEntry(c, settings, id, []string{"dn"}, attribute)
+	if appErr != nil {
+		return appErr
+	}
+	return l.checkEntryPassword(settings, entry, password)
+}
+
+// CheckProviderAttributes returns name of the first field of given patch which is managed by the directory,
+// such fields would be overwritten on the next synchronization. An empty string is returned when there is none.
+func (l *Ldap) CheckProviderAttributes(LS model_helper.LdapSettings, user model.User, patch model_helper.UserPatch) string {
+	for _, field := range []struct {
+		name      string
+		attribute *string
+		current   string
+		patched   *string
+	}{
+		{"email", LS.EmailAttribute, user.Email, patch.Email},
+		{"username", LS.UsernameAttribute, user.Username, patch.Username},
+		{"first_name", LS.FirstNameAttribute, user.FirstName, patch.FirstName},
+		{"last_name", LS.LastNameAttribute, user.LastName, patch.LastName},
+		{"nickname", LS.NicknameAttribute, user.Nickname, patch.Nickname},
+	} {
+		if field.patched != nil && *field.patched != field.current && field.attribute != nil && *field.attribute != "" {
+			return field.name
+		}
+	}
+	return ""
+}
+
+// SwitchToLdap switches the user of given id from email and password to the directory user of given login id
+func (l *Ldap) SwitchToLdap(userID, ldapID, ldapPassword string) *model_helper.AppError {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return appErr
+	}
+	defer c.Close()
+
+	entry, appErr := findEntry(c, settings, ldapID, userAttributes(settings), *settings.LoginIdAttribute)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := l.checkEntryPassword(settings, entry, ldapPassword); appErr != nil {
+		return appErr
+	}
+
+	authData := entryID(settings, entry)
+	if authData == "" {
+		return model_helper.NewAppError("SwitchToLdap", "ent.ldap.missing_attribute.app_error", map[string]any{"Attribute": *settings.IdAttribute}, "dn="+entry.DN, http.StatusBadRequest)
+	}
+
+	if _, appErr := l.srv.Account.UpdateUserAuth(userID, &model_helper.UserAuth{
+		AuthData:    &authData,
+		AuthService: model_helper.USER_AUTH_SERVICE_LDAP,
+	}); appErr != nil {
+		return appErr
+	}
+	l.srv.Account.InvalidateCacheForUser(userID)
+
+	return nil
+}
+
+// StartSynchronizeJob creates a job synchronizing users with the directory. When waitForJobToFinish is true,
+// the job is returned once it is finished.
+func (l *Ldap) StartSynchronizeJob(waitForJobToFinish bool) (*model.Job, *model_helper.AppError) {
+	job, appErr := l.srv.Jobs.CreateJob(model.JobTypeLdapSync, nil)
+	if appErr != nil || !waitForJobToFinish {
+		return job, appErr
+	}
+
+	ticker := time.NewTicker(waitForJobInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		job, appErr = l.srv.Jobs.GetJob(job.ID)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		switch job.Status {
+		case model.JobStatusSuccess, model.JobStatusWarning:
+			return job, nil
+		case model.JobStatusError, model.JobStatusCanceled:
+			return job, model_helper.NewAppError("StartSynchronizeJob", "ent.ldap.sync_job.app_error", nil, "job_id="+job.ID, http.StatusInternalServerError)
+		}
+	}
+	return job, nil
+}
+
+// RunTest checks the directory can be reached with configured settings
+func (l *Ldap) RunTest() *model_helper.AppError {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return appErr
+	}
+	defer c.Close()
+
+	_, appErr = search(c, settings, *settings.BaseDN, goldap.ScopeBaseObject, "(objectClass=*)", []string{"dn"})
+	return appErr
+}
+
+// GetAllLdapUsers returns every user of the directory. Users are not saved.
+func (l *Ldap) GetAllLdapUsers() ([]*model.User, *model_helper.AppError) {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer c.Close()
+
+	entries, appErr := search(c, settings, *settings.BaseDN, goldap.ScopeWholeSubtree, allUsersFilter(settings), userAttributes(settings))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return lo.Map(entries, func(entry *goldap.Entry, _ int) *model.User {
+		return userFromEntry(settings, entry)
+	}), nil
+}
+
+// MigrateIDAttribute rewrites auth data of directory users to values of given attribute, it must be called
+// before IdAttribute is changed to given attribute
+func (l *Ldap) MigrateIDAttribute(toAttribute string) error {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return appErr
+	}
+	defer c.Close()
+
+	entries, appErr := search(c, settings, *settings.BaseDN, goldap.ScopeWholeSubtree, allUsersFilter(settings), []string{*settings.IdAttribute, toAttribute})
+	if appErr != nil {
+		return appErr
+	}
+
+	newSettings := *settings
+	newSettings.IdAttribute = &toAttribute
+
+	newIDs := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		newIDs[entryID(settings, entry)] = entryID(&newSettings, entry)
+	}
+
+	users, appErr := l.accounts.ldapUsers()
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, user := range users {
+		if user.AuthData.IsNil() {
+			continue
+		}
+		newID, ok := newIDs[*user.AuthData.String]
+		if !ok || newID == "" {
+			slog.Warn("Directory user not found while migrating id attribute", slog.String("user_id", user.ID), slog.String("attribute", toAttribute))
+			continue
+		}
+		if _, err := l.srv.Store.User().UpdateAuthData(user.ID, model_helper.USER_AUTH_SERVICE_LDAP, &newID, "", false); err != nil {
+			return fmt.Errorf("failed to migrate auth data of user %s: %w", user.ID, err)
+		}
+		l.srv.Account.InvalidateCacheForUser(user.ID)
+	}
+
+	return nil
+}
+
+// FirstLoginSync updates a user signed in by another service with attributes and roles of its directory entry,
+// found by given email
+func (l *Ldap) FirstLoginSync(user *model.User, userAuthService, userAuthData, email string) *model_helper.AppError {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return appErr
+	}
+	defer c.Close()
+
+	entry, appErr := findEntry(c, settings, email, userAttributes(settings), *settings.EmailAttribute)
+	if appErr != nil {
+		return appErr
+	}
+
+	updated, appErr := l.updateUser(c, settings, entry, user)
+	if appErr != nil {
+		return appErr
+	}
+	*user = *updated
+
+	return nil
+}
+
+// UpdateProfilePictureIfNecessary sets profile image of given directory user to the picture of its entry
+func (l *Ldap) UpdateProfilePictureIfNecessary(user model.User, session model.Session) {
+	settings := l.settings()
+	if *settings.PictureAttribute == "" || !model_helper.UserIsLDAP(user) || user.AuthData.IsNil() {
+		return
+	}
+
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		slog.Warn("Failed to connect to the directory to update profile picture", slog.String("user_id", user.ID), slog.Err(appErr))
+		return
+	}
+	defer c.Close()
+
+	entry, appErr := findEntry(c, settings, *user.AuthData.String, []string{*settings.PictureAttribute}, *settings.IdAttribute)
+	if appErr != nil {
+		slog.Warn("Failed to find directory user to update profile picture", slog.String("user_id", user.ID), slog.Err(appErr))
+		return
+	}
+
+	picture := entry.GetRawAttributeValue(*settings.PictureAttribute)
+	if len(picture) == 0 {
+		return
+	}
+	if appErr := l.srv.Account.SetProfileImageFromFile(user.ID, bytes.NewReader(picture)); appErr != nil {
+		slog.Warn("Failed to update profile picture from the directory", slog.String("user_id", user.ID), slog.Err(appErr))
+	}
+}
+
+// GetADLdapIdFromSAMLId converts an Active Directory objectGUID sent by SAML providers, formatted as a GUID,
+// to the hex encoded bytes saved as auth data of directory users
+func (l *Ldap) GetADLdapIdFromSAMLId(authData string) string {
+	if !isObjectGUID(*l.settings().IdAttribute) {
+		return authData
+	}
+
+	raw, err := hex.DecodeString(strings.ReplaceAll(strings.Trim(authData, "{}"), "-", ""))
+	if err != nil || len(raw) != 16 {
+		return authData
+	}
+	swapGUIDByteOrder(raw)
+	return hex.EncodeToString(raw)
+}
+
+// GetSAMLIdFromADLdapId is the reverse of GetADLdapIdFromSAMLId
+func (l *Ldap) GetSAMLIdFromADLdapId(authData string) string {
+	if !isObjectGUID(*l.settings().IdAttribute) {
+		return authData
+	}
+
+	raw, err := hex.DecodeString(authData)
+	if err != nil || len(raw) != 16 {
+		return authData
+	}
+	swapGUIDByteOrder(raw)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:])
+}
+
+// swapGUIDByteOrder swaps between the little endian first three groups of GUIDs stored by Active Directory and
+// the big endian ones of their string form
+func swapGUIDByteOrder(raw []byte) {
+	lo.Reverse(raw[0:4])
+	lo.Reverse(raw[4:6])
+	lo.Reverse(raw[6:8])
+}
+
+// GetVendorNameAndVendorVersion returns vendor of the directory server, read from its root DSE
+func (l *Ldap) GetVendorNameAndVendorVersion() (string, string) {
+	settings := l.settings()
+	c, appErr := l.connect(settings)
+	if appErr != nil {
+		return "", ""
+	}
+	defer c.Close()
+
+	entries, appErr := search(c, settings, "", goldap.ScopeBaseObject, "(objectClass=*)", []string{"vendorName", "vendorVersion"})
+	if appErr != nil || len(entries) == 0 {
+		return "", ""
+	}
+	return entries[0].GetAttributeValue("vendorName"), entries[0].GetAttributeValue("vendorVersion")
+}
@@ -0,0 +1,449 @@
+/*
+NOTE: This package is initialized during server startup (modules/imports does that)
+so the init() function get the chance to register a function to create the metrics
+*/
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/mattermost/logr/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/einterfaces"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+const (
+	namespace = "sitename"
+
+	subsystemHTTP      = "http"
+	subsystemGraphQL   = "graphql"
+	subsystemDB        = "db"
+	subsystemCache     = "cache"
+	subsystemCluster   = "cluster"
+	subsystemLogin     = "login"
+	subsystemWebsocket = "websocket"
+	subsystemPlugin    = "plugin"
+	subsystemJobs      = "jobs"
+	subsystemLogging   = "logging"
+	subsystemUsers     = "users"
+	subsystemOrders    = "orders"
+	subsystemCheckouts = "checkouts"
+	subsystemPayments  = "payments"
+	subsystemStocks    = "stocks"
+)
+
+// sessionCacheName labels session cache counters, the session cache has its own methods
+const sessionCacheName = "Session"
+
+// Metrics collects metrics of the server into a Prometheus registry, which is served
+// by the metrics listener (MetricsSettings.ListenAddress).
+//
+// Cache hit ratio of a cache is
+// rate(sitename_cache_hits_total[5m]) / (rate(sitename_cache_hits_total[5m]) + rate(sitename_cache_misses_total[5m])),
+// and checkout completion rate of a channel is
+// rate(sitename_checkouts_completed_total[1h]) / rate(sitename_checkouts_created_total[1h]).
+type Metrics struct {
+	srv          *app.Server
+	registry     *prometheus.Registry
+	registerOnce sync.Once
+	collectors   []prometheus.Collector
+
+	httpRequests          prometheus.Counter
+	httpErrors            prometheus.Counter
+	apiEndpointDuration   *prometheus.HistogramVec
+	graphQLOperationTime  *prometheus.HistogramVec
+	storeMethodDuration   *prometheus.HistogramVec
+	replicaLagAbsolute    *prometheus.GaugeVec
+	replicaLagTime        *prometheus.GaugeVec
+	cacheHits             *prometheus.CounterVec
+	cacheMisses           *prometheus.CounterVec
+	cacheInvalidations    *prometheus.CounterVec
+	etagHits              *prometheus.CounterVec
+	etagMisses            *prometheus.CounterVec
+	clusterRequests       prometheus.Counter
+	clusterRequestTime    prometheus.Histogram
+	clusterEvents         *prometheus.CounterVec
+	logins                prometheus.Counter
+	loginFails            prometheus.Counter
+	websocketEvents       *prometheus.CounterVec
+	websocketBroadcasts   *prometheus.CounterVec
+	websocketBufferSize   *prometheus.GaugeVec
+	websocketUsers        *prometheus.GaugeVec
+	pluginHookDuration    *prometheus.HistogramVec
+	pluginMultiHookIter   *prometheus.HistogramVec
+	pluginMultiHookTime   prometheus.Histogram
+	pluginApiDuration     *prometheus.HistogramVec
+	jobsActive            *prometheus.GaugeVec
+	enabledUsers          prometheus.Gauge
+	ordersPlaced          *prometheus.CounterVec
+	checkoutsCreated      *prometheus.CounterVec
+	checkoutsCompleted    *prometheus.CounterVec
+	paymentGatewayErrors  *prometheus.CounterVec
+	stockAllocationFailed *prometheus.CounterVec
+
+	loggerCollector *loggerCollector
+}
+
+func init() {
+	app.RegisterMetricsInterface(func(s *app.Server) einterfaces.MetricsInterface {
+		return New(s)
+	})
+}
+
+func New(s *app.Server) *Metrics {
+	m := &Metrics{
+		srv:      s,
+		registry: prometheus.NewRegistry(),
+	}
+
+	m.httpRequests = m.newCounter(subsystemHTTP, "requests_total", "The total number of http requests")
+	m.httpErrors = m.newCounter(subsystemHTTP, "errors_total", "The total number of http requests failed with an app error")
+	m.apiEndpointDuration = m.newHistogramVec(subsystemHTTP, "request_duration_seconds", "Duration of http requests by handler", "handler", "method", "status_code")
+	m.graphQLOperationTime = m.newHistogramVec(subsystemGraphQL, "operation_duration_seconds", "Duration of GraphQL operations by operation type", "operation_type", "success")
+
+	m.storeMethodDuration = m.newHistogramVec(subsystemDB, "store_method_duration_seconds", "Duration of store methods", "method", "success")
+	m.replicaLagAbsolute = m.newGaugeVec(subsystemDB, "replica_lag_abs", "An abstract unit for estimating replication lag of replicas", "node")
+	m.replicaLagTime = m.newGaugeVec(subsystemDB, "replica_lag_time", "Replication lag of replicas, in seconds", "node")
+	m.collectors = append(m.collectors,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystemDB,
+			Name:      "master_connections_total",
+			Help:      "The number of open connections to the master database",
+		}, func() float64 { return float64(s.ClusterStats().TotalMasterDbConnections) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystemDB,
+			Name:      "read_replica_connections_total",
+			Help:      "The number of open connections to read replica databases",
+		}, func() float64 { return float64(s.ClusterStats().TotalReadDbConnections) }),
+	)
+
+	m.cacheHits = m.newCounterVec(subsystemCache, "hits_total", "The total number of cache hits", "name")
+	m.cacheMisses = m.newCounterVec(subsystemCache, "misses_total", "The total number of cache misses", "name")
+	m.cacheInvalidations = m.newCounterVec(subsystemCache, "invalidations_total", "The total number of cache invalidations", "name")
+	m.etagHits = m.newCounterVec(subsystemCache, "etag_hits_total", "The total number of etag hits", "route")
+	m.etagMisses = m.newCounterVec(subsystemCache, "etag_misses_total", "The total number of etag misses", "route")
+
+	m.clusterRequests = m.newCounter(subsystemCluster, "requests_total", "The total number of requests to other cluster nodes")
+	m.clusterRequestTime = m.newHistogram(subsystemCluster, "request_duration_seconds", "Duration of requests to other cluster nodes")
+	m.clusterEvents = m.newCounterVec(subsystemCluster, "events_total", "The total number of cluster messages received by event", "event")
+
+	m.logins = m.newCounter(subsystemLogin, "logins_total", "The total number of successful logins")
+	m.loginFails = m.newCounter(subsystemLogin, "logins_fail_total", "The total number of failed logins")
+
+	m.websocketEvents = m.newCounterVec(subsystemWebsocket, "events_total", "The total number of websocket events", "type")
+	m.websocketBroadcasts = m.newCounterVec(subsystemWebsocket, "broadcasts_total", "The total number of websocket broadcasts", "type")
+	m.websocketBufferSize = m.newGaugeVec(subsystemWebsocket, "broadcast_buffer_size", "Number of websocket broadcasts waiting in hubs", "hub")
+	m.websocketUsers = m.newGaugeVec(subsystemWebsocket, "broadcast_users_registered", "Number of users registered to websocket hubs", "hub")
+
+	m.pluginHookDuration = m.newHistogramVec(subsystemPlugin, "hook_duration_seconds", "Duration of plugin hooks", "plugin_id", "hook_name", "success")
+	m.pluginMultiHookIter = m.newHistogramVec(subsystemPlugin, "multi_hook_iteration_duration_seconds", "Duration of each plugin's iteration of multi plugin hooks", "plugin_id")
+	m.pluginMultiHookTime = m.newHistogram(subsystemPlugin, "multi_hook_duration_seconds", "Duration of multi plugin hooks")
+	m.pluginApiDuration = m.newHistogramVec(subsystemPlugin, "api_duration_seconds", "Duration of plugin API calls", "plugin_id", "api_name", "success")
+
+	m.jobsActive = m.newGaugeVec(subsystemJobs, "active", "Number of active jobs by type", "type")
+	m.enabledUsers = m.newGauge(subsystemUsers, "enabled_total", "The number of enabled users")
+
+	m.ordersPlaced = m.newCounterVec(subsystemOrders, "placed_total", "The total number of placed orders", "channel_id")
+	m.checkoutsCreated = m.newCounterVec(subsystemCheckouts, "created_total", "The total number of created checkouts", "channel_id")
+	m.checkoutsCompleted = m.newCounterVec(subsystemCheckouts, "completed_total", "The total number of checkouts completed into orders", "channel_id")
+	m.paymentGatewayErrors = m.newCounterVec(subsystemPayments, "gateway_errors_total", "The total number of failed payment gateway calls", "gateway")
+	m.stockAllocationFailed = m.newCounterVec(subsystemStocks, "allocation_failures_total", "The total number of stock allocations failed for insufficient stock", "channel_slug")
+
+	m.loggerCollector = newLoggerCollector(m)
+	m.collectors = append(m.collectors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{Namespace: namespace}),
+	)
+
+	return m
+}
+
+var _ einterfaces.MetricsInterface = (*Metrics)(nil)
+
+// Register adds collectors to the registry. Collectors are registered once,
+// metrics are kept when the metrics server restarts on config changes.
+func (m *Metrics) Register() {
+	m.registerOnce.Do(func() {
+		for _, collector := range m.collectors {
+			if err := m.registry.Register(collector); err != nil {
+				slog.Error("Failed to register metrics collector", slog.Err(err))
+			}
+		}
+		slog.Info("Metrics are registered")
+	})
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog: m.srv.Log.StdLogger(slog.LvlError),
+	})
+}
+
+func (m *Metrics) IncrementHttpRequest() { m.httpRequests.Inc() }
+
+func (m *Metrics) IncrementHttpError() { m.httpErrors.Inc() }
+
+func (m *Metrics) IncrementClusterRequest() { m.clusterRequests.Inc() }
+
+func (m *Metrics) ObserveClusterRequestDuration(elapsed float64) {
+	m.clusterRequestTime.Observe(elapsed)
+}
+
+func (m *Metrics) IncrementClusterEventType(eventType string) {
+	m.clusterEvents.WithLabelValues(eventType).Inc()
+}
+
+func (m *Metrics) IncrementLogin() { m.logins.Inc() }
+
+func (m *Metrics) IncrementLoginFail() { m.loginFails.Inc() }
+
+func (m *Metrics) IncrementEtagHitCounter(route string) {
+	m.etagHits.WithLabelValues(route).Inc()
+}
+
+func (m *Metrics) IncrementEtagMissCounter(route string) {
+	m.etagMisses.WithLabelValues(route).Inc()
+}
+
+func (m *Metrics) IncrementMemCacheHitCounter(cacheName string) {
+	m.cacheHits.WithLabelValues(cacheName).Inc()
+}
+
+func (m *Metrics) IncrementMemCacheMissCounter(cacheName string) {
+	m.cacheMisses.WithLabelValues(cacheName).Inc()
+}
+
+func (m *Metrics) IncrementMemCacheInvalidationCounter(cacheName string) {
+	m.cacheInvalidations.WithLabelValues(cacheName).Inc()
+}
+
+func (m *Metrics) IncrementMemCacheMissCounterSession() {
+	m.IncrementMemCacheMissCounter(sessionCacheName)
+}
+
+func (m *Metrics) IncrementMemCacheHitCounterSession() {
+	m.IncrementMemCacheHitCounter(sessionCacheName)
+}
+
+func (m *Metrics) IncrementMemCacheInvalidationCounterSession() {
+	m.IncrementMemCacheInvalidationCounter(sessionCacheName)
+}
+
+func (m *Metrics) AddMemCacheHitCounter(cacheName string, amount float64) {
+	m.cacheHits.WithLabelValues(cacheName).Add(amount)
+}
+
+func (m *Metrics) AddMemCacheMissCounter(cacheName string, amount float64) {
+	m.cacheMisses.WithLabelValues(cacheName).Add(amount)
+}
+
+func (m *Metrics) IncrementWebsocketEvent(eventType string) {
+	m.websocketEvents.WithLabelValues(eventType).Inc()
+}
+
+func (m *Metrics) IncrementWebSocketBroadcast(eventType string) {
+	m.websocketBroadcasts.WithLabelValues(eventType).Inc()
+}
+
+func (m *Metrics) IncrementWebSocketBroadcastBufferSize(hub string, amount float64) {
+	m.websocketBufferSize.WithLabelValues(hub).Add(amount)
+}
+
+func (m *Metrics) DecrementWebSocketBroadcastBufferSize(hub string, amount float64) {
+	m.websocketBufferSize.WithLabelValues(hub).Sub(amount)
+}
+
+func (m *Metrics) IncrementWebSocketBroadcastUsersRegistered(hub string, amount float64) {
+	m.websocketUsers.WithLabelValues(hub).Add(amount)
+}
+
+func (m *Metrics) DecrementWebSocketBroadcastUsersRegistered(hub string, amount float64) {
+	m.websocketUsers.WithLabelValues(hub).Sub(amount)
+}
+
+func (m *Metrics) ObserveStoreMethodDuration(method, success string, elapsed float64) {
+	m.storeMethodDuration.WithLabelValues(method, success).Observe(elapsed)
+}
+
+func (m *Metrics) ObserveApiEndpointDuration(endpoint, method, statusCode string, elapsed float64) {
+	m.apiEndpointDuration.WithLabelValues(endpoint, method, statusCode).Observe(elapsed)
+}
+
+func (m *Metrics) ObserveGraphQLOperationDuration(operationType string, success bool, elapsed float64) {
+	m.graphQLOperationTime.WithLabelValues(operationType, strconv.FormatBool(success)).Observe(elapsed)
+}
+
+func (m *Metrics) ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64) {
+	m.pluginHookDuration.WithLabelValues(pluginID, hookName, strconv.FormatBool(success)).Observe(elapsed)
+}
+
+func (m *Metrics) ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64) {
+	m.pluginMultiHookIter.WithLabelValues(pluginID).Observe(elapsed)
+}
+
+func (m *Metrics) ObservePluginMultiHookDuration(elapsed float64) {
+	m.pluginMultiHookTime.Observe(elapsed)
+}
+
+func (m *Metrics) ObservePluginApiDuration(pluginID, apiName string, success bool, elapsed float64) {
+	m.pluginApiDuration.WithLabelValues(pluginID, apiName, strconv.FormatBool(success)).Observe(elapsed)
+}
+
+func (m *Metrics) ObserveEnabledUsers(users int64) {
+	m.enabledUsers.Set(float64(users))
+}
+
+func (m *Metrics) GetLoggerMetricsCollector() logr.MetricsCollector {
+	return m.loggerCollector
+}
+
+func (m *Metrics) IncrementJobActive(jobType string) {
+	m.jobsActive.WithLabelValues(jobType).Inc()
+}
+
+func (m *Metrics) DecrementJobActive(jobType string) {
+	m.jobsActive.WithLabelValues(jobType).Dec()
+}
+
+func (m *Metrics) SetReplicaLagAbsolute(node string, value float64) {
+	m.replicaLagAbsolute.WithLabelValues(node).Set(value)
+}
+
+func (m *Metrics) SetReplicaLagTime(node string, value float64) {
+	m.replicaLagTime.WithLabelValues(node).Set(value)
+}
+
+func (m *Metrics) IncrementOrderPlaced(channelID string) {
+	m.ordersPlaced.WithLabelValues(channelID).Inc()
+}
+
+func (m *Metrics) IncrementCheckoutCreated(channelID string) {
+	m.checkoutsCreated.WithLabelValues(channelID).Inc()
+}
+
+func (m *Metrics) IncrementCheckoutCompleted(channelID string) {
+	m.checkoutsCompleted.WithLabelValues(channelID).Inc()
+}
+
+func (m *Metrics) IncrementPaymentGatewayError(gateway string) {
+	m.paymentGatewayErrors.WithLabelValues(gateway).Inc()
+}
+
+func (m *Metrics) IncrementStockAllocationFailure(channelSlug string) {
+	m.stockAllocationFailed.WithLabelValues(channelSlug).Inc()
+}
+
+func (m *Metrics) newCounter(subsystem, name, help string) prometheus.Counter {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+	m.collectors = append(m.collectors, counter)
+	return counter
+}
+
+func (m *Metrics) newCounterVec(subsystem, name, help string, labels ...string) *prometheus.CounterVec {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	m.collectors = append(m.collectors, counter)
+	return counter
+}
+
+func (m *Metrics) newGauge(subsystem, name, help string) prometheus.Gauge {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+	m.collectors = append(m.collectors, gauge)
+	return gauge
+}
+
+func (m *Metrics) newGaugeVec(subsystem, name, help string, labels ...string) *prometheus.GaugeVec {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	m.collectors = append(m.collectors, gauge)
+	return gauge
+}
+
+func (m *Metrics) newHistogram(subsystem, name, help string) prometheus.Histogram {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   prometheus.DefBuckets,
+	})
+	m.collectors = append(m.collectors, histogram)
+	return histogram
+}
+
+func (m *Metrics) newHistogramVec(subsystem, name, help string, labels ...string) *prometheus.HistogramVec {
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   prometheus.DefBuckets,
+	}, labels)
+	m.collectors = append(m.collectors, histogram)
+	return histogram
+}
+
+// loggerCollector provides metrics of logging targets to the logger
+type loggerCollector struct {
+	queueSize *prometheus.GaugeVec
+	logged    *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	dropped   *prometheus.CounterVec
+	blocked   *prometheus.CounterVec
+}
+
+func newLoggerCollector(m *Metrics) *loggerCollector {
+	return &loggerCollector{
+		queueSize: m.newGaugeVec(subsystemLogging, "logger_queue_used", "Number of log records waiting in queues of logging targets", "target"),
+		logged:    m.newCounterVec(subsystemLogging, "logger_logged_total", "The total number of log records logged by logging targets", "target"),
+		errors:    m.newCounterVec(subsystemLogging, "logger_error_total", "The total number of errors of logging targets", "target"),
+		dropped:   m.newCounterVec(subsystemLogging, "logger_dropped_total", "The total number of log records dropped by logging targets", "target"),
+		blocked:   m.newCounterVec(subsystemLogging, "logger_blocked_total", "The total number of times logging targets blocked", "target"),
+	}
+}
+
+func (c *loggerCollector) QueueSizeGauge(target string) (logr.Gauge, error) {
+	return c.queueSize.GetMetricWithLabelValues(target)
+}
+
+func (c *loggerCollector) LoggedCounter(target string) (logr.Counter, error) {
+	return c.logged.GetMetricWithLabelValues(target)
+}
+
+func (c *loggerCollector) ErrorCounter(target string) (logr.Counter, error) {
+	return c.errors.GetMetricWithLabelValues(target)
+}
+
+func (c *loggerCollector) DroppedCounter(target string) (logr.Counter, error) {
+	return c.dropped.GetMetricWithLabelValues(target)
+}
+
+func (c *loggerCollector) BlockedCounter(target string) (logr.Counter, error) {
+	return c.blocked.GetMetricWithLabelValues(target)
+}
@@ -14,11 +14,10 @@ import (
 	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/modules/slog"
 	"github.com/volatiletech/sqlboiler/v4/boil"
-	"gorm.io/gorm"
 )
 
 // OrderCreated. `fromDraft` is default to false
-func (a *ServiceOrder) OrderCreated(tx *gorm.DB, order model.Order, user *model.User, _ any, manager interfaces.PluginManagerInterface, fromDraft bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceOrder) OrderCreated(tx boil.ContextTransactor, order model.Order, user *model.User, _ any, manager interfaces.PluginManagerInterface, fromDraft bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	// create order created event
 	_, appErr := a.OrderCreatedEvent(order, user, nil, fromDraft)
 	if appErr != nil {
@@ -30,6 +29,10 @@ func (a *ServiceOrder) OrderCreated(tx *gorm.DB, order model.Order, user *model.
 		return nil, appErr
 	}
 
+	if a.srv.Metrics != nil {
+		a.srv.Metrics.IncrementOrderPlaced(order.ChannelID)
+	}
+
 	lastPaymentOfOrder, appErr := a.srv.Payment.GetLastOrderPayment(order.ID)
 	if appErr != nil {
 		if appErr.StatusCode == http.StatusInternalServerError {
@@ -75,7 +78,7 @@ finally:
 }
 
 // OrderConfirmed Trigger event, plugin hooks and optionally confirmation email.
-func (a *ServiceOrder) OrderConfirmed(tx *gorm.DB, order model.Order, user *model.User, _ any, manager interfaces.PluginManagerInterface, sendConfirmationEmail bool) *model_helper.AppError {
+func (a *ServiceOrder) OrderConfirmed(tx boil.ContextTransactor, order model.Order, user *model.User, _ any, manager interfaces.PluginManagerInterface, sendConfirmationEmail bool) *model_helper.AppError {
 	_, appErr := a.OrderConfirmedEvent(tx, order, user, nil)
 	if appErr != nil {
 		return appErr
@@ -162,10 +165,16 @@ func (a *ServiceOrder) CancelOrder(transaction boil.ContextTransactor, order *mo
 		return appErr
 	}
 
-	appErr = a.srv.WarehouseService().DeAllocateStockForOrder(order, manager)
+	backInStock, appErr := a.srv.WarehouseService().DeAllocateStockForOrder(transaction, order)
 	if appErr != nil {
 		return appErr
 	}
+	for _, stock := range backInStock {
+		appErr = manager.ProductVariantBackInStock(*stock)
+		if appErr != nil {
+			return appErr
+		}
+	}
 
 	order.Status = model.ORDER_STATUS_CANCELED
 	_, appErr = a.UpsertOrder(transaction, order)
@@ -1313,7 +1322,7 @@ func (a *ServiceOrder) moveOrderLinesToTargetFulfillment(orderLinesToMove []*mod
 	}
 
 	if len(orderLineDatasToDeAlocate) > 0 {
-		allocationErr, appErr := a.srv.WarehouseService().DeallocateStock(orderLineDatasToDeAlocate, manager)
+		allocationErr, appErr := a.srv.WarehouseService().DeallocateStock(transaction, orderLineDatasToDeAlocate, manager)
 		if appErr != nil {
 			return nil, appErr
 		}
@@ -2270,7 +2279,7 @@ func (a *ServiceOrder) processRefund(
 func (s *ServiceOrder) decreaseStocks(orderLinesInfo []*model.OrderLineData, manager interfaces.PluginManagerInterface, allowStockToBeExceeded bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	linesToDecreaseStock := s.srv.WarehouseService().GetOrderLinesWithTrackInventory(orderLinesInfo)
 	if len(linesToDecreaseStock) > 0 {
-		insufficientStock, appErr := s.srv.WarehouseService().DecreaseStock(linesToDecreaseStock, manager, true, allowStockToBeExceeded)
+		insufficientStock, appErr := s.srv.WarehouseService().DecreaseStock(nil, linesToDecreaseStock, manager, true, allowStockToBeExceeded)
 		if insufficientStock != nil || appErr != nil {
 			return insufficientStock, appErr
 		}
@@ -13,15 +13,8 @@ import (
 )
 
 // CommonCreateOrderEvent is common method for creating desired order event instance
-func (a *ServiceOrder) CommonCreateOrderEvent(transaction boil.ContextTransactor, option *model.OrderEventOption) (*model.OrderEvent, *model_helper.AppError) {
-	newOrderEvent := &model.OrderEvent{
-		OrderID:    option.OrderID,
-		Type:       option.Type,
-		Parameters: option.Parameters,
-		UserID:     option.UserID,
-	}
-
-	orderEvent, err := a.srv.Store.OrderEvent().Save(transaction, newOrderEvent)
+func (a *ServiceOrder) CommonCreateOrderEvent(transaction boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, *model_helper.AppError) {
+	savedOrderEvent, err := a.srv.Store.OrderEvent().Save(transaction, orderEvent)
 	if err != nil {
 		if appErr, ok := err.(*model_helper.AppError); ok {
 			return nil, appErr
@@ -29,14 +22,14 @@ func (a *ServiceOrder) CommonCreateOrderEvent(transaction boil.ContextTransactor
 		return nil, model_helper.NewAppError("CommonCreateOrderEvent", "app.order.error_creating_order_event.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	return orderEvent, nil
+	return savedOrderEvent, nil
 }
 
 func (s *ServiceOrder) LinePerQuantityToLineObject(quantity int, line *model.OrderLine) model_types.JSONString {
 	return model_types.JSONString{
 		"quantity": quantity,
-		"line_pk":  line.Id,
-		"item":     line.String(),
+		"line_pk":  line.ID,
+		"item":     model_helper.OrderLineString(*line),
 	}
 }
 
@@ -469,6 +469,20 @@ func (s *ServiceOrder) getDefaultFulfillmentPayload(order *model.Order, fulfillm
 	return res, nil
 }
 
+// getOrderSummaryPayload returns the order fields used by notifications that do not need order lines
+func getOrderSummaryPayload(order model.Order) model_types.JSONString {
+	return model_types.JSONString{
+		"id":                   order.ID,
+		"token":                order.Token,
+		"status":               order.Status,
+		"currency":             order.Currency,
+		"total_gross_amount":   order.TotalGrossAmount,
+		"total_net_amount":     order.TotalNetAmount,
+		"total_charged_amount": order.TotalChargedAmount,
+		"created_at":           order.CreatedAt,
+	}
+}
+
 // SendPaymentConfirmation sends notification with the payment confirmation
 func (s *ServiceOrder) SendPaymentConfirmation(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
 	panic("not implemented")
@@ -56,13 +56,14 @@ func (a *ServiceOrder) BulkUpsertOrders(transaction boil.ContextTransactor, orde
 }
 
 // FilterOrdersByOptions is common method for filtering orders by given option
-func (a *ServiceOrder) FilterOrdersByOptions(option *model.OrderFilterOption) (int64, []*model.Order, *model_helper.AppError) {
-	totalCount, orders, err := a.srv.Store.Order().FilterByOption(option)
+func (a *ServiceOrder) FilterOrdersByOptions(option model_helper.OrderFilterOption) (int64, []*model.Order, *model_helper.AppError) {
+	customOrders, err := a.srv.Store.Order().FilterByOption(option)
 	if err != nil {
 		return 0, nil, model_helper.NewAppError("FilterOrdersbyOption", "app.order.error_finding_orders_by_option.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	return totalCount, orders, nil
+	orders := lo.Map(customOrders, func(order *model_helper.CustomOrder, _ int) *model.Order { return &order.Order })
+	return int64(len(orders)), orders, nil
 }
 
 // OrderById retuns an order with given id
@@ -301,15 +302,13 @@ func (a *ServiceOrder) OrderTotalAuthorized(ord *model.Order) (*goprices.Money,
 
 // CustomerEmail try finding order's owner's email. If order has no user or error occured during the finding process, returns order's UserEmail property instead
 func (a *ServiceOrder) CustomerEmail(ord *model.Order) (string, *model_helper.AppError) {
-	if ord.UserID != nil {
-		user, appErr := a.srv.AccountService().UserById(context.Background(), *ord.UserID)
+	if !ord.UserID.IsNil() {
+		user, appErr := a.srv.Account.UserById(context.Background(), *ord.UserID.String)
 		if appErr != nil {
 			if appErr.StatusCode == http.StatusInternalServerError {
 				return "", appErr
 			}
-			if ord.UserEmail != "" {
-				return ord.UserEmail, nil
-			}
+			return ord.UserEmail, nil
 		}
 		return user.Email, nil
 	}
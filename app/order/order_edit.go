@@ -0,0 +1,394 @@
+package order
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samber/lo"
+	goprices "github.com/site-name/go-prices"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/measurement"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// EditOrder applies given changes to a draft or unconfirmed order.
+//
+// Line changes, stock allocations of unconfirmed orders, the shipping method change and recalculation of
+// taxes, totals and the order voucher are saved in one transaction. The shipping method of the order is
+// validated against the edited lines. If the new total of an unconfirmed order exceeds captured amount,
+// customer is requested to pay the balance.
+//
+// NOTE: user must not be nil
+func (s *ServiceOrder) EditOrder(order model.Order, input model_helper.OrderEditInput, user *model.User, manager interfaces.PluginManagerInterface) (*model.Order, *model_helper.InsufficientStock, *model_helper.AppError) {
+	if !model_helper.OrderIsDraft(order) && !model_helper.OrderIsUnConfirmed(order) {
+		return nil, nil, model_helper.NewAppError("EditOrder", "app.order.order_not_editable.app_error", map[string]any{"Status": order.Status}, "", http.StatusBadRequest)
+	}
+	if input.IsEmpty() {
+		return &order, nil, nil
+	}
+
+	edit, appErr := s.prepareOrderEdit(order, input)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	// the current shipping method is revalidated when lines change, since it may no longer fit the order
+	var shippingMethodID string
+	if input.ShippingMethodID != nil {
+		shippingMethodID = *input.ShippingMethodID
+	} else if edit.changesLines() && !order.ShippingMethodID.IsNil() {
+		shippingMethodID = *order.ShippingMethodID.String
+	}
+
+	var discounts []*model_helper.DiscountInfo
+	if len(edit.addedVariants) > 0 {
+		discounts, appErr = s.srv.Discount.FetchActiveDiscounts()
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+	}
+
+	tx, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, nil, model_helper.NewAppError("EditOrder", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(tx)
+
+	lines, insufficientStock, appErr := s.editOrderLines(tx, order, edit, user, manager, discounts)
+	if insufficientStock != nil || appErr != nil {
+		return nil, insufficientStock, appErr
+	}
+
+	appErr = s.UpdateTaxesForOrderLines(tx, lines, order, manager, *s.srv.Config().ShopSettings.IncludeTaxesInPrice)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	subtotal, appErr := s.srv.Payment.GetSubTotal(lines, order.Currency.String())
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	if shippingMethodID != "" {
+		shippingMethod, appErr := s.validateOrderShippingMethod(order, shippingMethodID, edit, subtotal.GetGross())
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		order.ShippingMethodID = model_types.NewNullString(shippingMethod.ID)
+		order.ShippingMethodName = model_types.NewNullString(shippingMethod.Name)
+	}
+
+	appErr = s.recalculateEditedOrder(tx, &order, lines, *subtotal, manager)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, model_helper.NewAppError("EditOrder", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	editedOrder, appErr := s.OrderById(order.ID)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	if model_helper.OrderIsDraft(*editedOrder) {
+		_, appErr = manager.DraftOrderUpdated(*editedOrder)
+	} else {
+		_, appErr = manager.OrderUpdated(*editedOrder)
+	}
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	if model_helper.OrderIsUnConfirmed(*editedOrder) && editedOrder.TotalGrossAmount.GreaterThan(editedOrder.TotalChargedAmount) {
+		appErr = s.SendOrderPaymentBalanceRequest(*editedOrder, manager)
+		if appErr != nil {
+			// order has been edited successfully, so we do not fail here
+			slog.Error("failed to request payment balance for edited order", slog.String("order_id", editedOrder.ID), slog.Err(appErr))
+		}
+	}
+
+	return editedOrder, nil, nil
+}
+
+// orderEdit holds validated line changes of an order edit
+type orderEdit struct {
+	lines         map[string]*model.OrderLine // current lines of the order, by id
+	newQuantities map[string]int              // new quantities of updated lines, 0 for removed lines
+	addedVariants model.ProductVariantSlice   // variants of input.AddLines, in the same order
+	addQuantities []int                       // quantities of input.AddLines
+}
+
+func (e *orderEdit) changesLines() bool {
+	return len(e.newQuantities) > 0 || len(e.addedVariants) > 0
+}
+
+// prepareOrderEdit validates line changes of given input against lines of the order
+func (s *ServiceOrder) prepareOrderEdit(order model.Order, input model_helper.OrderEditInput) (*orderEdit, *model_helper.AppError) {
+	lines, appErr := s.OrderLinesByOption(model_helper.OrderLineFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderLineWhere.OrderID.EQ(order.ID),
+			qm.Load(model.OrderLineRels.Variant),
+		),
+	})
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	edit := &orderEdit{
+		lines:         lo.SliceToMap(lines, func(line *model.OrderLine) (string, *model.OrderLine) { return line.ID, line }),
+		newQuantities: make(map[string]int, len(input.UpdateLines)+len(input.RemoveLines)),
+		addedVariants: make(model.ProductVariantSlice, len(input.AddLines)),
+		addQuantities: make([]int, len(input.AddLines)),
+	}
+
+	for lineID, quantity := range input.UpdateLines {
+		edit.newQuantities[lineID] = quantity
+	}
+	for _, lineID := range input.RemoveLines {
+		edit.newQuantities[lineID] = 0
+	}
+	for lineID, quantity := range edit.newQuantities {
+		if _, ok := edit.lines[lineID]; !ok || quantity < 0 {
+			return nil, model_helper.NewAppError("EditOrder", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "lines"}, "invalid line id or quantity: "+lineID, http.StatusBadRequest)
+		}
+	}
+
+	for idx, addLine := range input.AddLines {
+		if addLine.Quantity <= 0 {
+			return nil, model_helper.NewAppError("EditOrder", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "quantity"}, "quantity must be greater than 0", http.StatusBadRequest)
+		}
+		variant, appErr := s.srv.Product.ProductVariantById(addLine.VariantID)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				appErr = model_helper.NewAppError("EditOrder", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "variant_id"}, "variant not found: "+addLine.VariantID, http.StatusBadRequest)
+			}
+			return nil, appErr
+		}
+		edit.addedVariants[idx] = variant
+		edit.addQuantities[idx] = addLine.Quantity
+	}
+
+	return edit, nil
+}
+
+// editOrderLines adds, updates and removes lines of given order within given transaction,
+// records added_products/removed_products events and returns lines of the edited order
+func (s *ServiceOrder) editOrderLines(tx boil.ContextTransactor, order model.Order, edit *orderEdit, user *model.User, manager interfaces.PluginManagerInterface, discounts []*model_helper.DiscountInfo) (model.OrderLineSlice, *model_helper.InsufficientStock, *model_helper.AppError) {
+	lines := make(model.OrderLineSlice, 0, len(edit.lines)+len(edit.addedVariants))
+	for lineID, line := range edit.lines {
+		if _, updated := edit.newQuantities[lineID]; !updated {
+			lines = append(lines, line)
+		}
+	}
+	if !edit.changesLines() {
+		return lines, nil, nil
+	}
+
+	channel, appErr := s.srv.Channel.ChannelByOption(model_helper.ChannelFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ChannelWhere.ID.EQ(order.ChannelID)),
+	})
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	for lineID, newQuantity := range edit.newQuantities {
+		line := edit.lines[lineID]
+		lineInfo := &model_helper.OrderLineData{
+			Line:     *line,
+			Quantity: line.Quantity,
+			Variant:  line.R.GetVariant(),
+		}
+
+		insufficientStock, appErr := s.ChangeOrderLineQuantity(tx, user.ID, nil, lineInfo, line.Quantity, newQuantity, channel.Slug, manager, true)
+		if insufficientStock != nil || appErr != nil {
+			return nil, insufficientStock, appErr
+		}
+
+		if newQuantity > 0 {
+			editedLine := *line
+			editedLine.Quantity = newQuantity
+			lines = append(lines, &editedLine)
+		}
+	}
+
+	for idx, variant := range edit.addedVariants {
+		quantity := edit.addQuantities[idx]
+
+		line, insufficientStock, appErr := s.AddVariantToOrder(tx, order, *variant, quantity, user, nil, manager, discounts, model_helper.OrderIsUnConfirmed(order))
+		if insufficientStock != nil || appErr != nil {
+			return nil, insufficientStock, appErr
+		}
+
+		appErr = s.CreateOrderEvent(tx, line, user.ID, -quantity)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil, nil
+}
+
+// validateOrderShippingMethod checks if shipping method with given id can ship given order once the edit is applied:
+// the method must be listed in the order channel, its zone must cover the order shipping address and
+// the edited order weight and given edited subtotal must fit the method weight and price limits.
+func (s *ServiceOrder) validateOrderShippingMethod(order model.Order, shippingMethodID string, edit *orderEdit, subtotal goprices.Money) (*model.ShippingMethod, *model_helper.AppError) {
+	if order.ShippingAddressID.IsNil() {
+		return nil, model_helper.NewAppError("EditOrder", "app.order.shipping_address_required.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	weight, appErr := s.editedOrderWeight(order, edit)
+	if appErr != nil {
+		return nil, appErr
+	}
+	order.WeightAmount = float32(weight.Amount)
+	order.WeightUnit = string(weight.Unit)
+
+	// country code is taken from order shipping address
+	methods, appErr := s.srv.Shipping.ApplicableShippingMethodsForOrder(order, order.ChannelID, subtotal, "", nil)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	method, found := lo.Find(methods, func(method *model.ShippingMethod) bool { return method.ID == shippingMethodID })
+	if !found {
+		return nil, model_helper.NewAppError("EditOrder", "app.order.shipping_method_not_applicable.app_error", nil, "shipping method id: "+shippingMethodID, http.StatusBadRequest)
+	}
+	return method, nil
+}
+
+// editedOrderWeight returns total weight of order lines once given edit is applied, in order weight unit
+func (s *ServiceOrder) editedOrderWeight(order model.Order, edit *orderEdit) (*measurement.Weight, *model_helper.AppError) {
+	variantQuantities := map[string]int{}
+	for lineID, line := range edit.lines {
+		if line.VariantID.IsNil() {
+			continue
+		}
+		quantity, updated := edit.newQuantities[lineID]
+		if !updated {
+			quantity = line.Quantity
+		}
+		variantQuantities[*line.VariantID.String] += quantity
+	}
+	for idx, variant := range edit.addedVariants {
+		variantQuantities[variant.ID] += edit.addQuantities[idx]
+	}
+
+	unit := measurement.WeightUnit(order.WeightUnit)
+	if unit == "" {
+		unit = measurement.STANDARD_WEIGHT_UNIT
+	}
+	total := &measurement.Weight{Amount: 0, Unit: unit}
+
+	for variantID, quantity := range variantQuantities {
+		if quantity == 0 {
+			continue
+		}
+		variantWeight, appErr := s.srv.Product.ProductVariantGetWeight(variantID)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		var err error
+		total, err = total.Add(variantWeight.Mul(quantity))
+		if err != nil {
+			return nil, model_helper.NewAppError("EditOrder", model_helper.ErrorCalculatingMeasurementID, nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return total, nil
+}
+
+// recalculateEditedOrder recomputes shipping price, totals and discounts of given order from given edited lines,
+// whose taxes are already updated, within given transaction.
+func (s *ServiceOrder) recalculateEditedOrder(tx boil.ContextTransactor, order *model.Order, lines model.OrderLineSlice, subtotal goprices.TaxedMoney, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	appErr := s.updateOrderShippingPrice(tx, order, manager)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = s.removeInapplicableVoucher(tx, order, lines, subtotal)
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.RecalculateOrder(tx, order, map[string]any{})
+}
+
+// removeInapplicableVoucher removes voucher of given order when it no longer applies to given edited lines and subtotal.
+// Unconfirmed orders used the voucher up at checkout, so its usage is released within given transaction,
+// drafts only use it up when they are completed.
+func (s *ServiceOrder) removeInapplicableVoucher(tx boil.ContextTransactor, order *model.Order, lines model.OrderLineSlice, subtotal goprices.TaxedMoney) *model_helper.AppError {
+	if order.VoucherID.IsNil() {
+		return nil
+	}
+
+	voucher, appErr := s.srv.Discount.VoucherById(*order.VoucherID.String)
+	if appErr != nil {
+		return appErr
+	}
+	customerEmail, appErr := s.CustomerEmail(order)
+	if appErr != nil {
+		return appErr
+	}
+
+	quantity := lo.SumBy(lines, func(line *model.OrderLine) int { return line.Quantity })
+	notApplicable, appErr := s.srv.Discount.ValidateVoucher(*voucher, subtotal, quantity, customerEmail, order.ChannelID, lo.FromPtr(order.UserID.String))
+	if appErr != nil {
+		return appErr
+	}
+	if notApplicable == nil {
+		return nil
+	}
+
+	if order.Status == model.OrderStatusUnconfirmed {
+		appErr = s.srv.Checkout.ReleaseVoucherUsage(tx, map[string]any{
+			"voucher":    voucher,
+			"user_email": order.UserEmail,
+		})
+		if appErr != nil {
+			return appErr
+		}
+	}
+	order.VoucherID = model_types.NullString{}
+	order.VoucherCode = model_types.NullString{}
+	return nil
+}
+
+// SendOrderPaymentBalanceRequest notifies customer of given order that the order total
+// exceeds amount captured so far and the difference needs to be paid
+func (s *ServiceOrder) SendOrderPaymentBalanceRequest(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	customerEmail, appErr := s.CustomerEmail(&order)
+	if appErr != nil {
+		return appErr
+	}
+
+	total := model_helper.OrderGetTotalPrice(order)
+	captured, err := goprices.NewMoneyFromDecimal(order.TotalChargedAmount, order.Currency.String())
+	if err != nil {
+		return model_helper.NewAppError("SendOrderPaymentBalanceRequest", model_helper.ErrorCalculatingMoneyErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	totalGross := total.GetGross()
+	balance, err := totalGross.Sub(*captured)
+	if err != nil {
+		return model_helper.NewAppError("SendOrderPaymentBalanceRequest", model_helper.ErrorCalculatingMoneyErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	payload := model_types.JSONString{
+		"order":           getOrderSummaryPayload(order),
+		"balance_amount":  balance.GetAmount(),
+		"recipient_email": customerEmail,
+	}
+	payload.Merge(s.srv.GetSiteContext())
+
+	_, appErr = manager.Notify(model_helper.ORDER_PAYMENT_BALANCE_REQUEST, payload, order.ChannelID, "")
+	return appErr
+}
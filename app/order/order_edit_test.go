@@ -0,0 +1,254 @@
+package order
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/site-name/decimal"
+	goprices "github.com/site-name/go-prices"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/measurement"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// fakeProduct serves variants and their weights from memory
+type fakeProduct struct {
+	sub_app_iface.ProductService
+
+	variants map[string]*model.ProductVariant
+	weights  map[string]measurement.Weight
+}
+
+func (p *fakeProduct) ProductVariantById(id string) (*model.ProductVariant, *model_helper.AppError) {
+	variant, ok := p.variants[id]
+	if !ok {
+		return nil, model_helper.NewAppError("ProductVariantById", "app.product.product_variant_missing.app_error", nil, "", http.StatusNotFound)
+	}
+	return variant, nil
+}
+
+func (p *fakeProduct) ProductVariantGetWeight(variantID string) (*measurement.Weight, *model_helper.AppError) {
+	weight := p.weights[variantID]
+	return &weight, nil
+}
+
+// fakeShipping returns methods whose max weight and max price fit the order weight and subtotal
+type fakeShipping struct {
+	sub_app_iface.ShippingService
+
+	methods   model.ShippingMethodSlice
+	maxWeight map[string]float64 // shipping method id => max order weight in kg
+	maxPrice  map[string]int64   // shipping method id => max order subtotal, no limit if missing
+}
+
+func (s *fakeShipping) ApplicableShippingMethodsForOrder(order model.Order, _ string, price goprices.Money, _ model.CountryCode, _ model_helper.CheckoutLineInfos) (model.ShippingMethodSlice, *model_helper.AppError) {
+	var methods model.ShippingMethodSlice
+	for _, method := range s.methods {
+		maxPrice, limited := s.maxPrice[method.ID]
+		if float64(order.WeightAmount) <= s.maxWeight[method.ID] && (!limited || price.GetAmount().LessThanOrEqual(decimal.NewFromInt(maxPrice))) {
+			methods = append(methods, method)
+		}
+	}
+	return methods, nil
+}
+
+// fakeDiscount serves one voucher, which applies to subtotals of at least its min spent amount
+type fakeDiscount struct {
+	sub_app_iface.DiscountService
+
+	voucher  *model.Voucher
+	minSpent int64
+}
+
+func (d *fakeDiscount) VoucherById(id string) (*model.Voucher, *model_helper.AppError) {
+	return d.voucher, nil
+}
+
+func (d *fakeDiscount) ValidateVoucher(_ model.Voucher, totalPrice goprices.TaxedMoney, _ int, _ string, _ string, _ string) (*model_helper.NotApplicable, *model_helper.AppError) {
+	gross := totalPrice.GetGross()
+	if gross.GetAmount().LessThan(decimal.NewFromInt(d.minSpent)) {
+		return &model_helper.NotApplicable{Where: "ValidateVoucher", Message: "minimum spent not reached"}, nil
+	}
+	return nil, nil
+}
+
+// fakeCheckout records vouchers whose usage is released
+type fakeCheckout struct {
+	sub_app_iface.CheckoutService
+
+	released []any
+}
+
+func (c *fakeCheckout) ReleaseVoucherUsage(_ boil.ContextTransactor, orderData map[string]any) *model_helper.AppError {
+	c.released = append(c.released, orderData["voucher"])
+	return nil
+}
+
+func newTestOrderEditService(lines model.OrderLineSlice, product *fakeProduct, shipping *fakeShipping) *ServiceOrder {
+	orderLineStore := &mocks.OrderLineStore{}
+	orderLineStore.On("FilterbyOption", mock.Anything).Return(lines, nil)
+	mockStore := &mocks.Store{}
+	mockStore.On("OrderLine").Return(orderLineStore)
+
+	return &ServiceOrder{srv: &app.Server{Store: mockStore, Product: product, Shipping: shipping}}
+}
+
+func TestEditOrder(t *testing.T) {
+	heavy := &model.ProductVariant{ID: model_helper.NewId()}
+	light := &model.ProductVariant{ID: model_helper.NewId()}
+	product := &fakeProduct{
+		variants: map[string]*model.ProductVariant{heavy.ID: heavy, light.ID: light},
+		weights: map[string]measurement.Weight{
+			heavy.ID: {Amount: 2, Unit: measurement.KG},
+			light.ID: {Amount: 100, Unit: measurement.G},
+		},
+	}
+	line := &model.OrderLine{ID: model_helper.NewId(), VariantID: model_types.NewNullString(light.ID), Quantity: 5}
+	order := model.Order{
+		ID:                model_helper.NewId(),
+		Status:            model.OrderStatusUnconfirmed,
+		Currency:          model.CurrencyUSD,
+		ShippingAddressID: model_types.NewNullString(model_helper.NewId()),
+		WeightUnit:        string(measurement.KG),
+	}
+	standard := &model.ShippingMethod{ID: model_helper.NewId(), Name: "standard"}
+	freight := &model.ShippingMethod{ID: model_helper.NewId(), Name: "freight"}
+	shipping := &fakeShipping{
+		methods:   model.ShippingMethodSlice{standard, freight},
+		maxWeight: map[string]float64{standard.ID: 5, freight.ID: 100},
+		maxPrice:  map[string]int64{standard.ID: 100},
+	}
+	subtotal, _ := goprices.NewMoney(50, model.CurrencyUSD.String())
+
+	t.Run("order not editable", func(t *testing.T) {
+		s := newTestOrderEditService(nil, product, shipping)
+		fulfilled := order
+		fulfilled.Status = model.OrderStatusFulfilled
+
+		_, _, appErr := s.EditOrder(fulfilled, model_helper.OrderEditInput{RemoveLines: []string{line.ID}}, &model.User{}, nil)
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.order.order_not_editable.app_error", appErr.Id)
+	})
+
+	t.Run("invalid lines", func(t *testing.T) {
+		s := newTestOrderEditService(model.OrderLineSlice{line}, product, shipping)
+
+		for name, input := range map[string]model_helper.OrderEditInput{
+			"unknown line":      {RemoveLines: []string{model_helper.NewId()}},
+			"negative quantity": {UpdateLines: map[string]int{line.ID: -1}},
+			"zero add quantity": {AddLines: []model_helper.OrderEditLineInput{{VariantID: heavy.ID}}},
+			"unknown variant":   {AddLines: []model_helper.OrderEditLineInput{{VariantID: model_helper.NewId(), Quantity: 1}}},
+		} {
+			_, appErr := s.prepareOrderEdit(order, input)
+			require.NotNil(t, appErr, name)
+			require.Equal(t, http.StatusBadRequest, appErr.StatusCode, name)
+		}
+	})
+
+	t.Run("edited order weight", func(t *testing.T) {
+		s := newTestOrderEditService(model.OrderLineSlice{line}, product, shipping)
+
+		edit, appErr := s.prepareOrderEdit(order, model_helper.OrderEditInput{
+			UpdateLines: map[string]int{line.ID: 10},
+			AddLines:    []model_helper.OrderEditLineInput{{VariantID: heavy.ID, Quantity: 2}},
+		})
+		require.Nil(t, appErr)
+
+		weight, appErr := s.editedOrderWeight(order, edit)
+		require.Nil(t, appErr)
+		require.Equal(t, measurement.KG, weight.Unit)
+		require.InDelta(t, 5.0, weight.Amount, 0.0001)
+	})
+
+	t.Run("shipping method", func(t *testing.T) {
+		s := newTestOrderEditService(model.OrderLineSlice{line}, product, shipping)
+
+		edit, appErr := s.prepareOrderEdit(order, model_helper.OrderEditInput{})
+		require.Nil(t, appErr)
+		method, appErr := s.validateOrderShippingMethod(order, standard.ID, edit, *subtotal)
+		require.Nil(t, appErr)
+		require.Equal(t, standard.ID, method.ID)
+
+		// edited subtotal exceeds the price limit of standard shipping
+		editedSubtotal, _ := goprices.NewMoney(150, model.CurrencyUSD.String())
+		_, appErr = s.validateOrderShippingMethod(order, standard.ID, edit, *editedSubtotal)
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.order.shipping_method_not_applicable.app_error", appErr.Id)
+
+		// added lines make the order too heavy for standard shipping
+		edit, appErr = s.prepareOrderEdit(order, model_helper.OrderEditInput{
+			AddLines: []model_helper.OrderEditLineInput{{VariantID: heavy.ID, Quantity: 3}},
+		})
+		require.Nil(t, appErr)
+		_, appErr = s.validateOrderShippingMethod(order, standard.ID, edit, *subtotal)
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.order.shipping_method_not_applicable.app_error", appErr.Id)
+
+		method, appErr = s.validateOrderShippingMethod(order, freight.ID, edit, *subtotal)
+		require.Nil(t, appErr)
+		require.Equal(t, freight.ID, method.ID)
+
+		withoutAddress := order
+		withoutAddress.ShippingAddressID = model_types.NullString{}
+		_, appErr = s.validateOrderShippingMethod(withoutAddress, freight.ID, edit, *subtotal)
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.order.shipping_address_required.app_error", appErr.Id)
+	})
+}
+
+func TestRemoveInapplicableVoucher(t *testing.T) {
+	voucher := &model.Voucher{ID: model_helper.NewId(), Code: "SAVE10"}
+	lines := model.OrderLineSlice{{ID: model_helper.NewId(), Quantity: 2}}
+	newOrder := func(status model.OrderStatus) *model.Order {
+		return &model.Order{
+			ID:          model_helper.NewId(),
+			Status:      status,
+			UserEmail:   "customer@example.com",
+			VoucherID:   model_types.NewNullString(voucher.ID),
+			VoucherCode: model_types.NewNullString(voucher.Code),
+		}
+	}
+	newSubtotal := func(amount float64) goprices.TaxedMoney {
+		money, _ := goprices.NewMoney(amount, model.CurrencyUSD.String())
+		subtotal, _ := goprices.NewTaxedMoney(*money, *money)
+		return *subtotal
+	}
+	newService := func(checkout *fakeCheckout) *ServiceOrder {
+		return &ServiceOrder{srv: &app.Server{Discount: &fakeDiscount{voucher: voucher, minSpent: 100}, Checkout: checkout}}
+	}
+
+	t.Run("voucher still applies", func(t *testing.T) {
+		checkout := &fakeCheckout{}
+		order := newOrder(model.OrderStatusUnconfirmed)
+
+		require.Nil(t, newService(checkout).removeInapplicableVoucher(nil, order, lines, newSubtotal(150)))
+		require.Equal(t, voucher.ID, *order.VoucherID.String)
+		require.Empty(t, checkout.released)
+	})
+
+	t.Run("unconfirmed order releases voucher usage", func(t *testing.T) {
+		checkout := &fakeCheckout{}
+		order := newOrder(model.OrderStatusUnconfirmed)
+
+		require.Nil(t, newService(checkout).removeInapplicableVoucher(nil, order, lines, newSubtotal(50)))
+		require.True(t, order.VoucherID.IsNil())
+		require.True(t, order.VoucherCode.IsNil())
+		require.Equal(t, []any{voucher}, checkout.released)
+	})
+
+	t.Run("draft order did not use voucher up", func(t *testing.T) {
+		checkout := &fakeCheckout{}
+		order := newOrder(model.OrderStatusDraft)
+
+		require.Nil(t, newService(checkout).removeInapplicableVoucher(nil, order, lines, newSubtotal(50)))
+		require.True(t, order.VoucherID.IsNil())
+		require.Empty(t, checkout.released)
+	})
+}
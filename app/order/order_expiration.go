@@ -0,0 +1,274 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// expireOrdersBatchSize limits number of orders expired or deleted per channel in one run
+const expireOrdersBatchSize = 1000
+
+// pendingPaymentVoidKey is the private metadata key of expired orders whose payments are not voided yet
+const pendingPaymentVoidKey = "pending_payment_void"
+
+// ExpireOrders expires unconfirmed, unpaid orders placed from checkouts which are older than
+// ExpireOrdersAfter (minutes) of their channel, then deletes expired orders which have been expired
+// for longer than DeleteExpiredOrdersAfter (days) of their channel. Payments of expired orders that
+// failed to be voided in previous runs are voided again.
+func (s *ServiceOrder) ExpireOrders() *model_helper.AppError {
+	channels, appErr := s.srv.Channel.ChannelsByOption(model_helper.ChannelFilterOptions{})
+	if appErr != nil {
+		return appErr
+	}
+
+	var (
+		manager = s.srv.Plugin.GetPluginManager()
+		now     = model_helper.GetMillis()
+	)
+	for _, channel := range channels {
+		if !channel.ExpireOrdersAfter.IsNil() && *channel.ExpireOrdersAfter.Int > 0 {
+			createdBefore := now - int64(*channel.ExpireOrdersAfter.Int)*time.Minute.Milliseconds()
+
+			appErr = s.expireChannelOrders(*channel, createdBefore, manager)
+			if appErr != nil {
+				return appErr
+			}
+		}
+
+		appErr = s.retryChannelPaymentVoids(*channel, manager)
+		if appErr != nil {
+			return appErr
+		}
+
+		if !channel.DeleteExpiredOrdersAfter.IsNil() && *channel.DeleteExpiredOrdersAfter.Int > 0 {
+			expiredBefore := now - int64(*channel.DeleteExpiredOrdersAfter.Int)*(24*time.Hour).Milliseconds()
+
+			appErr = s.deleteExpiredChannelOrders(*channel, expiredBefore)
+			if appErr != nil {
+				return appErr
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceOrder) expireChannelOrders(channel model.Channel, createdBefore int64, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	_, orders, appErr := s.FilterOrdersByOptions(model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderWhere.ChannelID.EQ(channel.ID),
+			model.OrderWhere.Status.EQ(model.OrderStatusUnconfirmed),
+			model.OrderWhere.Origin.EQ(model.NullOrderOriginFrom(model.OrderOriginCheckout)),
+			model.OrderWhere.ChargeStatus.EQ(model.OrderChargeStatusNone),
+			model.OrderWhere.AuthorizeStatus.EQ(model.OrderAuthorizeStatusNone),
+			model.OrderWhere.CreatedAt.LT(createdBefore),
+			qm.Limit(expireOrdersBatchSize),
+		),
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, order := range orders {
+		appErr = s.ExpireOrder(*order, manager)
+		if appErr != nil {
+			// an order failing to expire must not block the others, it will be retried next run
+			slog.Error("failed to expire order", slog.String("order_id", order.ID), slog.Err(appErr))
+		}
+	}
+
+	return nil
+}
+
+// retryChannelPaymentVoids voids payments of expired orders of given channel which still have pending voids
+func (s *ServiceOrder) retryChannelPaymentVoids(channel model.Channel, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	_, orders, appErr := s.FilterOrdersByOptions(model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderWhere.ChannelID.EQ(channel.ID),
+			model.OrderWhere.Status.EQ(model.OrderStatusExpired),
+			model_helper.JsonbHasKey(model.OrderTableColumns.PrivateMetadata, pendingPaymentVoidKey),
+			qm.Limit(expireOrdersBatchSize),
+		),
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, order := range orders {
+		appErr = s.voidExpiredOrderPayments(*order, manager)
+		if appErr != nil {
+			slog.Error("failed to void payments of expired order", slog.String("order_id", order.ID), slog.Err(appErr))
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceOrder) deleteExpiredChannelOrders(channel model.Channel, expiredBefore int64) *model_helper.AppError {
+	_, orders, appErr := s.FilterOrdersByOptions(model_helper.OrderFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderWhere.ChannelID.EQ(channel.ID),
+			model.OrderWhere.Status.EQ(model.OrderStatusExpired),
+			model.OrderWhere.ExpiredAt.LT(model_types.NewNullInt64(expiredBefore)),
+			qm.Limit(expireOrdersBatchSize),
+		),
+	})
+	if appErr != nil || len(orders) == 0 {
+		return appErr
+	}
+
+	_, appErr = s.DeleteOrders(nil, lo.Map(orders, func(order *model.Order, _ int) string { return order.ID }))
+	return appErr
+}
+
+// ExpireOrder marks given order as expired, records an expired event, releases its stock allocations
+// and voucher usage in one transaction. Then it voids the order's payments and notifies the customer.
+// The order is flagged with a pending payment void until all of its payments are voided, so failed voids
+// are retried by ExpireOrders.
+func (s *ServiceOrder) ExpireOrder(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	tx, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return model_helper.NewAppError("ExpireOrder", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(tx)
+
+	order.Status = model.OrderStatusExpired
+	order.ExpiredAt = model_types.NewNullInt64(model_helper.GetMillis())
+	if order.PrivateMetadata == nil {
+		order.PrivateMetadata = model_types.JSONString{}
+	}
+	order.PrivateMetadata[pendingPaymentVoidKey] = true
+	expiredOrder, appErr := s.UpsertOrder(tx, &order)
+	if appErr != nil {
+		return appErr
+	}
+
+	_, appErr = s.CommonCreateOrderEvent(tx, model.OrderEvent{
+		OrderID: order.ID,
+		Type:    model.OrderEventTypeExpired,
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	backInStock, appErr := s.srv.Warehouse.DeAllocateStockForOrder(tx, expiredOrder)
+	if appErr != nil {
+		return appErr
+	}
+
+	appErr = s.releaseOrderVoucherUsage(tx, *expiredOrder)
+	if appErr != nil {
+		return appErr
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return model_helper.NewAppError("ExpireOrder", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, stock := range backInStock {
+		appErr = manager.ProductVariantBackInStock(*stock)
+		if appErr != nil {
+			return appErr
+		}
+	}
+
+	// the order is expired already, a failed void is retried by next run
+	appErr = s.voidExpiredOrderPayments(*expiredOrder, manager)
+	if appErr != nil {
+		slog.Error("failed to void payments of expired order", slog.String("order_id", expiredOrder.ID), slog.Err(appErr))
+	}
+
+	_, appErr = manager.OrderUpdated(*expiredOrder)
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.SendOrderExpiredNotification(*expiredOrder, manager)
+}
+
+func (s *ServiceOrder) releaseOrderVoucherUsage(transaction boil.ContextTransactor, order model.Order) *model_helper.AppError {
+	if order.VoucherID.IsNil() {
+		return nil
+	}
+
+	voucher, appErr := s.srv.Discount.VoucherById(*order.VoucherID.String)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound { // voucher was deleted
+			return nil
+		}
+		return appErr
+	}
+
+	return s.srv.Checkout.ReleaseVoucherUsage(transaction, map[string]any{
+		"voucher":    voucher,
+		"user_email": order.UserEmail,
+	})
+}
+
+// voidExpiredOrderPayments voids active payments of given expired order which can still be voided.
+// Payments voided by previous attempts cannot be voided anymore and are skipped, so it is safe to retry.
+// The pending payment void flag of the order is removed once every payment is voided.
+func (s *ServiceOrder) voidExpiredOrderPayments(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	payments, appErr := s.srv.Payment.PaymentsByOption(model_helper.PaymentFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.PaymentWhere.OrderID.EQ(model_types.NewNullString(order.ID)),
+			model.PaymentWhere.IsActive.EQ(true),
+		),
+	})
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, payment := range payments {
+		canVoid, appErr := s.srv.Payment.PaymentCanVoid(*payment)
+		if appErr != nil {
+			return appErr
+		}
+		if !canVoid {
+			continue
+		}
+
+		_, paymentErr, appErr := s.srv.Payment.Void(nil, *payment, manager, order.ChannelID)
+		if appErr != nil {
+			return appErr
+		}
+		if paymentErr != nil {
+			return model_helper.NewAppError("voidExpiredOrderPayments", "app.order.void_expired_order_payment.app_error", map[string]any{"PaymentID": payment.ID}, paymentErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	delete(order.PrivateMetadata, pendingPaymentVoidKey)
+	_, appErr = s.UpsertOrder(nil, &order)
+	return appErr
+}
+
+// SendOrderExpiredNotification notifies customer of given order that the order has expired
+func (s *ServiceOrder) SendOrderExpiredNotification(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	customerEmail, appErr := s.CustomerEmail(&order)
+	if appErr != nil {
+		return appErr
+	}
+	if customerEmail == "" {
+		return nil
+	}
+
+	payload := model_types.JSONString{
+		"order":           getOrderSummaryPayload(order),
+		"recipient_email": customerEmail,
+	}
+	payload.Merge(s.srv.GetSiteContext())
+
+	_, appErr = manager.Notify(model_helper.ORDER_EXPIRED, payload, order.ChannelID, "")
+	return appErr
+}
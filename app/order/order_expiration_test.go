@@ -0,0 +1,117 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// fakePayment is a payment service voiding payments in memory, a payment can be voided until its charge status is cancelled
+type fakePayment struct {
+	sub_app_iface.PaymentService
+
+	payments model.PaymentSlice
+	failVoid bool
+	voided   []string
+}
+
+func (p *fakePayment) PaymentsByOption(option model_helper.PaymentFilterOptions) (model.PaymentSlice, *model_helper.AppError) {
+	return p.payments, nil
+}
+
+func (p *fakePayment) PaymentCanVoid(payment model.Payment) (bool, *model_helper.AppError) {
+	return payment.ChargeStatus != model.PaymentChargeStatusCancelled, nil
+}
+
+func (p *fakePayment) Void(_ boil.ContextTransactor, payment model.Payment, _ interfaces.PluginManagerInterface, _ string) (*model.PaymentTransaction, *model_helper.PaymentError, *model_helper.AppError) {
+	if p.failVoid {
+		return nil, model_helper.NewPaymentError("Void", "gateway is unavailable", model_helper.INVALID), nil
+	}
+	for _, saved := range p.payments {
+		if saved.ID == payment.ID {
+			saved.ChargeStatus = model.PaymentChargeStatusCancelled
+		}
+	}
+	p.voided = append(p.voided, payment.ID)
+	return &model.PaymentTransaction{}, nil, nil
+}
+
+func newExpiredOrder() model.Order {
+	return model.Order{
+		ID:              model_helper.NewId(),
+		Status:          model.OrderStatusExpired,
+		PrivateMetadata: model_types.JSONString{pendingPaymentVoidKey: true, "note": "kept"},
+	}
+}
+
+func newTestOrderService(payment *fakePayment) (*ServiceOrder, *mocks.OrderStore) {
+	orderStore := &mocks.OrderStore{}
+	orderStore.On("BulkUpsert", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, orders model.OrderSlice) (model.OrderSlice, error) {
+		return orders, nil
+	})
+	mockStore := &mocks.Store{}
+	mockStore.On("Order").Return(orderStore)
+
+	return &ServiceOrder{srv: &app.Server{Store: mockStore, Payment: payment}}, orderStore
+}
+
+func TestVoidExpiredOrderPayments(t *testing.T) {
+	t.Run("voids payments and clears pending flag", func(t *testing.T) {
+		payment := &fakePayment{payments: model.PaymentSlice{
+			{ID: model_helper.NewId(), ChargeStatus: model.PaymentChargeStatusNotCharged},
+			{ID: model_helper.NewId(), ChargeStatus: model.PaymentChargeStatusCancelled},
+		}}
+		s, orderStore := newTestOrderService(payment)
+
+		require.Nil(t, s.voidExpiredOrderPayments(newExpiredOrder(), nil))
+		require.Equal(t, []string{payment.payments[0].ID}, payment.voided)
+
+		orderStore.AssertNumberOfCalls(t, "BulkUpsert", 1)
+		saved := orderStore.Calls[0].Arguments.Get(1).(model.OrderSlice)[0]
+		require.NotContains(t, saved.PrivateMetadata, pendingPaymentVoidKey)
+		require.Equal(t, "kept", saved.PrivateMetadata["note"])
+	})
+
+	t.Run("failed void keeps pending flag", func(t *testing.T) {
+		payment := &fakePayment{
+			payments: model.PaymentSlice{{ID: model_helper.NewId(), ChargeStatus: model.PaymentChargeStatusNotCharged}},
+			failVoid: true,
+		}
+		s, orderStore := newTestOrderService(payment)
+
+		appErr := s.voidExpiredOrderPayments(newExpiredOrder(), nil)
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.order.void_expired_order_payment.app_error", appErr.Id)
+		orderStore.AssertNotCalled(t, "BulkUpsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("retry does not void payments twice", func(t *testing.T) {
+		payment := &fakePayment{
+			payments: model.PaymentSlice{
+				{ID: model_helper.NewId(), ChargeStatus: model.PaymentChargeStatusNotCharged},
+				{ID: model_helper.NewId(), ChargeStatus: model.PaymentChargeStatusNotCharged},
+			},
+		}
+		s, _ := newTestOrderService(payment)
+
+		// first payment is voided, then the gateway fails on the second one
+		order := newExpiredOrder()
+		payment.failVoid = true
+		payment.payments[0].ChargeStatus = model.PaymentChargeStatusCancelled
+		payment.voided = []string{payment.payments[0].ID}
+		require.NotNil(t, s.voidExpiredOrderPayments(order, nil))
+
+		payment.failVoid = false
+		require.Nil(t, s.voidExpiredOrderPayments(order, nil))
+		require.Equal(t, []string{payment.payments[0].ID, payment.payments[1].ID}, payment.voided)
+	})
+}
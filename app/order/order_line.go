@@ -9,13 +9,15 @@ import (
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
 	"github.com/volatiletech/sqlboiler/v4/boil"
-	"gorm.io/gorm"
 )
 
 // UpsertOrderLine depends on given orderLine's Id property to decide update order save it
 func (a *ServiceOrder) UpsertOrderLine(transaction boil.ContextTransactor, orderLine *model.OrderLine) (*model.OrderLine, *model_helper.AppError) {
-	orderLine, err := a.srv.Store.OrderLine().Upsert(transaction, orderLine)
+	orderLine, err := a.srv.Store.OrderLine().Upsert(transaction, *orderLine)
 	if err != nil {
+		if appErr, ok := err.(*model_helper.AppError); ok {
+			return nil, appErr
+		}
 		status := http.StatusInternalServerError
 		if _, ok := err.(*store.ErrNotFound); ok { // this not found error is caused by Get method
 			status = http.StatusNotFound
@@ -27,8 +29,8 @@ func (a *ServiceOrder) UpsertOrderLine(transaction boil.ContextTransactor, order
 }
 
 // DeleteOrderLines perform bulk delete given order lines
-func (a *ServiceOrder) DeleteOrderLines(tx *gorm.DB, orderLineIDs []string) *model_helper.AppError {
-	err := a.srv.Store.OrderLine().BulkDelete(tx, orderLineIDs)
+func (a *ServiceOrder) DeleteOrderLines(tx boil.ContextTransactor, orderLineIDs []string) *model_helper.AppError {
+	err := a.srv.Store.OrderLine().Delete(tx, orderLineIDs)
 	if err != nil {
 		return model_helper.NewAppError("DeleteOrderLines", "app.order.error_deleting_order_lines.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -37,7 +39,7 @@ func (a *ServiceOrder) DeleteOrderLines(tx *gorm.DB, orderLineIDs []string) *mod
 }
 
 // OrderLinesByOption returns a list of order lines by given option
-func (a *ServiceOrder) OrderLinesByOption(option *model.OrderLineFilterOption) (model.OrderLineSlice, *model_helper.AppError) {
+func (a *ServiceOrder) OrderLinesByOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, *model_helper.AppError) {
 	orderLines, err := a.srv.Store.OrderLine().FilterbyOption(option)
 	var (
 		statusCode int
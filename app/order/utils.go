@@ -1,6 +1,7 @@
 package order
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"sync"
@@ -18,6 +19,7 @@ import (
 	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/modules/util"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"gorm.io/gorm"
 )
 
@@ -244,109 +246,127 @@ func (a *ServiceOrder) ReCalculateOrderWeight(transaction boil.ContextTransactor
 	return appErr
 }
 
-func (a *ServiceOrder) UpdateTaxesForOrderLine(line model.OrderLine, order model.Order, manager interfaces.PluginManagerInterface, taxIncluded bool) *model_helper.AppError {
-	variant := line.ProductVariant
+// UpdateTaxesForOrderLine recalculates prices and tax rate of given line through the plugin manager.
+// Lines whose variant has been deleted keep their prices.
+func (a *ServiceOrder) UpdateTaxesForOrderLine(line *model.OrderLine, order model.Order, manager interfaces.PluginManagerInterface, taxIncluded bool) *model_helper.AppError {
+	if line.VariantID.IsNil() {
+		return nil
+	}
+
+	variant := line.R.GetVariant()
 	if variant == nil {
 		var appErr *model_helper.AppError
-		variant, appErr = a.srv.ProductService().ProductVariantById(*line.ProductVariantID)
+		variant, appErr = a.srv.Product.ProductVariantById(*line.VariantID.String)
 		if appErr != nil {
 			return appErr
 		}
 	}
 
-	product, appErr := a.srv.ProductService().ProductById(variant.ProductID)
+	product, appErr := a.srv.Product.ProductById(variant.ProductID)
 	if appErr != nil {
 		return appErr
 	}
 
-	line.PopulateNonDbFields() // this is needed
-
-	linePrice := line.UnitPrice.Gross
+	linePrice := line.UnitPriceGrossAmount
 	if !taxIncluded {
-		linePrice = line.UnitPrice.Net
+		linePrice = line.UnitPriceNetAmount
 	}
+	line.UnitPriceNetAmount = linePrice
+	line.UnitPriceGrossAmount = linePrice
 
-	line.UnitPrice = &goprices.TaxedMoney{
-		Net:      linePrice,
-		Gross:    linePrice,
-		Currency: line.Currency,
-	}
-
-	unitPrice, appErr := manager.CalculateOrderLineUnit(order, line, *variant, *product)
+	unitPrice, appErr := manager.CalculateOrderLineUnit(order, *line, *variant, *product)
 	if appErr != nil {
 		return appErr
 	}
 
-	totalPrice, appErr := manager.CalculateOrderlineTotal(order, line, *variant, *product)
+	totalPrice, appErr := manager.CalculateOrderlineTotal(order, *line, *variant, *product)
 	if appErr != nil {
 		return appErr
 	}
 
-	line.UnitPrice = unitPrice
-	line.TotalPrice = totalPrice
+	model_helper.OrderLineSetUnitPrice(line, *unitPrice)
+	model_helper.OrderLineSetTotalPrice(line, *totalPrice)
 
-	line.UnDiscountedUnitPrice, _ = line.UnitPrice.Add(line.UnitDiscount)
-	line.UnDiscountedTotalPrice = totalPrice
-	if line.UnitDiscount != nil && !line.UnitDiscount.Amount.Equal(decimal.Zero) {
-		line.UnDiscountedTotalPrice = line.UnDiscountedUnitPrice.Mul(float64(line.Quantity))
-	}
+	quantity := decimal.NewFromInt(int64(line.Quantity))
+	line.UndiscountedUnitPriceNetAmount = line.UnitPriceNetAmount.Add(line.UnitDiscountAmount)
+	line.UndiscountedUnitPriceGrossAmount = line.UnitPriceGrossAmount.Add(line.UnitDiscountAmount)
+	line.UndiscountedTotalPriceNetAmount = line.UndiscountedUnitPriceNetAmount.Mul(quantity)
+	line.UndiscountedTotalPriceGrossAmount = line.UndiscountedUnitPriceGrossAmount.Mul(quantity)
 
-	unitPriceTax := unitPrice.Tax()
-	if !unitPriceTax.Amount.Equal(decimal.Zero) && !unitPrice.Net.Amount.Equal(decimal.Zero) {
-		line.TaxRate, appErr = manager.GetOrderLineTaxRate(order, *product, *variant, nil, *unitPrice)
+	if !line.UnitPriceNetAmount.IsZero() && !line.UnitPriceGrossAmount.Equal(line.UnitPriceNetAmount) {
+		taxRate, appErr := manager.GetOrderLineTaxRate(order, *product, *variant, nil, *unitPrice)
 		if appErr != nil {
 			return appErr
 		}
+		if taxRate != nil {
+			line.TaxRate = model_types.NewNullDecimal(*taxRate)
+		}
 	}
 
 	return nil
 }
 
-func (a *ServiceOrder) UpdateTaxesForOrderLines(lines model.OrderLineSlice, order model.Order, manager interfaces.PluginManagerInterface, taxIncludeed bool) *model_helper.AppError {
-	for _, line := range lines.FilterNils() {
-		appErr := a.UpdateTaxesForOrderLine(*line, order, manager, taxIncludeed)
+func (a *ServiceOrder) UpdateTaxesForOrderLines(transaction boil.ContextTransactor, lines model.OrderLineSlice, order model.Order, manager interfaces.PluginManagerInterface, taxIncludeed bool) *model_helper.AppError {
+	lines = lines.FilterNils()
+	for _, line := range lines {
+		appErr := a.UpdateTaxesForOrderLine(line, order, manager, taxIncludeed)
 		if appErr != nil {
 			return appErr
 		}
 	}
 
-	_, appErr := a.BulkUpsertOrderLines(nil, lines)
+	_, appErr := a.BulkUpsertOrderLines(transaction, lines)
 	return appErr
 }
 
 // UpdateOrderPrices Update prices in order with given discounts and proper taxes.
-func (a *ServiceOrder) UpdateOrderPrices(tx *gorm.DB, order model.Order, manager interfaces.PluginManagerInterface, taxIncluded bool) *model_helper.AppError {
-	lines, appErr := a.OrderLinesByOption(&model.OrderLineFilterOption{
-		Conditions: squirrel.Eq{model.OrderLineTableName + ".OrderID": order.Id},
+func (a *ServiceOrder) UpdateOrderPrices(tx boil.ContextTransactor, order model.Order, manager interfaces.PluginManagerInterface, taxIncluded bool) *model_helper.AppError {
+	lines, appErr := a.OrderLinesByOption(model_helper.OrderLineFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.OrderLineWhere.OrderID.EQ(order.ID),
+			qm.Load(model.OrderLineRels.Variant),
+		),
 	})
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return appErr
+	}
+
+	appErr = a.UpdateTaxesForOrderLines(tx, lines, order, manager, taxIncluded)
 	if appErr != nil {
 		return appErr
 	}
 
-	appErr = a.UpdateTaxesForOrderLines(lines, order, manager, taxIncluded)
+	appErr = a.updateOrderShippingPrice(tx, &order, manager)
 	if appErr != nil {
 		return appErr
 	}
 
-	if order.ShippingMethodID != nil && model_helper.IsValidId(*order.ShippingMethodID) {
-		shippingPrice, appErr := manager.CalculateOrderShipping(order)
-		if appErr != nil {
-			return appErr
-		}
+	return a.RecalculateOrder(tx, &order, map[string]any{})
+}
 
-		order.ShippingPrice = shippingPrice
-		order.ShippingTaxRate, appErr = manager.GetOrderShippingTaxRate(order, *shippingPrice)
-		if appErr != nil {
-			return appErr
-		}
+// updateOrderShippingPrice recalculates shipping price and shipping tax rate of given order through the plugin manager
+// and saves the order. Orders without shipping method are left untouched.
+func (a *ServiceOrder) updateOrderShippingPrice(tx boil.ContextTransactor, order *model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	if order.ShippingMethodID.IsNil() {
+		return nil
+	}
 
-		_, appErr = a.UpsertOrder(tx, &order)
-		if appErr != nil {
-			return appErr
-		}
+	shippingPrice, appErr := manager.CalculateOrderShipping(*order)
+	if appErr != nil {
+		return appErr
 	}
+	model_helper.OrderSetShippingPrice(order, *shippingPrice)
 
-	return a.RecalculateOrder(tx, &order, map[string]any{})
+	shippingTaxRate, appErr := manager.GetOrderShippingTaxRate(*order, *shippingPrice)
+	if appErr != nil {
+		return appErr
+	}
+	if shippingTaxRate != nil {
+		order.ShippingTaxRate = *shippingTaxRate
+	}
+
+	_, appErr = a.UpsertOrder(tx, order)
+	return appErr
 }
 
 func (s *ServiceOrder) GetValidCollectionPointsForOrder(lines model.OrderLineSlice, addressCountryCode model.CountryCode) (model.WarehouseSlice, *model_helper.AppError) {
@@ -674,13 +694,17 @@ func (a *ServiceOrder) UpdateOrderStatus(transaction boil.ContextTransactor, ord
 
 // AddVariantToOrder Add total_quantity of variant to order.
 //
-// Returns an order line the variant was added to.
-func (s *ServiceOrder) AddVariantToOrder(order model.Order, variant model.ProductVariant, quantity int, user *model.User, _ any, manager interfaces.PluginManagerInterface, discounts []*model_helper.DiscountInfo, allocateStock bool) (*model.OrderLine, *model_helper.InsufficientStock, *model_helper.AppError) {
-	transaction := s.srv.Store.GetMaster().Begin()
-	if transaction.Error != nil {
-		return nil, nil, model_helper.NewAppError("AddVariantToOrder", model_helper.ErrorCreatingTransactionErrorID, nil, transaction.Error.Error(), http.StatusInternalServerError)
+// Returns an order line the variant was added to. If transaction is nil, a new one is started and committed here.
+func (s *ServiceOrder) AddVariantToOrder(transaction boil.ContextTransactor, order model.Order, variant model.ProductVariant, quantity int, user *model.User, _ any, manager interfaces.PluginManagerInterface, discounts []*model_helper.DiscountInfo, allocateStock bool) (*model.OrderLine, *model_helper.InsufficientStock, *model_helper.AppError) {
+	ownTransaction := transaction == nil
+	if ownTransaction {
+		tx, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+		if err != nil {
+			return nil, nil, model_helper.NewAppError("AddVariantToOrder", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+		}
+		defer s.srv.Store.FinalizeTransaction(tx)
+		transaction = tx
 	}
-	defer s.srv.Store.FinalizeTransaction(transaction)
 
 	channel := order.Channel
 	if channel == nil {
@@ -851,6 +875,7 @@ func (s *ServiceOrder) AddVariantToOrder(order model.Order, variant model.Produc
 
 	if allocateStock {
 		insufficientStockErr, appErr := s.srv.WarehouseService().IncreaseAllocations(
+			transaction,
 			[]*model.OrderLineData{
 				{
 					Line:        *orderLine,
@@ -867,9 +892,10 @@ func (s *ServiceOrder) AddVariantToOrder(order model.Order, variant model.Produc
 		}
 	}
 
-	// commit transaction
-	if err := transaction.Commit().Error; err != nil {
-		return nil, nil, model_helper.NewAppError("AddVariantToOrder", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	if ownTransaction {
+		if err := transaction.Commit(); err != nil {
+			return nil, nil, model_helper.NewAppError("AddVariantToOrder", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
 
 	return orderLine, nil, nil
@@ -948,57 +974,50 @@ func (s *ServiceOrder) UpdateGiftcardBalance(giftCard *model.GiftCard, totalPric
 	}
 }
 
-func (a *ServiceOrder) updateAllocationsForLine(lineInfo *model.OrderLineData, oldQuantity int, newQuantity int, channelSlug string, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceOrder) updateAllocationsForLine(transaction boil.ContextTransactor, lineInfo *model_helper.OrderLineData, oldQuantity int, newQuantity int, channelSlug string, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	if oldQuantity == newQuantity {
 		return nil, nil
 	}
 
-	orderLinesWithTrackInventory := a.srv.WarehouseService().GetOrderLinesWithTrackInventory([]*model.OrderLineData{lineInfo})
+	orderLinesWithTrackInventory := a.srv.Warehouse.GetOrderLinesWithTrackInventory([]*model_helper.OrderLineData{lineInfo})
 	if len(orderLinesWithTrackInventory) == 0 {
 		return nil, nil
 	}
 
 	if oldQuantity < newQuantity {
 		lineInfo.Quantity = newQuantity - oldQuantity
-		return a.srv.WarehouseService().IncreaseAllocations([]*model.OrderLineData{lineInfo}, channelSlug, manager)
-	} else {
-		lineInfo.Quantity = oldQuantity - newQuantity
-		return a.srv.WarehouseService().DecreaseAllocations([]*model.OrderLineData{lineInfo}, manager)
+		return a.srv.Warehouse.IncreaseAllocations(transaction, []*model_helper.OrderLineData{lineInfo}, channelSlug, manager)
 	}
+	lineInfo.Quantity = oldQuantity - newQuantity
+	return a.srv.Warehouse.DecreaseAllocations(transaction, []*model_helper.OrderLineData{lineInfo}, manager)
 }
 
 // ChangeOrderLineQuantity Change the quantity of ordered items in a order line.
 //
 // NOTE: userID can be empty
-func (a *ServiceOrder) ChangeOrderLineQuantity(transaction boil.ContextTransactor, userID string, _ any, lineInfo *model.OrderLineData, oldQuantity int, newQuantity int, channelSlug string, manager interfaces.PluginManagerInterface, sendEvent bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceOrder) ChangeOrderLineQuantity(transaction boil.ContextTransactor, userID string, _ any, lineInfo *model_helper.OrderLineData, oldQuantity int, newQuantity int, channelSlug string, manager interfaces.PluginManagerInterface, sendEvent bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	orderLine := lineInfo.Line
-	// NOTE: this must be called
-	orderLine.PopulateNonDbFields()
 
 	if newQuantity != 0 {
-		order, appErr := a.OrderById(lineInfo.Line.OrderID)
+		order, appErr := a.OrderById(orderLine.OrderID)
 		if appErr != nil {
 			return nil, appErr
 		}
 
-		if order.IsUnconfirmed() {
-			insufficientStock, appErr := a.updateAllocationsForLine(lineInfo, oldQuantity, newQuantity, channelSlug, manager)
+		if model_helper.OrderIsUnConfirmed(*order) {
+			insufficientStock, appErr := a.updateAllocationsForLine(transaction, lineInfo, oldQuantity, newQuantity, channelSlug, manager)
 			if appErr != nil || insufficientStock != nil {
 				return insufficientStock, appErr
 			}
 		}
 
 		orderLine.Quantity = newQuantity
+		quantity := decimal.NewFromInt(int64(newQuantity))
 
-		totalPriceNetAmount := orderLine.UnitPriceNetAmount.Mul(decimal.NewFromInt(int64(orderLine.Quantity)))
-		totalPriceGrossAmount := orderLine.UnitPriceGrossAmount.Mul(decimal.NewFromInt(int64(orderLine.Quantity)))
-		orderLine.TotalPriceNetAmount = model_helper.GetPointerOfValue(totalPriceNetAmount.Round(3))
-		orderLine.TotalPriceGrossAmount = model_helper.GetPointerOfValue(totalPriceGrossAmount.Round(3))
-
-		unDiscountedTotalPriceNetAmount := orderLine.UnDiscountedUnitPriceNetAmount.Mul(decimal.NewFromInt32(int32(orderLine.Quantity)))
-		unDiscountedTotalpriceGrossAmount := orderLine.UnDiscountedUnitPriceGrossAmount.Mul(decimal.NewFromInt32(int32(orderLine.Quantity)))
-		orderLine.UnDiscountedTotalPriceNetAmount = model_helper.GetPointerOfValue(unDiscountedTotalPriceNetAmount.Round(3))
-		orderLine.UnDiscountedTotalPriceGrossAmount = model_helper.GetPointerOfValue(unDiscountedTotalpriceGrossAmount.Round(3))
+		orderLine.TotalPriceNetAmount = model_types.NewNullDecimal(orderLine.UnitPriceNetAmount.Mul(quantity).Round(3))
+		orderLine.TotalPriceGrossAmount = model_types.NewNullDecimal(orderLine.UnitPriceGrossAmount.Mul(quantity).Round(3))
+		orderLine.UndiscountedTotalPriceNetAmount = orderLine.UndiscountedUnitPriceNetAmount.Mul(quantity).Round(3)
+		orderLine.UndiscountedTotalPriceGrossAmount = orderLine.UndiscountedUnitPriceGrossAmount.Mul(quantity).Round(3)
 
 		_, appErr = a.UpsertOrderLine(transaction, &orderLine)
 		if appErr != nil {
@@ -1011,10 +1030,8 @@ func (a *ServiceOrder) ChangeOrderLineQuantity(transaction boil.ContextTransacto
 		}
 	}
 
-	quantityDiff := oldQuantity - newQuantity
-
 	if sendEvent {
-		appErr := a.CreateOrderEvent(transaction, &orderLine, userID, quantityDiff)
+		appErr := a.CreateOrderEvent(transaction, &orderLine, userID, oldQuantity-newQuantity)
 		if appErr != nil {
 			return nil, appErr
 		}
@@ -1023,62 +1040,47 @@ func (a *ServiceOrder) ChangeOrderLineQuantity(transaction boil.ContextTransacto
 	return nil, nil
 }
 
+// CreateOrderEvent records a removed_products event when quantityDiff is positive,
+// an added_products event when it is negative and does nothing otherwise.
 func (a *ServiceOrder) CreateOrderEvent(transaction boil.ContextTransactor, orderLine *model.OrderLine, userID string, quantityDiff int) *model_helper.AppError {
-	var appErr *model_helper.AppError
+	if quantityDiff == 0 {
+		return nil
+	}
 
-	var savingUserID *string
+	event := model.OrderEvent{
+		OrderID: orderLine.OrderID,
+		Type:    model.OrderEventTypeRemovedProducts,
+	}
 	if userID != "" {
-		savingUserID = &userID
-	}
-
-	if quantityDiff > 0 {
-		_, appErr = a.CommonCreateOrderEvent(transaction, &model.OrderEventOption{
-			OrderID: orderLine.OrderID,
-			UserID:  savingUserID,
-			Type:    model.ORDER_EVENT_TYPE_REMOVED_PRODUCTS,
-			Parameters: model_types.JSONString{
-				"lines": a.LinesPerQuantityToLineObjectList([]*model.QuantityOrderLine{
-					{
-						Quantity:  quantityDiff,
-						OrderLine: orderLine,
-					},
-				}),
-			},
-		})
-	} else if quantityDiff < 0 {
-		_, appErr = a.CommonCreateOrderEvent(transaction, &model.OrderEventOption{
-			OrderID: orderLine.OrderID,
-			UserID:  savingUserID,
-			Type:    model.ORDER_EVENT_TYPE_ADDED_PRODUCTS,
-			Parameters: model_types.JSONString{
-				"lines": a.LinesPerQuantityToLineObjectList([]*model.QuantityOrderLine{
-					{
-						Quantity:  quantityDiff * -1,
-						OrderLine: orderLine,
-					},
-				}),
-			},
-		})
+		event.UserID = model_types.NewNullString(userID)
+	}
+	if quantityDiff < 0 {
+		event.Type = model.OrderEventTypeAddedProducts
+		quantityDiff = -quantityDiff
+	}
+	event.Parameters = model_types.JSONString{
+		"lines": []model_types.JSONString{a.LinePerQuantityToLineObject(quantityDiff, orderLine)},
 	}
 
+	_, appErr := a.CommonCreateOrderEvent(transaction, event)
 	return appErr
 }
 
 // DeleteOrderLine Delete an order line from an order.
-func (a *ServiceOrder) DeleteOrderLine(tx *gorm.DB, lineInfo *model.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceOrder) DeleteOrderLine(tx boil.ContextTransactor, lineInfo *model_helper.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	order, appErr := a.OrderById(lineInfo.Line.OrderID)
 	if appErr != nil {
 		return nil, appErr
 	}
 
-	if order.IsUnconfirmed() {
-		insufficientErr, appErr := a.srv.WarehouseService().DecreaseAllocations([]*model.OrderLineData{lineInfo}, manager)
+	if model_helper.OrderIsUnConfirmed(*order) {
+		insufficientErr, appErr := a.srv.Warehouse.DecreaseAllocations(tx, []*model_helper.OrderLineData{lineInfo}, manager)
 		if appErr != nil || insufficientErr != nil {
 			return insufficientErr, appErr
 		}
 	}
 
-	return nil, a.DeleteOrderLines(tx, []string{lineInfo.Line.Id})
+	return nil, a.DeleteOrderLines(tx, []string{lineInfo.Line.ID})
 }
 
 // RestockOrderLines Return ordered products to corresponding stocks
@@ -1181,7 +1183,7 @@ func (a *ServiceOrder) RestockOrderLines(order *model.Order, manager interfaces.
 	wg.Wait()
 
 	if len(dellocatingStockLines) > 0 {
-		_, appError = a.srv.WarehouseService().DeallocateStock(dellocatingStockLines, manager)
+		_, appError = a.srv.WarehouseService().DeallocateStock(nil, dellocatingStockLines, manager)
 	}
 
 	return appError
@@ -1576,7 +1578,7 @@ func (a *ServiceOrder) UpdateDiscountForOrderLine(tx *gorm.DB, orderLine model.O
 		return appErr
 	}
 
-	appErr = a.UpdateTaxesForOrderLine(orderLine, order, manager, taxIncluded)
+	appErr = a.UpdateTaxesForOrderLine(&orderLine, order, manager, taxIncluded)
 	if appErr != nil {
 		return appErr
 	}
@@ -1600,7 +1602,7 @@ func (a *ServiceOrder) RemoveDiscountFromOrderLine(transaction boil.ContextTrans
 		return appErr
 	}
 
-	appErr = a.UpdateTaxesForOrderLine(orderLine, order, manager, taxIncluded)
+	appErr = a.UpdateTaxesForOrderLine(&orderLine, order, manager, taxIncluded)
 	if appErr != nil {
 		return appErr
 	}
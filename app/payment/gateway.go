@@ -497,13 +497,17 @@ func (a *ServicePayment) ListGateways(
 }
 
 func (a *ServicePayment) fetchGatewayResponse(paymentFunc PaymentMethod, gateway string, paymentData model_helper.PaymentData, channelID string) (res *model_helper.GatewayResponse, errMsg string) {
-	res, _ = paymentFunc(gateway, paymentData, channelID)
+	res, err := paymentFunc(gateway, paymentData, channelID)
 	gatewayErr := a.ValidateGatewayResponse(res)
 	if gatewayErr != nil {
 		a.srv.Log.Warn("Gateway response validation failed!")
 		errMsg = "Ops! Something went wrong."
 	}
 
+	if a.srv.Metrics != nil && (err != nil || gatewayErr != nil || res.Error != "") {
+		a.srv.Metrics.IncrementPaymentGatewayError(gateway)
+	}
+
 	return res, errMsg
 }
 
@@ -621,6 +621,10 @@ func (m *PluginManager) CustomerUpdated(customer model.User) (any, *model_helper
 }
 
 func (m *PluginManager) ProductCreated(product model.Product) (any, *model_helper.AppError) {
+	if appErr := m.Srv.Product.IndexProducts([]string{product.ID}); appErr != nil {
+		slog.Error("Failed to index created product", slog.String("product_id", product.ID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var (
@@ -643,6 +647,10 @@ func (m *PluginManager) ProductCreated(product model.Product) (any, *model_helpe
 }
 
 func (m *PluginManager) ProductUpdated(product model.Product) (any, *model_helper.AppError) {
+	if appErr := m.Srv.Product.IndexProducts([]string{product.ID}); appErr != nil {
+		slog.Error("Failed to index updated product", slog.String("product_id", product.ID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var (
@@ -665,6 +673,8 @@ func (m *PluginManager) ProductUpdated(product model.Product) (any, *model_helpe
 }
 
 func (m *PluginManager) ProductDeleted(product model.Product, variants []int) (any, *model_helper.AppError) {
+	m.Srv.Product.DeleteProductsFromSearchIndexes([]string{product.ID})
+
 	var defaultValue any
 
 	var (
@@ -687,6 +697,10 @@ func (m *PluginManager) ProductDeleted(product model.Product, variants []int) (a
 }
 
 func (m *PluginManager) ProductVariantCreated(variant model.ProductVariant) (any, *model_helper.AppError) {
+	if appErr := m.Srv.Product.IndexProducts([]string{variant.ProductID}); appErr != nil {
+		slog.Error("Failed to index product of created variant", slog.String("product_id", variant.ProductID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var (
@@ -709,6 +723,10 @@ func (m *PluginManager) ProductVariantCreated(variant model.ProductVariant) (any
 }
 
 func (m *PluginManager) ProductVariantUpdated(variant model.ProductVariant) (any, *model_helper.AppError) {
+	if appErr := m.Srv.Product.IndexProducts([]string{variant.ProductID}); appErr != nil {
+		slog.Error("Failed to index product of updated variant", slog.String("product_id", variant.ProductID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var (
@@ -731,6 +749,10 @@ func (m *PluginManager) ProductVariantUpdated(variant model.ProductVariant) (any
 }
 
 func (m *PluginManager) ProductVariantDeleted(variant model.ProductVariant) (any, *model_helper.AppError) {
+	if appErr := m.Srv.Product.IndexProducts([]string{variant.ProductID}); appErr != nil {
+		slog.Error("Failed to index product of deleted variant", slog.String("product_id", variant.ProductID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var (
@@ -753,6 +775,10 @@ func (m *PluginManager) ProductVariantDeleted(variant model.ProductVariant) (any
 }
 
 func (m *PluginManager) ProductVariantOutOfStock(stock model.Stock) *model_helper.AppError {
+	if appErr := m.Srv.Product.IndexProductsOfVariants([]string{stock.ProductVariantID}); appErr != nil {
+		slog.Error("Failed to index product of out of stock variant", slog.String("product_variant_id", stock.ProductVariantID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var appErr *model_helper.AppError
@@ -771,6 +797,10 @@ func (m *PluginManager) ProductVariantOutOfStock(stock model.Stock) *model_helpe
 }
 
 func (m *PluginManager) ProductVariantBackInStock(stock model.Stock) *model_helper.AppError {
+	if appErr := m.Srv.Product.IndexProductsOfVariants([]string{stock.ProductVariantID}); appErr != nil {
+		slog.Error("Failed to index product of back in stock variant", slog.String("product_variant_id", stock.ProductVariantID), slog.Err(appErr))
+	}
+
 	var defaultValue any
 
 	var appErr *model_helper.AppError
@@ -0,0 +1,138 @@
+package product
+
+import (
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model_helper"
+)
+
+const (
+	DefaultProductFeedLimit = 20
+	MaxProductFeedLimit     = 100
+)
+
+// weights of signals ranking products in feeds
+var (
+	feedFavoriteCategoryWeight    = 1.0
+	feedOrderedCategoryWeight     = 0.6
+	feedWishlistedCategoryWeight  = 0.4
+	feedInteractionSaturation     = 5.0 // number of interactions with a category giving it full weight
+	feedRecencyWeight             = 0.3
+	feedColdStartRecencyWeight    = 0.5
+	feedColdStartPopularityWeight = 0.5
+	feedPopularityWindow          = int64(30 * 24 * 60 * 60 * 1000) // orders placed in last 30 days count for popularity
+)
+
+// ProductFeedForUser returns a page of products visible in given channel, ranked by interest of the user.
+//
+// Interest is derived from the user's favourite categories and categories of products the user ordered or
+// added to wishlists, newer products rank higher. Users without known interests (including anonymous users,
+// whose userID is empty) get products ranked by recent popularity and recency.
+//
+// cursor is NextCursor of the previous page, empty for the first page. Next pages reuse the reference time and
+// category weights of the first page kept in the cursor, so items are neither repeated nor skipped.
+func (s *ServiceProduct) ProductFeedForUser(userID, channelIdOrSlug, cursor string, limit int) (*model_helper.ProductFeed, *model_helper.AppError) {
+	if channelIdOrSlug == "" {
+		return nil, model_helper.NewAppError("ProductFeedForUser", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "channelIdOrSlug"}, "", http.StatusBadRequest)
+	}
+	if limit <= 0 {
+		limit = DefaultProductFeedLimit
+	}
+	limit = min(limit, MaxProductFeedLimit)
+
+	options := model_helper.ProductFeedOptions{
+		ChannelIdOrSlug: channelIdOrSlug,
+		Now:             model_helper.GetMillis(),
+		Limit:           limit + 1, // one more item tells if there is a next page
+	}
+	if cursor != "" {
+		feedCursor, err := model_helper.DecodeProductFeedCursor(cursor)
+		if err != nil {
+			return nil, model_helper.NewAppError("ProductFeedForUser", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "cursor"}, err.Error(), http.StatusBadRequest)
+		}
+		options.Cursor = feedCursor
+		options.Now = feedCursor.Now
+		options.CategoryWeights = feedCursor.CategoryWeights
+	} else if userID != "" {
+		favoriteCategoryIDs, appErr := s.srv.Account.FavoriteCategoryIDsOfUser(userID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		interactions, err := s.srv.Store.Product().CategoryInteractionsOfUser(userID)
+		if err != nil {
+			return nil, model_helper.NewAppError("ProductFeedForUser", "app.product.error_counting_category_interactions.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		options.CategoryWeights = feedCategoryWeights(favoriteCategoryIDs, interactions)
+	}
+
+	coldStart := len(options.CategoryWeights) == 0
+	if coldStart {
+		options.RecencyWeight = feedColdStartRecencyWeight
+		options.PopularityWeight = feedColdStartPopularityWeight
+		options.PopularitySince = options.Now - feedPopularityWindow
+	} else {
+		options.RecencyWeight = feedRecencyWeight
+	}
+
+	items, err := s.srv.Store.Product().Feed(options)
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductFeedForUser", "app.product.error_finding_product_feed.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	feed := &model_helper.ProductFeed{
+		Items:     items,
+		ColdStart: coldStart,
+	}
+	if len(items) > limit {
+		feed.Items = items[:limit]
+		last := feed.Items[limit-1]
+		feed.NextCursor = (&model_helper.ProductFeedCursor{
+			Rank:            last.FeedRank,
+			ProductID:       last.ID,
+			Now:             options.Now,
+			CategoryWeights: options.CategoryWeights,
+		}).Encode()
+	}
+
+	return feed, nil
+}
+
+// feedCategoryWeights returns affinity of a user to categories, keyed by category ids.
+// Implicit signals grow with the number of interactions until feedInteractionSaturation.
+// Only the strongest model_helper.MaxProductFeedCursorCategories categories are kept, so they fit in cursors
+func feedCategoryWeights(favoriteCategoryIDs []string, interactions []*model_helper.ProductCategoryInteraction) map[string]float64 {
+	res := map[string]float64{}
+
+	for _, categoryID := range favoriteCategoryIDs {
+		res[categoryID] += feedFavoriteCategoryWeight
+	}
+	for _, interaction := range interactions {
+		if interaction.CategoryID == "" {
+			continue
+		}
+		if interaction.OrderedCount > 0 {
+			res[interaction.CategoryID] += feedOrderedCategoryWeight * math.Min(float64(interaction.OrderedCount)/feedInteractionSaturation, 1)
+		}
+		if interaction.WishlistedCount > 0 {
+			res[interaction.CategoryID] += feedWishlistedCategoryWeight * math.Min(float64(interaction.WishlistedCount)/feedInteractionSaturation, 1)
+		}
+	}
+
+	if len(res) > model_helper.MaxProductFeedCursorCategories {
+		categoryIDs := lo.Keys(res)
+		sort.Slice(categoryIDs, func(i, j int) bool {
+			if res[categoryIDs[i]] != res[categoryIDs[j]] {
+				return res[categoryIDs[i]] > res[categoryIDs[j]]
+			}
+			return categoryIDs[i] < categoryIDs[j]
+		})
+		for _, categoryID := range categoryIDs[model_helper.MaxProductFeedCursorCategories:] {
+			delete(res, categoryID)
+		}
+	}
+
+	return res
+}
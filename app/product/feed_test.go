@@ -0,0 +1,183 @@
+package product
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testAccountService struct {
+	sub_app_iface.AccountService
+	favoriteCategoryIDs []string
+	calls               int
+}
+
+func (a *testAccountService) FavoriteCategoryIDsOfUser(string) ([]string, *model_helper.AppError) {
+	a.calls++
+	return a.favoriteCategoryIDs, nil
+}
+
+// feedTest serves feeds of ranked products, options of each Feed call are recorded
+type feedTest struct {
+	service  *ServiceProduct
+	account  *testAccountService
+	products *mocks.ProductStore
+	items    []*model_helper.ProductFeedItem
+	options  []model_helper.ProductFeedOptions
+}
+
+func newFeedTest(itemCount int) *feedTest {
+	ft := &feedTest{
+		account:  &testAccountService{},
+		products: &mocks.ProductStore{},
+	}
+	for i := range itemCount {
+		ft.items = append(ft.items, &model_helper.ProductFeedItem{
+			Product:  model.Product{ID: model_helper.NewId()},
+			FeedRank: int64(1000 - i),
+		})
+	}
+
+	ft.products.On("Feed", mock.Anything).Return(func(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error) {
+		ft.options = append(ft.options, options)
+		items := ft.items
+		if options.Cursor != nil {
+			for i, item := range items {
+				if item.ID == options.Cursor.ProductID {
+					items = items[i+1:]
+					break
+				}
+			}
+		}
+		return items[:min(len(items), options.Limit)], nil
+	})
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Product").Return(ft.products)
+	ft.service = &ServiceProduct{srv: &app.Server{Store: mockStore, Account: ft.account}}
+	return ft
+}
+
+func TestProductFeedForUser(t *testing.T) {
+	t.Run("anonymous users get a cold start feed", func(t *testing.T) {
+		ft := newFeedTest(3)
+
+		feed, appErr := ft.service.ProductFeedForUser("", "default", "", 0)
+		require.Nil(t, appErr)
+		require.True(t, feed.ColdStart)
+		require.Len(t, feed.Items, 3)
+		require.Empty(t, feed.NextCursor)
+
+		require.Len(t, ft.options, 1)
+		options := ft.options[0]
+		require.Equal(t, DefaultProductFeedLimit+1, options.Limit)
+		require.Empty(t, options.CategoryWeights)
+		require.Equal(t, feedColdStartPopularityWeight, options.PopularityWeight)
+		require.Equal(t, options.Now-feedPopularityWindow, options.PopularitySince)
+		require.Zero(t, ft.account.calls)
+	})
+
+	t.Run("next pages keep the reference time and weights of the first page", func(t *testing.T) {
+		ft := newFeedTest(5)
+		favoriteCategoryID, orderedCategoryID := model_helper.NewId(), model_helper.NewId()
+		ft.account.favoriteCategoryIDs = []string{favoriteCategoryID}
+		userID := model_helper.NewId()
+		ft.products.On("CategoryInteractionsOfUser", userID).Return([]*model_helper.ProductCategoryInteraction{
+			{CategoryID: orderedCategoryID, OrderedCount: 10},
+		}, nil)
+
+		feed, appErr := ft.service.ProductFeedForUser(userID, "default", "", 2)
+		require.Nil(t, appErr)
+		require.False(t, feed.ColdStart)
+		require.Equal(t, []string{ft.items[0].ID, ft.items[1].ID}, []string{feed.Items[0].ID, feed.Items[1].ID})
+
+		cursor, err := model_helper.DecodeProductFeedCursor(feed.NextCursor)
+		require.NoError(t, err)
+		require.Equal(t, ft.items[1].FeedRank, cursor.Rank)
+		require.Equal(t, ft.items[1].ID, cursor.ProductID)
+		require.Equal(t, ft.options[0].Now, cursor.Now)
+		require.Equal(t, map[string]float64{
+			favoriteCategoryID: feedFavoriteCategoryWeight,
+			orderedCategoryID:  feedOrderedCategoryWeight,
+		}, cursor.CategoryWeights)
+
+		// interests changing between pages don't reorder the feed
+		ft.account.favoriteCategoryIDs = nil
+
+		var seen []string
+		for _, item := range feed.Items {
+			seen = append(seen, item.ID)
+		}
+		for feed.NextCursor != "" {
+			feed, appErr = ft.service.ProductFeedForUser(userID, "default", feed.NextCursor, 2)
+			require.Nil(t, appErr)
+			for _, item := range feed.Items {
+				seen = append(seen, item.ID)
+			}
+		}
+
+		require.Equal(t, 1, ft.account.calls)
+		require.Len(t, ft.options, 3)
+		for _, options := range ft.options[1:] {
+			require.Equal(t, ft.options[0].Now, options.Now)
+			require.Equal(t, ft.options[0].CategoryWeights, options.CategoryWeights)
+			require.Equal(t, ft.options[0].RecencyWeight, options.RecencyWeight)
+		}
+		require.Equal(t, []string{ft.items[0].ID, ft.items[1].ID, ft.items[2].ID, ft.items[3].ID, ft.items[4].ID}, seen)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		ft := newFeedTest(1)
+
+		_, appErr := ft.service.ProductFeedForUser("", "", "", 0)
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		_, appErr = ft.service.ProductFeedForUser("", "default", "not a cursor", 0)
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		forged := (&model_helper.ProductFeedCursor{
+			Rank:            1,
+			ProductID:       model_helper.NewId(),
+			Now:             model_helper.GetMillis(),
+			CategoryWeights: map[string]float64{"not an id": 1},
+		}).Encode()
+		_, appErr = ft.service.ProductFeedForUser("", "default", forged, 0)
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		require.Empty(t, ft.options)
+	})
+}
+
+func TestFeedCategoryWeights(t *testing.T) {
+	categoryID := model_helper.NewId()
+
+	weights := feedCategoryWeights([]string{categoryID}, []*model_helper.ProductCategoryInteraction{
+		{CategoryID: categoryID, OrderedCount: 1, WishlistedCount: 100},
+		{CategoryID: "", OrderedCount: 100},
+	})
+	require.Len(t, weights, 1)
+	require.InDelta(t, feedFavoriteCategoryWeight+feedOrderedCategoryWeight/feedInteractionSaturation+feedWishlistedCategoryWeight, weights[categoryID], 1e-9)
+
+	// only the strongest categories are kept
+	favoriteCategoryIDs := make([]string, 0, model_helper.MaxProductFeedCursorCategories)
+	var interactions []*model_helper.ProductCategoryInteraction
+	for range model_helper.MaxProductFeedCursorCategories {
+		favoriteCategoryIDs = append(favoriteCategoryIDs, model_helper.NewId())
+		interactions = append(interactions, &model_helper.ProductCategoryInteraction{CategoryID: model_helper.NewId(), WishlistedCount: 1})
+	}
+
+	weights = feedCategoryWeights(favoriteCategoryIDs, interactions)
+	require.Len(t, weights, model_helper.MaxProductFeedCursorCategories)
+	for _, categoryID := range favoriteCategoryIDs {
+		require.Equal(t, feedFavoriteCategoryWeight, weights[categoryID])
+	}
+}
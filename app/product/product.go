@@ -125,7 +125,10 @@ func (a *ServiceProduct) GetVisibleToUserProducts(channelIdOrSlug string, userIs
 }
 
 func (s *ServiceProduct) FilterProductsAdvanced(options *model.ExportProductsFilterOptions, channelIdOrSlug string) (model.ProductSlice, *model_helper.AppError) {
-	productsQuery := s.srv.Store.Product().AdvancedFilterQueryBuilder(options)
+	productsQuery, err := s.srv.Store.Product().AdvancedFilterQueryBuilder(options)
+	if err != nil {
+		return nil, model_helper.NewAppError("FilterProductsAdvanced", "app.product.filter_advanced_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
 
 	if channelIdOrSlug != "" {
 		productsQuery = productsQuery.
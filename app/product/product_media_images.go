@@ -0,0 +1,222 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/sitename/sitename/app/imaging"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+const (
+	productMediaImageJpegQuality = 90
+	productMediaImageWebpQuality = 80
+)
+
+// UploadProductMediaImage stores given image as a new media of the product, along with its size variants
+// in the original format and in WebP. ppoi is the primary point of interest thumbnails are cropped around,
+// empty ppoi means the image center.
+//
+// The image is turned upright according to its EXIF orientation and re-encoded, which strips EXIF and other metadata.
+func (s *ServiceProduct) UploadProductMediaImage(productID string, data io.Reader, alt, ppoi string) (*model.ProductMedium, *model_helper.AppError) {
+	if !model_helper.IsValidId(productID) {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "productID"}, "", http.StatusBadRequest)
+	}
+	if ppoi == "" {
+		ppoi = model_helper.DefaultProductMediaPpoi
+	}
+	if _, _, err := model_helper.ParseProductMediaPpoi(ppoi); err != nil {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "ppoi"}, err.Error(), http.StatusBadRequest)
+	}
+
+	fileSettings := s.srv.Config().FileSettings
+	raw, err := io.ReadAll(io.LimitReader(data, *fileSettings.MaxFileSize+1))
+	if err != nil {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.error_reading_product_media_image.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	if int64(len(raw)) > *fileSettings.MaxFileSize {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.product_media_image_too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+	}
+
+	imgConfig, _, err := s.srv.File.ImageDecoder().DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.invalid_product_media_image.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	if int64(imgConfig.Width)*int64(imgConfig.Height) > *fileSettings.MaxImageResolution {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.product_media_image_too_large.app_error", nil, fmt.Sprintf("image resolution %dx%d exceeds the limit", imgConfig.Width, imgConfig.Height), http.StatusBadRequest)
+	}
+
+	img, ext, release, err := s.decodeProductMediaImage(raw)
+	if err != nil {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.invalid_product_media_image.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	defer release()
+
+	tx, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(tx)
+
+	// the media is saved first, so its id is known for paths of the files
+	medias, err := s.srv.Store.ProductMedia().Upsert(tx, model.ProductMediumSlice{{
+		ProductID: productID,
+		Alt:       alt,
+		Ppoi:      ppoi,
+		Type:      model.ProductMediaTypeIMAGE,
+	}})
+	if err != nil {
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.upsert_product_media.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	media := medias[0]
+
+	media.Image = model_helper.ProductMediaOriginalImagePath(productID, media.ID, ext)
+	if appErr := s.writeProductMediaImage(img, ext, media.Image); appErr != nil {
+		return nil, appErr
+	}
+	for _, size := range model_helper.ProductMediaImageSizes {
+		for _, format := range []model_helper.ProductMediaImageFormat{model_helper.ProductMediaImageFormatOriginal, model_helper.ProductMediaImageFormatWebp} {
+			if _, appErr := s.generateProductMediaImage(media, img, size, format); appErr != nil {
+				s.removeProductMediaImages(media)
+				return nil, appErr
+			}
+		}
+	}
+
+	_, err = s.srv.Store.ProductMedia().Upsert(tx, model.ProductMediumSlice{media})
+	if err != nil {
+		s.removeProductMediaImages(media)
+		return nil, model_helper.NewAppError("UploadProductMediaImage", "app.product.upsert_product_media.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.removeProductMediaImages(media)
+		return nil, model_helper.NewAppError("UploadProductMediaImage", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return media, nil
+}
+
+// ProductMediaThumbnail returns path of given size variant of an uploaded product image in the file store.
+// Missing variants, e.g of images uploaded before variants were introduced, are generated from the original image.
+// Empty path is returned for media which are not uploaded images, like videos and external images.
+func (s *ServiceProduct) ProductMediaThumbnail(media *model.ProductMedium, size model_helper.ProductMediaImageSize, format model_helper.ProductMediaImageFormat) (string, *model_helper.AppError) {
+	if !size.IsValid() {
+		return "", model_helper.NewAppError("ProductMediaThumbnail", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "size"}, "", http.StatusBadRequest)
+	}
+	if !format.IsValid() {
+		return "", model_helper.NewAppError("ProductMediaThumbnail", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "format"}, "", http.StatusBadRequest)
+	}
+	if media.Type != model.ProductMediaTypeIMAGE || media.Image == "" {
+		return "", nil
+	}
+
+	imagePath := model_helper.ProductMediaImagePath(media.ProductID, media.ID, size, productMediaImageExtension(media, format))
+	exists, appErr := s.srv.File.FileExists(imagePath)
+	if appErr != nil {
+		return "", appErr
+	}
+	if exists {
+		return imagePath, nil
+	}
+
+	raw, appErr := s.srv.File.ReadFile(media.Image)
+	if appErr != nil {
+		return "", appErr
+	}
+	img, _, release, err := s.decodeProductMediaImage(raw)
+	if err != nil {
+		return "", model_helper.NewAppError("ProductMediaThumbnail", "app.product.invalid_product_media_image.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer release()
+
+	return s.generateProductMediaImage(media, img, size, format)
+}
+
+// decodeProductMediaImage decodes given image and turns it upright according to its EXIF orientation.
+// Extension of the format the image should be stored in is returned, jpg for jpeg images and png for others.
+func (s *ServiceProduct) decodeProductMediaImage(raw []byte) (image.Image, string, func(), error) {
+	img, format, release, err := s.srv.File.ImageDecoder().DecodeMemBounded(bytes.NewReader(raw))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	orientation, err := imaging.GetImageOrientation(bytes.NewReader(raw))
+	if err != nil {
+		slog.Debug("GetImageOrientation failed", slog.Err(err))
+	}
+	img = imaging.MakeImageUpright(img, orientation)
+
+	if format == "jpeg" {
+		return img, "jpg", release, nil
+	}
+	return img, "png", release, nil
+}
+
+// generateProductMediaImage generates given size variant of the media image and writes it to the file store
+func (s *ServiceProduct) generateProductMediaImage(media *model.ProductMedium, img image.Image, size model_helper.ProductMediaImageSize, format model_helper.ProductMediaImageFormat) (string, *model_helper.AppError) {
+	spec := model_helper.ProductMediaImageSizeSpecs[size]
+
+	var resized image.Image
+	if spec.Crop {
+		focalX, focalY, err := model_helper.ParseProductMediaPpoi(media.Ppoi)
+		if err != nil {
+			focalX, focalY = 0.5, 0.5
+		}
+		resized = imaging.CropAroundFocalPoint(img, spec.Width, spec.Height, focalX, focalY)
+	} else {
+		resized = imaging.GenerateThumbnail(img, spec.Width, spec.Height)
+	}
+
+	ext := productMediaImageExtension(media, format)
+	imagePath := model_helper.ProductMediaImagePath(media.ProductID, media.ID, size, ext)
+	return imagePath, s.writeProductMediaImage(resized, ext, imagePath)
+}
+
+func (s *ServiceProduct) writeProductMediaImage(img image.Image, ext, imagePath string) *model_helper.AppError {
+	var (
+		buf     bytes.Buffer
+		encoder = s.srv.File.ImageEncoder()
+		err     error
+	)
+	switch ext {
+	case "webp":
+		err = encoder.EncodeWebP(&buf, img, productMediaImageWebpQuality)
+	case "jpg":
+		err = encoder.EncodeJPEG(&buf, img, productMediaImageJpegQuality)
+	default:
+		err = encoder.EncodePNG(&buf, img)
+	}
+	if err != nil {
+		return model_helper.NewAppError("writeProductMediaImage", "app.product.error_encoding_product_media_image.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	_, appErr := s.srv.File.WriteFile(&buf, imagePath)
+	return appErr
+}
+
+// removeProductMediaImages removes files of given media, used when saving the media fails
+func (s *ServiceProduct) removeProductMediaImages(media *model.ProductMedium) {
+	if appErr := s.srv.File.RemoveDirectory(model_helper.ProductMediaImageDir(media.ProductID, media.ID)); appErr != nil {
+		slog.Warn("Failed to remove images of product media", slog.String("media_id", media.ID), slog.Err(appErr))
+	}
+}
+
+// productMediaImageExtension returns extension of size variants of the media image in given format
+func productMediaImageExtension(media *model.ProductMedium, format model_helper.ProductMediaImageFormat) string {
+	if format == model_helper.ProductMediaImageFormatWebp {
+		return "webp"
+	}
+	if ext := strings.ToLower(path.Ext(media.Image)); ext == ".jpg" || ext == ".jpeg" {
+		return "jpg"
+	}
+	return "png"
+}
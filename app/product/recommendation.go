@@ -0,0 +1,282 @@
+package product
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+)
+
+const (
+	DefaultProductRecommendationsLimit = 10
+	MaxProductRecommendationsLimit     = 50
+
+	recommendationProductsBatchSize = 500
+	// terms shared by more products than this (e.g huge categories) are not used to find similar products,
+	// they barely tell anything about similarity while making the computation quadratic.
+	recommendationMaxTermProducts = 1000
+	// weight of sharing the category, relative to sharing one attribute value
+	recommendationCategoryWeight = 2.0
+)
+
+// ComputeProductRecommendations is run periodically by the product recommendations job.
+// For each active channel, it replaces recommendations of products listed in the channel with:
+//
+//  1. products frequently bought together with them, according to orders placed in the channel recently,
+//  2. products sharing their category and attribute values.
+//
+// Only products published, visible in listings and in stock in the channel are recommended.
+// A channel failing doesn't prevent recommendations of other channels from being computed.
+func (s *ServiceProduct) ComputeProductRecommendations() *model_helper.AppError {
+	channels, err := s.srv.Store.Channel().FilterByOptions(model_helper.ChannelFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ChannelWhere.IsActive.EQ(true)),
+	})
+	if err != nil {
+		return model_helper.NewAppError("ComputeProductRecommendations", "app.channel.error_finding_channels_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var failedChannelIDs, failures []string
+	for _, channel := range channels {
+		if appErr := s.computeProductRecommendationsOfChannel(channel.ID); appErr != nil {
+			slog.Error("Failed to compute product recommendations", slog.String("channel_id", channel.ID), slog.Err(appErr))
+			failedChannelIDs = append(failedChannelIDs, channel.ID)
+			failures = append(failures, appErr.Error())
+		}
+	}
+
+	if len(failedChannelIDs) > 0 {
+		return model_helper.NewAppError("ComputeProductRecommendations", "app.product.error_computing_product_recommendations.app_error", map[string]any{"ChannelIDs": strings.Join(failedChannelIDs, ", ")}, strings.Join(failures, "; "), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (s *ServiceProduct) computeProductRecommendationsOfChannel(channelID string) *model_helper.AppError {
+	shopSettings := s.srv.Config().ShopSettings
+	since := model_helper.GetMillis() - int64(*shopSettings.ProductRecommendationsOrderWindowDays)*24*60*60*1000
+
+	products, appErr := s.productsForRecommendations(channelID)
+	if appErr != nil {
+		return appErr
+	}
+
+	coPurchases, err := s.srv.Store.ProductRecommendation().CoPurchases(channelID, since, *shopSettings.ProductRecommendationsMinCoPurchases)
+	if err != nil {
+		return model_helper.NewAppError("computeProductRecommendationsOfChannel", "app.product.error_counting_co_purchases.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	perProduct := *shopSettings.ProductRecommendationsPerProduct
+	recommendations := append(
+		coPurchaseRecommendations(products, coPurchases, perProduct),
+		similarProductRecommendations(products, perProduct)...,
+	)
+
+	if appErr = s.replaceProductRecommendationsOfChannel(channelID, recommendations); appErr != nil {
+		return appErr
+	}
+	slog.Debug("Computed product recommendations", slog.String("channel_id", channelID), slog.Int("recommendations", len(recommendations)))
+	return nil
+}
+
+// ProductRecommendations returns products recommended for given product, which are visible and in stock in given channel.
+// kind is model_helper.ProductRecommendationKindCoPurchase or model_helper.ProductRecommendationKindSimilar, empty kind means any kind.
+func (s *ServiceProduct) ProductRecommendations(productID, channelID, kind string, limit int) ([]*model_helper.RecommendedProduct, *model_helper.AppError) {
+	if !model_helper.IsValidId(productID) {
+		return nil, model_helper.NewAppError("ProductRecommendations", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "productID"}, "", http.StatusBadRequest)
+	}
+	if !model_helper.IsValidId(channelID) {
+		return nil, model_helper.NewAppError("ProductRecommendations", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "channelID"}, "", http.StatusBadRequest)
+	}
+	if kind != "" && kind != model_helper.ProductRecommendationKindCoPurchase && kind != model_helper.ProductRecommendationKindSimilar {
+		return nil, model_helper.NewAppError("ProductRecommendations", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "kind"}, "", http.StatusBadRequest)
+	}
+	if limit <= 0 {
+		limit = DefaultProductRecommendationsLimit
+	}
+
+	products, err := s.srv.Store.ProductRecommendation().RecommendedProducts(model_helper.RecommendedProductsOptions{
+		ProductID: productID,
+		ChannelID: channelID,
+		Kind:      kind,
+		Limit:     min(limit, MaxProductRecommendationsLimit),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductRecommendations", "app.product.error_finding_recommended_products.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return products, nil
+}
+
+// productsForRecommendations returns products listed in given channel, keyed by their ids
+func (s *ServiceProduct) productsForRecommendations(channelID string) (map[string]*model_helper.ProductForRecommendation, *model_helper.AppError) {
+	res := map[string]*model_helper.ProductForRecommendation{}
+	now := model_helper.GetMillis()
+
+	for afterID := ""; ; {
+		products, err := s.srv.Store.ProductRecommendation().ProductsForRecommendations(channelID, now, afterID, recommendationProductsBatchSize)
+		if err != nil {
+			return nil, model_helper.NewAppError("productsForRecommendations", "app.product.error_finding_products_for_recommendations.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, product := range products {
+			res[product.ID] = product
+		}
+		if len(products) < recommendationProductsBatchSize {
+			return res, nil
+		}
+		afterID = products[len(products)-1].ID
+	}
+}
+
+func (s *ServiceProduct) replaceProductRecommendationsOfChannel(channelID string, recommendations model.ProductRecommendationSlice) *model_helper.AppError {
+	transaction, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return model_helper.NewAppError("replaceProductRecommendationsOfChannel", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(transaction)
+
+	if err = s.srv.Store.ProductRecommendation().ReplaceForChannel(transaction, channelID, recommendations); err != nil {
+		if appErr, ok := err.(*model_helper.AppError); ok {
+			return appErr
+		}
+		return model_helper.NewAppError("replaceProductRecommendationsOfChannel", "app.product.error_saving_product_recommendations.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return model_helper.NewAppError("replaceProductRecommendationsOfChannel", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// coPurchaseRecommendations scores pairs of products bought together with the cosine similarity
+// of their orders: co-purchases / sqrt(orders of product * orders of other product).
+// So best sellers are not recommended for every product just because they are in many orders.
+func coPurchaseRecommendations(products map[string]*model_helper.ProductForRecommendation, coPurchases []*model_helper.ProductCoPurchase, perProduct int) model.ProductRecommendationSlice {
+	scores := map[string]map[string]float64{}
+
+	for _, coPurchase := range coPurchases {
+		if _, ok := products[coPurchase.ProductID]; !ok || !recommendable(products[coPurchase.OtherProductID]) {
+			continue
+		}
+		if coPurchase.ProductOrderCount == 0 || coPurchase.OtherProductOrderCount == 0 {
+			continue
+		}
+
+		if scores[coPurchase.ProductID] == nil {
+			scores[coPurchase.ProductID] = map[string]float64{}
+		}
+		scores[coPurchase.ProductID][coPurchase.OtherProductID] = float64(coPurchase.CoPurchaseCount) / math.Sqrt(float64(coPurchase.ProductOrderCount)*float64(coPurchase.OtherProductOrderCount))
+	}
+
+	return topProductRecommendations(model_helper.ProductRecommendationKindCoPurchase, scores, perProduct)
+}
+
+// similarProductRecommendations scores pairs of products with the weighted jaccard similarity of their
+// attribute values and categories. Sharing the category weighs as much as sharing recommendationCategoryWeight attribute values.
+func similarProductRecommendations(products map[string]*model_helper.ProductForRecommendation, perProduct int) model.ProductRecommendationSlice {
+	attributeTerms := make(map[string][]string, len(products))
+	// attribute term or category id => ids of recommendable products having it
+	termProducts := map[string][]string{}
+	categoryProducts := map[string][]string{}
+
+	for productID, product := range products {
+		attributeTerms[productID] = lo.Uniq(product.AttributeTerms)
+
+		if !recommendable(product) {
+			continue
+		}
+		for _, term := range attributeTerms[productID] {
+			termProducts[term] = append(termProducts[term], productID)
+		}
+		if product.CategoryID != "" {
+			categoryProducts[product.CategoryID] = append(categoryProducts[product.CategoryID], productID)
+		}
+	}
+
+	scores := map[string]map[string]float64{}
+
+	for productID, product := range products {
+		// number of attribute values shared with other products
+		sharedTerms := map[string]int{}
+		for _, term := range attributeTerms[productID] {
+			if len(termProducts[term]) > recommendationMaxTermProducts {
+				continue
+			}
+			for _, otherID := range termProducts[term] {
+				sharedTerms[otherID]++
+			}
+		}
+
+		sameCategory := map[string]bool{}
+		if categoryIDs := categoryProducts[product.CategoryID]; product.CategoryID != "" && len(categoryIDs) <= recommendationMaxTermProducts {
+			for _, otherID := range categoryIDs {
+				sameCategory[otherID] = true
+			}
+		}
+
+		candidateIDs := lo.Union(lo.Keys(sharedTerms), lo.Keys(sameCategory))
+		for _, otherID := range candidateIDs {
+			if otherID == productID {
+				continue
+			}
+
+			// categories of both products count as two terms of the union unless they are the same
+			shared := float64(sharedTerms[otherID])
+			union := float64(len(attributeTerms[productID])+len(attributeTerms[otherID])) - shared + 2*recommendationCategoryWeight
+			if sameCategory[otherID] {
+				shared += recommendationCategoryWeight
+				union -= recommendationCategoryWeight
+			}
+			if shared == 0 {
+				continue
+			}
+
+			if scores[productID] == nil {
+				scores[productID] = map[string]float64{}
+			}
+			scores[productID][otherID] = shared / union
+		}
+	}
+
+	return topProductRecommendations(model_helper.ProductRecommendationKindSimilar, scores, perProduct)
+}
+
+// recommendable tells if given product can be recommended to customers of the channel it was found in
+func recommendable(product *model_helper.ProductForRecommendation) bool {
+	return product != nil && product.Recommendable
+}
+
+// topProductRecommendations keeps perProduct best recommendations of each product.
+// scores are keyed by ids of products, then ids of recommended products
+func topProductRecommendations(kind string, scores map[string]map[string]float64, perProduct int) model.ProductRecommendationSlice {
+	var res model.ProductRecommendationSlice
+
+	for productID, productScores := range scores {
+		recommendedIDs := lo.Keys(productScores)
+		sort.Slice(recommendedIDs, func(i, j int) bool {
+			scoreI, scoreJ := productScores[recommendedIDs[i]], productScores[recommendedIDs[j]]
+			if scoreI != scoreJ {
+				return scoreI > scoreJ
+			}
+			return recommendedIDs[i] < recommendedIDs[j]
+		})
+		if len(recommendedIDs) > perProduct {
+			recommendedIDs = recommendedIDs[:perProduct]
+		}
+
+		for _, recommendedID := range recommendedIDs {
+			res = append(res, &model.ProductRecommendation{
+				ProductID:            productID,
+				RecommendedProductID: recommendedID,
+				Kind:                 kind,
+				Score:                float32(productScores[recommendedID]),
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,143 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func newRecommendationTestService(t *testing.T, recommendations *mocks.ProductRecommendationStore, channels ...*model.Channel) *ServiceProduct {
+	t.Helper()
+
+	memoryStore, err := config.NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := config.NewStoreFromBacking(memoryStore, nil, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { configStore.Close() })
+
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice(channels), nil)
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Channel").Return(channelStore)
+	mockStore.On("ProductRecommendation").Return(recommendations)
+	mockStore.On("GetMaster").Return(&testTransaction{})
+	mockStore.On("FinalizeTransaction", mock.Anything).Return()
+
+	return &ServiceProduct{srv: &app.Server{Store: mockStore, ConfigStore: configStore}}
+}
+
+func TestComputeProductRecommendations(t *testing.T) {
+	failing := &model.Channel{ID: model_helper.NewId()}
+	working := &model.Channel{ID: model_helper.NewId()}
+	shirt, pants := model_helper.NewId(), model_helper.NewId()
+
+	recommendations := &mocks.ProductRecommendationStore{}
+	recommendations.On("ProductsForRecommendations", failing.ID, mock.Anything, "", recommendationProductsBatchSize).
+		Return(nil, errors.New("connection lost"))
+	recommendations.On("ProductsForRecommendations", working.ID, mock.Anything, "", recommendationProductsBatchSize).
+		Return([]*model_helper.ProductForRecommendation{
+			{ID: shirt, CategoryID: "clothes", Recommendable: true, AttributeTerms: []string{"color:red"}},
+			{ID: pants, CategoryID: "clothes", Recommendable: true, AttributeTerms: []string{"color:red"}},
+		}, nil)
+	recommendations.On("CoPurchases", working.ID, mock.Anything, mock.Anything).Return([]*model_helper.ProductCoPurchase{}, nil)
+
+	var replaced model.ProductRecommendationSlice
+	recommendations.On("ReplaceForChannel", mock.Anything, working.ID, mock.Anything).Return(func(_ boil.ContextTransactor, _ string, slice model.ProductRecommendationSlice) error {
+		replaced = slice
+		return nil
+	})
+
+	service := newRecommendationTestService(t, recommendations, failing, working)
+
+	appErr := service.ComputeProductRecommendations()
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+	require.Contains(t, appErr.DetailedError, "connection lost")
+
+	// the failing channel doesn't prevent the other one from being computed
+	require.Len(t, replaced, 2)
+	require.ElementsMatch(t, [][2]string{{shirt, pants}, {pants, shirt}}, lo.Map(replaced, func(r *model.ProductRecommendation, _ int) [2]string {
+		return [2]string{r.ProductID, r.RecommendedProductID}
+	}))
+	recommendations.AssertNotCalled(t, "ReplaceForChannel", mock.Anything, failing.ID, mock.Anything)
+}
+
+func TestProductsForRecommendations(t *testing.T) {
+	channelID := model_helper.NewId()
+	products := make([]*model_helper.ProductForRecommendation, recommendationProductsBatchSize+1)
+	for i := range products {
+		products[i] = &model_helper.ProductForRecommendation{ID: model_helper.NewId()}
+	}
+
+	recommendations := &mocks.ProductRecommendationStore{}
+	recommendations.On("ProductsForRecommendations", channelID, mock.Anything, "", recommendationProductsBatchSize).
+		Return(products[:recommendationProductsBatchSize], nil).Once()
+	recommendations.On("ProductsForRecommendations", channelID, mock.Anything, products[recommendationProductsBatchSize-1].ID, recommendationProductsBatchSize).
+		Return(products[recommendationProductsBatchSize:], nil).Once()
+
+	service := newRecommendationTestService(t, recommendations)
+	res, appErr := service.productsForRecommendations(channelID)
+	require.Nil(t, appErr)
+	require.Len(t, res, len(products))
+	recommendations.AssertExpectations(t)
+}
+
+func TestCoPurchaseRecommendations(t *testing.T) {
+	a, b, c := model_helper.NewId(), model_helper.NewId(), model_helper.NewId()
+	products := map[string]*model_helper.ProductForRecommendation{
+		a: {ID: a, Recommendable: true},
+		b: {ID: b, Recommendable: true},
+		c: {ID: c},
+	}
+
+	res := coPurchaseRecommendations(products, []*model_helper.ProductCoPurchase{
+		{ProductID: a, OtherProductID: b, CoPurchaseCount: 2, ProductOrderCount: 4, OtherProductOrderCount: 1},
+		{ProductID: b, OtherProductID: a, CoPurchaseCount: 2, ProductOrderCount: 1, OtherProductOrderCount: 4},
+		// c is not recommendable, but other products can be recommended for it
+		{ProductID: a, OtherProductID: c, CoPurchaseCount: 3, ProductOrderCount: 4, OtherProductOrderCount: 3},
+		{ProductID: c, OtherProductID: a, CoPurchaseCount: 3, ProductOrderCount: 3, OtherProductOrderCount: 4},
+	}, 10)
+
+	// scores are co-purchases / sqrt(orders of product * orders of other product)
+	scores := lo.SliceToMap(res, func(r *model.ProductRecommendation) ([2]string, float32) {
+		return [2]string{r.ProductID, r.RecommendedProductID}, r.Score
+	})
+	require.Len(t, scores, 3)
+	require.InDelta(t, 1, scores[[2]string{a, b}], 1e-6)
+	require.InDelta(t, 1, scores[[2]string{b, a}], 1e-6)
+	require.InDelta(t, 0.8660254, scores[[2]string{c, a}], 1e-6)
+	for _, r := range res {
+		require.Equal(t, model_helper.ProductRecommendationKindCoPurchase, r.Kind)
+	}
+}
+
+func TestSimilarProductRecommendations(t *testing.T) {
+	a, b, c, d := model_helper.NewId(), model_helper.NewId(), model_helper.NewId(), model_helper.NewId()
+	products := map[string]*model_helper.ProductForRecommendation{
+		a: {ID: a, CategoryID: "shirts", Recommendable: true, AttributeTerms: []string{"color:red", "size:m"}},
+		b: {ID: b, CategoryID: "shirts", Recommendable: true, AttributeTerms: []string{"color:red", "size:l"}},
+		c: {ID: c, CategoryID: "hats", Recommendable: true, AttributeTerms: []string{"size:m"}},
+		// d shares everything with a but can't be recommended
+		d: {ID: d, CategoryID: "shirts", AttributeTerms: []string{"color:red", "size:m"}},
+	}
+
+	res := similarProductRecommendations(products, 1)
+	best := lo.SliceToMap(res, func(r *model.ProductRecommendation) (string, string) { return r.ProductID, r.RecommendedProductID })
+	require.Equal(t, map[string]string{a: b, b: a, c: a, d: a}, best)
+	for _, r := range res {
+		require.Equal(t, model_helper.ProductRecommendationKindSimilar, r.Kind)
+		require.Greater(t, r.Score, float32(0))
+		require.LessOrEqual(t, r.Score, float32(1))
+	}
+}
@@ -0,0 +1,127 @@
+package product
+
+import (
+	"net/http"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/services/searchengine"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// IndexProducts loads indexing data of given products and indexes them in all active search engines.
+// Indexing runs in background unless an engine is configured to index synchronously.
+func (s *ServiceProduct) IndexProducts(productIDs []string) *model_helper.AppError {
+	engines := s.indexingEngines()
+	if len(engines) == 0 || len(productIDs) == 0 {
+		return nil
+	}
+
+	products, err := s.srv.Store.Product().GetForIndexing(productIDs)
+	if err != nil {
+		return model_helper.NewAppError("IndexProducts", "app.product.get_products_for_indexing.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, engine := range engines {
+		runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			for _, product := range products {
+				if appErr := engineCopy.IndexProduct(product); appErr != nil {
+					slog.Error("Encountered error indexing product", slog.String("product_id", product.Id), slog.String("search_engine", engineCopy.GetName()), slog.Err(appErr))
+					continue
+				}
+				slog.Debug("Indexed product in search engine", slog.String("search_engine", engineCopy.GetName()), slog.String("product_id", product.Id))
+			}
+		})
+	}
+	return nil
+}
+
+// IndexProductsOfVariants indexes parent products of given variants, it is called when stocks or prices of variants change
+func (s *ServiceProduct) IndexProductsOfVariants(variantIDs []string) *model_helper.AppError {
+	if len(s.indexingEngines()) == 0 || len(variantIDs) == 0 {
+		return nil
+	}
+
+	variants, err := s.srv.Store.ProductVariant().FilterByOption(model_helper.ProductVariantFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			qm.Select(model.ProductVariantColumns.ID, model.ProductVariantColumns.ProductID),
+			model.ProductVariantWhere.ID.IN(variantIDs),
+		),
+	})
+	if err != nil {
+		return model_helper.NewAppError("IndexProductsOfVariants", "app.product.error_finding_product_variants_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	productIDs := lo.Uniq(lo.Map(variants, func(variant *model.ProductVariant, _ int) string { return variant.ProductID }))
+	return s.IndexProducts(productIDs)
+}
+
+// DeleteProductsFromSearchIndexes removes given products from all active search engines
+func (s *ServiceProduct) DeleteProductsFromSearchIndexes(productIDs []string) {
+	for _, engine := range s.indexingEngines() {
+		runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			for _, productID := range productIDs {
+				if appErr := engineCopy.DeleteProduct(productID); appErr != nil {
+					slog.Error("Encountered error deleting product", slog.String("product_id", productID), slog.String("search_engine", engineCopy.GetName()), slog.Err(appErr))
+					continue
+				}
+				slog.Debug("Removed product from the index in search engine", slog.String("search_engine", engineCopy.GetName()), slog.String("product_id", productID))
+			}
+		})
+	}
+}
+
+// SearchProducts searches products with the first active search engine having searching enabled
+func (s *ServiceProduct) SearchProducts(params *model_helper.ProductSearchParams) (*model_helper.ProductSearchResult, *model_helper.AppError) {
+	if params.ChannelID == "" {
+		return nil, model_helper.NewAppError("SearchProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "ChannelID"}, "channel id is required", http.StatusBadRequest)
+	}
+	if params.SortBy == "" {
+		params.SortBy = model_helper.ProductSearchSortRelevance
+	}
+	if !params.SortBy.IsValid() {
+		return nil, model_helper.NewAppError("SearchProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "SortBy"}, "", http.StatusBadRequest)
+	}
+	if params.Direction != "" && params.Direction != model_helper.ASC && params.Direction != model_helper.DESC {
+		return nil, model_helper.NewAppError("SearchProducts", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "Direction"}, "", http.StatusBadRequest)
+	}
+
+	for _, engine := range s.srv.SearchEngine.GetActiveEngines() {
+		if !engine.IsSearchEnabled() {
+			continue
+		}
+
+		result, appErr := engine.SearchProducts(params)
+		if appErr != nil {
+			slog.Error("Encountered error on SearchProducts", slog.String("search_engine", engine.GetName()), slog.Err(appErr))
+			continue
+		}
+		return result, nil
+	}
+
+	return nil, model_helper.NewAppError("SearchProducts", "app.product.search_products.no_search_engine.app_error", nil, "", http.StatusNotImplemented)
+}
+
+func (s *ServiceProduct) indexingEngines() []searchengine.SearchEngineInterface {
+	var engines []searchengine.SearchEngineInterface
+	for _, engine := range s.srv.SearchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			engines = append(engines, engine)
+		}
+	}
+	return engines
+}
+
+// runIndexFn runs an indexing function synchronously or asynchronously depending on the engine
+func runIndexFn(engine searchengine.SearchEngineInterface, indexFn func(searchengine.SearchEngineInterface)) {
+	if engine.IsIndexingSync() {
+		indexFn(engine)
+		if err := engine.RefreshIndexes(); err != nil {
+			slog.Error("Encountered error refresh the indexes", slog.Err(err))
+		}
+	} else {
+		go indexFn(engine)
+	}
+}
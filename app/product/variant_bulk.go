@@ -0,0 +1,602 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/measurement"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const MaxProductVariantsBulkSize = 500
+
+// variantBulkPlan holds records to be written for one item of a bulk variant call
+type variantBulkPlan struct {
+	index           int
+	variant         *model.ProductVariant
+	attributes      []*model_helper.CustomProductAttributeValuesInput
+	channelListings model.ProductVariantChannelListingSlice
+	stocks          model.StockSlice
+}
+
+// variantBulkErrors collects errors of a bulk variant call
+type variantBulkErrors []*model_helper.ProductVariantBulkError
+
+func (e *variantBulkErrors) add(index int, field, code, format string, args ...any) {
+	*e = append(*e, &model_helper.ProductVariantBulkError{Index: index, Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e variantBulkErrors) hasIndex(index int) bool {
+	return lo.ContainsBy(e, func(err *model_helper.ProductVariantBulkError) bool { return err.Index == index })
+}
+
+// ProductVariantsBulkCreate creates variants of given product, with their attribute values, channel listings and stocks.
+// Items failing validation or failing to be written are reported by their indexes in the result errors.
+// With REJECT_EVERYTHING policy (the default) nothing is created if any item fails.
+func (s *ServiceProduct) ProductVariantsBulkCreate(productID string, inputs []*model_helper.ProductVariantBulkInput, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (*model_helper.ProductVariantBulkResult, *model_helper.AppError) {
+	return s.productVariantsBulkUpsert("ProductVariantsBulkCreate", productID, inputs, errorPolicy, false)
+}
+
+// ProductVariantsBulkUpdate updates variants of given product. Channel listings and stocks of given channels and warehouses
+// are created or updated, other listings and stocks of the variants are kept.
+// Items failing validation or failing to be written are reported by their indexes in the result errors.
+// With REJECT_EVERYTHING policy (the default) nothing is updated if any item fails.
+func (s *ServiceProduct) ProductVariantsBulkUpdate(productID string, inputs []*model_helper.ProductVariantBulkInput, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (*model_helper.ProductVariantBulkResult, *model_helper.AppError) {
+	return s.productVariantsBulkUpsert("ProductVariantsBulkUpdate", productID, inputs, errorPolicy, true)
+}
+
+// ProductVariantsBulkDelete deletes given variants. Unknown variants and variants ordered in placed orders
+// are reported by their indexes in the result errors.
+// With REJECT_EVERYTHING policy (the default) nothing is deleted if any variant fails.
+func (s *ServiceProduct) ProductVariantsBulkDelete(variantIDs []string, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (*model_helper.ProductVariantBulkResult, *model_helper.AppError) {
+	errorPolicy, appErr := validateVariantsBulkArgs("ProductVariantsBulkDelete", len(variantIDs), errorPolicy)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	variants, err := s.srv.Store.ProductVariant().FilterByOption(model_helper.ProductVariantFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductVariantWhere.ID.IN(variantIDs)),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductVariantsBulkDelete", "app.product.error_finding_product_variants_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	variantsByID := lo.KeyBy(variants, func(v *model.ProductVariant) string { return v.ID })
+
+	orderLines, err := s.srv.Store.OrderLine().FilterbyOption(model_helper.OrderLineFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			qm.WhereIn(model.OrderLineTableColumns.VariantID+" IN ?", lo.ToAnySlice(variantIDs)...),
+		),
+		RelatedOrderConditions: model.OrderWhere.Status.NEQ(model.OrderStatusDraft),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductVariantsBulkDelete", "app.order.error_finding_order_lines_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	orderedVariantIDs := lo.SliceToMap(orderLines, func(l *model.OrderLine) (string, bool) { return *l.VariantID.String, true })
+
+	var (
+		errs     variantBulkErrors
+		seen     = map[string]bool{}
+		toDelete []string
+		result   = &model_helper.ProductVariantBulkResult{Variants: make(model.ProductVariantSlice, len(variantIDs))}
+	)
+	for idx, id := range variantIDs {
+		switch {
+		case variantsByID[id] == nil:
+			errs.add(idx, "id", model_helper.ProductVariantBulkErrorCodeNotFound, "variant %q does not exist", id)
+		case seen[id]:
+			errs.add(idx, "id", model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem, "variant %q is given more than once", id)
+		case orderedVariantIDs[id]:
+			errs.add(idx, "id", model_helper.ProductVariantBulkErrorCodeCannotDelete, "variant %q is ordered in placed orders", id)
+		default:
+			result.Variants[idx] = variantsByID[id]
+			toDelete = append(toDelete, id)
+		}
+		seen[id] = true
+	}
+
+	result.Errors = errs
+	if len(toDelete) == 0 || (len(errs) > 0 && errorPolicy == model_helper.ProductVariantBulkRejectEverything) {
+		result.Variants = make(model.ProductVariantSlice, len(variantIDs))
+		return result, nil
+	}
+
+	products, err := s.srv.Store.Product().FilterByOption(model_helper.ProductFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ProductWhere.ID.IN(lo.Uniq(lo.Map(toDelete, func(id string, _ int) string { return variantsByID[id].ProductID }))),
+			qm.Load(model.ProductRels.ProductVariants),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductVariantsBulkDelete", "app.product.error_finding_products_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	tx, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductVariantsBulkDelete", model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(tx)
+
+	numDeleted, err := s.srv.Store.ProductVariant().Delete(tx, toDelete)
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductVariantsBulkDelete", "app.product.error_deleting_variants.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, product := range products {
+		// the default variant is replaced by the first remaining variant
+		if !product.DefaultVariantID.IsNil() && lo.Contains(toDelete, *product.DefaultVariantID.String) {
+			product.DefaultVariantID = model_types.NullString{}
+			remaining := lo.Filter(product.R.GetProductVariants(), func(v *model.ProductVariant, _ int) bool { return !lo.Contains(toDelete, v.ID) })
+			if len(remaining) > 0 {
+				product.DefaultVariantID = model_types.NewNullString(remaining[0].ID)
+			}
+		}
+		if appErr = s.touchProductOfVariantsBulk(tx, "ProductVariantsBulkDelete", product); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, model_helper.NewAppError("ProductVariantsBulkDelete", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	s.notifyVariantsBulk(lo.Map(toDelete, func(id string, _ int) *model.ProductVariant { return variantsByID[id] }), interfaces.PluginManagerInterface.ProductVariantDeleted)
+
+	result.Count = int(numDeleted)
+	return result, nil
+}
+
+func validateVariantsBulkArgs(where string, size int, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (model_helper.ProductVariantBulkErrorPolicy, *model_helper.AppError) {
+	if errorPolicy == "" {
+		errorPolicy = model_helper.ProductVariantBulkRejectEverything
+	}
+	if !errorPolicy.IsValid() {
+		return "", model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "errorPolicy"}, "", http.StatusBadRequest)
+	}
+	if size == 0 || size > MaxProductVariantsBulkSize {
+		return "", model_helper.NewAppError(where, "app.product.invalid_variants_bulk_size.app_error", map[string]any{"Max": MaxProductVariantsBulkSize}, "", http.StatusBadRequest)
+	}
+	return errorPolicy, nil
+}
+
+// productForVariantsBulk finds product with given id, along with its channel listings and its variants
+// with their channel listings and stocks
+func (s *ServiceProduct) productForVariantsBulk(where, productID string) (*model.Product, *model_helper.AppError) {
+	products, err := s.srv.Store.Product().FilterByOption(model_helper.ProductFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ProductWhere.ID.EQ(productID),
+			qm.Load(model.ProductRels.ProductChannelListings),
+			qm.Load(model.ProductRels.ProductVariants),
+			qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.VariantProductVariantChannelListings),
+			qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.Stocks),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError(where, "app.product.error_finding_products_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(products) == 0 {
+		return nil, model_helper.NewAppError(where, "app.product.product_not_found.app_error", nil, "product not found", http.StatusNotFound)
+	}
+	return products[0], nil
+}
+
+func (s *ServiceProduct) productVariantsBulkUpsert(where, productID string, inputs []*model_helper.ProductVariantBulkInput, errorPolicy model_helper.ProductVariantBulkErrorPolicy, isUpdate bool) (*model_helper.ProductVariantBulkResult, *model_helper.AppError) {
+	errorPolicy, appErr := validateVariantsBulkArgs(where, len(inputs), errorPolicy)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !model_helper.IsValidId(productID) {
+		return nil, model_helper.NewAppError(where, model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "productID"}, "", http.StatusBadRequest)
+	}
+
+	product, appErr := s.productForVariantsBulk(where, productID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	plans, errs, appErr := s.planVariantsBulk(where, product, inputs, isUpdate)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	result := &model_helper.ProductVariantBulkResult{Variants: make(model.ProductVariantSlice, len(inputs))}
+	if len(plans) == 0 || (len(errs) > 0 && errorPolicy == model_helper.ProductVariantBulkRejectEverything) {
+		result.Errors = errs
+		return result, nil
+	}
+
+	if errorPolicy == model_helper.ProductVariantBulkRejectEverything {
+		variants, appErr := s.applyVariantBulkPlans(where, product, plans, &errs, isUpdate)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for idx, plan := range plans {
+			if variants != nil {
+				result.Variants[plan.index] = variants[idx]
+			}
+		}
+	} else {
+		for _, plan := range plans {
+			variants, appErr := s.applyVariantBulkPlans(where, product, []*variantBulkPlan{plan}, &errs, isUpdate)
+			if appErr != nil {
+				return nil, appErr
+			}
+			if variants != nil {
+				result.Variants[plan.index] = variants[0]
+			}
+		}
+	}
+
+	result.Count = lo.CountBy(result.Variants, func(v *model.ProductVariant) bool { return v != nil })
+	result.Errors = errs
+	return result, nil
+}
+
+// planVariantsBulk validates given inputs and prepares records to be written. Inputs with errors get no plans.
+func (s *ServiceProduct) planVariantsBulk(where string, product *model.Product, inputs []*model_helper.ProductVariantBulkInput, isUpdate bool) ([]*variantBulkPlan, variantBulkErrors, *model_helper.AppError) {
+	var channelIDs, warehouseIDs, skus []string
+	for _, input := range inputs {
+		if input == nil {
+			continue
+		}
+		for _, listing := range input.ChannelListings {
+			channelIDs = append(channelIDs, listing.ChannelID)
+		}
+		for _, stock := range input.Stocks {
+			warehouseIDs = append(warehouseIDs, stock.WarehouseID)
+		}
+		if input.Sku != nil {
+			skus = append(skus, *input.Sku)
+		}
+	}
+
+	channels, err := s.srv.Store.Channel().FilterByOptions(model_helper.ChannelFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ChannelWhere.ID.IN(lo.Uniq(channelIDs))),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError(where, "app.channel.error_finding_channels_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	warehouses, err := s.srv.Store.Warehouse().FilterByOprion(model_helper.WarehouseFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.WarehouseWhere.ID.IN(lo.Uniq(warehouseIDs))),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError(where, "app.warehouse.error_finding_warehouses_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	variantsWithSkus, err := s.srv.Store.ProductVariant().FilterByOption(model_helper.ProductVariantFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductVariantWhere.Sku.IN(lo.Uniq(skus))),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError(where, "app.product.error_finding_product_variants_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	customAttributes, err := s.srv.Store.CustomProductAttribute().FilterByOptions(model_helper.CustomProductAttributeFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.CustomProductAttributeWhere.ProductID.EQ(product.ID)),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError(where, "app.product.error_finding_custom_product_attributes_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var (
+		channelsByID     = lo.KeyBy(channels, func(c *model.Channel) string { return c.ID })
+		warehousesByID   = lo.KeyBy(warehouses, func(w *model.Warehouse) string { return w.ID })
+		skuOwners        = lo.SliceToMap(variantsWithSkus, func(v *model.ProductVariant) (string, string) { return v.Sku, v.ID })
+		variantsByID     = lo.KeyBy(product.R.GetProductVariants(), func(v *model.ProductVariant) string { return v.ID })
+		attributesByID   = lo.KeyBy(customAttributes, func(a *model.CustomProductAttribute) string { return a.ID })
+		listedChannelIDs = lo.SliceToMap(product.R.GetProductChannelListings(), func(l *model.ProductChannelListing) (string, bool) { return l.ChannelID, true })
+		batchSkus        = map[string]int{}
+		batchVariantIDs  = map[string]int{}
+		errs             variantBulkErrors
+		plans            []*variantBulkPlan
+	)
+
+	for idx, input := range inputs {
+		if input == nil {
+			errs.add(idx, "", model_helper.ProductVariantBulkErrorCodeRequired, "variant input is required")
+			continue
+		}
+
+		var variant *model.ProductVariant
+		if isUpdate {
+			existing := variantsByID[input.ID]
+			if existing == nil {
+				errs.add(idx, "id", model_helper.ProductVariantBulkErrorCodeNotFound, "variant %q does not belong to the product", input.ID)
+				continue
+			}
+			if first, ok := batchVariantIDs[input.ID]; ok {
+				errs.add(idx, "id", model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem, "variant %q is already updated by item %d", input.ID, first)
+				continue
+			}
+			batchVariantIDs[input.ID] = idx
+			variantCopy := *existing
+			variant = &variantCopy
+		} else {
+			variant = &model.ProductVariant{ProductID: product.ID}
+			if input.Sku == nil || *input.Sku == "" {
+				errs.add(idx, "sku", model_helper.ProductVariantBulkErrorCodeRequired, "sku is required")
+			}
+		}
+
+		if input.Sku != nil && *input.Sku != "" {
+			sku := *input.Sku
+			if first, ok := batchSkus[sku]; ok {
+				errs.add(idx, "sku", model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem, "sku %q is already used by item %d", sku, first)
+			} else if owner, ok := skuOwners[sku]; ok && owner != variant.ID {
+				errs.add(idx, "sku", model_helper.ProductVariantBulkErrorCodeUnique, "sku %q is already used by another variant", sku)
+			}
+			batchSkus[sku] = idx
+			variant.Sku = sku
+		} else if input.Sku != nil {
+			errs.add(idx, "sku", model_helper.ProductVariantBulkErrorCodeRequired, "sku can not be empty")
+		}
+
+		setVariantBulkFields(variant, input, idx, &errs)
+
+		plan := &variantBulkPlan{index: idx, variant: variant}
+
+		seenAttributes := map[string]bool{}
+		for _, attribute := range input.Attributes {
+			switch {
+			case attribute == nil || (attribute.ID == "" && attribute.Name == ""):
+				errs.add(idx, "attributes", model_helper.ProductVariantBulkErrorCodeRequired, "attribute id is required")
+				continue
+			case attribute.ID != "" && attributesByID[attribute.ID] == nil:
+				errs.add(idx, "attributes", model_helper.ProductVariantBulkErrorCodeNotFound, "attribute %q does not belong to the product", attribute.ID)
+				continue
+			case attribute.ID != "" && seenAttributes[attribute.ID]:
+				errs.add(idx, "attributes", model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem, "attribute %q is given more than once", attribute.ID)
+				continue
+			}
+			seenAttributes[attribute.ID] = true
+			if lo.ContainsBy(attribute.Values, func(v string) bool { return v == "" || len(v) > model_helper.CustomProductAttributeValueMaxLength }) {
+				errs.add(idx, "attributes", model_helper.ProductVariantBulkErrorCodeInvalid, "values of attribute %q must not be empty or longer than %d characters", lo.CoalesceOrEmpty(attribute.ID, attribute.Name), model_helper.CustomProductAttributeValueMaxLength)
+				continue
+			}
+			plan.attributes = append(plan.attributes, attribute)
+		}
+
+		seenChannels := map[string]bool{}
+		for _, listingInput := range input.ChannelListings {
+			channel := channelsByID[listingInput.ChannelID]
+			switch {
+			case channel == nil:
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeNotFound, "channel %q does not exist", listingInput.ChannelID)
+				continue
+			case seenChannels[channel.ID]:
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem, "channel %q is given more than once", channel.ID)
+				continue
+			case !listedChannelIDs[channel.ID]:
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeProductNotAssignedToChannel, "product is not available in channel %q", channel.Slug)
+				continue
+			}
+			seenChannels[channel.ID] = true
+
+			listing := findOrNewVariantBulkChannelListing(variant, channel)
+			if listingInput.Price != nil {
+				listing.PriceAmount = model_types.NewNullDecimal(*listingInput.Price)
+			}
+			if listingInput.CostPrice != nil {
+				listing.CostPriceAmount = model_types.NewNullDecimal(*listingInput.CostPrice)
+			}
+			if listingInput.PreorderQuantityThreshold != nil {
+				listing.PreorderQuantityThreshold = model_types.NewNullInt(*listingInput.PreorderQuantityThreshold)
+			}
+
+			switch {
+			case listing.PriceAmount.IsNil():
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeRequired, "price is required for channel %q", channel.Slug)
+			case listing.PriceAmount.Decimal.IsNegative():
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeInvalid, "price must not be negative")
+			case !listing.CostPriceAmount.IsNil() && listing.CostPriceAmount.Decimal.IsNegative():
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeInvalid, "cost price must not be negative")
+			case listingInput.PreorderQuantityThreshold != nil && *listingInput.PreorderQuantityThreshold < 0:
+				errs.add(idx, "channelListings", model_helper.ProductVariantBulkErrorCodeInvalid, "preorder quantity threshold must not be negative")
+			default:
+				plan.channelListings = append(plan.channelListings, listing)
+			}
+		}
+
+		seenWarehouses := map[string]bool{}
+		for _, stockInput := range input.Stocks {
+			switch {
+			case warehousesByID[stockInput.WarehouseID] == nil:
+				errs.add(idx, "stocks", model_helper.ProductVariantBulkErrorCodeNotFound, "warehouse %q does not exist", stockInput.WarehouseID)
+				continue
+			case seenWarehouses[stockInput.WarehouseID]:
+				errs.add(idx, "stocks", model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem, "warehouse %q is given more than once", stockInput.WarehouseID)
+				continue
+			case stockInput.Quantity < 0:
+				errs.add(idx, "stocks", model_helper.ProductVariantBulkErrorCodeInvalid, "quantity must not be negative")
+				continue
+			case stockInput.LowStockThreshold != nil && *stockInput.LowStockThreshold < 0:
+				errs.add(idx, "stocks", model_helper.ProductVariantBulkErrorCodeInvalid, "low stock threshold must not be negative")
+				continue
+			}
+			seenWarehouses[stockInput.WarehouseID] = true
+
+			stock, _ := lo.Find(variant.R.GetStocks(), func(s *model.Stock) bool { return s.WarehouseID == stockInput.WarehouseID })
+			if stock == nil {
+				stock = &model.Stock{WarehouseID: stockInput.WarehouseID}
+			} else {
+				stockCopy := *stock
+				stock = &stockCopy
+			}
+			stock.Quantity = stockInput.Quantity
+			if stockInput.LowStockThreshold != nil {
+				stock.LowStockThreshold = model_types.NewNullInt(*stockInput.LowStockThreshold)
+			}
+			plan.stocks = append(plan.stocks, stock)
+		}
+
+		if !errs.hasIndex(idx) {
+			plans = append(plans, plan)
+		}
+	}
+
+	return plans, errs, nil
+}
+
+// setVariantBulkFields sets plain fields of given input to the variant
+func setVariantBulkFields(variant *model.ProductVariant, input *model_helper.ProductVariantBulkInput, index int, errs *variantBulkErrors) {
+	if input.Name != nil {
+		variant.Name = *input.Name
+	}
+	if variant.Name == "" {
+		variant.Name = variant.Sku
+	}
+	if input.Weight != nil {
+		if *input.Weight < 0 {
+			errs.add(index, "weight", model_helper.ProductVariantBulkErrorCodeInvalid, "weight must not be negative")
+		}
+		variant.Weight = model_types.NewNullFloat32(*input.Weight)
+	}
+	if input.WeightUnit != nil {
+		if measurement.WEIGHT_UNIT_CONVERSION[measurement.WeightUnit(*input.WeightUnit)] == 0 {
+			errs.add(index, "weightUnit", model_helper.ProductVariantBulkErrorCodeInvalid, "unknown weight unit %q", *input.WeightUnit)
+		}
+		variant.WeightUnit = *input.WeightUnit
+	}
+	if input.TrackInventory != nil {
+		variant.TrackInventory = model_types.NewNullBool(*input.TrackInventory)
+	}
+	if input.QuantityLimitPerCustomer != nil {
+		if *input.QuantityLimitPerCustomer < 1 {
+			errs.add(index, "quantityLimitPerCustomer", model_helper.ProductVariantBulkErrorCodeInvalid, "quantity limit per customer must be positive")
+		}
+		variant.QuantityLimitPerCustomer = model_types.NewNullInt(*input.QuantityLimitPerCustomer)
+	}
+	if input.LowStockThreshold != nil {
+		if *input.LowStockThreshold < 0 {
+			errs.add(index, "lowStockThreshold", model_helper.ProductVariantBulkErrorCodeInvalid, "low stock threshold must not be negative")
+		}
+		variant.LowStockThreshold = model_types.NewNullInt(*input.LowStockThreshold)
+	}
+}
+
+func findOrNewVariantBulkChannelListing(variant *model.ProductVariant, channel *model.Channel) *model.ProductVariantChannelListing {
+	listing, _ := lo.Find(variant.R.GetVariantProductVariantChannelListings(), func(l *model.ProductVariantChannelListing) bool { return l.ChannelID == channel.ID })
+	if listing == nil {
+		return &model.ProductVariantChannelListing{
+			ChannelID: channel.ID,
+			Currency:  model.NullCurrency{Val: channel.Currency, Valid: true},
+		}
+	}
+	listingCopy := *listing
+	return &listingCopy
+}
+
+// applyVariantBulkPlans writes given plans in a transaction and notifies plugins about written variants.
+// If a plan fails, the transaction is rolled back, error of the plan is added to errs and nil variants are returned.
+func (s *ServiceProduct) applyVariantBulkPlans(where string, product *model.Product, plans []*variantBulkPlan, errs *variantBulkErrors, isUpdate bool) (model.ProductVariantSlice, *model_helper.AppError) {
+	tx, err := s.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, model_helper.NewAppError(where, model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer s.srv.Store.FinalizeTransaction(tx)
+
+	var variants model.ProductVariantSlice
+	for _, plan := range plans {
+		variant, field, err := s.applyVariantBulkPlan(tx, product.ID, plan)
+		if err != nil {
+			code := model_helper.ProductVariantBulkErrorCodeInvalid
+			var invalidInput *store.ErrInvalidInput
+			if errors.As(err, &invalidInput) && invalidInput.Field == model.ProductVariantColumns.Sku {
+				code = model_helper.ProductVariantBulkErrorCodeUnique
+			}
+			errs.add(plan.index, field, code, "%s", err.Error())
+			return nil, nil
+		}
+		variants = append(variants, variant)
+	}
+
+	productToSave := *product
+	if productToSave.DefaultVariantID.IsNil() && len(variants) > 0 {
+		productToSave.DefaultVariantID = model_types.NewNullString(variants[0].ID)
+	}
+	if appErr := s.touchProductOfVariantsBulk(tx, where, &productToSave); appErr != nil {
+		return nil, appErr
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, model_helper.NewAppError(where, model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	product.DefaultVariantID = productToSave.DefaultVariantID
+
+	if isUpdate {
+		s.notifyVariantsBulk(variants, interfaces.PluginManagerInterface.ProductVariantUpdated)
+	} else {
+		s.notifyVariantsBulk(variants, interfaces.PluginManagerInterface.ProductVariantCreated)
+	}
+	return variants, nil
+}
+
+// applyVariantBulkPlan writes records of given plan. Field of the input causing the error is returned along with the error
+func (s *ServiceProduct) applyVariantBulkPlan(tx boil.ContextTransactor, productID string, plan *variantBulkPlan) (*model.ProductVariant, string, error) {
+	variant, err := s.srv.Store.ProductVariant().Upsert(tx, *plan.variant)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(plan.attributes) > 0 {
+		err = s.srv.Store.CustomProductAttribute().AssignVariantValues(tx, productID, variant.ID, plan.attributes)
+		if err != nil {
+			return nil, "attributes", err
+		}
+	}
+
+	if len(plan.channelListings) > 0 {
+		listings := lo.Map(plan.channelListings, func(l *model.ProductVariantChannelListing, _ int) *model.ProductVariantChannelListing {
+			res := *l
+			res.VariantID = variant.ID
+			return &res
+		})
+		_, err = s.srv.Store.ProductVariantChannelListing().Upsert(tx, listings)
+		if err != nil {
+			return nil, "channelListings", err
+		}
+	}
+
+	if len(plan.stocks) > 0 {
+		stocks := lo.Map(plan.stocks, func(s *model.Stock, _ int) *model.Stock {
+			res := *s
+			res.ProductVariantID = variant.ID
+			return &res
+		})
+		_, err = s.srv.Store.Stock().Upsert(tx, stocks)
+		if err != nil {
+			return nil, "stocks", err
+		}
+	}
+
+	return variant, "", nil
+}
+
+// touchProductOfVariantsBulk saves given product after its variants changed
+func (s *ServiceProduct) touchProductOfVariantsBulk(tx boil.ContextTransactor, where string, product *model.Product) *model_helper.AppError {
+	productToSave := *product
+	productToSave.SearchIndexDirty = model_types.NewNullBool(true)
+	_, err := s.srv.Store.Product().Save(tx, productToSave)
+	if err != nil {
+		return model_helper.NewAppError(where, "app.product.error_saving_product.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// notifyVariantsBulk calls given plugin manager hook for each of given variants, the hooks also reindex parent
+// products of the variants. Variants are already committed, so failing hooks are only logged.
+func (s *ServiceProduct) notifyVariantsBulk(variants model.ProductVariantSlice, hook func(interfaces.PluginManagerInterface, model.ProductVariant) (any, *model_helper.AppError)) {
+	pluginMng := s.srv.Plugin.GetPluginManager()
+	for _, variant := range variants {
+		if _, appErr := hook(pluginMng, *variant); appErr != nil {
+			slog.Error("failed to notify plugins about bulk changed variant", slog.String("variant_id", variant.ID), slog.Err(appErr))
+		}
+	}
+}
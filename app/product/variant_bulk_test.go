@@ -0,0 +1,295 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/site-name/decimal"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type testTransaction struct {
+	store.ContextRunner
+	committed bool
+}
+
+func (t *testTransaction) BeginTx(context.Context, *sql.TxOptions) (store.ContextRunner, error) {
+	return &testTransaction{}, nil
+}
+
+func (t *testTransaction) Commit() error {
+	t.committed = true
+	return nil
+}
+
+func (t *testTransaction) Rollback() error {
+	return nil
+}
+
+// variantHooks records variant hooks called on the plugin manager
+type variantHooks struct {
+	interfaces.PluginManagerInterface
+
+	created, updated, deleted []string
+}
+
+func (h *variantHooks) ProductVariantCreated(variant model.ProductVariant) (any, *model_helper.AppError) {
+	h.created = append(h.created, variant.ID)
+	return nil, nil
+}
+
+func (h *variantHooks) ProductVariantUpdated(variant model.ProductVariant) (any, *model_helper.AppError) {
+	h.updated = append(h.updated, variant.ID)
+	return nil, nil
+}
+
+func (h *variantHooks) ProductVariantDeleted(variant model.ProductVariant) (any, *model_helper.AppError) {
+	h.deleted = append(h.deleted, variant.ID)
+	return nil, nil
+}
+
+type variantHooksPlugins struct {
+	sub_app_iface.PluginService
+
+	hooks *variantHooks
+}
+
+func (p *variantHooksPlugins) GetPluginManager() interfaces.PluginManagerInterface {
+	return p.hooks
+}
+
+// variantBulkTest holds a product with one variant listed in one channel, and stores recording writes of bulk variant calls
+type variantBulkTest struct {
+	service   *ServiceProduct
+	product   *model.Product
+	variant   *model.ProductVariant
+	channel   *model.Channel
+	warehouse *model.Warehouse
+	attribute *model.CustomProductAttribute
+
+	variants         *mocks.ProductVariantStore
+	customAttributes *mocks.CustomProductAttributeStore
+	orderLines       *mocks.OrderLineStore
+	savedProducts    []model.Product
+	hooks            *variantHooks
+}
+
+func newVariantBulkTest(t *testing.T) *variantBulkTest {
+	t.Helper()
+
+	vt := &variantBulkTest{
+		product:          &model.Product{ID: model_helper.NewId(), Name: "Green tea"},
+		channel:          &model.Channel{ID: model_helper.NewId(), Slug: "default", Currency: model.CurrencyUSD},
+		warehouse:        &model.Warehouse{ID: model_helper.NewId()},
+		variants:         &mocks.ProductVariantStore{},
+		customAttributes: &mocks.CustomProductAttributeStore{},
+		orderLines:       &mocks.OrderLineStore{},
+		hooks:            &variantHooks{},
+	}
+	vt.variant = &model.ProductVariant{ID: model_helper.NewId(), ProductID: vt.product.ID, Sku: "tea-50g"}
+	vt.attribute = &model.CustomProductAttribute{ID: model_helper.NewId(), ProductID: vt.product.ID, Name: "Size", Slug: "size"}
+	vt.product.DefaultVariantID = model_types.NewNullString(vt.variant.ID)
+	vt.product.R = vt.product.R.NewStruct()
+	vt.product.R.ProductChannelListings = model.ProductChannelListingSlice{{ProductID: vt.product.ID, ChannelID: vt.channel.ID}}
+	vt.product.R.ProductVariants = model.ProductVariantSlice{vt.variant}
+
+	products := &mocks.ProductStore{}
+	products.On("FilterByOption", mock.Anything).Return(func(model_helper.ProductFilterOption) (model.ProductSlice, error) {
+		product := *vt.product
+		return model.ProductSlice{&product}, nil
+	})
+	products.On("Save", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, product model.Product) (*model.Product, error) {
+		vt.savedProducts = append(vt.savedProducts, product)
+		return &product, nil
+	})
+
+	channels := &mocks.ChannelStore{}
+	channels.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice{vt.channel}, nil)
+	warehouses := &mocks.WarehouseStore{}
+	warehouses.On("FilterByOprion", mock.Anything).Return(model.WarehouseSlice{vt.warehouse}, nil)
+	vt.customAttributes.On("FilterByOptions", mock.Anything).Return(model.CustomProductAttributeSlice{vt.attribute}, nil)
+	vt.customAttributes.On("AssignVariantValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	vt.variants.On("FilterByOption", mock.Anything).Return(func(model_helper.ProductVariantFilterOptions) (model.ProductVariantSlice, error) {
+		return vt.product.R.ProductVariants, nil
+	})
+	vt.variants.On("Upsert", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, variant model.ProductVariant) (*model.ProductVariant, error) {
+		if variant.ID == "" {
+			variant.ID = model_helper.NewId()
+		}
+		return &variant, nil
+	})
+	listings := &mocks.ProductVariantChannelListingStore{}
+	listings.On("Upsert", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, listings model.ProductVariantChannelListingSlice) (model.ProductVariantChannelListingSlice, error) {
+		return listings, nil
+	})
+	stocks := &mocks.StockStore{}
+	stocks.On("Upsert", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, stocks model.StockSlice) (model.StockSlice, error) {
+		return stocks, nil
+	})
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Product").Return(products)
+	mockStore.On("ProductVariant").Return(vt.variants)
+	mockStore.On("Channel").Return(channels)
+	mockStore.On("Warehouse").Return(warehouses)
+	mockStore.On("CustomProductAttribute").Return(vt.customAttributes)
+	mockStore.On("ProductVariantChannelListing").Return(listings)
+	mockStore.On("Stock").Return(stocks)
+	mockStore.On("OrderLine").Return(vt.orderLines)
+	mockStore.On("GetMaster").Return(&testTransaction{})
+	mockStore.On("FinalizeTransaction", mock.Anything).Return()
+
+	vt.service = &ServiceProduct{srv: &app.Server{Store: mockStore, Plugin: &variantHooksPlugins{hooks: vt.hooks}}}
+	return vt
+}
+
+func errorCodesByIndex(errs []*model_helper.ProductVariantBulkError) map[int]string {
+	res := map[int]string{}
+	for _, err := range errs {
+		res[err.Index] = err.Code
+	}
+	return res
+}
+
+func TestProductVariantsBulkCreate(t *testing.T) {
+	newInputs := func(vt *variantBulkTest) []*model_helper.ProductVariantBulkInput {
+		return []*model_helper.ProductVariantBulkInput{
+			{
+				Sku:             model_helper.GetPointerOfValue("tea-100g"),
+				Attributes:      []*model_helper.CustomProductAttributeValuesInput{{ID: vt.attribute.ID, Values: []string{"100g"}}},
+				ChannelListings: []*model_helper.ProductVariantBulkChannelListingInput{{ChannelID: vt.channel.ID, Price: model_helper.GetPointerOfValue(decimal.NewFromInt(10))}},
+				Stocks:          []*model_helper.ProductVariantBulkStockInput{{WarehouseID: vt.warehouse.ID, Quantity: 5}},
+			},
+			{Sku: model_helper.GetPointerOfValue(vt.variant.Sku)},
+			{
+				Sku:        model_helper.GetPointerOfValue("tea-200g"),
+				Attributes: []*model_helper.CustomProductAttributeValuesInput{{ID: model_helper.NewId(), Values: []string{"200g"}}},
+			},
+			nil,
+		}
+	}
+	expectedErrors := map[int]string{
+		1: model_helper.ProductVariantBulkErrorCodeUnique,
+		2: model_helper.ProductVariantBulkErrorCodeNotFound,
+		3: model_helper.ProductVariantBulkErrorCodeRequired,
+	}
+
+	t.Run("reject everything", func(t *testing.T) {
+		vt := newVariantBulkTest(t)
+
+		result, appErr := vt.service.ProductVariantsBulkCreate(vt.product.ID, newInputs(vt), "")
+		require.Nil(t, appErr)
+		require.Zero(t, result.Count)
+		require.Len(t, result.Variants, 4)
+		require.Empty(t, lo.Compact(result.Variants))
+		require.Equal(t, expectedErrors, errorCodesByIndex(result.Errors))
+		vt.variants.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		require.Empty(t, vt.hooks.created)
+	})
+
+	t.Run("reject failed rows", func(t *testing.T) {
+		vt := newVariantBulkTest(t)
+
+		result, appErr := vt.service.ProductVariantsBulkCreate(vt.product.ID, newInputs(vt), model_helper.ProductVariantBulkRejectFailedRows)
+		require.Nil(t, appErr)
+		require.Equal(t, 1, result.Count)
+		require.NotNil(t, result.Variants[0])
+		require.Equal(t, "tea-100g", result.Variants[0].Sku)
+		require.Equal(t, expectedErrors, errorCodesByIndex(result.Errors))
+
+		// attribute values are assigned by attribute id
+		vt.customAttributes.AssertCalled(t, "AssignVariantValues", mock.Anything, vt.product.ID, result.Variants[0].ID, []*model_helper.CustomProductAttributeValuesInput{{ID: vt.attribute.ID, Values: []string{"100g"}}})
+		require.Len(t, vt.savedProducts, 1)
+		require.True(t, *vt.savedProducts[0].SearchIndexDirty.Bool)
+		require.Equal(t, []string{result.Variants[0].ID}, vt.hooks.created)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		vt := newVariantBulkTest(t)
+
+		_, appErr := vt.service.ProductVariantsBulkCreate(vt.product.ID, newInputs(vt), "IGNORE_EVERYTHING")
+		require.NotNil(t, appErr)
+		_, appErr = vt.service.ProductVariantsBulkCreate("not-an-id", newInputs(vt), "")
+		require.NotNil(t, appErr)
+		_, appErr = vt.service.ProductVariantsBulkCreate(vt.product.ID, nil, "")
+		require.NotNil(t, appErr)
+	})
+}
+
+func TestProductVariantsBulkUpdate(t *testing.T) {
+	vt := newVariantBulkTest(t)
+	unknownID := model_helper.NewId()
+
+	result, appErr := vt.service.ProductVariantsBulkUpdate(vt.product.ID, []*model_helper.ProductVariantBulkInput{
+		{ID: vt.variant.ID, Sku: model_helper.GetPointerOfValue(vt.variant.Sku), Name: model_helper.GetPointerOfValue("50 grams")},
+		{ID: unknownID, Name: model_helper.GetPointerOfValue("unknown")},
+		{ID: vt.variant.ID, Name: model_helper.GetPointerOfValue("again")},
+	}, model_helper.ProductVariantBulkRejectFailedRows)
+	require.Nil(t, appErr)
+	require.Equal(t, 1, result.Count)
+	require.Equal(t, vt.variant.ID, result.Variants[0].ID)
+	require.Equal(t, "50 grams", result.Variants[0].Name)
+	require.Equal(t, map[int]string{
+		1: model_helper.ProductVariantBulkErrorCodeNotFound,
+		2: model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem,
+	}, errorCodesByIndex(result.Errors))
+	require.Equal(t, []string{vt.variant.ID}, vt.hooks.updated)
+}
+
+func TestProductVariantsBulkDelete(t *testing.T) {
+	newTest := func(t *testing.T) (*variantBulkTest, *model.ProductVariant) {
+		vt := newVariantBulkTest(t)
+		ordered := &model.ProductVariant{ID: model_helper.NewId(), ProductID: vt.product.ID, Sku: "tea-1kg"}
+		vt.product.R.ProductVariants = append(vt.product.R.ProductVariants, ordered)
+
+		vt.variants.On("Delete", mock.Anything, mock.Anything).Return(func(_ boil.ContextTransactor, ids []string) (int64, error) {
+			return int64(len(ids)), nil
+		})
+		vt.orderLines.On("FilterbyOption", mock.Anything).Return(model.OrderLineSlice{{ID: model_helper.NewId(), VariantID: model_types.NewNullString(ordered.ID)}}, nil)
+		return vt, ordered
+	}
+	expectedErrors := map[int]string{
+		1: model_helper.ProductVariantBulkErrorCodeCannotDelete,
+		2: model_helper.ProductVariantBulkErrorCodeNotFound,
+		3: model_helper.ProductVariantBulkErrorCodeDuplicatedInputItem,
+	}
+
+	t.Run("reject everything", func(t *testing.T) {
+		vt, ordered := newTest(t)
+
+		result, appErr := vt.service.ProductVariantsBulkDelete([]string{vt.variant.ID, ordered.ID, model_helper.NewId(), vt.variant.ID}, "")
+		require.Nil(t, appErr)
+		require.Zero(t, result.Count)
+		require.Equal(t, expectedErrors, errorCodesByIndex(result.Errors))
+		vt.variants.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		require.Empty(t, vt.hooks.deleted)
+	})
+
+	t.Run("reject failed rows", func(t *testing.T) {
+		vt, ordered := newTest(t)
+
+		result, appErr := vt.service.ProductVariantsBulkDelete([]string{vt.variant.ID, ordered.ID, model_helper.NewId(), vt.variant.ID}, model_helper.ProductVariantBulkRejectFailedRows)
+		require.Nil(t, appErr)
+		require.Equal(t, 1, result.Count)
+		require.Equal(t, expectedErrors, errorCodesByIndex(result.Errors))
+		vt.variants.AssertCalled(t, "Delete", mock.Anything, []string{vt.variant.ID})
+
+		// the deleted default variant is replaced by the remaining one
+		require.Len(t, vt.savedProducts, 1)
+		require.Equal(t, ordered.ID, *vt.savedProducts[0].DefaultVariantID.String)
+		require.Equal(t, []string{vt.variant.ID}, vt.hooks.deleted)
+	})
+}
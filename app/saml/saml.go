@@ -0,0 +1,265 @@
+/*
+NOTE: This package is initialized during server startup (modules/imports does that)
+so the init() function get the chance to register a function to create the saml interface
+*/
+package saml
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	saml2 "github.com/mattermost/gosaml2"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/einterfaces"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/services/cache"
+)
+
+const (
+	samlCacheSize = 10000
+	// requestLifetime is how long users have to sign in at the identity provider after sign ins start here
+	requestLifetime = 15 * time.Minute
+	// assertionLifetime is how long used assertions are remembered when they have no expiry
+	assertionLifetime = time.Hour
+)
+
+// Saml signs staff users in through a SAML 2.0 identity provider, both when sign ins start here
+// (SP-initiated) and when they start at the identity provider (IdP-initiated).
+type Saml struct {
+	srv      *app.Server
+	settings func() *model_helper.SamlSettings
+	getFile  func(name string) ([]byte, error) // reads certificates and keys uploaded to the config store
+
+	mutex sync.RWMutex
+	sp    *saml2.SAMLServiceProvider // nil when SAML is disabled
+
+	requests   cache.Cache // ids of authentication requests sent to the identity provider, not answered yet
+	assertions cache.Cache // ids of assertions users signed in with, they must not be used twice
+}
+
+func init() {
+	app.RegisterSamlInterface(func(s *app.Server) einterfaces.SamlInterface {
+		return New(s)
+	})
+}
+
+func New(s *app.Server) *Saml {
+	return &Saml{
+		srv: s,
+		settings: func() *model_helper.SamlSettings {
+			return &s.Config().SamlSettings
+		},
+		getFile:    s.ConfigStore.GetFile,
+		requests:   newCache(s, "SamlRequests", requestLifetime),
+		assertions: newCache(s, "SamlAssertions", assertionLifetime),
+	}
+}
+
+// newCache creates a cache of the server's cache provider, so ids are shared by every node when caches are
+// kept in redis. An in-memory cache is used if the provider fails.
+func newCache(s *app.Server, name string, defaultExpiry time.Duration) cache.Cache {
+	if s.CacheProvider != nil {
+		res, err := s.CacheProvider.NewCache(&cache.CacheOptions{
+			Name:          name,
+			Size:          samlCacheSize,
+			DefaultExpiry: defaultExpiry,
+		})
+		if err == nil {
+			return res
+		}
+		slog.Error("Failed to create saml cache, falling back to in-memory cache", slog.String("name", name), slog.Err(err))
+	}
+
+	return cache.NewLRU(cache.LRUOptions{
+		Name:          name,
+		Size:          samlCacheSize,
+		DefaultExpiry: defaultExpiry,
+	})
+}
+
+var _ einterfaces.SamlInterface = (*Saml)(nil)
+
+// ConfigureSP builds the service provider from SamlSettings. It runs on startup and on every config change.
+func (sm *Saml) ConfigureSP() error {
+	settings := sm.settings()
+	if !*settings.Enable {
+		sm.setServiceProvider(nil)
+		return nil
+	}
+
+	sp, err := sm.newServiceProvider(settings)
+	if err != nil {
+		sm.setServiceProvider(nil)
+		return err
+	}
+	sm.setServiceProvider(sp)
+	return nil
+}
+
+func (sm *Saml) setServiceProvider(sp *saml2.SAMLServiceProvider) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	sm.sp = sp
+}
+
+// serviceProvider returns the configured service provider, or an error when SAML is disabled or misconfigured
+func (sm *Saml) serviceProvider(where string) (*saml2.SAMLServiceProvider, *model_helper.AppError) {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	if sm.sp == nil {
+		return nil, model_helper.NewAppError(where, "ent.saml.not_configured.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return sm.sp, nil
+}
+
+func (sm *Saml) newServiceProvider(settings *model_helper.SamlSettings) (*saml2.SAMLServiceProvider, error) {
+	idpCert, err := sm.loadCertificate(*settings.IdpCertificateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load identity provider certificate: %w", err)
+	}
+
+	sp := &saml2.SAMLServiceProvider{
+		IdentityProviderSSOURL:      *settings.IdpUrl,
+		IdentityProviderIssuer:      *settings.IdpDescriptorUrl,
+		ServiceProviderIssuer:       *settings.ServiceProviderIdentifier,
+		AssertionConsumerServiceURL: *settings.AssertionConsumerServiceURL,
+		AudienceURI:                 *settings.ServiceProviderIdentifier,
+		IDPCertificateStore: &dsig.MemoryX509CertificateStore{
+			Roots: []*x509.Certificate{idpCert},
+		},
+		SkipSignatureValidation: !*settings.Verify,
+		SignAuthnRequests:       *settings.SignRequest,
+		AllowMissingAttributes:  true,
+		ScopingIDPProviderId:    *settings.ScopingIDPProviderId,
+		ScopingIDPProviderName:  *settings.ScopingIDPName,
+	}
+
+	// the key pair decrypts assertions and signs requests
+	if *settings.Encrypt || *settings.SignRequest {
+		keyStore, err := sm.loadKeyPair(*settings.PublicCertificateFile, *settings.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load service provider key pair: %w", err)
+		}
+		sp.SPKeyStore = keyStore
+	}
+
+	if *settings.SignRequest {
+		sp.SignAuthnRequestsAlgorithm = signatureMethod(*settings.SignatureAlgorithm)
+		sp.SignAuthnRequestsCanonicalizer = canonicalizer(*settings.CanonicalAlgorithm)
+	}
+
+	return sp, nil
+}
+
+func signatureMethod(algorithm string) string {
+	switch algorithm {
+	case model_helper.SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA256:
+		return dsig.RSASHA256SignatureMethod
+	case model_helper.SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA512:
+		return dsig.RSASHA512SignatureMethod
+	default:
+		return dsig.RSASHA1SignatureMethod
+	}
+}
+
+func canonicalizer(algorithm string) dsig.Canonicalizer {
+	if algorithm == model_helper.SAML_SETTINGS_CANONICAL_ALGORITHM_C14N11 {
+		return dsig.MakeC14N11Canonicalizer()
+	}
+	return dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+}
+
+func (sm *Saml) loadCertificate(name string) (*x509.Certificate, error) {
+	data, err := sm.getFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded certificate found in " + name)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func (sm *Saml) loadKeyPair(certificateName, keyName string) (dsig.X509KeyStore, error) {
+	certificate, err := sm.getFile(certificateName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := sm.getFile(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPair, err := tls.X509KeyPair(certificate, key)
+	if err != nil {
+		return nil, err
+	}
+	return dsig.TLSCertKeyStore(keyPair), nil
+}
+
+// BuildRequest builds an authentication request for the identity provider, users are redirected to the returned URL.
+// Given relay state comes back with the response of the identity provider.
+func (sm *Saml) BuildRequest(relayState string) (*model_helper.SamlAuthRequest, *model_helper.AppError) {
+	sp, appErr := sm.serviceProvider("BuildRequest")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// requests sent with the redirect binding are signed in their query string
+	doc, err := sp.BuildAuthRequestDocumentNoSig()
+	if err != nil {
+		return nil, model_helper.NewAppError("BuildRequest", "ent.saml.build_request.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	authRequest, err := doc.WriteToString()
+	if err != nil {
+		return nil, model_helper.NewAppError("BuildRequest", "ent.saml.build_request.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	url, err := sp.BuildAuthURLRedirect(relayState, doc)
+	if err != nil {
+		return nil, model_helper.NewAppError("BuildRequest", "ent.saml.build_request.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// responses of the identity provider must answer a request sent here, see checkAssertion
+	requestID := doc.Root().SelectAttrValue("ID", "")
+	if err := sm.requests.SetWithDefaultExpiry(requestID, true); err != nil {
+		return nil, model_helper.NewAppError("BuildRequest", "ent.saml.build_request.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return &model_helper.SamlAuthRequest{
+		Base64AuthRequest: base64.StdEncoding.EncodeToString([]byte(authRequest)),
+		URL:               url,
+		RelayState:        relayState,
+	}, nil
+}
+
+// GetMetadata returns the service provider metadata XML, to be registered by the identity provider
+func (sm *Saml) GetMetadata() (string, *model_helper.AppError) {
+	sp, appErr := sm.serviceProvider("GetMetadata")
+	if appErr != nil {
+		return "", appErr
+	}
+
+	metadata, err := sp.Metadata()
+	if err != nil {
+		return "", model_helper.NewAppError("GetMetadata", "ent.saml.metadata.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	data, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return "", model_helper.NewAppError("GetMetadata", "ent.saml.metadata.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return xml.Header + string(data), nil
+}
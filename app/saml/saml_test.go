@@ -0,0 +1,281 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	saml2 "github.com/mattermost/gosaml2"
+	"github.com/mattermost/gosaml2/types"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/services/cache"
+	"github.com/stretchr/testify/require"
+)
+
+// newKeyPair returns a PEM encoded self signed certificate and its private key
+func newKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sitename"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func newTestSaml(t *testing.T) *Saml {
+	t.Helper()
+
+	idpCert, _ := newKeyPair(t)
+	spCert, spKey := newKeyPair(t)
+	files := map[string][]byte{
+		"saml-idp.crt":     idpCert,
+		"saml-public.crt":  spCert,
+		"saml-private.key": spKey,
+	}
+
+	settings := &model_helper.SamlSettings{}
+	settings.SetDefaults()
+	settings.Enable = model_helper.GetPointerOfValue(true)
+	settings.SignRequest = model_helper.GetPointerOfValue(true)
+	settings.IdpUrl = model_helper.GetPointerOfValue("https://idp.example.com/sso")
+	settings.IdpDescriptorUrl = model_helper.GetPointerOfValue("https://idp.example.com")
+	settings.ServiceProviderIdentifier = model_helper.GetPointerOfValue("https://shop.example.com")
+	settings.AssertionConsumerServiceURL = model_helper.GetPointerOfValue("https://shop.example.com/login/sso/saml")
+	settings.IdpCertificateFile = model_helper.GetPointerOfValue("saml-idp.crt")
+	settings.PublicCertificateFile = model_helper.GetPointerOfValue("saml-public.crt")
+	settings.PrivateKeyFile = model_helper.GetPointerOfValue("saml-private.key")
+	settings.EmailAttribute = model_helper.GetPointerOfValue("email")
+	settings.UsernameAttribute = model_helper.GetPointerOfValue("username")
+	settings.FirstNameAttribute = model_helper.GetPointerOfValue("givenName")
+	settings.LocaleAttribute = model_helper.GetPointerOfValue("locale")
+	settings.EnableAdminAttribute = model_helper.GetPointerOfValue(true)
+	settings.AdminAttribute = model_helper.GetPointerOfValue("role=admin")
+	settings.GroupAttribute = model_helper.GetPointerOfValue("groups")
+	settings.GroupRoles = map[string]string{
+		"catalogue": model_helper.ShopStaffRoleId,
+		"managers":  model_helper.ShopStaffRoleId + " " + model_helper.ShopAdminRoleId,
+	}
+
+	return &Saml{
+		settings:   func() *model_helper.SamlSettings { return settings },
+		requests:   cache.NewLRU(cache.LRUOptions{Size: 10, DefaultExpiry: requestLifetime}),
+		assertions: cache.NewLRU(cache.LRUOptions{Size: 10, DefaultExpiry: assertionLifetime}),
+		getFile: func(name string) ([]byte, error) {
+			if data, ok := files[name]; ok {
+				return data, nil
+			}
+			return nil, errors.New("file not found")
+		},
+	}
+}
+
+func newValues(attributes map[string][]string) saml2.Values {
+	values := saml2.Values{}
+	for name, attributeValues := range attributes {
+		attribute := types.Attribute{Name: name}
+		for _, value := range attributeValues {
+			attribute.Values = append(attribute.Values, types.AttributeValue{Value: value})
+		}
+		values[name] = attribute
+	}
+	return values
+}
+
+func TestConfigureSP(t *testing.T) {
+	sm := newTestSaml(t)
+
+	_, appErr := sm.GetMetadata()
+	require.NotNil(t, appErr, "metadata requires a configured service provider")
+
+	require.NoError(t, sm.ConfigureSP())
+
+	metadata, appErr := sm.GetMetadata()
+	require.Nil(t, appErr)
+	require.Contains(t, metadata, `entityID="https://shop.example.com"`)
+	require.Contains(t, metadata, `Location="https://shop.example.com/login/sso/saml"`)
+	require.Contains(t, metadata, `use="encryption"`)
+
+	request, appErr := sm.BuildRequest("state")
+	require.Nil(t, appErr)
+	require.Equal(t, "state", request.RelayState)
+	authRequest, err := base64.StdEncoding.DecodeString(request.Base64AuthRequest)
+	require.NoError(t, err)
+	require.Contains(t, string(authRequest), "AuthnRequest")
+
+	redirect, err := url.Parse(request.URL)
+	require.NoError(t, err)
+	require.Equal(t, "idp.example.com", redirect.Host)
+	require.NotEmpty(t, redirect.Query().Get("SAMLRequest"))
+	require.NotEmpty(t, redirect.Query().Get("Signature"))
+
+	sm.settings().Enable = model_helper.GetPointerOfValue(false)
+	require.NoError(t, sm.ConfigureSP())
+	_, appErr = sm.BuildRequest("")
+	require.NotNil(t, appErr)
+
+	sm.settings().Enable = model_helper.GetPointerOfValue(true)
+	sm.settings().IdpCertificateFile = model_helper.GetPointerOfValue("missing.crt")
+	require.Error(t, sm.ConfigureSP())
+}
+
+func TestUserFromAssertion(t *testing.T) {
+	settings := newTestSaml(t).settings()
+
+	user := userFromAssertion(settings, newValues(map[string][]string{
+		"email":     {" John.Doe@Example.com "},
+		"username":  {"jdoe"},
+		"givenName": {"John"},
+		"locale":    {"en-us"},
+	}))
+	require.Equal(t, model_helper.USER_AUTH_SERVICE_SAML, user.AuthService)
+	require.Equal(t, "john.doe@example.com", *user.AuthData.String, "users are identified by email without id attribute")
+	require.Equal(t, "john.doe@example.com", user.Email)
+	require.Equal(t, "jdoe", user.Username)
+	require.Equal(t, "John", user.FirstName)
+	require.Equal(t, model.LanguageCodeEN_US, user.Locale)
+
+	settings.IdAttribute = model_helper.GetPointerOfValue("uid")
+	user = userFromAssertion(settings, newValues(map[string][]string{"uid": {"1001"}, "email": {"jdoe@example.com"}}))
+	require.Equal(t, "1001", *user.AuthData.String)
+
+	// auth data from the id attribute is normalized like migrated users' auth data
+	settings.IdAttribute = settings.EmailAttribute
+	user = userFromAssertion(settings, newValues(map[string][]string{"email": {" JDoe@Example.com"}}))
+	require.Equal(t, "jdoe@example.com", *user.AuthData.String)
+}
+
+func TestRolesForAssertion(t *testing.T) {
+	settings := newTestSaml(t).settings()
+
+	require.ElementsMatch(t, []string{
+		model_helper.SystemUserRoleId,
+		model_helper.SystemAdminRoleId,
+		model_helper.ShopStaffRoleId,
+		model_helper.ShopAdminRoleId,
+	}, rolesForAssertion(settings, newValues(map[string][]string{
+		"role":   {"admin"},
+		"groups": {"catalogue", "managers"},
+	})))
+	require.ElementsMatch(t, []string{
+		model_helper.SystemUserRoleId,
+		model_helper.ShopStaffRoleId,
+	}, rolesForAssertion(settings, newValues(map[string][]string{
+		"role":   {"staff"},
+		"groups": {"catalogue", "unknown"},
+	})))
+
+	// roles granted outside of the identity provider are kept
+	require.Equal(t, "system_user custom shop_staff", mergeRoles("system_user custom shop_admin", []string{model_helper.SystemUserRoleId, model_helper.ShopStaffRoleId}, managedRoles(settings)))
+}
+
+func TestCheckProviderAttributes(t *testing.T) {
+	sm := newTestSaml(t)
+	user := model.User{Email: "jdoe@example.com", Nickname: "jd"}
+
+	require.Equal(t, "email", sm.CheckProviderAttributes(*sm.settings(), user, model_helper.UserPatch{Email: model_helper.GetPointerOfValue("other@example.com")}))
+	require.Empty(t, sm.CheckProviderAttributes(*sm.settings(), user, model_helper.UserPatch{Email: model_helper.GetPointerOfValue("jdoe@example.com")}))
+	require.Empty(t, sm.CheckProviderAttributes(*sm.settings(), user, model_helper.UserPatch{Nickname: model_helper.GetPointerOfValue("johnny")}), "nickname is not mapped")
+}
+
+func TestIsEncrypted(t *testing.T) {
+	encode := func(xml string) string {
+		return base64.StdEncoding.EncodeToString([]byte(xml))
+	}
+
+	require.True(t, isEncrypted(encode(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion"><saml:EncryptedAssertion/></samlp:Response>`)))
+	require.False(t, isEncrypted(encode(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion"><saml:Assertion/></samlp:Response>`)))
+	require.False(t, isEncrypted("not base64"))
+}
+
+func newAssertion(id, inResponseTo string) types.Assertion {
+	return types.Assertion{
+		ID: id,
+		Subject: &types.Subject{
+			SubjectConfirmation: &types.SubjectConfirmation{
+				SubjectConfirmationData: &types.SubjectConfirmationData{InResponseTo: inResponseTo},
+			},
+		},
+		Conditions: &types.Conditions{NotOnOrAfter: time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339)},
+	}
+}
+
+func TestCheckAssertion(t *testing.T) {
+	sm := newTestSaml(t)
+	require.NoError(t, sm.ConfigureSP())
+
+	request, appErr := sm.BuildRequest("")
+	require.Nil(t, appErr)
+	authRequest, err := base64.StdEncoding.DecodeString(request.Base64AuthRequest)
+	require.NoError(t, err)
+	var sent struct {
+		ID string `xml:"ID,attr"`
+	}
+	require.NoError(t, xml.Unmarshal(authRequest, &sent))
+	require.NotEmpty(t, sent.ID)
+
+	appErr = sm.checkAssertion(newAssertion("assertion-1", "unknown-request"))
+	require.NotNil(t, appErr)
+	require.Equal(t, "ent.saml.do_login.unknown_request.app_error", appErr.Id)
+
+	require.Nil(t, sm.checkAssertion(newAssertion("assertion-2", sent.ID)))
+
+	appErr = sm.checkAssertion(newAssertion("assertion-2", sent.ID))
+	require.NotNil(t, appErr)
+	require.Equal(t, "ent.saml.do_login.replayed.app_error", appErr.Id)
+
+	appErr = sm.checkAssertion(newAssertion("assertion-3", sent.ID))
+	require.NotNil(t, appErr, "requests are answered once")
+	require.Equal(t, "ent.saml.do_login.unknown_request.app_error", appErr.Id)
+
+	// IdP-initiated sign ins answer no request, they are still used once
+	require.Nil(t, sm.checkAssertion(newAssertion("assertion-4", "")))
+	appErr = sm.checkAssertion(newAssertion("assertion-4", ""))
+	require.NotNil(t, appErr)
+	require.Equal(t, "ent.saml.do_login.replayed.app_error", appErr.Id)
+
+	require.NotNil(t, sm.checkAssertion(newAssertion("", "")))
+}
+
+func TestCreateUser(t *testing.T) {
+	sm := newTestSaml(t)
+	settings := sm.settings()
+	staff := newValues(map[string][]string{"email": {"jdoe@example.com"}, "groups": {"catalogue"}})
+	customer := newValues(map[string][]string{"email": {"jdoe@example.com"}})
+
+	for name, test := range map[string]struct {
+		enabled bool
+		values  saml2.Values
+	}{
+		"creation disabled": {false, staff},
+		"not staff":         {true, customer},
+	} {
+		settings.EnableUserCreation = model_helper.GetPointerOfValue(test.enabled)
+
+		_, appErr := sm.createUser(settings, test.values, userFromAssertion(settings, test.values))
+		require.NotNil(t, appErr, name)
+		require.Equal(t, "ent.saml.do_login.user_not_found.app_error", appErr.Id, name)
+	}
+
+	require.True(t, isStaff(rolesForAssertion(settings, staff)))
+	require.False(t, isStaff(rolesForAssertion(settings, customer)))
+}
@@ -0,0 +1,316 @@
+package saml
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	saml2 "github.com/mattermost/gosaml2"
+	"github.com/mattermost/gosaml2/types"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app/request"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+)
+
+// authData returns the id of the user asserted in given values, which is saved as auth data of the user.
+// Users are identified by their email when IdAttribute is not set.
+func authData(settings *model_helper.SamlSettings, values saml2.Values) string {
+	if *settings.IdAttribute != "" {
+		return model_helper.NormalizeSamlAuthData(values.Get(*settings.IdAttribute))
+	}
+	return model_helper.NormalizeSamlAuthData(values.Get(*settings.EmailAttribute))
+}
+
+// userFromAssertion maps attributes of an assertion to a user. The user is not saved.
+func userFromAssertion(settings *model_helper.SamlSettings, values saml2.Values) *model.User {
+	attribute := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return strings.TrimSpace(values.Get(name))
+	}
+
+	user := &model.User{
+		AuthService:   model_helper.USER_AUTH_SERVICE_SAML,
+		AuthData:      model_types.NewNullString(authData(settings, values)),
+		Email:         strings.ToLower(attribute(*settings.EmailAttribute)),
+		FirstName:     attribute(*settings.FirstNameAttribute),
+		LastName:      attribute(*settings.LastNameAttribute),
+		Nickname:      attribute(*settings.NicknameAttribute),
+		EmailVerified: true,
+	}
+	if username := attribute(*settings.UsernameAttribute); username != "" {
+		user.Username = model_helper.CleanUsername(username)
+	}
+	// identity providers send locales like en-US
+	if locale := model.LanguageCode(strings.ToUpper(strings.ReplaceAll(attribute(*settings.LocaleAttribute), "-", "_"))); locale.IsValid() == nil {
+		user.Locale = locale
+	}
+	return user
+}
+
+// attributeMatches checks whether one of values of the attribute of given "attribute=value" condition is the value
+func attributeMatches(values saml2.Values, condition string) bool {
+	name, value, ok := strings.Cut(condition, "=")
+	if !ok {
+		return false
+	}
+	return lo.ContainsBy(values.GetAll(strings.TrimSpace(name)), func(candidate string) bool {
+		return strings.TrimSpace(candidate) == strings.TrimSpace(value)
+	})
+}
+
+// rolesForAssertion returns roles granted to the user asserted in given values. Guests matching GuestAttribute get
+// the guest role instead of the user one, admins matching AdminAttribute get the system admin role and members of
+// groups in GroupRoles get roles mapped to their groups.
+func rolesForAssertion(settings *model_helper.SamlSettings, values saml2.Values) []string {
+	roles := []string{model_helper.SystemUserRoleId}
+
+	if *settings.GuestAttribute != "" && attributeMatches(values, *settings.GuestAttribute) {
+		roles = []string{model_helper.SystemGuestRoleId}
+	}
+	if *settings.EnableAdminAttribute && *settings.AdminAttribute != "" && attributeMatches(values, *settings.AdminAttribute) {
+		roles = append(roles, model_helper.SystemAdminRoleId)
+	}
+	if *settings.GroupAttribute != "" {
+		for _, group := range values.GetAll(*settings.GroupAttribute) {
+			roles = append(roles, strings.Fields(settings.GroupRoles[strings.TrimSpace(group)])...)
+		}
+	}
+
+	return lo.Uniq(roles)
+}
+
+// managedRoles returns roles granted by the identity provider. Users not granted them anymore lose them on their
+// next login, other roles of users are left untouched.
+func managedRoles(settings *model_helper.SamlSettings) []string {
+	var roles []string
+	if *settings.GuestAttribute != "" {
+		roles = append(roles, model_helper.SystemUserRoleId, model_helper.SystemGuestRoleId)
+	}
+	if *settings.EnableAdminAttribute && *settings.AdminAttribute != "" {
+		roles = append(roles, model_helper.SystemAdminRoleId)
+	}
+	for _, groupRoles := range settings.GroupRoles {
+		roles = append(roles, strings.Fields(groupRoles)...)
+	}
+	return roles
+}
+
+// mergeRoles replaces managed roles of given space separated roles with granted ones
+func mergeRoles(roles string, granted, managed []string) string {
+	merged := lo.Filter(strings.Fields(roles), func(role string, _ int) bool {
+		return !lo.Contains(managed, role)
+	})
+	return strings.Join(lo.Uniq(append(merged, granted...)), " ")
+}
+
+// isStaff checks whether given roles granted by the identity provider make the user a staff member
+func isStaff(roles []string) bool {
+	return len(lo.Without(roles, model_helper.SystemUserRoleId, model_helper.SystemGuestRoleId)) > 0
+}
+
+// isEncrypted checks whether given base64 encoded response carries encrypted assertions
+func isEncrypted(encodedXML string) bool {
+	raw, err := base64.StdEncoding.DecodeString(encodedXML)
+	if err != nil {
+		return false
+	}
+
+	var response struct {
+		EncryptedAssertions []struct{} `xml:"EncryptedAssertion"`
+	}
+	if err := xml.Unmarshal(raw, &response); err != nil {
+		return false
+	}
+	return len(response.EncryptedAssertions) > 0
+}
+
+// DoLogin validates given response of the identity provider, then returns the asserted user saved with its latest
+// attributes and roles. Staff users are created on their first login when EnableUserCreation is on.
+func (sm *Saml) DoLogin(c *request.Context, encodedXML string, relayState map[string]string) (*model.User, *model_helper.AppError) {
+	sp, appErr := sm.serviceProvider("DoLogin")
+	if appErr != nil {
+		return nil, appErr
+	}
+	settings := sm.settings()
+
+	if *settings.Encrypt && !isEncrypted(encodedXML) {
+		return nil, model_helper.NewAppError("DoLogin", "ent.saml.do_login.not_encrypted.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	info, err := sp.RetrieveAssertionInfo(encodedXML)
+	if err != nil {
+		return nil, model_helper.NewAppError("DoLogin", "ent.saml.do_login.validate.app_error", nil, err.Error(), http.StatusUnauthorized)
+	}
+	if info.WarningInfo.InvalidTime {
+		return nil, model_helper.NewAppError("DoLogin", "ent.saml.do_login.validate.app_error", nil, "assertion expired", http.StatusUnauthorized)
+	}
+	if info.WarningInfo.NotInAudience {
+		return nil, model_helper.NewAppError("DoLogin", "ent.saml.do_login.validate.app_error", nil, "assertion not issued for "+*settings.ServiceProviderIdentifier, http.StatusUnauthorized)
+	}
+	for _, assertion := range info.Assertions {
+		if appErr := sm.checkAssertion(assertion); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	samlUser := userFromAssertion(settings, info.Values)
+	if samlUser.AuthData.IsNil() || *samlUser.AuthData.String == "" {
+		return nil, model_helper.NewAppError("DoLogin", "ent.saml.missing_attribute.app_error", map[string]any{"Attribute": lo.Ternary(*settings.IdAttribute != "", *settings.IdAttribute, *settings.EmailAttribute)}, "", http.StatusBadRequest)
+	}
+
+	user, appErr := sm.userByAuthData(*samlUser.AuthData.String)
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	if user == nil {
+		user, appErr = sm.createUser(settings, info.Values, samlUser)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return sm.updateUser(settings, info.Values, user)
+}
+
+// checkAssertion makes sure given validated assertion is used once, and answers an authentication request sent
+// by BuildRequest when it is not IdP-initiated.
+func (sm *Saml) checkAssertion(assertion types.Assertion) *model_helper.AppError {
+	if assertion.ID == "" {
+		return model_helper.NewAppError("DoLogin", "ent.saml.do_login.validate.app_error", nil, "assertion has no id", http.StatusUnauthorized)
+	}
+
+	var used bool
+	if err := sm.assertions.Get(assertion.ID, &used); err == nil {
+		return model_helper.NewAppError("DoLogin", "ent.saml.do_login.replayed.app_error", nil, "assertion_id="+assertion.ID, http.StatusUnauthorized)
+	}
+
+	if assertion.Subject != nil && assertion.Subject.SubjectConfirmation != nil && assertion.Subject.SubjectConfirmation.SubjectConfirmationData != nil {
+		if requestID := assertion.Subject.SubjectConfirmation.SubjectConfirmationData.InResponseTo; requestID != "" {
+			var sent bool
+			if err := sm.requests.Get(requestID, &sent); err != nil {
+				return model_helper.NewAppError("DoLogin", "ent.saml.do_login.unknown_request.app_error", nil, "in_response_to="+requestID, http.StatusUnauthorized)
+			}
+			sm.requests.Remove(requestID)
+		}
+	}
+
+	// assertions are remembered until they expire, later they fail validation anyway
+	expiry := assertionLifetime
+	if assertion.Conditions != nil {
+		if notOnOrAfter, err := time.Parse(time.RFC3339, assertion.Conditions.NotOnOrAfter); err == nil && time.Until(notOnOrAfter) > 0 {
+			expiry = time.Until(notOnOrAfter)
+		}
+	}
+	if err := sm.assertions.SetWithExpiry(assertion.ID, true, expiry); err != nil {
+		return model_helper.NewAppError("DoLogin", "ent.saml.do_login.validate.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// createUser provisions given user on its first login. Only staff users are created, when EnableUserCreation is on.
+func (sm *Saml) createUser(settings *model_helper.SamlSettings, values saml2.Values, samlUser *model.User) (*model.User, *model_helper.AppError) {
+	if !*settings.EnableUserCreation || !isStaff(rolesForAssertion(settings, values)) {
+		return nil, model_helper.NewAppError("createUser", "ent.saml.do_login.user_not_found.app_error", nil, "auth_data="+*samlUser.AuthData.String, http.StatusForbidden)
+	}
+	if samlUser.Email == "" {
+		return nil, model_helper.NewAppError("createUser", "ent.saml.missing_attribute.app_error", map[string]any{"Attribute": *settings.EmailAttribute}, "", http.StatusBadRequest)
+	}
+
+	// accounts of other services must be migrated first, see the migrate_auth command
+	existing, appErr := sm.srv.Account.GetUserByOptions(model_helper.UserFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.UserWhere.Email.EQ(samlUser.Email),
+		),
+	})
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+	if existing != nil {
+		return nil, model_helper.NewAppError("createUser", "ent.saml.do_login.email_in_use.app_error", map[string]any{"Email": samlUser.Email}, "user_id="+existing.ID, http.StatusConflict)
+	}
+
+	if samlUser.Username == "" {
+		samlUser.Username = model_helper.CleanUsername(strings.Split(samlUser.Email, "@")[0])
+	}
+	return sm.srv.Account.CreateUser(*request.EmptyContext(), *samlUser)
+}
+
+// updateUser updates given user with attributes and roles asserted in given values
+func (sm *Saml) updateUser(settings *model_helper.SamlSettings, values saml2.Values, user *model.User) (*model.User, *model_helper.AppError) {
+	samlUser := userFromAssertion(settings, values)
+
+	changed := false
+	for _, field := range []struct{ current, saml *string }{
+		{&user.Email, &samlUser.Email},
+		{&user.FirstName, &samlUser.FirstName},
+		{&user.LastName, &samlUser.LastName},
+		{&user.Nickname, &samlUser.Nickname},
+	} {
+		if *field.saml != "" && *field.current != *field.saml {
+			*field.current = *field.saml
+			changed = true
+		}
+	}
+	if samlUser.Locale != "" && user.Locale != samlUser.Locale {
+		user.Locale = samlUser.Locale
+		changed = true
+	}
+
+	var appErr *model_helper.AppError
+	if changed {
+		user, appErr = sm.srv.Account.UpdateUser(*user, false)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if roles := mergeRoles(user.Roles, rolesForAssertion(settings, values), managedRoles(settings)); roles != user.Roles {
+		user, appErr = sm.srv.Account.UpdateUserRolesWithUser(*user, roles, false)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return user, nil
+}
+
+func (sm *Saml) userByAuthData(authData string) (*model.User, *model_helper.AppError) {
+	return sm.srv.Account.GetUserByOptions(model_helper.UserFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.UserWhere.AuthData.EQ(model_types.NewNullString(authData)),
+			model.UserWhere.AuthService.EQ(model_helper.USER_AUTH_SERVICE_SAML),
+		),
+	})
+}
+
+// CheckProviderAttributes returns name of the first field of given patch which is asserted by the identity provider,
+// such fields would be overwritten on the next login. An empty string is returned when there is none.
+func (sm *Saml) CheckProviderAttributes(SS model_helper.SamlSettings, user model.User, patch model_helper.UserPatch) string {
+	for _, field := range []struct {
+		name      string
+		attribute *string
+		current   string
+		patched   *string
+	}{
+		{"email", SS.EmailAttribute, user.Email, patch.Email},
+		{"username", SS.UsernameAttribute, user.Username, patch.Username},
+		{"first_name", SS.FirstNameAttribute, user.FirstName, patch.FirstName},
+		{"last_name", SS.LastNameAttribute, user.LastName, patch.LastName},
+		{"nickname", SS.NicknameAttribute, user.Nickname, patch.Nickname},
+	} {
+		if field.patched != nil && *field.patched != field.current && field.attribute != nil && *field.attribute != "" {
+			return field.name
+		}
+	}
+	if patch.Locale != nil && *patch.Locale != user.Locale && SS.LocaleAttribute != nil && *SS.LocaleAttribute != "" {
+		return "locale"
+	}
+	return ""
+}
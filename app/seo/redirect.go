@@ -0,0 +1,99 @@
+package seo
+
+import (
+	"net/http"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ResolveSlugRedirect finds the current slug of the object of given type that had oldSlug before being renamed.
+// oldSlug is returned as is when an object of the type currently has it, and a not found error is returned
+// if no object of the type was ever known by the slug.
+func (s *ServiceSeo) ResolveSlugRedirect(objectType model_helper.SeoObjectType, oldSlug string) (string, *model_helper.AppError) {
+	if !model_helper.SeoObjectTypeHasSlugRedirects(objectType) {
+		return "", model_helper.NewAppError("ResolveSlugRedirect", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "objectType"}, "", http.StatusBadRequest)
+	}
+
+	// objects created after a rename may take over old slugs of other objects
+	slug, err := s.findObjectSlug(objectType, oldSlug, "")
+	if err == nil {
+		return slug, nil
+	}
+	if _, ok := err.(*store.ErrNotFound); !ok {
+		return "", slugRedirectAppError(err)
+	}
+
+	redirect, err := s.srv.Store.SlugRedirect().GetByOldSlug(objectType, oldSlug)
+	if err != nil {
+		return "", slugRedirectAppError(err)
+	}
+
+	slug, err = s.findObjectSlug(objectType, "", redirect.ObjectID)
+	if err != nil {
+		return "", slugRedirectAppError(err)
+	}
+	return slug, nil
+}
+
+// findObjectSlug finds the slug of the object of given type having given slug or id
+func (s *ServiceSeo) findObjectSlug(objectType model_helper.SeoObjectType, slug, id string) (string, error) {
+	var slugs []string
+
+	switch objectType {
+	case model_helper.SeoObjectTypeProduct:
+		conds := []qm.QueryMod{model.ProductWhere.Slug.EQ(slug)}
+		if id != "" {
+			conds = []qm.QueryMod{model.ProductWhere.ID.EQ(id)}
+		}
+		products, err := s.srv.Store.Product().FilterByOption(model_helper.ProductFilterOption{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(conds...),
+		})
+		if err != nil {
+			return "", err
+		}
+		slugs = lo.Map(products, func(item *model.Product, _ int) string { return item.Slug })
+
+	case model_helper.SeoObjectTypeCategory:
+		conds := []qm.QueryMod{model.CategoryWhere.Slug.EQ(slug)}
+		if id != "" {
+			conds = []qm.QueryMod{model.CategoryWhere.ID.EQ(id)}
+		}
+		categories, err := s.srv.Store.Category().FilterByOption(model_helper.CategoryFilterOption{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(conds...),
+		})
+		if err != nil {
+			return "", err
+		}
+		slugs = lo.Map(categories, func(item *model.Category, _ int) string { return item.Slug })
+
+	case model_helper.SeoObjectTypeCollection:
+		conds := []qm.QueryMod{model.CollectionWhere.Slug.EQ(slug)}
+		if id != "" {
+			conds = []qm.QueryMod{model.CollectionWhere.ID.EQ(id)}
+		}
+		collections, err := s.srv.Store.Collection().FilterByOption(model_helper.CollectionFilterOptions{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(conds...),
+		})
+		if err != nil {
+			return "", err
+		}
+		slugs = lo.Map(collections, func(item *model_helper.CustomCollection, _ int) string { return item.Slug })
+	}
+
+	if len(slugs) == 0 {
+		return "", store.NewErrNotFound(string(objectType), slug+id)
+	}
+	return slugs[0], nil
+}
+
+func slugRedirectAppError(err error) *model_helper.AppError {
+	statusCode := http.StatusInternalServerError
+	if _, ok := err.(*store.ErrNotFound); ok {
+		statusCode = http.StatusNotFound
+	}
+	return model_helper.NewAppError("ResolveSlugRedirect", "app.seo.error_resolving_slug_redirect.app_error", nil, err.Error(), statusCode)
+}
@@ -1 +1,249 @@
 package seo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mattermost/squirrel"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/util"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ProductStructuredData returns JSON-LD Product (with its Offer) and BreadcrumbList documents of given product's
+// page in storefront of the channel. Prices come from the product availability in the channel, taxed for the
+// channel's default country. Offers are left out when the product has no prices in the channel.
+func (s *ServiceSeo) ProductStructuredData(productID, channelSlug string) (*model_helper.ProductStructuredData, *model_helper.AppError) {
+	if !model_helper.IsValidId(productID) {
+		return nil, model_helper.NewAppError("ProductStructuredData", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "productID"}, "", http.StatusBadRequest)
+	}
+
+	channel, appErr := s.activeChannelBySlug(channelSlug)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	products, err := s.srv.Store.Product().FilterByOption(model_helper.ProductFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductWhere.ID.EQ(productID)),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductStructuredData", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(products) == 0 {
+		return nil, model_helper.NewAppError("ProductStructuredData", "app.seo.product_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+	product := products[0]
+
+	productURL := s.storefrontURL(channel.Slug, model_helper.SeoObjectTypeProduct, product.Slug)
+	productJsonLd := &model_helper.ProductJsonLd{
+		Context:     model_helper.SchemaOrgContext,
+		Type:        model_helper.SchemaOrgTypeProduct,
+		Name:        product.Name,
+		Description: product.SeoDescription,
+		URL:         productURL,
+	}
+	if productJsonLd.Description == "" {
+		productJsonLd.Description = util.EditorJSPlainText(product.Description)
+	}
+
+	medias, err := s.srv.Store.ProductMedia().FilterByOption(model_helper.ProductMediaFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ProductMediumWhere.ProductID.EQ(product.ID),
+			model.ProductMediumWhere.Type.EQ(model.ProductMediaTypeIMAGE),
+			qm.OrderBy(model.ProductMediumColumns.SortOrder+" ASC"),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("ProductStructuredData", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, media := range medias {
+		if !media.ExternalURL.IsNil() && *media.ExternalURL.String != "" {
+			productJsonLd.Image = append(productJsonLd.Image, *media.ExternalURL.String)
+		} else if media.Image != "" {
+			productJsonLd.Image = append(productJsonLd.Image, model_helper.ProductMediaImageURL(*s.srv.Config().ServiceSettings.SiteURL, media.Image))
+		}
+	}
+
+	offer, variants, appErr := s.productOffer(*product, *channel, productURL)
+	if appErr != nil {
+		return nil, appErr
+	}
+	productJsonLd.Offers = offer
+	if len(variants) == 1 {
+		productJsonLd.Sku = variants[0].Sku
+	}
+
+	breadcrumbs, appErr := s.productBreadcrumbs(*product, *channel, productURL)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &model_helper.ProductStructuredData{
+		Product:     productJsonLd,
+		Breadcrumbs: breadcrumbs,
+	}, nil
+}
+
+// productOffer builds the offer of given product in the channel, nil when the product has no prices in the channel.
+// Variants of the product are returned too.
+func (s *ServiceSeo) productOffer(product model.Product, channel model.Channel, productURL string) (*model_helper.OfferJsonLd, model.ProductVariantSlice, *model_helper.AppError) {
+	productChannelListings, err := s.srv.Store.ProductChannelListing().FilterByOption(model_helper.ProductChannelListingFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ProductChannelListingWhere.ProductID.EQ(product.ID),
+			model.ProductChannelListingWhere.ChannelID.EQ(channel.ID),
+		),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError("productOffer", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(productChannelListings) == 0 {
+		return nil, nil, nil
+	}
+
+	variants, err := s.srv.Store.ProductVariant().FilterByOption(model_helper.ProductVariantFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductVariantWhere.ProductID.EQ(product.ID)),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError("productOffer", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	variantChannelListings, err := s.srv.Store.ProductVariantChannelListing().FilterbyOption(model_helper.ProductVariantChannelListingFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductVariantChannelListingWhere.ChannelID.EQ(channel.ID)),
+		VariantProductID:   model.ProductVariantWhere.ProductID.EQ(product.ID),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError("productOffer", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(variantChannelListings) == 0 {
+		return nil, variants, nil
+	}
+
+	customCollections, err := s.srv.Store.Collection().FilterByOption(model_helper.CollectionFilterOptions{
+		ProductID: model.ProductCollectionWhere.ProductID.EQ(product.ID),
+	})
+	if err != nil {
+		return nil, nil, model_helper.NewAppError("productOffer", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	collections := lo.Map(customCollections, func(item *model_helper.CustomCollection, _ int) *model.Collection { return &item.Collection })
+
+	discounts, appErr := s.srv.Discount.FetchActiveDiscounts()
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	availability, appErr := s.srv.Product.GetProductAvailability(
+		product,
+		productChannelListings[0],
+		variants,
+		variantChannelListings,
+		collections,
+		discounts,
+		channel,
+		s.srv.Plugin.GetPluginManager(),
+		channel.DefaultCountry,
+		"",
+	)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	if availability.PriceRange == nil {
+		return nil, variants, nil
+	}
+
+	inStock, appErr := s.productInStock(variants, channel)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	start := availability.PriceRange.GetStart()
+	stop := availability.PriceRange.GetStop()
+	lowPrice := start.GetGross()
+	highPrice := stop.GetGross()
+
+	offer := &model_helper.OfferJsonLd{
+		Type:          model_helper.SchemaOrgTypeOffer,
+		PriceCurrency: lowPrice.GetCurrency(),
+		Availability:  model_helper.SchemaOrgOutOfStock,
+		URL:           productURL,
+	}
+	if inStock {
+		offer.Availability = model_helper.SchemaOrgInStock
+	}
+	if lowPrice.GetAmount().Equal(highPrice.GetAmount()) {
+		offer.Price = lowPrice.GetAmount().String()
+	} else {
+		offer.Type = model_helper.SchemaOrgTypeAggregate
+		offer.LowPrice = lowPrice.GetAmount().String()
+		offer.HighPrice = highPrice.GetAmount().String()
+	}
+
+	return offer, variants, nil
+}
+
+// productInStock tells if any of given variants has quantity left in warehouses of the channel
+func (s *ServiceSeo) productInStock(variants model.ProductVariantSlice, channel model.Channel) (bool, *model_helper.AppError) {
+	if len(variants) == 0 {
+		return false, nil
+	}
+
+	stocks, err := s.srv.Store.Stock().FilterForChannel(model_helper.StockFilterForChannelOption{
+		ChannelID:  channel.ID,
+		Conditions: squirrel.Eq{model.StockTableColumns.ProductVariantID: lo.Map(variants, func(item *model.ProductVariant, _ int) string { return item.ID })},
+	})
+	if err != nil {
+		return false, model_helper.NewAppError("productInStock", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return lo.SomeBy(stocks, func(stock *model.Stock) bool { return stock.Quantity > stock.QuantityAllocated }), nil
+}
+
+// productBreadcrumbs builds breadcrumbs of given product's page: the channel's storefront home,
+// categories from the root category down to the product's category, then the product.
+func (s *ServiceSeo) productBreadcrumbs(product model.Product, channel model.Channel, productURL string) (*model_helper.BreadcrumbListJsonLd, *model_helper.AppError) {
+	var categories model.CategorySlice // from the product's category up to the root
+
+	categoryID := product.CategoryID
+	for categoryID != "" && len(categories) < maxBreadcrumbsCategoryDepth {
+		category, err := s.srv.Store.Category().Get(context.Background(), categoryID, true)
+		if err != nil {
+			return nil, model_helper.NewAppError("productBreadcrumbs", "app.seo.error_finding_structured_data_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		categories = append(categories, category)
+
+		categoryID = ""
+		if !category.ParentID.IsNil() {
+			categoryID = *category.ParentID.String
+		}
+	}
+
+	items := []*model_helper.ListItemJsonLd{{
+		Name: channel.Name,
+		Item: s.absoluteURL("/" + channel.Slug + "/"),
+	}}
+	for idx := len(categories) - 1; idx >= 0; idx-- {
+		items = append(items, &model_helper.ListItemJsonLd{
+			Name: categories[idx].Name,
+			Item: s.storefrontURL(channel.Slug, model_helper.SeoObjectTypeCategory, categories[idx].Slug),
+		})
+	}
+	items = append(items, &model_helper.ListItemJsonLd{
+		Name: product.Name,
+		Item: productURL,
+	})
+
+	for idx, item := range items {
+		item.Type = model_helper.SchemaOrgTypeListItem
+		item.Position = idx + 1
+	}
+
+	return &model_helper.BreadcrumbListJsonLd{
+		Context:         model_helper.SchemaOrgContext,
+		Type:            model_helper.SchemaOrgTypeBreadcrumbs,
+		ItemListElement: items,
+	}, nil
+}
+
+// maxBreadcrumbsCategoryDepth guards against cycles in category trees
+const maxBreadcrumbsCategoryDepth = 20
@@ -0,0 +1,77 @@
+package seo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductStructuredData(t *testing.T) {
+	channel := &model.Channel{ID: model_helper.NewId(), Slug: "default-channel", Name: "Default", IsActive: true}
+	clothes := &model.Category{ID: model_helper.NewId(), Name: "Clothes", Slug: "clothes"}
+	shirts := &model.Category{ID: model_helper.NewId(), Name: "Shirts", Slug: "shirts", ParentID: model_types.NewNullString(clothes.ID)}
+	product := &model.Product{ID: model_helper.NewId(), Name: "Red Shirt", Slug: "red-shirt", SeoDescription: "A red shirt", CategoryID: shirts.ID}
+	imagePath := model_helper.ProductMediaOriginalImagePath(product.ID, model_helper.NewId(), "jpg")
+
+	service, mockStore := newTestService(t, channel)
+
+	products := &mocks.ProductStore{}
+	products.On("FilterByOption", mock.Anything).Return(model.ProductSlice{product}, nil)
+	medias := &mocks.ProductMediaStore{}
+	medias.On("FilterByOption", mock.Anything).Return(model.ProductMediumSlice{
+		{Image: imagePath},
+		{ExternalURL: model_types.NewNullString("https://cdn.example.com/red-shirt.jpg")},
+	}, nil)
+	// the product is not listed in the channel, so it has no offer
+	listings := &mocks.ProductChannelListingStore{}
+	listings.On("FilterByOption", mock.Anything).Return(model.ProductChannelListingSlice{}, nil)
+	categories := &mocks.CategoryStore{}
+	categories.On("Get", mock.Anything, shirts.ID, true).Return(shirts, nil)
+	categories.On("Get", mock.Anything, clothes.ID, true).Return(clothes, nil)
+
+	mockStore.On("Product").Return(products)
+	mockStore.On("ProductMedia").Return(medias)
+	mockStore.On("ProductChannelListing").Return(listings)
+	mockStore.On("Category").Return(categories)
+
+	data, appErr := service.ProductStructuredData(product.ID, channel.Slug)
+	require.Nil(t, appErr)
+
+	productURL := testSiteURL + "/default-channel/products/red-shirt/"
+	require.Equal(t, &model_helper.ProductJsonLd{
+		Context:     model_helper.SchemaOrgContext,
+		Type:        model_helper.SchemaOrgTypeProduct,
+		Name:        "Red Shirt",
+		Description: "A red shirt",
+		URL:         productURL,
+		// uploaded images are served by the media endpoint, external ones are kept as is
+		Image: []string{testSiteURL + "/media/" + imagePath, "https://cdn.example.com/red-shirt.jpg"},
+	}, data.Product)
+
+	require.Equal(t, []string{"Default", "Clothes", "Shirts", "Red Shirt"}, lo.Map(data.Breadcrumbs.ItemListElement, func(item *model_helper.ListItemJsonLd, _ int) string { return item.Name }))
+	require.Equal(t, []string{
+		testSiteURL + "/default-channel/",
+		testSiteURL + "/default-channel/categories/clothes/",
+		testSiteURL + "/default-channel/categories/shirts/",
+		productURL,
+	}, lo.Map(data.Breadcrumbs.ItemListElement, func(item *model_helper.ListItemJsonLd, _ int) string { return item.Item }))
+	require.Equal(t, 4, data.Breadcrumbs.ItemListElement[3].Position)
+
+	t.Run("unknown product", func(t *testing.T) {
+		products := &mocks.ProductStore{}
+		products.On("FilterByOption", mock.Anything).Return(model.ProductSlice{}, nil)
+		service, mockStore := newTestService(t, channel)
+		mockStore.On("Product").Return(products)
+
+		_, appErr := service.ProductStructuredData(model_helper.NewId(), channel.Slug)
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
@@ -5,17 +5,32 @@ so the init() function get the chance to register a function to create `ServiceA
 package seo
 
 import (
+	"time"
+
 	"github.com/sitename/sitename/app"
 	"github.com/sitename/sitename/app/sub_app_iface"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/services/cache"
+)
+
+const (
+	// sitemap urls of a channel are listed at most once per this duration, which is also how long clients cache sitemaps
+	sitemapCacheExpiry = time.Hour
+	sitemapCacheSize   = 100 // number of channels
 )
 
 type ServiceSeo struct {
 	srv *app.Server
+	// sitemapCache holds urls of sitemaps, keyed by channel ids
+	sitemapCache cache.Cache
 }
 
 func init() {
 	app.RegisterService(func(s *app.Server) error {
-		s.Seo = &ServiceSeo{s}
+		s.Seo = &ServiceSeo{
+			srv:          s,
+			sitemapCache: newSitemapCache(s),
+		}
 		return nil
 	})
 }
@@ -26,6 +41,29 @@ type ServiceSeoConfig struct {
 
 func NewServiceSeo(config *ServiceSeoConfig) sub_app_iface.SeoService {
 	return &ServiceSeo{
-		srv: config.Server,
+		srv:          config.Server,
+		sitemapCache: newSitemapCache(config.Server),
+	}
+}
+
+// newSitemapCache creates the sitemap cache with the server's cache provider, so every node shares it when
+// caches are kept in redis. An in-memory cache is used if the provider fails.
+func newSitemapCache(s *app.Server) cache.Cache {
+	if s.CacheProvider != nil {
+		res, err := s.CacheProvider.NewCache(&cache.CacheOptions{
+			Name:          "Sitemaps",
+			Size:          sitemapCacheSize,
+			DefaultExpiry: sitemapCacheExpiry,
+		})
+		if err == nil {
+			return res
+		}
+		slog.Error("Failed to create sitemap cache, falling back to in-memory cache", slog.Err(err))
 	}
+
+	return cache.NewLRU(cache.LRUOptions{
+		Name:          "Sitemaps",
+		Size:          sitemapCacheSize,
+		DefaultExpiry: sitemapCacheExpiry,
+	})
 }
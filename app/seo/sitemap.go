@@ -0,0 +1,203 @@
+package seo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// SitemapIndex returns the sitemap index of given channel's storefront, in XML.
+// The index lists sitemap files of the channel, each holding at most model_helper.SitemapMaxURLs urls.
+func (s *ServiceSeo) SitemapIndex(channelSlug string) ([]byte, *model_helper.AppError) {
+	channel, appErr := s.activeChannelBySlug(channelSlug)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	urls, appErr := s.sitemapURLs(channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	numOfSitemaps := max(1, (len(urls)+model_helper.SitemapMaxURLs-1)/model_helper.SitemapMaxURLs)
+	entries := make([]*model_helper.SitemapIndexEntry, numOfSitemaps)
+	for idx := range entries {
+		entries[idx] = &model_helper.SitemapIndexEntry{
+			Loc: s.absoluteURL(SitemapPath(channel.Slug, idx+1)),
+		}
+	}
+
+	return marshalSitemapXML("SitemapIndex", model_helper.NewSitemapIndex(entries))
+}
+
+// Sitemap returns given sitemap file of given channel's storefront in XML, number starts at 1.
+// Sitemaps list published pages, categories, collections published in the channel and products published in the channel.
+func (s *ServiceSeo) Sitemap(channelSlug string, number int) ([]byte, *model_helper.AppError) {
+	channel, appErr := s.activeChannelBySlug(channelSlug)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	urls, appErr := s.sitemapURLs(channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	start := (number - 1) * model_helper.SitemapMaxURLs
+	if number < 1 || (start >= len(urls) && number != 1) {
+		return nil, model_helper.NewAppError("Sitemap", "app.seo.sitemap_not_found.app_error", map[string]any{"Number": number}, "", http.StatusNotFound)
+	}
+	end := min(start+model_helper.SitemapMaxURLs, len(urls))
+
+	return marshalSitemapXML("Sitemap", model_helper.NewSitemapURLSet(urls[start:end]))
+}
+
+// SitemapIndexPath returns path of sitemap index of a channel, e.g "/sitemaps/default-channel.xml"
+func SitemapIndexPath(channelSlug string) string {
+	return fmt.Sprintf("/sitemaps/%s.xml", channelSlug)
+}
+
+// SitemapPath returns path of given sitemap file of a channel, e.g "/sitemaps/default-channel/1.xml"
+func SitemapPath(channelSlug string, number int) string {
+	return fmt.Sprintf("/sitemaps/%s/%d.xml", channelSlug, number)
+}
+
+// sitemapURLs returns urls listed in sitemaps of given channel. They are cached for sitemapCacheExpiry,
+// so sitemap files requested one after another don't each scan the whole catalogue.
+func (s *ServiceSeo) sitemapURLs(channel *model.Channel) ([]*model_helper.SitemapURL, *model_helper.AppError) {
+	var urls []*model_helper.SitemapURL
+	if err := s.sitemapCache.Get(channel.ID, &urls); err == nil {
+		return urls, nil
+	}
+
+	urls, appErr := s.listSitemapURLs(channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.sitemapCache.SetWithDefaultExpiry(channel.ID, urls); err != nil {
+		slog.Warn("Failed to cache sitemap urls", slog.String("channel_id", channel.ID), slog.Err(err))
+	}
+	return urls, nil
+}
+
+func (s *ServiceSeo) listSitemapURLs(channel *model.Channel) ([]*model_helper.SitemapURL, *model_helper.AppError) {
+	now := time.Now().UTC()
+	var urls []*model_helper.SitemapURL
+
+	pages, err := s.srv.Store.Page().FilterByOptions(model_helper.PageFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.PageWhere.IsPublished.EQ(true),
+			qm.Expr(
+				model.PageWhere.PublicationDate.IsNull(),
+				qm.Or2(model.PageWhere.PublicationDate.LTE(model_types.NewNullTime(now))),
+			),
+			qm.OrderBy(model.PageColumns.Slug),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("listSitemapURLs", "app.seo.error_finding_sitemap_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, page := range pages {
+		urls = append(urls, &model_helper.SitemapURL{
+			Loc:     s.storefrontURL(channel.Slug, model_helper.SeoObjectTypePage, page.Slug),
+			LastMod: sitemapDate(page.CreatedAt),
+		})
+	}
+
+	categories, err := s.srv.Store.Category().FilterByOption(model_helper.CategoryFilterOption{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(qm.OrderBy(model.CategoryColumns.Slug)),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("listSitemapURLs", "app.seo.error_finding_sitemap_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, category := range categories {
+		urls = append(urls, &model_helper.SitemapURL{
+			Loc: s.storefrontURL(channel.Slug, model_helper.SeoObjectTypeCategory, category.Slug),
+		})
+	}
+
+	collections, err := s.srv.Store.Collection().FilterByOption(model_helper.CollectionFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(qm.OrderBy(model.CollectionTableColumns.Slug)),
+		RelatedCollectionChannelListingConds: qm.Expr(
+			model.CollectionChannelListingWhere.ChannelID.EQ(model_types.NewNullString(channel.ID)),
+			model.CollectionChannelListingWhere.IsPublished.EQ(model_types.NewNullBool(true)),
+			qm.Expr(
+				model.CollectionChannelListingWhere.PublicationDate.IsNull(),
+				qm.Or2(model.CollectionChannelListingWhere.PublicationDate.LTE(model_types.NewNullTime(now))),
+			),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("listSitemapURLs", "app.seo.error_finding_sitemap_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, collection := range collections {
+		urls = append(urls, &model_helper.SitemapURL{
+			Loc: s.storefrontURL(channel.Slug, model_helper.SeoObjectTypeCollection, collection.Slug),
+		})
+	}
+
+	products, err := s.srv.Store.Product().PublishedProducts(channel.Slug)
+	if err != nil {
+		return nil, model_helper.NewAppError("listSitemapURLs", "app.seo.error_finding_sitemap_objects.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, product := range products {
+		urls = append(urls, &model_helper.SitemapURL{
+			Loc:     s.storefrontURL(channel.Slug, model_helper.SeoObjectTypeProduct, product.Slug),
+			LastMod: sitemapDate(product.UpdatedAt),
+		})
+	}
+
+	return urls, nil
+}
+
+func (s *ServiceSeo) activeChannelBySlug(channelSlug string) (*model.Channel, *model_helper.AppError) {
+	channels, err := s.srv.Store.Channel().FilterByOptions(model_helper.ChannelFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.ChannelWhere.Slug.EQ(channelSlug),
+			model.ChannelWhere.IsActive.EQ(true),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("activeChannelBySlug", "app.channel.error_finding_channels_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(channels) == 0 {
+		return nil, model_helper.NewAppError("activeChannelBySlug", "app.seo.channel_not_found.app_error", map[string]any{"Slug": channelSlug}, "", http.StatusNotFound)
+	}
+
+	return channels[0], nil
+}
+
+// storefrontURL returns absolute url of given object in storefront of the channel
+func (s *ServiceSeo) storefrontURL(channelSlug string, objectType model_helper.SeoObjectType, slug string) string {
+	return s.absoluteURL(model_helper.StorefrontPath(channelSlug, objectType, slug))
+}
+
+// absoluteURL joins given path with site url
+func (s *ServiceSeo) absoluteURL(path string) string {
+	return strings.TrimRight(*s.srv.Config().ServiceSettings.SiteURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// sitemapDate formats given milliseconds as W3C date, empty for zero
+func sitemapDate(millis int64) string {
+	if millis <= 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(time.DateOnly)
+}
+
+func marshalSitemapXML(where string, v any) ([]byte, *model_helper.AppError) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, model_helper.NewAppError(where, "app.seo.error_encoding_sitemap.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
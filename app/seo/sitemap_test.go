@@ -0,0 +1,99 @@
+package seo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/config"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSiteURL = "https://shop.example.com"
+
+// newTestService returns a seo service of a server whose store serves given active channels
+func newTestService(t *testing.T, channels ...*model.Channel) (*ServiceSeo, *mocks.Store) {
+	t.Helper()
+
+	memoryStore, err := config.NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := config.NewStoreFromBacking(memoryStore, nil, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { configStore.Close() })
+
+	cfg := configStore.Get().Clone()
+	cfg.ServiceSettings.SiteURL = model_helper.GetPointerOfValue(testSiteURL)
+	_, _, err = configStore.Set(cfg)
+	require.NoError(t, err)
+
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice(channels), nil)
+
+	mockStore := &mocks.Store{}
+	mockStore.On("Channel").Return(channelStore)
+
+	service := NewServiceSeo(&ServiceSeoConfig{Server: &app.Server{Store: mockStore, ConfigStore: configStore}}).(*ServiceSeo)
+	return service, mockStore
+}
+
+func TestSitemap(t *testing.T) {
+	channel := &model.Channel{ID: model_helper.NewId(), Slug: "default-channel", Name: "Default", IsActive: true}
+	service, mockStore := newTestService(t, channel)
+
+	pages := &mocks.PageStore{}
+	pages.On("FilterByOptions", mock.Anything).Return(model.PageSlice{{Slug: "about"}}, nil)
+	categories := &mocks.CategoryStore{}
+	categories.On("FilterByOption", mock.Anything).Return(model.CategorySlice{{Slug: "shirts"}}, nil)
+	collections := &mocks.CollectionStore{}
+	collections.On("FilterByOption", mock.Anything).Return(model_helper.CustomCollectionSlice{}, nil)
+
+	// one more product than a sitemap file holds
+	products := make(model.ProductSlice, model_helper.SitemapMaxURLs-1)
+	for idx := range products {
+		products[idx] = &model.Product{Slug: fmt.Sprintf("product-%d", idx)}
+	}
+	productStore := &mocks.ProductStore{}
+	productStore.On("PublishedProducts", channel.Slug).Return(products, nil)
+
+	mockStore.On("Page").Return(pages)
+	mockStore.On("Category").Return(categories)
+	mockStore.On("Collection").Return(collections)
+	mockStore.On("Product").Return(productStore)
+
+	index, appErr := service.SitemapIndex(channel.Slug)
+	require.Nil(t, appErr)
+	require.Contains(t, string(index), "<loc>"+testSiteURL+"/sitemaps/default-channel/1.xml</loc>")
+	require.Contains(t, string(index), "<loc>"+testSiteURL+"/sitemaps/default-channel/2.xml</loc>")
+	require.NotContains(t, string(index), "3.xml")
+
+	first, appErr := service.Sitemap(channel.Slug, 1)
+	require.Nil(t, appErr)
+	require.Equal(t, model_helper.SitemapMaxURLs, strings.Count(string(first), "<url>"))
+	require.Contains(t, string(first), "<loc>"+testSiteURL+"/default-channel/pages/about/</loc>")
+
+	second, appErr := service.Sitemap(channel.Slug, 2)
+	require.Nil(t, appErr)
+	require.Equal(t, 1, strings.Count(string(second), "<url>"))
+
+	_, appErr = service.Sitemap(channel.Slug, 3)
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	// urls are listed once for every sitemap of the channel
+	productStore.AssertNumberOfCalls(t, "PublishedProducts", 1)
+	pages.AssertNumberOfCalls(t, "FilterByOptions", 1)
+}
+
+func TestSitemapOfUnknownChannel(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, appErr := service.SitemapIndex("unknown")
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
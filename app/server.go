@@ -38,7 +38,12 @@ import (
 	"github.com/sitename/sitename/modules/config"
 	"github.com/sitename/sitename/modules/i18n"
 	"github.com/sitename/sitename/modules/jobs"
+	"github.com/sitename/sitename/modules/jobs/abandoned_checkouts"
 	"github.com/sitename/sitename/modules/jobs/active_users"
+	"github.com/sitename/sitename/modules/jobs/expire_orders"
+	"github.com/sitename/sitename/modules/jobs/export_process"
+	"github.com/sitename/sitename/modules/jobs/import_process"
+	"github.com/sitename/sitename/modules/jobs/product_recommendations"
 	"github.com/sitename/sitename/modules/mail"
 	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/modules/plugin"
@@ -293,10 +298,17 @@ func NewServer(options ...Option) (*Server, error) {
 	searchEngine.RegisterBleveEngine(bleveEngine)
 	s.SearchEngine = searchEngine
 
-	// at the moment we only have this implementation
-	// in the future the cache provider will be built based on the loaded config
 	// this must be created before registering sub services
-	s.CacheProvider = cache.NewProvider()
+	if cacheSettings := s.Config().CacheSettings; *cacheSettings.CacheType == model_helper.CACHE_TYPE_REDIS {
+		s.CacheProvider = cache.NewRedisProvider(cache.RedisOptions{
+			Address:   *cacheSettings.RedisAddress,
+			Password:  *cacheSettings.RedisPassword,
+			DB:        *cacheSettings.RedisDB,
+			KeyPrefix: *cacheSettings.RedisKeyPrefix,
+		})
+	} else {
+		s.CacheProvider = cache.NewProvider()
+	}
 	if err := s.CacheProvider.Connect(); err != nil {
 		return nil, errors.Wrapf(err, "Unable to connect to cache provider")
 	}
@@ -304,6 +316,7 @@ func NewServer(options ...Option) (*Server, error) {
 	var err error
 	if s.openGraphDataCache, err = s.CacheProvider.NewCache(&cache.CacheOptions{
 		Size: openGraphMetadataCacheSize,
+		Name: "OpenGraphData",
 	}); err != nil {
 		return nil, errors.Wrap(err, "Unable to create opengraphdata cache")
 	}
@@ -577,7 +590,7 @@ func (s *Server) initJobs() {
 	}
 
 	if jobsLdapSyncInterface != nil {
-		builder := jobsLdapSyncInterface(New())
+		builder := jobsLdapSyncInterface(New(ServerConnector(s)))
 		s.Jobs.RegisterJobType(model.JobTypeLdapSync, builder.MakeWorker(), builder.MakeScheduler())
 	}
 
@@ -593,6 +606,61 @@ func (s *Server) initJobs() {
 		active_users.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeAbandonedCheckouts,
+		abandoned_checkouts.MakeWorker(s.Jobs, func() error {
+			if appErr := s.Checkout.ProcessAbandonedCheckouts(); appErr != nil {
+				return appErr
+			}
+			return nil
+		}),
+		abandoned_checkouts.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeExpireOrders,
+		expire_orders.MakeWorker(s.Jobs, func() error {
+			if appErr := s.Order.ExpireOrders(); appErr != nil {
+				return appErr
+			}
+			return nil
+		}),
+		expire_orders.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeProductRecommendations,
+		product_recommendations.MakeWorker(s.Jobs, func() error {
+			if appErr := s.Product.ComputeProductRecommendations(); appErr != nil {
+				return appErr
+			}
+			return nil
+		}),
+		product_recommendations.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeExportProcess,
+		export_process.MakeWorker(s.Jobs, func(job model.Job) error {
+			if appErr := s.Csv.ProcessExportJob(job); appErr != nil {
+				return appErr
+			}
+			return nil
+		}),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeImportProcess,
+		import_process.MakeWorker(s.Jobs, func(job model.Job) error {
+			if appErr := s.Csv.ProcessImportJob(job); appErr != nil {
+				return appErr
+			}
+			return nil
+		}),
+		nil,
+	)
+
 	// s.Jobs.RegisterJobType(
 	// 	model.JobTypeMigrations,
 	// 	migrations.MakeWorker(s.Jobs, s.Store),
@@ -1431,6 +1499,10 @@ func (s *Server) InitMetricsRouter() error {
 	s.metricsRouter.HandleFunc("/", rootHandler)
 	s.metricsRouter.StrictSlash(true)
 
+	if s.Metrics != nil {
+		s.metricsRouter.Handle("/metrics", s.Metrics.Handler())
+	}
+
 	s.metricsRouter.Handle("/debug", http.RedirectHandler("/", http.StatusMovedPermanently))
 	s.metricsRouter.HandleFunc("/debug/pprof/", pprof.Index)
 	s.metricsRouter.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
@@ -81,6 +81,10 @@ type AccountService interface {
 	//
 	// otherwise: set number of failed attempts to 0
 	DoubleCheckPassword(user model.User, password string) *model_helper.AppError
+	// EraseUserData anonymizes given user, the user's addresses and personal details of the user's orders
+	// and payments, then removes the user's sessions, access tokens, wishlist items, checkouts and export files.
+	// Orders, their lines and totals are kept so accounting stays correct.
+	EraseUserData(user model.User) *model_helper.AppError
 	// ExtendSessionExpiryIfNeeded extends Session.ExpiresAt based on session lengths in config.
 	// A new ExpiresAt is only written if enough time has elapsed since last update.
 	// Returns true only if the session was extended.
@@ -163,6 +167,8 @@ type AccountService interface {
 	DisableUserAccessToken(token *model.UserAccessToken) *model_helper.AppError
 	DoLogin(c *request.Context, w http.ResponseWriter, r *http.Request, user model.User, deviceID string, isMobile, isOAuthUser, isSaml bool) *model_helper.AppError
 	EnableUserAccessToken(token *model.UserAccessToken) *model_helper.AppError
+	// FavoriteCategoryIDsOfUser returns ids of product categories the user marked as favourite
+	FavoriteCategoryIDsOfUser(userID string) ([]string, *model_helper.AppError)
 	FindUsersByOptions(options model_helper.UserFilterOptions) (model.UserSlice, *model_helper.AppError)
 	GenerateMfaSecret(userID string) (*model_helper.MfaSecret, *model_helper.AppError)
 	GetCloudSession(token string) (*model.Session, *model_helper.AppError)
@@ -196,6 +202,12 @@ type AccountService interface {
 	PatchRole(role model.Role, patch model_helper.RolePatch) (*model.Role, *model_helper.AppError)
 	PermanentDeleteAllUsers(c *request.Context) *model_helper.AppError
 	PermanentDeleteUser(c *request.Context, user model.User) *model_helper.AppError
+	// RecordDataPrivacyEvent records given data privacy step of given user both as a customer event
+	// and as an audit record of the user, so the step stays traceable after the user is anonymized.
+	RecordDataPrivacyEvent(userID string, eventType model.CustomerEventType, params model_types.JSONString) *model_helper.AppError
+	// RequestDataErasure saves a token confirming erasure of given user's personal data, then emails
+	// the user a link to given redirect url with the token attached.
+	RequestDataErasure(user model.User, redirectURL string) *model_helper.AppError
 	ResetPasswordFromToken(userSuppliedTokenString, newPassword string) *model_helper.AppError
 	ReturnSessionToPool(session *model.Session)
 	RevokeSessionsForDeviceId(userID string, deviceID string, currentSessionId string) *model_helper.AppError
@@ -209,6 +221,8 @@ type AccountService interface {
 	SendPasswordReset(email string, siteURL string) (bool, *model_helper.AppError)
 	SessionCacheLength() int
 	SetDefaultProfileImage(user *model.User) *model_helper.AppError
+	// SetFavoriteCategoriesOfUser replaces favourite product categories of the user with given ones
+	SetFavoriteCategoriesOfUser(userID string, categoryIDs []string) *model_helper.AppError
 	SetProfileImage(userID string, imageData *multipart.FileHeader) *model_helper.AppError
 	SetProfileImageFromFile(userID string, file io.Reader) *model_helper.AppError
 	SetProfileImageFromMultiPartFile(userID string, f multipart.File) *model_helper.AppError
@@ -237,6 +251,9 @@ type AccountService interface {
 	UpsertAddress(transaction store.ContextRunner, address model.Address) (*model.Address, *model_helper.AppError)
 	UserById(ctx context.Context, userID string) (*model.User, *model_helper.AppError)
 	UserSetDefaultAddress(userID, addressID string, addressType model_helper.AddressTypeEnum) (*model.User, *model_helper.AppError)
+	// ValidateAddress checks given address against the address rules of its country and returns a normalized copy of it.
+	// Returned app error wraps a model_helper.AddressFieldErrors so callers can report errors per field.
+	ValidateAddress(address model.Address) (*model.Address, *model_helper.AppError)
 	VerifyEmailFromToken(userSuppliedTokenString string) *model_helper.AppError
 	VerifyUserEmail(userID, email string) *model_helper.AppError
 }
@@ -91,11 +91,25 @@ type CheckoutService interface {
 	CompleteCheckout(dbTransaction boil.ContextTransactor, manager interfaces.PluginManagerInterface, checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos, paymentData map[string]any, storeSource bool, discounts []*model_helper.DiscountInfo, user *model.User, _ any, siteSettings model_helper.ShopSettings, trackingCode string, redirectURL string) (*model.Order, bool, model_types.JSONString, *model_helper.PaymentError, *model_helper.AppError)
 	// PrepareInsufficientStockCheckoutValidationAppError
 	PrepareInsufficientStockCheckoutValidationAppError(where string, err model_helper.InsufficientStock) *model_helper.AppError
+	// ProcessAbandonedCheckouts is run periodically by the abandoned checkouts job. It:
+	//
+	//  1. records orders placed from checkouts that were sent recovery emails,
+	//  2. emails customers whose checkouts have been inactive for the configured number of hours,
+	//  3. purges anonymous checkouts older than the configured retention period.
+	//
+	// The data retention job purges old anonymous checkouts too, so they are purged whichever job is enabled.
+	ProcessAbandonedCheckouts() *model_helper.AppError
+	// PurgeAnonymousCheckouts deletes up to limit checkouts without users last updated before given time, along with
+	// their recoveries. It returns the number of deleted checkouts.
+	PurgeAnonymousCheckouts(endTime int64, limit int) (int64, *model_helper.AppError)
 	// RecalculateCheckoutDiscount Recalculate `checkout.discount` based on the voucher.
 	// Will clear both voucher and discount if the discount is no longer applicable.
 	RecalculateCheckoutDiscount(manager interfaces.PluginManagerInterface, checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos, discounts []*model_helper.DiscountInfo) *model_helper.AppError
+	// RecoverAbandonedCheckout checks given signature against given checkout token, marks the checkout as recovered
+	// and returns it.
+	RecoverAbandonedCheckout(checkoutToken, signature string) (*model.Checkout, *model_helper.AppError)
 	// ReleaseVoucherUsage
-	ReleaseVoucherUsage(orderData map[string]any) *model_helper.AppError
+	ReleaseVoucherUsage(transaction boil.ContextTransactor, orderData map[string]any) *model_helper.AppError
 	// RemovePromoCodeFromCheckout Remove gift card or voucher data from checkout.
 	RemovePromoCodeFromCheckout(checkoutInfo model_helper.CheckoutInfo, promoCode string) *model_helper.AppError
 	// RemoveVoucherCodeFromCheckout Remove voucher data from checkout by code.
@@ -128,6 +142,10 @@ type CheckoutService interface {
 	CheckoutLineWithVariant(checkout model.Checkout, productVariantID string) (*model.CheckoutLine, *model_helper.AppError)
 	CheckoutLinesByCheckoutToken(checkoutToken string) (model.CheckoutLineSlice, *model_helper.AppError)
 	CheckoutLinesByOption(option model_helper.CheckoutLineFilterOptions) (model.CheckoutLineSlice, *model_helper.AppError)
+	// CheckoutRecoveriesByOptions returns a list of checkout recoveries filtered using given options
+	CheckoutRecoveriesByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, *model_helper.AppError)
+	// CheckoutRecoveryURL returns a signed link that restores given checkout
+	CheckoutRecoveryURL(checkoutToken string) (string, error)
 	CheckoutSetCountry(checkout model.Checkout, newCountryCode model.CountryCode) *model_helper.AppError
 	CheckoutShippingPrice(manager interfaces.PluginManagerInterface, checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos, address *model.Address, discounts []*model_helper.DiscountInfo) (*goprices.TaxedMoney, *model_helper.AppError)
 	CheckoutSubTotal(manager interfaces.PluginManagerInterface, checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos, address *model.Address, discounts []*model_helper.DiscountInfo) (*goprices.TaxedMoney, *model_helper.AppError)
@@ -137,6 +155,8 @@ type CheckoutService interface {
 	CleanCheckoutPayment(tx boil.ContextTransactor, manager interfaces.PluginManagerInterface, checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos, discounts []*model_helper.DiscountInfo, lastPayment *model.Payment) (*model_helper.PaymentError, *model_helper.AppError)
 	CleanCheckoutShipping(checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos) *model_helper.AppError
 	ClearDeliveryMethod(checkoutInfo model_helper.CheckoutInfo) *model_helper.AppError
+	// CountAnonymousCheckouts counts checkouts without users last updated before given time
+	CountAnonymousCheckouts(endTime int64) (int64, *model_helper.AppError)
 	DeleteCheckoutLines(transaction boil.ContextTransactor, checkoutLineIDs []string) *model_helper.AppError
 	DeleteCheckoutsByOption(transaction boil.ContextTransactor, option model_helper.CheckoutFilterOptions) *model_helper.AppError
 	FetchCheckoutInfo(checkout model.Checkout, lines model_helper.CheckoutLineInfos, discounts []*model_helper.DiscountInfo, manager interfaces.PluginManagerInterface) (*model_helper.CheckoutInfo, *model_helper.AppError)
@@ -147,6 +167,8 @@ type CheckoutService interface {
 	UpdateCheckoutShippingMethodIfValid(checkoutInfo model_helper.CheckoutInfo, lines model_helper.CheckoutLineInfos) *model_helper.AppError
 	UpsertCheckoutLine(checkoutLine model.CheckoutLine) (*model.CheckoutLine, *model_helper.AppError)
 	UpsertCheckoutLines(checkoutLines model.CheckoutLineSlice) (model.CheckoutLineSlice, *model_helper.AppError)
+	// UpsertCheckoutRecovery inserts or updates given checkout recovery
+	UpsertCheckoutRecovery(transaction boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, *model_helper.AppError)
 	UpsertCheckouts(transaction boil.ContextTransactor, checkouts model.CheckoutSlice) (model.CheckoutSlice, *model_helper.AppError)
 	ValidateVariantsInCheckoutLines(lines model_helper.CheckoutLineInfos) *model_helper.AppError
 }
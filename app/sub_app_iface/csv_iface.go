@@ -4,9 +4,8 @@
 package sub_app_iface
 
 import (
-	"github.com/mattermost/squirrel"
+	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
-	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/modules/util"
 )
 
@@ -16,12 +15,26 @@ type CsvService interface {
 	CommonCreateExportEvent(exportEvent model.ExportEvent) (*model.ExportEvent, *model_helper.AppError)
 	// CreateExportFile inserts given export file into database then returns it
 	CreateExportFile(file model.ExportFile) (*model.ExportFile, *model_helper.AppError)
+	// DeleteExportFiles removes exported contents of given export files from the file store, then deletes them
+	// along with their events
+	DeleteExportFiles(files model.ExportFileSlice) *model_helper.AppError
+	// ExportCustomers is called by export job, it writes customers selected by given input into the export file,
+	// then records export events and notifies requestor of the export about the result.
+	ExportCustomers(exportFile model.ExportFile, input model_helper.ExportCustomersFilterOptions, delimiter string) *model_helper.AppError
 	// ExportEventsByOption returns a list of export events filtered using given options
-	ExportEventsByOption(options model.ExportEventFilterOption) ([]*model.ExportEvent, *model_helper.AppError)
+	ExportEventsByOption(options model_helper.ExportEventFilterOption) (model.ExportEventSlice, *model_helper.AppError)
 	// ExportFileById returns an export file found by given id
 	ExportFileById(id string) (*model.ExportFile, *model_helper.AppError)
-	// ExportProducts is called by product export job, taks needed arguments then exports products
-	ExportProducts(input *model.ExportProductsFilterOptions, delimeter string) *model_helper.AppError
+	ExportFilesByOption(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, *model_helper.AppError)
+	// ExportGiftcards is called by export job, it writes giftcards selected by given input into the export file,
+	// then records export events and notifies requestor of the export about the result.
+	ExportGiftcards(exportFile model.ExportFile, input model_helper.ExportGiftcardsFilterOptions, delimiter string) *model_helper.AppError
+	// ExportOrders is called by export job, it writes orders selected by given input into the export file,
+	// then records export events and notifies requestor of the export about the result.
+	ExportOrders(exportFile model.ExportFile, input model_helper.ExportOrdersFilterOptions, delimiter string) *model_helper.AppError
+	// ExportProducts is called by export job, it writes products selected by given input into the export file,
+	// then records export events and notifies requestor of the export about the result.
+	ExportProducts(exportFile model.ExportFile, input model_helper.ExportProductsFilterOptions, delimiter string) *model_helper.AppError
 	// Get export fields, all headers and headers mapping.
 	// Based on export_info returns exported fields, fields to headers mapping and
 	// all headers.
@@ -32,10 +45,13 @@ type CsvService interface {
 		Channels   []string
 		Fields     []string
 	}) ([]string, []string, []string, *model_helper.AppError)
+	// ExportUserData is called by export job, it writes personal data of the user who requested given export file
+	// into a zip archive, then records export events and emails the user a link to download the archive.
+	ExportUserData(exportFile model.ExportFile) *model_helper.AppError
 	// Get headers for exported attributes.
-	// Headers are build from slug and contains information if it's a product or variant
-	// attribute. Respectively for product: "slug-value (product attribute)"
-	// and for variant: "slug-value (variant attribute)".
+	// Headers are build from slug. Example: "slug-value (product attribute)".
+	//
+	// NOTE: variants carry custom attributes of their products, so variant only attributes are not exported.
 	GetAttributeHeaders(exportInfo struct {
 		Attributes []string
 		Warehouses []string
@@ -68,15 +84,46 @@ type CsvService interface {
 	GetDefaultExportPayload(exportFile model.ExportFile) (map[string]any, *model_helper.AppError)
 	// GetProductsData Create data list of products and their variants with fields values.
 	//
-	// It return list with product and variant data which can be used as import to
-	// csv writer and list of attribute and warehouse headers.
+	// Each returned row represents one product variant (or a product without variants) and
+	// maps data headers (export fields, attribute, warehouse and channel headers) to cell values.
 	//
-	// TODO: consider improving me
-	GetProductsData(products model.ProductSlice, exportFields, attributeIDs, warehouseIDs, channelIDs util.AnyArray[string]) []model_types.JSONString
-	ExportProductsInBatches(productQuery squirrel.SelectBuilder, ExportInfo struct {
-		Attributes []string
-		Warehouses []string
-		Channels   []string
-		Fields     []string
-	}, exportFields []string, headers []string, delimiter string, fileType string) *model_helper.AppError
+	// NOTE: products must be loaded with relations returned by productExportRelations
+	GetProductsData(products model.ProductSlice, exportFields, attributeIDs, warehouseIDs, channelIDs util.AnyArray[string]) []model_helper.StringMap
+	// ImportProducts is called by import job. It upserts products, variants, attribute values, channel listings,
+	// stocks and media urls read from given file in transactional batches.
+	//
+	// Rows that can't be imported are skipped and reported in an error report file, path of the report is saved into
+	// "error_report" of the job data. Progress of the import is saved into the job after every batch.
+	ImportProducts(job model.Job, filePath, fileType string, dryRun bool) *model_helper.AppError
+	// ProcessExportJob runs the export scheduled by given export job
+	ProcessExportJob(job model.Job) *model_helper.AppError
+	// ProcessImportJob runs the import scheduled by given import job
+	ProcessImportJob(job model.Job) *model_helper.AppError
+	// SendExportDownloadLinkNotification sends a link to download given export file to the user who requested the export.
+	// Exports requested by apps are not notified.
+	SendExportDownloadLinkNotification(exportFile model.ExportFile, dataType string) *model_helper.AppError
+	// SendExportFailedInfo notifies the user who requested given export that the export has failed.
+	// Exports requested by apps are not notified.
+	SendExportFailedInfo(exportFile model.ExportFile, dataType, message string) *model_helper.AppError
+	// SendUserDataExportLink emails the customer who requested given user data export a link to download the archive
+	SendUserDataExportLink(exportFile model.ExportFile) *model_helper.AppError
+	// StartCustomersExport validates given input, then schedules a job that exports customers with their
+	// addresses and order counts. Either userID or appID should be provided.
+	StartCustomersExport(input model_helper.ExportCustomersFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError)
+	// StartGiftcardsExport validates given input, then schedules a job that exports giftcards.
+	// Either userID or appID should be provided.
+	StartGiftcardsExport(input model_helper.ExportGiftcardsFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError)
+	// StartOrdersExport validates given input, then schedules a job that exports orders with their lines,
+	// payments and fulfillments. Either userID or appID should be provided.
+	StartOrdersExport(input model_helper.ExportOrdersFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError)
+	// StartProductsExport validates given input, creates an export file with a pending event for it,
+	// then schedules a job that exports the products. Either userID or appID should be provided.
+	StartProductsExport(input model_helper.ExportProductsFilterOptions, userID, appID string) (*model.ExportFile, *model_helper.AppError)
+	// StartProductsImport schedules a job that imports products from given file of the file store.
+	// The file must have the same layout as files written by product export.
+	// In dry-run mode the file is only validated, nothing is saved.
+	StartProductsImport(filePath, fileType string, dryRun bool, userID string) (*model.Job, *model_helper.AppError)
+	// StartUserDataExport schedules a job that bundles personal data of given user into a zip archive,
+	// then emails the user a link to download it.
+	StartUserDataExport(user model.User) (*model.ExportFile, *model_helper.AppError)
 }
@@ -90,7 +90,7 @@ type DiscountService interface {
 	// VoucherTranslationsByOption returns a list of voucher translations filtered using given option
 	VoucherTranslationsByOption(option *model.VoucherTranslationFilterOption) ([]*model.VoucherTranslation, *model_helper.AppError)
 	AddVoucherUsageByCustomer(voucher model.Voucher, customerEmail string) (*model_helper.NotApplicable, *model_helper.AppError)
-	AlterVoucherUsage(voucher model.Voucher, usageDelta int) (*model.Voucher, *model_helper.AppError)
+	AlterVoucherUsage(transaction boil.ContextTransactor, voucher model.Voucher, usageDelta int) (*model.Voucher, *model_helper.AppError)
 	BulkDeleteOrderDiscounts(orderDiscountIDs []string) *model_helper.AppError
 	CreateNewVoucherCustomer(voucherID string, customerEmail string) (*model.VoucherCustomer, *model_helper.AppError)
 	FetchDiscounts(date time.Time) ([]*model_helper.DiscountInfo, *model_helper.AppError)
@@ -99,14 +99,14 @@ type DiscountService interface {
 	GetVoucherDiscount(voucher model.Voucher, channelID string) (types.DiscountCalculator, *model_helper.AppError)
 	OrderDiscountsByOption(option model_helper.OrderDiscountFilterOption) (model.OrderDiscountSlice, *model_helper.AppError)
 	PromoCodeIsVoucher(code string) (bool, *model_helper.AppError)
-	RemoveVoucherUsageByCustomer(voucher model.Voucher, customerEmail string) *model_helper.AppError
+	RemoveVoucherUsageByCustomer(transaction boil.ContextTransactor, voucher model.Voucher, customerEmail string) *model_helper.AppError
 	SaleCategoriesByOption(option squirrel.Sqlizer) ([]*model.SaleCategory, *model_helper.AppError)
 	SaleChannelListingsByOptions(options *model.SaleChannelListingFilterOption) ([]*model.SaleChannelListing, *model_helper.AppError)
 	ToggleSaleRelations(transaction boil.ContextTransactor, saleID string, productIDs, variantIDs, categoryIDs, collectionIDs []string, isDelete bool) *model_helper.AppError
 	ToggleVoucherRelations(transaction boil.ContextTransactor, vouchers model.Vouchers, productIDs, variantIDs, categoryIDs, collectionIDs []string, isDelete bool) *model_helper.AppError
 	UpsertOrderDiscount(transaction boil.ContextTransactor, orderDiscount model.OrderDiscount) (*model.OrderDiscount, *model_helper.AppError)
 	UpsertSale(transaction boil.ContextTransactor, sale model.Sale) (*model.Sale, *model_helper.AppError)
-	UpsertVoucher(voucher model.Voucher) (*model.Voucher, *model_helper.AppError)
+	UpsertVoucher(transaction boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, *model_helper.AppError)
 	ValidateOnlyForStaff(voucher model.Voucher, customerID string) (*model_helper.NotApplicable, *model_helper.AppError)
 	ValidateVoucher(voucher model.Voucher, totalPrice goprices.TaxedMoney, quantity int, customerEmail string, channelID string, customerID string) (notApplicableErr *model_helper.NotApplicable, appErr *model_helper.AppError)
 	ValidateVoucherInOrder(order *model.Order) (notApplicableErr *model_helper.NotApplicable, appErr *model_helper.AppError)
@@ -5,6 +5,7 @@ package sub_app_iface
 
 import (
 	"io"
+	"net/url"
 	"time"
 
 	"github.com/sitename/sitename/app/imaging"
@@ -34,6 +35,12 @@ type FileService interface {
 	ImageEncoder() *imaging.Encoder
 	// MoveFile moves file from given oldPath to newPath
 	MoveFile(oldPath, newPath string) *model_helper.AppError
+	// PresignedDownloadURL returns an url which allows the client to download the file at given path
+	// directly from the file backend.
+	PresignedDownloadURL(path string) (string, *model_helper.AppError)
+	// PresignedUploadURL returns an url which allows the client to upload the data of given upload session
+	// directly to the file backend with a PUT request. Once uploaded, the session is finished with CompleteUploadSession.
+	PresignedUploadURL(us *model.UploadSession) (string, *model_helper.AppError)
 	// ReadFile read file content from given path
 	ReadFile(path string) ([]byte, *model_helper.AppError)
 	// TestFileStoreConnection test if connection to file backend server is good
@@ -51,6 +58,12 @@ type FileService interface {
 	UploadFileX(c *request.Context, channelID, name string, input io.Reader, userID *string, timestamp *time.Time, contentLength *int64, clientID *string, raw *bool) (*model.FileInfo, *model_helper.AppError)
 	AppendFile(fr io.Reader, path string) (int64, *model_helper.AppError)
 	CheckMandatoryS3Fields(settings *model_helper.FileSettings) *model_helper.AppError
+	// CompleteUploadSession finishes given upload session whose data was uploaded with a presigned url.
+	// It verifies the uploaded object exists and has the size of the session before creating its FileInfo.
+	CompleteUploadSession(c *request.Context, us *model.UploadSession) (*model.FileInfo, *model_helper.AppError)
+	// CreateUploadSession saves given upload session, its data is written to the import directory for imports,
+	// otherwise to a directory of the uploading user.
+	CreateUploadSession(us *model.UploadSession) (*model.UploadSession, *model_helper.AppError)
 	CopyFileInfos(userID string, fileIDs []string) ([]string, *model_helper.AppError)
 	DoUploadFile(c *request.Context, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte) (*model.FileInfo, *model_helper.AppError)
 	DoUploadFileExpectModification(c *request.Context, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte) (*model.FileInfo, []byte, *model_helper.AppError)
@@ -66,5 +79,8 @@ type FileService interface {
 	RemoveDirectory(path string) *model_helper.AppError
 	RemoveFile(path string) *model_helper.AppError
 	UploadData(c *request.Context, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model_helper.AppError)
+	// VerifyLocalSignedURL checks that a request with given method to path of the local file backend
+	// carries a valid, unexpired signature in its query.
+	VerifyLocalSignedURL(method, path string, query url.Values) *model_helper.AppError
 	WriteFile(fr io.Reader, path string) (int64, *model_helper.AppError)
 }
@@ -21,8 +21,8 @@ type OrderService interface {
 	AddGiftcardsToOrder(transaction boil.ContextTransactor, checkoutInfo model_helper.CheckoutInfo, order *model.Order, totalPriceLeft *goprices.Money, user *model.User, _ any) *model_helper.AppError
 	// AddVariantToOrder Add total_quantity of variant to order.
 	//
-	// Returns an order line the variant was added to.
-	AddVariantToOrder(order model.Order, variant model.ProductVariant, quantity int, user *model.User, _ any, manager interfaces.PluginManagerInterface, discounts []*model_helper.DiscountInfo, allocateStock bool) (*model.OrderLine, *model_helper.InsufficientStock, *model_helper.AppError)
+	// Returns an order line the variant was added to. If transaction is nil, a new one is started and committed here.
+	AddVariantToOrder(transaction boil.ContextTransactor, order model.Order, variant model.ProductVariant, quantity int, user *model.User, _ any, manager interfaces.PluginManagerInterface, discounts []*model_helper.DiscountInfo, allocateStock bool) (*model.OrderLine, *model_helper.InsufficientStock, *model_helper.AppError)
 	// AllDigitalOrderLinesOfOrder finds all order lines belong to given order, and are digital products
 	AllDigitalOrderLinesOfOrder(orderID string) (model.OrderLineSlice, *model_helper.AppError)
 	// ApplyDiscountToValue Calculate the price based on the provided values
@@ -53,15 +53,18 @@ type OrderService interface {
 	// ChangeOrderLineQuantity Change the quantity of ordered items in a order line.
 	//
 	// NOTE: userID can be empty
-	ChangeOrderLineQuantity(transaction boil.ContextTransactor, userID string, _ any, lineInfo *model.OrderLineData, oldQuantity int, newQuantity int, channelSlug string, manager interfaces.PluginManagerInterface, sendEvent bool) (*model_helper.InsufficientStock, *model_helper.AppError)
+	ChangeOrderLineQuantity(transaction boil.ContextTransactor, userID string, _ any, lineInfo *model_helper.OrderLineData, oldQuantity int, newQuantity int, channelSlug string, manager interfaces.PluginManagerInterface, sendEvent bool) (*model_helper.InsufficientStock, *model_helper.AppError)
 	// CleanMarkOrderAsPaid Check if an order can be marked as paid.
 	CleanMarkOrderAsPaid(order *model.Order) *model_helper.AppError
 	// CommonCreateOrderEvent is common method for creating desired order event instance
-	CommonCreateOrderEvent(transaction boil.ContextTransactor, option *model.OrderEventOption) (*model.OrderEvent, *model_helper.AppError)
+	CommonCreateOrderEvent(transaction boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, *model_helper.AppError)
 	// CreateGiftcardsWhenApprovingFulfillment
 	CreateGiftcardsWhenApprovingFulfillment(order *model.Order, linesData []*model.OrderLineData, user *model.User, _ any, manager interfaces.PluginManagerInterface, settings model.ShopSettings) *model_helper.AppError
 	// CreateOrderDiscountForOrder Add new order discount and update the prices
 	CreateOrderDiscountForOrder(transaction boil.ContextTransactor, order *model.Order, reason string, valueType model.DiscountValueType, value *decimal.Decimal) (*model.OrderDiscount, *model_helper.AppError)
+	// CreateOrderEvent records a removed_products event when quantityDiff is positive,
+	// an added_products event when it is negative and does nothing otherwise.
+	CreateOrderEvent(transaction boil.ContextTransactor, orderLine *model.OrderLine, userID string, quantityDiff int) *model_helper.AppError
 	// CreateReplaceOrder Create draft order with lines to replace
 	CreateReplaceOrder(user *model.User, _ any, originalOrder model.Order, orderLinesToReplace []*model.OrderLineData, fulfillmentLinesToReplace []*model.FulfillmentLineData) (*model.Order, *model_helper.AppError)
 	// CustomerEmail try finding order's owner's email. If order has no user or error occured during the finding process, returns order's UserEmail property instead
@@ -69,9 +72,25 @@ type OrderService interface {
 	// DeleteFulfillmentLinesByOption tells store to delete fulfillment lines filtered by given option
 	DeleteFulfillmentLinesByOption(transaction boil.ContextTransactor, option *model.FulfillmentLineFilterOption) *model_helper.AppError
 	// DeleteOrderLine Delete an order line from an order.
-	DeleteOrderLine(tx boil.ContextTransactor, lineInfo *model.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError)
+	DeleteOrderLine(tx boil.ContextTransactor, lineInfo *model_helper.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError)
 	// DeleteOrderLines perform bulk delete given order lines
 	DeleteOrderLines(tx boil.ContextTransactor, orderLineIDs []string) *model_helper.AppError
+	// EditOrder applies given changes to a draft or unconfirmed order.
+	//
+	// Line changes, stock allocations of unconfirmed orders, the shipping method change and recalculation of
+	// taxes, totals and the order voucher are saved in one transaction. The shipping method of the order is
+	// validated against the edited lines. If the new total of an unconfirmed order exceeds captured amount,
+	// customer is requested to pay the balance.
+	//
+	// NOTE: user must not be nil
+	EditOrder(order model.Order, input model_helper.OrderEditInput, user *model.User, manager interfaces.PluginManagerInterface) (*model.Order, *model_helper.InsufficientStock, *model_helper.AppError)
+	// ExpireOrder marks given order as expired, records an expired event, releases its stock allocations
+	// and voucher usage, voids its payments and notifies the customer.
+	ExpireOrder(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError
+	// ExpireOrders expires unconfirmed, unpaid orders placed from checkouts which are older than
+	// ExpireOrdersAfter (minutes) of their channel, then deletes expired orders which have been expired
+	// for longer than DeleteExpiredOrdersAfter (days) of their channel.
+	ExpireOrders() *model_helper.AppError
 	// FilterOrdersByOptions is common method for filtering orders by given option
 	FilterOrdersByOptions(option model_helper.OrderFilterOption) (int64, []*model.Order, *model_helper.AppError)
 	// Fulfill order.
@@ -271,6 +290,11 @@ type OrderService interface {
 	SendOrderConfirmation(order *model.Order, redirectURL string, manager interfaces.PluginManagerInterface) *model_helper.AppError
 	// SendOrderConfirmed Send email which tells customer that order has been confirmed
 	SendOrderConfirmed(order model.Order, user *model.User, _ any, manager interfaces.PluginManagerInterface)
+	// SendOrderExpiredNotification notifies customer of given order that the order has expired
+	SendOrderExpiredNotification(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError
+	// SendOrderPaymentBalanceRequest notifies customer of given order that the order total
+	// exceeds amount captured so far and the difference needs to be paid
+	SendOrderPaymentBalanceRequest(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError
 	// SendPaymentConfirmation sends notification with the payment confirmation
 	SendPaymentConfirmation(order model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError
 	// UpdateDiscountForOrderLine Update discount fields for order line. Apply discount to the price
@@ -287,6 +311,9 @@ type OrderService interface {
 	UpdateOrderStatus(transaction boil.ContextTransactor, order model.Order) *model_helper.AppError
 	// UpdateOrderTotalPaid update given order's total paid amount
 	UpdateOrderTotalPaid(transaction boil.ContextTransactor, orDer *model.Order) *model_helper.AppError
+	// UpdateTaxesForOrderLine recalculates prices and tax rate of given line through the plugin manager.
+	// Lines whose variant has been deleted keep their prices.
+	UpdateTaxesForOrderLine(line *model.OrderLine, order model.Order, manager interfaces.PluginManagerInterface, taxIncluded bool) *model_helper.AppError
 	// UpdateVoucherDiscount Recalculate order discount amount based on order voucher
 	UpdateVoucherDiscount(fun types.RecalculateOrderPricesFunc) types.RecalculateOrderPricesFunc
 	// UpsertFulfillment performs some actions then save given fulfillment
@@ -306,7 +333,6 @@ type OrderService interface {
 	// ValidateProductIsPublishedInChannel checks if some of given variants belong to unpublished products
 	ValidateProductIsPublishedInChannel(variants model.ProductVariantSlice, channelID string) *model_helper.AppError
 	ApproveFulfillment(fulfillment *model.Fulfillment, user *model.User, _ any, manager interfaces.PluginManagerInterface, settings model.ShopSettings, notifyCustomer bool, allowStockTobeExceeded bool) (*model.Fulfillment, *model_helper.InsufficientStock, *model_helper.AppError)
-	CreateOrderEvent(transaction boil.ContextTransactor, orderLine *model.OrderLine, userID string, quantityDiff int) *model_helper.AppError
 	CreateReturnFulfillment(requester *model.User, order model.Order, orderLineDatas []*model.OrderLineData, fulfillmentLineDatas []*model.FulfillmentLineData, totalRefundAmount *decimal.Decimal, shippingRefundAmount *decimal.Decimal, manager interfaces.PluginManagerInterface) (*model.Fulfillment, *model_helper.AppError)
 	DeleteOrders(transaction boil.ContextTransactor, ids []string) (int64, *model_helper.AppError)
 	DraftOrderCreatedFromReplaceEvent(transaction boil.ContextTransactor, draftOrder model.Order, originalOrder model.Order, user *model.User, _ any, lines []*model.QuantityOrderLine) (*model.OrderEvent, *model_helper.AppError)
@@ -336,6 +362,5 @@ type OrderService interface {
 	SendOrderRefundedConfirmation(order model.Order, user *model.User, _ any, amount decimal.Decimal, currency string, manager interfaces.PluginManagerInterface) *model_helper.AppError
 	SumOrderTotals(orders []*model.Order, currencyCode string) (*goprices.TaxedMoney, *model_helper.AppError)
 	UpdateGiftcardBalance(giftCard *model.GiftCard, totalPriceLeft *goprices.Money) model.BalanceObject
-	UpdateTaxesForOrderLine(line model.OrderLine, order model.Order, manager interfaces.PluginManagerInterface, taxIncluded bool) *model_helper.AppError
-	UpdateTaxesForOrderLines(lines model.OrderLineSlice, order model.Order, manager interfaces.PluginManagerInterface, taxIncludeed bool) *model_helper.AppError
+	UpdateTaxesForOrderLines(transaction boil.ContextTransactor, lines model.OrderLineSlice, order model.Order, manager interfaces.PluginManagerInterface, taxIncludeed bool) *model_helper.AppError
 }
@@ -4,6 +4,7 @@
 package sub_app_iface
 
 import (
+	"io"
 	"time"
 
 	goprices "github.com/site-name/go-prices"
@@ -75,16 +76,36 @@ type ProductService interface {
 	// Attribute must be product attribute and attribute input type must be
 	// in ALLOWED_IN_VARIANT_SELECTION list.
 	GetVariantSelectionAttributes(attributes []*model.Attribute) []*model.Attribute
+	// IndexProducts loads indexing data of given products and indexes them in all active search engines.
+	// Indexing runs in background unless an engine is configured to index synchronously.
+	IndexProducts(productIDs []string) *model_helper.AppError
+	// IndexProductsOfVariants indexes parent products of given variants, it is called when stocks or prices of variants change
+	IndexProductsOfVariants(variantIDs []string) *model_helper.AppError
 	// ProductById returns 1 product by given id
 	ProductById(productID string) (*model.Product, *model_helper.AppError)
 	// ProductByOption returns 1 product that satisfy given option
 	ProductByOption(option *model.ProductFilterOption) (*model.Product, *model_helper.AppError)
 	// ProductChannelListingsByOption returns a list of product channel listings filtered using given option
 	ProductChannelListingsByOption(option *model.ProductChannelListingFilterOption) ([]*model.ProductChannelListing, *model_helper.AppError)
+	// ProductFeedForUser returns a page of products visible in given channel, ranked by interest of the user.
+	//
+	// Interest is derived from the user's favourite categories and categories of products the user ordered or
+	// added to wishlists, newer products rank higher. Users without known interests (including anonymous users,
+	// whose userID is empty) get products ranked by recent popularity and recency.
+	//
+	// cursor is NextCursor of the previous page, empty for the first page.
+	ProductFeedForUser(userID, channelIdOrSlug, cursor string, limit int) (*model_helper.ProductFeed, *model_helper.AppError)
 	// ProductGetFirstImage returns first media of given product
 	ProductGetFirstImage(productID string) (*model.ProductMedia, *model_helper.AppError)
+	// ProductMediaThumbnail returns path of given size variant of an uploaded product image in the file store.
+	// Missing variants, e.g of images uploaded before variants were introduced, are generated from the original image.
+	// Empty path is returned for media which are not uploaded images, like videos and external images.
+	ProductMediaThumbnail(media *model.ProductMedium, size model_helper.ProductMediaImageSize, format model_helper.ProductMediaImageFormat) (string, *model_helper.AppError)
 	// ProductMediasByOption returns a list of product medias that satisfy given option
 	ProductMediasByOption(option *model.ProductMediaFilterOption) ([]*model.ProductMedia, *model_helper.AppError)
+	// ProductRecommendations returns products recommended for given product, which are visible and in stock in given channel.
+	// kind is model_helper.ProductRecommendationKindCoPurchase or model_helper.ProductRecommendationKindSimilar, empty kind means any kind.
+	ProductRecommendations(productID, channelID, kind string, limit int) ([]*model_helper.RecommendedProduct, *model_helper.AppError)
 	// ProductTranslationsByOption returns a list of product translations
 	ProductTranslationsByOption(option *model.ProductTranslationFilterOption) ([]*model.ProductTranslation, *model_helper.AppError)
 	// ProductTypeByOption returns a product type with given option
@@ -107,6 +128,19 @@ type ProductService interface {
 	ProductVariantTranslationsByOption(option *model.ProductVariantTranslationFilterOption) ([]*model.ProductVariantTranslation, *model_helper.AppError)
 	// ProductVariantsAvailableInChannel returns product variants based on given channel slug
 	ProductVariantsAvailableInChannel(channelSlug string) ([]*model.ProductVariant, *model_helper.AppError)
+	// ProductVariantsBulkCreate creates variants of given product, with their attribute values, channel listings and stocks.
+	// Items failing validation or failing to be written are reported by their indexes in the result errors.
+	// With REJECT_EVERYTHING policy (the default) nothing is created if any item fails.
+	ProductVariantsBulkCreate(productID string, inputs []*model_helper.ProductVariantBulkInput, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (*model_helper.ProductVariantBulkResult, *model_helper.AppError)
+	// ProductVariantsBulkDelete deletes given variants. Unknown variants and variants ordered in placed orders
+	// are reported by their indexes in the result errors.
+	// With REJECT_EVERYTHING policy (the default) nothing is deleted if any variant fails.
+	ProductVariantsBulkDelete(variantIDs []string, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (*model_helper.ProductVariantBulkResult, *model_helper.AppError)
+	// ProductVariantsBulkUpdate updates variants of given product. Channel listings and stocks of given channels and warehouses
+	// are created or updated, other listings and stocks of the variants are kept.
+	// Items failing validation or failing to be written are reported by their indexes in the result errors.
+	// With REJECT_EVERYTHING policy (the default) nothing is updated if any item fails.
+	ProductVariantsBulkUpdate(productID string, inputs []*model_helper.ProductVariantBulkInput, errorPolicy model_helper.ProductVariantBulkErrorPolicy) (*model_helper.ProductVariantBulkResult, *model_helper.AppError)
 	// ProductVariantsByOption returns a list of product variants satisfy given option
 	ProductVariantsByOption(option model_helper.ProductVariantFilterOptions) (model.ProductVariantSlice, *model_helper.AppError)
 	// ProductsByOption returns a list of products that satisfy given option
@@ -127,6 +161,12 @@ type ProductService interface {
 	//
 	// NOTE: discount must be either *Sale or *Voucher
 	UpdateProductsDiscountedPricesOfDiscount(transaction boil.ContextTransactor, discount any) *model_helper.AppError
+	// UploadProductMediaImage stores given image as a new media of the product, along with its size variants
+	// in the original format and in WebP. ppoi is the primary point of interest thumbnails are cropped around,
+	// empty ppoi means the image center.
+	//
+	// The image is turned upright according to its EXIF orientation and re-encoded, which strips EXIF and other metadata.
+	UploadProductMediaImage(productID string, data io.Reader, alt, ppoi string) (*model.ProductMedium, *model_helper.AppError)
 	// UpsertCategory first checks if given category need a Level number.
 	// Performs upsert given category into database.
 	// asynchronously does category anayltic to update category cache.
@@ -137,10 +177,21 @@ type ProductService interface {
 	UpsertProductVariant(transaction boil.ContextTransactor, variant *model.ProductVariant) (*model.ProductVariant, *model_helper.AppError)
 	CategoryByIds(ids []string, allowFromCache bool) (model.CategorySlice, *model_helper.AppError)
 	CollectionChannelListingsByOptions(options *model.CollectionChannelListingFilterOptions) ([]*model.CollectionChannelListing, *model_helper.AppError)
+	// ComputeProductRecommendations is run periodically by the product recommendations job.
+	// For each active channel, it replaces recommendations of products listed in the channel with:
+	//
+	//  1. products frequently bought together with them, according to orders placed in the channel recently,
+	//  2. products sharing their category and attribute values.
+	//
+	// Only products published, visible in listings and in stock in the channel are recommended.
+	// A channel failing doesn't prevent recommendations of other channels from being computed.
+	ComputeProductRecommendations() *model_helper.AppError
 	CreateCollectionProductRelations(transaction boil.ContextTransactor, relations []*model.CollectionProduct) ([]*model.CollectionProduct, *model_helper.AppError)
 	DeleteProductMedias(tx *gorm.DB, ids []string) (int64, *model_helper.AppError)
 	DeleteProductTypes(tx *gorm.DB, ids []string) (int64, *model_helper.AppError)
 	DeleteProductVariants(variantIds []string, requesterID string) (int64, *model_helper.AppError)
+	// DeleteProductsFromSearchIndexes removes given products from all active search engines
+	DeleteProductsFromSearchIndexes(productIDs []string)
 	DigitalContentURLSByOptions(options *model.DigitalContentUrlFilterOptions) ([]*model.DigitalContentUrl, *model_helper.AppError)
 	DigitalContentsbyOptions(option *model.DigitalContentFilterOption) (int64, []*model.DigitalContent, *model_helper.AppError)
 	FilterCategoriesFromCache(filter func(c *model.Category) bool) model.CategorySlice
@@ -151,6 +202,8 @@ type ProductService interface {
 	GetVariantAvailability(variant model.ProductVariant, variantChannelListing model.ProductVariantChannelListing, product model.Product, productChannelListing *model.ProductChannelListing, collections []*model.Collection, discounts []*model_helper.DiscountInfo, chanNel model.Channel, plugins interfaces.PluginManagerInterface, country model.CountryCode, localCurrency string) (*model.VariantAvailability, *model_helper.AppError)
 	GetVisibleToUserProducts(channelIdOrSlug string, userIsShopStaff bool) (model.ProductSlice, *model_helper.AppError)
 	IncrementDownloadCount(contentURL model.DigitalContentUrl) (*model.DigitalContentUrl, *model_helper.AppError)
+	// SearchProducts searches products with the first active search engine having searching enabled
+	SearchProducts(params *model_helper.ProductSearchParams) (*model_helper.ProductSearchResult, *model_helper.AppError)
 	SetDefaultProductVariantForProduct(productID, variantID string) (*model.Product, *model_helper.AppError)
 	ToggleProductTypeAttributeRelations(tx *gorm.DB, productTypeID string, variantAttributes, productAttributes model.Attributes, isDelete bool) *model_helper.AppError
 	ToggleVariantRelations(variants model.ProductVariantSlice, medias model.ProductMedias, sales model.Sales, vouchers model.Vouchers, wishlistItems model.WishlistItems, isDelete bool) *model_helper.AppError
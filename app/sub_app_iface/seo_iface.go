@@ -3,6 +3,23 @@
 
 package sub_app_iface
 
+import (
+	"github.com/sitename/sitename/model_helper"
+)
+
 // SeoService contains methods for working with seos
 type SeoService interface {
+	// ProductStructuredData returns JSON-LD Product (with its Offer) and BreadcrumbList documents of given product's
+	// page in storefront of the channel. Prices come from the product availability in the channel, taxed for the
+	// channel's default country. Offers are left out when the product has no prices in the channel.
+	ProductStructuredData(productID, channelSlug string) (*model_helper.ProductStructuredData, *model_helper.AppError)
+	// ResolveSlugRedirect finds the current slug of the object of given type that had oldSlug before being renamed.
+	// It returns a not found error if no object of the type was ever known by the slug.
+	ResolveSlugRedirect(objectType model_helper.SeoObjectType, oldSlug string) (string, *model_helper.AppError)
+	// Sitemap returns given sitemap file of given channel's storefront in XML, number starts at 1.
+	// Sitemaps list published pages, categories, collections published in the channel and products published in the channel.
+	Sitemap(channelSlug string, number int) ([]byte, *model_helper.AppError)
+	// SitemapIndex returns the sitemap index of given channel's storefront, in XML.
+	// The index lists sitemap files of the channel, each holding at most model_helper.SitemapMaxURLs urls.
+	SitemapIndex(channelSlug string) ([]byte, *model_helper.AppError)
 }
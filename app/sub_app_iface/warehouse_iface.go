@@ -21,7 +21,7 @@ type WarehouseService interface {
 	// Iterate by stocks and allocate as many items as needed or available in stock
 	// for order line, until allocated all required quantity for the order line.
 	// If there is less quantity in stocks then rise InsufficientStock exception.
-	AllocateStocks(orderLineInfos model.OrderLineDatas, countryCode model.CountryCode, channelSlug string, manager interfaces.PluginManagerInterface, additionalFilterLookup model_types.JSONString) (*model_helper.InsufficientStock, *model_helper.AppError)
+	AllocateStocks(transaction boil.ContextTransactor, orderLineInfos model.OrderLineDatas, countryCode model.CountryCode, channelSlug string, manager interfaces.PluginManagerInterface, additionalFilterLookup model_types.JSONString) (*model_helper.InsufficientStock, *model_helper.AppError)
 	// AllocatePreOrders allocates pre-order variant for given `order_lines` in given channel
 	AllocatePreOrders(orderLinesInfo model.OrderLineDatas, channelSlug string) (*model_helper.InsufficientStock, *model_helper.AppError)
 	// AllocationsByOption returns all warehouse allocations filtered based on given option
@@ -31,6 +31,8 @@ type WarehouseService interface {
 	// This method should be used only if stocks quantity will be checked in further
 	// validation steps, for instance in checkout completion.
 	ApplicableForClickAndCollectNoQuantityCheck(checkoutLines model.CheckoutLines, country string) (model.WarehouseSlice, *model_helper.AppError)
+	// BackInStockSubscriptionsByOptions returns a list of back in stock subscriptions filtered using given options
+	BackInStockSubscriptionsByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, *model_helper.AppError)
 	// BulkCreate tells store to insert given preorder allocations into database then returns them
 	BulkCreate(transaction boil.ContextTransactor, preorderAllocations []*model.PreorderAllocation) ([]*model.PreorderAllocation, *model_helper.AppError)
 	// BulkDeleteAllocations performs bulk delete given allocations.
@@ -53,8 +55,10 @@ type WarehouseService interface {
 	//
 	// `additionalFilterBoolup`, `existingLines` can be nil, replace default to false
 	CheckStockAndPreorderQuantityBulk(variants []*model.ProductVariant, countryCode model.CountryCode, quantities []int, channelSlug string, additionalFilterBoolup model_types.JSONString, existingLines model_helper.CheckoutLineInfos, replace bool) (*model_helper.InsufficientStock, *model_helper.AppError)
-	// DeAllocateStockForOrder Remove all allocations for given order
-	DeAllocateStockForOrder(ord *model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError
+	// DeAllocateStockForOrder Remove all allocations for given order within given transaction.
+	// It returns stocks which had no available quantity before, callers should trigger ProductVariantBackInStock
+	// for them once the transaction is committed.
+	DeAllocateStockForOrder(transaction boil.ContextTransactor, ord *model.Order) ([]*model.Stock, *model_helper.AppError)
 	// DeactivatePreorderForVariant Complete preorder for product variant.
 	// All preorder settings should be cleared and all preorder allocations
 	// should be replaced by regular allocations.
@@ -66,7 +70,7 @@ type WarehouseService interface {
 	// as needed of available in stock for order line, until deallocated all required
 	// quantity for the order line. If there is less quantity in stocks then
 	// raise an exception.
-	DeallocateStock(orderLineDatas model.OrderLineDatas, manager interfaces.PluginManagerInterface) (*model.AllocationError, *model_helper.AppError)
+	DeallocateStock(transaction boil.ContextTransactor, orderLineDatas model.OrderLineDatas, manager interfaces.PluginManagerInterface) (*model.AllocationError, *model_helper.AppError)
 	// Decrease stocks quantities for given `order_lines` in given warehouses.
 	//
 	// Function deallocate as many quantities as requested if order_line has less quantity
@@ -79,9 +83,11 @@ type WarehouseService interface {
 	// If allow_stock_to_be_exceeded flag is True then quantity could be < 0.
 	//
 	// updateStocks default to true
-	DecreaseStock(orderLineInfos model.OrderLineDatas, manager interfaces.PluginManagerInterface, updateStocks bool, allowStockTobeExceeded bool) (*model_helper.InsufficientStock, *model_helper.AppError)
+	DecreaseStock(transaction boil.ContextTransactor, orderLineInfos model.OrderLineDatas, manager interfaces.PluginManagerInterface, updateStocks bool, allowStockTobeExceeded bool) (*model_helper.InsufficientStock, *model_helper.AppError)
 	// DecreaseAllocations Decreate allocations for provided order lines.
-	DecreaseAllocations(lineInfos []*model.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError)
+	DecreaseAllocations(transaction boil.ContextTransactor, lineInfos []*model.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError)
+	// DeleteBackInStockSubscriptions tells store to delete back in stock subscriptions with given ids
+	DeleteBackInStockSubscriptions(transaction boil.ContextTransactor, ids []string) *model_helper.AppError
 	// DeletePreorderAllocations tells store to delete given preorder allocations
 	DeletePreorderAllocations(transaction boil.ContextTransactor, preorderAllocationIDs ...string) *model_helper.AppError
 	// FilterStocksForChannel returns a slice of stocks that filtered using given options
@@ -103,7 +109,7 @@ type WarehouseService interface {
 	// Note it will raise a 'Stock.DoesNotExist' exception if no such stock is found.
 	GetVariantStocksForCountry(countryCode model.CountryCode, channelSlug string, variantID string) ([]*model.Stock, *model_helper.AppError)
 	// IncreaseAllocations ncrease allocation for order lines with appropriate quantity
-	IncreaseAllocations(lineInfos model.OrderLineDatas, channelSlug string, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError)
+	IncreaseAllocations(transaction boil.ContextTransactor, lineInfos model.OrderLineDatas, channelSlug string, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError)
 	// IncreaseStock Increse stock quantity for given `order_line` in a given warehouse.
 	//
 	// Function lock for update stock and allocations related to given `order_line`
@@ -116,8 +122,22 @@ type WarehouseService interface {
 	//
 	// NOTE: allocate is default to false
 	IncreaseStock(orderLine *model.OrderLine, wareHouse *model.Warehouse, quantity int, allocate bool) *model_helper.AppError
+	// LowStockThreshold returns the low stock threshold applied to given stock.
+	// The stock's own threshold takes precedence over its variant's one, which in turn
+	// takes precedence over the shop default. 0 means low stock notifications are disabled.
+	LowStockThreshold(stock model.Stock, variant *model.ProductVariant) int
+	// NotifyBackInStockSubscribers emails customers of given subscriptions whose variant is now available in the subscription's channel.
+	// Each subscriber receives a single email listing all of their variants. Subscriptions are removed once notified.
+	NotifyBackInStockSubscribers(subscriptions model.BackInStockSubscriptionSlice) *model_helper.AppError
+	// NotifyLowStocks notifies staff about given stocks whose available quantity has just dropped to or below their low stock threshold.
+	//
+	// previousQuantities maps stock ids to their available quantities before the change.
+	// Stocks that were already below their threshold are not reported again.
+	NotifyLowStocks(stocks model.StockSlice, previousQuantities map[string]int, manager interfaces.PluginManagerInterface) *model_helper.AppError
 	// PreOrderAllocationsByOptions returns a list of preorder allocations filtered using given options
 	PreOrderAllocationsByOptions(options *model.PreorderAllocationFilterOption) (model.PreorderAllocations, *model_helper.AppError)
+	// StaffNotificationRecipientEmails returns emails of all active staff notification recipients
+	StaffNotificationRecipientEmails() ([]string, *model_helper.AppError)
 	// StockDecreaseQuantity Return given quantity of product to a stock.
 	StockDecreaseQuantity(stockID string, quantity int) *model_helper.AppError
 	// StockIncreaseQuantity Return given quantity of product to a stock.
@@ -133,6 +153,11 @@ type WarehouseService interface {
 	//
 	// :raises InsufficientStock: when there is not enough items in stock for a variant
 	CheckStockQuantityBulk(variants model.ProductVariantSlice, countryCode model.CountryCode, quantities []int, channelSlug string, additionalFilterLookup model_types.JSONString, existingLines model_helper.CheckoutLineInfos, replace bool) (*model_helper.InsufficientStock, *model_helper.AppError)
+	// SubscribeBackInStock registers given customer to be notified when given variant is available again
+	SubscribeBackInStock(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, *model_helper.AppError)
+	// UnavailableBackInStockSubscriptions returns subscriptions to given variants whose variant is not available in the subscription's channel.
+	// Call it before stocks of the variants are increased, then pass the result to NotifyBackInStockSubscribers once the change is committed.
+	UnavailableBackInStockSubscriptions(variantIDs []string) (model.BackInStockSubscriptionSlice, *model_helper.AppError)
 	// ValidateWarehouseCount
 	//
 	//	Every ShippingZone can be assigned to only one warehouse.
@@ -1,6 +1,7 @@
 package warehouse
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/modules/slog"
 	"github.com/sitename/sitename/modules/util"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
@@ -19,6 +21,28 @@ type StockData struct {
 	Quantity int    // Quantity of the stock
 }
 
+// beginTransaction returns given transaction, or starts a new one if it is nil.
+// Returned commit and finalize functions only act on transactions started here,
+// transactions given by callers are committed by the callers themselves.
+func (a *ServiceWarehouse) beginTransaction(where string, transaction boil.ContextTransactor) (boil.ContextTransactor, func() *model_helper.AppError, func(), *model_helper.AppError) {
+	if transaction != nil {
+		return transaction, func() *model_helper.AppError { return nil }, func() {}, nil
+	}
+
+	tx, err := a.srv.Store.GetMaster().BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, nil, nil, model_helper.NewAppError(where, model_helper.ErrorCreatingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	commit := func() *model_helper.AppError {
+		if err := tx.Commit(); err != nil {
+			return model_helper.NewAppError(where, model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+		}
+		return nil
+	}
+	return tx, commit, func() { a.srv.Store.FinalizeTransaction(tx) }, nil
+}
+
 // Allocate stocks for given `order_lines` in given country.
 //
 // Function lock for update all stocks and allocations for variants in
@@ -27,12 +51,12 @@ type StockData struct {
 // Iterate by stocks and allocate as many items as needed or available in stock
 // for order line, until allocated all required quantity for the order line.
 // If there is less quantity in stocks then rise InsufficientStock exception.
-func (a *ServiceWarehouse) AllocateStocks(orderLineInfos model.OrderLineDatas, countryCode model.CountryCode, channelSlug string, manager interfaces.PluginManagerInterface, additionalFilterLookup model_types.JSONString) (*model_helper.InsufficientStock, *model_helper.AppError) {
-	transaction := a.srv.Store.GetMaster().Begin()
-	if transaction.Error != nil {
-		return nil, model_helper.NewAppError("AlloccateStocks", model_helper.ErrorCreatingTransactionErrorID, nil, transaction.Error.Error(), http.StatusInternalServerError)
+func (a *ServiceWarehouse) AllocateStocks(transaction boil.ContextTransactor, orderLineInfos model.OrderLineDatas, countryCode model.CountryCode, channelSlug string, manager interfaces.PluginManagerInterface, additionalFilterLookup model_types.JSONString) (*model_helper.InsufficientStock, *model_helper.AppError) {
+	transaction, commit, finalize, appErr := a.beginTransaction("AllocateStocks", transaction)
+	if appErr != nil {
+		return nil, appErr
 	}
-	defer a.srv.Store.FinalizeTransaction(transaction)
+	defer finalize()
 
 	// allocation only applied to order lines with variants with track inventory set to True
 	orderLineInfos = a.GetOrderLinesWithTrackInventory(orderLineInfos)
@@ -109,6 +133,9 @@ func (a *ServiceWarehouse) AllocateStocks(orderLineInfos model.OrderLineDatas, c
 	}
 
 	if len(insufficientStock) > 0 {
+		if a.srv.Metrics != nil {
+			a.srv.Metrics.IncrementStockAllocationFailure(channelSlug)
+		}
 		return &model_helper.InsufficientStock{Items: insufficientStock}, nil
 	}
 
@@ -161,8 +188,8 @@ func (a *ServiceWarehouse) AllocateStocks(orderLineInfos model.OrderLineDatas, c
 	}
 
 	// commit transaction
-	if err := transaction.Commit().Error; err != nil {
-		return nil, model_helper.NewAppError("AllocateStocks", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	if appErr := commit(); appErr != nil {
+		return nil, appErr
 	}
 
 	if len(outOfStocks) > 0 {
@@ -221,12 +248,12 @@ func (a *ServiceWarehouse) createAllocations(lineInfo *model.OrderLineData, stoc
 // as needed of available in stock for order line, until deallocated all required
 // quantity for the order line. If there is less quantity in stocks then
 // raise an exception.
-func (a *ServiceWarehouse) DeallocateStock(orderLineDatas model.OrderLineDatas, manager interfaces.PluginManagerInterface) (*model.AllocationError, *model_helper.AppError) {
-	transaction := a.srv.Store.GetMaster().Begin()
-	if transaction.Error != nil {
-		return nil, model_helper.NewAppError("DeallocateStock", model_helper.ErrorCreatingTransactionErrorID, nil, transaction.Error.Error(), http.StatusInternalServerError)
+func (a *ServiceWarehouse) DeallocateStock(transaction boil.ContextTransactor, orderLineDatas model.OrderLineDatas, manager interfaces.PluginManagerInterface) (*model.AllocationError, *model_helper.AppError) {
+	transaction, commit, finalize, appErr := a.beginTransaction("DeallocateStock", transaction)
+	if appErr != nil {
+		return nil, appErr
 	}
-	defer a.srv.Store.FinalizeTransaction(transaction)
+	defer finalize()
 
 	linesAllocations, appErr := a.AllocationsByOption(&model.AllocationFilterOption{
 		Conditions:           squirrel.Eq{model.AllocationTableName + ".OrderLineID": orderLineDatas.OrderLines().IDs()},
@@ -303,8 +330,8 @@ func (a *ServiceWarehouse) DeallocateStock(orderLineDatas model.OrderLineDatas,
 	}
 
 	// commit transaction:
-	if err := transaction.Commit().Error; err != nil {
-		return nil, model_helper.NewAppError("DeallocateStock", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	if appErr := commit(); appErr != nil {
+		return nil, appErr
 	}
 
 	// stockAndTotalQuantityAllocatedMap has keys are stock ids
@@ -362,6 +389,15 @@ func (a *ServiceWarehouse) IncreaseStock(orderLine *model.OrderLine, wareHouse *
 		stock = stocks[0]
 	}
 
+	// subscribers waiting for the variant in channels where it is unavailable are notified once the transaction is committed
+	var outOfStockSubscriptions model.BackInStockSubscriptionSlice
+	if !orderLine.VariantID.IsNil() {
+		outOfStockSubscriptions, appErr = a.UnavailableBackInStockSubscriptions([]string{*orderLine.VariantID.String})
+		if appErr != nil {
+			return appErr
+		}
+	}
+
 	if stock != nil {
 		stock.Quantity += quantity
 	} else {
@@ -418,22 +454,30 @@ func (a *ServiceWarehouse) IncreaseStock(orderLine *model.OrderLine, wareHouse *
 		return model_helper.NewAppError("IncreaseStock", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if len(outOfStockSubscriptions) > 0 {
+		a.srv.Go(func() {
+			if appErr := a.NotifyBackInStockSubscribers(outOfStockSubscriptions); appErr != nil {
+				slog.Error("Failed to notify back in stock subscribers", slog.String("product_variant_id", stock.ProductVariantID), slog.Err(appErr))
+			}
+		})
+	}
+
 	return nil
 }
 
 // IncreaseAllocations ncrease allocation for order lines with appropriate quantity
-func (a *ServiceWarehouse) IncreaseAllocations(lineInfos model.OrderLineDatas, channelSlug string, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceWarehouse) IncreaseAllocations(transaction boil.ContextTransactor, lineInfos model.OrderLineDatas, channelSlug string, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	// validate lineInfos is not nil nor empty
 	if len(lineInfos) == 0 {
 		return nil, model_helper.NewAppError("IncreaseAllocations", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "lineInfos"}, "", http.StatusBadRequest)
 	}
 
 	// start a transaction
-	transaction := a.srv.Store.GetMaster().Begin()
-	if transaction.Error != nil {
-		return nil, model_helper.NewAppError("IncreaseAllocations", model_helper.ErrorCreatingTransactionErrorID, nil, transaction.Error.Error(), http.StatusInternalServerError)
+	transaction, commit, finalize, appErr := a.beginTransaction("IncreaseAllocations", transaction)
+	if appErr != nil {
+		return nil, appErr
 	}
-	defer a.srv.Store.FinalizeTransaction(transaction)
+	defer finalize()
 
 	allocations, appErr := a.AllocationsByOption(&model.AllocationFilterOption{
 		Conditions:             squirrel.Eq{model.AllocationTableName + "." + model.AllocationColumnOrderLineID: lineInfos.OrderLines().IDs()},
@@ -490,26 +534,26 @@ func (a *ServiceWarehouse) IncreaseAllocations(lineInfos model.OrderLineDatas, c
 		return nil, appErr
 	}
 
-	insufficientErr, appErr := a.AllocateStocks(lineInfos, shippingAddress.Country, channelSlug, manager, nil)
+	insufficientErr, appErr := a.AllocateStocks(transaction, lineInfos, shippingAddress.Country, channelSlug, manager, nil)
 	if insufficientErr != nil || appErr != nil {
 		return insufficientErr, appErr
 	}
 
-	if err := transaction.Commit().Error; err != nil {
-		return nil, model_helper.NewAppError("IncreaseAllocations", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	if appErr := commit(); appErr != nil {
+		return nil, appErr
 	}
 
 	return nil, nil
 }
 
 // DecreaseAllocations Decreate allocations for provided order lines.
-func (a *ServiceWarehouse) DecreaseAllocations(lineInfos []*model.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceWarehouse) DecreaseAllocations(transaction boil.ContextTransactor, lineInfos []*model.OrderLineData, manager interfaces.PluginManagerInterface) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	trackedOrderLines := a.GetOrderLinesWithTrackInventory(lineInfos)
 	if len(trackedOrderLines) == 0 {
 		return nil, nil
 	}
 
-	return a.DecreaseStock(lineInfos, manager, false, false)
+	return a.DecreaseStock(transaction, lineInfos, manager, false, false)
 }
 
 // Decrease stocks quantities for given `order_lines` in given warehouses.
@@ -524,24 +568,24 @@ func (a *ServiceWarehouse) DecreaseAllocations(lineInfos []*model.OrderLineData,
 // If allow_stock_to_be_exceeded flag is True then quantity could be < 0.
 //
 // updateStocks default to true
-func (a *ServiceWarehouse) DecreaseStock(orderLineInfos model.OrderLineDatas, manager interfaces.PluginManagerInterface, updateStocks bool, allowStockTobeExceeded bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
+func (a *ServiceWarehouse) DecreaseStock(transaction boil.ContextTransactor, orderLineInfos model.OrderLineDatas, manager interfaces.PluginManagerInterface, updateStocks bool, allowStockTobeExceeded bool) (*model_helper.InsufficientStock, *model_helper.AppError) {
 	// validate orderLineInfos is not nil nor empty
 	if len(orderLineInfos) == 0 {
 		return nil, model_helper.NewAppError("DecreaseStock", model_helper.InvalidArgumentAppErrorID, map[string]any{"Fields": "orderLineInfos"}, "", http.StatusBadRequest)
 	}
 
-	transaction := a.srv.Store.GetMaster().Begin()
-	if transaction.Error != nil {
-		return nil, model_helper.NewAppError("DecreaseStock", model_helper.ErrorCreatingTransactionErrorID, nil, transaction.Error.Error(), http.StatusInternalServerError)
+	transaction, commit, finalize, appErr := a.beginTransaction("DecreaseStock", transaction)
+	if appErr != nil {
+		return nil, appErr
 	}
-	defer a.srv.Store.FinalizeTransaction(transaction)
+	defer finalize()
 
 	var (
 		variantIDs   = orderLineInfos.Variants().IDs()
 		warehouseIDs = orderLineInfos.WarehouseIDs()
 	)
 
-	allocationErr, appErr := a.DeallocateStock(orderLineInfos, manager)
+	allocationErr, appErr := a.DeallocateStock(transaction, orderLineInfos, manager)
 	if appErr != nil {
 		return nil, appErr
 	}
@@ -610,6 +654,12 @@ func (a *ServiceWarehouse) DecreaseStock(orderLineInfos model.OrderLineDatas, ma
 		quantityAllocationForStocks[allocation.StockID] += allocation.QuantityAllocated
 	}
 
+	// availableQuantitiesBefore has keys are stock ids, values are available quantities of stocks before decreasing
+	var availableQuantitiesBefore = map[string]int{}
+	for _, stock := range stocks {
+		availableQuantitiesBefore[stock.ID] = stock.Quantity - stock.QuantityAllocated
+	}
+
 	if updateStocks {
 		insufficientErr, appErr := a.decreaseStocksQuantity(transaction, orderLineInfos, variantAndWarehouseToStock, quantityAllocationForStocks)
 		if insufficientErr != nil || appErr != nil {
@@ -618,8 +668,8 @@ func (a *ServiceWarehouse) DecreaseStock(orderLineInfos model.OrderLineDatas, ma
 	}
 
 	// commit transaction
-	if err := transaction.Commit().Error; err != nil {
-		return nil, model_helper.NewAppError("DecreaseStock", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
+	if appErr := commit(); appErr != nil {
+		return nil, appErr
 	}
 
 	if updateStocks {
@@ -642,6 +692,10 @@ func (a *ServiceWarehouse) DecreaseStock(orderLineInfos model.OrderLineDatas, ma
 				}
 			}
 		}
+
+		if appErr := a.NotifyLowStocks(foundStocks, availableQuantitiesBefore, manager); appErr != nil {
+			slog.Error("Failed to notify staff about low stocks", slog.Err(appErr))
+		}
 	}
 
 	return nil, nil
@@ -716,14 +770,10 @@ func (a *ServiceWarehouse) GetOrderLinesWithTrackInventory(orderLineInfos []*mod
 	return res
 }
 
-// DeAllocateStockForOrder Remove all allocations for given order
-func (a *ServiceWarehouse) DeAllocateStockForOrder(ord *model.Order, manager interfaces.PluginManagerInterface) *model_helper.AppError {
-	transaction := a.srv.Store.GetMaster().Begin()
-	if transaction.Error != nil {
-		return model_helper.NewAppError("DeAllocateStockForOrder", model_helper.ErrorCreatingTransactionErrorID, nil, transaction.Error.Error(), http.StatusInternalServerError)
-	}
-	defer a.srv.Store.FinalizeTransaction(transaction)
-
+// DeAllocateStockForOrder Remove all allocations for given order within given transaction.
+// It returns stocks which had no available quantity before, callers should trigger ProductVariantBackInStock
+// for them once the transaction is committed.
+func (a *ServiceWarehouse) DeAllocateStockForOrder(transaction boil.ContextTransactor, ord *model.Order) ([]*model.Stock, *model_helper.AppError) {
 	allocations, appErr := a.AllocationsByOption(&model.AllocationFilterOption{
 		Conditions:                     squirrel.Gt{model.AllocationTableName + ".QuantityAllocated": 0},
 		OrderLineOrderID:               squirrel.Eq{model.OrderLineTableName + ".OrderID": ord.Id},
@@ -732,40 +782,28 @@ func (a *ServiceWarehouse) DeAllocateStockForOrder(ord *model.Order, manager int
 	})
 	if appErr != nil {
 		if appErr.StatusCode == http.StatusInternalServerError {
-			return appErr
+			return nil, appErr
 		}
-		return nil
+		return nil, nil
 	}
 
-	allocationsToHandleAfterCommit := []*model.Allocation{}
+	var backInStock []*model.Stock
 
 	for _, allocation := range allocations {
 
 		allocation.QuantityAllocated = 0
 
 		if allocation.StockAvailableQuantity <= 0 {
-			allocationsToHandleAfterCommit = append(allocationsToHandleAfterCommit, allocation)
+			backInStock = append(backInStock, allocation.Stock)
 		}
 	}
 
 	_, appErr = a.BulkUpsertAllocations(transaction, allocations)
 	if appErr != nil {
-		return appErr
-	}
-
-	// commit transaction
-	if err := transaction.Commit().Error; err != nil {
-		return model_helper.NewAppError("DeAllocateStockForOrder", model_helper.ErrorCommittingTransactionErrorID, nil, err.Error(), http.StatusInternalServerError)
-	}
-
-	for _, allocation := range allocationsToHandleAfterCommit {
-		appErr = manager.ProductVariantBackInStock(*allocation.Stock)
-		if appErr != nil {
-			return appErr
-		}
+		return nil, appErr
 	}
 
-	return nil
+	return backInStock, nil
 }
 
 // AllocatePreOrders allocates pre-order variant for given `order_lines` in given channel
@@ -860,6 +898,9 @@ func (s *ServiceWarehouse) AllocatePreOrders(orderLinesInfo model.OrderLineDatas
 	}
 
 	if len(insufficientStocks) > 0 {
+		if s.srv.Metrics != nil {
+			s.srv.Metrics.IncrementStockAllocationFailure(channelSlug)
+		}
 		return model.NewInsufficientStock(insufficientStocks), nil
 	}
 
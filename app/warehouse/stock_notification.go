@@ -0,0 +1,308 @@
+package warehouse
+
+import (
+	"net/http"
+
+	"github.com/mattermost/squirrel"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/app/email"
+	"github.com/sitename/sitename/app/plugin/interfaces"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/slog"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// BackInStockSubscriptionsByOptions returns a list of back in stock subscriptions filtered using given options
+func (s *ServiceWarehouse) BackInStockSubscriptionsByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, *model_helper.AppError) {
+	subscriptions, err := s.srv.Store.BackInStockSubscription().FilterByOptions(options)
+	if err != nil {
+		return nil, model_helper.NewAppError("BackInStockSubscriptionsByOptions", "app.warehouse.error_finding_back_in_stock_subscriptions_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return subscriptions, nil
+}
+
+// SubscribeBackInStock registers given customer to be notified when given variant is available again
+func (s *ServiceWarehouse) SubscribeBackInStock(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, *model_helper.AppError) {
+	savedSubscription, err := s.srv.Store.BackInStockSubscription().Save(subscription)
+	if err != nil {
+		if appErr, ok := err.(*model_helper.AppError); ok {
+			return nil, appErr
+		}
+		statusCode := http.StatusInternalServerError
+		if _, ok := err.(*store.ErrInvalidInput); ok {
+			statusCode = http.StatusBadRequest
+		}
+		return nil, model_helper.NewAppError("SubscribeBackInStock", "app.warehouse.error_saving_back_in_stock_subscription.app_error", nil, err.Error(), statusCode)
+	}
+
+	return savedSubscription, nil
+}
+
+// DeleteBackInStockSubscriptions tells store to delete back in stock subscriptions with given ids
+func (s *ServiceWarehouse) DeleteBackInStockSubscriptions(transaction boil.ContextTransactor, ids []string) *model_helper.AppError {
+	err := s.srv.Store.BackInStockSubscription().Delete(transaction, ids)
+	if err != nil {
+		return model_helper.NewAppError("DeleteBackInStockSubscriptions", "app.warehouse.error_deleting_back_in_stock_subscriptions.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// LowStockThreshold returns the low stock threshold applied to given stock.
+// The stock's own threshold takes precedence over its variant's one, which in turn
+// takes precedence over the shop default. 0 means low stock notifications are disabled.
+func (s *ServiceWarehouse) LowStockThreshold(stock model.Stock, variant *model.ProductVariant) int {
+	if stock.LowStockThreshold.Int != nil {
+		return *stock.LowStockThreshold.Int
+	}
+	if variant != nil && variant.LowStockThreshold.Int != nil {
+		return *variant.LowStockThreshold.Int
+	}
+	return *s.srv.Config().ShopSettings.DefaultLowStockThreshold
+}
+
+// StaffNotificationRecipientEmails returns emails of all active staff notification recipients
+func (s *ServiceWarehouse) StaffNotificationRecipientEmails() ([]string, *model_helper.AppError) {
+	recipients, err := s.srv.Store.StaffNotificationRecipient().FilterByOptions(model_helper.StaffNotificationRecipientFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(
+			model.StaffNotificationRecipientWhere.Active.EQ(true),
+			qm.Load(model.StaffNotificationRecipientRels.User),
+		),
+	})
+	if err != nil {
+		return nil, model_helper.NewAppError("StaffNotificationRecipientEmails", "app.account.staff_notification_recipients_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	emails := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if user := recipient.R.GetUser(); user != nil {
+			emails = append(emails, user.Email)
+		} else if recipient.StaffEmail.String != nil {
+			emails = append(emails, *recipient.StaffEmail.String)
+		}
+	}
+
+	return emails, nil
+}
+
+// NotifyLowStocks notifies staff about given stocks whose available quantity has just dropped to or below their low stock threshold.
+//
+// previousQuantities maps stock ids to their available quantities before the change.
+// Stocks that were already below their threshold are not reported again.
+func (s *ServiceWarehouse) NotifyLowStocks(stocks model.StockSlice, previousQuantities map[string]int, manager interfaces.PluginManagerInterface) *model_helper.AppError {
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	variantIDs := make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		variantIDs = append(variantIDs, stock.ProductVariantID)
+	}
+	variants, err := s.srv.Store.ProductVariant().FilterByOption(model_helper.ProductVariantFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductVariantWhere.ID.IN(variantIDs)),
+	})
+	if err != nil {
+		return model_helper.NewAppError("NotifyLowStocks", "app.product.error_finding_product_variants_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	variantsMap := map[string]*model.ProductVariant{}
+	for _, variant := range variants {
+		variantsMap[variant.ID] = variant
+	}
+
+	var lowStocks []map[string]any
+	for _, stock := range stocks {
+		variant := variantsMap[stock.ProductVariantID]
+		threshold := s.LowStockThreshold(*stock, variant)
+		if threshold <= 0 {
+			continue
+		}
+
+		available := stock.Quantity - stock.QuantityAllocated
+		if previous, ok := previousQuantities[stock.ID]; available > threshold || (ok && previous <= threshold) {
+			continue
+		}
+
+		stockPayload := map[string]any{
+			"id":                  stock.ID,
+			"warehouse_id":        stock.WarehouseID,
+			"product_variant_id":  stock.ProductVariantID,
+			"quantity":            stock.Quantity,
+			"quantity_allocated":  stock.QuantityAllocated,
+			"low_stock_threshold": threshold,
+		}
+		if variant != nil {
+			stockPayload["product_variant_name"] = variant.Name
+			stockPayload["sku"] = variant.Sku
+		}
+		lowStocks = append(lowStocks, stockPayload)
+	}
+	if len(lowStocks) == 0 {
+		return nil
+	}
+
+	recipients, appErr := s.StaffNotificationRecipientEmails()
+	if appErr != nil {
+		return appErr
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	payload := map[string]any{
+		"recipient_list": recipients,
+		"stocks":         lowStocks,
+		"domain":         *s.srv.Config().ServiceSettings.SiteURL,
+		"site_name":      *s.srv.Config().ServiceSettings.SiteName,
+	}
+
+	_, appErr = manager.Notify(model_helper.STAFF_LOW_STOCK, payload, "", "")
+	return appErr
+}
+
+// availableBackInStockSubscriptions tells which of given subscriptions have their variant available in their channel.
+// Available quantity of a variant is its stock quantity minus allocated quantity, summed over warehouses of the channel.
+// The returned map's keys are subscription ids.
+func (s *ServiceWarehouse) availableBackInStockSubscriptions(subscriptions model.BackInStockSubscriptionSlice) (map[string]bool, *model_helper.AppError) {
+	type variantInChannel struct{ variantID, channelID string }
+
+	variantIDsByChannel := map[string][]string{}
+	for _, subscription := range subscriptions {
+		variantIDsByChannel[subscription.ChannelID] = append(variantIDsByChannel[subscription.ChannelID], subscription.ProductVariantID)
+	}
+
+	availableQuantities := map[variantInChannel]int{}
+	for channelID, variantIDs := range variantIDsByChannel {
+		stocks, err := s.srv.Store.Stock().FilterForChannel(model_helper.StockFilterForChannelOption{
+			ChannelID:  channelID,
+			Conditions: squirrel.Eq{model.StockTableColumns.ProductVariantID: lo.Uniq(variantIDs)},
+		})
+		if err != nil {
+			return nil, model_helper.NewAppError("availableBackInStockSubscriptions", "app.warehouse.error_finding_stocks_for_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, stock := range stocks {
+			availableQuantities[variantInChannel{stock.ProductVariantID, channelID}] += max(stock.Quantity-stock.QuantityAllocated, 0)
+		}
+	}
+
+	available := make(map[string]bool, len(subscriptions))
+	for _, subscription := range subscriptions {
+		available[subscription.ID] = availableQuantities[variantInChannel{subscription.ProductVariantID, subscription.ChannelID}] > 0
+	}
+	return available, nil
+}
+
+// UnavailableBackInStockSubscriptions returns subscriptions to given variants whose variant is not available in the subscription's channel.
+// Call it before stocks of the variants are increased, then pass the result to NotifyBackInStockSubscribers once the change is committed.
+func (s *ServiceWarehouse) UnavailableBackInStockSubscriptions(variantIDs []string) (model.BackInStockSubscriptionSlice, *model_helper.AppError) {
+	subscriptions, appErr := s.BackInStockSubscriptionsByOptions(model_helper.BackInStockSubscriptionFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.BackInStockSubscriptionWhere.ProductVariantID.IN(variantIDs)),
+	})
+	if appErr != nil || len(subscriptions) == 0 {
+		return nil, appErr
+	}
+
+	available, appErr := s.availableBackInStockSubscriptions(subscriptions)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return lo.Filter(subscriptions, func(subscription *model.BackInStockSubscription, _ int) bool { return !available[subscription.ID] }), nil
+}
+
+// NotifyBackInStockSubscribers emails customers of given subscriptions whose variant is now available in the subscription's channel.
+// Each subscriber receives a single email listing all of their variants. Subscriptions are removed once notified.
+func (s *ServiceWarehouse) NotifyBackInStockSubscribers(subscriptions model.BackInStockSubscriptionSlice) *model_helper.AppError {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	available, appErr := s.availableBackInStockSubscriptions(subscriptions)
+	if appErr != nil {
+		return appErr
+	}
+	subscriptions = lo.Filter(subscriptions, func(subscription *model.BackInStockSubscription, _ int) bool { return available[subscription.ID] })
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	variantIDs := lo.Uniq(lo.Map(subscriptions, func(subscription *model.BackInStockSubscription, _ int) string { return subscription.ProductVariantID }))
+	variants, err := s.srv.Store.ProductVariant().FilterByOption(model_helper.ProductVariantFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ProductVariantWhere.ID.IN(variantIDs)),
+		Preloads:           []string{model.ProductVariantRels.Product},
+	})
+	if err != nil {
+		return model_helper.NewAppError("NotifyBackInStockSubscribers", "app.product.error_finding_product_variants_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var (
+		siteURL     = *s.srv.Config().ServiceSettings.SiteURL
+		products    = map[string]email.BackInStockProduct{}
+		userIDs     []string
+		subsByEmail = map[string]model.BackInStockSubscriptionSlice{}
+	)
+	for _, variant := range variants {
+		product := email.BackInStockProduct{Name: variant.Name}
+		if prd := variant.R.GetProduct(); prd != nil {
+			if variant.Name == "" {
+				product.Name = prd.Name
+			} else {
+				product.Name = prd.Name + " - " + variant.Name
+			}
+			product.URL = siteURL + "/products/" + prd.Slug + "?variant=" + variant.ID
+		}
+		products[variant.ID] = product
+	}
+	for _, subscription := range subscriptions {
+		subsByEmail[subscription.Email] = append(subsByEmail[subscription.Email], subscription)
+		if subscription.UserID.String != nil {
+			userIDs = append(userIDs, *subscription.UserID.String)
+		}
+	}
+
+	localesByEmail := map[string]string{}
+	if len(userIDs) > 0 {
+		users, err := s.srv.Store.User().Find(model_helper.UserFilterOptions{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(model.UserWhere.ID.IN(userIDs)),
+		})
+		if err != nil {
+			return model_helper.NewAppError("NotifyBackInStockSubscribers", "app.account.error_finding_users_by_options.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, user := range users {
+			localesByEmail[model_helper.NormalizeEmail(user.Email)] = user.Locale.String()
+		}
+	}
+
+	var notifiedIDs []string
+	for emailAddress, subs := range subsByEmail {
+		var items []email.BackInStockProduct
+		for _, sub := range subs {
+			if product, ok := products[sub.ProductVariantID]; ok {
+				items = append(items, product)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		locale, ok := localesByEmail[emailAddress]
+		if !ok {
+			locale = s.srv.Config().LocalizationSettings.DefaultClientLocale.String()
+		}
+
+		if err := s.srv.EmailService.SendBackInStockEmail(emailAddress, items, locale, siteURL); err != nil {
+			// keep the subscriptions so the customer gets notified next time the variant is restocked
+			slog.Error("Failed to send back in stock email", slog.String("email", emailAddress), slog.Err(err))
+			continue
+		}
+		for _, sub := range subs {
+			notifiedIDs = append(notifiedIDs, sub.ID)
+		}
+	}
+
+	if len(notifiedIDs) == 0 {
+		return nil
+	}
+	return s.DeleteBackInStockSubscriptions(nil, notifiedIDs)
+}
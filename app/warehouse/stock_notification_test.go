@@ -0,0 +1,53 @@
+package warehouse
+
+import (
+	"testing"
+
+	"github.com/sitename/sitename/app"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackInStockSubscriptionsTrigger(t *testing.T) {
+	var (
+		shirt, pants   = model_helper.NewId(), model_helper.NewId()
+		europe, asia   = model_helper.NewId(), model_helper.NewId()
+		shirtInEurope  = &model.BackInStockSubscription{ID: model_helper.NewId(), ProductVariantID: shirt, ChannelID: europe}
+		shirtInAsia    = &model.BackInStockSubscription{ID: model_helper.NewId(), ProductVariantID: shirt, ChannelID: asia}
+		pantsInEurope  = &model.BackInStockSubscription{ID: model_helper.NewId(), ProductVariantID: pants, ChannelID: europe}
+		stocksOfEurope = model.StockSlice{
+			// all shirts in Europe are allocated to orders
+			{ProductVariantID: shirt, Quantity: 5, QuantityAllocated: 5},
+			{ProductVariantID: shirt, Quantity: 0},
+			{ProductVariantID: pants, Quantity: 2},
+		}
+		stocksOfAsia = model.StockSlice{
+			{ProductVariantID: shirt, Quantity: 3, QuantityAllocated: 1},
+		}
+	)
+
+	subscriptions := &mocks.BackInStockSubscriptionStore{}
+	subscriptions.On("FilterByOptions", mock.Anything).Return(model.BackInStockSubscriptionSlice{shirtInEurope, shirtInAsia, pantsInEurope}, nil)
+	stocks := &mocks.StockStore{}
+	stocks.On("FilterForChannel", mock.MatchedBy(func(options model_helper.StockFilterForChannelOption) bool { return options.ChannelID == europe })).Return(stocksOfEurope, nil)
+	stocks.On("FilterForChannel", mock.MatchedBy(func(options model_helper.StockFilterForChannelOption) bool { return options.ChannelID == asia })).Return(stocksOfAsia, nil)
+
+	mockStore := &mocks.Store{}
+	mockStore.On("BackInStockSubscription").Return(subscriptions)
+	mockStore.On("Stock").Return(stocks)
+	service := &ServiceWarehouse{srv: &app.Server{Store: mockStore}}
+
+	// only the shirt in Europe is unavailable, counting allocations and all warehouses of the channel
+	unavailable, appErr := service.UnavailableBackInStockSubscriptions([]string{shirt, pants})
+	require.Nil(t, appErr)
+	require.Equal(t, model.BackInStockSubscriptionSlice{shirtInEurope}, unavailable)
+
+	// shirts were restocked in a warehouse that does not serve Europe, so nobody is notified
+	appErr = service.NotifyBackInStockSubscribers(unavailable)
+	require.Nil(t, appErr)
+	mockStore.AssertNotCalled(t, "ProductVariant")
+	subscriptions.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS back_in_stock_subscriptions_product_variant_id;
+DROP INDEX IF EXISTS back_in_stock_subscriptions_user_id;
+DROP INDEX IF EXISTS back_in_stock_subscriptions_product_variant_id_channel_id_email;
+DROP TABLE IF EXISTS back_in_stock_subscriptions;
+
+ALTER TABLE product_variants DROP COLUMN IF EXISTS low_stock_threshold;
+ALTER TABLE stocks DROP COLUMN IF EXISTS low_stock_threshold;
@@ -0,0 +1,15 @@
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS low_stock_threshold integer;
+ALTER TABLE product_variants ADD COLUMN IF NOT EXISTS low_stock_threshold integer;
+
+CREATE TABLE IF NOT EXISTS back_in_stock_subscriptions (
+  id varchar(36) NOT NULL PRIMARY KEY,
+  product_variant_id varchar(36) NOT NULL,
+  channel_id varchar(36) NOT NULL,
+  email varchar(128) NOT NULL,
+  user_id varchar(36),
+  created_at bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS back_in_stock_subscriptions_product_variant_id ON back_in_stock_subscriptions (product_variant_id);
+CREATE INDEX IF NOT EXISTS back_in_stock_subscriptions_user_id ON back_in_stock_subscriptions (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS back_in_stock_subscriptions_product_variant_id_channel_id_email ON back_in_stock_subscriptions (product_variant_id, channel_id, email);
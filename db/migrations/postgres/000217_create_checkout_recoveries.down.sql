@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS checkout_recoveries_checkout_token_key;
+DROP INDEX IF EXISTS checkout_recoveries_order_id;
+DROP TABLE IF EXISTS checkout_recoveries;
+
+-- NOTE: postgres does not support removing a value from an enum type, 'abandoned_checkouts' stays in job_type.
@@ -0,0 +1,16 @@
+ALTER TYPE job_type ADD VALUE IF NOT EXISTS 'abandoned_checkouts';
+
+CREATE TABLE IF NOT EXISTS checkout_recoveries (
+  id varchar(36) NOT NULL PRIMARY KEY,
+  checkout_token varchar(36) NOT NULL,
+  email varchar(128) NOT NULL,
+  reminders_sent integer NOT NULL,
+  last_reminder_at bigint NOT NULL,
+  recovered_at bigint,
+  order_id varchar(36),
+  converted_at bigint,
+  created_at bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS checkout_recoveries_checkout_token_key ON checkout_recoveries (checkout_token);
+CREATE INDEX IF NOT EXISTS checkout_recoveries_order_id ON checkout_recoveries (order_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_channel_id_status_created_at;
+
+-- NOTE: postgres does not support removing a value from an enum type, 'expire_orders' stays in job_type.
@@ -0,0 +1,3 @@
+ALTER TYPE job_type ADD VALUE IF NOT EXISTS 'expire_orders';
+
+CREATE INDEX IF NOT EXISTS idx_orders_channel_id_status_created_at ON orders (channel_id, status, created_at);
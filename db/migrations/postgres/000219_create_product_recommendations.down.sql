@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS product_recommendations_channel_id_product_id_kind_recommended_product_id_key;
+DROP INDEX IF EXISTS product_recommendations_recommended_product_id;
+DROP TABLE IF EXISTS product_recommendations;
+
+-- NOTE: postgres does not support removing a value from an enum type, 'product_recommendations' stays in job_type.
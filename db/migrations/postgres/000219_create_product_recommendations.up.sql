@@ -0,0 +1,14 @@
+ALTER TYPE job_type ADD VALUE IF NOT EXISTS 'product_recommendations';
+
+CREATE TABLE IF NOT EXISTS product_recommendations (
+  id varchar(36) NOT NULL PRIMARY KEY,
+  channel_id varchar(36) NOT NULL,
+  product_id varchar(36) NOT NULL,
+  recommended_product_id varchar(36) NOT NULL,
+  kind varchar(32) NOT NULL,
+  score real NOT NULL,
+  created_at bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS product_recommendations_channel_id_product_id_kind_recommended_product_id_key ON product_recommendations (channel_id, product_id, kind, recommended_product_id);
+CREATE INDEX IF NOT EXISTS product_recommendations_recommended_product_id ON product_recommendations (recommended_product_id);
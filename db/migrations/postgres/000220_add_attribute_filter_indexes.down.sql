@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_attribute_values_attribute_id_boolean;
+DROP INDEX IF EXISTS idx_attribute_values_attribute_id_datetime;
+DROP INDEX IF EXISTS idx_assigned_product_attribute_values_assignment_id;
+DROP INDEX IF EXISTS idx_custom_product_attributes_product_id_slug;
+DROP INDEX IF EXISTS idx_custom_product_attribute_values_attribute_id;
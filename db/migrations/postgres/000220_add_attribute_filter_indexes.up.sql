@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_attribute_values_attribute_id_boolean ON attribute_values (attribute_id, boolean);
+CREATE INDEX IF NOT EXISTS idx_attribute_values_attribute_id_datetime ON attribute_values (attribute_id, datetime);
+CREATE INDEX IF NOT EXISTS idx_assigned_product_attribute_values_assignment_id ON assigned_product_attribute_values (assignment_id);
+CREATE INDEX IF NOT EXISTS idx_custom_product_attributes_product_id_slug ON custom_product_attributes (product_id, slug);
+CREATE INDEX IF NOT EXISTS idx_custom_product_attribute_values_attribute_id ON custom_product_attribute_values (attribute_id);
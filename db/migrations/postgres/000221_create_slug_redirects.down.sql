@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS slug_redirects_object_id;
+DROP INDEX IF EXISTS slug_redirects_object_type_old_slug_key;
+DROP TABLE IF EXISTS slug_redirects;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS slug_redirects (
+  id varchar(36) NOT NULL PRIMARY KEY,
+  object_type varchar(32) NOT NULL,
+  object_id varchar(36) NOT NULL,
+  old_slug varchar(255) NOT NULL,
+  created_at bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS slug_redirects_object_type_old_slug_key ON slug_redirects (object_type, old_slug);
+CREATE INDEX IF NOT EXISTS slug_redirects_object_id ON slug_redirects (object_id);
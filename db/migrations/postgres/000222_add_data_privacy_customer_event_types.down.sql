@@ -0,0 +1 @@
+-- NOTE: postgres does not support removing a value from an enum type, the data privacy values stay in customer_event_type.
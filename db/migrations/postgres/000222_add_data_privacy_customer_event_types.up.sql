@@ -0,0 +1,4 @@
+ALTER TYPE customer_event_type ADD VALUE IF NOT EXISTS 'DATA_EXPORT_REQUESTED';
+ALTER TYPE customer_event_type ADD VALUE IF NOT EXISTS 'DATA_EXPORTED';
+ALTER TYPE customer_event_type ADD VALUE IF NOT EXISTS 'DATA_ERASURE_REQUESTED';
+ALTER TYPE customer_event_type ADD VALUE IF NOT EXISTS 'DATA_ERASED';
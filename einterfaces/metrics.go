@@ -1,18 +1,15 @@
 package einterfaces
 
 import (
+	"net/http"
+
 	"github.com/mattermost/logr/v2"
 )
 
 type MetricsInterface interface {
 	Register()
-
-	IncrementPostCreate()
-	IncrementWebhookPost()
-	IncrementPostSentEmail()
-	IncrementPostSentPush()
-	IncrementPostBroadcast()
-	IncrementPostFileAttachment(count int)
+	// Handler serves collected metrics, in Prometheus exposition format
+	Handler() http.Handler
 
 	IncrementHttpRequest()
 	IncrementHttpError()
@@ -44,16 +41,9 @@ type MetricsInterface interface {
 	AddMemCacheHitCounter(cacheName string, amount float64)
 	AddMemCacheMissCounter(cacheName string, amount float64)
 
-	IncrementPostsSearchCounter()
-	ObservePostsSearchDuration(elapsed float64)
-	IncrementFilesSearchCounter()
-	ObserveFilesSearchDuration(elapsed float64)
 	ObserveStoreMethodDuration(method, success string, elapsed float64)
 	ObserveApiEndpointDuration(endpoint, method, statusCode string, elapsed float64)
-	IncrementPostIndexCounter()
-	IncrementFileIndexCounter()
-	IncrementUserIndexCounter()
-	IncrementChannelIndexCounter()
+	ObserveGraphQLOperationDuration(operationType string, success bool, elapsed float64)
 
 	ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64)
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
@@ -63,16 +53,17 @@ type MetricsInterface interface {
 	ObserveEnabledUsers(users int64)
 	GetLoggerMetricsCollector() logr.MetricsCollector
 
-	IncrementRemoteClusterMsgSentCounter(remoteID string)
-	IncrementRemoteClusterMsgReceivedCounter(remoteID string)
-	IncrementRemoteClusterMsgErrorsCounter(remoteID string, timeout bool)
-	ObserveRemoteClusterPingDuration(remoteID string, elapsed float64)
-	ObserveRemoteClusterClockSkew(remoteID string, skew float64)
-	IncrementRemoteClusterConnStateChangeCounter(remoteID string, online bool)
-
 	IncrementJobActive(jobType string)
 	DecrementJobActive(jobType string)
 
 	SetReplicaLagAbsolute(node string, value float64)
 	SetReplicaLagTime(node string, value float64)
+
+	// IncrementOrderPlaced counts orders placed in the channel, from checkouts or completed draft orders
+	IncrementOrderPlaced(channelID string)
+	// IncrementCheckoutCreated and IncrementCheckoutCompleted count checkouts, their ratio is the checkout completion rate
+	IncrementCheckoutCreated(channelID string)
+	IncrementCheckoutCompleted(channelID string)
+	IncrementPaymentGatewayError(gateway string)
+	IncrementStockAllocationFailure(channelSlug string)
 }
@@ -17,6 +17,7 @@ var TableNames = struct {
 	AttributeValues                       string
 	Attributes                            string
 	Audits                                string
+	BackInStockSubscriptions              string
 	Categories                            string
 	CategoryAttributes                    string
 	CategoryTranslations                  string
@@ -24,6 +25,7 @@ var TableNames = struct {
 	CheckoutDiscounts                     string
 	CheckoutLineDiscounts                 string
 	CheckoutLines                         string
+	CheckoutRecoveries                    string
 	Checkouts                             string
 	ClusterDiscoveries                    string
 	CollectionChannelListings             string
@@ -75,6 +77,7 @@ var TableNames = struct {
 	ProductChannelListings                string
 	ProductCollections                    string
 	ProductMedia                          string
+	ProductRecommendations                string
 	ProductTranslations                   string
 	ProductVariantChannelListings         string
 	ProductVariantTranslations            string
@@ -107,6 +110,7 @@ var TableNames = struct {
 	ShopStaffs                            string
 	ShopTranslations                      string
 	Shops                                 string
+	SlugRedirects                         string
 	StaffNotificationRecipients           string
 	Status                                string
 	Stocks                                string
@@ -153,6 +157,7 @@ var TableNames = struct {
 	AttributeValues:                       "attribute_values",
 	Attributes:                            "attributes",
 	Audits:                                "audits",
+	BackInStockSubscriptions:              "back_in_stock_subscriptions",
 	Categories:                            "categories",
 	CategoryAttributes:                    "category_attributes",
 	CategoryTranslations:                  "category_translations",
@@ -160,6 +165,7 @@ var TableNames = struct {
 	CheckoutDiscounts:                     "checkout_discounts",
 	CheckoutLineDiscounts:                 "checkout_line_discounts",
 	CheckoutLines:                         "checkout_lines",
+	CheckoutRecoveries:                    "checkout_recoveries",
 	Checkouts:                             "checkouts",
 	ClusterDiscoveries:                    "cluster_discoveries",
 	CollectionChannelListings:             "collection_channel_listings",
@@ -211,6 +217,7 @@ var TableNames = struct {
 	ProductChannelListings:                "product_channel_listings",
 	ProductCollections:                    "product_collections",
 	ProductMedia:                          "product_media",
+	ProductRecommendations:                "product_recommendations",
 	ProductTranslations:                   "product_translations",
 	ProductVariantChannelListings:         "product_variant_channel_listings",
 	ProductVariantTranslations:            "product_variant_translations",
@@ -243,6 +250,7 @@ var TableNames = struct {
 	ShopStaffs:                            "shop_staffs",
 	ShopTranslations:                      "shop_translations",
 	Shops:                                 "shops",
+	SlugRedirects:                         "slug_redirects",
 	StaffNotificationRecipients:           "staff_notification_recipients",
 	Status:                                "status",
 	Stocks:                                "stocks",
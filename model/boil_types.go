@@ -5751,6 +5751,10 @@ const (
 	CustomerEventTypeNOTE_ADDED               CustomerEventType = "NOTE_ADDED"
 	CustomerEventTypeACCOUNT_ACTIVATED        CustomerEventType = "ACCOUNT_ACTIVATED"
 	CustomerEventTypeACCOUNT_DEACTIVATED      CustomerEventType = "ACCOUNT_DEACTIVATED"
+	CustomerEventTypeDATA_EXPORT_REQUESTED    CustomerEventType = "DATA_EXPORT_REQUESTED"
+	CustomerEventTypeDATA_EXPORTED            CustomerEventType = "DATA_EXPORTED"
+	CustomerEventTypeDATA_ERASURE_REQUESTED   CustomerEventType = "DATA_ERASURE_REQUESTED"
+	CustomerEventTypeDATA_ERASED              CustomerEventType = "DATA_ERASED"
 )
 
 func AllCustomerEventType() []CustomerEventType {
@@ -5770,12 +5774,16 @@ func AllCustomerEventType() []CustomerEventType {
 		CustomerEventTypeNOTE_ADDED,
 		CustomerEventTypeACCOUNT_ACTIVATED,
 		CustomerEventTypeACCOUNT_DEACTIVATED,
+		CustomerEventTypeDATA_EXPORT_REQUESTED,
+		CustomerEventTypeDATA_EXPORTED,
+		CustomerEventTypeDATA_ERASURE_REQUESTED,
+		CustomerEventTypeDATA_ERASED,
 	}
 }
 
 func (e CustomerEventType) IsValid() error {
 	switch e {
-	case CustomerEventTypeACCOUNT_CREATED, CustomerEventTypePASSWORD_RESET_LINK_SENT, CustomerEventTypePASSWORD_RESET, CustomerEventTypePASSWORD_CHANGED, CustomerEventTypeEMAIL_CHANGED_REQUEST, CustomerEventTypeEMAIL_CHANGED, CustomerEventTypePLACED_ORDER, CustomerEventTypeNOTE_ADDED_TO_ORDER, CustomerEventTypeDIGITAL_LINK_DOWNLOADED, CustomerEventTypeCUSTOMER_DELETED, CustomerEventTypeEMAIL_ASSIGNED, CustomerEventTypeNAME_ASSIGNED, CustomerEventTypeNOTE_ADDED, CustomerEventTypeACCOUNT_ACTIVATED, CustomerEventTypeACCOUNT_DEACTIVATED:
+	case CustomerEventTypeACCOUNT_CREATED, CustomerEventTypePASSWORD_RESET_LINK_SENT, CustomerEventTypePASSWORD_RESET, CustomerEventTypePASSWORD_CHANGED, CustomerEventTypeEMAIL_CHANGED_REQUEST, CustomerEventTypeEMAIL_CHANGED, CustomerEventTypePLACED_ORDER, CustomerEventTypeNOTE_ADDED_TO_ORDER, CustomerEventTypeDIGITAL_LINK_DOWNLOADED, CustomerEventTypeCUSTOMER_DELETED, CustomerEventTypeEMAIL_ASSIGNED, CustomerEventTypeNAME_ASSIGNED, CustomerEventTypeNOTE_ADDED, CustomerEventTypeACCOUNT_ACTIVATED, CustomerEventTypeACCOUNT_DEACTIVATED, CustomerEventTypeDATA_EXPORT_REQUESTED, CustomerEventTypeDATA_EXPORTED, CustomerEventTypeDATA_ERASURE_REQUESTED, CustomerEventTypeDATA_ERASED:
 		return nil
 	default:
 		return errors.New("enum is not valid")
@@ -5818,6 +5826,14 @@ func (e CustomerEventType) Ordinal() int {
 		return 13
 	case CustomerEventTypeACCOUNT_DEACTIVATED:
 		return 14
+	case CustomerEventTypeDATA_EXPORT_REQUESTED:
+		return 15
+	case CustomerEventTypeDATA_EXPORTED:
+		return 16
+	case CustomerEventTypeDATA_ERASURE_REQUESTED:
+		return 17
+	case CustomerEventTypeDATA_ERASED:
+		return 18
 
 	default:
 		panic(errors.New("enum is not valid"))
@@ -6129,6 +6145,9 @@ const (
 	JobTypeExportDelete                 JobType = "export_delete"
 	JobTypeCloud                        JobType = "cloud"
 	JobTypeResendInvitationEmail        JobType = "resend_invitation_email"
+	JobTypeAbandonedCheckouts           JobType = "abandoned_checkouts"
+	JobTypeExpireOrders                 JobType = "expire_orders"
+	JobTypeProductRecommendations       JobType = "product_recommendations"
 )
 
 func AllJobType() []JobType {
@@ -6150,12 +6169,15 @@ func AllJobType() []JobType {
 		JobTypeExportDelete,
 		JobTypeCloud,
 		JobTypeResendInvitationEmail,
+		JobTypeAbandonedCheckouts,
+		JobTypeExpireOrders,
+		JobTypeProductRecommendations,
 	}
 }
 
 func (e JobType) IsValid() error {
 	switch e {
-	case JobTypeDataRetention, JobTypeMessageExport, JobTypeElasticsearchPostIndexing, JobTypeElasticsearchPostAggregation, JobTypeBlevePostIndexing, JobTypeLdapSync, JobTypeMigrations, JobTypePlugins, JobTypeExpiryNotify, JobTypeProductNotices, JobTypeActiveUsers, JobTypeImportProcess, JobTypeImportDelete, JobTypeExportProcess, JobTypeExportDelete, JobTypeCloud, JobTypeResendInvitationEmail:
+	case JobTypeDataRetention, JobTypeMessageExport, JobTypeElasticsearchPostIndexing, JobTypeElasticsearchPostAggregation, JobTypeBlevePostIndexing, JobTypeLdapSync, JobTypeMigrations, JobTypePlugins, JobTypeExpiryNotify, JobTypeProductNotices, JobTypeActiveUsers, JobTypeImportProcess, JobTypeImportDelete, JobTypeExportProcess, JobTypeExportDelete, JobTypeCloud, JobTypeResendInvitationEmail, JobTypeAbandonedCheckouts, JobTypeExpireOrders, JobTypeProductRecommendations:
 		return nil
 	default:
 		return errors.New("enum is not valid")
@@ -6202,6 +6224,12 @@ func (e JobType) Ordinal() int {
 		return 15
 	case JobTypeResendInvitationEmail:
 		return 16
+	case JobTypeAbandonedCheckouts:
+		return 17
+	case JobTypeExpireOrders:
+		return 18
+	case JobTypeProductRecommendations:
+		return 19
 
 	default:
 		panic(errors.New("enum is not valid"))
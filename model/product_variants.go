@@ -39,6 +39,7 @@ type ProductVariant struct {
 	QuantityLimitPerCustomer model_types.NullInt     `boil:"quantity_limit_per_customer" json:"quantity_limit_per_customer,omitempty" toml:"quantity_limit_per_customer" yaml:"quantity_limit_per_customer,omitempty"`
 	CreatedAt                int64                   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt                model_types.NullInt64   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	LowStockThreshold        model_types.NullInt     `boil:"low_stock_threshold" json:"low_stock_threshold,omitempty" toml:"low_stock_threshold" yaml:"low_stock_threshold,omitempty"`
 
 	R *productVariantR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L productVariantL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -61,6 +62,7 @@ var ProductVariantColumns = struct {
 	QuantityLimitPerCustomer string
 	CreatedAt                string
 	UpdatedAt                string
+	LowStockThreshold        string
 }{
 	ID:                       "id",
 	Name:                     "name",
@@ -78,6 +80,7 @@ var ProductVariantColumns = struct {
 	QuantityLimitPerCustomer: "quantity_limit_per_customer",
 	CreatedAt:                "created_at",
 	UpdatedAt:                "updated_at",
+	LowStockThreshold:        "low_stock_threshold",
 }
 
 var ProductVariantTableColumns = struct {
@@ -97,6 +100,7 @@ var ProductVariantTableColumns = struct {
 	QuantityLimitPerCustomer string
 	CreatedAt                string
 	UpdatedAt                string
+	LowStockThreshold        string
 }{
 	ID:                       "product_variants.id",
 	Name:                     "product_variants.name",
@@ -114,6 +118,7 @@ var ProductVariantTableColumns = struct {
 	QuantityLimitPerCustomer: "product_variants.quantity_limit_per_customer",
 	CreatedAt:                "product_variants.created_at",
 	UpdatedAt:                "product_variants.updated_at",
+	LowStockThreshold:        "product_variants.low_stock_threshold",
 }
 
 // Generated where
@@ -163,6 +168,7 @@ var ProductVariantWhere = struct {
 	QuantityLimitPerCustomer whereHelpermodel_types_NullInt
 	CreatedAt                whereHelperint64
 	UpdatedAt                whereHelpermodel_types_NullInt64
+	LowStockThreshold        whereHelpermodel_types_NullInt
 }{
 	ID:                       whereHelperstring{field: "\"product_variants\".\"id\""},
 	Name:                     whereHelperstring{field: "\"product_variants\".\"name\""},
@@ -180,6 +186,7 @@ var ProductVariantWhere = struct {
 	QuantityLimitPerCustomer: whereHelpermodel_types_NullInt{field: "\"product_variants\".\"quantity_limit_per_customer\""},
 	CreatedAt:                whereHelperint64{field: "\"product_variants\".\"created_at\""},
 	UpdatedAt:                whereHelpermodel_types_NullInt64{field: "\"product_variants\".\"updated_at\""},
+	LowStockThreshold:        whereHelpermodel_types_NullInt{field: "\"product_variants\".\"low_stock_threshold\""},
 }
 
 // ProductVariantRels is where relationship names are stored.
@@ -330,9 +337,9 @@ func (r *productVariantR) GetProductWishlistItems() WishlistItemSlice {
 type productVariantL struct{}
 
 var (
-	productVariantAllColumns            = []string{"id", "name", "product_id", "sku", "weight", "weight_unit", "track_inventory", "is_preorder", "preorder_end_date", "preorder_global_threshold", "sort_order", "metadata", "private_metadata", "quantity_limit_per_customer", "created_at", "updated_at"}
+	productVariantAllColumns            = []string{"id", "name", "product_id", "sku", "weight", "weight_unit", "track_inventory", "is_preorder", "preorder_end_date", "preorder_global_threshold", "sort_order", "metadata", "private_metadata", "quantity_limit_per_customer", "created_at", "updated_at", "low_stock_threshold"}
 	productVariantColumnsWithoutDefault = []string{"id", "name", "product_id", "sku", "weight_unit", "is_preorder", "created_at"}
-	productVariantColumnsWithDefault    = []string{"weight", "track_inventory", "preorder_end_date", "preorder_global_threshold", "sort_order", "metadata", "private_metadata", "quantity_limit_per_customer", "updated_at"}
+	productVariantColumnsWithDefault    = []string{"weight", "track_inventory", "preorder_end_date", "preorder_global_threshold", "sort_order", "metadata", "private_metadata", "quantity_limit_per_customer", "updated_at", "low_stock_threshold"}
 	productVariantPrimaryKeyColumns     = []string{"id"}
 	productVariantGeneratedColumns      = []string{}
 )
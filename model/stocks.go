@@ -30,6 +30,7 @@ type Stock struct {
 	Quantity          int                    `boil:"quantity" json:"quantity" toml:"quantity" yaml:"quantity"`
 	QuantityAllocated int                    `boil:"quantity_allocated" json:"quantity_allocated" toml:"quantity_allocated" yaml:"quantity_allocated"`
 	Annotations       model_types.JSONString `boil:"annotations" json:"-" toml:"-" yaml:"-"`
+	LowStockThreshold model_types.NullInt    `boil:"low_stock_threshold" json:"low_stock_threshold,omitempty" toml:"low_stock_threshold" yaml:"low_stock_threshold,omitempty"`
 
 	R *stockR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L stockL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -43,6 +44,7 @@ var StockColumns = struct {
 	Quantity          string
 	QuantityAllocated string
 	Annotations       string
+	LowStockThreshold string
 }{
 	ID:                "id",
 	CreatedAt:         "created_at",
@@ -51,6 +53,7 @@ var StockColumns = struct {
 	Quantity:          "quantity",
 	QuantityAllocated: "quantity_allocated",
 	Annotations:       "annotations",
+	LowStockThreshold: "low_stock_threshold",
 }
 
 var StockTableColumns = struct {
@@ -61,6 +64,7 @@ var StockTableColumns = struct {
 	Quantity          string
 	QuantityAllocated string
 	Annotations       string
+	LowStockThreshold string
 }{
 	ID:                "stocks.id",
 	CreatedAt:         "stocks.created_at",
@@ -69,6 +73,7 @@ var StockTableColumns = struct {
 	Quantity:          "stocks.quantity",
 	QuantityAllocated: "stocks.quantity_allocated",
 	Annotations:       "stocks.annotations",
+	LowStockThreshold: "stocks.low_stock_threshold",
 }
 
 // Generated where
@@ -81,6 +86,7 @@ var StockWhere = struct {
 	Quantity          whereHelperint
 	QuantityAllocated whereHelperint
 	Annotations       whereHelpermodel_types_JSONString
+	LowStockThreshold whereHelpermodel_types_NullInt
 }{
 	ID:                whereHelperstring{field: "\"stocks\".\"id\""},
 	CreatedAt:         whereHelperint64{field: "\"stocks\".\"created_at\""},
@@ -89,6 +95,7 @@ var StockWhere = struct {
 	Quantity:          whereHelperint{field: "\"stocks\".\"quantity\""},
 	QuantityAllocated: whereHelperint{field: "\"stocks\".\"quantity_allocated\""},
 	Annotations:       whereHelpermodel_types_JSONString{field: "\"stocks\".\"annotations\""},
+	LowStockThreshold: whereHelpermodel_types_NullInt{field: "\"stocks\".\"low_stock_threshold\""},
 }
 
 // StockRels is where relationship names are stored.
@@ -149,9 +156,9 @@ func (r *stockR) GetFulfillmentLines() FulfillmentLineSlice {
 type stockL struct{}
 
 var (
-	stockAllColumns            = []string{"id", "created_at", "warehouse_id", "product_variant_id", "quantity", "quantity_allocated", "annotations"}
+	stockAllColumns            = []string{"id", "created_at", "warehouse_id", "product_variant_id", "quantity", "quantity_allocated", "annotations", "low_stock_threshold"}
 	stockColumnsWithoutDefault = []string{"id", "created_at", "warehouse_id", "product_variant_id", "quantity", "quantity_allocated"}
-	stockColumnsWithDefault    = []string{"annotations"}
+	stockColumnsWithDefault    = []string{"annotations", "low_stock_threshold"}
 	stockPrimaryKeyColumns     = []string{"id"}
 	stockGeneratedColumns      = []string{}
 )
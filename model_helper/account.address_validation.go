@@ -0,0 +1,151 @@
+package model_helper
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/site-name/i18naddress"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/modules/util"
+)
+
+type AddressErrorCode string
+
+const (
+	ADDRESS_ERROR_CODE_INVALID  AddressErrorCode = "invalid"
+	ADDRESS_ERROR_CODE_REQUIRED AddressErrorCode = "required"
+)
+
+// AddressFieldErrors maps address columns to the reason they failed validation.
+//
+// E.g
+//
+//	{"postal_code": "invalid", "country_area": "required"}
+type AddressFieldErrors map[string]AddressErrorCode
+
+func (e AddressFieldErrors) Error() string {
+	fields := lo.Keys(e)
+	sort.Strings(fields)
+
+	res := make([]string, len(fields))
+	for idx, field := range fields {
+		res[idx] = field + ": " + string(e[field])
+	}
+	return strings.Join(res, ", ")
+}
+
+// Fields returns sorted names of invalid fields
+func (e AddressFieldErrors) Fields() []string {
+	fields := lo.Keys(e)
+	sort.Strings(fields)
+	return fields
+}
+
+// NormalizeAddress checks given address against the address rules of its country
+// (required fields, postal code patterns, country area and city choices) and returns
+// a normalized copy of it. Country areas and cities are replaced with their canonical
+// codes/names, fields not used by the country are cleared and the phone number is
+// converted to international format.
+//
+// Addresses with ValidationSkipped set are returned as is.
+func NormalizeAddress(address model.Address) (*model.Address, AddressFieldErrors) {
+	if address.ValidationSkipped {
+		return &address, nil
+	}
+
+	var (
+		res    = address
+		errors = AddressFieldErrors{}
+	)
+	res.Country = model.CountryCode(strings.ToUpper(strings.TrimSpace(res.Country.String())))
+	if res.Country.IsValid() != nil {
+		errors[model.AddressColumns.Country] = ADDRESS_ERROR_CODE_INVALID
+		return nil, errors
+	}
+
+	params := &i18naddress.Params{CountryCode: res.Country.String()}
+	rules, err := i18naddress.GetValidationRules(params)
+	if err != nil {
+		errors[model.AddressColumns.Country] = ADDRESS_ERROR_CODE_INVALID
+		return nil, errors
+	}
+
+	// city choices depend on the country area, city area choices depend on the city
+	res.CountryArea = normalizeAddressField(model.AddressColumns.CountryArea, "country_area", res.CountryArea, rules, rules.CountryAreaChoices, errors)
+	if res.CountryArea != "" && len(rules.CountryAreaChoices) > 0 && errors[model.AddressColumns.CountryArea] == "" {
+		params.CountryArea = res.CountryArea
+		if areaRules, err := i18naddress.GetValidationRules(params); err == nil {
+			rules = areaRules
+		}
+	}
+	res.City = normalizeAddressField(model.AddressColumns.City, "city", res.City, rules, rules.CityChoices, errors)
+	if res.City != "" && len(rules.CityChoices) > 0 && errors[model.AddressColumns.City] == "" {
+		params.City = res.City
+		if cityRules, err := i18naddress.GetValidationRules(params); err == nil {
+			rules = cityRules
+		}
+	}
+	res.CityArea = normalizeAddressField(model.AddressColumns.CityArea, "city_area", res.CityArea, rules, rules.CityAreaChoices, errors)
+
+	res.PostalCode = normalizeAddressField(model.AddressColumns.PostalCode, "postal_code", res.PostalCode, rules, nil, errors)
+	if res.PostalCode != "" {
+		for _, matcher := range rules.PostalCodeMatchers {
+			if !matcher.MatchString(res.PostalCode) {
+				errors[model.AddressColumns.PostalCode] = ADDRESS_ERROR_CODE_INVALID
+				break
+			}
+		}
+	}
+
+	res.StreetAddress1 = strings.TrimSpace(res.StreetAddress1)
+	res.StreetAddress2 = strings.TrimSpace(res.StreetAddress2)
+	if res.StreetAddress1 == "" && res.StreetAddress2 == "" && lo.Contains(rules.RequiredFields, "street_address") {
+		errors[model.AddressColumns.StreetAddress1] = ADDRESS_ERROR_CODE_REQUIRED
+	}
+
+	if res.Phone = strings.TrimSpace(res.Phone); res.Phone != "" {
+		phone, ok := util.ValidatePhoneNumber(res.Phone, res.Country.String())
+		if !ok {
+			errors[model.AddressColumns.Phone] = ADDRESS_ERROR_CODE_INVALID
+		} else {
+			res.Phone = phone
+		}
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+	return &res, nil
+}
+
+// normalizeAddressField validates and normalizes value of given field.
+// column is the address column the value belongs to, name is the field name used by i18naddress.
+func normalizeAddressField(column, name, value string, rules *i18naddress.ValidationRules, choices [][2]string, errors AddressFieldErrors) string {
+	value = strings.TrimSpace(value)
+
+	if !lo.Contains(rules.AllowedFields, name) {
+		return ""
+	}
+	if value == "" {
+		if lo.Contains(rules.RequiredFields, name) {
+			errors[column] = ADDRESS_ERROR_CODE_REQUIRED
+		}
+		return ""
+	}
+
+	if len(choices) > 0 {
+		for _, choice := range choices {
+			if strings.EqualFold(choice[0], value) || strings.EqualFold(choice[1], value) {
+				return choice[0]
+			}
+		}
+		errors[column] = ADDRESS_ERROR_CODE_INVALID
+		return value
+	}
+
+	if lo.Contains(rules.UpperFields, name) {
+		value = strings.ToUpper(value)
+	}
+	return value
+}
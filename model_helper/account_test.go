@@ -16,3 +16,30 @@ func TestAddressIsValid(t *testing.T) {
 	err := AddressIsValid(addr)
 	require.NotNil(t, err, "error should be non-nil")
 }
+
+func TestNormalizeAddress(t *testing.T) {
+	addr, errs := NormalizeAddress(model.Address{
+		StreetAddress1: "1600 Amphitheatre Pkwy",
+		City:           "mountain view",
+		PostalCode:     "94043",
+		Country:        model.CountryCodeUS,
+		CountryArea:    "california",
+		Phone:          "6502530000",
+	})
+	require.Empty(t, errs)
+	require.Equal(t, "MOUNTAIN VIEW", addr.City)
+	require.Equal(t, "CA", addr.CountryArea)
+	require.Equal(t, "+16502530000", addr.Phone)
+
+	_, errs = NormalizeAddress(model.Address{
+		StreetAddress1: "1600 Amphitheatre Pkwy",
+		PostalCode:     "abc",
+		Country:        model.CountryCodeUS,
+		CountryArea:    "XX",
+	})
+	require.Equal(t, AddressFieldErrors{
+		model.AddressColumns.City:        ADDRESS_ERROR_CODE_REQUIRED,
+		model.AddressColumns.CountryArea: ADDRESS_ERROR_CODE_INVALID,
+		model.AddressColumns.PostalCode:  ADDRESS_ERROR_CODE_INVALID,
+	}, errs)
+}
@@ -2,6 +2,7 @@ package model_helper
 
 import (
 	"net/http"
+	"unicode/utf8"
 
 	"github.com/gosimple/slug"
 	"github.com/sitename/sitename/model"
@@ -107,6 +108,12 @@ func AssignedProductAttributeValueIsValid(a model.AssignedProductAttributeValue)
 	return nil
 }
 
+func AssignedProductAttributePreSave(a *model.AssignedProductAttribute) {
+	if a.ID == "" {
+		a.ID = NewId()
+	}
+}
+
 func AssignedProductAttributeValuePreSave(a *model.AssignedProductAttributeValue) {
 	if a.ID == "" {
 		a.ID = NewId()
@@ -250,6 +257,36 @@ func CustomProductAttributeIsValid(a model.CustomProductAttribute) *AppError {
 	return nil
 }
 
+func CustomProductAttributeValuePreSave(v *model.CustomProductAttributeValue) {
+	if v.ID == "" {
+		v.ID = NewId()
+	}
+	v.Value = SanitizeUnicode(v.Value)
+}
+
+func CustomProductAttributeValueIsValid(v model.CustomProductAttributeValue) *AppError {
+	if !IsValidId(v.ID) {
+		return NewAppError("CustomProductAttributeValueIsValid", "model.custom_product_attribute_value.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+	if !IsValidId(v.AttributeID) {
+		return NewAppError("CustomProductAttributeValueIsValid", "model.custom_product_attribute_value.is_valid.attribute_id.app_error", nil, "", http.StatusBadRequest)
+	}
+	if v.Value == "" || utf8.RuneCountInString(v.Value) > CustomProductAttributeValueMaxLength {
+		return NewAppError("CustomProductAttributeValueIsValid", "model.custom_product_attribute_value.is_valid.value.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+const CustomProductAttributeValueMaxLength = 250
+
+// CustomProductAttributeValuesInput holds values of a custom attribute of a product, assigned to one of its variants.
+// The attribute is found by ID when given. Otherwise it is found by slug of its name and created if the product does not have it yet
+type CustomProductAttributeValuesInput struct {
+	ID     string
+	Name   string
+	Values []string
+}
+
 type AssignedProductAttributeValueFilterOptions struct {
 	CommonQueryOptions
 }
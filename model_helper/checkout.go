@@ -132,3 +132,39 @@ func CheckoutLineIsValid(cl model.CheckoutLine) *AppError {
 
 	return nil
 }
+
+type CheckoutRecoveryFilterOptions struct {
+	CommonQueryOptions
+}
+
+func CheckoutRecoveryPreSave(r *model.CheckoutRecovery) {
+	if r.ID == "" {
+		r.ID = NewId()
+	}
+	if r.CreatedAt == 0 {
+		r.CreatedAt = GetMillis()
+	}
+	r.Email = NormalizeEmail(r.Email)
+}
+
+func CheckoutRecoveryIsValid(r model.CheckoutRecovery) *AppError {
+	if !IsValidId(r.ID) {
+		return NewAppError("CheckoutRecoveryIsValid", "model.checkout_recovery.is_valid.id.app_error", nil, "please provide valid id", http.StatusBadRequest)
+	}
+	if !IsValidId(r.CheckoutToken) {
+		return NewAppError("CheckoutRecoveryIsValid", "model.checkout_recovery.is_valid.checkout_token.app_error", nil, "please provide valid checkout token", http.StatusBadRequest)
+	}
+	if !IsValidEmail(r.Email) {
+		return NewAppError("CheckoutRecoveryIsValid", "model.checkout_recovery.is_valid.email.app_error", nil, "please provide valid email", http.StatusBadRequest)
+	}
+	if r.RemindersSent < 0 {
+		return NewAppError("CheckoutRecoveryIsValid", "model.checkout_recovery.is_valid.reminders_sent.app_error", nil, "please provide valid reminders sent", http.StatusBadRequest)
+	}
+	if !r.OrderID.IsNil() && !IsValidId(*r.OrderID.String) {
+		return NewAppError("CheckoutRecoveryIsValid", "model.checkout_recovery.is_valid.order_id.app_error", nil, "please provide valid order id", http.StatusBadRequest)
+	}
+	if r.CreatedAt <= 0 {
+		return NewAppError("CheckoutRecoveryIsValid", "model.checkout_recovery.is_valid.created_at.app_error", nil, "please provide valid created at", http.StatusBadRequest)
+	}
+	return nil
+}
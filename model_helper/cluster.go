@@ -47,6 +47,12 @@ const (
 	ClusterEventInvalidateCacheForTermsOfService            ClusterEvent = "inv_terms_of_service"
 	ClusterEventBusyStateChanged                            ClusterEvent = "busy_state_change"
 	ClusterEventInvalidateCacheForCategoryByIds             ClusterEvent = "inv_category_ids"
+	ClusterEventInvalidateCacheForShippingZones             ClusterEvent = "inv_shipping_zones"
+	ClusterEventInvalidateCacheForPageTypeAttributes        ClusterEvent = "inv_page_type_attributes"
+	ClusterEventInvalidateCacheForChannelFilters            ClusterEvent = "inv_channel_filters"
+	ClusterEventInvalidateCacheForCategoryFilters           ClusterEvent = "inv_category_filters"
+	ClusterEventInvalidateCacheForShippingZoneFilters       ClusterEvent = "inv_shipping_zone_filters"
+	ClusterEventInvalidateCacheForAttributeFilters          ClusterEvent = "inv_attribute_filters"
 
 	// Gossip communication
 	ClusterGossipEventRequestGetLogs            = "gossip_request_get_logs"
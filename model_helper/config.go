@@ -33,6 +33,8 @@ const (
 
 	IMAGE_DRIVER_LOCAL = "local"
 	IMAGE_DRIVER_S3    = "amazons3"
+	IMAGE_DRIVER_GCS   = "googlecloudstorage"
+	IMAGE_DRIVER_AZURE = "azureblob"
 
 	DATABASE_DRIVER_MYSQL    = "mysql"
 	DATABASE_DRIVER_POSTGRES = "postgres"
@@ -138,6 +140,7 @@ const (
 	LDAP_SETTINGS_DEFAULT_LOGIN_FIELD_NAME             = ""
 	LDAP_SETTINGS_DEFAULT_GROUP_DISPLAY_NAME_ATTRIBUTE = ""
 	LDAP_SETTINGS_DEFAULT_GROUP_ID_ATTRIBUTE           = ""
+	LDAP_SETTINGS_DEFAULT_GROUP_MEMBER_ATTRIBUTE       = "member"
 	LDAP_SETTINGS_DEFAULT_PICTURE_ATTRIBUTE            = ""
 
 	SAML_SETTINGS_DEFAULT_ID_ATTRIBUTE         = ""
@@ -150,6 +153,7 @@ const (
 	SAML_SETTINGS_DEFAULT_NICKNAME_ATTRIBUTE   = ""
 	SAML_SETTINGS_DEFAULT_LOCALE_ATTRIBUTE     = ""
 	SAML_SETTINGS_DEFAULT_POSITION_ATTRIBUTE   = ""
+	SAML_SETTINGS_DEFAULT_GROUP_ATTRIBUTE      = ""
 
 	SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA1    = "RSAwithSHA1"
 	SAML_SETTINGS_SIGNATURE_ALGORITHM_SHA256  = "RSAwithSHA256"
@@ -194,9 +198,13 @@ const (
 	BLEVE_SETTINGS_DEFAULT_INDEX_DIR                         = ""
 	BLEVE_SETTINGS_DEFAULT_BULK_INDEXING_TIME_WINDOW_SECONDS = 3600
 
-	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS  = 365
-	DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS     = 365
-	DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME = "02:00"
+	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS         = 365
+	DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS            = 365
+	DATA_RETENTION_SETTINGS_DEFAULT_CUSTOMER_EVENT_RETENTION_DAYS  = 730
+	DATA_RETENTION_SETTINGS_DEFAULT_ORDER_EVENT_RETENTION_DAYS     = 1825
+	DATA_RETENTION_SETTINGS_DEFAULT_AUDIT_RETENTION_DAYS           = 365
+	DATA_RETENTION_SETTINGS_DEFAULT_EXPIRED_SESSION_RETENTION_DAYS = 30
+	DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME        = "02:00"
 
 	PLUGIN_SETTINGS_DEFAULT_DIRECTORY          = "./plugins"
 	PLUGIN_SETTINGS_DEFAULT_CLIENT_DIRECTORY   = "./client/plugins"
@@ -217,6 +225,9 @@ const (
 	IMAGE_PROXY_TYPE_LOCAL      = "local"
 	IMAGE_PROXY_TYPE_ATMOS_CAMO = "atmos/camo"
 
+	CACHE_TYPE_LRU   = "lru"
+	CACHE_TYPE_REDIS = "redis"
+
 	GOOGLE_SETTINGS_DEFAULT_SCOPE             = "profile email"
 	GOOGLE_SETTINGS_DEFAULT_AUTH_ENDPOINT     = "https://accounts.google.com/o/oauth2/v2/auth"
 	GOOGLE_SETTINGS_DEFAULT_TOKEN_ENDPOINT    = "https://www.googleapis.com/oauth2/v4/token"
@@ -889,6 +900,16 @@ type ShopSettings struct {
 	GiftcardExpiryPeriod                     *int                        // default 10
 	AutomaticallyFulfillNonShippableGiftcard *bool                       // default true
 	MaxCheckoutLineQuantity                  *int                        // default to 50
+	DefaultLowStockThreshold                 *int                        // default 0 (disabled)
+	EnableAbandonedCheckoutEmails            *bool                       // default false
+	AbandonedCheckoutInactivityHours         *int                        // default 24
+	AbandonedCheckoutMaxReminders            *int                        // default 3
+	AbandonedCheckoutRecoveryURL             *string                     // default to "<SiteURL>/checkout/recover"
+	AnonymousCheckoutRetentionDays           *int                        // default 30, 0 disables purging by the abandoned checkouts and data retention jobs
+	EnableProductRecommendations             *bool                       // default false
+	ProductRecommendationsPerProduct         *int                        // default 10, per kind of recommendations
+	ProductRecommendationsOrderWindowDays    *int                        // default 180, only orders placed in this window count for co-purchases
+	ProductRecommendationsMinCoPurchases     *int                        // default 2
 }
 
 func (s *ShopSettings) SetDefaults() {
@@ -952,6 +973,36 @@ func (s *ShopSettings) SetDefaults() {
 	if s.MaxCheckoutLineQuantity == nil {
 		s.MaxCheckoutLineQuantity = GetPointerOfValue(MAX_CHECKOUT_LINE_QUANTITY)
 	}
+	if s.DefaultLowStockThreshold == nil {
+		s.DefaultLowStockThreshold = GetPointerOfValue(0)
+	}
+	if s.EnableAbandonedCheckoutEmails == nil {
+		s.EnableAbandonedCheckoutEmails = GetPointerOfValue(false)
+	}
+	if s.AbandonedCheckoutInactivityHours == nil {
+		s.AbandonedCheckoutInactivityHours = GetPointerOfValue(24)
+	}
+	if s.AbandonedCheckoutMaxReminders == nil {
+		s.AbandonedCheckoutMaxReminders = GetPointerOfValue(3)
+	}
+	if s.AbandonedCheckoutRecoveryURL == nil {
+		s.AbandonedCheckoutRecoveryURL = GetPointerOfValue("")
+	}
+	if s.AnonymousCheckoutRetentionDays == nil {
+		s.AnonymousCheckoutRetentionDays = GetPointerOfValue(30)
+	}
+	if s.EnableProductRecommendations == nil {
+		s.EnableProductRecommendations = GetPointerOfValue(false)
+	}
+	if s.ProductRecommendationsPerProduct == nil {
+		s.ProductRecommendationsPerProduct = GetPointerOfValue(10)
+	}
+	if s.ProductRecommendationsOrderWindowDays == nil {
+		s.ProductRecommendationsOrderWindowDays = GetPointerOfValue(180)
+	}
+	if s.ProductRecommendationsMinCoPurchases == nil {
+		s.ProductRecommendationsMinCoPurchases = GetPointerOfValue(2)
+	}
 }
 
 type ClusterSettings struct {
@@ -1039,6 +1090,50 @@ func (s *ClusterSettings) SetDefaults() {
 	}
 }
 
+// CacheSettings selects the provider of in memory caches. With the redis provider all nodes
+// of a cluster share the same caches instead of keeping their own copies.
+type CacheSettings struct {
+	CacheType      *string `access:"environment_high_availability,write_restrictable,cloud_restrictable"` // lru or redis
+	RedisAddress   *string `access:"environment_high_availability,write_restrictable,cloud_restrictable"` // telemetry: none
+	RedisPassword  *string `access:"environment_high_availability,write_restrictable,cloud_restrictable"` // telemetry: none
+	RedisDB        *int    `access:"environment_high_availability,write_restrictable,cloud_restrictable"` // telemetry: none
+	RedisKeyPrefix *string `access:"environment_high_availability,write_restrictable,cloud_restrictable"` // telemetry: none
+}
+
+func (s *CacheSettings) SetDefaults() {
+	if s.CacheType == nil {
+		s.CacheType = GetPointerOfValue(CACHE_TYPE_LRU)
+	}
+	if s.RedisAddress == nil {
+		s.RedisAddress = GetPointerOfValue("")
+	}
+	if s.RedisPassword == nil {
+		s.RedisPassword = GetPointerOfValue("")
+	}
+	if s.RedisDB == nil {
+		s.RedisDB = GetPointerOfValue(0)
+	}
+	if s.RedisKeyPrefix == nil {
+		s.RedisKeyPrefix = GetPointerOfValue("")
+	}
+}
+
+func (s *CacheSettings) isValid() *AppError {
+	switch *s.CacheType {
+	case CACHE_TYPE_LRU:
+	case CACHE_TYPE_REDIS:
+		if *s.RedisAddress == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.cache_redis_address.app_error", nil, "", http.StatusBadRequest)
+		}
+		if *s.RedisDB < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.cache_redis_db.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.cache_type.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
 type MetricsSettings struct {
 	Enable           *bool   `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"`
 	BlockProfileRate *int    `access:"environment_performance_monitoring,write_restrictable,cloud_restrictable"`
@@ -1473,6 +1568,7 @@ type FileSettings struct {
 	ExtractContent          *bool   `access:"environment_file_storage,write_restrictable"`
 	ArchiveRecursion        *bool   `access:"environment_file_storage,write_restrictable"`
 	PublicLinkSalt          *string `access:"site_public_links,cloud_restrictable"`                           // telemetry: none
+	PresignedURLSigningKey  *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
 	InitialFont             *string `access:"environment_file_storage,cloud_restrictable"`                    // telemetry: none
 	AmazonS3AccessKeyId     *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
 	AmazonS3SecretAccessKey *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
@@ -1484,6 +1580,17 @@ type FileSettings struct {
 	AmazonS3SignV2          *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	AmazonS3SSE             *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	AmazonS3Trace           *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
+
+	GoogleCloudStorageBucket          *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GoogleCloudStoragePathPrefix      *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GoogleCloudStorageProjectID       *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GoogleCloudStorageCredentialsJSON *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+
+	AzureStorageAccountName *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureStorageAccountKey  *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureStorageContainer   *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureStoragePathPrefix  *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureStorageEndpoint    *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
 }
 
 func (s *FileSettings) SetDefaults(isUpdate bool) {
@@ -1537,6 +1644,15 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 		s.PublicLinkSalt = GetPointerOfValue("")
 	}
 
+	if isUpdate {
+		// presigned urls of the local file backend are signed with their own key, so they do not share a secret with public links.
+		if s.PresignedURLSigningKey == nil || *s.PresignedURLSigningKey == "" {
+			s.PresignedURLSigningKey = GetPointerOfValue(NewRandomString(32))
+		}
+	} else {
+		s.PresignedURLSigningKey = GetPointerOfValue("")
+	}
+
 	if s.InitialFont == nil {
 		// Defaults to "nunito-bold.ttf"
 		s.InitialFont = GetPointerOfValue("nunito-bold.ttf")
@@ -1583,13 +1699,71 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 	if s.AmazonS3Trace == nil {
 		s.AmazonS3Trace = GetPointerOfValue(false)
 	}
+
+	if s.GoogleCloudStorageBucket == nil {
+		s.GoogleCloudStorageBucket = GetPointerOfValue("")
+	}
+
+	if s.GoogleCloudStoragePathPrefix == nil {
+		s.GoogleCloudStoragePathPrefix = GetPointerOfValue("")
+	}
+
+	if s.GoogleCloudStorageProjectID == nil {
+		s.GoogleCloudStorageProjectID = GetPointerOfValue("")
+	}
+
+	if s.GoogleCloudStorageCredentialsJSON == nil {
+		// Application default credentials are used when empty
+		s.GoogleCloudStorageCredentialsJSON = GetPointerOfValue("")
+	}
+
+	if s.AzureStorageAccountName == nil {
+		s.AzureStorageAccountName = GetPointerOfValue("")
+	}
+
+	if s.AzureStorageAccountKey == nil {
+		s.AzureStorageAccountKey = GetPointerOfValue("")
+	}
+
+	if s.AzureStorageContainer == nil {
+		s.AzureStorageContainer = GetPointerOfValue("")
+	}
+
+	if s.AzureStoragePathPrefix == nil {
+		s.AzureStoragePathPrefix = GetPointerOfValue("")
+	}
+
+	if s.AzureStorageEndpoint == nil {
+		// Defaults to "https://<account name>.blob.core.windows.net/" when empty
+		s.AzureStorageEndpoint = GetPointerOfValue("")
+	}
 }
 
 func (s *FileSettings) ToFileBackendSettings(enableComplianceFeature bool) filestore.FileBackendSettings {
 	if *s.DriverName == IMAGE_DRIVER_LOCAL {
 		return filestore.FileBackendSettings{
-			DriverName: *s.DriverName,
-			Directory:  *s.Directory,
+			DriverName:      *s.DriverName,
+			Directory:       *s.Directory,
+			LocalSigningKey: GetValueOfPointerOrZero(s.PresignedURLSigningKey),
+		}
+	}
+	if *s.DriverName == IMAGE_DRIVER_GCS {
+		return filestore.FileBackendSettings{
+			DriverName:                        *s.DriverName,
+			GoogleCloudStorageBucket:          *s.GoogleCloudStorageBucket,
+			GoogleCloudStoragePathPrefix:      *s.GoogleCloudStoragePathPrefix,
+			GoogleCloudStorageProjectID:       *s.GoogleCloudStorageProjectID,
+			GoogleCloudStorageCredentialsJSON: *s.GoogleCloudStorageCredentialsJSON,
+		}
+	}
+	if *s.DriverName == IMAGE_DRIVER_AZURE {
+		return filestore.FileBackendSettings{
+			DriverName:              *s.DriverName,
+			AzureStorageAccountName: *s.AzureStorageAccountName,
+			AzureStorageAccountKey:  *s.AzureStorageAccountKey,
+			AzureStorageContainer:   *s.AzureStorageContainer,
+			AzureStoragePathPrefix:  *s.AzureStoragePathPrefix,
+			AzureStorageEndpoint:    *s.AzureStorageEndpoint,
 		}
 	}
 	return filestore.FileBackendSettings{
@@ -2011,6 +2185,9 @@ type LdapSettings struct {
 	// Group Mapping
 	GroupDisplayNameAttribute *string `access:"authentication_ldap"`
 	GroupIdAttribute          *string `access:"authentication_ldap"`
+	GroupMemberAttribute      *string `access:"authentication_ldap"` // attribute of groups holding DNs of their members
+	// GroupRoles maps ids of groups to space separated names of roles their members are granted, like shop_staff
+	GroupRoles map[string]string `access:"authentication_ldap"`
 
 	// User Mapping
 	FirstNameAttribute *string `access:"authentication_ldap"`
@@ -2025,6 +2202,9 @@ type LdapSettings struct {
 
 	// Synchronization
 	SyncIntervalMinutes *int `access:"authentication_ldap"`
+	// SyncMaxDeactivationRatio is the highest share of active directory users a single synchronization may
+	// deactivate. Above it, nobody is deactivated, since a wrong BaseDN or UserFilter looks like an emptied directory.
+	SyncMaxDeactivationRatio *float64 `access:"authentication_ldap"`
 
 	// Advanced
 	SkipCertificateVerification *bool   `access:"authentication_ldap"`
@@ -2113,6 +2293,14 @@ func (s *LdapSettings) SetDefaults() {
 		s.GroupIdAttribute = GetPointerOfValue(LDAP_SETTINGS_DEFAULT_GROUP_ID_ATTRIBUTE)
 	}
 
+	if s.GroupMemberAttribute == nil {
+		s.GroupMemberAttribute = GetPointerOfValue(LDAP_SETTINGS_DEFAULT_GROUP_MEMBER_ATTRIBUTE)
+	}
+
+	if s.GroupRoles == nil {
+		s.GroupRoles = make(map[string]string)
+	}
+
 	if s.FirstNameAttribute == nil {
 		s.FirstNameAttribute = GetPointerOfValue(LDAP_SETTINGS_DEFAULT_FIRST_NAME_ATTRIBUTE)
 	}
@@ -2155,6 +2343,10 @@ func (s *LdapSettings) SetDefaults() {
 		s.SyncIntervalMinutes = GetPointerOfValue(60)
 	}
 
+	if s.SyncMaxDeactivationRatio == nil {
+		s.SyncMaxDeactivationRatio = GetPointerOfValue(0.5)
+	}
+
 	if s.SkipCertificateVerification == nil {
 		s.SkipCertificateVerification = GetPointerOfValue(false)
 	}
@@ -2238,6 +2430,8 @@ type SamlSettings struct {
 	EnableSyncWithLdap            *bool `access:"authentication_saml"`
 	EnableSyncWithLdapIncludeAuth *bool `access:"authentication_saml"`
 	IgnoreGuestsLdapSync          *bool `access:"authentication_saml"`
+	// EnableUserCreation lets staff users asserted by the identity provider be created on their first login
+	EnableUserCreation *bool `access:"authentication_saml"`
 
 	Verify      *bool `access:"authentication_saml"`
 	Encrypt     *bool `access:"authentication_saml"`
@@ -2271,6 +2465,9 @@ type SamlSettings struct {
 	NicknameAttribute    *string `access:"authentication_saml"`
 	LocaleAttribute      *string `access:"authentication_saml"`
 	PositionAttribute    *string `access:"authentication_saml"`
+	GroupAttribute       *string `access:"authentication_saml"` // attribute of assertions listing groups of users
+	// GroupRoles maps groups listed in GroupAttribute to space separated names of roles their members are granted, like shop_staff
+	GroupRoles map[string]string `access:"authentication_saml"`
 
 	LoginButtonText *string `access:"authentication_saml"`
 
@@ -2284,6 +2481,10 @@ func (s *SamlSettings) SetDefaults() {
 		s.Enable = GetPointerOfValue(false)
 	}
 
+	if s.EnableUserCreation == nil {
+		s.EnableUserCreation = GetPointerOfValue(false)
+	}
+
 	if s.EnableSyncWithLdap == nil {
 		s.EnableSyncWithLdap = GetPointerOfValue(false)
 	}
@@ -2406,6 +2607,14 @@ func (s *SamlSettings) SetDefaults() {
 		s.LocaleAttribute = GetPointerOfValue(SAML_SETTINGS_DEFAULT_LOCALE_ATTRIBUTE)
 	}
 
+	if s.GroupAttribute == nil {
+		s.GroupAttribute = GetPointerOfValue(SAML_SETTINGS_DEFAULT_GROUP_ATTRIBUTE)
+	}
+
+	if s.GroupRoles == nil {
+		s.GroupRoles = make(map[string]string)
+	}
+
 	if s.LoginButtonColor == nil {
 		s.LoginButtonColor = GetPointerOfValue("#34a28b")
 	}
@@ -2584,12 +2793,23 @@ func (bs *BleveSettings) SetDefaults() {
 	}
 }
 
+// DataRetentionSettings configures the data retention job. File deletion applies to export files, expired session
+// deletion applies to both expired sessions and tokens. Anonymous checkouts are deleted after
+// ShopSettings.AnonymousCheckoutRetentionDays.
 type DataRetentionSettings struct {
-	EnableMessageDeletion *bool   `access:"compliance_data_retention_policy"`
-	EnableFileDeletion    *bool   `access:"compliance_data_retention_policy"`
-	MessageRetentionDays  *int    `access:"compliance_data_retention_policy"`
-	FileRetentionDays     *int    `access:"compliance_data_retention_policy"`
-	DeletionJobStartTime  *string `access:"compliance_data_retention_policy"`
+	EnableMessageDeletion        *bool   `access:"compliance_data_retention_policy"`
+	EnableFileDeletion           *bool   `access:"compliance_data_retention_policy"`
+	EnableCustomerEventDeletion  *bool   `access:"compliance_data_retention_policy"`
+	EnableOrderEventDeletion     *bool   `access:"compliance_data_retention_policy"`
+	EnableAuditDeletion          *bool   `access:"compliance_data_retention_policy"`
+	EnableExpiredSessionDeletion *bool   `access:"compliance_data_retention_policy"`
+	MessageRetentionDays         *int    `access:"compliance_data_retention_policy"`
+	FileRetentionDays            *int    `access:"compliance_data_retention_policy"`
+	CustomerEventRetentionDays   *int    `access:"compliance_data_retention_policy"`
+	OrderEventRetentionDays      *int    `access:"compliance_data_retention_policy"`
+	AuditRetentionDays           *int    `access:"compliance_data_retention_policy"`
+	ExpiredSessionRetentionDays  *int    `access:"compliance_data_retention_policy"`
+	DeletionJobStartTime         *string `access:"compliance_data_retention_policy"`
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -2601,6 +2821,22 @@ func (s *DataRetentionSettings) SetDefaults() {
 		s.EnableFileDeletion = GetPointerOfValue(false)
 	}
 
+	if s.EnableCustomerEventDeletion == nil {
+		s.EnableCustomerEventDeletion = GetPointerOfValue(false)
+	}
+
+	if s.EnableOrderEventDeletion == nil {
+		s.EnableOrderEventDeletion = GetPointerOfValue(false)
+	}
+
+	if s.EnableAuditDeletion == nil {
+		s.EnableAuditDeletion = GetPointerOfValue(false)
+	}
+
+	if s.EnableExpiredSessionDeletion == nil {
+		s.EnableExpiredSessionDeletion = GetPointerOfValue(false)
+	}
+
 	if s.MessageRetentionDays == nil {
 		s.MessageRetentionDays = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS)
 	}
@@ -2609,6 +2845,22 @@ func (s *DataRetentionSettings) SetDefaults() {
 		s.FileRetentionDays = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS)
 	}
 
+	if s.CustomerEventRetentionDays == nil {
+		s.CustomerEventRetentionDays = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_CUSTOMER_EVENT_RETENTION_DAYS)
+	}
+
+	if s.OrderEventRetentionDays == nil {
+		s.OrderEventRetentionDays = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_ORDER_EVENT_RETENTION_DAYS)
+	}
+
+	if s.AuditRetentionDays == nil {
+		s.AuditRetentionDays = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_AUDIT_RETENTION_DAYS)
+	}
+
+	if s.ExpiredSessionRetentionDays == nil {
+		s.ExpiredSessionRetentionDays = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_EXPIRED_SESSION_RETENTION_DAYS)
+	}
+
 	if s.DeletionJobStartTime == nil {
 		s.DeletionJobStartTime = GetPointerOfValue(DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME)
 	}
@@ -3023,6 +3275,7 @@ type Config struct {
 	SamlSettings              SamlSettings
 	NativeAppSettings         NativeAppSettings
 	ClusterSettings           ClusterSettings
+	CacheSettings             CacheSettings
 	MetricsSettings           MetricsSettings
 	ExperimentalSettings      ExperimentalSettings
 	AnalyticsSettings         AnalyticsSettings
@@ -3111,6 +3364,7 @@ func (o *Config) SetDefaults() {
 	o.AnnouncementSettings.SetDefaults()
 	o.ThemeSettings.SetDefaults()
 	o.ClusterSettings.SetDefaults()
+	o.CacheSettings.SetDefaults()
 	o.PluginSettings.SetDefaults(o.LogSettings)
 	o.AnalyticsSettings.SetDefaults()
 	o.ComplianceSettings.SetDefaults()
@@ -3174,6 +3428,9 @@ func (o *Config) IsValid() *AppError {
 	if err := o.ServiceSettings.isValid(); err != nil {
 		return err
 	}
+	if err := o.CacheSettings.isValid(); err != nil {
+		return err
+	}
 	if err := o.ElasticsearchSettings.isValid(); err != nil {
 		return err
 	}
@@ -3243,14 +3500,26 @@ func (s *FileSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_file_size.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if !(*s.DriverName == IMAGE_DRIVER_LOCAL || *s.DriverName == IMAGE_DRIVER_S3) {
+	if !(*s.DriverName == IMAGE_DRIVER_LOCAL || *s.DriverName == IMAGE_DRIVER_S3 || *s.DriverName == IMAGE_DRIVER_GCS || *s.DriverName == IMAGE_DRIVER_AZURE) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.file_driver.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.DriverName == IMAGE_DRIVER_GCS && *s.GoogleCloudStorageBucket == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.gcs_bucket.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.DriverName == IMAGE_DRIVER_AZURE && (*s.AzureStorageAccountName == "" || *s.AzureStorageAccountKey == "" || *s.AzureStorageContainer == "") {
+		return NewAppError("Config.IsValid", "model.config.is_valid.azure_storage.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.PublicLinkSalt != "" && len(*s.PublicLinkSalt) < 32 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.file_salt.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.PresignedURLSigningKey != "" && len(*s.PresignedURLSigningKey) < 32 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.presigned_url_signing_key.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.Directory == "" {
 		return NewAppError("Config.IsValid", "model.config.is_valid.directory.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3303,6 +3572,10 @@ func (s *LdapSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.ldap_sync_interval.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.SyncMaxDeactivationRatio < 0 || *s.SyncMaxDeactivationRatio > 1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.ldap_sync_max_deactivation_ratio.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.MaxPageSize < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.ldap_max_page_size.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3349,6 +3622,16 @@ func (s *LdapSettings) isValid() *AppError {
 				return NewAppError("LdapSettings.isValid", "ent.ldap.validate_admin_filter.app_error", nil, err.Error(), http.StatusBadRequest)
 			}
 		}
+
+		if *s.GroupFilter != "" {
+			if _, err := ldap.CompileFilter(*s.GroupFilter); err != nil {
+				return NewAppError("LdapSettings.isValid", "ent.ldap.validate_group_filter.app_error", nil, err.Error(), http.StatusBadRequest)
+			}
+		}
+
+		if len(s.GroupRoles) > 0 && (*s.GroupIdAttribute == "" || *s.GroupMemberAttribute == "") {
+			return NewAppError("LdapSettings.isValid", "model.config.is_valid.ldap_group_roles.app_error", nil, "", http.StatusBadRequest)
+		}
 	}
 
 	return nil
@@ -3424,6 +3707,10 @@ func (s *SamlSettings) isValid() *AppError {
 				return NewAppError("Config.IsValid", "model.config.is_valid.saml_admin_attribute.app_error", nil, "", http.StatusBadRequest)
 			}
 		}
+
+		if len(s.GroupRoles) > 0 && *s.GroupAttribute == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.saml_group_roles.app_error", nil, "", http.StatusBadRequest)
+		}
 	}
 
 	return nil
@@ -3582,6 +3869,22 @@ func (s *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.file_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.CustomerEventRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.customer_event_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OrderEventRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.order_event_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.AuditRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.audit_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.ExpiredSessionRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.expired_session_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if _, err := time.Parse("15:04", *s.DeletionJobStartTime); err != nil {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
@@ -3692,10 +3995,26 @@ func (o *Config) Sanitize() {
 
 	*o.FileSettings.PublicLinkSalt = FAKE_SETTING
 
+	if *o.FileSettings.PresignedURLSigningKey != "" {
+		*o.FileSettings.PresignedURLSigningKey = FAKE_SETTING
+	}
+
 	if *o.FileSettings.AmazonS3SecretAccessKey != "" {
 		*o.FileSettings.AmazonS3SecretAccessKey = FAKE_SETTING
 	}
 
+	if *o.FileSettings.GoogleCloudStorageCredentialsJSON != "" {
+		*o.FileSettings.GoogleCloudStorageCredentialsJSON = FAKE_SETTING
+	}
+
+	if *o.FileSettings.AzureStorageAccountKey != "" {
+		*o.FileSettings.AzureStorageAccountKey = FAKE_SETTING
+	}
+
+	if o.CacheSettings.RedisPassword != nil && *o.CacheSettings.RedisPassword != "" {
+		*o.CacheSettings.RedisPassword = FAKE_SETTING
+	}
+
 	if o.EmailSettings.SMTPPassword != nil && *o.EmailSettings.SMTPPassword != "" {
 		*o.EmailSettings.SMTPPassword = FAKE_SETTING
 	}
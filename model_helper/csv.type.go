@@ -2,6 +2,8 @@ package model_helper
 
 import (
 	"time"
+
+	"github.com/sitename/sitename/model"
 )
 
 type StockAvailability string
@@ -38,8 +40,9 @@ type ProductFilterInput struct {
 	Collections       []string
 	Categories        []string
 	HasCategory       *bool
-	Attributes        []*AttributeFilter
-	StockAvailability *StockAvailability // can either be Instock or outOfStock
+	Attributes        []*AttributeFilter   // all filters must be satisfied
+	AttributesWhere   *AttributeWhereInput // attribute filters combined with AND/OR, applied along with Attributes
+	StockAvailability *StockAvailability   // can either be Instock or outOfStock
 	Stocks            *struct {
 		WarehouseIds []string
 		Quantity     *struct {
@@ -107,6 +110,12 @@ type ProductOrder struct {
 	AttributeID *string
 }
 
+// file types of exported files
+const (
+	ExportFileTypeCsv  = "csv"
+	ExportFileTypeXlsx = "xlsx"
+)
+
 type ExportProductsFilterOptions struct {
 	Scope      string // "all" or "ids" or "filter"
 	Filter     *ProductFilterInput
@@ -120,3 +129,76 @@ type ExportProductsFilterOptions struct {
 	}
 	FileType string // xlsx or csv
 }
+
+type OrderFilterInput struct {
+	PaymentStatus []model.PaymentChargeStatus
+	Status        []OrderFilterStatus
+	Customer      *string
+	Created       *struct {
+		Gte *time.Time
+		Lte *time.Time
+	}
+	Search   *string
+	Metadata []*struct {
+		Key   string
+		Value string
+	}
+	Channels []string
+}
+
+type CustomerFilterInput struct {
+	DateJoined *struct {
+		Gte *time.Time
+		Lte *time.Time
+	}
+	NumberOfOrders *struct {
+		Gte *int32
+		Lte *int32
+	}
+	PlacedOrders *struct {
+		Gte *time.Time
+		Lte *time.Time
+	}
+	Search   *string
+	Metadata []*struct {
+		Key   string
+		Value string
+	}
+}
+
+type GiftcardFilterInput struct {
+	IsActive       *bool
+	Tags           []string
+	Products       []string
+	UsedBy         []string
+	Currency       *string
+	CurrentBalance *struct {
+		Gte *float64
+		Lte *float64
+	}
+	InitialBalance *struct {
+		Gte *float64
+		Lte *float64
+	}
+}
+
+type ExportOrdersFilterOptions struct {
+	Scope    string // "all" or "ids" or "filter"
+	Filter   *OrderFilterInput
+	Ids      []string
+	FileType string // xlsx or csv
+}
+
+type ExportCustomersFilterOptions struct {
+	Scope    string // "all" or "ids" or "filter"
+	Filter   *CustomerFilterInput
+	Ids      []string
+	FileType string // xlsx or csv
+}
+
+type ExportGiftcardsFilterOptions struct {
+	Scope    string // "all" or "ids" or "filter"
+	Filter   *GiftcardFilterInput
+	Ids      []string
+	FileType string // xlsx or csv
+}
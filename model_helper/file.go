@@ -183,10 +183,13 @@ func ExportFilePreSave(f *model.ExportFile) {
 	if f.CreatedAt == 0 {
 		f.CreatedAt = GetMillis()
 	}
-	f.CreatedAt = GetMillis()
 	f.UpdatedAt = f.CreatedAt
 }
 
+func ExportFilePreUpdate(f *model.ExportFile) {
+	f.UpdatedAt = GetMillis()
+}
+
 func ExportFileIsValid(f model.ExportFile) *AppError {
 	if !IsValidId(f.ID) {
 		return NewAppError("ExportFileIsValid", "model.export_file.is_valid.id.app_error", nil, "", http.StatusBadRequest)
@@ -21,6 +21,7 @@ const (
 	TokenTypeCWSAccess          TokenType = "cws_access_token"
 	TokenTypeRequestChangeEmail TokenType = "request_change_email"
 	TokenTypeDeactivateAccount  TokenType = "deactivate_account"
+	TokenTypeDataErasure        TokenType = "data_erasure"
 )
 
 type TokenType string
@@ -33,7 +34,8 @@ func (t TokenType) IsValid() bool {
 		TokenTypeGuestInvitation,
 		TokenTypeCWSAccess,
 		TokenTypeRequestChangeEmail,
-		TokenTypeDeactivateAccount:
+		TokenTypeDeactivateAccount,
+		TokenTypeDataErasure:
 		return true
 	default:
 		return false
@@ -44,6 +46,11 @@ func (t TokenType) String() string {
 	return string(t)
 }
 
+// DataErasureTokenExtra is the extra data of data erasure tokens confirming data erasure requests
+type DataErasureTokenExtra struct {
+	UserID string `json:"user_id"`
+}
+
 func TokenPreSave(t *model.Token) {
 	if t.CreatedAt == 0 {
 		t.CreatedAt = GetMillis()
@@ -79,6 +86,10 @@ type ExportEventFilterOption struct {
 	CommonQueryOptions
 }
 
+type ExportFileFilterOption struct {
+	CommonQueryOptions
+}
+
 func OpenExchangeRateCommonPre(rate *model.OpenExchangeRate) {
 	if rate == nil {
 		return
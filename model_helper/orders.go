@@ -157,6 +157,14 @@ func OrderGetShippingPrice(order model.Order) goprices.TaxedMoney {
 	return *taxedMoney
 }
 
+func OrderSetShippingPrice(o *model.Order, price goprices.TaxedMoney) {
+	net := price.GetNet()
+	gross := price.GetGross()
+
+	o.ShippingPriceNetAmount = net.GetAmount()
+	o.ShippingPriceGrossAmount = gross.GetAmount()
+}
+
 func OrderGetTotalPrice(order model.Order) goprices.TaxedMoney {
 	totalPriceNet, _ := goprices.NewMoneyFromDecimal(order.TotalNetAmount, order.Currency.String())
 	totalPriceGross, _ := goprices.NewMoneyFromDecimal(order.TotalGrossAmount, order.Currency.String())
@@ -250,6 +258,11 @@ var CustomOrderTableColumns = struct {
 
 type CustomOrderSlice []*CustomOrder
 
+type OrderCountByUserID struct {
+	UserID     string `json:"user_id"`
+	OrderCount uint64 `json:"order_count"`
+}
+
 // NOTE: when model.Order is updated, this function should be updated too
 func OrderScanValues(o *model.Order) []any {
 	return []any{
@@ -456,6 +469,56 @@ type FulfillmentLineFilterOption struct {
 	Preload                 []string
 }
 
+func OrderEventPreSave(e *model.OrderEvent) {
+	if e.ID == "" {
+		e.ID = NewId()
+	}
+	if e.CreatedAt == 0 {
+		e.CreatedAt = GetMillis()
+	}
+}
+
+func OrderEventIsValid(e model.OrderEvent) *AppError {
+	if !IsValidId(e.ID) {
+		return NewAppError("OrderEventIsValid", "model.order_event.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+	if !IsValidId(e.OrderID) {
+		return NewAppError("OrderEventIsValid", "model.order_event.is_valid.order_id.app_error", nil, "", http.StatusBadRequest)
+	}
+	if e.CreatedAt <= 0 {
+		return NewAppError("OrderEventIsValid", "model.order_event.is_valid.created_at.app_error", nil, "", http.StatusBadRequest)
+	}
+	if e.Type.IsValid() != nil {
+		return NewAppError("OrderEventIsValid", "model.order_event.is_valid.type.app_error", nil, "", http.StatusBadRequest)
+	}
+	if !e.UserID.IsNil() && !IsValidId(*e.UserID.String) {
+		return NewAppError("OrderEventIsValid", "model.order_event.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+type OrderEventFilterOptions struct {
+	CommonQueryOptions
+}
+
+type OrderEditLineInput struct {
+	VariantID string
+	Quantity  int
+}
+
+// OrderEditInput describes changes staff make to a draft or unconfirmed order.
+type OrderEditInput struct {
+	AddLines         []OrderEditLineInput
+	UpdateLines      map[string]int // order line ids => new quantities. Zero quantity removes the line
+	RemoveLines      []string       // ids of order lines to remove
+	ShippingMethodID *string        // nil keeps current shipping method
+}
+
+func (i OrderEditInput) IsEmpty() bool {
+	return len(i.AddLines) == 0 && len(i.UpdateLines) == 0 && len(i.RemoveLines) == 0 && i.ShippingMethodID == nil
+}
+
 func OrderLineGetUnitPrice(o model.OrderLine) goprices.TaxedMoney {
 	unitPriceNet, _ := goprices.NewMoneyFromDecimal(o.UnitPriceNetAmount, o.Currency.String())
 	unitPriceGross, _ := goprices.NewMoneyFromDecimal(o.UnitPriceGrossAmount, o.Currency.String())
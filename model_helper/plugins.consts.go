@@ -8,6 +8,8 @@ const (
 	ACCOUNT_CHANGE_EMAIL_CONFIRM   = "account_change_email_confirm"
 	ACCOUNT_DELETE                 = "account_delete"
 	ACCOUNT_SET_CUSTOMER_PASSWORD  = "account_set_customer_password"
+	CSV_EXPORT_SUCCESS             = "csv_export_success"
+	CSV_EXPORT_FAILED              = "csv_export_failed"
 	INVOICE_READY                  = "invoice_ready"
 	ORDER_CONFIRMATION             = "order_confirmation"
 	ORDER_CONFIRMED                = "order_confirmed"
@@ -16,7 +18,10 @@ const (
 	ORDER_PAYMENT_CONFIRMATION     = "order_payment_confirmation"
 	ORDER_CANCELED                 = "order_canceled"
 	ORDER_REFUND_CONFIRMATION      = "order_refund_confirmation"
+	ORDER_PAYMENT_BALANCE_REQUEST  = "order_payment_balance_request"
+	ORDER_EXPIRED                  = "order_expired"
 	SEND_GIFT_CARD                 = "send_gift_card"
+	STAFF_LOW_STOCK                = "staff_low_stock"
 )
 
 // PluginEventData used to notify peers about plugin changes.
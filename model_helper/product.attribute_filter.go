@@ -0,0 +1,144 @@
+package model_helper
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sitename/sitename/modules/measurement"
+)
+
+// valid values for AttributeWhereInput.Operator
+const (
+	AttributeFilterOperatorAnd AttributeFilterOperator = "AND"
+	AttributeFilterOperatorOr  AttributeFilterOperator = "OR"
+)
+
+type AttributeFilterOperator string
+
+// AttributeWhereInput combines attribute filters and nested groups of filters with AND or OR.
+//
+// E.g (color is red OR blue) AND (width between 10 and 20 cm OR size is XL):
+//
+//	AttributeWhereInput{
+//		Filters: []*AttributeValueFilter{{Slug: "color", Values: []string{"red", "blue"}}},
+//		Groups: []*AttributeWhereInput{{
+//			Operator: AttributeFilterOperatorOr,
+//			Filters: []*AttributeValueFilter{
+//				{Slug: "width", ValuesRange: &AttributeNumericRange{Gte: &ten, Lte: &twenty, Unit: &cm}},
+//				{Slug: "size", Values: []string{"xl"}},
+//			},
+//		}},
+//	}
+type AttributeWhereInput struct {
+	Operator AttributeFilterOperator // default to AND
+	Filters  []*AttributeValueFilter
+	Groups   []*AttributeWhereInput
+}
+
+// AttributeValueFilter matches products having a value of the attribute with given slug satisfying
+// the only condition set. Values of product level attributes and of the product's variants are both checked.
+type AttributeValueFilter struct {
+	Slug         string
+	Values       []string               // slugs of values, for dropdown, multiselect, swatch, plain text, rich text and file attributes
+	ValuesRange  *AttributeNumericRange // for numeric attributes
+	Boolean      *bool                  // for boolean attributes
+	Date         *AttributeTimeRange    // for date attributes, only dates of the bounds are used
+	DateTime     *AttributeTimeRange    // for date time attributes
+	ReferenceIDs []string               // ids of referenced products, variants or pages, for reference attributes
+}
+
+type AttributeNumericRange struct {
+	Gte  *float64
+	Lte  *float64
+	Unit *string // unit of the bounds, they are converted into units of the filtered attributes. Nil means units of the attributes
+}
+
+type AttributeTimeRange struct {
+	Gte *time.Time
+	Lte *time.Time
+}
+
+// Slugs returns slugs of all attributes filtered by the input and its nested groups
+func (w *AttributeWhereInput) Slugs() []string {
+	if w == nil {
+		return nil
+	}
+
+	var res []string
+	for _, filter := range w.Filters {
+		if filter != nil {
+			res = append(res, filter.Slug)
+		}
+	}
+	for _, group := range w.Groups {
+		res = append(res, group.Slugs()...)
+	}
+	return res
+}
+
+func (w *AttributeWhereInput) Validate() error {
+	if w == nil {
+		return nil
+	}
+	if w.Operator != "" && w.Operator != AttributeFilterOperatorAnd && w.Operator != AttributeFilterOperatorOr {
+		return errors.Errorf("invalid attribute filter operator %q", w.Operator)
+	}
+
+	for _, filter := range w.Filters {
+		if filter == nil {
+			continue
+		}
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, group := range w.Groups {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *AttributeValueFilter) Validate() error {
+	if f.Slug == "" {
+		return errors.New("attribute slug is required")
+	}
+
+	conditions := 0
+	for _, set := range []bool{
+		len(f.Values) > 0,
+		f.ValuesRange != nil,
+		f.Boolean != nil,
+		f.Date != nil,
+		f.DateTime != nil,
+		len(f.ReferenceIDs) > 0,
+	} {
+		if set {
+			conditions++
+		}
+	}
+	if conditions != 1 {
+		return errors.Errorf("exactly one condition must be provided for attribute %q", f.Slug)
+	}
+
+	if r := f.ValuesRange; r != nil {
+		if r.Gte == nil && r.Lte == nil {
+			return errors.Errorf("values range of attribute %q must have at least one bound", f.Slug)
+		}
+		if r.Unit != nil && measurement.MeasurementUnitMap[*r.Unit] == "" {
+			return errors.Errorf("unknown unit %q for attribute %q", *r.Unit, f.Slug)
+		}
+	}
+	for _, r := range []*AttributeTimeRange{f.Date, f.DateTime} {
+		if r != nil && r.Gte == nil && r.Lte == nil {
+			return errors.Errorf("time range of attribute %q must have at least one bound", f.Slug)
+		}
+	}
+	for _, id := range f.ReferenceIDs {
+		if !IsValidId(id) {
+			return errors.Errorf("invalid reference id %q for attribute %q", id, f.Slug)
+		}
+	}
+	return nil
+}
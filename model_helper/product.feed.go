@@ -0,0 +1,84 @@
+package model_helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/sitename/sitename/model"
+)
+
+// preference category storing favourite product categories of users.
+// Name of each preference is a category id, value is "true"
+const PREFERENCE_CATEGORY_FAVORITE_PRODUCT_CATEGORY = "favorite_product_category"
+
+// ProductCategoryInteraction counts interactions of a user with products of a category
+type ProductCategoryInteraction struct {
+	CategoryID      string `boil:"category_id"`
+	OrderedCount    uint64 `boil:"ordered_count"`
+	WishlistedCount uint64 `boil:"wishlisted_count"`
+}
+
+type ProductFeedOptions struct {
+	ChannelIdOrSlug  string
+	CategoryWeights  map[string]float64 // category id => affinity of the user to the category
+	RecencyWeight    float64            // weight of new products, decays with product age
+	PopularityWeight float64            // weight of products ordered recently by all customers, 0 disables it
+	PopularitySince  int64              // only orders created between this time and Now count for popularity
+	Now              int64              // reference time of the feed, scores depend on it
+	Cursor           *ProductFeedCursor
+	Limit            int
+}
+
+// ProductFeedItem is a product with its score in a feed.
+// FeedRank is the score scaled to an integer, feeds are ordered by it
+type ProductFeedItem struct {
+	model.Product `boil:",bind"`
+	FeedScore     float64 `boil:"feed_score" json:"feed_score"`
+	FeedRank      int64   `boil:"feed_rank" json:"feed_rank"`
+}
+
+// MaxProductFeedCursorCategories limits category weights a cursor can carry
+const MaxProductFeedCursorCategories = 200
+
+// ProductFeedCursor points to the last item of a feed page.
+// It keeps the reference time and category weights of the first page, so ranks of next
+// pages are computed the same way even if the user's interests changed in the meantime
+type ProductFeedCursor struct {
+	Rank            int64              `json:"r"`
+	ProductID       string             `json:"p"`
+	Now             int64              `json:"t"`
+	CategoryWeights map[string]float64 `json:"w,omitempty"`
+}
+
+func (c *ProductFeedCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeProductFeedCursor(cursor string) (*ProductFeedCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode cursor")
+	}
+
+	var res ProductFeedCursor
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse cursor")
+	}
+	if !IsValidId(res.ProductID) || res.Now <= 0 || len(res.CategoryWeights) > MaxProductFeedCursorCategories {
+		return nil, errors.New("invalid cursor")
+	}
+	for categoryID := range res.CategoryWeights {
+		if !IsValidId(categoryID) {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+	return &res, nil
+}
+
+type ProductFeed struct {
+	Items      []*ProductFeedItem
+	NextCursor string // empty if there are no more items
+	ColdStart  bool   // true if the user has no known interests, products are ranked by popularity and recency
+}
@@ -351,6 +351,9 @@ func ProductMediaCommonPre(p *model.ProductMedium) {
 	if p.Type.IsValid() != nil {
 		p.Type = model.ProductMediaTypeIMAGE
 	}
+	if p.Ppoi == "" {
+		p.Ppoi = DefaultProductMediaPpoi
+	}
 }
 
 func ProductMediaIsValid(p model.ProductMedium) *AppError {
@@ -366,6 +369,9 @@ func ProductMediaIsValid(p model.ProductMedium) *AppError {
 	if p.Type.IsValid() != nil {
 		return NewAppError("ProductMedia.IsValid", "model.product_media.is_valid.type.app_error", nil, "", http.StatusBadRequest)
 	}
+	if _, _, err := ParseProductMediaPpoi(p.Ppoi); err != nil {
+		return NewAppError("ProductMedia.IsValid", "model.product_media.is_valid.ppoi.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
 
 	return nil
 }
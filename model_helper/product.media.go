@@ -0,0 +1,124 @@
+package model_helper
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ProductMediaImageSize names a size variant generated for uploaded product images
+type ProductMediaImageSize string
+
+const (
+	ProductMediaImageSizeThumbnail ProductMediaImageSize = "thumbnail"
+	ProductMediaImageSizeMedium    ProductMediaImageSize = "medium"
+	ProductMediaImageSizeLarge     ProductMediaImageSize = "large"
+)
+
+// ProductMediaImageSizeSpec describes how a size variant is generated.
+// Cropped variants are cut to exactly Width x Height around the primary point of interest of the media,
+// other variants are scaled to fit within Width x Height.
+type ProductMediaImageSizeSpec struct {
+	Width  int
+	Height int
+	Crop   bool
+}
+
+// ProductMediaImageSizes lists size variants of product images, from the smallest
+var ProductMediaImageSizes = []ProductMediaImageSize{
+	ProductMediaImageSizeThumbnail,
+	ProductMediaImageSizeMedium,
+	ProductMediaImageSizeLarge,
+}
+
+var ProductMediaImageSizeSpecs = map[ProductMediaImageSize]ProductMediaImageSizeSpec{
+	ProductMediaImageSizeThumbnail: {Width: 256, Height: 256, Crop: true},
+	ProductMediaImageSizeMedium:    {Width: 1024, Height: 1024},
+	ProductMediaImageSizeLarge:     {Width: 2048, Height: 2048},
+}
+
+func (s ProductMediaImageSize) IsValid() bool {
+	_, ok := ProductMediaImageSizeSpecs[s]
+	return ok
+}
+
+// ProductMediaImageSizeForWidth returns the smallest size variant at least as wide as given width,
+// or the largest variant if none is wide enough.
+func ProductMediaImageSizeForWidth(width int) ProductMediaImageSize {
+	for _, size := range ProductMediaImageSizes {
+		if ProductMediaImageSizeSpecs[size].Width >= width {
+			return size
+		}
+	}
+	return ProductMediaImageSizeLarge
+}
+
+// ProductMediaImageFormat is format of a size variant of product images
+type ProductMediaImageFormat string
+
+const (
+	ProductMediaImageFormatOriginal ProductMediaImageFormat = "original" // format of the uploaded image, jpeg or png
+	ProductMediaImageFormatWebp     ProductMediaImageFormat = "webp"
+)
+
+func (f ProductMediaImageFormat) IsValid() bool {
+	return f == ProductMediaImageFormatOriginal || f == ProductMediaImageFormatWebp
+}
+
+// ProductMediaImageDir returns directory holding the original and size variants of an uploaded product image.
+// E.g "products/<product id>/media/<media id>"
+func ProductMediaImageDir(productID, mediaID string) string {
+	return path.Join("products", productID, "media", mediaID)
+}
+
+// ProductMediaURLPath is path of the endpoint publicly serving product images and their size variants
+const ProductMediaURLPath = "/media"
+
+// IsProductMediaImagePath tells if given file path is under directory of a product image,
+// only those files are served at ProductMediaURLPath.
+func IsProductMediaImagePath(filePath string) bool {
+	parts := strings.Split(filePath, "/")
+	return path.Clean(filePath) == filePath &&
+		len(parts) == 5 &&
+		parts[0] == "products" &&
+		IsValidId(parts[1]) &&
+		parts[2] == "media" &&
+		IsValidId(parts[3]) &&
+		parts[4] != ""
+}
+
+// ProductMediaImageURL returns absolute url serving given product image path, e.g
+// "https://example.com/media/products/<product id>/media/<media id>/original.jpg"
+func ProductMediaImageURL(siteURL, imagePath string) string {
+	return strings.TrimRight(siteURL, "/") + ProductMediaURLPath + "/" + strings.TrimLeft(imagePath, "/")
+}
+
+// ProductMediaOriginalImagePath returns path of the uploaded image, ext is extension of its format, e.g "jpg"
+func ProductMediaOriginalImagePath(productID, mediaID, ext string) string {
+	return path.Join(ProductMediaImageDir(productID, mediaID), "original."+ext)
+}
+
+// ProductMediaImagePath returns path of a size variant of an uploaded product image,
+// ext is extension of the variant format, e.g "products/<product id>/media/<media id>/thumbnail.webp"
+func ProductMediaImagePath(productID, mediaID string, size ProductMediaImageSize, ext string) string {
+	return path.Join(ProductMediaImageDir(productID, mediaID), string(size)+"."+ext)
+}
+
+// DefaultProductMediaPpoi is the center of images
+const DefaultProductMediaPpoi = "0.5x0.5"
+
+// ParseProductMediaPpoi parses primary point of interest of a product media, in "<x>x<y>" format
+// where x and y are fractions of the image width and height, e.g "0.5x0.5" is the center.
+func ParseProductMediaPpoi(ppoi string) (x, y float64, err error) {
+	xStr, yStr, ok := strings.Cut(ppoi, "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid primary point of interest %q", ppoi)
+	}
+	x, errX := strconv.ParseFloat(xStr, 64)
+	y, errY := strconv.ParseFloat(yStr, 64)
+	if errX != nil || errY != nil || x < 0 || x > 1 || y < 0 || y > 1 {
+		return 0, 0, fmt.Errorf("invalid primary point of interest %q", ppoi)
+	}
+	return x, y, nil
+}
@@ -0,0 +1,77 @@
+package model_helper
+
+import (
+	"net/http"
+
+	"github.com/sitename/sitename/model"
+)
+
+// valid values for ProductRecommendation.Kind
+const (
+	ProductRecommendationKindCoPurchase = "co_purchase" // products frequently bought together with the product
+	ProductRecommendationKindSimilar    = "similar"     // products sharing category and attribute values with the product
+)
+
+func ProductRecommendationPreSave(r *model.ProductRecommendation) {
+	if r.ID == "" {
+		r.ID = NewId()
+	}
+	if r.CreatedAt == 0 {
+		r.CreatedAt = GetMillis()
+	}
+}
+
+func ProductRecommendationIsValid(r model.ProductRecommendation) *AppError {
+	if !IsValidId(r.ID) {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.id.app_error", nil, "please provide valid id", http.StatusBadRequest)
+	}
+	if !IsValidId(r.ChannelID) {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.channel_id.app_error", nil, "please provide valid channel id", http.StatusBadRequest)
+	}
+	if !IsValidId(r.ProductID) {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.product_id.app_error", nil, "please provide valid product id", http.StatusBadRequest)
+	}
+	if !IsValidId(r.RecommendedProductID) || r.RecommendedProductID == r.ProductID {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.recommended_product_id.app_error", nil, "please provide valid recommended product id", http.StatusBadRequest)
+	}
+	if r.Kind != ProductRecommendationKindCoPurchase && r.Kind != ProductRecommendationKindSimilar {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.kind.app_error", nil, "please provide valid kind", http.StatusBadRequest)
+	}
+	if r.Score <= 0 {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.score.app_error", nil, "please provide valid score", http.StatusBadRequest)
+	}
+	if r.CreatedAt <= 0 {
+		return NewAppError("ProductRecommendationIsValid", "model.product_recommendation.is_valid.created_at.app_error", nil, "please provide valid created at", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// ProductCoPurchase counts orders containing both ProductID and OtherProductID
+type ProductCoPurchase struct {
+	ProductID              string `boil:"product_id"`
+	OtherProductID         string `boil:"other_product_id"`
+	CoPurchaseCount        uint64 `boil:"co_purchase_count"`
+	ProductOrderCount      uint64 `boil:"product_order_count"`       // number of orders containing ProductID
+	OtherProductOrderCount uint64 `boil:"other_product_order_count"` // number of orders containing OtherProductID
+}
+
+type RecommendedProductsOptions struct {
+	ProductID string // required
+	ChannelID string // required, only products visible and in stock in the channel are returned
+	Kind      string // ProductRecommendationKindCoPurchase or ProductRecommendationKindSimilar, empty means any kind
+	Limit     int
+}
+
+// RecommendedProduct is a product recommended for another one, with the best score of its recommendations
+type RecommendedProduct struct {
+	model.Product       `boil:",bind"`
+	RecommendationScore float64 `boil:"recommendation_score" json:"recommendation_score"`
+}
+
+// ProductForRecommendation holds what recommendations of a product listed in a channel are computed from
+type ProductForRecommendation struct {
+	ID             string   `boil:"id"`
+	CategoryID     string   `boil:"category_id"`
+	Recommendable  bool     `boil:"recommendable"` // the product is published by now, visible in listings and in stock in the channel
+	AttributeTerms []string `boil:"-"`             // "attribute slug:value slug" of values assigned to the product and its variants
+}
@@ -0,0 +1,102 @@
+package model_helper
+
+// ProductForIndexing holds data of a product that search engines index
+type ProductForIndexing struct {
+	Id              string                              `json:"id"`
+	Name            string                              `json:"name"`
+	Slug            string                              `json:"slug"`
+	Description     string                              `json:"description"` // plain text of the product's EditorJS description
+	CategoryID      string                              `json:"category_id"`
+	CategoryName    string                              `json:"category_name"`
+	Rating          float64                             `json:"rating"`
+	CreatedAt       int64                               `json:"created_at"`
+	VariantNames    []string                            `json:"variant_names"`
+	Skus            []string                            `json:"skus"`
+	Collections     []*ProductCollectionForIndexing     `json:"collections"`
+	Attributes      []*ProductAttributeValueForIndexing `json:"attributes"`
+	ChannelListings []*ProductChannelListingForIndexing `json:"channel_listings"`
+}
+
+type ProductCollectionForIndexing struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProductAttributeValueForIndexing is a value assigned to a product or one of its variants
+type ProductAttributeValueForIndexing struct {
+	AttributeSlug string `json:"attribute_slug"`
+	ValueSlug     string `json:"value_slug"`
+	ValueName     string `json:"value_name"`
+}
+
+// ProductChannelListingForIndexing holds publication and availability of a product in a channel.
+// Dates are indexed as they are, search engines compare them with the current time when searching.
+type ProductChannelListingForIndexing struct {
+	ChannelID              string   `json:"channel_id"`
+	IsPublished            bool     `json:"is_published"`
+	PublishedAt            int64    `json:"published_at"` // 0 if the listing has no publication date
+	VisibleInListings      bool     `json:"visible_in_listings"`
+	AvailableForPurchaseAt *int64   `json:"available_for_purchase_at"` // nil if the product is not available for purchase
+	MinimalPrice           *float64 `json:"minimal_price"`             // lowest discounted price of the product's variants in the channel
+	InStock                bool     `json:"in_stock"`                  // true if any variant has stock in a warehouse shipping to the channel
+}
+
+// valid values for ProductSearchParams.SortBy
+const (
+	ProductSearchSortRelevance ProductSearchSortField = "relevance"
+	ProductSearchSortName      ProductSearchSortField = "name"
+	ProductSearchSortPrice     ProductSearchSortField = "price"
+	ProductSearchSortCreatedAt ProductSearchSortField = "created_at"
+	ProductSearchSortRating    ProductSearchSortField = "rating"
+)
+
+type ProductSearchSortField string
+
+func (p ProductSearchSortField) IsValid() bool {
+	switch p {
+	case ProductSearchSortRelevance,
+		ProductSearchSortName,
+		ProductSearchSortPrice,
+		ProductSearchSortCreatedAt,
+		ProductSearchSortRating:
+		return true
+	default:
+		return false
+	}
+}
+
+type ProductSearchParams struct {
+	Terms             string
+	ChannelID         string              // required, only products visible in the channel are returned
+	Attributes        map[string][]string // attribute slug => value slugs, values of an attribute are ORed
+	CategoryIDs       []string
+	CollectionIDs     []string
+	StockAvailability *StockAvailability
+	Price             *struct {
+		Gte *float64
+		Lte *float64
+	}
+	SortBy    ProductSearchSortField
+	Direction OrderDirection
+	Page      int
+	PerPage   int
+}
+
+type ProductSearchResult struct {
+	ProductIDs        []string
+	TotalCount        uint64
+	AttributeFacets   map[string][]*ProductSearchTermFacet // attribute slug => counts of values
+	PriceFacets       []*ProductSearchPriceFacet
+	AvailabilityFacet map[StockAvailability]int
+}
+
+type ProductSearchTermFacet struct {
+	Term  string
+	Count int
+}
+
+type ProductSearchPriceFacet struct {
+	Min   *float64
+	Max   *float64
+	Count int
+}
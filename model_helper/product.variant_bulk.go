@@ -0,0 +1,77 @@
+package model_helper
+
+import (
+	"github.com/site-name/decimal"
+	"github.com/sitename/sitename/model"
+)
+
+// ProductVariantBulkErrorPolicy decides what happens to valid items of a bulk variant call when some items fail
+type ProductVariantBulkErrorPolicy string
+
+const (
+	// all items are written in one transaction, nothing is written if any item fails
+	ProductVariantBulkRejectEverything ProductVariantBulkErrorPolicy = "REJECT_EVERYTHING"
+	// each item is written in its own transaction, only failed items are rejected
+	ProductVariantBulkRejectFailedRows ProductVariantBulkErrorPolicy = "REJECT_FAILED_ROWS"
+)
+
+func (p ProductVariantBulkErrorPolicy) IsValid() bool {
+	return p == ProductVariantBulkRejectEverything || p == ProductVariantBulkRejectFailedRows
+}
+
+// codes of ProductVariantBulkError
+const (
+	ProductVariantBulkErrorCodeRequired                    = "required"
+	ProductVariantBulkErrorCodeInvalid                     = "invalid"
+	ProductVariantBulkErrorCodeNotFound                    = "not_found"
+	ProductVariantBulkErrorCodeUnique                      = "unique"
+	ProductVariantBulkErrorCodeDuplicatedInputItem         = "duplicated_input_item"
+	ProductVariantBulkErrorCodeProductNotAssignedToChannel = "product_not_assigned_to_channel"
+	ProductVariantBulkErrorCodeCannotDelete                = "cannot_delete"
+)
+
+// ProductVariantBulkInput holds fields of a variant to create or update in bulk.
+// Nil fields are left untouched on update.
+type ProductVariantBulkInput struct {
+	ID                       string // required on update, ignored on create
+	Sku                      *string
+	Name                     *string
+	Weight                   *float32
+	WeightUnit               *string
+	TrackInventory           *bool
+	QuantityLimitPerCustomer *int
+	LowStockThreshold        *int                                 // overrides the shop default for stocks of the variant
+	Attributes               []*CustomProductAttributeValuesInput // replace values of given attributes, other attributes of the variant are kept
+	ChannelListings          []*ProductVariantBulkChannelListingInput
+	Stocks                   []*ProductVariantBulkStockInput
+}
+
+type ProductVariantBulkChannelListingInput struct {
+	ChannelID                 string
+	Price                     *decimal.Decimal // required when the variant is not listed in the channel yet
+	CostPrice                 *decimal.Decimal
+	PreorderQuantityThreshold *int
+}
+
+type ProductVariantBulkStockInput struct {
+	WarehouseID       string
+	Quantity          int
+	LowStockThreshold *int // overrides the variant threshold for this stock
+}
+
+// ProductVariantBulkError describes a problem of the item at Index of a bulk variant call.
+// Field is empty for problems of a whole item
+type ProductVariantBulkError struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ProductVariantBulkResult is result of a bulk variant call.
+// Variants has one entry per input item, entries of rejected items are nil
+type ProductVariantBulkResult struct {
+	Count    int
+	Variants model.ProductVariantSlice
+	Errors   []*ProductVariantBulkError
+}
@@ -3,6 +3,7 @@ package model_helper
 import (
 	"encoding/xml"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,12 @@ const (
 	USER_AUTH_SERVICE_IS_OAUTH  = "isOAuthUser"
 )
 
+// NormalizeSamlAuthData returns given id asserted by the identity provider in the form it is saved
+// as auth data of SAML users, so logins and migrated users match whichever attribute identifies them.
+func NormalizeSamlAuthData(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
 type SamlAuthRequest struct {
 	Base64AuthRequest string
 	URL               string
@@ -0,0 +1,183 @@
+package model_helper
+
+import (
+	"encoding/xml"
+	"net/http"
+	"path"
+
+	"github.com/sitename/sitename/model"
+)
+
+// SeoObjectType is type of storefront objects having their own urls
+type SeoObjectType string
+
+const (
+	SeoObjectTypeProduct    SeoObjectType = "product"
+	SeoObjectTypeCategory   SeoObjectType = "category"
+	SeoObjectTypeCollection SeoObjectType = "collection"
+	SeoObjectTypePage       SeoObjectType = "page"
+)
+
+var seoObjectTypePathSegments = map[SeoObjectType]string{
+	SeoObjectTypeProduct:    "products",
+	SeoObjectTypeCategory:   "categories",
+	SeoObjectTypeCollection: "collections",
+	SeoObjectTypePage:       "pages",
+}
+
+func (t SeoObjectType) IsValid() bool {
+	_, ok := seoObjectTypePathSegments[t]
+	return ok
+}
+
+// PathSegment returns the url path segment of objects of the type, e.g "products"
+func (t SeoObjectType) PathSegment() string {
+	return seoObjectTypePathSegments[t]
+}
+
+// SeoObjectTypeFromPathSegment is the reverse of SeoObjectType.PathSegment
+func SeoObjectTypeFromPathSegment(segment string) (SeoObjectType, bool) {
+	for objectType, objectSegment := range seoObjectTypePathSegments {
+		if objectSegment == segment {
+			return objectType, true
+		}
+	}
+	return "", false
+}
+
+// SeoObjectTypeHasSlugRedirects tells if renaming slugs of objects of given type keeps redirects from the old slugs
+func SeoObjectTypeHasSlugRedirects(t SeoObjectType) bool {
+	return t == SeoObjectTypeProduct || t == SeoObjectTypeCategory || t == SeoObjectTypeCollection
+}
+
+// StorefrontPath returns path of given object in storefront of the channel. E.g "/default-channel/products/apple-juice/"
+func StorefrontPath(channelSlug string, objectType SeoObjectType, slug string) string {
+	return path.Join("/", channelSlug, objectType.PathSegment(), slug) + "/"
+}
+
+func SlugRedirectPreSave(r *model.SlugRedirect) {
+	if r.ID == "" {
+		r.ID = NewId()
+	}
+	if r.CreatedAt == 0 {
+		r.CreatedAt = GetMillis()
+	}
+}
+
+func SlugRedirectIsValid(r model.SlugRedirect) *AppError {
+	if !IsValidId(r.ID) {
+		return NewAppError("SlugRedirectIsValid", "model.slug_redirect.is_valid.id.app_error", nil, "please provide valid id", http.StatusBadRequest)
+	}
+	if !SeoObjectTypeHasSlugRedirects(SeoObjectType(r.ObjectType)) {
+		return NewAppError("SlugRedirectIsValid", "model.slug_redirect.is_valid.object_type.app_error", nil, "please provide valid object type", http.StatusBadRequest)
+	}
+	if !IsValidId(r.ObjectID) {
+		return NewAppError("SlugRedirectIsValid", "model.slug_redirect.is_valid.object_id.app_error", nil, "please provide valid object id", http.StatusBadRequest)
+	}
+	if r.OldSlug == "" || len(r.OldSlug) > 255 {
+		return NewAppError("SlugRedirectIsValid", "model.slug_redirect.is_valid.old_slug.app_error", nil, "please provide valid old slug", http.StatusBadRequest)
+	}
+	if r.CreatedAt <= 0 {
+		return NewAppError("SlugRedirectIsValid", "model.slug_redirect.is_valid.created_at.app_error", nil, "please provide valid created at", http.StatusBadRequest)
+	}
+	return nil
+}
+
+type SlugRedirectFilterOptions struct {
+	CommonQueryOptions
+}
+
+// SitemapMaxURLs is the maximum number of urls a single sitemap file may contain, per the sitemaps protocol
+const SitemapMaxURLs = 50000
+
+const sitemapXmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapURL is an <url> entry of sitemaps
+type SitemapURL struct {
+	Loc        string  `xml:"loc"`
+	LastMod    string  `xml:"lastmod,omitempty"` // W3C datetime, e.g 2024-01-02
+	ChangeFreq string  `xml:"changefreq,omitempty"`
+	Priority   float32 `xml:"priority,omitempty"`
+}
+
+// SitemapURLSet is a sitemap file listing urls
+type SitemapURLSet struct {
+	XMLName xml.Name      `xml:"urlset"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	URLs    []*SitemapURL `xml:"url"`
+}
+
+func NewSitemapURLSet(urls []*SitemapURL) *SitemapURLSet {
+	return &SitemapURLSet{Xmlns: sitemapXmlns, URLs: urls}
+}
+
+// SitemapIndexEntry is a <sitemap> entry of sitemap indexes
+type SitemapIndexEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SitemapIndex lists sitemap files of a site
+type SitemapIndex struct {
+	XMLName  xml.Name             `xml:"sitemapindex"`
+	Xmlns    string               `xml:"xmlns,attr"`
+	Sitemaps []*SitemapIndexEntry `xml:"sitemap"`
+}
+
+func NewSitemapIndex(entries []*SitemapIndexEntry) *SitemapIndex {
+	return &SitemapIndex{Xmlns: sitemapXmlns, Sitemaps: entries}
+}
+
+const (
+	SchemaOrgContext         = "https://schema.org"
+	SchemaOrgInStock         = "https://schema.org/InStock"
+	SchemaOrgOutOfStock      = "https://schema.org/OutOfStock"
+	SchemaOrgTypeProduct     = "Product"
+	SchemaOrgTypeOffer       = "Offer"
+	SchemaOrgTypeAggregate   = "AggregateOffer"
+	SchemaOrgTypeBreadcrumbs = "BreadcrumbList"
+	SchemaOrgTypeListItem    = "ListItem"
+)
+
+// ProductJsonLd is JSON-LD structured data of a product, see https://schema.org/Product
+type ProductJsonLd struct {
+	Context     string       `json:"@context"`
+	Type        string       `json:"@type"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Sku         string       `json:"sku,omitempty"`
+	Image       []string     `json:"image,omitempty"`
+	URL         string       `json:"url"`
+	Offers      *OfferJsonLd `json:"offers,omitempty"`
+}
+
+// OfferJsonLd is an Offer, or an AggregateOffer when the product price ranges, see https://schema.org/Offer
+type OfferJsonLd struct {
+	Type          string `json:"@type"`
+	PriceCurrency string `json:"priceCurrency"`
+	Price         string `json:"price,omitempty"`
+	LowPrice      string `json:"lowPrice,omitempty"`
+	HighPrice     string `json:"highPrice,omitempty"`
+	Availability  string `json:"availability"`
+	URL           string `json:"url"`
+}
+
+// BreadcrumbListJsonLd is JSON-LD breadcrumbs of a page, see https://schema.org/BreadcrumbList
+type BreadcrumbListJsonLd struct {
+	Context         string            `json:"@context"`
+	Type            string            `json:"@type"`
+	ItemListElement []*ListItemJsonLd `json:"itemListElement"`
+}
+
+type ListItemJsonLd struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	Item     string `json:"item"`
+}
+
+// ProductStructuredData holds JSON-LD documents describing a product page
+type ProductStructuredData struct {
+	Product     *ProductJsonLd        `json:"product"`
+	Breadcrumbs *BreadcrumbListJsonLd `json:"breadcrumbs"`
+}
@@ -185,3 +185,39 @@ func StockIsValid(s model.Stock) *AppError {
 	}
 	return nil
 }
+
+func BackInStockSubscriptionPreSave(s *model.BackInStockSubscription) {
+	if s.ID == "" {
+		s.ID = NewId()
+	}
+	if s.CreatedAt == 0 {
+		s.CreatedAt = GetMillis()
+	}
+	s.Email = NormalizeEmail(s.Email)
+}
+
+func BackInStockSubscriptionIsValid(s model.BackInStockSubscription) *AppError {
+	if !IsValidId(s.ID) {
+		return NewAppError("BackInStockSubscriptionIsValid", "model.back_in_stock_subscription.is_valid.id.app_error", nil, "please provide valid id", http.StatusBadRequest)
+	}
+	if !IsValidId(s.ProductVariantID) {
+		return NewAppError("BackInStockSubscriptionIsValid", "model.back_in_stock_subscription.is_valid.product_variant_id.app_error", nil, "please provide valid product variant id", http.StatusBadRequest)
+	}
+	if !IsValidId(s.ChannelID) {
+		return NewAppError("BackInStockSubscriptionIsValid", "model.back_in_stock_subscription.is_valid.channel_id.app_error", nil, "please provide valid channel id", http.StatusBadRequest)
+	}
+	if !IsValidEmail(s.Email) {
+		return NewAppError("BackInStockSubscriptionIsValid", "model.back_in_stock_subscription.is_valid.email.app_error", nil, "please provide valid email", http.StatusBadRequest)
+	}
+	if !s.UserID.IsNil() && !IsValidId(*s.UserID.String) {
+		return NewAppError("BackInStockSubscriptionIsValid", "model.back_in_stock_subscription.is_valid.user_id.app_error", nil, "please provide valid user id", http.StatusBadRequest)
+	}
+	if s.CreatedAt <= 0 {
+		return NewAppError("BackInStockSubscriptionIsValid", "model.back_in_stock_subscription.is_valid.created_at.app_error", nil, "please provide valid created at", http.StatusBadRequest)
+	}
+	return nil
+}
+
+type BackInStockSubscriptionFilterOptions struct {
+	CommonQueryOptions
+}
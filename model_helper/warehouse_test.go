@@ -0,0 +1,22 @@
+package model_helper
+
+import (
+	"testing"
+
+	"github.com/sitename/sitename/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackInStockSubscriptionIsValid(t *testing.T) {
+	subscription := model.BackInStockSubscription{
+		ProductVariantID: NewId(),
+		ChannelID:        NewId(),
+		Email:            "customer@Example.COM",
+	}
+	BackInStockSubscriptionPreSave(&subscription)
+	require.Equal(t, "customer@example.com", subscription.Email)
+	require.Nil(t, BackInStockSubscriptionIsValid(subscription))
+
+	subscription.Email = "not an email"
+	require.NotNil(t, BackInStockSubscriptionIsValid(subscription))
+}
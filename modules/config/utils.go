@@ -27,10 +27,25 @@ func desanitize(actual, target *model_helper.Config) {
 	if *target.FileSettings.PublicLinkSalt == model_helper.FAKE_SETTING {
 		*target.FileSettings.PublicLinkSalt = *actual.FileSettings.PublicLinkSalt
 	}
+	if target.FileSettings.PresignedURLSigningKey != nil && *target.FileSettings.PresignedURLSigningKey == model_helper.FAKE_SETTING {
+		target.FileSettings.PresignedURLSigningKey = actual.FileSettings.PresignedURLSigningKey
+	}
 	if *target.FileSettings.AmazonS3SecretAccessKey == model_helper.FAKE_SETTING {
 		target.FileSettings.AmazonS3SecretAccessKey = actual.FileSettings.AmazonS3SecretAccessKey
 	}
 
+	if target.FileSettings.GoogleCloudStorageCredentialsJSON != nil && *target.FileSettings.GoogleCloudStorageCredentialsJSON == model_helper.FAKE_SETTING {
+		target.FileSettings.GoogleCloudStorageCredentialsJSON = actual.FileSettings.GoogleCloudStorageCredentialsJSON
+	}
+
+	if target.FileSettings.AzureStorageAccountKey != nil && *target.FileSettings.AzureStorageAccountKey == model_helper.FAKE_SETTING {
+		target.FileSettings.AzureStorageAccountKey = actual.FileSettings.AzureStorageAccountKey
+	}
+
+	if target.CacheSettings.RedisPassword != nil && *target.CacheSettings.RedisPassword == model_helper.FAKE_SETTING {
+		target.CacheSettings.RedisPassword = actual.CacheSettings.RedisPassword
+	}
+
 	if *target.EmailSettings.SMTPPassword == model_helper.FAKE_SETTING {
 		target.EmailSettings.SMTPPassword = actual.EmailSettings.SMTPPassword
 	}
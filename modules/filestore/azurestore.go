@@ -0,0 +1,363 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/pkg/errors"
+
+	"github.com/sitename/sitename/modules/slog"
+)
+
+// azureCopyPollInterval is the interval between checks of pending blob copies
+const azureCopyPollInterval = 100 * time.Millisecond
+
+// AzureFileBackend contains all necessary information to communicate with
+// an Azure Blob Storage container.
+type AzureFileBackend struct {
+	pathPrefix string
+	client     *container.Client
+}
+
+// AzureFileBackendNoContainerError is returned when testing a connection and no Azure container is found
+type AzureFileBackendNoContainerError struct{}
+
+func (s *AzureFileBackendNoContainerError) Error() string {
+	return "no such container"
+}
+
+// NewAzureFileBackend returns an instance of an AzureFileBackend.
+// Blobs are stored at https://<account name>.blob.core.windows.net/ unless an endpoint is set,
+// e.g http://127.0.0.1:10000/devstoreaccount1 for Azurite.
+func NewAzureFileBackend(settings FileBackendSettings) (*AzureFileBackend, error) {
+	credential, err := container.NewSharedKeyCredential(settings.AzureStorageAccountName, settings.AzureStorageAccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid azure storage account credentials")
+	}
+
+	endpoint := settings.AzureStorageEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", settings.AzureStorageAccountName)
+	}
+	containerURL, err := url.JoinPath(endpoint, settings.AzureStorageContainer)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid azure storage endpoint")
+	}
+
+	client, err := container.NewClientWithSharedKeyCredential(containerURL, credential, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureFileBackend{
+		pathPrefix: settings.AzureStoragePathPrefix,
+		client:     client,
+	}, nil
+}
+
+func (b *AzureFileBackend) blob(path string) *blockblob.Client {
+	return b.client.NewBlockBlobClient(filepath.Join(b.pathPrefix, path))
+}
+
+func (b *AzureFileBackend) TestConnection() error {
+	_, err := b.client.GetProperties(context.Background(), nil)
+	if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+		return &AzureFileBackendNoContainerError{}
+	}
+	if err != nil {
+		return errors.Wrap(err, "unable to check if the azure container exists")
+	}
+	slog.Debug("Connection to Azure Blob Storage is good. Container exists.")
+	return nil
+}
+
+func (b *AzureFileBackend) MakeContainer() error {
+	if _, err := b.client.Create(context.Background(), nil); err != nil {
+		return errors.Wrap(err, "unable to create the azure container")
+	}
+	return nil
+}
+
+// Caller must close the first return value
+func (b *AzureFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	blobClient := b.blob(path)
+	props, err := blobClient.GetProperties(context.Background(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	return &rangeReadSeeker{
+		size: *props.ContentLength,
+		open: func(offset int64) (io.ReadCloser, error) {
+			resp, err := blobClient.DownloadStream(context.Background(), &blob.DownloadStreamOptions{
+				Range: blob.HTTPRange{Offset: offset},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.Body, nil
+		},
+	}, nil
+}
+
+func (b *AzureFileBackend) ReadFile(path string) ([]byte, error) {
+	resp, err := b.blob(path).DownloadStream(context.Background(), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	defer resp.Body.Close()
+	f, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return f, nil
+}
+
+func (b *AzureFileBackend) FileExists(path string) (bool, error) {
+	_, err := b.blob(path).GetProperties(context.Background(), nil)
+	if err == nil {
+		return true, nil
+	}
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+	return false, errors.Wrapf(err, "unable to know if file %s exists", path)
+}
+
+func (b *AzureFileBackend) FileSize(path string) (int64, error) {
+	props, err := b.blob(path).GetProperties(context.Background(), nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	return *props.ContentLength, nil
+}
+
+func (b *AzureFileBackend) FileModTime(path string) (time.Time, error) {
+	props, err := b.blob(path).GetProperties(context.Background(), nil)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+	return *props.LastModified, nil
+}
+
+// copyBlob starts copying a blob inside the container, then waits for the copy to finish.
+func (b *AzureFileBackend) copyBlob(oldPath, newPath string) error {
+	dst := b.blob(newPath)
+	resp, err := dst.StartCopyFromURL(context.Background(), b.blob(oldPath).URL(), nil)
+	if err != nil {
+		return err
+	}
+
+	status := resp.CopyStatus
+	for status != nil && *status == blob.CopyStatusTypePending {
+		time.Sleep(azureCopyPollInterval)
+		props, err := dst.GetProperties(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		status = props.CopyStatus
+	}
+	if status != nil && *status != blob.CopyStatusTypeSuccess {
+		return errors.Errorf("copy finished with status %s", *status)
+	}
+	return nil
+}
+
+func (b *AzureFileBackend) CopyFile(oldPath, newPath string) error {
+	if err := b.copyBlob(oldPath, newPath); err != nil {
+		return errors.Wrapf(err, "unable to copy file from %s to %s", oldPath, newPath)
+	}
+	return nil
+}
+
+func (b *AzureFileBackend) MoveFile(oldPath, newPath string) error {
+	if err := b.copyBlob(oldPath, newPath); err != nil {
+		return errors.Wrapf(err, "unable to copy the file to %s to the new destionation", newPath)
+	}
+
+	if _, err := b.blob(oldPath).Delete(context.Background(), nil); err != nil {
+		return errors.Wrapf(err, "unable to remove the file old file %s", oldPath)
+	}
+
+	return nil
+}
+
+// countingReader counts bytes read from the underlying reader
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+func (b *AzureFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	contentType := getContentType(path)
+	reader := &countingReader{Reader: fr}
+
+	_, err := b.blob(path).UploadStream(context.Background(), reader, &blockblob.UploadStreamOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: &contentType},
+	})
+	if err != nil {
+		return reader.count, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+	return reader.count, nil
+}
+
+// AppendFile stages given data as a new block of the blob, then commits it after existing blocks.
+func (b *AzureFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	blobClient := b.blob(path)
+	props, err := blobClient.GetProperties(context.Background(), nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	data, err := io.ReadAll(fr)
+	if err != nil || len(data) == 0 {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	blockList, err := blobClient.GetBlockList(context.Background(), blockblob.BlockListTypeCommitted, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+	var blockIDs []string
+	for _, block := range blockList.CommittedBlocks {
+		blockIDs = append(blockIDs, *block.Name)
+	}
+
+	// Every block of a blob must have ids of the same length.
+	idLength := 16
+	if len(blockIDs) > 0 {
+		decoded, err := base64.StdEncoding.DecodeString(blockIDs[0])
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+		}
+		idLength = len(decoded)
+	} else if *props.ContentLength > 0 {
+		// blobs uploaded in a single request have no blocks, so their content becomes the first one.
+		content, err := b.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		blockIDs = []string{newAzureBlockID(idLength)}
+		if _, err = blobClient.StageBlock(context.Background(), blockIDs[0], streaming.NopCloser(bytes.NewReader(content)), nil); err != nil {
+			return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+		}
+	}
+
+	blockID := newAzureBlockID(idLength)
+	if _, err = blobClient.StageBlock(context.Background(), blockID, streaming.NopCloser(bytes.NewReader(data)), nil); err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+	_, err = blobClient.CommitBlockList(context.Background(), append(blockIDs, blockID), &blockblob.CommitBlockListOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: props.ContentType},
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+	return int64(len(data)), nil
+}
+
+func newAzureBlockID(length int) string {
+	id := make([]byte, length)
+	rand.Read(id)
+	return base64.StdEncoding.EncodeToString(id)
+}
+
+func (b *AzureFileBackend) RemoveFile(path string) error {
+	if _, err := b.blob(path).Delete(context.Background(), nil); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return errors.Wrapf(err, "unable to remove the file %s", path)
+	}
+	return nil
+}
+
+func (b *AzureFileBackend) ListDirectory(path string) ([]string, error) {
+	path = filepath.Join(b.pathPrefix, path)
+	if !strings.HasSuffix(path, "/") && path != "" {
+		// appending "/" to make it consistent across all filestores
+		path = path + "/"
+	}
+
+	var paths []string
+	addPath := func(name string) {
+		// We strip the path prefix that gets applied,
+		// so that it remains transparent to the application.
+		trimmed := strings.Trim(strings.TrimPrefix(name, b.pathPrefix), "/")
+		if trimmed != "" {
+			paths = append(paths, trimmed)
+		}
+	}
+
+	pager := b.client.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{Prefix: &path})
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+		}
+		for _, prefix := range page.Segment.BlobPrefixes {
+			addPath(*prefix.Name)
+		}
+		for _, item := range page.Segment.BlobItems {
+			addPath(*item.Name)
+		}
+	}
+
+	return paths, nil
+}
+
+func (b *AzureFileBackend) RemoveDirectory(path string) error {
+	prefix := filepath.Join(b.pathPrefix, path)
+	pager := b.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &prefix})
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return errors.Wrapf(err, "unable to remove the directory %s", path)
+		}
+		for _, item := range page.Segment.BlobItems {
+			_, err = b.client.NewBlobClient(*item.Name).Delete(context.Background(), nil)
+			if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+				return errors.Wrapf(err, "unable to remove the directory %s", path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// PresignedGetURL returns an url with a shared access signature, which allows downloading the file at given path until it expires.
+func (b *AzureFileBackend) PresignedGetURL(path string, expiry time.Duration) (string, error) {
+	return b.sasURL(path, sas.BlobPermissions{Read: true}, expiry)
+}
+
+// PresignedPutURL returns an url with a shared access signature, which allows uploading a file to given path until it expires.
+// Uploads must set "x-ms-blob-type: BlockBlob" header.
+func (b *AzureFileBackend) PresignedPutURL(path string, expiry time.Duration) (string, error) {
+	return b.sasURL(path, sas.BlobPermissions{Create: true, Write: true}, expiry)
+}
+
+func (b *AzureFileBackend) sasURL(path string, permissions sas.BlobPermissions, expiry time.Duration) (string, error) {
+	url, err := b.client.NewBlobClient(filepath.Join(b.pathPrefix, path)).GetSASURL(permissions, time.Now().Add(expiry), nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to sign url of the file %s", path)
+	}
+	return url, nil
+}
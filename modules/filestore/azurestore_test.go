@@ -0,0 +1,34 @@
+package filestore
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzurePresignedURL(t *testing.T) {
+	backend, err := NewAzureFileBackend(FileBackendSettings{
+		AzureStorageAccountName: "account",
+		AzureStorageAccountKey:  "a2V5",
+		AzureStorageContainer:   "sitename-test",
+		AzureStoragePathPrefix:  "prefix",
+	})
+	require.NoError(t, err)
+
+	signed, err := backend.PresignedGetURL("data/file.zip", time.Hour)
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, "account.blob.core.windows.net", parsed.Host)
+	require.Equal(t, "/sitename-test/prefix/data/file.zip", parsed.Path)
+	require.Equal(t, "r", parsed.Query().Get("sp"))
+	require.NotEmpty(t, parsed.Query().Get("sig"))
+
+	signed, err = backend.PresignedPutURL("data/file.zip", time.Hour)
+	require.NoError(t, err)
+	parsed, err = url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, "cw", parsed.Query().Get("sp"))
+}
@@ -10,6 +10,8 @@ import (
 const (
 	driverS3    = "amazons3"
 	driverLocal = "local"
+	driverGCS   = "googlecloudstorage"
+	driverAzure = "azureblob"
 )
 
 type ReadCloseSeeker interface {
@@ -33,11 +35,20 @@ type FileBackend interface {
 
 	ListDirectory(path string) ([]string, error)
 	RemoveDirectory(path string) error
+
+	// PresignedGetURL returns an url which allows downloading the file at given path without
+	// going through the api server, until expiry has elapsed.
+	PresignedGetURL(path string, expiry time.Duration) (string, error)
+	// PresignedPutURL returns an url which allows uploading a file to given path with a PUT request
+	// without going through the api server, until expiry has elapsed.
+	PresignedPutURL(path string, expiry time.Duration) (string, error)
 }
 
 type FileBackendSettings struct {
 	DriverName              string
 	Directory               string
+	LocalSigningKey         string // key used to sign presigned urls of the local backend
+	LocalSignedURLBase      string // base url of the app endpoint serving presigned urls of the local backend
 	AmazonS3AccessKeyId     string
 	AmazonS3SecretAccessKey string
 	AmazonS3Bucket          string
@@ -48,6 +59,17 @@ type FileBackendSettings struct {
 	AmazonS3SignV2          bool
 	AmazonS3SSE             bool
 	AmazonS3Trace           bool
+
+	GoogleCloudStorageBucket          string
+	GoogleCloudStoragePathPrefix      string
+	GoogleCloudStorageProjectID       string
+	GoogleCloudStorageCredentialsJSON string
+
+	AzureStorageAccountName string
+	AzureStorageAccountKey  string
+	AzureStorageContainer   string
+	AzureStoragePathPrefix  string
+	AzureStorageEndpoint    string
 }
 
 // CheckMandatoryS3Fields performs:
@@ -69,7 +91,7 @@ func (settings *FileBackendSettings) CheckMandatoryS3Fields() error {
 }
 
 // NewFileBackend creates new FileBackend with given settings.
-// Either aws3 or local will be created
+// Either aws3, google cloud storage, azure blob or local will be created
 func NewFileBackend(settings FileBackendSettings) (FileBackend, error) {
 	switch settings.DriverName {
 	case driverS3:
@@ -78,9 +100,23 @@ func NewFileBackend(settings FileBackendSettings) (FileBackend, error) {
 			return nil, errors.Wrap(err, "unable to connect to the s3 backend")
 		}
 		return backend, nil
+	case driverGCS:
+		backend, err := NewGCSFileBackend(settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to the google cloud storage backend")
+		}
+		return backend, nil
+	case driverAzure:
+		backend, err := NewAzureFileBackend(settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to the azure blob backend")
+		}
+		return backend, nil
 	case driverLocal:
 		return &LocalFileBackend{
-			directory: settings.Directory,
+			directory:     settings.Directory,
+			signingKey:    settings.LocalSigningKey,
+			signedURLBase: settings.LocalSignedURLBase,
 		}, nil
 	}
 	return nil, errors.New("no valid filestorage driver found")
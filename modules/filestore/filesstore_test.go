@@ -6,8 +6,10 @@ package filestore
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
+	"net"
 	"os"
 	"testing"
 	"time"
@@ -86,6 +88,63 @@ func runBackendTest(t *testing.T, encrypt bool) {
 	})
 }
 
+func TestGCSFileBackendTestSuite(t *testing.T) {
+	gcsHost := os.Getenv("CI_FAKE_GCS_HOST")
+	if gcsHost == "" {
+		gcsHost = "localhost"
+	}
+
+	gcsPort := os.Getenv("CI_FAKE_GCS_PORT")
+	if gcsPort == "" {
+		gcsPort = "4443"
+	}
+
+	gcsEndpoint := net.JoinHostPort(gcsHost, gcsPort)
+	conn, err := net.DialTimeout("tcp", gcsEndpoint, 2*time.Second)
+	if err != nil {
+		// the emulator is required on CI, where its host is set
+		if os.Getenv("CI_FAKE_GCS_HOST") == "" {
+			t.Skipf("fake-gcs-server is not reachable at %s: %v", gcsEndpoint, err)
+		}
+		require.NoError(t, err, "fake-gcs-server is not reachable")
+	}
+	conn.Close()
+
+	// fake-gcs-server must run with "-scheme http"
+	t.Setenv("STORAGE_EMULATOR_HOST", gcsEndpoint)
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:                  driverGCS,
+			GoogleCloudStorageBucket:    "sitename-test",
+			GoogleCloudStorageProjectID: "test",
+		},
+	})
+}
+
+func TestAzureFileBackendTestSuite(t *testing.T) {
+	azuriteHost := os.Getenv("CI_AZURITE_HOST")
+	if azuriteHost == "" {
+		azuriteHost = "localhost"
+	}
+
+	azuritePort := os.Getenv("CI_AZURITE_PORT")
+	if azuritePort == "" {
+		azuritePort = "10000"
+	}
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName: driverAzure,
+			// well known development account of Azurite
+			AzureStorageAccountName: "devstoreaccount1",
+			AzureStorageAccountKey:  "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==",
+			AzureStorageContainer:   "sitename-test",
+			AzureStorageEndpoint:    fmt.Sprintf("http://%s:%s/devstoreaccount1", azuriteHost, azuritePort),
+		},
+	})
+}
+
 func (s *FileBackendTestSuite) SetupTest() {
 	backend, err := NewFileBackend(s.settings)
 	require.NoError(s.T(), err)
@@ -93,10 +152,14 @@ func (s *FileBackendTestSuite) SetupTest() {
 
 	// This is needed to create the bucket if it doesn't exist.
 	err = s.backend.TestConnection()
-	if _, ok := err.(*S3FileBackendNoBucketError); ok {
-		s3Backend := s.backend.(*S3FileBackend)
-		s.NoError(s3Backend.MakeBucket())
-	} else {
+	switch err.(type) {
+	case *S3FileBackendNoBucketError:
+		s.NoError(s.backend.(*S3FileBackend).MakeBucket())
+	case *GCSFileBackendNoBucketError:
+		s.NoError(s.backend.(*GCSFileBackend).MakeBucket())
+	case *AzureFileBackendNoContainerError:
+		s.NoError(s.backend.(*AzureFileBackend).MakeContainer())
+	default:
 		s.NoError(err)
 	}
 }
@@ -137,6 +200,37 @@ func (s *FileBackendTestSuite) TestReadWriteFileImage() {
 	s.EqualValues(readString, "testimage")
 }
 
+func (s *FileBackendTestSuite) TestReader() {
+	b := []byte("0123456789")
+	path := "tests/" + randomString()
+
+	written, err := s.backend.WriteFile(bytes.NewReader(b), path)
+	s.Nil(err)
+	s.EqualValues(len(b), written, "expected given number of bytes to have been written")
+	defer s.backend.RemoveFile(path)
+
+	reader, err := s.backend.Reader(path)
+	s.Require().NoError(err)
+	defer reader.Close()
+
+	read, err := io.ReadAll(reader)
+	s.Nil(err)
+	s.Equal(b, read)
+
+	_, err = reader.Seek(4, io.SeekStart)
+	s.Nil(err)
+	read = make([]byte, 3)
+	_, err = io.ReadFull(reader, read)
+	s.Nil(err)
+	s.Equal("456", string(read))
+
+	_, err = reader.Seek(-2, io.SeekEnd)
+	s.Nil(err)
+	read, err = io.ReadAll(reader)
+	s.Nil(err)
+	s.Equal("89", string(read))
+}
+
 func (s *FileBackendTestSuite) TestFileExists() {
 	b := []byte("testimage")
 	path := "tests/" + randomString() + ".png"
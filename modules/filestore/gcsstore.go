@@ -0,0 +1,425 @@
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/sitename/sitename/modules/slog"
+)
+
+// GCSFileBackend contains all necessary information to communicate with
+// a Google Cloud Storage bucket.
+//
+// Set STORAGE_EMULATOR_HOST environment variable to use an emulator like fake-gcs-server.
+type GCSFileBackend struct {
+	bucket     string
+	pathPrefix string
+	projectID  string
+	// googleAccessID and privateKey sign urls when they are found in given credentials,
+	// otherwise the client detects them from the environment.
+	googleAccessID string
+	privateKey     []byte
+	client         *storage.Client
+	timeout        time.Duration
+}
+
+const (
+	// gcsMaxComposeComponents is the maximum number of components a composite object may have,
+	// appending to an object which reaches it flattens the object first.
+	gcsMaxComposeComponents = 1024
+	// gcsRequestTimeout bounds every request to GCS, the client otherwise retries
+	// an unreachable endpoint forever.
+	gcsRequestTimeout = 30 * time.Second
+)
+
+// GCSFileBackendNoBucketError is returned when testing a connection and no GCS bucket is found
+type GCSFileBackendNoBucketError struct{}
+
+func (s *GCSFileBackendNoBucketError) Error() string {
+	return "no such bucket"
+}
+
+// NewGCSFileBackend returns an instance of an GCSFileBackend.
+// Application default credentials are used when no credentials json is provided.
+func NewGCSFileBackend(settings FileBackendSettings) (*GCSFileBackend, error) {
+	backend := &GCSFileBackend{
+		bucket:     settings.GoogleCloudStorageBucket,
+		pathPrefix: settings.GoogleCloudStoragePathPrefix,
+		projectID:  settings.GoogleCloudStorageProjectID,
+		timeout:    gcsRequestTimeout,
+	}
+
+	var opts []option.ClientOption
+	if settings.GoogleCloudStorageCredentialsJSON != "" {
+		var credentials struct {
+			ClientEmail string `json:"client_email"`
+			PrivateKey  string `json:"private_key"`
+		}
+		if err := json.Unmarshal([]byte(settings.GoogleCloudStorageCredentialsJSON), &credentials); err != nil {
+			return nil, errors.Wrap(err, "unable to parse the google cloud storage credentials")
+		}
+		backend.googleAccessID = credentials.ClientEmail
+		backend.privateKey = []byte(credentials.PrivateKey)
+		opts = append(opts, option.WithCredentialsJSON([]byte(settings.GoogleCloudStorageCredentialsJSON)))
+	}
+
+	// The client keeps given context to refresh its credentials, so it must never be cancelled.
+	// Creating the client sends no request.
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	backend.client = client
+	return backend, nil
+}
+
+func (b *GCSFileBackend) object(path string) *storage.ObjectHandle {
+	return b.client.Bucket(b.bucket).Object(filepath.Join(b.pathPrefix, path))
+}
+
+// requestContext returns a context which cancels a request taking longer than the backend's timeout.
+func (b *GCSFileBackend) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), b.timeout)
+}
+
+func (b *GCSFileBackend) TestConnection() error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	// Like S3, objects under the path prefix are listed when a prefix is set, since
+	// the user might only be allowed access to the specified path prefix.
+	var err error
+	if b.pathPrefix != "" {
+		_, err = b.client.Bucket(b.bucket).Objects(ctx, &storage.Query{Prefix: b.pathPrefix}).Next()
+		if err == iterator.Done {
+			err = nil
+		}
+	} else {
+		_, err = b.client.Bucket(b.bucket).Attrs(ctx)
+	}
+
+	if errors.Is(err, storage.ErrBucketNotExist) {
+		return &GCSFileBackendNoBucketError{}
+	}
+	if err != nil {
+		return errors.Wrap(err, "unable to check if the GCS bucket exists")
+	}
+	slog.Debug("Connection to GCS is good. Bucket exists.")
+	return nil
+}
+
+func (b *GCSFileBackend) MakeBucket() error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	if err := b.client.Bucket(b.bucket).Create(ctx, b.projectID, nil); err != nil {
+		return errors.Wrap(err, "unable to create the gcs bucket")
+	}
+	return nil
+}
+
+// Caller must close the first return value
+func (b *GCSFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	obj := b.object(path)
+	attrs, err := b.attrs(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	return &rangeReadSeeker{
+		size: attrs.Size,
+		open: func(offset int64) (io.ReadCloser, error) {
+			ctx, cancel := b.requestContext()
+			reader, err := obj.NewRangeReader(ctx, offset, -1)
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			return &gcsReader{Reader: reader, cancel: cancel}, nil
+		},
+	}, nil
+}
+
+// gcsReader releases the context of its request once it is closed.
+type gcsReader struct {
+	*storage.Reader
+	cancel context.CancelFunc
+}
+
+func (r *gcsReader) Close() error {
+	defer r.cancel()
+	return r.Reader.Close()
+}
+
+func (b *GCSFileBackend) attrs(obj *storage.ObjectHandle) (*storage.ObjectAttrs, error) {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+	return obj.Attrs(ctx)
+}
+
+func (b *GCSFileBackend) ReadFile(path string) ([]byte, error) {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	reader, err := b.object(path).NewReader(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	defer reader.Close()
+	f, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return f, nil
+}
+
+func (b *GCSFileBackend) FileExists(path string) (bool, error) {
+	_, err := b.attrs(b.object(path))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	return false, errors.Wrapf(err, "unable to know if file %s exists", path)
+}
+
+func (b *GCSFileBackend) FileSize(path string) (int64, error) {
+	attrs, err := b.attrs(b.object(path))
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	return attrs.Size, nil
+}
+
+func (b *GCSFileBackend) FileModTime(path string) (time.Time, error) {
+	attrs, err := b.attrs(b.object(path))
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+	return attrs.Updated, nil
+}
+
+func (b *GCSFileBackend) CopyFile(oldPath, newPath string) error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	if _, err := b.object(newPath).CopierFrom(b.object(oldPath)).Run(ctx); err != nil {
+		return errors.Wrapf(err, "unable to copy file from %s to %s", oldPath, newPath)
+	}
+	return nil
+}
+
+func (b *GCSFileBackend) MoveFile(oldPath, newPath string) error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	if _, err := b.object(newPath).CopierFrom(b.object(oldPath)).Run(ctx); err != nil {
+		return errors.Wrapf(err, "unable to copy the file to %s to the new destionation", newPath)
+	}
+
+	if err := b.object(oldPath).Delete(ctx); err != nil {
+		return errors.Wrapf(err, "unable to remove the file old file %s", oldPath)
+	}
+
+	return nil
+}
+
+// writeObject streams given data into given object and returns the attributes of the written object.
+// The upload has no deadline since callers may stream for long, but each uploaded chunk gives up
+// retrying after the backend's timeout.
+func (b *GCSFileBackend) writeObject(fr io.Reader, obj *storage.ObjectHandle, contentType string) (int64, *storage.ObjectAttrs, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	writer := obj.NewWriter(ctx)
+	writer.ContentType = contentType
+	writer.ChunkRetryDeadline = b.timeout
+
+	written, err := io.Copy(writer, fr)
+	if err != nil {
+		// cancelling the context aborts the upload, so a partial object is never written
+		cancel()
+		writer.Close()
+		return written, nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return written, nil, err
+	}
+	return written, writer.Attrs(), nil
+}
+
+func (b *GCSFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	written, _, err := b.writeObject(fr, b.object(path), getContentType(path))
+	if err != nil {
+		return written, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+	return written, nil
+}
+
+// AppendFile writes given data into a part object, then composes the file with it.
+// The file is composed only if nobody changed it in the meantime, so concurrent appends
+// to the same file fail instead of dropping each other's data.
+func (b *GCSFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	obj := b.object(path)
+	attrs, err := b.attrs(obj)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	// composing the object with the part adds one more component
+	if attrs.ComponentCount >= gcsMaxComposeComponents-1 {
+		if attrs, err = b.flattenObject(obj, attrs); err != nil {
+			return 0, errors.Wrapf(err, "unable to flatten the file %s to append the data", path)
+		}
+	}
+
+	part := b.object(path + "." + uuid.NewString() + ".part")
+	written, _, err := b.writeObject(fr, part, attrs.ContentType)
+	defer b.removeObject(part)
+	if err != nil || written == 0 {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	composer := obj.If(storage.Conditions{GenerationMatch: attrs.Generation}).ComposerFrom(obj.Generation(attrs.Generation), part)
+	composer.ContentType = attrs.ContentType
+	if _, err = composer.Run(ctx); err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+	return written, nil
+}
+
+// flattenObject rewrites given composite object into a non composite one and returns its new attributes,
+// it is overwritten only if nobody changed it in the meantime.
+func (b *GCSFileBackend) flattenObject(obj *storage.ObjectHandle, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	reader, err := obj.Generation(attrs.Generation).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	_, flattened, err := b.writeObject(reader, obj.If(storage.Conditions{GenerationMatch: attrs.Generation}), attrs.ContentType)
+	return flattened, err
+}
+
+func (b *GCSFileBackend) RemoveFile(path string) error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	if err := b.object(path).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return errors.Wrapf(err, "unable to remove the file %s", path)
+	}
+	return nil
+}
+
+// directoryPrefix returns the prefix of objects in given directory.
+func (b *GCSFileBackend) directoryPrefix(path string) string {
+	path = filepath.Join(b.pathPrefix, path)
+	if !strings.HasSuffix(path, "/") && path != "" {
+		// appending "/" to make it consistent across all filestores
+		path = path + "/"
+	}
+	return path
+}
+
+func (b *GCSFileBackend) ListDirectory(path string) ([]string, error) {
+	path = b.directoryPrefix(path)
+
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	var paths []string
+	objects := b.client.Bucket(b.bucket).Objects(ctx, &storage.Query{Prefix: path, Delimiter: "/"})
+	for {
+		attrs, err := objects.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+		}
+
+		// sub directories are returned as prefixes.
+		name := attrs.Name
+		if name == "" {
+			name = attrs.Prefix
+		}
+		// We strip the path prefix that gets applied,
+		// so that it remains transparent to the application.
+		trimmed := strings.Trim(strings.TrimPrefix(name, b.pathPrefix), "/")
+		if trimmed != "" {
+			paths = append(paths, trimmed)
+		}
+	}
+
+	return paths, nil
+}
+
+func (b *GCSFileBackend) RemoveDirectory(path string) error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+
+	bucket := b.client.Bucket(b.bucket)
+	objects := bucket.Objects(ctx, &storage.Query{Prefix: b.directoryPrefix(path)})
+	for {
+		attrs, err := objects.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err == nil {
+			err = b.removeObject(bucket.Object(attrs.Name))
+		}
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return errors.Wrapf(err, "unable to remove the directory %s", path)
+		}
+	}
+}
+
+func (b *GCSFileBackend) removeObject(obj *storage.ObjectHandle) error {
+	ctx, cancel := b.requestContext()
+	defer cancel()
+	return obj.Delete(ctx)
+}
+
+// PresignedGetURL returns a v4 signed url, which allows downloading the file at given path until it expires.
+func (b *GCSFileBackend) PresignedGetURL(path string, expiry time.Duration) (string, error) {
+	return b.signedURL(path, http.MethodGet, expiry)
+}
+
+// PresignedPutURL returns a v4 signed url, which allows uploading a file to given path until it expires.
+func (b *GCSFileBackend) PresignedPutURL(path string, expiry time.Duration) (string, error) {
+	return b.signedURL(path, http.MethodPut, expiry)
+}
+
+func (b *GCSFileBackend) signedURL(path, method string, expiry time.Duration) (string, error) {
+	path = filepath.Join(b.pathPrefix, path)
+	url, err := b.client.Bucket(b.bucket).SignedURL(path, &storage.SignedURLOptions{
+		GoogleAccessID: b.googleAccessID,
+		PrivateKey:     b.privateKey,
+		Method:         method,
+		Expires:        time.Now().Add(expiry),
+		Scheme:         storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to sign url of the file %s", path)
+	}
+	return url, nil
+}
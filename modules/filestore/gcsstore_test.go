@@ -0,0 +1,58 @@
+package filestore
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCSPresignedURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "sitename@test.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":    "https://oauth2.googleapis.com/token",
+	})
+	require.NoError(t, err)
+
+	backend, err := NewGCSFileBackend(FileBackendSettings{
+		GoogleCloudStorageBucket:          "sitename-test",
+		GoogleCloudStoragePathPrefix:      "prefix",
+		GoogleCloudStorageCredentialsJSON: string(credentials),
+	})
+	require.NoError(t, err)
+
+	for method, sign := range map[string]func(string, time.Duration) (string, error){
+		"GET": backend.PresignedGetURL,
+		"PUT": backend.PresignedPutURL,
+	} {
+		signed, err := sign("data/file.zip", time.Hour)
+		require.NoError(t, err, method)
+
+		parsed, err := url.Parse(signed)
+		require.NoError(t, err)
+		require.Equal(t, "/sitename-test/prefix/data/file.zip", parsed.Path)
+		require.NotEmpty(t, parsed.Query().Get("X-Goog-Expires"))
+		require.NotEmpty(t, parsed.Query().Get("X-Goog-Signature"))
+		require.Contains(t, parsed.Query().Get("X-Goog-Credential"), "sitename@test.iam.gserviceaccount.com")
+	}
+}
+
+func TestGCSDirectoryPrefix(t *testing.T) {
+	backend := &GCSFileBackend{pathPrefix: "prefix"}
+	require.Equal(t, "prefix/exports/", backend.directoryPrefix("exports"))
+	require.Equal(t, "prefix/exports/", backend.directoryPrefix("exports/"))
+	require.Equal(t, "prefix/", backend.directoryPrefix(""))
+
+	// the whole bucket is listed without a path prefix
+	require.Equal(t, "", (&GCSFileBackend{}).directoryPrefix(""))
+}
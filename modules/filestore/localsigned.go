@@ -0,0 +1,83 @@
+package filestore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Query parameters of urls signed by the local backend.
+const (
+	LocalSignedURLExpiresParam   = "expires"
+	LocalSignedURLSignatureParam = "signature"
+)
+
+var (
+	ErrLocalSignedURLExpired   = errors.New("signed url has expired")
+	ErrLocalSignedURLSignature = errors.New("signed url has an invalid signature")
+)
+
+// PresignedGetURL returns an url of the app endpoint serving local files, signed with a HMAC that
+// allows downloading the file at given path until it expires.
+func (b *LocalFileBackend) PresignedGetURL(path string, expiry time.Duration) (string, error) {
+	return b.signedURL(http.MethodGet, path, expiry)
+}
+
+// PresignedPutURL returns an url of the app endpoint serving local files, signed with a HMAC that
+// allows uploading a file to given path until it expires.
+func (b *LocalFileBackend) PresignedPutURL(path string, expiry time.Duration) (string, error) {
+	return b.signedURL(http.MethodPut, path, expiry)
+}
+
+func (b *LocalFileBackend) signedURL(method, path string, expiry time.Duration) (string, error) {
+	if b.signingKey == "" || b.signedURLBase == "" {
+		return "", errors.New("signing key and signed url base are required to presign local file urls")
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+
+	query := url.Values{}
+	query.Set(LocalSignedURLExpiresParam, expires)
+	query.Set(LocalSignedURLSignatureParam, localURLSignature(b.signingKey, method, path, expires))
+
+	return strings.TrimSuffix(b.signedURLBase, "/") + "/" + (&url.URL{Path: path}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// VerifyLocalSignedURL checks that given query of a request to the local files endpoint carries a valid
+// signature for method and path, and that it has not expired yet.
+func VerifyLocalSignedURL(signingKey, method, path string, query url.Values) error {
+	if signingKey == "" {
+		return errors.New("signing key is required to verify local signed urls")
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	expires := query.Get(LocalSignedURLExpiresParam)
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrLocalSignedURLSignature
+	}
+
+	expected := localURLSignature(signingKey, method, path, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get(LocalSignedURLSignatureParam))) {
+		return ErrLocalSignedURLSignature
+	}
+	if time.Now().Unix() > expiresAt {
+		return ErrLocalSignedURLExpired
+	}
+
+	return nil
+}
+
+func localURLSignature(signingKey, method, path, expires string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(method + "\n" + path + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
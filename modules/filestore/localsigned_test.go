@@ -0,0 +1,42 @@
+package filestore
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalPresignedURL(t *testing.T) {
+	backend, err := NewFileBackend(FileBackendSettings{
+		DriverName:         driverLocal,
+		Directory:          t.TempDir(),
+		LocalSigningKey:    "signingkey",
+		LocalSignedURLBase: "http://localhost:8000/files/signed/",
+	})
+	require.NoError(t, err)
+
+	signed, err := backend.PresignedPutURL("/videos/big file.mp4", time.Hour)
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, "/files/signed/videos/big file.mp4", parsed.Path)
+
+	path := strings.TrimPrefix(parsed.Path, "/files/signed/")
+	require.NoError(t, VerifyLocalSignedURL("signingkey", http.MethodPut, path, parsed.Query()))
+	require.Equal(t, ErrLocalSignedURLSignature, VerifyLocalSignedURL("signingkey", http.MethodGet, path, parsed.Query()))
+	require.Equal(t, ErrLocalSignedURLSignature, VerifyLocalSignedURL("otherkey", http.MethodPut, path, parsed.Query()))
+	require.Equal(t, ErrLocalSignedURLSignature, VerifyLocalSignedURL("signingkey", http.MethodPut, "videos/other.mp4", parsed.Query()))
+
+	signed, err = backend.PresignedGetURL("videos/big file.mp4", -time.Minute)
+	require.NoError(t, err)
+	parsed, err = url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, ErrLocalSignedURLExpired, VerifyLocalSignedURL("signingkey", http.MethodGet, path, parsed.Query()))
+
+	_, err = (&LocalFileBackend{directory: t.TempDir()}).PresignedGetURL("file", time.Hour)
+	require.Error(t, err)
+}
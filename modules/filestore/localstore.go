@@ -18,7 +18,9 @@ const (
 )
 
 type LocalFileBackend struct {
-	directory string
+	directory     string
+	signingKey    string
+	signedURLBase string
 }
 
 // copyFile will copy a file from src path to dst path.
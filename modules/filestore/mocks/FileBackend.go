@@ -154,6 +154,48 @@ func (_m *FileBackend) MoveFile(oldPath string, newPath string) error {
 	return r0
 }
 
+// PresignedGetURL provides a mock function with given fields: path, expiry
+func (_m *FileBackend) PresignedGetURL(path string, expiry time.Duration) (string, error) {
+	ret := _m.Called(path, expiry)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Duration) string); ok {
+		r0 = rf(path, expiry)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(path, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PresignedPutURL provides a mock function with given fields: path, expiry
+func (_m *FileBackend) PresignedPutURL(path string, expiry time.Duration) (string, error) {
+	ret := _m.Called(path, expiry)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Duration) string); ok {
+		r0 = rf(path, expiry)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(path, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadFile provides a mock function with given fields: path
 func (_m *FileBackend) ReadFile(path string) ([]byte, error) {
 	ret := _m.Called(path)
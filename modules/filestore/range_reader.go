@@ -0,0 +1,64 @@
+package filestore
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// rangeReadSeeker makes objects of cloud backends seekable. It opens a ranged read
+// from the current offset on the first Read after every Seek.
+type rangeReadSeeker struct {
+	size   int64
+	offset int64
+	open   func(offset int64) (io.ReadCloser, error)
+	reader io.ReadCloser
+}
+
+func (r *rangeReadSeeker) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.reader == nil {
+		reader, err := r.open(r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+	}
+
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *rangeReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != r.offset && r.reader != nil {
+		r.reader.Close()
+		r.reader = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *rangeReadSeeker) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
@@ -63,6 +63,14 @@ func getImageMimeType(ext string) string {
 	return imageMimeTypes[ext]
 }
 
+// getContentType returns content type of files stored in cloud backends
+func getContentType(path string) string {
+	if ext := filepath.Ext(path); isFileExtImage(ext) {
+		return getImageMimeType(ext)
+	}
+	return "binary/octet-stream"
+}
+
 func (s *S3FileBackendAuthError) Error() string {
 	return s.DetailedError
 }
@@ -425,6 +433,28 @@ func (b *S3FileBackend) RemoveDirectory(path string) error {
 	return nil
 }
 
+// PresignedGetURL returns a presigned url, which allows downloading the object at given path until it expires.
+func (b *S3FileBackend) PresignedGetURL(path string, expiry time.Duration) (string, error) {
+	path = filepath.Join(b.pathPrefix, path)
+	u, err := b.client.PresignedGetObject(context.Background(), b.bucket, path, expiry, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to presign download url for %s", path)
+	}
+
+	return u.String(), nil
+}
+
+// PresignedPutURL returns a presigned url, which allows uploading an object to given path until it expires.
+func (b *S3FileBackend) PresignedPutURL(path string, expiry time.Duration) (string, error) {
+	path = filepath.Join(b.pathPrefix, path)
+	u, err := b.client.PresignedPutObject(context.Background(), b.bucket, path, expiry)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to presign upload url for %s", path)
+	}
+
+	return u.String(), nil
+}
+
 func s3PutOptions(encrypted bool, contentType string) s3.PutObjectOptions {
 	options := s3.PutObjectOptions{}
 	if encrypted {
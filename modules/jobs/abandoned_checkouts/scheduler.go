@@ -0,0 +1,15 @@
+package abandoned_checkouts
+
+import (
+	"time"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const schedFreq = 1 * time.Hour
+
+func MakeScheduler(jobServer *jobs.JobServer) model_helper.Scheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeAbandonedCheckouts, schedFreq, isEnabled)
+}
@@ -0,0 +1,24 @@
+package abandoned_checkouts
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const (
+	JobName = "AbandonedCheckouts"
+)
+
+func isEnabled(cfg *model_helper.Config) bool {
+	return *cfg.ShopSettings.EnableAbandonedCheckoutEmails || *cfg.ShopSettings.AnonymousCheckoutRetentionDays > 0
+}
+
+// MakeWorker returns a worker that sends recovery emails for abandoned checkouts,
+// tracks their conversions and purges old anonymous checkouts.
+func MakeWorker(jobServer *jobs.JobServer, processAbandonedCheckouts func() error) model_helper.Worker {
+	execute := func(job model.Job) error {
+		return processAbandonedCheckouts()
+	}
+	return jobs.NewSimpleWorker(JobName, jobServer, execute, isEnabled)
+}
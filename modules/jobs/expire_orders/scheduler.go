@@ -0,0 +1,15 @@
+package expire_orders
+
+import (
+	"time"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const schedFreq = 5 * time.Minute
+
+func MakeScheduler(jobServer *jobs.JobServer) model_helper.Scheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeExpireOrders, schedFreq, isEnabled)
+}
@@ -0,0 +1,24 @@
+package expire_orders
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const (
+	JobName = "ExpireOrders"
+)
+
+// orders expire per channel settings, channels without them are skipped by the job itself
+func isEnabled(cfg *model_helper.Config) bool {
+	return true
+}
+
+// MakeWorker returns a worker that expires unpaid orders and deletes old expired orders
+func MakeWorker(jobServer *jobs.JobServer, expireOrders func() error) model_helper.Worker {
+	execute := func(job model.Job) error {
+		return expireOrders()
+	}
+	return jobs.NewSimpleWorker(JobName, jobServer, execute, isEnabled)
+}
@@ -0,0 +1,24 @@
+package export_process
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const (
+	JobName = "ExportProcess"
+)
+
+// export jobs are created on demand, when staff users or apps request data exports
+func isEnabled(cfg *model_helper.Config) bool {
+	return true
+}
+
+// MakeWorker returns a worker that runs the export described by data of each job
+func MakeWorker(jobServer *jobs.JobServer, processExport func(job model.Job) error) model_helper.Worker {
+	execute := func(job model.Job) error {
+		return processExport(job)
+	}
+	return jobs.NewSimpleWorker(JobName, jobServer, execute, isEnabled)
+}
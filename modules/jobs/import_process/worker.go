@@ -0,0 +1,24 @@
+package import_process
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const (
+	JobName = "ImportProcess"
+)
+
+// import jobs are created on demand, when staff users upload files to import
+func isEnabled(cfg *model_helper.Config) bool {
+	return true
+}
+
+// MakeWorker returns a worker that runs the import described by data of each job
+func MakeWorker(jobServer *jobs.JobServer, processImport func(job model.Job) error) model_helper.Worker {
+	execute := func(job model.Job) error {
+		return processImport(job)
+	}
+	return jobs.NewSimpleWorker(JobName, jobServer, execute, isEnabled)
+}
@@ -0,0 +1,15 @@
+package product_recommendations
+
+import (
+	"time"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const schedFreq = 24 * time.Hour
+
+func MakeScheduler(jobServer *jobs.JobServer) model_helper.Scheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeProductRecommendations, schedFreq, isEnabled)
+}
@@ -0,0 +1,24 @@
+package product_recommendations
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+)
+
+const (
+	JobName = "ProductRecommendations"
+)
+
+func isEnabled(cfg *model_helper.Config) bool {
+	return *cfg.ShopSettings.EnableProductRecommendations
+}
+
+// MakeWorker returns a worker that recomputes co-purchase and similarity
+// recommendations of products in every channel.
+func MakeWorker(jobServer *jobs.JobServer, computeProductRecommendations func() error) model_helper.Worker {
+	execute := func(job model.Job) error {
+		return computeProductRecommendations()
+	}
+	return jobs.NewSimpleWorker(JobName, jobServer, execute, isEnabled)
+}
@@ -0,0 +1,70 @@
+package measurement
+
+import (
+	"errors"
+)
+
+var (
+	ErrUnknownUnit        = errors.New("unknown measurement unit")
+	ErrIncompatibleUnits  = errors.New("measurement units are of different kinds")
+	unitsInStandardAmount = map[string]struct {
+		kind   string
+		amount float64 // amount of the standard unit of the kind (m, sq_m, cubic_meter, kg) in one unit
+	}{
+		string(CM):   {"distance", 0.01},
+		string(M):    {"distance", 1},
+		string(KM):   {"distance", 1000},
+		string(FT):   {"distance", 0.3048},
+		string(YD):   {"distance", 0.9144},
+		string(INCH): {"distance", 0.0254},
+
+		SQ_CM:   {"area", 0.0001},
+		SQ_M:    {"area", 1},
+		SQ_KM:   {"area", 1000000},
+		SQ_FT:   {"area", 0.09290304},
+		SQ_YD:   {"area", 0.83612736},
+		SQ_INCH: {"area", 0.00064516},
+
+		CUBIC_MILLIMETER: {"volume", 0.000000001},
+		CUBIC_CENTIMETER: {"volume", 0.000001},
+		CUBIC_DECIMETER:  {"volume", 0.001},
+		CUBIC_METER:      {"volume", 1},
+		LITER:            {"volume", 0.001},
+		CUBIC_FOOT:       {"volume", 0.028316846592},
+		CUBIC_INCH:       {"volume", 0.000016387064},
+		CUBIC_YARD:       {"volume", 0.764554857984},
+		QT:               {"volume", 0.000946352946},
+		PINT:             {"volume", 0.000473176473},
+		FL_OZ:            {"volume", 0.0000295735295625},
+		ACRE_IN:          {"volume", 102.79015312896},
+		ACRE_FT:          {"volume", 1233.48183754752},
+
+		string(G):     {"weight", 0.001},
+		string(KG):    {"weight", 1},
+		string(LB):    {"weight", 0.45359237},
+		string(OZ):    {"weight", 0.028349523125},
+		string(TONNE): {"weight", 1000},
+	}
+)
+
+// ConvertAmount converts given amount of a unit into the amount of another unit of the same kind,
+// e.g from "cm" to "inch" or from "liter" to "fl_oz". Units must be keys of MeasurementUnitMap.
+func ConvertAmount(amount float64, from, to string) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	fromUnit, ok := unitsInStandardAmount[from]
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+	toUnit, ok := unitsInStandardAmount[to]
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+	if fromUnit.kind != toUnit.kind {
+		return 0, ErrIncompatibleUnits
+	}
+
+	return amount * fromUnit.amount / toUnit.amount, nil
+}
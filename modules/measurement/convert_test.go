@@ -0,0 +1,27 @@
+package measurement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmount(t *testing.T) {
+	amount, err := ConvertAmount(254, string(CM), string(INCH))
+	require.NoError(t, err)
+	require.InDelta(t, 100, amount, 1e-9)
+
+	amount, err = ConvertAmount(2, LITER, CUBIC_CENTIMETER)
+	require.NoError(t, err)
+	require.InDelta(t, 2000, amount, 1e-9)
+
+	amount, err = ConvertAmount(3.5, string(KG), string(KG))
+	require.NoError(t, err)
+	require.Equal(t, 3.5, amount)
+
+	_, err = ConvertAmount(1, string(KG), string(M))
+	require.ErrorIs(t, err, ErrIncompatibleUnits)
+
+	_, err = ConvertAmount(1, "parsec", string(M))
+	require.ErrorIs(t, err, ErrUnknownUnit)
+}
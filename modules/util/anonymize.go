@@ -26,3 +26,20 @@ func ObfuscateString(value string, isPhone bool) string {
 	}
 	return value[:cutoff] + strings.Repeat(".", strLen-cutoff)
 }
+
+// AnonymizedEmailDomain is the domain of emails replacing erased ones. The .invalid TLD is reserved so mails are never delivered
+const AnonymizedEmailDomain = "anonymized.invalid"
+
+// AnonymizeEmail returns an undeliverable email unique to given id, to replace an erased email
+//
+// E.g:
+//
+//	AnonymizeEmail("abc") == "deleted-abc@anonymized.invalid"
+func AnonymizeEmail(id string) string {
+	return "deleted-" + id + "@" + AnonymizedEmailDomain
+}
+
+// IsAnonymizedEmail checks if given email was produced by AnonymizeEmail
+func IsAnonymizedEmail(email string) bool {
+	return strings.HasPrefix(email, "deleted-") && strings.HasSuffix(email, "@"+AnonymizedEmailDomain)
+}
@@ -1,15 +1,47 @@
 package util
 
 import (
+	"html"
 	"regexp"
-	// "strings"
+	"strings"
 )
 
 var (
 	BLACKLISTED_URL_SCHEMES        = []string{"javascript"}
 	HYPERLINK_TAG_WITH_URL_PATTERN = regexp.MustCompile(`(.*?<a\s+href=\\?\")(\w+://\S+[^\\])(\\?\">)`)
+	HTML_TAG_PATTERN               = regexp.MustCompile(`<[^>]*>`)
 )
 
+// EditorJSPlainText returns texts of all blocks of given EditorJS definitions joined by spaces,
+// with inline html tags stripped. Texts of list items are included.
+func EditorJSPlainText(definitions map[string]any) string {
+	blocks, _ := definitions["blocks"].([]any)
+
+	var texts []string
+	for _, block := range blocks {
+		blockMap, _ := block.(map[string]any)
+		data, _ := blockMap["data"].(map[string]any)
+
+		if text, ok := data["text"].(string); ok {
+			texts = append(texts, text)
+		}
+		items, _ := data["items"].([]any)
+		for _, item := range items {
+			switch value := item.(type) {
+			case string:
+				texts = append(texts, value)
+			case map[string]any: // nested lists keep text in "content"
+				if content, ok := value["content"].(string); ok {
+					texts = append(texts, content)
+				}
+			}
+		}
+	}
+
+	text := HTML_TAG_PATTERN.ReplaceAllString(strings.Join(texts, " "), "")
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
 // Sanitize a given EditorJS JSON definitions.
 //
 // Look for not allowed URLs, replaced them with `invalid` value, and clean valid ones.
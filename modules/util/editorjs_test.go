@@ -0,0 +1,20 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditorJSPlainText(t *testing.T) {
+	definitions := map[string]any{
+		"blocks": []any{
+			map[string]any{"type": "header", "data": map[string]any{"text": "Juice &amp; more"}},
+			map[string]any{"type": "paragraph", "data": map[string]any{"text": "Made of <b>fresh</b> apples"}},
+			map[string]any{"type": "list", "data": map[string]any{"items": []any{"No sugar", map[string]any{"content": "1 liter"}}}},
+		},
+	}
+
+	require.Equal(t, "Juice & more Made of fresh apples No sugar 1 liter", EditorJSPlainText(definitions))
+	require.Equal(t, "", EditorJSPlainText(nil))
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/sitename/sitename/model_helper"
+	"github.com/tinylib/msgp/msgp"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 // ErrKeyNotFound is the error when the given key is not found
@@ -49,3 +51,48 @@ type Cache interface {
 	// Name returns the name of the cache
 	Name() string
 }
+
+// encode serializes given value into msgpack, the format every cache stores values in
+func encode(value any) ([]byte, error) {
+	// We use a fast path for hot structs.
+	if msgpVal, ok := value.(msgp.Marshaler); ok {
+		return msgpVal.MarshalMsg(nil)
+	}
+
+	// Slow path for other structs.
+	return msgpack.Marshal(value)
+}
+
+// decode deserializes given msgpack data into value, which must be a pointer
+func decode(val []byte, value any) error {
+	// We use a fast path for hot structs.
+	if msgpVal, ok := value.(msgp.Unmarshaler); ok {
+		_, err := msgpVal.UnmarshalMsg(val)
+		return err
+	}
+
+	// This is ugly and makes the cache package aware of the model package.
+	// But this is due to 2 things.
+	// 1. The msgp package works on methods on structs rather than functions.
+	// 2. Our cache interface passes pointers to empty pointers, and not pointers
+	// to values. This is mainly how all our model structs are passed around.
+	// It might be technically possible to use values _just_ for hot structs
+	// like these and then return a pointer while returning from the cache function,
+	// but it will make the codebase inconsistent, and has some edge-cases to take care of.
+
+	// switch v := value.(type) {
+	// case **model.User:
+	// 	var u model.User
+	// 	_, err := u.UnmarshalMsg(val)
+	// 	*v = &u
+	// 	return err
+	// case *map[string]*model.User:
+	// 	var u model.UserMap
+	// 	_, err := u.UnmarshalMsg(val)
+	// 	*v = u
+	// 	return err
+	// }
+
+	// Slow path for other structs.
+	return msgpack.Unmarshal(val, value)
+}
@@ -6,8 +6,6 @@ import (
 	"time"
 
 	"github.com/sitename/sitename/model_helper"
-	"github.com/tinylib/msgp/msgp"
-	"github.com/vmihailenco/msgpack/v5"
 	// "github.com/sitename/sitename/model/account"
 )
 
@@ -140,15 +138,7 @@ func (l *LRU) set(key string, value any, ttl time.Duration) error {
 		expires = time.Now().Add(ttl)
 	}
 
-	var buf []byte
-	var err error
-	// We use a fast path for hot structs.
-	if msgpVal, ok := value.(msgp.Marshaler); ok {
-		buf, err = msgpVal.MarshalMsg(nil)
-	} else {
-		// Slow path for other structs.
-		buf, err = msgpack.Marshal(value)
-	}
+	buf, err := encode(value)
 	if err != nil {
 		return err
 	}
@@ -187,36 +177,7 @@ func (l *LRU) get(key string, value any) error {
 		return err
 	}
 
-	// We use a fast path for hot structs.
-	if msgpVal, ok := value.(msgp.Unmarshaler); ok {
-		_, err := msgpVal.UnmarshalMsg(val)
-		return err
-	}
-
-	// This is ugly and makes the cache package aware of the model package.
-	// But this is due to 2 things.
-	// 1. The msgp package works on methods on structs rather than functions.
-	// 2. Our cache interface passes pointers to empty pointers, and not pointers
-	// to values. This is mainly how all our model structs are passed around.
-	// It might be technically possible to use values _just_ for hot structs
-	// like these and then return a pointer while returning from the cache function,
-	// but it will make the codebase inconsistent, and has some edge-cases to take care of.
-
-	// switch v := value.(type) {
-	// case **model.User:
-	// 	var u model.User
-	// 	_, err := u.UnmarshalMsg(val)
-	// 	*v = &u
-	// 	return err
-	// case *map[string]*model.User:
-	// 	var u model.UserMap
-	// 	_, err := u.UnmarshalMsg(val)
-	// 	*v = u
-	// 	return err
-	// }
-
-	// Slow path for other structs.
-	return msgpack.Unmarshal(val, value)
+	return decode(val, value)
 }
 
 func (l *LRU) getItem(key string) ([]byte, error) {
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sitename/sitename/model_helper"
+)
+
+// redisScanCount is the number of keys asked from every SCAN call when listing or purging caches
+const redisScanCount = 1000
+
+// RedisOptions contains options for connecting to a redis server
+type RedisOptions struct {
+	Address  string
+	Password string
+	DB       int
+	// KeyPrefix is prepended to keys of every cache, so several sites can share one server
+	KeyPrefix string
+}
+
+type redisProvider struct {
+	opts   RedisOptions
+	client *redis.Client
+}
+
+// NewRedisProvider creates a new CacheProvider whose caches are stored in a redis server,
+// so they are shared by every node of a cluster. Connect must be called before creating caches.
+func NewRedisProvider(opts RedisOptions) Provider {
+	return &redisProvider{opts: opts}
+}
+
+// NewCache creates a new cache with given opts. Caches are told apart by their names, so a name is required.
+// Size and striping options are ignored since the redis server manages its memory itself.
+func (r *redisProvider) NewCache(opts *CacheOptions) (Cache, error) {
+	if r.client == nil {
+		return nil, errors.New("redis cache provider is not connected")
+	}
+	if opts.Name == "" {
+		return nil, errors.New("redis caches must have a name")
+	}
+
+	return &Redis{
+		name:                   opts.Name,
+		prefix:                 r.opts.KeyPrefix + opts.Name + ":",
+		client:                 r.client,
+		defaultExpiry:          opts.DefaultExpiry,
+		invalidateClusterEvent: opts.InvalidateClusterEvent,
+	}, nil
+}
+
+// Connect opens a new connection to the redis server and checks it is reachable.
+func (r *redisProvider) Connect() error {
+	client := redis.NewClient(&redis.Options{
+		Addr:     r.opts.Address,
+		Password: r.opts.Password,
+		DB:       r.opts.DB,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return err
+	}
+
+	r.client = client
+	return nil
+}
+
+// Close releases the connection to the redis server.
+func (r *redisProvider) Close() error {
+	if r.client == nil {
+		return nil
+	}
+	return r.client.Close()
+}
+
+// Redis is a cache stored in a redis server. Values are encoded with msgpack like LRU does,
+// and keys are prefixed with the cache name so caches sharing a server never collide.
+type Redis struct {
+	name                   string
+	prefix                 string
+	client                 *redis.Client
+	defaultExpiry          time.Duration
+	invalidateClusterEvent model_helper.ClusterEvent
+}
+
+// Purge is used to completely clear the cache. Keys are deleted in batches without blocking the server.
+func (r *Redis) Purge() error {
+	return r.scan(func(keys []string) error {
+		return r.client.Unlink(context.Background(), keys...).Err()
+	})
+}
+
+// Set adds the given key and value to the store without an expiry. If the key already exists,
+// it will overwrite the previous value.
+func (r *Redis) Set(key string, value any) error {
+	return r.SetWithExpiry(key, value, 0)
+}
+
+// SetWithDefaultExpiry adds the given key and value to the store with the default expiry. If
+// the key already exists, it will overwrite the previoous value
+func (r *Redis) SetWithDefaultExpiry(key string, value any) error {
+	return r.SetWithExpiry(key, value, r.defaultExpiry)
+}
+
+// SetWithExpiry adds the given key and value to the cache with the given expiry. If the key
+// already exists, it will overwrite the previoous value
+func (r *Redis) SetWithExpiry(key string, value any, ttl time.Duration) error {
+	buf, err := encode(value)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(context.Background(), r.prefix+key, buf, ttl).Err()
+}
+
+// Get the content stored in the cache for the given key, and decode it into the value interface.
+// return ErrKeyNotFound if the key is missing from the cache
+func (r *Redis) Get(key string, value any) error {
+	val, err := r.client.Get(context.Background(), r.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrKeyNotFound
+		}
+		return err
+	}
+	return decode(val, value)
+}
+
+// Remove deletes the value for a key.
+func (r *Redis) Remove(key string) error {
+	return r.client.Del(context.Background(), r.prefix+key).Err()
+}
+
+// Keys returns a slice of the keys in the cache.
+func (r *Redis) Keys() ([]string, error) {
+	var keys []string
+	err := r.scan(func(batch []string) error {
+		for _, key := range batch {
+			keys = append(keys, key[len(r.prefix):])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Len returns the number of items in the cache.
+func (r *Redis) Len() (int, error) {
+	var count int
+	err := r.scan(func(keys []string) error {
+		count += len(keys)
+		return nil
+	})
+	return count, err
+}
+
+// GetInvalidateClusterEvent returns the cluster event configured when this cache was created.
+func (r *Redis) GetInvalidateClusterEvent() model_helper.ClusterEvent {
+	return r.invalidateClusterEvent
+}
+
+// Name returns the name of the cache
+func (r *Redis) Name() string {
+	return r.name
+}
+
+// scan calls fn with every batch of keys of the cache, as stored in the server
+func (r *Redis) scan(fn func(keys []string) error) error {
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(context.Background(), cursor, r.prefix+"*", redisScanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisProvider(t *testing.T) (*miniredis.Miniredis, Provider) {
+	server := miniredis.RunT(t)
+
+	p := NewRedisProvider(RedisOptions{Address: server.Addr(), KeyPrefix: "test:"})
+	require.NoError(t, p.Connect())
+	t.Cleanup(func() { p.Close() })
+
+	return server, p
+}
+
+func TestRedisNewCache(t *testing.T) {
+	t.Run("not connected", func(t *testing.T) {
+		_, err := NewRedisProvider(RedisOptions{}).NewCache(&CacheOptions{Name: "name"})
+		require.Error(t, err)
+	})
+
+	t.Run("without name", func(t *testing.T) {
+		_, p := newTestRedisProvider(t)
+		_, err := p.NewCache(&CacheOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("with all options specified", func(t *testing.T) {
+		_, p := newTestRedisProvider(t)
+		event := model_helper.ClusterEvent("clusterEvent")
+		c, err := p.NewCache(&CacheOptions{
+			Name:                   "name",
+			DefaultExpiry:          time.Minute,
+			InvalidateClusterEvent: event,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "name", c.Name())
+		require.Equal(t, event, c.GetInvalidateClusterEvent())
+	})
+}
+
+func TestRedisGetSet(t *testing.T) {
+	server, p := newTestRedisProvider(t)
+	c, err := p.NewCache(&CacheOptions{Name: "users"})
+	require.NoError(t, err)
+
+	var v string
+	require.Equal(t, ErrKeyNotFound, c.Get("missing", &v))
+
+	require.NoError(t, c.Set("key", "value"))
+	require.NoError(t, c.Get("key", &v))
+	require.Equal(t, "value", v)
+	require.True(t, server.Exists("test:users:key"), "keys are prefixed with the cache name")
+
+	type item struct {
+		ID    string
+		Count int
+	}
+	require.NoError(t, c.Set("item", &item{ID: "a", Count: 2}))
+	var got *item
+	require.NoError(t, c.Get("item", &got))
+	require.Equal(t, &item{ID: "a", Count: 2}, got)
+
+	require.NoError(t, c.Remove("key"))
+	require.Equal(t, ErrKeyNotFound, c.Get("key", &v))
+}
+
+func TestRedisExpiry(t *testing.T) {
+	server, p := newTestRedisProvider(t)
+	c, err := p.NewCache(&CacheOptions{Name: "name", DefaultExpiry: time.Minute})
+	require.NoError(t, err)
+
+	require.NoError(t, c.SetWithDefaultExpiry("default", 1))
+	require.NoError(t, c.SetWithExpiry("short", 1, time.Second))
+	require.NoError(t, c.Set("forever", 1))
+
+	server.FastForward(2 * time.Second)
+	var v int
+	require.Equal(t, ErrKeyNotFound, c.Get("short", &v))
+	require.NoError(t, c.Get("default", &v))
+
+	server.FastForward(time.Minute)
+	require.Equal(t, ErrKeyNotFound, c.Get("default", &v))
+	require.NoError(t, c.Get("forever", &v))
+}
+
+func TestRedisKeysAndPurge(t *testing.T) {
+	_, p := newTestRedisProvider(t)
+	roles, err := p.NewCache(&CacheOptions{Name: "roles"})
+	require.NoError(t, err)
+	users, err := p.NewCache(&CacheOptions{Name: "users"})
+	require.NoError(t, err)
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, roles.Set(key, key))
+	}
+	require.NoError(t, users.Set("a", "a"))
+
+	keys, err := roles.Keys()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"a", "b", "c"}, keys)
+	l, err := roles.Len()
+	require.NoError(t, err)
+	require.Equal(t, 3, l)
+
+	require.NoError(t, roles.Purge())
+	l, err = roles.Len()
+	require.NoError(t, err)
+	require.Zero(t, l)
+
+	var v string
+	require.NoError(t, users.Get("a", &v), "purging a cache keeps other caches")
+}
@@ -25,6 +25,7 @@ const (
 	FileIndex    = "files"
 	UserIndex    = "users"
 	ChannelIndex = "channels"
+	ProductIndex = "products"
 )
 
 type BleveEngine struct {
@@ -32,17 +33,19 @@ type BleveEngine struct {
 	// FileIndex    bleve.Index
 	// ChannelIndex bleve.Index
 
-	UserIndex bleve.Index
-	Mutex     sync.RWMutex
-	ready     int32
-	cfg       *model_helper.Config
-	jobServer *jobs.JobServer
-	indexSync bool
+	UserIndex    bleve.Index
+	ProductIndex bleve.Index
+	Mutex        sync.RWMutex
+	ready        int32
+	cfg          *model_helper.Config
+	jobServer    *jobs.JobServer
+	indexSync    bool
 }
 
 var keywordMapping *mapping.FieldMapping
 var standardMapping *mapping.FieldMapping
 var dateMapping *mapping.FieldMapping
+var numericMapping *mapping.FieldMapping
 
 func init() {
 	keywordMapping = bleve.NewTextFieldMapping()
@@ -52,6 +55,8 @@ func init() {
 	standardMapping.Analyzer = standard.Name
 
 	dateMapping = bleve.NewNumericFieldMapping()
+
+	numericMapping = bleve.NewNumericFieldMapping()
 }
 
 // func getChannelIndexMapping() *mapping.IndexMappingImpl {
@@ -115,6 +120,40 @@ func getUserIndexMapping() *mapping.IndexMappingImpl {
 	return indexMapping
 }
 
+func getProductIndexMapping() *mapping.IndexMappingImpl {
+	productMapping := bleve.NewDocumentMapping()
+	productMapping.AddFieldMappingsAt("Id", keywordMapping)
+	productMapping.AddFieldMappingsAt("Name", standardMapping)
+	productMapping.AddFieldMappingsAt("SortName", keywordMapping)
+	productMapping.AddFieldMappingsAt("Description", standardMapping)
+	productMapping.AddFieldMappingsAt("Skus", keywordMapping)
+	productMapping.AddFieldMappingsAt("VariantNames", standardMapping)
+	productMapping.AddFieldMappingsAt("AttributeValues", standardMapping)
+	productMapping.AddFieldMappingsAt("Attributes", keywordMapping)
+	productMapping.AddFieldMappingsAt("CategoryId", keywordMapping)
+	productMapping.AddFieldMappingsAt("CategoryName", standardMapping)
+	productMapping.AddFieldMappingsAt("CollectionIds", keywordMapping)
+	productMapping.AddFieldMappingsAt("CollectionNames", standardMapping)
+	productMapping.AddFieldMappingsAt("Channels", keywordMapping)
+	productMapping.AddFieldMappingsAt("CreateAt", dateMapping)
+	productMapping.AddFieldMappingsAt("Rating", numericMapping)
+
+	// Prices, dates and Stock are keyed by channel ids, their fields are mapped dynamically
+	pricesMapping := bleve.NewDocumentMapping()
+	productMapping.AddSubDocumentMapping("Prices", pricesMapping)
+	productMapping.AddSubDocumentMapping("PublishedAt", bleve.NewDocumentMapping())
+	productMapping.AddSubDocumentMapping("AvailableAt", bleve.NewDocumentMapping())
+
+	stockMapping := bleve.NewDocumentMapping()
+	stockMapping.DefaultAnalyzer = keyword.Name
+	productMapping.AddSubDocumentMapping("Stock", stockMapping)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.AddDocumentMapping("_default", productMapping)
+
+	return indexMapping
+}
+
 func NewBleveEngine(cfg *model_helper.Config, jobServer *jobs.JobServer) *BleveEngine {
 	return &BleveEngine{
 		cfg:       cfg,
@@ -160,6 +199,11 @@ func (b *BleveEngine) openIndexes() *model_helper.AppError {
 		return model_helper.NewAppError("Bleveengine.Start", "bleveengine.create_user_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	b.ProductIndex, err = b.createOrOpenIndex(ProductIndex, getProductIndexMapping())
+	if err != nil {
+		return model_helper.NewAppError("Bleveengine.Start", "bleveengine.create_product_index.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	// b.ChannelIndex, err = b.createOrOpenIndex(ChannelIndex, getChannelIndexMapping())
 	// if err != nil {
 	// 	return model_helper.NewAppError("Bleveengine.Start", "bleveengine.create_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
@@ -196,6 +240,10 @@ func (b *BleveEngine) closeIndexes() *model_helper.AppError {
 			return model_helper.NewAppError("Bleveengine.Stop", "bleveengine.stop_user_index.error", nil, err.Error(), http.StatusInternalServerError)
 		}
 
+		if err := b.ProductIndex.Close(); err != nil {
+			return model_helper.NewAppError("Bleveengine.Stop", "bleveengine.stop_product_index.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
 		// if err := b.ChannelIndex.Close(); err != nil {
 		// 	return model_helper.NewAppError("Bleveengine.Stop", "bleveengine.stop_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 		// }
@@ -259,6 +307,9 @@ func (b *BleveEngine) deleteIndexes() *model_helper.AppError {
 	if err := os.RemoveAll(b.getIndexDir(FileIndex)); err != nil {
 		return model_helper.NewAppError("Bleveengine.PurgeIndexes", "bleveengine.purge_file_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
+	if err := os.RemoveAll(b.getIndexDir(ProductIndex)); err != nil {
+		return model_helper.NewAppError("Bleveengine.PurgeIndexes", "bleveengine.purge_product_index.error", nil, err.Error(), http.StatusInternalServerError)
+	}
 	return nil
 }
 
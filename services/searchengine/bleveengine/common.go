@@ -22,6 +22,28 @@ type BLVUser struct {
 	ChannelsIds                []string
 }
 
+type BLVProduct struct {
+	Id              string
+	Name            string
+	SortName        string
+	Description     string
+	Skus            []string
+	VariantNames    []string
+	AttributeValues []string
+	Attributes      []string // in form of attribute slug:value slug
+	CategoryId      string
+	CategoryName    string
+	CollectionIds   []string
+	CollectionNames []string
+	Channels        []string           // ids of channels the product is published and visible in
+	PublishedAt     map[string]int64   // channel id => publication date, searches skip products published later
+	AvailableAt     map[string]int64   // channel id => date the product is available for purchase from
+	Prices          map[string]float64 // channel id => minimal price
+	Stock           map[string]string  // channel id => stock availability, regardless of the purchase date
+	CreateAt        int64
+	Rating          float64
+}
+
 type BLVPost struct {
 	Id          string
 	TeamId      string
@@ -104,6 +126,61 @@ func BLVUserFromUserForIndexing(userForIndexing *model_helper.UserForIndexing) *
 
 }
 
+func BLVProductFromProductForIndexing(product *model_helper.ProductForIndexing) *BLVProduct {
+	blvProduct := &BLVProduct{
+		Id:           product.Id,
+		Name:         product.Name,
+		SortName:     strings.ToLower(product.Name),
+		Description:  product.Description,
+		Skus:         product.Skus,
+		VariantNames: product.VariantNames,
+		CategoryId:   product.CategoryID,
+		CategoryName: product.CategoryName,
+		Channels:     []string{},
+		PublishedAt:  map[string]int64{},
+		AvailableAt:  map[string]int64{},
+		Prices:       map[string]float64{},
+		Stock:        map[string]string{},
+		CreateAt:     product.CreatedAt,
+		Rating:       product.Rating,
+	}
+
+	for _, attribute := range product.Attributes {
+		blvProduct.Attributes = append(blvProduct.Attributes, productAttributeTerm(attribute.AttributeSlug, attribute.ValueSlug))
+		blvProduct.AttributeValues = append(blvProduct.AttributeValues, attribute.ValueName)
+	}
+	for _, collection := range product.Collections {
+		blvProduct.CollectionIds = append(blvProduct.CollectionIds, collection.Id)
+		blvProduct.CollectionNames = append(blvProduct.CollectionNames, collection.Name)
+	}
+
+	for _, listing := range product.ChannelListings {
+		if !listing.IsPublished || !listing.VisibleInListings {
+			continue
+		}
+		blvProduct.Channels = append(blvProduct.Channels, listing.ChannelID)
+		blvProduct.PublishedAt[listing.ChannelID] = listing.PublishedAt
+		if listing.AvailableForPurchaseAt != nil {
+			blvProduct.AvailableAt[listing.ChannelID] = *listing.AvailableForPurchaseAt
+		}
+		if listing.MinimalPrice != nil {
+			blvProduct.Prices[listing.ChannelID] = *listing.MinimalPrice
+		}
+
+		stock := model_helper.StockAvailabilityOutOfStock
+		if listing.InStock {
+			stock = model_helper.StockAvailabilityInStock
+		}
+		blvProduct.Stock[listing.ChannelID] = string(stock)
+	}
+
+	return blvProduct
+}
+
+func productAttributeTerm(attributeSlug, valueSlug string) string {
+	return attributeSlug + ":" + valueSlug
+}
+
 // func BLVPostFromPost(post *model.Post, teamId string) *BLVPost {
 // 	p := &model.PostForIndexing{
 // 		TeamId: teamId,
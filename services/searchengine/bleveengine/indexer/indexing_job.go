@@ -21,6 +21,7 @@ const (
 	EstimatedFilesCount   = 100000
 	EstimatedChannelCount = 100000
 	EstimatedUserCount    = 10000
+	EstimatedProductCount = 100000
 )
 
 type BleveIndexerWorker struct {
@@ -60,6 +61,10 @@ type IndexingProgress struct {
 	DoneUsersCount  int64
 	DoneUsers       bool
 
+	TotalProductsCount int64
+	DoneProductsCount  int64
+	DoneProducts       bool
+
 	// TotalPostsCount    int64
 	// DonePostsCount     int64
 	// DonePosts          bool
@@ -73,12 +78,14 @@ type IndexingProgress struct {
 
 func (ip *IndexingProgress) CurrentProgress() int64 {
 	// return (ip.DonePostsCount + ip.DoneChannelsCount + ip.DoneUsersCount + ip.DoneFilesCount) * 100 / (ip.TotalPostsCount + ip.TotalChannelsCount + ip.TotalUsersCount + ip.TotalFilesCount)
-	return (ip.DoneUsersCount) * 100 / ip.TotalUsersCount
+	progress := (ip.DoneUsersCount + ip.DoneProductsCount) * 100 / (ip.TotalUsersCount + ip.TotalProductsCount)
+	// the products total is an estimation
+	return min(progress, 100)
 }
 
 func (ip *IndexingProgress) IsDone() bool {
 	// return ip.DonePosts && ip.DoneChannels && ip.DoneUsers && ip.DoneFiles
-	return ip.DoneUsers
+	return ip.DoneUsers && ip.DoneProducts
 }
 
 func (worker *BleveIndexerWorker) JobChannel() chan<- model.Job {
@@ -139,7 +146,8 @@ func (worker *BleveIndexerWorker) DoJob(job model.Job) {
 		Now: time.Now(),
 		// DonePosts:    false,
 		// DoneChannels: false,
-		DoneUsers: false,
+		DoneUsers:    false,
+		DoneProducts: false,
 		// DoneFiles:    false,
 		StartAtTime: 0,
 		EndAtTime:   model_helper.GetMillis(),
@@ -216,6 +224,9 @@ func (worker *BleveIndexerWorker) DoJob(job model.Job) {
 		progress.TotalUsersCount = count
 	}
 
+	// There is no cheap way to count products yet, the progress % reporting is based on an estimation.
+	progress.TotalProductsCount = EstimatedProductCount
+
 	// Counting all files may fail or timeout when the file_info table is large. If this happens, log a warning, but carry
 	// on with the indexing job anyway. The only issue is that the progress % reporting will be inaccurate.
 	// if count, err := worker.jobServer.Store.FileInfo().CountAll(); err != nil {
@@ -289,6 +300,9 @@ func (worker *BleveIndexerWorker) IndexBatch(progress IndexingProgress) (Indexin
 	if !progress.DoneUsers {
 		return worker.IndexUsersBatch(progress)
 	}
+	if !progress.DoneProducts {
+		return worker.IndexProductsBatch(progress)
+	}
 	// if !progress.DoneFiles {
 	// 	return worker.IndexFilesBatch(progress)
 	// }
@@ -606,3 +620,71 @@ func (worker *BleveIndexerWorker) BulkIndexUsers(users []*model_helper.UserForIn
 	}
 	return lastCreateAt, nil
 }
+
+func (worker *BleveIndexerWorker) IndexProductsBatch(progress IndexingProgress) (IndexingProgress, *model_helper.AppError) {
+	endTime := progress.LastEntityTime + int64(*worker.jobServer.Config().BleveSettings.BulkIndexingTimeWindowSeconds*1000)
+
+	var products []*model_helper.ProductForIndexing
+
+	tries := 0
+	for products == nil {
+		if productsBatch, err := worker.jobServer.Store.Product().GetProductsBatchForIndexing(progress.LastEntityTime, endTime, BatchSize); err != nil {
+			if tries >= 10 {
+				return progress, model_helper.NewAppError("IndexProductsBatch", "app.product.get_products_batch_for_indexing.get_products.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+			slog.Warn("Failed to get products batch for indexing. Retrying.", slog.Err(err))
+
+			// Wait a bit before trying again.
+			time.Sleep(15 * time.Second)
+		} else {
+			products = productsBatch
+		}
+
+		tries++
+	}
+
+	newLastProductTime, err := worker.BulkIndexProducts(products, progress)
+	if err != nil {
+		return progress, err
+	}
+
+	// Same as users, an incomplete batch means there is nothing left before endTime.
+	if len(products) < BatchSize {
+		newLastProductTime = endTime
+	}
+
+	if progress.EndAtTime <= newLastProductTime {
+		progress.DoneProducts = true
+		progress.LastEntityTime = progress.StartAtTime
+	} else if progress.LastEntityTime == newLastProductTime && len(products) == BatchSize {
+		slog.Warn("More products with the same CreateAt time were detected than the permitted batch size. Aborting indexing job.", slog.Int64("CreateAt", newLastProductTime), slog.Int("Batch Size", BatchSize))
+		progress.DoneProducts = true
+		progress.LastEntityTime = progress.StartAtTime
+	} else {
+		progress.LastEntityTime = newLastProductTime
+	}
+
+	progress.DoneProductsCount += int64(len(products))
+
+	return progress, nil
+}
+
+func (worker *BleveIndexerWorker) BulkIndexProducts(products []*model_helper.ProductForIndexing, progress IndexingProgress) (int64, *model_helper.AppError) {
+	lastCreateAt := int64(0)
+	batch := worker.engine.ProductIndex.NewBatch()
+
+	for _, product := range products {
+		searchProduct := bleveengine.BLVProductFromProductForIndexing(product)
+		batch.Index(searchProduct.Id, searchProduct)
+
+		lastCreateAt = product.CreatedAt
+	}
+
+	worker.engine.Mutex.RLock()
+	defer worker.engine.Mutex.RUnlock()
+
+	if err := worker.engine.ProductIndex.Batch(batch); err != nil {
+		return 0, model_helper.NewAppError("BleveIndexerWorker.BulkIndexProducts", "bleveengine.indexer.do_job.bulk_index_products.batch_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return lastCreateAt, nil
+}
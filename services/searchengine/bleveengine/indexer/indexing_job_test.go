@@ -0,0 +1,115 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/jobs"
+	"github.com/sitename/sitename/modules/util/testutils"
+	"github.com/sitename/sitename/services/searchengine/bleveengine"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWorker(t *testing.T, windowSeconds int) (*BleveIndexerWorker, *mocks.ProductStore) {
+	cfg := &model_helper.Config{}
+	cfg.SetDefaults()
+	cfg.BleveSettings.EnableIndexing = model_helper.GetPointerOfValue(true)
+	cfg.BleveSettings.IndexDir = model_helper.GetPointerOfValue(t.TempDir())
+	cfg.BleveSettings.BulkIndexingTimeWindowSeconds = model_helper.GetPointerOfValue(windowSeconds)
+
+	engine := bleveengine.NewBleveEngine(cfg, nil)
+	require.Nil(t, engine.Start())
+	t.Cleanup(func() { engine.Stop() })
+
+	productStore := &mocks.ProductStore{}
+	mockStore := &mocks.Store{}
+	mockStore.On("Product").Return(productStore)
+
+	jobServer := &jobs.JobServer{ConfigService: testutils.StaticConfigService{Cfg: cfg}, Store: mockStore}
+	return MakeWorker(jobServer, engine).(*BleveIndexerWorker), productStore
+}
+
+func newTestProductForIndexing(channelID string, createdAt int64) *model_helper.ProductForIndexing {
+	return &model_helper.ProductForIndexing{
+		Id:        model_helper.NewId(),
+		Name:      "Product " + model_helper.NewId(),
+		CreatedAt: createdAt,
+		ChannelListings: []*model_helper.ProductChannelListingForIndexing{
+			{ChannelID: channelID, IsPublished: true, VisibleInListings: true},
+		},
+	}
+}
+
+func TestIndexProductsBatch(t *testing.T) {
+	channelID := model_helper.NewId()
+
+	t.Run("last batch", func(t *testing.T) {
+		worker, productStore := newTestWorker(t, 3600)
+		products := []*model_helper.ProductForIndexing{
+			newTestProductForIndexing(channelID, 100),
+			newTestProductForIndexing(channelID, 200),
+		}
+		productStore.On("GetProductsBatchForIndexing", int64(0), int64(3600*1000), BatchSize).Return(products, nil).Once()
+
+		progress, appErr := worker.IndexProductsBatch(IndexingProgress{StartAtTime: 0, EndAtTime: 10000, DoneUsers: true})
+		require.Nil(t, appErr)
+		require.True(t, progress.DoneProducts)
+		require.EqualValues(t, 2, progress.DoneProductsCount)
+		require.Zero(t, progress.LastEntityTime)
+		require.True(t, progress.IsDone())
+
+		result, appErr := worker.engine.SearchProducts(&model_helper.ProductSearchParams{ChannelID: channelID})
+		require.Nil(t, appErr)
+		require.ElementsMatch(t, []string{products[0].Id, products[1].Id}, result.ProductIDs)
+		productStore.AssertExpectations(t)
+	})
+
+	t.Run("moves on to the next time window", func(t *testing.T) {
+		worker, productStore := newTestWorker(t, 1)
+		productStore.On("GetProductsBatchForIndexing", int64(0), int64(1000), BatchSize).
+			Return([]*model_helper.ProductForIndexing{newTestProductForIndexing(channelID, 100)}, nil).Once()
+		productStore.On("GetProductsBatchForIndexing", int64(1000), int64(2000), BatchSize).
+			Return([]*model_helper.ProductForIndexing{}, nil).Once()
+
+		progress, appErr := worker.IndexProductsBatch(IndexingProgress{StartAtTime: 0, EndAtTime: 5000})
+		require.Nil(t, appErr)
+		require.False(t, progress.DoneProducts)
+		require.EqualValues(t, 1000, progress.LastEntityTime)
+		require.EqualValues(t, 1, progress.DoneProductsCount)
+
+		progress, appErr = worker.IndexProductsBatch(progress)
+		require.Nil(t, appErr)
+		require.False(t, progress.DoneProducts)
+		require.EqualValues(t, 2000, progress.LastEntityTime)
+		require.EqualValues(t, 1, progress.DoneProductsCount)
+		productStore.AssertExpectations(t)
+	})
+
+	t.Run("products are indexed once users are done", func(t *testing.T) {
+		worker, productStore := newTestWorker(t, 3600)
+		productStore.On("GetProductsBatchForIndexing", mock.Anything, mock.Anything, mock.Anything).Return([]*model_helper.ProductForIndexing{}, nil)
+
+		progress, appErr := worker.IndexBatch(IndexingProgress{EndAtTime: 10000, DoneUsers: true})
+		require.Nil(t, appErr)
+		require.True(t, progress.DoneProducts)
+		productStore.AssertNumberOfCalls(t, "GetProductsBatchForIndexing", 1)
+	})
+}
+
+func TestIndexingProgress(t *testing.T) {
+	progress := IndexingProgress{
+		DoneUsers:          true,
+		TotalProductsCount: EstimatedProductCount,
+		DoneProductsCount:  EstimatedProductCount / 2,
+	}
+	require.False(t, progress.IsDone())
+	require.EqualValues(t, 50, progress.CurrentProgress())
+
+	// the products total is estimated, progress never goes over 100%
+	progress.DoneProductsCount = EstimatedProductCount * 2
+	progress.DoneProducts = true
+	require.True(t, progress.IsDone())
+	require.EqualValues(t, 100, progress.CurrentProgress())
+}
@@ -0,0 +1,312 @@
+package bleveengine
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blevesearch/bleve"
+	"github.com/blevesearch/bleve/search"
+	"github.com/blevesearch/bleve/search/query"
+
+	"github.com/sitename/sitename/model_helper"
+)
+
+const (
+	DefaultProductSearchPerPage = 20
+	productAttributeFacetSize   = 200
+
+	productAttributesFacet = "attributes"
+	productPriceFacet      = "price"
+)
+
+// productPriceFacetBoundaries are boundaries of price ranges products are counted in
+var productPriceFacetBoundaries = []float64{10, 25, 50, 100, 250, 500, 1000}
+
+// productTextFieldBoosts are text fields searched by terms, along with their boosts
+var productTextFieldBoosts = []struct {
+	field string
+	boost float64
+}{
+	{"Name", 4},
+	{"VariantNames", 2},
+	{"AttributeValues", 2},
+	{"CategoryName", 1.5},
+	{"CollectionNames", 1.5},
+	{"Description", 1},
+}
+
+func (b *BleveEngine) IndexProduct(product *model_helper.ProductForIndexing) *model_helper.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvProduct := BLVProductFromProductForIndexing(product)
+	if err := b.ProductIndex.Index(blvProduct.Id, blvProduct); err != nil {
+		return model_helper.NewAppError("Bleveengine.IndexProduct", "bleveengine.index_product.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) DeleteProduct(productID string) *model_helper.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	if err := b.ProductIndex.Delete(productID); err != nil {
+		return model_helper.NewAppError("Bleveengine.DeleteProduct", "bleveengine.delete_product.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) SearchProducts(params *model_helper.ProductSearchParams) (*model_helper.ProductSearchResult, *model_helper.AppError) {
+	perPage := params.PerPage
+	if perPage <= 0 {
+		perPage = DefaultProductSearchPerPage
+	}
+	page := max(params.Page, 0)
+
+	now := model_helper.GetMillis()
+	searchQuery := buildProductSearchQuery(params, now)
+
+	searchRequest := bleve.NewSearchRequestOptions(searchQuery, perPage, page*perPage, false)
+	searchRequest.SortBy(productSearchSortOrder(params))
+
+	searchRequest.AddFacet(productAttributesFacet, bleve.NewFacetRequest("Attributes", productAttributeFacetSize))
+
+	priceFacet := bleve.NewFacetRequest(productPriceField(params.ChannelID), len(productPriceFacetBoundaries)+1)
+	for i := 0; i <= len(productPriceFacetBoundaries); i++ {
+		var lower, upper *float64
+		if i > 0 {
+			lower = &productPriceFacetBoundaries[i-1]
+		}
+		if i < len(productPriceFacetBoundaries) {
+			upper = &productPriceFacetBoundaries[i]
+		}
+		priceFacet.AddNumericRange(strconv.Itoa(i), lower, upper)
+	}
+	searchRequest.AddFacet(productPriceFacet, priceFacet)
+
+	// availability depends on the purchase date, so it is counted by another search instead of a terms facet
+	inStockRequest := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(searchQuery, productAvailableQuery(params.ChannelID, now)), 0, 0, false)
+
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	results, err := b.ProductIndex.Search(searchRequest)
+	if err != nil {
+		return nil, model_helper.NewAppError("Bleveengine.SearchProducts", "bleveengine.search_products.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	inStockResults, err := b.ProductIndex.Search(inStockRequest)
+	if err != nil {
+		return nil, model_helper.NewAppError("Bleveengine.SearchProducts", "bleveengine.search_products.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	res := &model_helper.ProductSearchResult{
+		ProductIDs:      make([]string, 0, len(results.Hits)),
+		TotalCount:      results.Total,
+		AttributeFacets: map[string][]*model_helper.ProductSearchTermFacet{},
+		PriceFacets:     []*model_helper.ProductSearchPriceFacet{},
+		AvailabilityFacet: map[model_helper.StockAvailability]int{
+			model_helper.StockAvailabilityInStock:    int(inStockResults.Total),
+			model_helper.StockAvailabilityOutOfStock: int(results.Total - inStockResults.Total),
+		},
+	}
+	for _, hit := range results.Hits {
+		res.ProductIDs = append(res.ProductIDs, hit.ID)
+	}
+
+	if facet, ok := results.Facets[productAttributesFacet]; ok {
+		for _, term := range facet.Terms {
+			attributeSlug, valueSlug, found := strings.Cut(term.Term, ":")
+			if !found {
+				continue
+			}
+			res.AttributeFacets[attributeSlug] = append(res.AttributeFacets[attributeSlug], &model_helper.ProductSearchTermFacet{Term: valueSlug, Count: term.Count})
+		}
+	}
+	if facet, ok := results.Facets[productPriceFacet]; ok {
+		res.PriceFacets = productPriceFacets(facet)
+	}
+
+	return res, nil
+}
+
+// buildProductSearchQuery returns a query matching terms of given params,
+// restricted to products published in the channel by now and satisfying the filters
+func buildProductSearchQuery(params *model_helper.ProductSearchParams, now int64) query.Query {
+	channelQ := bleve.NewTermQuery(params.ChannelID)
+	channelQ.SetField("Channels")
+
+	boolQ := bleve.NewBooleanQuery()
+	boolQ.AddMust(channelQ, numericAtMostQuery(productPublishedAtField(params.ChannelID), now))
+
+	if terms := strings.TrimSpace(params.Terms); terms != "" {
+		boolQ.AddMust(buildProductTermsQuery(terms))
+	}
+
+	// values of an attribute are ORed, attributes are ANDed
+	for attributeSlug, valueSlugs := range params.Attributes {
+		if len(valueSlugs) == 0 {
+			continue
+		}
+		valueQueries := make([]query.Query, 0, len(valueSlugs))
+		for _, valueSlug := range valueSlugs {
+			valueQ := bleve.NewTermQuery(productAttributeTerm(attributeSlug, valueSlug))
+			valueQ.SetField("Attributes")
+			valueQueries = append(valueQueries, valueQ)
+		}
+		boolQ.AddMust(bleve.NewDisjunctionQuery(valueQueries...))
+	}
+
+	if len(params.CategoryIDs) > 0 {
+		boolQ.AddMust(termsQuery("CategoryId", params.CategoryIDs))
+	}
+	if len(params.CollectionIDs) > 0 {
+		boolQ.AddMust(termsQuery("CollectionIds", params.CollectionIDs))
+	}
+
+	if params.StockAvailability != nil {
+		if *params.StockAvailability == model_helper.StockAvailabilityInStock {
+			boolQ.AddMust(productAvailableQuery(params.ChannelID, now))
+		} else {
+			boolQ.AddMustNot(productAvailableQuery(params.ChannelID, now))
+		}
+	}
+
+	if params.Price != nil && (params.Price.Gte != nil || params.Price.Lte != nil) {
+		inclusive := true
+		priceQ := bleve.NewNumericRangeInclusiveQuery(params.Price.Gte, params.Price.Lte, &inclusive, &inclusive)
+		priceQ.SetField(productPriceField(params.ChannelID))
+		boolQ.AddMust(priceQ)
+	}
+
+	return boolQ
+}
+
+// buildProductTermsQuery returns a query requiring every word of given terms to match
+// one of product text fields. Words are matched with typo tolerance depending on their lengths.
+// Products having a sku equal to the terms are matched too.
+func buildProductTermsQuery(terms string) query.Query {
+	words := strings.Fields(strings.ToLower(terms))
+	wordQueries := make([]query.Query, 0, len(words))
+
+	for _, word := range words {
+		fieldQueries := make([]query.Query, 0, len(productTextFieldBoosts)+1)
+		for _, item := range productTextFieldBoosts {
+			matchQ := bleve.NewMatchQuery(word)
+			matchQ.SetField(item.field)
+			matchQ.SetFuzziness(productTermFuzziness(word))
+			matchQ.SetBoost(item.boost)
+			fieldQueries = append(fieldQueries, matchQ)
+		}
+
+		// allows finding products while their names are being typed
+		prefixQ := bleve.NewPrefixQuery(word)
+		prefixQ.SetField("Name")
+		prefixQ.SetBoost(2)
+		fieldQueries = append(fieldQueries, prefixQ)
+
+		wordQueries = append(wordQueries, bleve.NewDisjunctionQuery(fieldQueries...))
+	}
+
+	skuQ := bleve.NewTermQuery(terms)
+	skuQ.SetField("Skus")
+	skuQ.SetBoost(10)
+
+	return bleve.NewDisjunctionQuery(bleve.NewConjunctionQuery(wordQueries...), skuQ)
+}
+
+// productTermFuzziness returns the number of typos tolerated for given word
+func productTermFuzziness(word string) int {
+	switch length := len([]rune(word)); {
+	case length <= 3:
+		return 0
+	case length <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func productSearchSortOrder(params *model_helper.ProductSearchParams) []string {
+	var field string
+	switch params.SortBy {
+	case model_helper.ProductSearchSortName:
+		field = "SortName"
+	case model_helper.ProductSearchSortPrice:
+		field = productPriceField(params.ChannelID)
+	case model_helper.ProductSearchSortCreatedAt:
+		field = "CreateAt"
+	case model_helper.ProductSearchSortRating:
+		field = "Rating"
+	default:
+		return []string{"-_score", "_id"}
+	}
+
+	if params.Direction == model_helper.DESC {
+		field = "-" + field
+	}
+	return []string{field, "-_score", "_id"}
+}
+
+func productPriceFacets(facet *search.FacetResult) []*model_helper.ProductSearchPriceFacet {
+	res := make([]*model_helper.ProductSearchPriceFacet, 0, len(facet.NumericRanges))
+	for _, numericRange := range facet.NumericRanges {
+		res = append(res, &model_helper.ProductSearchPriceFacet{
+			Min:   numericRange.Min,
+			Max:   numericRange.Max,
+			Count: numericRange.Count,
+		})
+	}
+
+	// bleve orders ranges by counts, we keep them ordered by prices
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Min == nil || res[j].Min == nil {
+			return res[i].Min == nil && res[j].Min != nil
+		}
+		return *res[i].Min < *res[j].Min
+	})
+	return res
+}
+
+// productAvailableQuery matches products in stock and available for purchase by now in given channel
+func productAvailableQuery(channelID string, now int64) query.Query {
+	stockQ := bleve.NewTermQuery(string(model_helper.StockAvailabilityInStock))
+	stockQ.SetField(productStockField(channelID))
+
+	return bleve.NewConjunctionQuery(stockQ, numericAtMostQuery(productAvailableAtField(channelID), now))
+}
+
+func numericAtMostQuery(field string, value int64) query.Query {
+	upper := float64(value)
+	inclusive := true
+	rangeQ := bleve.NewNumericRangeInclusiveQuery(nil, &upper, nil, &inclusive)
+	rangeQ.SetField(field)
+	return rangeQ
+}
+
+func termsQuery(field string, terms []string) query.Query {
+	termQueries := make([]query.Query, 0, len(terms))
+	for _, term := range terms {
+		termQ := bleve.NewTermQuery(term)
+		termQ.SetField(field)
+		termQueries = append(termQueries, termQ)
+	}
+	return bleve.NewDisjunctionQuery(termQueries...)
+}
+
+func productPriceField(channelID string) string {
+	return "Prices." + channelID
+}
+
+func productStockField(channelID string) string {
+	return "Stock." + channelID
+}
+
+func productPublishedAtField(channelID string) string {
+	return "PublishedAt." + channelID
+}
+
+func productAvailableAtField(channelID string) string {
+	return "AvailableAt." + channelID
+}
@@ -0,0 +1,173 @@
+package bleveengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProductEngine(t *testing.T, products ...*model_helper.ProductForIndexing) *BleveEngine {
+	index, err := bleve.NewMemOnly(getProductIndexMapping())
+	require.NoError(t, err)
+	t.Cleanup(func() { index.Close() })
+
+	engine := &BleveEngine{ProductIndex: index}
+	for _, product := range products {
+		require.Nil(t, engine.IndexProduct(product))
+	}
+	return engine
+}
+
+// newTestProduct returns a product published, visible and available for purchase in given channel
+func newTestProduct(name, channelID string, price float64, inStock bool, attributes ...string) *model_helper.ProductForIndexing {
+	past := model_helper.GetMillis() - time.Hour.Milliseconds()
+	product := &model_helper.ProductForIndexing{
+		Id:        model_helper.NewId(),
+		Name:      name,
+		CreatedAt: past,
+		ChannelListings: []*model_helper.ProductChannelListingForIndexing{
+			{
+				ChannelID:              channelID,
+				IsPublished:            true,
+				PublishedAt:            past,
+				VisibleInListings:      true,
+				AvailableForPurchaseAt: &past,
+				MinimalPrice:           &price,
+				InStock:                inStock,
+			},
+		},
+	}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		product.Attributes = append(product.Attributes, &model_helper.ProductAttributeValueForIndexing{
+			AttributeSlug: attributes[i],
+			ValueSlug:     attributes[i+1],
+			ValueName:     attributes[i+1],
+		})
+	}
+	return product
+}
+
+func TestSearchProducts(t *testing.T) {
+	channelID := model_helper.NewId()
+	future := model_helper.GetMillis() + time.Hour.Milliseconds()
+
+	red := newTestProduct("Red Cotton Shirt", channelID, 20, true, "color", "red", "size", "m")
+	red.Skus = []string{"SKU-0001"}
+	blue := newTestProduct("Blue Cotton Shirt", channelID, 60, false, "color", "blue", "size", "l")
+	green := newTestProduct("Green Hoodie", channelID, 40, true, "color", "green", "size", "m")
+	green.ChannelListings[0].AvailableForPurchaseAt = &future
+	upcoming := newTestProduct("Yellow Shirt", channelID, 30, true, "color", "yellow")
+	upcoming.ChannelListings[0].PublishedAt = future
+	hidden := newTestProduct("Hidden Shirt", channelID, 30, true)
+	hidden.ChannelListings[0].VisibleInListings = false
+	otherChannel := newTestProduct("Other Shirt", model_helper.NewId(), 30, true)
+
+	engine := newTestProductEngine(t, red, blue, green, upcoming, hidden, otherChannel)
+
+	search := func(t *testing.T, params model_helper.ProductSearchParams) *model_helper.ProductSearchResult {
+		params.ChannelID = channelID
+		result, appErr := engine.SearchProducts(&params)
+		require.Nil(t, appErr)
+		return result
+	}
+
+	t.Run("only products published and visible in the channel by now", func(t *testing.T) {
+		result := search(t, model_helper.ProductSearchParams{SortBy: model_helper.ProductSearchSortName})
+		require.Equal(t, []string{blue.Id, green.Id, red.Id}, result.ProductIDs)
+		require.EqualValues(t, 3, result.TotalCount)
+	})
+
+	t.Run("terms tolerate typos and match skus", func(t *testing.T) {
+		result := search(t, model_helper.ProductSearchParams{Terms: "cotton shirtt"})
+		require.ElementsMatch(t, []string{red.Id, blue.Id}, result.ProductIDs)
+
+		result = search(t, model_helper.ProductSearchParams{Terms: "SKU-0001"})
+		require.Equal(t, []string{red.Id}, result.ProductIDs)
+
+		result = search(t, model_helper.ProductSearchParams{Terms: "hoo"})
+		require.Equal(t, []string{green.Id}, result.ProductIDs)
+	})
+
+	t.Run("attribute values are ORed and attributes are ANDed", func(t *testing.T) {
+		result := search(t, model_helper.ProductSearchParams{Attributes: map[string][]string{"color": {"red", "green"}}})
+		require.ElementsMatch(t, []string{red.Id, green.Id}, result.ProductIDs)
+
+		result = search(t, model_helper.ProductSearchParams{Attributes: map[string][]string{"color": {"red", "blue"}, "size": {"l"}}})
+		require.Equal(t, []string{blue.Id}, result.ProductIDs)
+
+		sizes := lo.SliceToMap(result.AttributeFacets["size"], func(f *model_helper.ProductSearchTermFacet) (string, int) { return f.Term, f.Count })
+		require.Equal(t, map[string]int{"l": 1}, sizes)
+	})
+
+	t.Run("availability takes the purchase date into account", func(t *testing.T) {
+		result := search(t, model_helper.ProductSearchParams{})
+		require.Equal(t, map[model_helper.StockAvailability]int{
+			model_helper.StockAvailabilityInStock:    1,
+			model_helper.StockAvailabilityOutOfStock: 2,
+		}, result.AvailabilityFacet)
+
+		inStock := model_helper.StockAvailabilityInStock
+		result = search(t, model_helper.ProductSearchParams{StockAvailability: &inStock})
+		require.Equal(t, []string{red.Id}, result.ProductIDs)
+
+		outOfStock := model_helper.StockAvailabilityOutOfStock
+		result = search(t, model_helper.ProductSearchParams{StockAvailability: &outOfStock})
+		require.ElementsMatch(t, []string{blue.Id, green.Id}, result.ProductIDs)
+		require.Zero(t, result.AvailabilityFacet[model_helper.StockAvailabilityInStock])
+	})
+
+	t.Run("price range, sorting and facets", func(t *testing.T) {
+		result := search(t, model_helper.ProductSearchParams{
+			Price: &struct {
+				Gte *float64
+				Lte *float64
+			}{Gte: lo.ToPtr(30.0)},
+			SortBy:    model_helper.ProductSearchSortPrice,
+			Direction: model_helper.DESC,
+		})
+		require.Equal(t, []string{blue.Id, green.Id}, result.ProductIDs)
+
+		result = search(t, model_helper.ProductSearchParams{SortBy: model_helper.ProductSearchSortPrice})
+		require.Equal(t, []string{red.Id, green.Id, blue.Id}, result.ProductIDs)
+
+		counts := map[float64]int{}
+		for _, facet := range result.PriceFacets {
+			counts[lo.FromPtr(facet.Min)] = facet.Count
+		}
+		require.Equal(t, map[float64]int{10: 1, 25: 1, 50: 1}, counts)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		result := search(t, model_helper.ProductSearchParams{SortBy: model_helper.ProductSearchSortName, Page: 1, PerPage: 2})
+		require.Equal(t, []string{red.Id}, result.ProductIDs)
+		require.EqualValues(t, 3, result.TotalCount)
+	})
+
+	t.Run("deleted products are not found", func(t *testing.T) {
+		engine := newTestProductEngine(t, red, blue)
+		require.Nil(t, engine.DeleteProduct(red.Id))
+
+		result, appErr := engine.SearchProducts(&model_helper.ProductSearchParams{ChannelID: channelID})
+		require.Nil(t, appErr)
+		require.Equal(t, []string{blue.Id}, result.ProductIDs)
+	})
+}
+
+func TestBLVProductFromProductForIndexing(t *testing.T) {
+	channelID := model_helper.NewId()
+	product := newTestProduct("Shirt", channelID, 20, true, "color", "red")
+	product.ChannelListings[0].AvailableForPurchaseAt = nil
+	product.ChannelListings = append(product.ChannelListings, &model_helper.ProductChannelListingForIndexing{ChannelID: model_helper.NewId()})
+
+	blvProduct := BLVProductFromProductForIndexing(product)
+	require.Equal(t, "shirt", blvProduct.SortName)
+	require.Equal(t, []string{channelID}, blvProduct.Channels)
+	require.Equal(t, []string{"color:red"}, blvProduct.Attributes)
+	require.Equal(t, map[string]float64{channelID: 20}, blvProduct.Prices)
+	require.Equal(t, map[string]string{channelID: string(model_helper.StockAvailabilityInStock)}, blvProduct.Stock)
+	require.Empty(t, blvProduct.AvailableAt)
+}
@@ -24,6 +24,9 @@ type SearchEngineInterface interface {
 	IndexUser(user *model.User, teamsIds, channelsIds []string) *model_helper.AppError
 	// DeleteFile(fileID string) *model_helper.AppError
 	DeleteUser(user *model.User) *model_helper.AppError
+	IndexProduct(product *model_helper.ProductForIndexing) *model_helper.AppError
+	DeleteProduct(productID string) *model_helper.AppError
+	SearchProducts(params *model_helper.ProductSearchParams) (*model_helper.ProductSearchResult, *model_helper.AppError)
 	// DeletePostFiles(postID string) *model_helper.AppError
 	// DeleteUserFiles(userID string) *model_helper.AppError
 	// DeleteFilesBatch(endTime, limit int64) *model_helper.AppError
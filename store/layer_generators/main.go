@@ -112,7 +112,7 @@ func buildStoreImplement() error {
 				return "app"
 			case "Channel", "ChannelShop":
 				return "channel"
-			case "Checkout", "CheckoutLine":
+			case "Checkout", "CheckoutLine", "CheckoutRecovery":
 				return "checkout"
 			case "CsvExportEvent", "CsvExportFile":
 				return "csv"
@@ -136,12 +136,12 @@ func buildStoreImplement() error {
 			case "Category", "CategoryTranslation", "ProductType", "Product", "ProductTranslation",
 				"ProductChannelListing", "ProductVariant", "ProductVariantTranslation", "ProductVariantChannelListing",
 				"DigitalContent", "DigitalContentUrl", "ProductMedia", "VariantMedia",
-				"CollectionProduct", "Collection", "CollectionChannelListing", "CollectionTranslation":
+				"CollectionProduct", "Collection", "CollectionChannelListing", "CollectionTranslation", "ProductRecommendation", "SlugRedirect":
 				return "product"
 			case "ShippingMethodTranslation", "ShippingMethodChannelListing",
 				"ShippingMethodPostalCodeRule", "ShippingMethod", "ShippingZone":
 				return "shipping"
-			case "Warehouse", "Stock", "Allocation", "WarehouseShippingZone", "PreorderAllocation":
+			case "Warehouse", "Stock", "Allocation", "WarehouseShippingZone", "PreorderAllocation", "BackInStockSubscription":
 				return "warehouse"
 			case "Wishlist", "WishlistItem", "WishlistItemProductVariant":
 				return "wishlist"
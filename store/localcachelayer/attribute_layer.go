@@ -0,0 +1,123 @@
+package localcachelayer
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type LocalCacheAttributeStore struct {
+	store.AttributeStore
+	rootStore *LocalCacheStore
+}
+
+func (s LocalCacheAttributeStore) handleClusterInvalidatePageTypeAttributes(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.pageTypeAttributesCache.Purge()
+	} else {
+		s.rootStore.pageTypeAttributesCache.Remove(string(msg.Data))
+	}
+}
+
+func pageTypeAttributesKey(pageTypeID string, unassigned bool) string {
+	return pageTypeID + ":" + strconv.FormatBool(unassigned)
+}
+
+func (s LocalCacheAttributeStore) GetPageTypeAttributes(pageTypeID string, unassigned bool) (model.AttributeSlice, error) {
+	key := pageTypeAttributesKey(pageTypeID, unassigned)
+
+	var attributes model.AttributeSlice
+	if err := s.rootStore.doStandardReadCache(s.rootStore.pageTypeAttributesCache, key, &attributes); err == nil {
+		return attributes, nil
+	}
+
+	attributes, err := s.AttributeStore.GetPageTypeAttributes(pageTypeID, unassigned)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.pageTypeAttributesCache, key, attributes)
+	return attributes, nil
+}
+
+func (s LocalCacheAttributeStore) handleClusterInvalidateAttributeFilters(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.attributeFilterCache.Purge()
+	} else {
+		s.rootStore.attributeFilterCache.Remove(string(msg.Data))
+	}
+}
+
+func attributeFilterKey(option model_helper.AttributeFilterOption) (string, bool) {
+	if len(option.Preload) > 0 {
+		return "", false
+	}
+	key, ok := filterCacheKey(model.Attributes(option.Conditions...).Query, option.Conditions)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s:%q:%#v", key, option.Search, option.Metadata), true
+}
+
+func (s LocalCacheAttributeStore) FilterbyOption(option model_helper.AttributeFilterOption) (model.AttributeSlice, error) {
+	key, ok := attributeFilterKey(option)
+	if !ok {
+		return s.AttributeStore.FilterbyOption(option)
+	}
+
+	var attributes model.AttributeSlice
+	if err := s.rootStore.doStandardReadCache(s.rootStore.attributeFilterCache, key, &attributes); err == nil {
+		return attributes, nil
+	}
+
+	attributes, err := s.AttributeStore.FilterbyOption(option)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.attributeFilterCache, key, attributes)
+	return attributes, nil
+}
+
+// Upsert clears all cached page type attributes, since given attribute may be listed by any page type
+func (s LocalCacheAttributeStore) Upsert(attr model.Attribute) (*model.Attribute, error) {
+	upserted, err := s.AttributeStore.Upsert(attr)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doClearCacheCluster(s.rootStore.pageTypeAttributesCache)
+	s.rootStore.doClearCacheCluster(s.rootStore.attributeFilterCache)
+	return upserted, nil
+}
+
+func (s LocalCacheAttributeStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+	count, err := s.AttributeStore.Delete(tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	s.rootStore.afterCommit(tx, func() {
+		s.rootStore.doClearCacheCluster(s.rootStore.pageTypeAttributesCache)
+		s.rootStore.doClearCacheCluster(s.rootStore.attributeFilterCache)
+	})
+	return count, nil
+}
+
+// LocalCacheAttributePageStore invalidates cached page type attributes when attributes get assigned to page types
+type LocalCacheAttributePageStore struct {
+	store.AttributePageStore
+	rootStore *LocalCacheStore
+}
+
+func (s LocalCacheAttributePageStore) Save(page model.AttributePage) (*model.AttributePage, error) {
+	attributePage, err := s.AttributePageStore.Save(page)
+	if err == nil {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.pageTypeAttributesCache, pageTypeAttributesKey(page.PageTypeID, false))
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.pageTypeAttributesCache, pageTypeAttributesKey(page.PageTypeID, true))
+	}
+	return attributePage, err
+}
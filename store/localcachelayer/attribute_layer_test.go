@@ -0,0 +1,71 @@
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestAttributeStoreFilterbyOptionCache(t *testing.T) {
+	attribute := &model.Attribute{ID: model_helper.NewId(), Slug: "color"}
+
+	t.Run("keyed by search and metadata", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.attributes.On("FilterbyOption", mock.Anything).Return(model.AttributeSlice{attribute}, nil)
+
+		for _, option := range []model_helper.AttributeFilterOption{
+			{},
+			{Search: "col"},
+			{Metadata: map[string]any{"visible": true}},
+			{Search: "col"},
+			{},
+		} {
+			_, err := layer.Attribute().FilterbyOption(option)
+			require.NoError(t, err)
+		}
+
+		layer.attributes.AssertNumberOfCalls(t, "FilterbyOption", 3)
+		require.Equal(t, 2, layer.metrics.hits["AttributeFilter"])
+	})
+
+	t.Run("eager loads are not cached", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.attributes.On("FilterbyOption", mock.Anything).Return(model.AttributeSlice{attribute}, nil)
+
+		for _, option := range []model_helper.AttributeFilterOption{
+			{Preload: []string{model.AttributeRels.AttributeValues}},
+			{CommonQueryOptions: model_helper.NewCommonQueryOptions(qm.Load(model.AttributeRels.AttributeValues))},
+		} {
+			for range 2 {
+				_, err := layer.Attribute().FilterbyOption(option)
+				require.NoError(t, err)
+			}
+		}
+
+		layer.attributes.AssertNumberOfCalls(t, "FilterbyOption", 4)
+	})
+
+	t.Run("invalidated right away outside of transactions", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.attributes.On("FilterbyOption", mock.Anything).Return(model.AttributeSlice{attribute}, nil)
+		layer.attributes.On("Delete", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+		_, err := layer.Attribute().FilterbyOption(model_helper.AttributeFilterOption{})
+		require.NoError(t, err)
+
+		_, err = layer.Attribute().Delete(nil, []string{attribute.ID})
+		require.NoError(t, err)
+		require.Equal(t, []model_helper.ClusterEvent{
+			model_helper.ClusterEventInvalidateCacheForPageTypeAttributes,
+			model_helper.ClusterEventInvalidateCacheForAttributeFilters,
+		}, layer.cluster.sentEvents())
+
+		_, err = layer.Attribute().FilterbyOption(model_helper.AttributeFilterOption{})
+		require.NoError(t, err)
+		layer.attributes.AssertNumberOfCalls(t, "FilterbyOption", 2)
+	})
+}
@@ -0,0 +1,88 @@
+package localcachelayer
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+)
+
+type LocalCacheCategoryStore struct {
+	store.CategoryStore
+	rootStore *LocalCacheStore
+}
+
+func (s LocalCacheCategoryStore) handleClusterInvalidateCategory(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.categoryCache.Purge()
+	} else {
+		s.rootStore.categoryCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCacheCategoryStore) Get(ctx context.Context, categoryID string, allowFromCache bool) (*model.Category, error) {
+	if !allowFromCache {
+		return s.CategoryStore.Get(ctx, categoryID, false)
+	}
+
+	var category *model.Category
+	if err := s.rootStore.doStandardReadCache(s.rootStore.categoryCache, categoryID, &category); err == nil {
+		return category, nil
+	}
+
+	category, err := s.CategoryStore.Get(ctx, categoryID, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.categoryCache, categoryID, category)
+	return category, nil
+}
+
+func (s LocalCacheCategoryStore) handleClusterInvalidateCategoryFilters(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.categoryFilterCache.Purge()
+	} else {
+		s.rootStore.categoryFilterCache.Remove(string(msg.Data))
+	}
+}
+
+func categoryFilterKey(option model_helper.CategoryFilterOption) (string, bool) {
+	if option.ProductID != nil || option.SaleID != nil || option.VoucherID != nil {
+		return "", false
+	}
+	return filterCacheKey(model.Categories(option.Conditions...).Query, option.Conditions)
+}
+
+func (s LocalCacheCategoryStore) FilterByOption(option model_helper.CategoryFilterOption) (model.CategorySlice, error) {
+	key, ok := categoryFilterKey(option)
+	if !ok {
+		return s.CategoryStore.FilterByOption(option)
+	}
+
+	var categories model.CategorySlice
+	if err := s.rootStore.doStandardReadCache(s.rootStore.categoryFilterCache, key, &categories); err == nil {
+		return categories, nil
+	}
+
+	categories, err := s.CategoryStore.FilterByOption(option)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.categoryFilterCache, key, categories)
+	return categories, nil
+}
+
+func (s LocalCacheCategoryStore) Upsert(category model.Category) (*model.Category, error) {
+	upserted, err := s.CategoryStore.Upsert(category)
+	if err != nil {
+		return nil, err
+	}
+
+	if category.ID != "" {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.categoryCache, category.ID)
+	}
+	s.rootStore.doClearCacheCluster(s.rootStore.categoryFilterCache)
+	return upserted, nil
+}
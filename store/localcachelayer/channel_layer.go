@@ -0,0 +1,101 @@
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type LocalCacheChannelStore struct {
+	store.ChannelStore
+	rootStore *LocalCacheStore
+}
+
+func (s LocalCacheChannelStore) handleClusterInvalidateChannel(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.channelCache.Purge()
+	} else {
+		s.rootStore.channelCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCacheChannelStore) Get(id string) (*model.Channel, error) {
+	var channel *model.Channel
+	if err := s.rootStore.doStandardReadCache(s.rootStore.channelCache, id, &channel); err == nil {
+		return channel, nil
+	}
+
+	channel, err := s.ChannelStore.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.channelCache, id, channel)
+	return channel, nil
+}
+
+func (s LocalCacheChannelStore) handleClusterInvalidateChannelFilters(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.channelFilterCache.Purge()
+	} else {
+		s.rootStore.channelFilterCache.Remove(string(msg.Data))
+	}
+}
+
+func channelFilterKey(options model_helper.ChannelFilterOptions) (string, bool) {
+	if options.ShippingZoneID != nil || options.VoucherID != nil || options.AnnotateHasOrders {
+		return "", false
+	}
+	return filterCacheKey(model.Channels(options.Conditions...).Query, options.Conditions)
+}
+
+func (s LocalCacheChannelStore) FilterByOptions(options model_helper.ChannelFilterOptions) (model.ChannelSlice, error) {
+	key, ok := channelFilterKey(options)
+	if !ok {
+		return s.ChannelStore.FilterByOptions(options)
+	}
+
+	var channels model.ChannelSlice
+	if err := s.rootStore.doStandardReadCache(s.rootStore.channelFilterCache, key, &channels); err == nil {
+		return channels, nil
+	}
+
+	channels, err := s.ChannelStore.FilterByOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.channelFilterCache, key, channels)
+	return channels, nil
+}
+
+func (s LocalCacheChannelStore) Upsert(tx boil.ContextTransactor, channel model.Channel) (*model.Channel, error) {
+	upserted, err := s.ChannelStore.Upsert(tx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.afterCommit(tx, func() {
+		if channel.ID != "" {
+			s.rootStore.doInvalidateCacheCluster(s.rootStore.channelCache, channel.ID)
+		}
+		s.rootStore.doClearCacheCluster(s.rootStore.channelFilterCache)
+	})
+	return upserted, nil
+}
+
+func (s LocalCacheChannelStore) DeleteChannels(tx boil.ContextTransactor, ids []string) error {
+	err := s.ChannelStore.DeleteChannels(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	s.rootStore.afterCommit(tx, func() {
+		for _, id := range ids {
+			s.rootStore.doInvalidateCacheCluster(s.rootStore.channelCache, id)
+		}
+		s.rootStore.doClearCacheCluster(s.rootStore.channelFilterCache)
+	})
+	return nil
+}
@@ -0,0 +1,131 @@
+package localcachelayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func TestChannelStoreFilterByOptionsCache(t *testing.T) {
+	channel := &model.Channel{ID: model_helper.NewId(), Name: "Default", Slug: "default", IsActive: true}
+	active := model_helper.ChannelFilterOptions{
+		CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ChannelWhere.IsActive.EQ(true)),
+	}
+
+	t.Run("hit and miss", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.channels.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice{channel}, nil)
+
+		channels, err := layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+
+		channels, err = layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		require.Equal(t, channel.ID, channels[0].ID)
+
+		layer.channels.AssertNumberOfCalls(t, "FilterByOptions", 1)
+		require.Equal(t, 1, layer.metrics.misses["ChannelFilter"])
+		require.Equal(t, 1, layer.metrics.hits["ChannelFilter"])
+
+		// other conditions are cached apart
+		_, err = layer.Channel().FilterByOptions(model_helper.ChannelFilterOptions{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(model.ChannelWhere.IsActive.EQ(false)),
+		})
+		require.NoError(t, err)
+		layer.channels.AssertNumberOfCalls(t, "FilterByOptions", 2)
+	})
+
+	t.Run("queries reading other tables are not cached", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.channels.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice{channel}, nil)
+
+		for _, options := range []model_helper.ChannelFilterOptions{
+			{ShippingZoneID: model.ShippingZoneChannelWhere.ShippingZoneID.EQ(model_helper.NewId())},
+			{AnnotateHasOrders: true},
+			{CommonQueryOptions: model_helper.NewCommonQueryOptions(qm.InnerJoin(model.TableNames.ShippingZoneChannels + " ON true"))},
+			{CommonQueryOptions: model_helper.NewCommonQueryOptions(qm.Where("EXISTS (SELECT 1 FROM " + model.TableNames.Orders + ")"))},
+		} {
+			for range 2 {
+				_, err := layer.Channel().FilterByOptions(options)
+				require.NoError(t, err)
+			}
+		}
+
+		layer.channels.AssertNumberOfCalls(t, "FilterByOptions", 8)
+		require.Empty(t, layer.metrics.hits)
+		require.Empty(t, layer.metrics.misses)
+	})
+
+	t.Run("invalidated after upsert is committed", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.channels.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice{channel}, nil)
+		layer.channels.On("Get", channel.ID).Return(channel, nil)
+		layer.channels.On("Upsert", mock.Anything, mock.Anything).Return(channel, nil)
+
+		_, err := layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		_, err = layer.Channel().Get(channel.ID)
+		require.NoError(t, err)
+
+		tx, err := layer.GetMaster().BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+		_, err = layer.Channel().Upsert(tx, *channel)
+		require.NoError(t, err)
+
+		// a concurrent read before commit still hits the cache, and gets dropped once committed
+		_, err = layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		require.Empty(t, layer.cluster.sentEvents())
+		layer.channels.AssertNumberOfCalls(t, "FilterByOptions", 1)
+
+		require.NoError(t, tx.Commit())
+		require.Equal(t, []model_helper.ClusterEvent{
+			model_helper.ClusterEventInvalidateCacheForChannel,
+			model_helper.ClusterEventInvalidateCacheForChannelFilters,
+		}, layer.cluster.sentEvents())
+
+		_, err = layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		_, err = layer.Channel().Get(channel.ID)
+		require.NoError(t, err)
+		layer.channels.AssertNumberOfCalls(t, "FilterByOptions", 2)
+		layer.channels.AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("invalidated by cluster messages", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.channels.On("FilterByOptions", mock.Anything).Return(model.ChannelSlice{channel}, nil)
+		layer.channels.On("Get", channel.ID).Return(channel, nil)
+
+		_, err := layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		_, err = layer.Channel().Get(channel.ID)
+		require.NoError(t, err)
+
+		layer.cluster.handlers[model_helper.ClusterEventInvalidateCacheForChannelFilters](&model_helper.ClusterMessage{Data: clearCacheMessageData})
+		layer.cluster.handlers[model_helper.ClusterEventInvalidateCacheForChannel](&model_helper.ClusterMessage{Data: []byte(channel.ID)})
+
+		_, err = layer.Channel().FilterByOptions(active)
+		require.NoError(t, err)
+		_, err = layer.Channel().Get(channel.ID)
+		require.NoError(t, err)
+		layer.channels.AssertNumberOfCalls(t, "FilterByOptions", 2)
+		layer.channels.AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("failed writes don't invalidate", func(t *testing.T) {
+		layer := newTestLayer(t)
+		layer.channels.On("DeleteChannels", mock.Anything, mock.Anything).Return(model_helper.NewAppError("test", "test", nil, "", 500))
+
+		err := layer.Channel().DeleteChannels(nil, []string{channel.ID})
+		require.Error(t, err)
+		require.Empty(t, layer.cluster.sentEvents())
+	})
+}
@@ -1,13 +1,22 @@
 package localcachelayer
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sitename/sitename/einterfaces"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/services/cache"
 	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 const (
@@ -55,10 +64,20 @@ const (
 	TeamCacheSize = 20000
 	TeamCacheSec  = 30 * 60
 
-	ChannelCacheSec = 15 * 60 // 15 mins
+	ChannelCacheSize = 5000
+	ChannelCacheSec  = 15 * 60 // 15 mins
 
 	CategoryCacheSize = 25000
 	CategoryCacheSec  = 30 * 60
+
+	ShippingZoneCacheSize = 5000
+	ShippingZoneCacheSec  = 30 * 60
+
+	PageTypeAttributesCacheSize = 5000
+	PageTypeAttributesCacheSec  = 30 * 60
+
+	FilterCacheSize = 5000
+	FilterCacheSec  = 15 * 60
 )
 
 var clearCacheMessageData = []byte("")
@@ -74,6 +93,23 @@ type LocalCacheStore struct {
 	role                 LocalCacheRoleStore
 	roleCache            cache.Cache
 	rolePermissionsCache cache.Cache
+
+	channel            LocalCacheChannelStore
+	channelCache       cache.Cache
+	channelFilterCache cache.Cache
+
+	category            LocalCacheCategoryStore
+	categoryCache       cache.Cache
+	categoryFilterCache cache.Cache
+
+	shippingZone            LocalCacheShippingZoneStore
+	shippingZoneCache       cache.Cache
+	shippingZoneFilterCache cache.Cache
+
+	attribute               LocalCacheAttributeStore
+	attributePage           LocalCacheAttributePageStore
+	pageTypeAttributesCache cache.Cache
+	attributeFilterCache    cache.Cache
 }
 
 func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface, cacheProvider cache.Provider) (localCacheStore LocalCacheStore, err error) {
@@ -121,11 +157,97 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 	}
 	localCacheStore.role = LocalCacheRoleStore{RoleStore: baseStore.Role(), rootStore: &localCacheStore}
 
+	// Channels
+	if localCacheStore.channelCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   ChannelCacheSize,
+		Name:                   "Channel",
+		DefaultExpiry:          ChannelCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForChannel,
+	}); err != nil {
+		return
+	}
+	if localCacheStore.channelFilterCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   FilterCacheSize,
+		Name:                   "ChannelFilter",
+		DefaultExpiry:          FilterCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForChannelFilters,
+	}); err != nil {
+		return
+	}
+	localCacheStore.channel = LocalCacheChannelStore{ChannelStore: baseStore.Channel(), rootStore: &localCacheStore}
+
+	// Categories
+	if localCacheStore.categoryCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   CategoryCacheSize,
+		Name:                   "Category",
+		DefaultExpiry:          CategoryCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForCategoryByIds,
+		Striped:                true,
+		StripedBuckets:         max(runtime.NumCPU()-1, 1),
+	}); err != nil {
+		return
+	}
+	if localCacheStore.categoryFilterCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   FilterCacheSize,
+		Name:                   "CategoryFilter",
+		DefaultExpiry:          FilterCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForCategoryFilters,
+	}); err != nil {
+		return
+	}
+	localCacheStore.category = LocalCacheCategoryStore{CategoryStore: baseStore.Category(), rootStore: &localCacheStore}
+
+	// Shipping zones
+	if localCacheStore.shippingZoneCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   ShippingZoneCacheSize,
+		Name:                   "ShippingZone",
+		DefaultExpiry:          ShippingZoneCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForShippingZones,
+	}); err != nil {
+		return
+	}
+	if localCacheStore.shippingZoneFilterCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   FilterCacheSize,
+		Name:                   "ShippingZoneFilter",
+		DefaultExpiry:          FilterCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForShippingZoneFilters,
+	}); err != nil {
+		return
+	}
+	localCacheStore.shippingZone = LocalCacheShippingZoneStore{ShippingZoneStore: baseStore.ShippingZone(), rootStore: &localCacheStore}
+
+	// Attributes
+	if localCacheStore.pageTypeAttributesCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   PageTypeAttributesCacheSize,
+		Name:                   "PageTypeAttributes",
+		DefaultExpiry:          PageTypeAttributesCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForPageTypeAttributes,
+	}); err != nil {
+		return
+	}
+	if localCacheStore.attributeFilterCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   FilterCacheSize,
+		Name:                   "AttributeFilter",
+		DefaultExpiry:          FilterCacheSec * time.Second,
+		InvalidateClusterEvent: model_helper.ClusterEventInvalidateCacheForAttributeFilters,
+	}); err != nil {
+		return
+	}
+	localCacheStore.attribute = LocalCacheAttributeStore{AttributeStore: baseStore.Attribute(), rootStore: &localCacheStore}
+	localCacheStore.attributePage = LocalCacheAttributePageStore{AttributePageStore: baseStore.AttributePage(), rootStore: &localCacheStore}
+
 	if cluster != nil {
 		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForRoles, localCacheStore.role.handleClusterInvalidateRole)
 		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForProfileByIds, localCacheStore.user.handleClusterInvalidateScheme)
 		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForRolePermissions, localCacheStore.role.handleClusterInvalidateRolePermissions)
-		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForProfileByIds, localCacheStore.user.handleClusterInvalidateScheme)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForChannel, localCacheStore.channel.handleClusterInvalidateChannel)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForCategoryByIds, localCacheStore.category.handleClusterInvalidateCategory)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForShippingZones, localCacheStore.shippingZone.handleClusterInvalidateShippingZone)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForPageTypeAttributes, localCacheStore.attribute.handleClusterInvalidatePageTypeAttributes)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForChannelFilters, localCacheStore.channel.handleClusterInvalidateChannelFilters)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForCategoryFilters, localCacheStore.category.handleClusterInvalidateCategoryFilters)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForShippingZoneFilters, localCacheStore.shippingZone.handleClusterInvalidateShippingZoneFilters)
+		cluster.RegisterClusterMessageHandler(model_helper.ClusterEventInvalidateCacheForAttributeFilters, localCacheStore.attribute.handleClusterInvalidateAttributeFilters)
 	}
 
 	return
@@ -139,6 +261,31 @@ func (s LocalCacheStore) Role() store.RoleStore {
 	return s.role
 }
 
+func (s LocalCacheStore) Channel() store.ChannelStore {
+	return s.channel
+}
+
+func (s LocalCacheStore) Category() store.CategoryStore {
+	return s.category
+}
+
+func (s LocalCacheStore) ShippingZone() store.ShippingZoneStore {
+	return s.shippingZone
+}
+
+func (s LocalCacheStore) Attribute() store.AttributeStore {
+	return s.attribute
+}
+
+func (s LocalCacheStore) AttributePage() store.AttributePageStore {
+	return s.attributePage
+}
+
+// GetMaster wraps the master connection, transactions begun on it hold cache invalidations back until they are committed
+func (s LocalCacheStore) GetMaster() store.ContextRunner {
+	return localCacheMaster{s.Store.GetMaster()}
+}
+
 func (s LocalCacheStore) DropAllTables() {
 	s.Invalidate()
 	s.Store.DropAllTables()
@@ -189,4 +336,93 @@ func (s *LocalCacheStore) doClearCacheCluster(cache cache.Cache) {
 func (s *LocalCacheStore) Invalidate() {
 	s.doClearCacheCluster(s.userProfileByIdsCache)
 	s.doClearCacheCluster(s.roleCache)
+	s.doClearCacheCluster(s.rolePermissionsCache)
+	s.doClearCacheCluster(s.channelCache)
+	s.doClearCacheCluster(s.categoryCache)
+	s.doClearCacheCluster(s.shippingZoneCache)
+	s.doClearCacheCluster(s.pageTypeAttributesCache)
+	s.doClearCacheCluster(s.channelFilterCache)
+	s.doClearCacheCluster(s.categoryFilterCache)
+	s.doClearCacheCluster(s.shippingZoneFilterCache)
+	s.doClearCacheCluster(s.attributeFilterCache)
+}
+
+// afterCommit runs fn once given transaction is committed, or right away when tx is not a transaction begun through this layer.
+// Invalidating before commit lets concurrent reads cache rows the transaction is about to change.
+func (s *LocalCacheStore) afterCommit(tx boil.ContextTransactor, fn func()) {
+	if transaction, ok := tx.(*localCacheTransaction); ok {
+		transaction.mut.Lock()
+		transaction.afterCommit = append(transaction.afterCommit, fn)
+		transaction.mut.Unlock()
+		return
+	}
+	fn()
+}
+
+type localCacheMaster struct {
+	store.ContextRunner
+}
+
+func (m localCacheMaster) BeginTx(ctx context.Context, opts *sql.TxOptions) (store.ContextRunner, error) {
+	tx, err := m.ContextRunner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &localCacheTransaction{ContextRunner: tx}, nil
+}
+
+// localCacheTransaction runs registered cache invalidations after the wrapped transaction is committed
+type localCacheTransaction struct {
+	store.ContextRunner
+	mut         sync.Mutex
+	afterCommit []func()
+}
+
+// BeginTx returns the transaction itself, like nested transactions of the sql store do
+func (t *localCacheTransaction) BeginTx(context.Context, *sql.TxOptions) (store.ContextRunner, error) {
+	return t, nil
+}
+
+func (t *localCacheTransaction) Commit() error {
+	if err := t.ContextRunner.Commit(); err != nil {
+		return err
+	}
+
+	t.mut.Lock()
+	fns := t.afterCommit
+	t.afterCommit = nil
+	t.mut.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+	return nil
+}
+
+var loadQueryModType = reflect.TypeOf(qm.Load(""))
+
+// filterCacheKey returns the key that results of given filter query are cached with.
+//
+// Queries reading other tables than their own are not cached, since writes to those tables don't invalidate them,
+// nor are queries eager loading relationships, since the cache doesn't keep them.
+func filterCacheKey(query *queries.Query, mods []qm.QueryMod) (string, bool) {
+	for _, mod := range mods {
+		if reflect.TypeOf(mod) == loadQueryModType {
+			return "", false
+		}
+	}
+
+	sqlString, args := queries.BuildQuery(query)
+	upper := strings.ToUpper(sqlString)
+	if strings.Contains(upper, " JOIN ") || strings.Count(upper, "SELECT ") > 1 {
+		return "", false
+	}
+	for _, arg := range args {
+		// pointers would be keyed by their addresses
+		if reflect.ValueOf(arg).Kind() == reflect.Pointer {
+			return "", false
+		}
+	}
+
+	return fmt.Sprintf("%s%#v", sqlString, args), true
 }
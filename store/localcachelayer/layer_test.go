@@ -0,0 +1,145 @@
+package localcachelayer
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sitename/sitename/einterfaces"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/services/cache"
+	"github.com/sitename/sitename/store"
+	"github.com/sitename/sitename/store/storetest/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type testCluster struct {
+	einterfaces.ClusterInterface
+	handlers map[model_helper.ClusterEvent]einterfaces.ClusterMessageHandler
+	sent     []*model_helper.ClusterMessage
+}
+
+func (c *testCluster) RegisterClusterMessageHandler(event model_helper.ClusterEvent, handler einterfaces.ClusterMessageHandler) {
+	c.handlers[event] = handler
+}
+
+func (c *testCluster) SendClusterMessage(msg *model_helper.ClusterMessage) {
+	c.sent = append(c.sent, msg)
+}
+
+// sentEvents returns events of sent messages, then forgets them
+func (c *testCluster) sentEvents() []model_helper.ClusterEvent {
+	var events []model_helper.ClusterEvent
+	for _, msg := range c.sent {
+		events = append(events, msg.Event)
+	}
+	c.sent = nil
+	return events
+}
+
+type testMetrics struct {
+	einterfaces.MetricsInterface
+	hits   map[string]int
+	misses map[string]int
+}
+
+func (m *testMetrics) IncrementMemCacheHitCounter(cacheName string) {
+	m.hits[cacheName]++
+}
+
+func (m *testMetrics) IncrementMemCacheMissCounter(cacheName string) {
+	m.misses[cacheName]++
+}
+
+type testRunner struct {
+	store.ContextRunner
+	committed bool
+}
+
+func (r *testRunner) BeginTx(context.Context, *sql.TxOptions) (store.ContextRunner, error) {
+	return &testRunner{}, nil
+}
+
+func (r *testRunner) Commit() error {
+	r.committed = true
+	return nil
+}
+
+func (r *testRunner) Rollback() error {
+	return nil
+}
+
+type testLayer struct {
+	LocalCacheStore
+	channels      *mocks.ChannelStore
+	categories    *mocks.CategoryStore
+	shippingZones *mocks.ShippingZoneStore
+	attributes    *mocks.AttributeStore
+	cluster       *testCluster
+	metrics       *testMetrics
+}
+
+func newTestLayer(t *testing.T) *testLayer {
+	layer := &testLayer{
+		channels:      &mocks.ChannelStore{},
+		categories:    &mocks.CategoryStore{},
+		shippingZones: &mocks.ShippingZoneStore{},
+		attributes:    &mocks.AttributeStore{},
+		cluster:       &testCluster{handlers: map[model_helper.ClusterEvent]einterfaces.ClusterMessageHandler{}},
+		metrics:       &testMetrics{hits: map[string]int{}, misses: map[string]int{}},
+	}
+
+	baseStore := &mocks.Store{}
+	baseStore.On("User").Return(&mocks.UserStore{})
+	baseStore.On("Role").Return(&mocks.RoleStore{})
+	baseStore.On("Channel").Return(layer.channels)
+	baseStore.On("Category").Return(layer.categories)
+	baseStore.On("ShippingZone").Return(layer.shippingZones)
+	baseStore.On("Attribute").Return(layer.attributes)
+	baseStore.On("AttributePage").Return(&mocks.AttributePageStore{})
+	baseStore.On("GetMaster").Return(&testRunner{})
+
+	var err error
+	layer.LocalCacheStore, err = NewLocalCacheLayer(baseStore, layer.metrics, layer.cluster, cache.NewProvider())
+	require.NoError(t, err)
+	return layer
+}
+
+func TestAfterCommit(t *testing.T) {
+	layer := newTestLayer(t)
+
+	t.Run("outside of transactions", func(t *testing.T) {
+		var ran bool
+		layer.afterCommit(nil, func() { ran = true })
+		require.True(t, ran)
+
+		ran = false
+		layer.afterCommit(layer.GetMaster(), func() { ran = true })
+		require.True(t, ran)
+	})
+
+	t.Run("inside a transaction", func(t *testing.T) {
+		tx, err := layer.GetMaster().BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		nested, err := tx.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+		require.Same(t, tx, nested)
+
+		var ran int
+		layer.afterCommit(tx, func() { ran++ })
+		layer.afterCommit(nested, func() { ran++ })
+		require.Zero(t, ran)
+
+		require.NoError(t, tx.Rollback())
+		require.Zero(t, ran)
+
+		require.NoError(t, tx.Commit())
+		require.True(t, tx.(*localCacheTransaction).ContextRunner.(*testRunner).committed)
+		require.Equal(t, 2, ran)
+
+		// invalidations run once
+		require.NoError(t, tx.Commit())
+		require.Equal(t, 2, ran)
+	})
+}
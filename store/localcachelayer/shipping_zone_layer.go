@@ -0,0 +1,101 @@
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type LocalCacheShippingZoneStore struct {
+	store.ShippingZoneStore
+	rootStore *LocalCacheStore
+}
+
+func (s LocalCacheShippingZoneStore) handleClusterInvalidateShippingZone(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.shippingZoneCache.Purge()
+	} else {
+		s.rootStore.shippingZoneCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCacheShippingZoneStore) Get(id string) (*model.ShippingZone, error) {
+	var zone *model.ShippingZone
+	if err := s.rootStore.doStandardReadCache(s.rootStore.shippingZoneCache, id, &zone); err == nil {
+		return zone, nil
+	}
+
+	zone, err := s.ShippingZoneStore.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.shippingZoneCache, id, zone)
+	return zone, nil
+}
+
+func (s LocalCacheShippingZoneStore) handleClusterInvalidateShippingZoneFilters(msg *model_helper.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.shippingZoneFilterCache.Purge()
+	} else {
+		s.rootStore.shippingZoneFilterCache.Remove(string(msg.Data))
+	}
+}
+
+func shippingZoneFilterKey(option model_helper.ShippingZoneFilterOption) (string, bool) {
+	if option.WarehouseID != nil || option.ChannelID != nil {
+		return "", false
+	}
+	return filterCacheKey(model.ShippingZones(option.Conditions...).Query, option.Conditions)
+}
+
+func (s LocalCacheShippingZoneStore) FilterByOption(option model_helper.ShippingZoneFilterOption) (model.ShippingZoneSlice, error) {
+	key, ok := shippingZoneFilterKey(option)
+	if !ok {
+		return s.ShippingZoneStore.FilterByOption(option)
+	}
+
+	var zones model.ShippingZoneSlice
+	if err := s.rootStore.doStandardReadCache(s.rootStore.shippingZoneFilterCache, key, &zones); err == nil {
+		return zones, nil
+	}
+
+	zones, err := s.ShippingZoneStore.FilterByOption(option)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.shippingZoneFilterCache, key, zones)
+	return zones, nil
+}
+
+func (s LocalCacheShippingZoneStore) Upsert(tx boil.ContextTransactor, shippingZone model.ShippingZone) (*model.ShippingZone, error) {
+	upserted, err := s.ShippingZoneStore.Upsert(tx, shippingZone)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.afterCommit(tx, func() {
+		if shippingZone.ID != "" {
+			s.rootStore.doInvalidateCacheCluster(s.rootStore.shippingZoneCache, shippingZone.ID)
+		}
+		s.rootStore.doClearCacheCluster(s.rootStore.shippingZoneFilterCache)
+	})
+	return upserted, nil
+}
+
+func (s LocalCacheShippingZoneStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+	count, err := s.ShippingZoneStore.Delete(tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	s.rootStore.afterCommit(tx, func() {
+		for _, id := range ids {
+			s.rootStore.doInvalidateCacheCluster(s.rootStore.shippingZoneCache, id)
+		}
+		s.rootStore.doClearCacheCluster(s.rootStore.shippingZoneFilterCache)
+	})
+	return count, nil
+}
@@ -35,11 +35,13 @@ type OpenTracingLayer struct {
 	AttributeValueStore                store.AttributeValueStore
 	AttributeValueTranslationStore     store.AttributeValueTranslationStore
 	AuditStore                         store.AuditStore
+	BackInStockSubscriptionStore       store.BackInStockSubscriptionStore
 	CategoryStore                      store.CategoryStore
 	CategoryTranslationStore           store.CategoryTranslationStore
 	ChannelStore                       store.ChannelStore
 	CheckoutStore                      store.CheckoutStore
 	CheckoutLineStore                  store.CheckoutLineStore
+	CheckoutRecoveryStore              store.CheckoutRecoveryStore
 	ClusterDiscoveryStore              store.ClusterDiscoveryStore
 	CollectionStore                    store.CollectionStore
 	CollectionChannelListingStore      store.CollectionChannelListingStore
@@ -85,6 +87,7 @@ type OpenTracingLayer struct {
 	ProductStore                       store.ProductStore
 	ProductChannelListingStore         store.ProductChannelListingStore
 	ProductMediaStore                  store.ProductMediaStore
+	ProductRecommendationStore         store.ProductRecommendationStore
 	ProductTranslationStore            store.ProductTranslationStore
 	ProductTypeStore                   store.ProductTypeStore
 	ProductVariantStore                store.ProductVariantStore
@@ -99,6 +102,7 @@ type OpenTracingLayer struct {
 	ShippingZoneStore                  store.ShippingZoneStore
 	ShopStaffStore                     store.ShopStaffStore
 	ShopTranslationStore               store.ShopTranslationStore
+	SlugRedirectStore                  store.SlugRedirectStore
 	StaffNotificationRecipientStore    store.StaffNotificationRecipientStore
 	StatusStore                        store.StatusStore
 	StockStore                         store.StockStore
@@ -173,6 +177,10 @@ func (s *OpenTracingLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *OpenTracingLayer) BackInStockSubscription() store.BackInStockSubscriptionStore {
+	return s.BackInStockSubscriptionStore
+}
+
 func (s *OpenTracingLayer) Category() store.CategoryStore {
 	return s.CategoryStore
 }
@@ -193,6 +201,10 @@ func (s *OpenTracingLayer) CheckoutLine() store.CheckoutLineStore {
 	return s.CheckoutLineStore
 }
 
+func (s *OpenTracingLayer) CheckoutRecovery() store.CheckoutRecoveryStore {
+	return s.CheckoutRecoveryStore
+}
+
 func (s *OpenTracingLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -373,6 +385,10 @@ func (s *OpenTracingLayer) ProductMedia() store.ProductMediaStore {
 	return s.ProductMediaStore
 }
 
+func (s *OpenTracingLayer) ProductRecommendation() store.ProductRecommendationStore {
+	return s.ProductRecommendationStore
+}
+
 func (s *OpenTracingLayer) ProductTranslation() store.ProductTranslationStore {
 	return s.ProductTranslationStore
 }
@@ -429,6 +445,10 @@ func (s *OpenTracingLayer) ShopTranslation() store.ShopTranslationStore {
 	return s.ShopTranslationStore
 }
 
+func (s *OpenTracingLayer) SlugRedirect() store.SlugRedirectStore {
+	return s.SlugRedirectStore
+}
+
 func (s *OpenTracingLayer) StaffNotificationRecipient() store.StaffNotificationRecipientStore {
 	return s.StaffNotificationRecipientStore
 }
@@ -563,6 +583,11 @@ type OpenTracingLayerAuditStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerBackInStockSubscriptionStore struct {
+	store.BackInStockSubscriptionStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerCategoryStore struct {
 	store.CategoryStore
 	Root *OpenTracingLayer
@@ -588,6 +613,11 @@ type OpenTracingLayerCheckoutLineStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerCheckoutRecoveryStore struct {
+	store.CheckoutRecoveryStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *OpenTracingLayer
@@ -813,6 +843,11 @@ type OpenTracingLayerProductMediaStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerProductRecommendationStore struct {
+	store.ProductRecommendationStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerProductTranslationStore struct {
 	store.ProductTranslationStore
 	Root *OpenTracingLayer
@@ -883,6 +918,11 @@ type OpenTracingLayerShopTranslationStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerSlugRedirectStore struct {
+	store.SlugRedirectStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerStaffNotificationRecipientStore struct {
 	store.StaffNotificationRecipientStore
 	Root *OpenTracingLayer
@@ -963,6 +1003,24 @@ type OpenTracingLayerWishlistItemStore struct {
 	Root *OpenTracingLayer
 }
 
+func (s *OpenTracingLayerAddressStore) Anonymize(tx boil.ContextTransactor, addressIDs []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AddressStore.Anonymize")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.AddressStore.Anonymize(tx, addressIDs)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerAddressStore) DeleteAddresses(tx boil.ContextTransactor, addressIDs []string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AddressStore.DeleteAddresses")
@@ -1269,7 +1327,7 @@ func (s *OpenTracingLayerAssignedProductAttributeStore) GetWithOption(option mod
 	return result, err
 }
 
-func (s *OpenTracingLayerAssignedProductAttributeStore) Save(assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
+func (s *OpenTracingLayerAssignedProductAttributeStore) Save(tx boil.ContextTransactor, assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AssignedProductAttributeStore.Save")
 	s.Root.Store.SetContext(newCtx)
@@ -1278,7 +1336,25 @@ func (s *OpenTracingLayerAssignedProductAttributeStore) Save(assignedProductAttr
 	}()
 
 	defer span.Finish()
-	result, err := s.AssignedProductAttributeStore.Save(assignedProductAttribute)
+	result, err := s.AssignedProductAttributeStore.Save(tx, assignedProductAttribute)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerAssignedProductAttributeValueStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AssignedProductAttributeValueStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.AssignedProductAttributeValueStore.Delete(tx, ids)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -1323,7 +1399,7 @@ func (s *OpenTracingLayerAssignedProductAttributeValueStore) Get(assignedProduct
 	return result, err
 }
 
-func (s *OpenTracingLayerAssignedProductAttributeValueStore) Save(assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
+func (s *OpenTracingLayerAssignedProductAttributeValueStore) Save(tx boil.ContextTransactor, assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AssignedProductAttributeValueStore.Save")
 	s.Root.Store.SetContext(newCtx)
@@ -1332,7 +1408,7 @@ func (s *OpenTracingLayerAssignedProductAttributeValueStore) Save(assignedProduc
 	}()
 
 	defer span.Finish()
-	result, err := s.AssignedProductAttributeValueStore.Save(assignedProductAttrValues)
+	result, err := s.AssignedProductAttributeValueStore.Save(tx, assignedProductAttrValues)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -1611,6 +1687,24 @@ func (s *OpenTracingLayerAttributeValueStore) Upsert(tx boil.ContextTransactor,
 	return result, err
 }
 
+func (s *OpenTracingLayerAuditStore) CountBefore(endTime int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditStore.CountBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.AuditStore.CountBefore(endTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerAuditStore) Get(userID string, offset int, limit int) (model.AuditSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditStore.Get")
@@ -1629,6 +1723,24 @@ func (s *OpenTracingLayerAuditStore) Get(userID string, offset int, limit int) (
 	return result, err
 }
 
+func (s *OpenTracingLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditStore.PermanentDeleteBatch")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.AuditStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerAuditStore) PermanentDeleteByUser(userID string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditStore.PermanentDeleteByUser")
@@ -1665,6 +1777,60 @@ func (s *OpenTracingLayerAuditStore) Save(audit model.Audit) error {
 	return err
 }
 
+func (s *OpenTracingLayerBackInStockSubscriptionStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "BackInStockSubscriptionStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.BackInStockSubscriptionStore.Delete(tx, ids)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerBackInStockSubscriptionStore) FilterByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "BackInStockSubscriptionStore.FilterByOptions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.BackInStockSubscriptionStore.FilterByOptions(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerBackInStockSubscriptionStore) Save(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "BackInStockSubscriptionStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.BackInStockSubscriptionStore.Save(subscription)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerCategoryStore) FilterByOption(option model_helper.CategoryFilterOption) (model.CategorySlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CategoryStore.FilterByOption")
@@ -1989,6 +2155,60 @@ func (s *OpenTracingLayerCheckoutLineStore) Upsert(checkoutLines model.CheckoutL
 	return result, err
 }
 
+func (s *OpenTracingLayerCheckoutRecoveryStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CheckoutRecoveryStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.CheckoutRecoveryStore.Delete(tx, ids)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerCheckoutRecoveryStore) FilterByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CheckoutRecoveryStore.FilterByOptions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.CheckoutRecoveryStore.FilterByOptions(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerCheckoutRecoveryStore) Upsert(tx boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CheckoutRecoveryStore.Upsert")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.CheckoutRecoveryStore.Upsert(tx, recovery)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerClusterDiscoveryStore) Cleanup() error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ClusterDiscoveryStore.Cleanup")
@@ -2349,6 +2569,60 @@ func (s *OpenTracingLayerCsvExportEventStore) Save(event model.ExportEvent) (*mo
 	return result, err
 }
 
+func (s *OpenTracingLayerCsvExportFileStore) CountBefore(endTime int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CsvExportFileStore.CountBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.CsvExportFileStore.CountBefore(endTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerCsvExportFileStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CsvExportFileStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.CsvExportFileStore.Delete(tx, ids)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerCsvExportFileStore) FilterByOptions(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CsvExportFileStore.FilterByOptions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.CsvExportFileStore.FilterByOptions(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerCsvExportFileStore) Get(id string) (*model.ExportFile, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CsvExportFileStore.Get")
@@ -2367,16 +2641,16 @@ func (s *OpenTracingLayerCsvExportFileStore) Get(id string) (*model.ExportFile,
 	return result, err
 }
 
-func (s *OpenTracingLayerCsvExportFileStore) Save(file model.ExportFile) (*model.ExportFile, error) {
+func (s *OpenTracingLayerCsvExportFileStore) Upsert(file model.ExportFile) (*model.ExportFile, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CsvExportFileStore.Save")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CsvExportFileStore.Upsert")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
 	defer span.Finish()
-	result, err := s.CsvExportFileStore.Save(file)
+	result, err := s.CsvExportFileStore.Upsert(file)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -2385,6 +2659,24 @@ func (s *OpenTracingLayerCsvExportFileStore) Save(file model.ExportFile) (*model
 	return result, err
 }
 
+func (s *OpenTracingLayerCustomProductAttributeStore) AssignVariantValues(tx boil.ContextTransactor, productID string, variantID string, inputs []*model_helper.CustomProductAttributeValuesInput) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CustomProductAttributeStore.AssignVariantValues")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.CustomProductAttributeStore.AssignVariantValues(tx, productID, variantID, inputs)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerCustomProductAttributeStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CustomProductAttributeStore.Delete")
@@ -2457,6 +2749,24 @@ func (s *OpenTracingLayerCustomerEventStore) Count() (int64, error) {
 	return result, err
 }
 
+func (s *OpenTracingLayerCustomerEventStore) CountBefore(endTime int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CustomerEventStore.CountBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.CustomerEventStore.CountBefore(endTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerCustomerEventStore) FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CustomerEventStore.FilterByOptions")
@@ -2493,6 +2803,24 @@ func (s *OpenTracingLayerCustomerEventStore) Get(id string) (*model.CustomerEven
 	return result, err
 }
 
+func (s *OpenTracingLayerCustomerEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CustomerEventStore.PermanentDeleteBatch")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.CustomerEventStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerCustomerEventStore) Upsert(tx boil.ContextTransactor, customemrEvent model.CustomerEvent) (*model.CustomerEvent, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "CustomerEventStore.Upsert")
@@ -2889,7 +3217,7 @@ func (s *OpenTracingLayerDiscountVoucherStore) Get(id string) (*model.Voucher, e
 	return result, err
 }
 
-func (s *OpenTracingLayerDiscountVoucherStore) Upsert(voucher model.Voucher) (*model.Voucher, error) {
+func (s *OpenTracingLayerDiscountVoucherStore) Upsert(tx boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "DiscountVoucherStore.Upsert")
 	s.Root.Store.SetContext(newCtx)
@@ -2898,7 +3226,7 @@ func (s *OpenTracingLayerDiscountVoucherStore) Upsert(voucher model.Voucher) (*m
 	}()
 
 	defer span.Finish()
-	result, err := s.DiscountVoucherStore.Upsert(voucher)
+	result, err := s.DiscountVoucherStore.Upsert(tx, voucher)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -3779,6 +4107,24 @@ func (s *OpenTracingLayerOpenExchangeRateStore) GetAll() (model.OpenExchangeRate
 	return result, err
 }
 
+func (s *OpenTracingLayerOrderStore) AnonymizeByUser(tx boil.ContextTransactor, userID string, email string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderStore.AnonymizeByUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.OrderStore.AnonymizeByUser(tx, userID, email)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerOrderStore) BulkUpsert(tx boil.ContextTransactor, orders model.OrderSlice) (model.OrderSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderStore.BulkUpsert")
@@ -3797,6 +4143,24 @@ func (s *OpenTracingLayerOrderStore) BulkUpsert(tx boil.ContextTransactor, order
 	return result, err
 }
 
+func (s *OpenTracingLayerOrderStore) CountByUserIDs(userIDs []string) ([]*model_helper.OrderCountByUserID, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderStore.CountByUserIDs")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OrderStore.CountByUserIDs(userIDs)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerOrderStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderStore.Delete")
@@ -3923,6 +4287,96 @@ func (s *OpenTracingLayerOrderDiscountStore) Upsert(tx boil.ContextTransactor, o
 	return result, err
 }
 
+func (s *OpenTracingLayerOrderEventStore) CountBefore(endTime int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderEventStore.CountBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OrderEventStore.CountBefore(endTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOrderEventStore) FilterByOptions(options model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderEventStore.FilterByOptions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OrderEventStore.FilterByOptions(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOrderEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderEventStore.PermanentDeleteBatch")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OrderEventStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOrderEventStore) Save(tx boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderEventStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OrderEventStore.Save(tx, orderEvent)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOrderLineStore) Delete(tx boil.ContextTransactor, orderLineIDs []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderLineStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.OrderLineStore.Delete(tx, orderLineIDs)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerOrderLineStore) FilterbyOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderLineStore.FilterbyOption")
@@ -3961,14 +4415,32 @@ func (s *OpenTracingLayerOrderLineStore) Get(id string) (*model.OrderLine, error
 
 func (s *OpenTracingLayerOrderLineStore) Upsert(tx boil.ContextTransactor, orderLine model.OrderLine) (*model.OrderLine, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderLineStore.Upsert")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OrderLineStore.Upsert")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OrderLineStore.Upsert(tx, orderLine)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPageStore) FilterByOptions(options model_helper.PageFilterOptions) (model.PageSlice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PageStore.FilterByOptions")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
 	defer span.Finish()
-	result, err := s.OrderLineStore.Upsert(tx, orderLine)
+	result, err := s.PageStore.FilterByOptions(options)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -3977,22 +4449,22 @@ func (s *OpenTracingLayerOrderLineStore) Upsert(tx boil.ContextTransactor, order
 	return result, err
 }
 
-func (s *OpenTracingLayerPageStore) FilterByOptions(options model_helper.PageFilterOptions) (model.PageSlice, error) {
+func (s *OpenTracingLayerPaymentStore) AnonymizeByOrders(tx boil.ContextTransactor, orderIDs []string, email string) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PageStore.FilterByOptions")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PaymentStore.AnonymizeByOrders")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
 	defer span.Finish()
-	result, err := s.PageStore.FilterByOptions(options)
+	err := s.PaymentStore.AnonymizeByOrders(tx, orderIDs, email)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
 	}
 
-	return result, err
+	return err
 }
 
 func (s *OpenTracingLayerPaymentStore) CancelActivePaymentsOfCheckout(checkoutToken string) error {
@@ -4566,7 +5038,7 @@ func (s *OpenTracingLayerPreorderAllocationStore) Upsert(tx boil.ContextTransact
 	return result, err
 }
 
-func (s *OpenTracingLayerProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder {
+func (s *OpenTracingLayerProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.AdvancedFilterQueryBuilder")
 	s.Root.Store.SetContext(newCtx)
@@ -4575,8 +5047,31 @@ func (s *OpenTracingLayerProductStore) AdvancedFilterQueryBuilder(input model_he
 	}()
 
 	defer span.Finish()
-	result := s.ProductStore.AdvancedFilterQueryBuilder(input)
-	return result
+	result, err := s.ProductStore.AdvancedFilterQueryBuilder(input)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerProductStore) CategoryInteractionsOfUser(userID string) ([]*model_helper.ProductCategoryInteraction, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.CategoryInteractionsOfUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductStore.CategoryInteractionsOfUser(userID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
 }
 
 func (s *OpenTracingLayerProductStore) CountByCategoryIDs(categoryIDs []string) ([]*model_helper.ProductCountByCategoryID, error) {
@@ -4597,6 +5092,24 @@ func (s *OpenTracingLayerProductStore) CountByCategoryIDs(categoryIDs []string)
 	return result, err
 }
 
+func (s *OpenTracingLayerProductStore) Feed(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.Feed")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductStore.Feed(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerProductStore) FilterByOption(option model_helper.ProductFilterOption) (model.ProductSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.FilterByOption")
@@ -4633,6 +5146,42 @@ func (s *OpenTracingLayerProductStore) FilterByQuery(query squirrel.SelectBuilde
 	return result, err
 }
 
+func (s *OpenTracingLayerProductStore) GetForIndexing(productIDs []string) ([]*model_helper.ProductForIndexing, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.GetForIndexing")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductStore.GetForIndexing(productIDs)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerProductStore) GetProductsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model_helper.ProductForIndexing, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.GetProductsBatchForIndexing")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductStore.GetProductsBatchForIndexing(startTime, endTime, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerProductStore) NotPublishedProducts(channelID string) (model.ProductSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductStore.NotPublishedProducts")
@@ -4857,6 +5406,78 @@ func (s *OpenTracingLayerProductMediaStore) Upsert(tx boil.ContextTransactor, me
 	return result, err
 }
 
+func (s *OpenTracingLayerProductRecommendationStore) CoPurchases(channelID string, since int64, minCount int) ([]*model_helper.ProductCoPurchase, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductRecommendationStore.CoPurchases")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductRecommendationStore.CoPurchases(channelID, since, minCount)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerProductRecommendationStore) ProductsForRecommendations(channelID string, now int64, afterID string, limit int) ([]*model_helper.ProductForRecommendation, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductRecommendationStore.ProductsForRecommendations")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductRecommendationStore.ProductsForRecommendations(channelID, now, afterID, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerProductRecommendationStore) RecommendedProducts(options model_helper.RecommendedProductsOptions) ([]*model_helper.RecommendedProduct, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductRecommendationStore.RecommendedProducts")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ProductRecommendationStore.RecommendedProducts(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerProductRecommendationStore) ReplaceForChannel(tx boil.ContextTransactor, channelID string, recommendations model.ProductRecommendationSlice) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductRecommendationStore.ReplaceForChannel")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.ProductRecommendationStore.ReplaceForChannel(tx, channelID, recommendations)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerProductVariantStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductVariantStore.Delete")
@@ -5194,6 +5815,24 @@ func (s *OpenTracingLayerSessionStore) Cleanup(expiryTime int64, batchSize int64
 
 }
 
+func (s *OpenTracingLayerSessionStore) CountExpiredBefore(endTime int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SessionStore.CountExpiredBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.SessionStore.CountExpiredBefore(endTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SessionStore.Get")
@@ -5266,7 +5905,25 @@ func (s *OpenTracingLayerSessionStore) GetSessionsWithActiveDeviceIds(userID str
 	return result, err
 }
 
-func (s *OpenTracingLayerSessionStore) PermanentDeleteSessionsByUser(userID string) error {
+func (s *OpenTracingLayerSessionStore) PermanentDeleteExpiredBatch(endTime int64, limit int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SessionStore.PermanentDeleteExpiredBatch")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.SessionStore.PermanentDeleteExpiredBatch(endTime, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerSessionStore) PermanentDeleteSessionsByUser(tx boil.ContextTransactor, userID string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SessionStore.PermanentDeleteSessionsByUser")
 	s.Root.Store.SetContext(newCtx)
@@ -5275,7 +5932,7 @@ func (s *OpenTracingLayerSessionStore) PermanentDeleteSessionsByUser(userID stri
 	}()
 
 	defer span.Finish()
-	err := s.SessionStore.PermanentDeleteSessionsByUser(userID)
+	err := s.SessionStore.PermanentDeleteSessionsByUser(tx, userID)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -5860,6 +6517,60 @@ func (s *OpenTracingLayerShopTranslationStore) Upsert(translation model.ShopTran
 	return result, err
 }
 
+func (s *OpenTracingLayerSlugRedirectStore) FilterByOptions(options model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SlugRedirectStore.FilterByOptions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.SlugRedirectStore.FilterByOptions(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerSlugRedirectStore) GetByOldSlug(objectType model_helper.SeoObjectType, oldSlug string) (*model.SlugRedirect, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SlugRedirectStore.GetByOldSlug")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.SlugRedirectStore.GetByOldSlug(objectType, oldSlug)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerSlugRedirectStore) RecordSlugChange(tx boil.ContextTransactor, objectType model_helper.SeoObjectType, objectID string, newSlug string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SlugRedirectStore.RecordSlugChange")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.SlugRedirectStore.RecordSlugChange(tx, objectType, objectID, newSlug)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerStaffNotificationRecipientStore) FilterByOptions(options model_helper.StaffNotificationRecipientFilterOptions) (model.StaffNotificationRecipientSlice, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "StaffNotificationRecipientStore.FilterByOptions")
@@ -6395,6 +7106,24 @@ func (s *OpenTracingLayerTokenStore) Cleanup() error {
 	return err
 }
 
+func (s *OpenTracingLayerTokenStore) CountBefore(endTime int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "TokenStore.CountBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.TokenStore.CountBefore(endTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerTokenStore) Delete(token string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "TokenStore.Delete")
@@ -6449,6 +7178,24 @@ func (s *OpenTracingLayerTokenStore) GetByToken(token string) (*model.Token, err
 	return result, err
 }
 
+func (s *OpenTracingLayerTokenStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "TokenStore.PermanentDeleteBatch")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.TokenStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerTokenStore) Save(token model.Token) (*model.Token, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "TokenStore.Save")
@@ -6647,6 +7394,24 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error
 	return result, err
 }
 
+func (s *OpenTracingLayerUserStore) Anonymize(tx boil.ContextTransactor, userID string, email string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserStore.Anonymize")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.UserStore.Anonymize(tx, userID, email)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerUserStore) ClearAllCustomRoleAssignments() error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserStore.ClearAllCustomRoleAssignments")
@@ -7167,7 +7932,7 @@ func (s *OpenTracingLayerUserAccessTokenStore) Delete(tokenID string) error {
 	return err
 }
 
-func (s *OpenTracingLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+func (s *OpenTracingLayerUserAccessTokenStore) DeleteAllForUser(tx boil.ContextTransactor, userID string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserAccessTokenStore.DeleteAllForUser")
 	s.Root.Store.SetContext(newCtx)
@@ -7176,7 +7941,7 @@ func (s *OpenTracingLayerUserAccessTokenStore) DeleteAllForUser(userID string) e
 	}()
 
 	defer span.Finish()
-	err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+	err := s.UserAccessTokenStore.DeleteAllForUser(tx, userID)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -7419,7 +8184,7 @@ func (s *OpenTracingLayerVoucherChannelListingStore) Upsert(tx boil.ContextTrans
 	return result, err
 }
 
-func (s *OpenTracingLayerVoucherCustomerStore) Delete(ids []string) error {
+func (s *OpenTracingLayerVoucherCustomerStore) Delete(tx boil.ContextTransactor, ids []string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "VoucherCustomerStore.Delete")
 	s.Root.Store.SetContext(newCtx)
@@ -7428,7 +8193,7 @@ func (s *OpenTracingLayerVoucherCustomerStore) Delete(ids []string) error {
 	}()
 
 	defer span.Finish()
-	err := s.VoucherCustomerStore.Delete(ids)
+	err := s.VoucherCustomerStore.Delete(tx, ids)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
@@ -7876,11 +8641,13 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.AttributeValueStore = &OpenTracingLayerAttributeValueStore{AttributeValueStore: childStore.AttributeValue(), Root: &newStore}
 	newStore.AttributeValueTranslationStore = &OpenTracingLayerAttributeValueTranslationStore{AttributeValueTranslationStore: childStore.AttributeValueTranslation(), Root: &newStore}
 	newStore.AuditStore = &OpenTracingLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.BackInStockSubscriptionStore = &OpenTracingLayerBackInStockSubscriptionStore{BackInStockSubscriptionStore: childStore.BackInStockSubscription(), Root: &newStore}
 	newStore.CategoryStore = &OpenTracingLayerCategoryStore{CategoryStore: childStore.Category(), Root: &newStore}
 	newStore.CategoryTranslationStore = &OpenTracingLayerCategoryTranslationStore{CategoryTranslationStore: childStore.CategoryTranslation(), Root: &newStore}
 	newStore.ChannelStore = &OpenTracingLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.CheckoutStore = &OpenTracingLayerCheckoutStore{CheckoutStore: childStore.Checkout(), Root: &newStore}
 	newStore.CheckoutLineStore = &OpenTracingLayerCheckoutLineStore{CheckoutLineStore: childStore.CheckoutLine(), Root: &newStore}
+	newStore.CheckoutRecoveryStore = &OpenTracingLayerCheckoutRecoveryStore{CheckoutRecoveryStore: childStore.CheckoutRecovery(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &OpenTracingLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CollectionStore = &OpenTracingLayerCollectionStore{CollectionStore: childStore.Collection(), Root: &newStore}
 	newStore.CollectionChannelListingStore = &OpenTracingLayerCollectionChannelListingStore{CollectionChannelListingStore: childStore.CollectionChannelListing(), Root: &newStore}
@@ -7926,6 +8693,7 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.ProductStore = &OpenTracingLayerProductStore{ProductStore: childStore.Product(), Root: &newStore}
 	newStore.ProductChannelListingStore = &OpenTracingLayerProductChannelListingStore{ProductChannelListingStore: childStore.ProductChannelListing(), Root: &newStore}
 	newStore.ProductMediaStore = &OpenTracingLayerProductMediaStore{ProductMediaStore: childStore.ProductMedia(), Root: &newStore}
+	newStore.ProductRecommendationStore = &OpenTracingLayerProductRecommendationStore{ProductRecommendationStore: childStore.ProductRecommendation(), Root: &newStore}
 	newStore.ProductTranslationStore = &OpenTracingLayerProductTranslationStore{ProductTranslationStore: childStore.ProductTranslation(), Root: &newStore}
 	newStore.ProductTypeStore = &OpenTracingLayerProductTypeStore{ProductTypeStore: childStore.ProductType(), Root: &newStore}
 	newStore.ProductVariantStore = &OpenTracingLayerProductVariantStore{ProductVariantStore: childStore.ProductVariant(), Root: &newStore}
@@ -7940,6 +8708,7 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.ShippingZoneStore = &OpenTracingLayerShippingZoneStore{ShippingZoneStore: childStore.ShippingZone(), Root: &newStore}
 	newStore.ShopStaffStore = &OpenTracingLayerShopStaffStore{ShopStaffStore: childStore.ShopStaff(), Root: &newStore}
 	newStore.ShopTranslationStore = &OpenTracingLayerShopTranslationStore{ShopTranslationStore: childStore.ShopTranslation(), Root: &newStore}
+	newStore.SlugRedirectStore = &OpenTracingLayerSlugRedirectStore{SlugRedirectStore: childStore.SlugRedirect(), Root: &newStore}
 	newStore.StaffNotificationRecipientStore = &OpenTracingLayerStaffNotificationRecipientStore{StaffNotificationRecipientStore: childStore.StaffNotificationRecipient(), Root: &newStore}
 	newStore.StatusStore = &OpenTracingLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.StockStore = &OpenTracingLayerStockStore{StockStore: childStore.Stock(), Root: &newStore}
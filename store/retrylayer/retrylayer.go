@@ -34,11 +34,13 @@ type RetryLayer struct {
 	AttributeValueStore                store.AttributeValueStore
 	AttributeValueTranslationStore     store.AttributeValueTranslationStore
 	AuditStore                         store.AuditStore
+	BackInStockSubscriptionStore       store.BackInStockSubscriptionStore
 	CategoryStore                      store.CategoryStore
 	CategoryTranslationStore           store.CategoryTranslationStore
 	ChannelStore                       store.ChannelStore
 	CheckoutStore                      store.CheckoutStore
 	CheckoutLineStore                  store.CheckoutLineStore
+	CheckoutRecoveryStore              store.CheckoutRecoveryStore
 	ClusterDiscoveryStore              store.ClusterDiscoveryStore
 	CollectionStore                    store.CollectionStore
 	CollectionChannelListingStore      store.CollectionChannelListingStore
@@ -84,6 +86,7 @@ type RetryLayer struct {
 	ProductStore                       store.ProductStore
 	ProductChannelListingStore         store.ProductChannelListingStore
 	ProductMediaStore                  store.ProductMediaStore
+	ProductRecommendationStore         store.ProductRecommendationStore
 	ProductTranslationStore            store.ProductTranslationStore
 	ProductTypeStore                   store.ProductTypeStore
 	ProductVariantStore                store.ProductVariantStore
@@ -98,6 +101,7 @@ type RetryLayer struct {
 	ShippingZoneStore                  store.ShippingZoneStore
 	ShopStaffStore                     store.ShopStaffStore
 	ShopTranslationStore               store.ShopTranslationStore
+	SlugRedirectStore                  store.SlugRedirectStore
 	StaffNotificationRecipientStore    store.StaffNotificationRecipientStore
 	StatusStore                        store.StatusStore
 	StockStore                         store.StockStore
@@ -172,6 +176,10 @@ func (s *RetryLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *RetryLayer) BackInStockSubscription() store.BackInStockSubscriptionStore {
+	return s.BackInStockSubscriptionStore
+}
+
 func (s *RetryLayer) Category() store.CategoryStore {
 	return s.CategoryStore
 }
@@ -192,6 +200,10 @@ func (s *RetryLayer) CheckoutLine() store.CheckoutLineStore {
 	return s.CheckoutLineStore
 }
 
+func (s *RetryLayer) CheckoutRecovery() store.CheckoutRecoveryStore {
+	return s.CheckoutRecoveryStore
+}
+
 func (s *RetryLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -372,6 +384,10 @@ func (s *RetryLayer) ProductMedia() store.ProductMediaStore {
 	return s.ProductMediaStore
 }
 
+func (s *RetryLayer) ProductRecommendation() store.ProductRecommendationStore {
+	return s.ProductRecommendationStore
+}
+
 func (s *RetryLayer) ProductTranslation() store.ProductTranslationStore {
 	return s.ProductTranslationStore
 }
@@ -428,6 +444,10 @@ func (s *RetryLayer) ShopTranslation() store.ShopTranslationStore {
 	return s.ShopTranslationStore
 }
 
+func (s *RetryLayer) SlugRedirect() store.SlugRedirectStore {
+	return s.SlugRedirectStore
+}
+
 func (s *RetryLayer) StaffNotificationRecipient() store.StaffNotificationRecipientStore {
 	return s.StaffNotificationRecipientStore
 }
@@ -562,6 +582,11 @@ type RetryLayerAuditStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerBackInStockSubscriptionStore struct {
+	store.BackInStockSubscriptionStore
+	Root *RetryLayer
+}
+
 type RetryLayerCategoryStore struct {
 	store.CategoryStore
 	Root *RetryLayer
@@ -587,6 +612,11 @@ type RetryLayerCheckoutLineStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerCheckoutRecoveryStore struct {
+	store.CheckoutRecoveryStore
+	Root *RetryLayer
+}
+
 type RetryLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *RetryLayer
@@ -812,6 +842,11 @@ type RetryLayerProductMediaStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerProductRecommendationStore struct {
+	store.ProductRecommendationStore
+	Root *RetryLayer
+}
+
 type RetryLayerProductTranslationStore struct {
 	store.ProductTranslationStore
 	Root *RetryLayer
@@ -882,6 +917,11 @@ type RetryLayerShopTranslationStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerSlugRedirectStore struct {
+	store.SlugRedirectStore
+	Root *RetryLayer
+}
+
 type RetryLayerStaffNotificationRecipientStore struct {
 	store.StaffNotificationRecipientStore
 	Root *RetryLayer
@@ -973,6 +1013,26 @@ func isRepeatableError(err error) bool {
 	return false
 }
 
+func (s *RetryLayerAddressStore) Anonymize(tx boil.ContextTransactor, addressIDs []string) error {
+
+	tries := 0
+	for {
+		err := s.AddressStore.Anonymize(tx, addressIDs)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerAddressStore) DeleteAddresses(tx boil.ContextTransactor, addressIDs []string) error {
 
 	tries := 0
@@ -1313,11 +1373,31 @@ func (s *RetryLayerAssignedProductAttributeStore) GetWithOption(option model_hel
 
 }
 
-func (s *RetryLayerAssignedProductAttributeStore) Save(assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
+func (s *RetryLayerAssignedProductAttributeStore) Save(tx boil.ContextTransactor, assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
+
+	tries := 0
+	for {
+		result, err := s.AssignedProductAttributeStore.Save(tx, assignedProductAttribute)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerAssignedProductAttributeValueStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.AssignedProductAttributeStore.Save(assignedProductAttribute)
+		result, err := s.AssignedProductAttributeValueStore.Delete(tx, ids)
 		if err == nil {
 			return result, nil
 		}
@@ -1373,11 +1453,11 @@ func (s *RetryLayerAssignedProductAttributeValueStore) Get(assignedProductAttrVa
 
 }
 
-func (s *RetryLayerAssignedProductAttributeValueStore) Save(assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
+func (s *RetryLayerAssignedProductAttributeValueStore) Save(tx boil.ContextTransactor, assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
 
 	tries := 0
 	for {
-		result, err := s.AssignedProductAttributeValueStore.Save(assignedProductAttrValues)
+		result, err := s.AssignedProductAttributeValueStore.Save(tx, assignedProductAttrValues)
 		if err == nil {
 			return result, nil
 		}
@@ -1693,6 +1773,26 @@ func (s *RetryLayerAttributeValueStore) Upsert(tx boil.ContextTransactor, values
 
 }
 
+func (s *RetryLayerAuditStore) CountBefore(endTime int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditStore.CountBefore(endTime)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerAuditStore) Get(userID string, offset int, limit int) (model.AuditSlice, error) {
 
 	tries := 0
@@ -1713,6 +1813,26 @@ func (s *RetryLayerAuditStore) Get(userID string, offset int, limit int) (model.
 
 }
 
+func (s *RetryLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerAuditStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
@@ -1753,6 +1873,66 @@ func (s *RetryLayerAuditStore) Save(audit model.Audit) error {
 
 }
 
+func (s *RetryLayerBackInStockSubscriptionStore) Delete(tx boil.ContextTransactor, ids []string) error {
+
+	tries := 0
+	for {
+		err := s.BackInStockSubscriptionStore.Delete(tx, ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerBackInStockSubscriptionStore) FilterByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.BackInStockSubscriptionStore.FilterByOptions(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerBackInStockSubscriptionStore) Save(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, error) {
+
+	tries := 0
+	for {
+		result, err := s.BackInStockSubscriptionStore.Save(subscription)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerCategoryStore) FilterByOption(option model_helper.CategoryFilterOption) (model.CategorySlice, error) {
 
 	tries := 0
@@ -2113,6 +2293,66 @@ func (s *RetryLayerCheckoutLineStore) Upsert(checkoutLines model.CheckoutLineSli
 
 }
 
+func (s *RetryLayerCheckoutRecoveryStore) Delete(tx boil.ContextTransactor, ids []string) error {
+
+	tries := 0
+	for {
+		err := s.CheckoutRecoveryStore.Delete(tx, ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerCheckoutRecoveryStore) FilterByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.CheckoutRecoveryStore.FilterByOptions(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerCheckoutRecoveryStore) Upsert(tx boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, error) {
+
+	tries := 0
+	for {
+		result, err := s.CheckoutRecoveryStore.Upsert(tx, recovery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerClusterDiscoveryStore) Cleanup() error {
 
 	tries := 0
@@ -2513,11 +2753,11 @@ func (s *RetryLayerCsvExportEventStore) Save(event model.ExportEvent) (*model.Ex
 
 }
 
-func (s *RetryLayerCsvExportFileStore) Get(id string) (*model.ExportFile, error) {
+func (s *RetryLayerCsvExportFileStore) CountBefore(endTime int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.CsvExportFileStore.Get(id)
+		result, err := s.CsvExportFileStore.CountBefore(endTime)
 		if err == nil {
 			return result, nil
 		}
@@ -2533,31 +2773,31 @@ func (s *RetryLayerCsvExportFileStore) Get(id string) (*model.ExportFile, error)
 
 }
 
-func (s *RetryLayerCsvExportFileStore) Save(file model.ExportFile) (*model.ExportFile, error) {
+func (s *RetryLayerCsvExportFileStore) Delete(tx boil.ContextTransactor, ids []string) error {
 
 	tries := 0
 	for {
-		result, err := s.CsvExportFileStore.Save(file)
+		err := s.CsvExportFileStore.Delete(tx, ids)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerCustomProductAttributeStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+func (s *RetryLayerCsvExportFileStore) FilterByOptions(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomProductAttributeStore.Delete(tx, ids)
+		result, err := s.CsvExportFileStore.FilterByOptions(options)
 		if err == nil {
 			return result, nil
 		}
@@ -2573,11 +2813,11 @@ func (s *RetryLayerCustomProductAttributeStore) Delete(tx boil.ContextTransactor
 
 }
 
-func (s *RetryLayerCustomProductAttributeStore) FilterByOptions(options model_helper.CustomProductAttributeFilterOptions) (model.CustomProductAttributeSlice, error) {
+func (s *RetryLayerCsvExportFileStore) Get(id string) (*model.ExportFile, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomProductAttributeStore.FilterByOptions(options)
+		result, err := s.CsvExportFileStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -2593,11 +2833,11 @@ func (s *RetryLayerCustomProductAttributeStore) FilterByOptions(options model_he
 
 }
 
-func (s *RetryLayerCustomProductAttributeStore) Upsert(tx boil.ContextTransactor, record model.CustomProductAttribute) (*model.CustomProductAttribute, error) {
+func (s *RetryLayerCsvExportFileStore) Upsert(file model.ExportFile) (*model.ExportFile, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomProductAttributeStore.Upsert(tx, record)
+		result, err := s.CsvExportFileStore.Upsert(file)
 		if err == nil {
 			return result, nil
 		}
@@ -2613,31 +2853,31 @@ func (s *RetryLayerCustomProductAttributeStore) Upsert(tx boil.ContextTransactor
 
 }
 
-func (s *RetryLayerCustomerEventStore) Count() (int64, error) {
+func (s *RetryLayerCustomProductAttributeStore) AssignVariantValues(tx boil.ContextTransactor, productID string, variantID string, inputs []*model_helper.CustomProductAttributeValuesInput) error {
 
 	tries := 0
 	for {
-		result, err := s.CustomerEventStore.Count()
+		err := s.CustomProductAttributeStore.AssignVariantValues(tx, productID, variantID, inputs)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerCustomerEventStore) FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error) {
+func (s *RetryLayerCustomProductAttributeStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomerEventStore.FilterByOptions(options)
+		result, err := s.CustomProductAttributeStore.Delete(tx, ids)
 		if err == nil {
 			return result, nil
 		}
@@ -2653,11 +2893,11 @@ func (s *RetryLayerCustomerEventStore) FilterByOptions(options model_helper.Cust
 
 }
 
-func (s *RetryLayerCustomerEventStore) Get(id string) (*model.CustomerEvent, error) {
+func (s *RetryLayerCustomProductAttributeStore) FilterByOptions(options model_helper.CustomProductAttributeFilterOptions) (model.CustomProductAttributeSlice, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomerEventStore.Get(id)
+		result, err := s.CustomProductAttributeStore.FilterByOptions(options)
 		if err == nil {
 			return result, nil
 		}
@@ -2673,11 +2913,11 @@ func (s *RetryLayerCustomerEventStore) Get(id string) (*model.CustomerEvent, err
 
 }
 
-func (s *RetryLayerCustomerEventStore) Upsert(tx boil.ContextTransactor, customemrEvent model.CustomerEvent) (*model.CustomerEvent, error) {
+func (s *RetryLayerCustomProductAttributeStore) Upsert(tx boil.ContextTransactor, record model.CustomProductAttribute) (*model.CustomProductAttribute, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomerEventStore.Upsert(tx, customemrEvent)
+		result, err := s.CustomProductAttributeStore.Upsert(tx, record)
 		if err == nil {
 			return result, nil
 		}
@@ -2693,11 +2933,11 @@ func (s *RetryLayerCustomerEventStore) Upsert(tx boil.ContextTransactor, custome
 
 }
 
-func (s *RetryLayerCustomerNoteStore) Get(id string) (*model.CustomerNote, error) {
+func (s *RetryLayerCustomerEventStore) Count() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomerNoteStore.Get(id)
+		result, err := s.CustomerEventStore.Count()
 		if err == nil {
 			return result, nil
 		}
@@ -2713,11 +2953,11 @@ func (s *RetryLayerCustomerNoteStore) Get(id string) (*model.CustomerNote, error
 
 }
 
-func (s *RetryLayerCustomerNoteStore) Upsert(note model.CustomerNote) (*model.CustomerNote, error) {
+func (s *RetryLayerCustomerEventStore) CountBefore(endTime int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.CustomerNoteStore.Upsert(note)
+		result, err := s.CustomerEventStore.CountBefore(endTime)
 		if err == nil {
 			return result, nil
 		}
@@ -2733,31 +2973,151 @@ func (s *RetryLayerCustomerNoteStore) Upsert(note model.CustomerNote) (*model.Cu
 
 }
 
-func (s *RetryLayerDigitalContentStore) Delete(tx boil.ContextTransactor, ids []string) error {
+func (s *RetryLayerCustomerEventStore) FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error) {
 
 	tries := 0
 	for {
-		err := s.DigitalContentStore.Delete(tx, ids)
+		result, err := s.CustomerEventStore.FilterByOptions(options)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerDigitalContentStore) FilterByOption(option model_helper.DigitalContentFilterOption) (model.DigitalContentSlice, error) {
+func (s *RetryLayerCustomerEventStore) Get(id string) (*model.CustomerEvent, error) {
 
 	tries := 0
 	for {
-		result, err := s.DigitalContentStore.FilterByOption(option)
+		result, err := s.CustomerEventStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerCustomerEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.CustomerEventStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerCustomerEventStore) Upsert(tx boil.ContextTransactor, customemrEvent model.CustomerEvent) (*model.CustomerEvent, error) {
+
+	tries := 0
+	for {
+		result, err := s.CustomerEventStore.Upsert(tx, customemrEvent)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerCustomerNoteStore) Get(id string) (*model.CustomerNote, error) {
+
+	tries := 0
+	for {
+		result, err := s.CustomerNoteStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerCustomerNoteStore) Upsert(note model.CustomerNote) (*model.CustomerNote, error) {
+
+	tries := 0
+	for {
+		result, err := s.CustomerNoteStore.Upsert(note)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerDigitalContentStore) Delete(tx boil.ContextTransactor, ids []string) error {
+
+	tries := 0
+	for {
+		err := s.DigitalContentStore.Delete(tx, ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerDigitalContentStore) FilterByOption(option model_helper.DigitalContentFilterOption) (model.DigitalContentSlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.DigitalContentStore.FilterByOption(option)
 		if err == nil {
 			return result, nil
 		}
@@ -3113,11 +3473,11 @@ func (s *RetryLayerDiscountVoucherStore) Get(id string) (*model.Voucher, error)
 
 }
 
-func (s *RetryLayerDiscountVoucherStore) Upsert(voucher model.Voucher) (*model.Voucher, error) {
+func (s *RetryLayerDiscountVoucherStore) Upsert(tx boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, error) {
 
 	tries := 0
 	for {
-		result, err := s.DiscountVoucherStore.Upsert(voucher)
+		result, err := s.DiscountVoucherStore.Upsert(tx, voucher)
 		if err == nil {
 			return result, nil
 		}
@@ -4085,6 +4445,26 @@ func (s *RetryLayerOpenExchangeRateStore) GetAll() (model.OpenExchangeRateSlice,
 
 }
 
+func (s *RetryLayerOrderStore) AnonymizeByUser(tx boil.ContextTransactor, userID string, email string) error {
+
+	tries := 0
+	for {
+		err := s.OrderStore.AnonymizeByUser(tx, userID, email)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerOrderStore) BulkUpsert(tx boil.ContextTransactor, orders model.OrderSlice) (model.OrderSlice, error) {
 
 	tries := 0
@@ -4105,6 +4485,26 @@ func (s *RetryLayerOrderStore) BulkUpsert(tx boil.ContextTransactor, orders mode
 
 }
 
+func (s *RetryLayerOrderStore) CountByUserIDs(userIDs []string) ([]*model_helper.OrderCountByUserID, error) {
+
+	tries := 0
+	for {
+		result, err := s.OrderStore.CountByUserIDs(userIDs)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerOrderStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 
 	tries := 0
@@ -4245,6 +4645,106 @@ func (s *RetryLayerOrderDiscountStore) Upsert(tx boil.ContextTransactor, orderDi
 
 }
 
+func (s *RetryLayerOrderEventStore) CountBefore(endTime int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.OrderEventStore.CountBefore(endTime)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerOrderEventStore) FilterByOptions(options model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.OrderEventStore.FilterByOptions(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerOrderEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.OrderEventStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerOrderEventStore) Save(tx boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, error) {
+
+	tries := 0
+	for {
+		result, err := s.OrderEventStore.Save(tx, orderEvent)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerOrderLineStore) Delete(tx boil.ContextTransactor, orderLineIDs []string) error {
+
+	tries := 0
+	for {
+		err := s.OrderLineStore.Delete(tx, orderLineIDs)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerOrderLineStore) FilterbyOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, error) {
 
 	tries := 0
@@ -4325,6 +4825,26 @@ func (s *RetryLayerPageStore) FilterByOptions(options model_helper.PageFilterOpt
 
 }
 
+func (s *RetryLayerPaymentStore) AnonymizeByOrders(tx boil.ContextTransactor, orderIDs []string, email string) error {
+
+	tries := 0
+	for {
+		err := s.PaymentStore.AnonymizeByOrders(tx, orderIDs, email)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerPaymentStore) CancelActivePaymentsOfCheckout(checkoutToken string) error {
 
 	tries := 0
@@ -4860,62 +5380,162 @@ func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 			return nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Save(preferences model.PreferenceSlice) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.Save(preferences)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreorderAllocationStore) Delete(tx boil.ContextTransactor, ids []string) error {
+
+	tries := 0
+	for {
+		err := s.PreorderAllocationStore.Delete(tx, ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreorderAllocationStore) FilterByOption(options model_helper.PreorderAllocationFilterOption) (model.PreorderAllocationSlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreorderAllocationStore.FilterByOption(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPreorderAllocationStore) Upsert(tx boil.ContextTransactor, preorderAllocations model.PreorderAllocationSlice) (model.PreorderAllocationSlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreorderAllocationStore.Upsert(tx, preorderAllocations)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error) {
+
+	tries := 0
+	for {
+		result, err := s.ProductStore.AdvancedFilterQueryBuilder(input)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) Save(preferences model.PreferenceSlice) error {
+func (s *RetryLayerProductStore) CategoryInteractionsOfUser(userID string) ([]*model_helper.ProductCategoryInteraction, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Save(preferences)
+		result, err := s.ProductStore.CategoryInteractionsOfUser(userID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPreorderAllocationStore) Delete(tx boil.ContextTransactor, ids []string) error {
+func (s *RetryLayerProductStore) CountByCategoryIDs(categoryIDs []string) ([]*model_helper.ProductCountByCategoryID, error) {
 
 	tries := 0
 	for {
-		err := s.PreorderAllocationStore.Delete(tx, ids)
+		result, err := s.ProductStore.CountByCategoryIDs(categoryIDs)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPreorderAllocationStore) FilterByOption(options model_helper.PreorderAllocationFilterOption) (model.PreorderAllocationSlice, error) {
+func (s *RetryLayerProductStore) Feed(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreorderAllocationStore.FilterByOption(options)
+		result, err := s.ProductStore.Feed(options)
 		if err == nil {
 			return result, nil
 		}
@@ -4931,11 +5551,11 @@ func (s *RetryLayerPreorderAllocationStore) FilterByOption(options model_helper.
 
 }
 
-func (s *RetryLayerPreorderAllocationStore) Upsert(tx boil.ContextTransactor, preorderAllocations model.PreorderAllocationSlice) (model.PreorderAllocationSlice, error) {
+func (s *RetryLayerProductStore) FilterByOption(option model_helper.ProductFilterOption) (model.ProductSlice, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreorderAllocationStore.Upsert(tx, preorderAllocations)
+		result, err := s.ProductStore.FilterByOption(option)
 		if err == nil {
 			return result, nil
 		}
@@ -4951,17 +5571,11 @@ func (s *RetryLayerPreorderAllocationStore) Upsert(tx boil.ContextTransactor, pr
 
 }
 
-func (s *RetryLayerProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder {
-
-	return s.ProductStore.AdvancedFilterQueryBuilder(input)
-
-}
-
-func (s *RetryLayerProductStore) CountByCategoryIDs(categoryIDs []string) ([]*model_helper.ProductCountByCategoryID, error) {
+func (s *RetryLayerProductStore) FilterByQuery(query squirrel.SelectBuilder) (model.ProductSlice, error) {
 
 	tries := 0
 	for {
-		result, err := s.ProductStore.CountByCategoryIDs(categoryIDs)
+		result, err := s.ProductStore.FilterByQuery(query)
 		if err == nil {
 			return result, nil
 		}
@@ -4977,11 +5591,11 @@ func (s *RetryLayerProductStore) CountByCategoryIDs(categoryIDs []string) ([]*mo
 
 }
 
-func (s *RetryLayerProductStore) FilterByOption(option model_helper.ProductFilterOption) (model.ProductSlice, error) {
+func (s *RetryLayerProductStore) GetForIndexing(productIDs []string) ([]*model_helper.ProductForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.ProductStore.FilterByOption(option)
+		result, err := s.ProductStore.GetForIndexing(productIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -4997,11 +5611,11 @@ func (s *RetryLayerProductStore) FilterByOption(option model_helper.ProductFilte
 
 }
 
-func (s *RetryLayerProductStore) FilterByQuery(query squirrel.SelectBuilder) (model.ProductSlice, error) {
+func (s *RetryLayerProductStore) GetProductsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model_helper.ProductForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.ProductStore.FilterByQuery(query)
+		result, err := s.ProductStore.GetProductsBatchForIndexing(startTime, endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5249,6 +5863,86 @@ func (s *RetryLayerProductMediaStore) Upsert(tx boil.ContextTransactor, medias m
 
 }
 
+func (s *RetryLayerProductRecommendationStore) CoPurchases(channelID string, since int64, minCount int) ([]*model_helper.ProductCoPurchase, error) {
+
+	tries := 0
+	for {
+		result, err := s.ProductRecommendationStore.CoPurchases(channelID, since, minCount)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductRecommendationStore) ProductsForRecommendations(channelID string, now int64, afterID string, limit int) ([]*model_helper.ProductForRecommendation, error) {
+
+	tries := 0
+	for {
+		result, err := s.ProductRecommendationStore.ProductsForRecommendations(channelID, now, afterID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductRecommendationStore) RecommendedProducts(options model_helper.RecommendedProductsOptions) ([]*model_helper.RecommendedProduct, error) {
+
+	tries := 0
+	for {
+		result, err := s.ProductRecommendationStore.RecommendedProducts(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerProductRecommendationStore) ReplaceForChannel(tx boil.ContextTransactor, channelID string, recommendations model.ProductRecommendationSlice) error {
+
+	tries := 0
+	for {
+		err := s.ProductRecommendationStore.ReplaceForChannel(tx, channelID, recommendations)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerProductVariantStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 
 	tries := 0
@@ -5615,6 +6309,26 @@ func (s *RetryLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 
 }
 
+func (s *RetryLayerSessionStore) CountExpiredBefore(endTime int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.CountExpiredBefore(endTime)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
 
 	tries := 0
@@ -5695,11 +6409,31 @@ func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 
 }
 
-func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(userID string) error {
+func (s *RetryLayerSessionStore) PermanentDeleteExpiredBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.PermanentDeleteExpiredBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(tx boil.ContextTransactor, userID string) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.PermanentDeleteSessionsByUser(userID)
+		err := s.SessionStore.PermanentDeleteSessionsByUser(tx, userID)
 		if err == nil {
 			return nil
 		}
@@ -6355,6 +7089,66 @@ func (s *RetryLayerShopTranslationStore) Upsert(translation model.ShopTranslatio
 
 }
 
+func (s *RetryLayerSlugRedirectStore) FilterByOptions(options model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error) {
+
+	tries := 0
+	for {
+		result, err := s.SlugRedirectStore.FilterByOptions(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerSlugRedirectStore) GetByOldSlug(objectType model_helper.SeoObjectType, oldSlug string) (*model.SlugRedirect, error) {
+
+	tries := 0
+	for {
+		result, err := s.SlugRedirectStore.GetByOldSlug(objectType, oldSlug)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerSlugRedirectStore) RecordSlugChange(tx boil.ContextTransactor, objectType model_helper.SeoObjectType, objectID string, newSlug string) error {
+
+	tries := 0
+	for {
+		err := s.SlugRedirectStore.RecordSlugChange(tx, objectType, objectID, newSlug)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerStaffNotificationRecipientStore) FilterByOptions(options model_helper.StaffNotificationRecipientFilterOptions) (model.StaffNotificationRecipientSlice, error) {
 
 	tries := 0
@@ -6941,6 +7735,26 @@ func (s *RetryLayerTokenStore) Cleanup() error {
 
 }
 
+func (s *RetryLayerTokenStore) CountBefore(endTime int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.TokenStore.CountBefore(endTime)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerTokenStore) Delete(token string) error {
 
 	tries := 0
@@ -7001,6 +7815,26 @@ func (s *RetryLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 
 }
 
+func (s *RetryLayerTokenStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.TokenStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerTokenStore) Save(token model.Token) (*model.Token, error) {
 
 	tries := 0
@@ -7221,6 +8055,26 @@ func (s *RetryLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 
 }
 
+func (s *RetryLayerUserStore) Anonymize(tx boil.ContextTransactor, userID string, email string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.Anonymize(tx, userID, email)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerUserStore) ClearAllCustomRoleAssignments() error {
 
 	tries := 0
@@ -7765,11 +8619,11 @@ func (s *RetryLayerUserAccessTokenStore) Delete(tokenID string) error {
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(tx boil.ContextTransactor, userID string) error {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+		err := s.UserAccessTokenStore.DeleteAllForUser(tx, userID)
 		if err == nil {
 			return nil
 		}
@@ -8045,11 +8899,11 @@ func (s *RetryLayerVoucherChannelListingStore) Upsert(tx boil.ContextTransactor,
 
 }
 
-func (s *RetryLayerVoucherCustomerStore) Delete(ids []string) error {
+func (s *RetryLayerVoucherCustomerStore) Delete(tx boil.ContextTransactor, ids []string) error {
 
 	tries := 0
 	for {
-		err := s.VoucherCustomerStore.Delete(ids)
+		err := s.VoucherCustomerStore.Delete(tx, ids)
 		if err == nil {
 			return nil
 		}
@@ -8548,11 +9402,13 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.AttributeValueStore = &RetryLayerAttributeValueStore{AttributeValueStore: childStore.AttributeValue(), Root: &newStore}
 	newStore.AttributeValueTranslationStore = &RetryLayerAttributeValueTranslationStore{AttributeValueTranslationStore: childStore.AttributeValueTranslation(), Root: &newStore}
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.BackInStockSubscriptionStore = &RetryLayerBackInStockSubscriptionStore{BackInStockSubscriptionStore: childStore.BackInStockSubscription(), Root: &newStore}
 	newStore.CategoryStore = &RetryLayerCategoryStore{CategoryStore: childStore.Category(), Root: &newStore}
 	newStore.CategoryTranslationStore = &RetryLayerCategoryTranslationStore{CategoryTranslationStore: childStore.CategoryTranslation(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.CheckoutStore = &RetryLayerCheckoutStore{CheckoutStore: childStore.Checkout(), Root: &newStore}
 	newStore.CheckoutLineStore = &RetryLayerCheckoutLineStore{CheckoutLineStore: childStore.CheckoutLine(), Root: &newStore}
+	newStore.CheckoutRecoveryStore = &RetryLayerCheckoutRecoveryStore{CheckoutRecoveryStore: childStore.CheckoutRecovery(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CollectionStore = &RetryLayerCollectionStore{CollectionStore: childStore.Collection(), Root: &newStore}
 	newStore.CollectionChannelListingStore = &RetryLayerCollectionChannelListingStore{CollectionChannelListingStore: childStore.CollectionChannelListing(), Root: &newStore}
@@ -8598,6 +9454,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.ProductStore = &RetryLayerProductStore{ProductStore: childStore.Product(), Root: &newStore}
 	newStore.ProductChannelListingStore = &RetryLayerProductChannelListingStore{ProductChannelListingStore: childStore.ProductChannelListing(), Root: &newStore}
 	newStore.ProductMediaStore = &RetryLayerProductMediaStore{ProductMediaStore: childStore.ProductMedia(), Root: &newStore}
+	newStore.ProductRecommendationStore = &RetryLayerProductRecommendationStore{ProductRecommendationStore: childStore.ProductRecommendation(), Root: &newStore}
 	newStore.ProductTranslationStore = &RetryLayerProductTranslationStore{ProductTranslationStore: childStore.ProductTranslation(), Root: &newStore}
 	newStore.ProductTypeStore = &RetryLayerProductTypeStore{ProductTypeStore: childStore.ProductType(), Root: &newStore}
 	newStore.ProductVariantStore = &RetryLayerProductVariantStore{ProductVariantStore: childStore.ProductVariant(), Root: &newStore}
@@ -8612,6 +9469,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.ShippingZoneStore = &RetryLayerShippingZoneStore{ShippingZoneStore: childStore.ShippingZone(), Root: &newStore}
 	newStore.ShopStaffStore = &RetryLayerShopStaffStore{ShopStaffStore: childStore.ShopStaff(), Root: &newStore}
 	newStore.ShopTranslationStore = &RetryLayerShopTranslationStore{ShopTranslationStore: childStore.ShopTranslation(), Root: &newStore}
+	newStore.SlugRedirectStore = &RetryLayerSlugRedirectStore{SlugRedirectStore: childStore.SlugRedirect(), Root: &newStore}
 	newStore.StaffNotificationRecipientStore = &RetryLayerStaffNotificationRecipientStore{StaffNotificationRecipientStore: childStore.StaffNotificationRecipient(), Root: &newStore}
 	newStore.StatusStore = &RetryLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.StockStore = &RetryLayerStockStore{StockStore: childStore.Stock(), Root: &newStore}
@@ -72,3 +72,25 @@ func (as *SqlAddressStore) DeleteAddresses(transaction boil.ContextTransactor, a
 	_, err := model.Addresses(model.AddressWhere.ID.IN(addressIDs)).DeleteAll(transaction)
 	return err
 }
+
+// Anonymize clears personal details of given addresses, keeping only their country, country area and city.
+// It skips validation since anonymized addresses are not valid anymore.
+func (as *SqlAddressStore) Anonymize(transaction boil.ContextTransactor, addressIDs []string) error {
+	if transaction == nil {
+		transaction = as.GetMaster()
+	}
+
+	_, err := model.Addresses(model.AddressWhere.ID.IN(addressIDs)).UpdateAll(transaction, model.M{
+		model.AddressColumns.FirstName:         "",
+		model.AddressColumns.LastName:          "",
+		model.AddressColumns.CompanyName:       "",
+		model.AddressColumns.StreetAddress1:    "",
+		model.AddressColumns.StreetAddress2:    "",
+		model.AddressColumns.CityArea:          "",
+		model.AddressColumns.PostalCode:        "",
+		model.AddressColumns.Phone:             "",
+		model.AddressColumns.ValidationSkipped: true,
+		model.AddressColumns.UpdatedAt:         model_helper.GetMillis(),
+	})
+	return err
+}
@@ -59,3 +59,14 @@ func (cs *SqlCustomerEventStore) Count() (int64, error) {
 func (cs *SqlCustomerEventStore) FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error) {
 	return model.CustomerEvents(options.Conditions...).All(cs.GetReplica())
 }
+
+func (cs *SqlCustomerEventStore) CountBefore(endTime int64) (int64, error) {
+	return model.CustomerEvents(model.CustomerEventWhere.Date.LT(endTime)).Count(cs.GetReplica())
+}
+
+// PermanentDeleteBatch deletes up to limit customer events dated before given time, then returns the number of deleted rows
+func (cs *SqlCustomerEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	return model.CustomerEvents(
+		store.BatchCondition(model.TableNames.CustomerEvents, model.CustomerEventColumns.ID, model.CustomerEventColumns.Date+" < ?", limit, endTime),
+	).DeleteAll(cs.GetMaster())
+}
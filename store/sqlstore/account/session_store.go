@@ -102,8 +102,11 @@ func (me *SqlSessionStore) RemoveAllSessions() error {
 	return err
 }
 
-func (me *SqlSessionStore) PermanentDeleteSessionsByUser(userId string) error {
-	_, err := model.Sessions(model.SessionWhere.UserID.EQ(userId)).DeleteAll(me.GetMaster())
+func (me *SqlSessionStore) PermanentDeleteSessionsByUser(tx boil.ContextTransactor, userId string) error {
+	if tx == nil {
+		tx = me.GetMaster()
+	}
+	_, err := model.Sessions(model.SessionWhere.UserID.EQ(userId)).DeleteAll(tx)
 	return err
 }
 
@@ -178,3 +181,18 @@ func (me *SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	}
 
 }
+
+// CountExpiredBefore counts sessions that expired before given time, sessions that never expire are ignored
+func (me *SqlSessionStore) CountExpiredBefore(endTime int64) (int64, error) {
+	return model.Sessions(
+		model.SessionWhere.ExpiresAt.NEQ(0),
+		model.SessionWhere.ExpiresAt.LT(endTime),
+	).Count(me.GetReplica())
+}
+
+// PermanentDeleteExpiredBatch deletes up to limit sessions that expired before given time, then returns the number of deleted rows
+func (me *SqlSessionStore) PermanentDeleteExpiredBatch(endTime int64, limit int64) (int64, error) {
+	return model.Sessions(
+		store.BatchCondition(model.TableNames.Sessions, model.SessionColumns.ID, model.SessionColumns.ExpiresAt+" != 0 AND "+model.SessionColumns.ExpiresAt+" < ?", limit, endTime),
+	).DeleteAll(me.GetMaster())
+}
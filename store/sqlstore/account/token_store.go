@@ -56,3 +56,14 @@ func (s *SqlTokenStore) Cleanup() error {
 func (s *SqlTokenStore) GetAllTokensByType(tokenType model_helper.TokenType) (model.TokenSlice, error) {
 	return model.Tokens(model.TokenWhere.Type.EQ(string(tokenType))).All(s.GetReplica())
 }
+
+func (s *SqlTokenStore) CountBefore(endTime int64) (int64, error) {
+	return model.Tokens(model.TokenWhere.CreatedAt.LT(endTime)).Count(s.GetReplica())
+}
+
+// PermanentDeleteBatch deletes up to limit tokens created before given time, then returns the number of deleted rows
+func (s *SqlTokenStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	return model.Tokens(
+		store.BatchCondition(model.TableNames.Tokens, model.TokenColumns.Token, model.TokenColumns.CreatedAt+" < ?", limit, endTime),
+	).DeleteAll(s.GetMaster())
+}
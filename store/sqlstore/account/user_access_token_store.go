@@ -70,15 +70,29 @@ func (s *SqlUserAccessTokenStore) Delete(tokenId string) error {
 	return nil
 }
 
-func (s *SqlUserAccessTokenStore) DeleteAllForUser(userId string) error {
-	tx, err := s.GetMaster().BeginTx(s.Context(), &sql.TxOptions{})
-	if err != nil {
-		return errors.Wrap(err, "begin_transaction")
+func (s *SqlUserAccessTokenStore) DeleteAllForUser(transaction boil.ContextTransactor, userId string) error {
+	if transaction == nil {
+		tx, err := s.GetMaster().BeginTx(s.Context(), &sql.TxOptions{})
+		if err != nil {
+			return errors.Wrap(err, "begin_transaction")
+		}
+		defer s.FinalizeTransaction(tx)
+
+		if err := s.deleteAllForUser(tx, userId); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrap(err, "commit_transaction")
+		}
+		return nil
 	}
-	defer s.FinalizeTransaction(tx)
 
+	return s.deleteAllForUser(transaction, userId)
+}
+
+func (s *SqlUserAccessTokenStore) deleteAllForUser(transaction boil.ContextTransactor, userId string) error {
 	// delete related user session
-	_, err = tx.ExecContext(
+	_, err := transaction.ExecContext(
 		s.Context(),
 		fmt.Sprintf(
 			"DELETE FROM %s USING %s WHERE %s = %s AND %s = $1",
@@ -94,15 +108,8 @@ func (s *SqlUserAccessTokenStore) DeleteAllForUser(userId string) error {
 	}
 
 	// delete user access token
-	_, err = model.UserAccessTokens(model.UserAccessTokenWhere.UserID.EQ(userId)).DeleteAll(tx)
-	if err != nil {
-		return err
-	}
-
-	if err := tx.Commit(); err != nil {
-		return errors.Wrap(err, "commit_transaction")
-	}
-	return nil
+	_, err = model.UserAccessTokens(model.UserAccessTokenWhere.UserID.EQ(userId)).DeleteAll(transaction)
+	return err
 }
 
 func (s *SqlUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, error) {
@@ -11,6 +11,7 @@ import (
 	"github.com/sitename/sitename/einterfaces"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/store"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
@@ -287,6 +288,38 @@ func (us *SqlUserStore) UpdateAuthData(userId string, service string, authData *
 	return userId, nil
 }
 
+func (us *SqlUserStore) Anonymize(tx boil.ContextTransactor, userID, email string) error {
+	if tx == nil {
+		tx = us.GetMaster()
+	}
+
+	now := model_helper.GetMillis()
+	_, err := model.
+		Users(model.UserWhere.ID.EQ(userID)).
+		UpdateAll(tx, model.M{
+			model.UserColumns.Email:                    email,
+			model.UserColumns.Username:                 "deleted-" + userID,
+			model.UserColumns.FirstName:                "",
+			model.UserColumns.LastName:                 "",
+			model.UserColumns.Nickname:                 "",
+			model.UserColumns.Note:                     nil,
+			model.UserColumns.Props:                    model_types.JSONString{},
+			model.UserColumns.Metadata:                 model_types.JSONString{},
+			model.UserColumns.PrivateMetadata:          model_types.JSONString{},
+			model.UserColumns.DefaultBillingAddressID:  nil,
+			model.UserColumns.DefaultShippingAddressID: nil,
+			model.UserColumns.Password:                 "",
+			model.UserColumns.AuthService:              "",
+			model.UserColumns.AuthData:                 nil,
+			model.UserColumns.MfaActive:                false,
+			model.UserColumns.MfaSecret:                "",
+			model.UserColumns.IsActive:                 false,
+			model.UserColumns.DeleteAt:                 now,
+			model.UserColumns.UpdatedAt:                now,
+		})
+	return err
+}
+
 // UpdateMfaSecret updates mfa secret for current user
 func (us *SqlUserStore) UpdateMfaSecret(userId, secret string) error {
 	updateAt := model_helper.GetMillis()
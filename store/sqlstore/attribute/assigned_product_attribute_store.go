@@ -8,6 +8,7 @@ import (
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
@@ -19,8 +20,25 @@ func NewSqlAssignedProductAttributeStore(s store.Store) store.AssignedProductAtt
 	return &SqlAssignedProductAttributeStore{s}
 }
 
-func (as *SqlAssignedProductAttributeStore) Save(assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
-	panic("unimplemented")
+func (as *SqlAssignedProductAttributeStore) Save(tx boil.ContextTransactor, assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
+	if tx == nil {
+		tx = as.GetMaster()
+	}
+
+	model_helper.AssignedProductAttributePreSave(&assignedProductAttribute)
+	if err := model_helper.AssignedProductAttributeIsValid(assignedProductAttribute); err != nil {
+		return nil, err
+	}
+
+	err := assignedProductAttribute.Insert(tx, boil.Infer())
+	if err != nil {
+		if as.IsUniqueConstraintError(err, []string{"assigned_product_attributes_product_id_assignment_id_key", model.AssignedProductAttributeColumns.ProductID, model.AssignedProductAttributeColumns.AssignmentID}) {
+			return nil, store.NewErrInvalidInput(model.TableNames.AssignedProductAttributes, "ProductID/AssignmentID", "unique")
+		}
+		return nil, err
+	}
+
+	return &assignedProductAttribute, nil
 }
 
 func (as *SqlAssignedProductAttributeStore) Get(id string) (*model.AssignedProductAttribute, error) {
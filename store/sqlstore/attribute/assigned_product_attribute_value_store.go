@@ -17,7 +17,11 @@ func NewSqlAssignedProductAttributeValueStore(s store.Store) store.AssignedProdu
 	return &SqlAssignedProductAttributeValueStore{s}
 }
 
-func (as *SqlAssignedProductAttributeValueStore) Save(assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
+func (as *SqlAssignedProductAttributeValueStore) Save(tx boil.ContextTransactor, assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
+	if tx == nil {
+		tx = as.GetMaster()
+	}
+
 	for _, relation := range assignedProductAttrValues {
 		if relation == nil {
 			continue
@@ -28,7 +32,7 @@ func (as *SqlAssignedProductAttributeValueStore) Save(assignedProductAttrValues
 			return nil, err
 		}
 
-		err := relation.Insert(as.GetMaster(), boil.Infer())
+		err := relation.Insert(tx, boil.Infer())
 		if err != nil {
 			if as.IsUniqueConstraintError(err, []string{"assigned_product_attribute_values_value_id_assignment_id_key", model.AssignedProductAttributeValueColumns.ValueID, model.AssignedProductAttributeValueColumns.AssignmentID}) {
 				return nil, store.NewErrInvalidInput(model.TableNames.AssignedProductAttributeValues, "ValueID/AssignmentID", "unique")
@@ -89,3 +93,11 @@ func (as *SqlAssignedProductAttributeValueStore) SelectForSort(assignmentID stri
 func (s *SqlAssignedProductAttributeValueStore) FilterByOptions(options model_helper.AssignedProductAttributeValueFilterOptions) (model.AssignedProductAttributeValueSlice, error) {
 	return model.AssignedProductAttributeValues(options.Conditions...).All(s.GetReplica())
 }
+
+func (as *SqlAssignedProductAttributeValueStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+	if tx == nil {
+		tx = as.GetMaster()
+	}
+
+	return model.AssignedProductAttributeValues(model.AssignedProductAttributeValueWhere.ID.IN(ids)).DeleteAll(tx)
+}
@@ -1,6 +1,9 @@
 package attribute
 
 import (
+	"github.com/gosimple/slug"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
@@ -56,3 +59,78 @@ func (cpas *SqlCustomProductAttributeStore) Delete(tx boil.ContextTransactor, id
 
 	return model.CustomProductAttributes(model.CustomProductAttributeWhere.ID.IN(ids)).DeleteAll(tx)
 }
+
+func (cpas *SqlCustomProductAttributeStore) AssignVariantValues(tx boil.ContextTransactor, productID, variantID string, inputs []*model_helper.CustomProductAttributeValuesInput) error {
+	if tx == nil {
+		tx = cpas.GetMaster()
+	}
+
+	attributes, err := model.CustomProductAttributes(model.CustomProductAttributeWhere.ProductID.EQ(productID)).All(tx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find custom attributes of product with id=%s", productID)
+	}
+	attributesByID := lo.KeyBy(attributes, func(a *model.CustomProductAttribute) string { return a.ID })
+	attributesBySlug := lo.KeyBy(attributes, func(a *model.CustomProductAttribute) string { return a.Slug })
+
+	for _, input := range inputs {
+		if input == nil {
+			continue
+		}
+
+		var attribute *model.CustomProductAttribute
+		if input.ID != "" {
+			attribute = attributesByID[input.ID]
+			if attribute == nil {
+				return store.NewErrNotFound(model.TableNames.CustomProductAttributes, input.ID)
+			}
+		} else if attribute = attributesBySlug[slug.Make(input.Name)]; attribute == nil {
+			attribute, err = cpas.Upsert(tx, model.CustomProductAttribute{Name: input.Name, ProductID: productID})
+			if err != nil {
+				return err
+			}
+			attributesByID[attribute.ID] = attribute
+			attributesBySlug[attribute.Slug] = attribute
+		}
+
+		existingValues, err := model.CustomProductAttributeValues(model.CustomProductAttributeValueWhere.AttributeID.EQ(attribute.ID)).All(tx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find values of custom attribute with id=%s", attribute.ID)
+		}
+		valuesByValue := lo.KeyBy(existingValues, func(v *model.CustomProductAttributeValue) string { return v.Value })
+
+		// unassign current values of the attribute
+		_, err = model.AssignedProductVariantAttributeValues(
+			model.AssignedProductVariantAttributeValueWhere.VariantID.EQ(variantID),
+			model.AssignedProductVariantAttributeValueWhere.AttributeValueID.IN(lo.Map(existingValues, func(v *model.CustomProductAttributeValue, _ int) string { return v.ID })),
+		).DeleteAll(tx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unassign values of custom attribute with id=%s from variant with id=%s", attribute.ID, variantID)
+		}
+
+		for _, value := range lo.Uniq(input.Values) {
+			attributeValue := valuesByValue[value]
+			if attributeValue == nil {
+				attributeValue = &model.CustomProductAttributeValue{Value: value, AttributeID: attribute.ID}
+				model_helper.CustomProductAttributeValuePreSave(attributeValue)
+				if appErr := model_helper.CustomProductAttributeValueIsValid(*attributeValue); appErr != nil {
+					return appErr
+				}
+				if err = attributeValue.Insert(tx, boil.Infer()); err != nil {
+					return errors.Wrapf(err, "failed to insert value of custom attribute with id=%s", attribute.ID)
+				}
+				valuesByValue[value] = attributeValue
+			}
+
+			assigned := model.AssignedProductVariantAttributeValue{
+				ID:               model_helper.NewId(),
+				AttributeValueID: attributeValue.ID,
+				VariantID:        variantID,
+			}
+			if err = assigned.Insert(tx, boil.Infer()); err != nil {
+				return errors.Wrapf(err, "failed to assign value of custom attribute with id=%s to variant with id=%s", attribute.ID, variantID)
+			}
+		}
+	}
+
+	return nil
+}
@@ -37,3 +37,14 @@ func (s *SqlAuditStore) PermanentDeleteByUser(userId string) error {
 	_, err := model.Audits(model.AuditWhere.UserID.EQ(userId)).DeleteAll(s.GetMaster())
 	return err
 }
+
+func (s *SqlAuditStore) CountBefore(endTime int64) (int64, error) {
+	return model.Audits(model.AuditWhere.CreatedAt.LT(endTime)).Count(s.GetReplica())
+}
+
+// PermanentDeleteBatch deletes up to limit audits created before given time, then returns the number of deleted rows
+func (s *SqlAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	return model.Audits(
+		store.BatchCondition(model.TableNames.Audits, model.AuditColumns.ID, model.AuditColumns.CreatedAt+" < ?", limit, endTime),
+	).DeleteAll(s.GetMaster())
+}
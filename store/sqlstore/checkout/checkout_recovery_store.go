@@ -0,0 +1,60 @@
+package checkout
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type SqlCheckoutRecoveryStore struct {
+	store.Store
+}
+
+func NewSqlCheckoutRecoveryStore(s store.Store) store.CheckoutRecoveryStore {
+	return &SqlCheckoutRecoveryStore{s}
+}
+
+func (cs *SqlCheckoutRecoveryStore) Upsert(tx boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, error) {
+	if tx == nil {
+		tx = cs.GetMaster()
+	}
+
+	isSaving := recovery.ID == ""
+	if isSaving {
+		model_helper.CheckoutRecoveryPreSave(&recovery)
+	}
+
+	if err := model_helper.CheckoutRecoveryIsValid(recovery); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if isSaving {
+		err = recovery.Insert(tx, boil.Infer())
+	} else {
+		_, err = recovery.Update(tx, boil.Blacklist(model.CheckoutRecoveryColumns.CreatedAt))
+	}
+
+	if err != nil {
+		if cs.IsUniqueConstraintError(err, []string{"checkout_recoveries_checkout_token_key"}) {
+			return nil, store.NewErrInvalidInput(model.TableNames.CheckoutRecoveries, "CheckoutToken", recovery.CheckoutToken)
+		}
+		return nil, err
+	}
+
+	return &recovery, nil
+}
+
+func (cs *SqlCheckoutRecoveryStore) FilterByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error) {
+	return model.CheckoutRecoveries(options.Conditions...).All(cs.GetReplica())
+}
+
+func (cs *SqlCheckoutRecoveryStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	if tx == nil {
+		tx = cs.GetMaster()
+	}
+
+	_, err := model.CheckoutRecoveries(model.CheckoutRecoveryWhere.ID.IN(ids)).DeleteAll(tx)
+	return err
+}
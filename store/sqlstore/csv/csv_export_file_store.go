@@ -17,13 +17,24 @@ func NewSqlCsvExportFileStore(s store.Store) store.CsvExportFileStore {
 	return &SqlCsvExportFileStore{s}
 }
 
-func (cs *SqlCsvExportFileStore) Save(file model.ExportFile) (*model.ExportFile, error) {
-	model_helper.ExportFilePreSave(&file)
+func (cs *SqlCsvExportFileStore) Upsert(file model.ExportFile) (*model.ExportFile, error) {
+	isSaving := file.ID == ""
+	if isSaving {
+		model_helper.ExportFilePreSave(&file)
+	} else {
+		model_helper.ExportFilePreUpdate(&file)
+	}
+
 	if err := model_helper.ExportFileIsValid(file); err != nil {
 		return nil, err
 	}
 
-	err := file.Insert(cs.GetMaster(), boil.Infer())
+	var err error
+	if isSaving {
+		err = file.Insert(cs.GetMaster(), boil.Infer())
+	} else {
+		_, err = file.Update(cs.GetMaster(), boil.Blacklist(model.ExportFileColumns.CreatedAt))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,3 +53,25 @@ func (cs *SqlCsvExportFileStore) Get(id string) (*model.ExportFile, error) {
 
 	return record, nil
 }
+
+func (cs *SqlCsvExportFileStore) FilterByOptions(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, error) {
+	return model.ExportFiles(options.Conditions...).All(cs.GetReplica())
+}
+
+// Delete deletes export files with given ids along with their events
+func (cs *SqlCsvExportFileStore) Delete(transaction boil.ContextTransactor, ids []string) error {
+	if transaction == nil {
+		transaction = cs.GetMaster()
+	}
+
+	_, err := model.ExportEvents(model.ExportEventWhere.ExportFileID.IN(ids)).DeleteAll(transaction)
+	if err != nil {
+		return err
+	}
+	_, err = model.ExportFiles(model.ExportFileWhere.ID.IN(ids)).DeleteAll(transaction)
+	return err
+}
+
+func (cs *SqlCsvExportFileStore) CountBefore(endTime int64) (int64, error) {
+	return model.ExportFiles(model.ExportFileWhere.CreatedAt.LT(endTime)).Count(cs.GetReplica())
+}
@@ -52,7 +52,10 @@ func (vcs *SqlVoucherCustomerStore) FilterByOptions(options model_helper.Voucher
 }
 
 // DeleteInBulk deletes given voucher-customers with given id
-func (vcs *SqlVoucherCustomerStore) Delete(ids []string) error {
-	_, err := model.VoucherCustomers(model.VoucherCustomerWhere.ID.IN(ids)).DeleteAll(vcs.GetMaster())
+func (vcs *SqlVoucherCustomerStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	if tx == nil {
+		tx = vcs.GetMaster()
+	}
+	_, err := model.VoucherCustomers(model.VoucherCustomerWhere.ID.IN(ids)).DeleteAll(tx)
 	return err
 }
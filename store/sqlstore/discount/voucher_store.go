@@ -23,7 +23,11 @@ func NewSqlDiscountVoucherStore(sqlStore store.Store) store.DiscountVoucherStore
 	return &SqlVoucherStore{sqlStore}
 }
 
-func (vs *SqlVoucherStore) Upsert(voucher model.Voucher) (*model.Voucher, error) {
+func (vs *SqlVoucherStore) Upsert(tx boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, error) {
+	if tx == nil {
+		tx = vs.GetMaster()
+	}
+
 	isSaving := voucher.ID == ""
 	if isSaving {
 		model_helper.VoucherPreSave(&voucher)
@@ -37,9 +41,9 @@ func (vs *SqlVoucherStore) Upsert(voucher model.Voucher) (*model.Voucher, error)
 
 	var err error
 	if isSaving {
-		err = voucher.Insert(vs.GetMaster(), boil.Infer())
+		err = voucher.Insert(tx, boil.Infer())
 	} else {
-		_, err = voucher.Update(vs.GetMaster(), boil.Blacklist(model.VoucherColumns.CreatedAt))
+		_, err = voucher.Update(tx, boil.Blacklist(model.VoucherColumns.CreatedAt))
 	}
 
 	if err != nil {
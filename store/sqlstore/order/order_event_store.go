@@ -1,7 +1,10 @@
 package order
 
 import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 type SqlOrderEventStore struct {
@@ -12,33 +15,35 @@ func NewSqlOrderEventStore(s store.Store) store.OrderEventStore {
 	return &SqlOrderEventStore{s}
 }
 
-// func (oes *SqlOrderEventStore) Save(transaction boil.ContextTransactor, orderEvent *model.OrderEvent) (*model.OrderEvent, error) {
-// 	if transaction == nil {
-// 		transaction = oes.GetMaster()
-// 	}
+func (oes *SqlOrderEventStore) Save(transaction boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, error) {
+	if transaction == nil {
+		transaction = oes.GetMaster()
+	}
 
-// 	if err := transaction.Create(orderEvent).Error; err != nil {
-// 		return nil, errors.Wrap(err, "failed to save order event")
-// 	}
+	model_helper.OrderEventPreSave(&orderEvent)
+	if err := model_helper.OrderEventIsValid(orderEvent); err != nil {
+		return nil, err
+	}
 
-// 	return orderEvent, nil
-// }
+	err := orderEvent.Insert(transaction, boil.Infer())
+	if err != nil {
+		return nil, err
+	}
 
-// func (oes *SqlOrderEventStore) Get(orderEventID string) (*model.OrderEvent, error) {
-// 	model.FindOrderEvent()
-// }
+	return &orderEvent, nil
+}
 
-// func (s *SqlOrderEventStore) FilterByOptions(options *model.OrderEventFilterOptions) ([]*model.OrderEvent, error) {
-// 	args, err := store.BuildSqlizer(options.Conditions, "OrderEvent_FilterByOptions")
-// 	if err != nil {
-// 		return nil, err
-// 	}
+func (oes *SqlOrderEventStore) FilterByOptions(options model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error) {
+	return model.OrderEvents(options.Conditions...).All(oes.GetReplica())
+}
 
-// 	var res []*model.OrderEvent
-// 	err = s.GetReplica().Find(&res, args...).Error
-// 	if err != nil {
-// 		return nil, errors.Wrap(err, "failed to find order events by given options")
-// 	}
+func (oes *SqlOrderEventStore) CountBefore(endTime int64) (int64, error) {
+	return model.OrderEvents(model.OrderEventWhere.CreatedAt.LT(endTime)).Count(oes.GetReplica())
+}
 
-// 	return res, nil
-// }
+// PermanentDeleteBatch deletes up to limit order events created before given time, then returns the number of deleted rows
+func (oes *SqlOrderEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	return model.OrderEvents(
+		store.BatchCondition(model.TableNames.OrderEvents, model.OrderEventColumns.ID, model.OrderEventColumns.CreatedAt+" < ?", limit, endTime),
+	).DeleteAll(oes.GetMaster())
+}
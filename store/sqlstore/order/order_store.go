@@ -1,6 +1,7 @@
 package order
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/model_types"
 	"github.com/sitename/sitename/store"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -348,3 +350,38 @@ func (s *SqlOrderStore) Delete(transaction boil.ContextTransactor, ids []string)
 
 	return model.Orders(model.OrderWhere.ID.IN(ids)).DeleteAll(transaction)
 }
+
+func (s *SqlOrderStore) CountByUserIDs(userIDs []string) ([]*model_helper.OrderCountByUserID, error) {
+	var res []*model_helper.OrderCountByUserID
+	err := model.Orders(
+		qm.Select(
+			model.OrderTableColumns.UserID,
+			fmt.Sprintf("COUNT (%s) as %q", model.OrderTableColumns.ID, "order_count"),
+		),
+		model_helper.And{
+			squirrel.Eq{model.OrderTableColumns.UserID: userIDs},
+		},
+		model.OrderWhere.Status.NEQ(model.OrderStatusDraft),
+		qm.GroupBy(model.OrderTableColumns.UserID),
+	).Bind(context.Background(), s.GetReplica(), &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count orders by given user ids")
+	}
+
+	return res, nil
+}
+
+// AnonymizeByUser replaces the email and clears the customer note of every order placed by given user.
+// Totals and lines are kept untouched so accounting stays correct.
+func (s *SqlOrderStore) AnonymizeByUser(transaction boil.ContextTransactor, userID string, email string) error {
+	if transaction == nil {
+		transaction = s.GetMaster()
+	}
+
+	_, err := model.Orders(model.OrderWhere.UserID.EQ(model_types.NewNullString(userID))).UpdateAll(transaction, model.M{
+		model.OrderColumns.UserEmail:    email,
+		model.OrderColumns.CustomerNote: "",
+		model.OrderColumns.UpdatedAt:    model_helper.GetMillis(),
+	})
+	return err
+}
@@ -111,3 +111,27 @@ func (ps *SqlPaymentStore) PaymentOwnedByUser(userID, paymentID string) (bool, e
 		},
 	).Exists(ps.GetReplica())
 }
+
+// AnonymizeByOrders replaces the billing email and clears other billing details of payments of given orders.
+// Amounts and gateway references are kept untouched.
+func (ps *SqlPaymentStore) AnonymizeByOrders(transaction boil.ContextTransactor, orderIDs []string, email string) error {
+	if transaction == nil {
+		transaction = ps.GetMaster()
+	}
+
+	_, err := model.Payments(
+		model_helper.And{squirrel.Eq{model.PaymentTableColumns.OrderID: orderIDs}},
+	).UpdateAll(transaction, model.M{
+		model.PaymentColumns.BillingEmail:       email,
+		model.PaymentColumns.BillingFirstName:   "",
+		model.PaymentColumns.BillingLastName:    "",
+		model.PaymentColumns.BillingCompanyName: "",
+		model.PaymentColumns.BillingAddress1:    "",
+		model.PaymentColumns.BillingAddress2:    "",
+		model.PaymentColumns.BillingCityArea:    "",
+		model.PaymentColumns.BillingPostalCode:  "",
+		model.PaymentColumns.CustomerIPAddress:  nil,
+		model.PaymentColumns.UpdatedAt:          model_helper.GetMillis(),
+	})
+	return err
+}
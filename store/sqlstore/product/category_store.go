@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/sitename/sitename/model"
 	"github.com/sitename/sitename/model_helper"
 	"github.com/sitename/sitename/store"
@@ -36,7 +37,7 @@ func (cs *SqlCategoryStore) Upsert(category model.Category) (*model.Category, er
 	if isSaving {
 		err = category.Insert(cs.GetMaster(), boil.Infer())
 	} else {
-		_, err = category.Update(cs.GetMaster(), boil.Infer())
+		err = cs.update(category)
 	}
 
 	if err != nil {
@@ -49,6 +50,29 @@ func (cs *SqlCategoryStore) Upsert(category model.Category) (*model.Category, er
 	return &category, nil
 }
 
+// update updates given category, keeping its old slug as a redirect to it when the slug changes
+func (cs *SqlCategoryStore) update(category model.Category) error {
+	tx, err := cs.GetMaster().BeginTx(cs.Context(), &sql.TxOptions{})
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer cs.FinalizeTransaction(tx)
+
+	err = cs.SlugRedirect().RecordSlugChange(tx, model_helper.SeoObjectTypeCategory, category.ID, category.Slug)
+	if err != nil {
+		return err
+	}
+	_, err = category.Update(tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	return nil
+}
+
 func (cs *SqlCategoryStore) Get(ctx context.Context, categoryID string, allowFromCache bool) (*model.Category, error) {
 	category, err := model.FindCategory(cs.DBXFromContext(ctx), categoryID)
 	if err != nil {
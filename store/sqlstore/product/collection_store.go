@@ -38,7 +38,7 @@ func (cs *SqlCollectionStore) Upsert(collection model.Collection) (*model.Collec
 	if isSaving {
 		err = collection.Insert(cs.GetMaster(), boil.Infer())
 	} else {
-		_, err = collection.Update(cs.GetMaster(), boil.Infer())
+		err = cs.update(collection)
 	}
 
 	if err != nil {
@@ -51,6 +51,29 @@ func (cs *SqlCollectionStore) Upsert(collection model.Collection) (*model.Collec
 	return &collection, nil
 }
 
+// update updates given collection, keeping its old slug as a redirect to it when the slug changes
+func (cs *SqlCollectionStore) update(collection model.Collection) error {
+	tx, err := cs.GetMaster().BeginTx(cs.Context(), &sql.TxOptions{})
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer cs.FinalizeTransaction(tx)
+
+	err = cs.SlugRedirect().RecordSlugChange(tx, model_helper.SeoObjectTypeCollection, collection.ID, collection.Slug)
+	if err != nil {
+		return err
+	}
+	_, err = collection.Update(tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	return nil
+}
+
 func (cs *SqlCollectionStore) Get(collectionID string) (*model.Collection, error) {
 	collection, err := model.FindCollection(cs.GetReplica(), collectionID)
 	if err != nil {
@@ -0,0 +1,327 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gosimple/slug"
+	"github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+type SqlProductRecommendationStore struct {
+	store.Store
+}
+
+func NewSqlProductRecommendationStore(s store.Store) store.ProductRecommendationStore {
+	return &SqlProductRecommendationStore{s}
+}
+
+// CoPurchases counts orders containing both products of each pair. Orders that were never placed
+// (drafts, unconfirmed and expired ones) or were canceled are not counted.
+// Every pair is returned in both directions.
+func (rs *SqlProductRecommendationStore) CoPurchases(channelID string, since int64, minCount int) ([]*model_helper.ProductCoPurchase, error) {
+	orderProductsQuery := rs.GetQueryBuilder(squirrel.Question).
+		Select(
+			model.OrderLineTableColumns.OrderID+" AS order_id",
+			model.ProductVariantTableColumns.ProductID+" AS product_id",
+		).
+		Distinct().
+		From(model.TableNames.OrderLines).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Orders, model.OrderTableColumns.ID, model.OrderLineTableColumns.OrderID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ProductVariants, model.ProductVariantTableColumns.ID, model.OrderLineTableColumns.VariantID)).
+		Where(squirrel.Eq{model.OrderTableColumns.ChannelID: channelID}).
+		Where(squirrel.GtOrEq{model.OrderTableColumns.CreatedAt: since}).
+		Where(squirrel.NotEq{model.OrderTableColumns.Status: []model.OrderStatus{
+			model.OrderStatusDraft,
+			model.OrderStatusUnconfirmed,
+			model.OrderStatusCanceled,
+			model.OrderStatusExpired,
+		}})
+
+	cte := orderProductsQuery.
+		Prefix("WITH order_products AS (").
+		Suffix("), product_orders AS (SELECT product_id, COUNT(*) AS order_count FROM order_products GROUP BY product_id)")
+
+	query, args, err := rs.GetQueryBuilder().
+		Select(
+			"a.product_id",
+			"b.product_id AS other_product_id",
+			"COUNT(*) AS co_purchase_count",
+			"pa.order_count AS product_order_count",
+			"pb.order_count AS other_product_order_count",
+		).
+		PrefixExpr(cte).
+		From("order_products a").
+		InnerJoin("order_products b ON b.order_id = a.order_id AND b.product_id <> a.product_id").
+		InnerJoin("product_orders pa ON pa.product_id = a.product_id").
+		InnerJoin("product_orders pb ON pb.product_id = b.product_id").
+		GroupBy("a.product_id", "b.product_id", "pa.order_count", "pb.order_count").
+		Having("COUNT(*) >= ?", minCount).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "CoPurchases_ToSql")
+	}
+
+	var res []*model_helper.ProductCoPurchase
+	err = queries.Raw(query, args...).Bind(context.Background(), rs.GetReplica(), &res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to count co purchases of products in channel with id=%s", channelID)
+	}
+
+	return res, nil
+}
+
+// recommendations are inserted in batches of this size, so statements stay far below the limit of bind parameters
+const productRecommendationsInsertBatchSize = 1000
+
+func (rs *SqlProductRecommendationStore) ReplaceForChannel(tx boil.ContextTransactor, channelID string, recommendations model.ProductRecommendationSlice) error {
+	if tx == nil {
+		tx = rs.GetMaster()
+	}
+
+	_, err := model.ProductRecommendations(model.ProductRecommendationWhere.ChannelID.EQ(channelID)).DeleteAll(tx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete recommendations of channel with id=%s", channelID)
+	}
+
+	recommendations = lo.Filter(recommendations, func(recommendation *model.ProductRecommendation, _ int) bool { return recommendation != nil })
+	for _, recommendation := range recommendations {
+		recommendation.ChannelID = channelID
+		model_helper.ProductRecommendationPreSave(recommendation)
+		if appErr := model_helper.ProductRecommendationIsValid(*recommendation); appErr != nil {
+			return appErr
+		}
+	}
+
+	for _, batch := range lo.Chunk(recommendations, productRecommendationsInsertBatchSize) {
+		query := rs.GetQueryBuilder().
+			Insert(model.TableNames.ProductRecommendations).
+			Columns(
+				model.ProductRecommendationColumns.ID,
+				model.ProductRecommendationColumns.ChannelID,
+				model.ProductRecommendationColumns.ProductID,
+				model.ProductRecommendationColumns.RecommendedProductID,
+				model.ProductRecommendationColumns.Kind,
+				model.ProductRecommendationColumns.Score,
+				model.ProductRecommendationColumns.CreatedAt,
+			)
+		for _, recommendation := range batch {
+			query = query.Values(
+				recommendation.ID,
+				recommendation.ChannelID,
+				recommendation.ProductID,
+				recommendation.RecommendedProductID,
+				recommendation.Kind,
+				recommendation.Score,
+				recommendation.CreatedAt,
+			)
+		}
+
+		queryString, args, err := query.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "ReplaceForChannel_ToSql")
+		}
+		_, err = queries.Raw(queryString, args...).Exec(tx)
+		if err != nil {
+			if rs.IsUniqueConstraintError(err, []string{"product_recommendations_channel_id_product_id_kind_recommended_product_id_key"}) {
+				return store.NewErrInvalidInput(model.TableNames.ProductRecommendations, "RecommendedProductID", "duplicate")
+			}
+			return errors.Wrapf(err, "failed to insert recommendations of channel with id=%s", channelID)
+		}
+	}
+
+	return nil
+}
+
+func (rs *SqlProductRecommendationStore) ProductsForRecommendations(channelID string, now int64, afterID string, limit int) ([]*model_helper.ProductForRecommendation, error) {
+	inStockSql, inStockArgs, err := rs.inStockInChannelCondition(channelID).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "ProductsForRecommendations_inStock_ToSql")
+	}
+
+	recommendableExpr := fmt.Sprintf(
+		"(%s AND (%s IS NULL OR %s <= ?) AND %s AND %s) AS recommendable",
+		model.ProductChannelListingTableColumns.IsPublished,
+		model.ProductChannelListingTableColumns.PublicationDate,
+		model.ProductChannelListingTableColumns.PublicationDate,
+		model.ProductChannelListingTableColumns.VisibleInListings,
+		inStockSql,
+	)
+
+	query, args, err := rs.GetQueryBuilder().
+		Select(
+			model.ProductTableColumns.ID+" AS id",
+			model.ProductTableColumns.CategoryID+" AS category_id",
+		).
+		Column(squirrel.Expr(recommendableExpr, append([]any{now}, inStockArgs...)...)).
+		From(model.TableNames.Products).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ProductChannelListings, model.ProductChannelListingTableColumns.ProductID, model.ProductTableColumns.ID)).
+		Where(squirrel.Eq{model.ProductChannelListingTableColumns.ChannelID: channelID}).
+		Where(squirrel.Gt{model.ProductTableColumns.ID: afterID}).
+		OrderBy(model.ProductTableColumns.ID).
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "ProductsForRecommendations_ToSql")
+	}
+
+	var res []*model_helper.ProductForRecommendation
+	err = queries.Raw(query, args...).Bind(context.Background(), rs.GetReplica(), &res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find products for recommendations in channel with id=%s", channelID)
+	}
+	if len(res) == 0 {
+		return res, nil
+	}
+
+	terms, err := rs.attributeTermsOfProducts(lo.Map(res, func(p *model_helper.ProductForRecommendation, _ int) string { return p.ID }))
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range res {
+		product.AttributeTerms = terms[product.ID]
+	}
+
+	return res, nil
+}
+
+// attributeTermsOfProducts returns "attribute slug:value slug" of values assigned to given products and their variants,
+// keyed by product ids. Values assigned to variants are free form, their slugs are derived from them like for indexing.
+func (rs *SqlProductRecommendationStore) attributeTermsOfProducts(productIDs []string) (map[string][]string, error) {
+	productValuesQuery := rs.GetQueryBuilder(squirrel.Question).
+		Select(
+			model.AssignedProductAttributeTableColumns.ProductID+" AS product_id",
+			model.AttributeTableColumns.Slug+" AS attribute_slug",
+			model.AttributeValueTableColumns.Slug+" AS value",
+			"FALSE AS variant_value",
+		).
+		From(model.TableNames.AssignedProductAttributes).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.AssignedProductAttributeValues, model.AssignedProductAttributeValueTableColumns.AssignmentID, model.AssignedProductAttributeTableColumns.ID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.AttributeValues, model.AttributeValueTableColumns.ID, model.AssignedProductAttributeValueTableColumns.ValueID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Attributes, model.AttributeTableColumns.ID, model.AttributeValueTableColumns.AttributeID)).
+		Where(squirrel.Eq{model.AssignedProductAttributeTableColumns.ProductID: productIDs})
+
+	variantValuesQuery := rs.GetQueryBuilder(squirrel.Question).
+		Select(
+			model.ProductVariantTableColumns.ProductID+" AS product_id",
+			model.AttributeTableColumns.Slug+" AS attribute_slug",
+			model.AttributeValueTableColumns.Value+" AS value",
+			"TRUE AS variant_value",
+		).
+		From(model.TableNames.AssignedProductVariantAttributeValues).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ProductVariants, model.ProductVariantTableColumns.ID, model.AssignedProductVariantAttributeValueTableColumns.VariantID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.AttributeValues, model.AttributeValueTableColumns.ID, model.AssignedProductVariantAttributeValueTableColumns.AttributeValueID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Attributes, model.AttributeTableColumns.ID, model.AttributeValueTableColumns.AttributeID)).
+		Where(squirrel.Eq{model.ProductVariantTableColumns.ProductID: productIDs})
+
+	query, args, err := productValuesQuery.
+		SuffixExpr(variantValuesQuery.Prefix("UNION ALL")).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "attributeTermsOfProducts_ToSql")
+	}
+
+	var rows []struct {
+		ProductID     string `boil:"product_id"`
+		AttributeSlug string `boil:"attribute_slug"`
+		Value         string `boil:"value"`
+		VariantValue  bool   `boil:"variant_value"`
+	}
+	err = queries.Raw(query, args...).Bind(context.Background(), rs.GetReplica(), &rows)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find attribute values of products")
+	}
+
+	res := map[string][]string{}
+	for _, row := range rows {
+		valueSlug := row.Value
+		if row.VariantValue {
+			valueSlug = slug.Make(row.Value)
+		}
+		term := row.AttributeSlug + ":" + valueSlug
+		if !lo.Contains(res[row.ProductID], term) {
+			res[row.ProductID] = append(res[row.ProductID], term)
+		}
+	}
+	return res, nil
+}
+
+func (rs *SqlProductRecommendationStore) RecommendedProducts(options model_helper.RecommendedProductsOptions) ([]*model_helper.RecommendedProduct, error) {
+	recommendationsQuery := rs.GetQueryBuilder(squirrel.Question).
+		Select(
+			model.ProductRecommendationTableColumns.RecommendedProductID+" AS recommended_product_id",
+			"MAX("+model.ProductRecommendationTableColumns.Score+") AS score",
+		).
+		From(model.TableNames.ProductRecommendations).
+		Where(squirrel.Eq{
+			model.ProductRecommendationTableColumns.ChannelID: options.ChannelID,
+			model.ProductRecommendationTableColumns.ProductID: options.ProductID,
+		}).
+		GroupBy(model.ProductRecommendationTableColumns.RecommendedProductID)
+	if options.Kind != "" {
+		recommendationsQuery = recommendationsQuery.Where(squirrel.Eq{model.ProductRecommendationTableColumns.Kind: options.Kind})
+	}
+
+	recommendationsSql, recommendationsArgs, err := recommendationsQuery.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "RecommendedProducts_recommendations_ToSql")
+	}
+
+	query := rs.Product().
+		VisibleToUserProductsQuery(options.ChannelID, false).
+		Column("recommendations.score AS recommendation_score").
+		InnerJoin(
+			fmt.Sprintf("(%s) AS recommendations ON recommendations.recommended_product_id = %s", recommendationsSql, model.ProductTableColumns.ID),
+			recommendationsArgs...,
+		).
+		Where(rs.inStockInChannelCondition(options.ChannelID)).
+		OrderBy("recommendation_score DESC", model.ProductTableColumns.ID)
+	if options.Limit > 0 {
+		query = query.Limit(uint64(options.Limit))
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "RecommendedProducts_ToSql")
+	}
+
+	var res []*model_helper.RecommendedProduct
+	err = queries.Raw(queryString, args...).Bind(context.Background(), rs.GetReplica(), &res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find recommended products of product with id=%s", options.ProductID)
+	}
+
+	return res, nil
+}
+
+// inStockInChannelCondition checks if a product has a variant with available quantity
+// in a warehouse shipping to given channel
+func (rs *SqlProductRecommendationStore) inStockInChannelCondition(channelID string) squirrel.SelectBuilder {
+	return rs.GetQueryBuilder(squirrel.Question).
+		Select(`(1) AS "a"`).
+		Prefix("EXISTS (").
+		From(model.TableNames.Stocks).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ProductVariants, model.ProductVariantTableColumns.ID, model.StockTableColumns.ProductVariantID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.WarehouseShippingZones, model.WarehouseShippingZoneTableColumns.WarehouseID, model.StockTableColumns.WarehouseID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ShippingZoneChannels, model.ShippingZoneChannelTableColumns.ShippingZoneID, model.WarehouseShippingZoneTableColumns.ShippingZoneID)).
+		Where(squirrel.Expr(model.ProductVariantTableColumns.ProductID + " = " + model.ProductTableColumns.ID)).
+		Where(squirrel.Eq{model.ShippingZoneChannelTableColumns.ChannelID: channelID}).
+		Where(fmt.Sprintf(
+			"%s > COALESCE ((SELECT SUM (%s) FROM %s WHERE %s = %s), 0)",
+			model.StockTableColumns.Quantity,
+			model.AllocationTableColumns.QuantityAllocated,
+			model.TableNames.Allocations,
+			model.AllocationTableColumns.StockID,
+			model.StockTableColumns.ID,
+		)).
+		Suffix(")").
+		Limit(1)
+}
@@ -44,6 +44,10 @@ func (ps *SqlProductStore) Save(tx boil.ContextTransactor, product model.Product
 	if isSaving {
 		err = product.Insert(tx, boil.Infer())
 	} else {
+		err = ps.SlugRedirect().RecordSlugChange(tx, model_helper.SeoObjectTypeProduct, product.ID, product.Slug)
+		if err != nil {
+			return nil, err
+		}
 		_, err = product.Update(tx, boil.Blacklist(model.ProductColumns.CreatedAt))
 	}
 
@@ -226,7 +230,7 @@ func (ps *SqlProductStore) PublishedWithVariants(channelIdOrSlug string) squirre
 		}).
 		Where(squirrel.And{
 			squirrel.Expr(model.ProductChannelListingTableColumns.IsPublished),
-			squirrel.Eq{model.ProductChannelListingTableColumns.ProductID: model.ProductTableColumns.ID},
+			squirrel.Expr(model.ProductChannelListingTableColumns.ProductID + " = " + model.ProductTableColumns.ID),
 		}).
 		Where(channelQuery1).
 		Suffix(")").
@@ -242,7 +246,7 @@ func (ps *SqlProductStore) PublishedWithVariants(channelIdOrSlug string) squirre
 		Where(channelQuery2).
 		Where(squirrel.And{
 			squirrel.Expr(model.ProductVariantChannelListingTableColumns.PriceAmount + " IS NOT NULL"),
-			squirrel.Eq{model.ProductVariantChannelListingTableColumns.VariantID: model.ProductVariantTableColumns.ID},
+			squirrel.Expr(model.ProductVariantChannelListingTableColumns.VariantID + " = " + model.ProductVariantTableColumns.ID),
 		}).
 		Suffix(")").
 		Limit(1)
@@ -253,7 +257,7 @@ func (ps *SqlProductStore) PublishedWithVariants(channelIdOrSlug string) squirre
 		Prefix("EXISTS (").
 		From(model.TableNames.ProductVariants).
 		Where(productVariantChannelListingQuery).
-		Where(squirrel.Eq{model.ProductVariantTableColumns.ProductID: model.ProductTableColumns.ID}).
+		Where(squirrel.Expr(model.ProductVariantTableColumns.ProductID + " = " + model.ProductTableColumns.ID)).
 		Suffix(")").
 		Limit(1)
 
@@ -285,7 +289,7 @@ func (ps *SqlProductStore) VisibleToUserProductsQuery(channelSlugOrID string, us
 			Prefix("EXISTS (").
 			From(model.TableNames.ProductChannelListings).
 			Where(channelQuery).
-			Where(squirrel.Eq{model.ProductChannelListingTableColumns.ProductID: model.ProductTableColumns.ID}).
+			Where(squirrel.Expr(model.ProductChannelListingTableColumns.ProductID + " = " + model.ProductTableColumns.ID)).
 			Suffix(")").
 			Limit(1)
 
@@ -328,16 +332,16 @@ func (ps *SqlProductStore) SelectForUpdateDiscountedPricesOfCatalogues(transacti
 }
 
 // AdvancedFilterQueryBuilder advancedly finds products, filtered using given options
-func (ps *SqlProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder {
+func (ps *SqlProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error) {
 	query := ps.GetQueryBuilder().
 		Select(model.TableNames.Products + ".*").
 		From(model.TableNames.Products)
 
 	if input.Scope == "all" {
-		return query
+		return query, nil
 	}
 	if input.Scope == "ids" {
-		return query.Where(squirrel.Eq{model.ProductTableColumns.ID: input.Ids})
+		return query.Where(squirrel.Eq{model.ProductTableColumns.ID: input.Ids}), nil
 	}
 
 	var channelIdOrSlug string
@@ -370,8 +374,18 @@ func (ps *SqlProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportP
 	if input.Filter.MinimalPrice != nil {
 		query = ps.filterMinimalPrice(query, *input.Filter.MinimalPrice, channelIdOrSlug)
 	}
+	var err error
 	if len(input.Filter.Attributes) > 0 {
-		query = ps.filterAttributes(query, input.Filter.Attributes)
+		query, err = ps.filterAttributes(query, input.Filter.Attributes)
+		if err != nil {
+			return query, err
+		}
+	}
+	if input.Filter.AttributesWhere != nil {
+		query, err = ps.filterAttributesWhere(query, input.Filter.AttributesWhere)
+		if err != nil {
+			return query, err
+		}
 	}
 	if input.Filter.StockAvailability != nil {
 		query = ps.filterStockAvailability(query, *input.Filter.StockAvailability, channelIdOrSlug)
@@ -535,7 +549,7 @@ func (ps *SqlProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportP
 		}
 	}
 
-	return query
+	return query, nil
 }
 
 func (ps *SqlProductStore) FilterByQuery(query squirrel.SelectBuilder) (model.ProductSlice, error) {
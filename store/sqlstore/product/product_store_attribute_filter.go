@@ -0,0 +1,257 @@
+package product
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/measurement"
+	"github.com/sitename/sitename/modules/util"
+)
+
+// regular expressions telling if a text attribute value can be compared as a number, date or date time.
+// NOTE: they avoid "?" since squirrel would take it as a placeholder.
+const (
+	numericValueRegex        = `^-{0,1}[0-9]+([.][0-9]+){0,1}$`
+	numericValuePrefixRegex  = `^-{0,1}[0-9]+[.]{0,1}[0-9]*`
+	dateValuePrefixRegex     = `^[0-9]{4}-[0-9]{2}-[0-9]{2}`
+	dateTimeValuePrefixRegex = `^[0-9]{4}-[0-9]{2}-[0-9]{2}[ T][0-9]{2}:[0-9]{2}:[0-9]{2}`
+
+	attributeDateLayout     = "2006-01-02"
+	attributeDateTimeLayout = "2006-01-02 15:04:05"
+)
+
+// filterAttributesWhere filters products by attribute values, combined as described by given input.
+//
+// A filter is satisfied when one of the following has a matching value:
+//
+//	+) an attribute assigned to the product (attribute_values, through assigned_product_attributes)
+//
+//	+) a custom attribute of the product assigned to its variants (custom_product_attribute_values, through assigned_product_variant_attribute_values)
+//
+// Every filter becomes EXISTS subqueries starting from the filtered attribute, see product_store_doc.md for the generated SQL and indexes.
+func (ps *SqlProductStore) filterAttributesWhere(query squirrel.SelectBuilder, where *model_helper.AttributeWhereInput) (squirrel.SelectBuilder, error) {
+	if err := where.Validate(); err != nil {
+		return query, errors.Wrap(err, "invalid attribute filter")
+	}
+
+	slugs := lo.Uniq(where.Slugs())
+	if len(slugs) == 0 {
+		return query, nil
+	}
+
+	attributes, err := model.Attributes(model.AttributeWhere.Slug.IN(slugs)).All(ps.GetReplica())
+	if err != nil {
+		return query, errors.Wrap(err, "failed to find filtered attributes")
+	}
+	attributesBySlug := lo.KeyBy(attributes, func(attribute *model.Attribute) string { return attribute.Slug })
+
+	if condition := ps.attributeWhereCondition(where, attributesBySlug); condition != nil {
+		query = query.Where(condition)
+	}
+	return query, nil
+}
+
+func (ps *SqlProductStore) attributeWhereCondition(where *model_helper.AttributeWhereInput, attributesBySlug map[string]*model.Attribute) squirrel.Sqlizer {
+	var conditions []squirrel.Sqlizer
+
+	for _, filter := range where.Filters {
+		if filter != nil {
+			conditions = append(conditions, ps.attributeValueFilterCondition(filter, attributesBySlug[filter.Slug]))
+		}
+	}
+	for _, group := range where.Groups {
+		if group == nil {
+			continue
+		}
+		if condition := ps.attributeWhereCondition(group, attributesBySlug); condition != nil {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	switch {
+	case len(conditions) == 0:
+		return nil
+	case where.Operator == model_helper.AttributeFilterOperatorOr:
+		return squirrel.Or(conditions)
+	default:
+		return squirrel.And(conditions)
+	}
+}
+
+// attributeValueFilterCondition checks if a product or its variants have values of the filtered attribute satisfying given filter.
+// attribute is nil if there is no product level attribute with the filtered slug.
+func (ps *SqlProductStore) attributeValueFilterCondition(filter *model_helper.AttributeValueFilter, attribute *model.Attribute) squirrel.Sqlizer {
+	condition := squirrel.Or{ps.variantAttributeValueExists(filter)}
+
+	if attribute != nil {
+		if valueCondition := attributeValueCondition(filter, attribute); valueCondition != nil {
+			condition = append(condition, ps.productAttributeValueExists(attribute.ID, valueCondition))
+		}
+	}
+	return condition
+}
+
+// productAttributeValueExists checks if a value of given attribute, satisfying valueCondition, is assigned to the product.
+func (ps *SqlProductStore) productAttributeValueExists(attributeID string, valueCondition squirrel.Sqlizer) squirrel.Sqlizer {
+	return ps.GetQueryBuilder(squirrel.Question).
+		Select(`(1) AS "a"`).
+		Prefix("EXISTS (").
+		From(model.TableNames.AttributeValues).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.AssignedProductAttributeValues, model.AssignedProductAttributeValueTableColumns.ValueID, model.AttributeValueTableColumns.ID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.AssignedProductAttributes, model.AssignedProductAttributeTableColumns.ID, model.AssignedProductAttributeValueTableColumns.AssignmentID)).
+		Where(squirrel.Eq{model.AttributeValueTableColumns.AttributeID: attributeID}).
+		Where(valueCondition).
+		Where(squirrel.Expr(model.AssignedProductAttributeTableColumns.ProductID + " = " + model.ProductTableColumns.ID)).
+		Suffix(")").
+		Limit(1)
+}
+
+// variantAttributeValueExists checks if a variant of the product is assigned a value of the product's custom attribute with
+// the filtered slug, satisfying the filter. Custom attribute values are texts so they are compared after being parsed.
+func (ps *SqlProductStore) variantAttributeValueExists(filter *model_helper.AttributeValueFilter) squirrel.Sqlizer {
+	return ps.GetQueryBuilder(squirrel.Question).
+		Select(`(1) AS "a"`).
+		Prefix("EXISTS (").
+		From(model.TableNames.CustomProductAttributes).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.CustomProductAttributeValues, model.CustomProductAttributeValueTableColumns.AttributeID, model.CustomProductAttributeTableColumns.ID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.AssignedProductVariantAttributeValues, model.AssignedProductVariantAttributeValueTableColumns.AttributeValueID, model.CustomProductAttributeValueTableColumns.ID)).
+		Where(squirrel.Expr(model.CustomProductAttributeTableColumns.ProductID + " = " + model.ProductTableColumns.ID)).
+		Where(squirrel.Eq{model.CustomProductAttributeTableColumns.Slug: filter.Slug}).
+		Where(customAttributeValueCondition(filter)).
+		Suffix(")").
+		Limit(1)
+}
+
+// attributeValueCondition returns condition on attribute_values satisfying given filter,
+// nil if the filter does not apply to input type of the attribute.
+func attributeValueCondition(filter *model_helper.AttributeValueFilter, attribute *model.Attribute) squirrel.Sqlizer {
+	switch {
+	case len(filter.Values) > 0:
+		return squirrel.Eq{model.AttributeValueTableColumns.Slug: filter.Values}
+
+	case filter.ValuesRange != nil:
+		if attribute.InputType != model.AttributeInputTypeNumeric {
+			return nil
+		}
+
+		gte, lte := filter.ValuesRange.Gte, filter.ValuesRange.Lte
+		if filter.ValuesRange.Unit != nil && !attribute.Unit.IsNil() {
+			var err error
+			if gte, err = convertAttributeAmount(gte, *filter.ValuesRange.Unit, *attribute.Unit.String); err != nil {
+				return nil // units of different kinds never match
+			}
+			if lte, err = convertAttributeAmount(lte, *filter.ValuesRange.Unit, *attribute.Unit.String); err != nil {
+				return nil
+			}
+		}
+
+		// numeric values are stored as names of attribute values
+		numericExpr := fmt.Sprintf("(CASE WHEN %[1]s ~ '%[2]s' THEN %[1]s::float8 END)", model.AttributeValueTableColumns.Name, numericValueRegex)
+		return rangeCondition(numericExpr, gte, lte)
+
+	case filter.Boolean != nil:
+		if attribute.InputType != model.AttributeInputTypeBoolean {
+			return nil
+		}
+		return squirrel.Eq{model.AttributeValueTableColumns.Boolean: *filter.Boolean}
+
+	case filter.Date != nil:
+		if attribute.InputType != model.AttributeInputTypeDate {
+			return nil
+		}
+
+		condition := squirrel.And{}
+		if filter.Date.Gte != nil {
+			condition = append(condition, squirrel.GtOrEq{model.AttributeValueTableColumns.Datetime: util.StartOfDay(filter.Date.Gte.UTC())})
+		}
+		if filter.Date.Lte != nil {
+			condition = append(condition, squirrel.Lt{model.AttributeValueTableColumns.Datetime: util.StartOfDay(filter.Date.Lte.UTC()).Add(24 * time.Hour)})
+		}
+		return condition
+
+	case filter.DateTime != nil:
+		if attribute.InputType != model.AttributeInputTypeDateTime {
+			return nil
+		}
+		return rangeCondition(model.AttributeValueTableColumns.Datetime, filter.DateTime.Gte, filter.DateTime.Lte)
+
+	case len(filter.ReferenceIDs) > 0:
+		if attribute.InputType != model.AttributeInputTypeReference {
+			return nil
+		}
+		// reference values have slug in form of "<attribute value id>_<referenced entity id>"
+		return squirrel.Eq{"SPLIT_PART(" + model.AttributeValueTableColumns.Slug + ", '_', 2)": filter.ReferenceIDs}
+	}
+
+	return nil
+}
+
+// customAttributeValueCondition returns condition on custom_product_attribute_values satisfying given filter.
+// Custom attributes have no units, ranges are compared with leading numbers of the values.
+func customAttributeValueCondition(filter *model_helper.AttributeValueFilter) squirrel.Sqlizer {
+	value := model.CustomProductAttributeValueTableColumns.Value
+
+	switch {
+	case len(filter.Values) > 0:
+		slugs := lo.Map(filter.Values, func(slug string, _ int) string { return strings.ToLower(slug) })
+		return squirrel.Eq{fmt.Sprintf("LOWER(REPLACE(%s, ' ', '-'))", value): slugs}
+
+	case filter.ValuesRange != nil:
+		numericExpr := fmt.Sprintf("(CASE WHEN %[1]s ~ '%[2]s' THEN SUBSTRING(%[1]s FROM '%[2]s')::float8 END)", value, numericValuePrefixRegex)
+		return rangeCondition(numericExpr, filter.ValuesRange.Gte, filter.ValuesRange.Lte)
+
+	case filter.Boolean != nil:
+		texts := lo.Ternary(*filter.Boolean, []string{"true", "yes", "1"}, []string{"false", "no", "0"})
+		return squirrel.Eq{"LOWER(" + value + ")": texts}
+
+	case filter.Date != nil:
+		// ISO dates are compared as texts
+		dateExpr := fmt.Sprintf("(CASE WHEN %[1]s ~ '%[2]s' THEN LEFT(%[1]s, 10) END)", value, dateValuePrefixRegex)
+		return rangeCondition(dateExpr, formatAttributeTime(filter.Date.Gte, attributeDateLayout), formatAttributeTime(filter.Date.Lte, attributeDateLayout))
+
+	case filter.DateTime != nil:
+		dateTimeExpr := fmt.Sprintf("(CASE WHEN %[1]s ~ '%[2]s' THEN REPLACE(LEFT(%[1]s, 19), 'T', ' ') END)", value, dateTimeValuePrefixRegex)
+		return rangeCondition(dateTimeExpr, formatAttributeTime(filter.DateTime.Gte, attributeDateTimeLayout), formatAttributeTime(filter.DateTime.Lte, attributeDateTimeLayout))
+
+	case len(filter.ReferenceIDs) > 0:
+		return squirrel.Eq{value: filter.ReferenceIDs}
+	}
+
+	return squirrel.Expr("FALSE")
+}
+
+// rangeCondition checks expr is between non nil bounds, inclusively
+func rangeCondition[T any](expr string, gte, lte *T) squirrel.Sqlizer {
+	condition := squirrel.And{}
+	if gte != nil {
+		condition = append(condition, squirrel.Expr(expr+" >= ?", *gte))
+	}
+	if lte != nil {
+		condition = append(condition, squirrel.Expr(expr+" <= ?", *lte))
+	}
+	return condition
+}
+
+func convertAttributeAmount(amount *float64, from, to string) (*float64, error) {
+	if amount == nil {
+		return nil, nil
+	}
+	res, err := measurement.ConvertAmount(*amount, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func formatAttributeTime(t *time.Time, layout string) *string {
+	if t == nil {
+		return nil
+	}
+	return model_helper.GetPointerOfValue(t.UTC().Format(layout))
+}
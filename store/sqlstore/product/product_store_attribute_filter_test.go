@@ -0,0 +1,129 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/mattermost/squirrel"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/measurement"
+	"github.com/sitename/sitename/modules/model_types"
+	"github.com/sitename/sitename/store"
+	"github.com/stretchr/testify/require"
+)
+
+// queryBuilderStore only builds queries, attribute filter conditions need nothing else
+type queryBuilderStore struct {
+	store.Store
+}
+
+func (queryBuilderStore) GetQueryBuilder(placeholderFormats ...squirrel.PlaceholderFormat) squirrel.StatementBuilderType {
+	if len(placeholderFormats) == 0 {
+		return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	}
+	return squirrel.StatementBuilder.PlaceholderFormat(placeholderFormats[0])
+}
+
+func TestAttributeWhereCondition(t *testing.T) {
+	ps := &SqlProductStore{queryBuilderStore{}}
+
+	width := &model.Attribute{
+		ID:        model_helper.NewId(),
+		Slug:      "width",
+		InputType: model.AttributeInputTypeNumeric,
+		Unit:      model_types.NewNullString(string(measurement.CM)),
+	}
+	attributesBySlug := map[string]*model.Attribute{width.Slug: width}
+
+	// color and size are custom attributes of variants only
+	color := &model_helper.AttributeValueFilter{Slug: "color", Values: []string{"red"}}
+	size := &model_helper.AttributeValueFilter{Slug: "size", Values: []string{"xl"}}
+
+	toSql := func(t *testing.T, where *model_helper.AttributeWhereInput) (string, []any) {
+		t.Helper()
+
+		condition := ps.attributeWhereCondition(where, attributesBySlug)
+		require.NotNil(t, condition)
+		sql, args, err := condition.ToSql()
+		require.NoError(t, err)
+		return sql, args
+	}
+
+	t.Run("and", func(t *testing.T) {
+		sql, args := toSql(t, &model_helper.AttributeWhereInput{Filters: []*model_helper.AttributeValueFilter{color, size}})
+		require.Contains(t, sql, "LIMIT 1 )) AND (EXISTS (")
+		require.NotContains(t, sql, " OR ")
+		require.Equal(t, []any{"color", "red", "size", "xl"}, args)
+	})
+
+	t.Run("or", func(t *testing.T) {
+		sql, args := toSql(t, &model_helper.AttributeWhereInput{
+			Operator: model_helper.AttributeFilterOperatorOr,
+			Filters:  []*model_helper.AttributeValueFilter{color, size},
+		})
+		require.Contains(t, sql, "LIMIT 1 )) OR (EXISTS (")
+		require.NotContains(t, sql, ")) AND (")
+		require.Equal(t, []any{"color", "red", "size", "xl"}, args)
+	})
+
+	t.Run("range in other unit", func(t *testing.T) {
+		one, two := 1.0, 2.0
+		sql, args := toSql(t, &model_helper.AttributeWhereInput{
+			Filters: []*model_helper.AttributeValueFilter{
+				{Slug: width.Slug, ValuesRange: &model_helper.AttributeNumericRange{Gte: &one, Lte: &two, Unit: model_helper.GetPointerOfValue(string(measurement.M))}},
+			},
+		})
+
+		// variant values are compared as they are, product values after being converted into centimeters
+		require.Contains(t, sql, model.TableNames.CustomProductAttributes)
+		require.Contains(t, sql, model.TableNames.AssignedProductAttributes)
+		require.Len(t, args, 6)
+		require.Equal(t, []any{width.Slug, one, two, width.ID}, args[:4])
+		require.InDelta(t, 100, args[4], 1e-9)
+		require.InDelta(t, 200, args[5], 1e-9)
+	})
+
+	t.Run("or group inside and", func(t *testing.T) {
+		gte := 10.0
+		sql, args := toSql(t, &model_helper.AttributeWhereInput{
+			Filters: []*model_helper.AttributeValueFilter{color},
+			Groups: []*model_helper.AttributeWhereInput{{
+				Operator: model_helper.AttributeFilterOperatorOr,
+				Filters: []*model_helper.AttributeValueFilter{
+					size,
+					{Slug: width.Slug, ValuesRange: &model_helper.AttributeNumericRange{Gte: &gte}},
+				},
+			}},
+		})
+
+		require.Contains(t, sql, "LIMIT 1 )) AND ((EXISTS (")
+		require.Contains(t, sql, "LIMIT 1 )) OR (EXISTS (")
+		require.Equal(t, []any{"color", "red", "size", "xl", width.Slug, gte, width.ID, gte}, args)
+	})
+
+	t.Run("unit of other kind", func(t *testing.T) {
+		gte := 1.0
+		_, args := toSql(t, &model_helper.AttributeWhereInput{
+			Filters: []*model_helper.AttributeValueFilter{
+				{Slug: width.Slug, ValuesRange: &model_helper.AttributeNumericRange{Gte: &gte, Unit: model_helper.GetPointerOfValue(string(measurement.KG))}},
+			},
+		})
+		// product values never match, only variant values are checked
+		require.Equal(t, []any{width.Slug, gte}, args)
+	})
+}
+
+func TestFilterAttributesWhereInvalidInput(t *testing.T) {
+	ps := &SqlProductStore{queryBuilderStore{}}
+	query := ps.GetQueryBuilder().Select("*").From(model.TableNames.Products)
+
+	for name, where := range map[string]*model_helper.AttributeWhereInput{
+		"unknown operator":    {Operator: "XOR", Filters: []*model_helper.AttributeValueFilter{{Slug: "color", Values: []string{"red"}}}},
+		"missing slug":        {Filters: []*model_helper.AttributeValueFilter{{Values: []string{"red"}}}},
+		"no condition":        {Filters: []*model_helper.AttributeValueFilter{{Slug: "color"}}},
+		"invalid nested unit": {Groups: []*model_helper.AttributeWhereInput{{Filters: []*model_helper.AttributeValueFilter{{Slug: "width", ValuesRange: &model_helper.AttributeNumericRange{Lte: model_helper.GetPointerOfValue(1.0), Unit: model_helper.GetPointerOfValue("parsec")}}}}}},
+	} {
+		_, err := ps.filterAttributesWhere(query, where)
+		require.Error(t, err, name)
+	}
+}
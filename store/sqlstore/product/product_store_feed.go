@@ -0,0 +1,175 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+const (
+	// productFeedRecencyHalfLife is the product age (in milliseconds) at which recency score is halved
+	productFeedRecencyHalfLife = 30 * 24 * 60 * 60 * 1000
+	// productFeedRankScale turns float scores into integer ranks, so pages compare exact values
+	productFeedRankScale = 1_000_000
+)
+
+// CategoryInteractionsOfUser counts products of each category the user ordered (drafts excluded) or added to wishlist
+func (ps *SqlProductStore) CategoryInteractionsOfUser(userID string) ([]*model_helper.ProductCategoryInteraction, error) {
+	orderedQuery := ps.GetQueryBuilder(squirrel.Question).
+		Select(
+			model.ProductTableColumns.CategoryID+" AS category_id",
+			"COUNT(*) AS ordered_count",
+			"0 AS wishlisted_count",
+		).
+		From(model.TableNames.OrderLines).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Orders, model.OrderTableColumns.ID, model.OrderLineTableColumns.OrderID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ProductVariants, model.ProductVariantTableColumns.ID, model.OrderLineTableColumns.VariantID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Products, model.ProductTableColumns.ID, model.ProductVariantTableColumns.ProductID)).
+		Where(squirrel.Eq{model.OrderTableColumns.UserID: userID}).
+		Where(squirrel.NotEq{model.OrderTableColumns.Status: model.OrderStatusDraft}).
+		GroupBy(model.ProductTableColumns.CategoryID)
+
+	wishlistedQuery := ps.GetQueryBuilder(squirrel.Question).
+		Select(
+			model.ProductTableColumns.CategoryID+" AS category_id",
+			"0 AS ordered_count",
+			"COUNT(*) AS wishlisted_count",
+		).
+		From(model.TableNames.WishlistItems).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Wishlists, model.WishlistTableColumns.ID, model.WishlistItemTableColumns.WishlistID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.Products, model.ProductTableColumns.ID, model.WishlistItemTableColumns.ProductID)).
+		Where(squirrel.Eq{model.WishlistTableColumns.UserID: userID}).
+		GroupBy(model.ProductTableColumns.CategoryID)
+
+	query, args, err := ps.GetQueryBuilder().
+		Select(
+			"interactions.category_id",
+			"SUM(interactions.ordered_count) AS ordered_count",
+			"SUM(interactions.wishlisted_count) AS wishlisted_count",
+		).
+		FromSelect(orderedQuery.SuffixExpr(wishlistedQuery.Prefix("UNION ALL")), "interactions").
+		GroupBy("interactions.category_id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "CategoryInteractionsOfUser_ToSql")
+	}
+
+	var res []*model_helper.ProductCategoryInteraction
+	err = queries.Raw(query, args...).Bind(context.Background(), ps.GetReplica(), &res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to count category interactions of user with id=%s", userID)
+	}
+
+	return res, nil
+}
+
+// Feed finds products visible in given channel, ranked by their feed ranks descending then ids.
+// The rank is the feed score scaled to an integer, next pages are found by comparing it with the cursor.
+//
+// Score of a product is the sum of:
+//
+//	+) weight of its category in options.CategoryWeights
+//
+//	+) options.RecencyWeight, halved every 30 days of the product's age
+//
+//	+) options.PopularityWeight * ln(1 + number of times the product was ordered since options.PopularitySince until options.Now)
+func (ps *SqlProductStore) Feed(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error) {
+	scoreExpr, scoreArgs := productFeedScoreExpr(options)
+
+	scoredQuery := ps.
+		VisibleToUserProductsQuery(options.ChannelIdOrSlug, false).
+		Column(squirrel.Expr("("+scoreExpr+") AS feed_score", scoreArgs...))
+
+	query := ps.GetQueryBuilder().
+		Select("feed.*", fmt.Sprintf("ROUND(feed.feed_score * %d)::bigint AS feed_rank", productFeedRankScale)).
+		FromSelect(scoredQuery, "feed").
+		OrderBy("feed_rank DESC", "feed.id DESC").
+		Limit(uint64(options.Limit))
+
+	if cursor := options.Cursor; cursor != nil {
+		query = query.Where(fmt.Sprintf("(ROUND(feed.feed_score * %d)::bigint, feed.id) < (?, ?)", productFeedRankScale), cursor.Rank, cursor.ProductID)
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Feed_ToSql")
+	}
+
+	var res []*model_helper.ProductFeedItem
+	err = queries.Raw(queryString, args...).Bind(context.Background(), ps.GetReplica(), &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find product feed")
+	}
+
+	return res, nil
+}
+
+func productFeedScoreExpr(options model_helper.ProductFeedOptions) (string, []any) {
+	var (
+		terms []string
+		args  []any
+	)
+
+	if len(options.CategoryWeights) > 0 {
+		// sort category ids to keep the query deterministic
+		categoryIDs := make([]string, 0, len(options.CategoryWeights))
+		for categoryID := range options.CategoryWeights {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+		sort.Strings(categoryIDs)
+
+		var caseExpr strings.Builder
+		caseExpr.WriteString("CASE " + model.ProductTableColumns.CategoryID)
+		for _, categoryID := range categoryIDs {
+			caseExpr.WriteString(" WHEN ? THEN ?::float8")
+			args = append(args, categoryID, options.CategoryWeights[categoryID])
+		}
+		caseExpr.WriteString(" ELSE 0 END")
+		terms = append(terms, caseExpr.String())
+	}
+
+	if options.RecencyWeight > 0 {
+		terms = append(terms, fmt.Sprintf(
+			"?::float8 * POWER(0.5, GREATEST(?::bigint - %s, 0)::float8 / %d)",
+			model.ProductTableColumns.CreatedAt,
+			productFeedRecencyHalfLife,
+		))
+		args = append(args, options.RecencyWeight, options.Now)
+	}
+
+	if options.PopularityWeight > 0 {
+		terms = append(terms, fmt.Sprintf(
+			`?::float8 * LN(1 + (
+				SELECT COUNT(*) FROM %[1]s
+				INNER JOIN %[2]s ON %[3]s = %[4]s
+				INNER JOIN %[5]s ON %[6]s = %[7]s
+				WHERE %[8]s = %[9]s AND %[10]s >= ? AND %[10]s < ? AND %[11]s <> '%[12]s'
+			))`,
+			model.TableNames.OrderLines,                // 1
+			model.TableNames.Orders,                    // 2
+			model.OrderTableColumns.ID,                 // 3
+			model.OrderLineTableColumns.OrderID,        // 4
+			model.TableNames.ProductVariants,           // 5
+			model.ProductVariantTableColumns.ID,        // 6
+			model.OrderLineTableColumns.VariantID,      // 7
+			model.ProductVariantTableColumns.ProductID, // 8
+			model.ProductTableColumns.ID,               // 9
+			model.OrderTableColumns.CreatedAt,          // 10
+			model.OrderTableColumns.Status,             // 11
+			model.OrderStatusDraft,                     // 12
+		))
+		args = append(args, options.PopularityWeight, options.PopularitySince, options.Now)
+	}
+
+	if len(terms) == 0 {
+		return "0::float8", nil
+	}
+	return strings.Join(terms, " + "), args
+}
@@ -0,0 +1,38 @@
+package product
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sitename/sitename/model_helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductFeedScoreExpr(t *testing.T) {
+	t.Run("no weights", func(t *testing.T) {
+		expr, args := productFeedScoreExpr(model_helper.ProductFeedOptions{Now: 1000})
+		require.Equal(t, "0::float8", expr)
+		require.Empty(t, args)
+	})
+
+	t.Run("scores only depend on the options", func(t *testing.T) {
+		options := model_helper.ProductFeedOptions{
+			CategoryWeights:  map[string]float64{"b": 0.5, "a": 1, "c": 0.25},
+			RecencyWeight:    0.3,
+			PopularityWeight: 0.5,
+			PopularitySince:  500,
+			Now:              1000,
+		}
+
+		expr, args := productFeedScoreExpr(options)
+		for range 10 {
+			sameExpr, sameArgs := productFeedScoreExpr(options)
+			require.Equal(t, expr, sameExpr)
+			require.Equal(t, args, sameArgs)
+		}
+
+		// categories are sorted, then recency and popularity, whose orders are bounded by the feed time
+		require.Equal(t, []any{"a", 1.0, "b", 0.5, "c", 0.25, 0.3, int64(1000), 0.5, int64(500), int64(1000)}, args)
+		require.Equal(t, len(args), strings.Count(expr, "?"))
+	})
+}
@@ -0,0 +1,206 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gosimple/slug"
+	"github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/modules/util"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func (ps *SqlProductStore) GetProductsBatchForIndexing(startTime, endTime int64, limit int) ([]*model_helper.ProductForIndexing, error) {
+	return ps.getForIndexing(
+		model.ProductWhere.CreatedAt.GTE(startTime),
+		model.ProductWhere.CreatedAt.LT(endTime),
+		qm.OrderBy(model.ProductColumns.CreatedAt),
+		qm.Limit(limit),
+	)
+}
+
+func (ps *SqlProductStore) GetForIndexing(productIDs []string) ([]*model_helper.ProductForIndexing, error) {
+	return ps.getForIndexing(
+		model.ProductWhere.ID.IN(productIDs),
+		qm.OrderBy(model.ProductColumns.CreatedAt),
+	)
+}
+
+func (ps *SqlProductStore) getForIndexing(conds ...qm.QueryMod) ([]*model_helper.ProductForIndexing, error) {
+	products, err := model.Products(append(
+		conds,
+		qm.Load(model.ProductRels.Category),
+		qm.Load(model.ProductRels.ProductCollections+"."+model.ProductCollectionRels.Collection),
+		qm.Load(model.ProductRels.ProductChannelListings),
+		qm.Load(model.ProductRels.AssignedProductAttributes+"."+model.AssignedProductAttributeRels.AssignmentAssignedProductAttributeValues+"."+model.AssignedProductAttributeValueRels.Value+"."+model.AttributeValueRels.Attribute),
+		qm.Load(model.ProductRels.ProductVariants),
+		qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.VariantProductVariantChannelListings),
+		qm.Load(model.ProductRels.ProductVariants+"."+model.ProductVariantRels.VariantAssignedProductVariantAttributeValues+"."+model.AssignedProductVariantAttributeValueRels.AttributeValue+"."+model.AttributeValueRels.Attribute),
+	)...).All(ps.GetReplica())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find products for indexing")
+	}
+	if len(products) == 0 {
+		return []*model_helper.ProductForIndexing{}, nil
+	}
+
+	inStockChannels, err := ps.inStockChannelsOfProducts(lo.Map(products, func(p *model.Product, _ int) string { return p.ID }))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model_helper.ProductForIndexing, 0, len(products))
+
+	for _, product := range products {
+		item := &model_helper.ProductForIndexing{
+			Id:          product.ID,
+			Name:        product.Name,
+			Slug:        product.Slug,
+			Description: product.DescriptionPlainText,
+			CategoryID:  product.CategoryID,
+			CreatedAt:   product.CreatedAt,
+		}
+		if item.Description == "" {
+			item.Description = util.EditorJSPlainText(product.Description)
+		}
+		if category := product.R.GetCategory(); category != nil {
+			item.CategoryName = category.Name
+		}
+		if product.Rating.Float32 != nil {
+			item.Rating = float64(*product.Rating.Float32)
+		}
+
+		for _, relation := range product.R.GetProductCollections() {
+			if collection := relation.R.GetCollection(); collection != nil {
+				item.Collections = append(item.Collections, &model_helper.ProductCollectionForIndexing{Id: collection.ID, Name: collection.Name})
+			}
+		}
+
+		for _, assignedAttribute := range product.R.GetAssignedProductAttributes() {
+			for _, assignedValue := range assignedAttribute.R.GetAssignmentAssignedProductAttributeValues() {
+				value := assignedValue.R.GetValue()
+				if value == nil || value.R.GetAttribute() == nil {
+					continue
+				}
+				item.Attributes = appendAttributeValueForIndexing(item.Attributes, value.R.GetAttribute().Slug, value.Slug, value.Name)
+			}
+		}
+
+		// minimal prices of variants per channel
+		minimalPrices := map[string]float64{}
+		for _, variant := range product.R.GetProductVariants() {
+			item.VariantNames = append(item.VariantNames, variant.Name)
+			if variant.Sku != "" {
+				item.Skus = append(item.Skus, variant.Sku)
+			}
+
+			for _, assignedValue := range variant.R.GetVariantAssignedProductVariantAttributeValues() {
+				// values assigned to variants are free form, their slugs are derived from them
+				value := assignedValue.R.GetAttributeValue()
+				if value == nil || value.R.GetAttribute() == nil {
+					continue
+				}
+				item.Attributes = appendAttributeValueForIndexing(item.Attributes, value.R.GetAttribute().Slug, slug.Make(value.Value), value.Value)
+			}
+
+			for _, listing := range variant.R.GetVariantProductVariantChannelListings() {
+				price := listing.DiscountedPriceAmount
+				if price.IsNil() {
+					price = listing.PriceAmount
+				}
+				if price.IsNil() {
+					continue
+				}
+				amount, _ := price.Decimal.Float64()
+				if current, ok := minimalPrices[listing.ChannelID]; !ok || amount < current {
+					minimalPrices[listing.ChannelID] = amount
+				}
+			}
+		}
+
+		for _, listing := range product.R.GetProductChannelListings() {
+			channelListing := &model_helper.ProductChannelListingForIndexing{
+				ChannelID:              listing.ChannelID,
+				IsPublished:            listing.IsPublished,
+				PublishedAt:            lo.FromPtr(listing.PublicationDate.Int64),
+				VisibleInListings:      listing.VisibleInListings,
+				AvailableForPurchaseAt: listing.AvailableForPurchaseAt.Int64,
+				InStock:                lo.Contains(inStockChannels[product.ID], listing.ChannelID),
+			}
+			if price, ok := minimalPrices[listing.ChannelID]; ok {
+				channelListing.MinimalPrice = &price
+			} else if !listing.DiscountedPriceAmount.IsNil() {
+				price, _ := listing.DiscountedPriceAmount.Decimal.Float64()
+				channelListing.MinimalPrice = &price
+			}
+
+			item.ChannelListings = append(item.ChannelListings, channelListing)
+		}
+
+		res = append(res, item)
+	}
+
+	return res, nil
+}
+
+// appendAttributeValueForIndexing appends given attribute value to values if it is not there yet
+func appendAttributeValueForIndexing(values []*model_helper.ProductAttributeValueForIndexing, attributeSlug, valueSlug, valueName string) []*model_helper.ProductAttributeValueForIndexing {
+	for _, existing := range values {
+		if existing.AttributeSlug == attributeSlug && existing.ValueSlug == valueSlug {
+			return values
+		}
+	}
+	return append(values, &model_helper.ProductAttributeValueForIndexing{
+		AttributeSlug: attributeSlug,
+		ValueSlug:     valueSlug,
+		ValueName:     valueName,
+	})
+}
+
+// inStockChannelsOfProducts returns ids of channels in which given products have at least one
+// variant with available quantity in a warehouse shipping to the channel, keyed by product ids.
+func (ps *SqlProductStore) inStockChannelsOfProducts(productIDs []string) (map[string][]string, error) {
+	query, args, err := ps.GetQueryBuilder().
+		Select(
+			fmt.Sprintf("%s AS product_id", model.ProductVariantTableColumns.ProductID),
+			fmt.Sprintf("%s AS channel_id", model.ShippingZoneChannelTableColumns.ChannelID),
+		).
+		Distinct().
+		From(model.TableNames.Stocks).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ProductVariants, model.ProductVariantTableColumns.ID, model.StockTableColumns.ProductVariantID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.WarehouseShippingZones, model.WarehouseShippingZoneTableColumns.WarehouseID, model.StockTableColumns.WarehouseID)).
+		InnerJoin(fmt.Sprintf("%s ON %s = %s", model.TableNames.ShippingZoneChannels, model.ShippingZoneChannelTableColumns.ShippingZoneID, model.WarehouseShippingZoneTableColumns.ShippingZoneID)).
+		Where(squirrel.Eq{model.ProductVariantTableColumns.ProductID: productIDs}).
+		Where(fmt.Sprintf(
+			"%s > COALESCE ((SELECT SUM (%s) FROM %s WHERE %s = %s), 0)",
+			model.StockTableColumns.Quantity,
+			model.AllocationTableColumns.QuantityAllocated,
+			model.TableNames.Allocations,
+			model.AllocationTableColumns.StockID,
+			model.StockTableColumns.ID,
+		)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "inStockChannelsOfProducts_ToSql")
+	}
+
+	var rows []struct {
+		ProductID string `boil:"product_id"`
+		ChannelID string `boil:"channel_id"`
+	}
+	err = queries.Raw(query, args...).Bind(context.Background(), ps.GetReplica(), &rows)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find in stock channels of products")
+	}
+
+	res := map[string][]string{}
+	for _, row := range rows {
+		res[row.ProductID] = append(res[row.ProductID], row.ChannelID)
+	}
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package product
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type SqlSlugRedirectStore struct {
+	store.Store
+}
+
+func NewSqlSlugRedirectStore(s store.Store) store.SlugRedirectStore {
+	return &SqlSlugRedirectStore{s}
+}
+
+// RecordSlugChange keeps current slug of given object as a redirect to the object if newSlug differs from it.
+// Redirects are kept by object id, so renaming an object multiple times keeps all its old slugs pointing to
+// its latest slug. Redirects from newSlug are removed, since the slug is in use again.
+func (rs *SqlSlugRedirectStore) RecordSlugChange(tx boil.ContextTransactor, objectType model_helper.SeoObjectType, objectID, newSlug string) error {
+	if tx == nil {
+		tx = rs.GetMaster()
+	}
+
+	var query interface {
+		QueryRow(exec boil.Executor) *sql.Row
+	}
+	switch objectType {
+	case model_helper.SeoObjectTypeProduct:
+		query = model.Products(qm.Select(model.ProductColumns.Slug), model.ProductWhere.ID.EQ(objectID))
+	case model_helper.SeoObjectTypeCategory:
+		query = model.Categories(qm.Select(model.CategoryColumns.Slug), model.CategoryWhere.ID.EQ(objectID))
+	case model_helper.SeoObjectTypeCollection:
+		query = model.Collections(qm.Select(model.CollectionColumns.Slug), model.CollectionWhere.ID.EQ(objectID))
+	default:
+		return store.NewErrInvalidInput(model.TableNames.SlugRedirects, model.SlugRedirectColumns.ObjectType, objectType)
+	}
+
+	var currentSlug string
+	err := query.QueryRow(tx).Scan(&currentSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil // the object is being created
+		}
+		return errors.Wrap(err, "failed to find current slug")
+	}
+	if currentSlug == newSlug || currentSlug == "" {
+		return nil
+	}
+
+	_, err = model.SlugRedirects(
+		model.SlugRedirectWhere.ObjectType.EQ(string(objectType)),
+		model.SlugRedirectWhere.OldSlug.EQ(newSlug),
+	).DeleteAll(tx)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete redirects from new slug")
+	}
+
+	redirect := model.SlugRedirect{
+		ObjectType: string(objectType),
+		ObjectID:   objectID,
+		OldSlug:    currentSlug,
+	}
+	model_helper.SlugRedirectPreSave(&redirect)
+	if appErr := model_helper.SlugRedirectIsValid(redirect); appErr != nil {
+		return appErr
+	}
+
+	// the old slug may be taken over from another object that had it before
+	err = redirect.Upsert(
+		tx,
+		true,
+		[]string{model.SlugRedirectColumns.ObjectType, model.SlugRedirectColumns.OldSlug},
+		boil.Whitelist(model.SlugRedirectColumns.ObjectID, model.SlugRedirectColumns.CreatedAt),
+		boil.Infer(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to upsert slug redirect")
+	}
+	return nil
+}
+
+func (rs *SqlSlugRedirectStore) GetByOldSlug(objectType model_helper.SeoObjectType, oldSlug string) (*model.SlugRedirect, error) {
+	redirect, err := model.SlugRedirects(
+		model.SlugRedirectWhere.ObjectType.EQ(string(objectType)),
+		model.SlugRedirectWhere.OldSlug.EQ(oldSlug),
+	).One(rs.GetReplica())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound(model.TableNames.SlugRedirects, string(objectType)+"/"+oldSlug)
+		}
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+func (rs *SqlSlugRedirectStore) FilterByOptions(options model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error) {
+	return model.SlugRedirects(options.Conditions...).All(rs.GetReplica())
+}
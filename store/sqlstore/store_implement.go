@@ -49,11 +49,13 @@ type SqlStoreStores struct {
 	attributeValue                store.AttributeValueStore
 	attributeValueTranslation     store.AttributeValueTranslationStore
 	audit                         store.AuditStore
+	backInStockSubscription       store.BackInStockSubscriptionStore
 	category                      store.CategoryStore
 	categoryTranslation           store.CategoryTranslationStore
 	channel                       store.ChannelStore
 	checkout                      store.CheckoutStore
 	checkoutLine                  store.CheckoutLineStore
+	checkoutRecovery              store.CheckoutRecoveryStore
 	clusterDiscovery              store.ClusterDiscoveryStore
 	collection                    store.CollectionStore
 	collectionChannelListing      store.CollectionChannelListingStore
@@ -99,6 +101,7 @@ type SqlStoreStores struct {
 	product                       store.ProductStore
 	productChannelListing         store.ProductChannelListingStore
 	productMedia                  store.ProductMediaStore
+	productRecommendation         store.ProductRecommendationStore
 	productTranslation            store.ProductTranslationStore
 	productType                   store.ProductTypeStore
 	productVariant                store.ProductVariantStore
@@ -113,6 +116,7 @@ type SqlStoreStores struct {
 	shippingZone                  store.ShippingZoneStore
 	shopStaff                     store.ShopStaffStore
 	shopTranslation               store.ShopTranslationStore
+	slugRedirect                  store.SlugRedirectStore
 	staffNotificationRecipient    store.StaffNotificationRecipientStore
 	status                        store.StatusStore
 	stock                         store.StockStore
@@ -148,11 +152,13 @@ func (store *SqlStore) setupStores() {
 		attributeValue:                attribute.NewSqlAttributeValueStore(store),
 		attributeValueTranslation:     attribute.NewSqlAttributeValueTranslationStore(store),
 		audit:                         audit.NewSqlAuditStore(store),
+		backInStockSubscription:       warehouse.NewSqlBackInStockSubscriptionStore(store),
 		category:                      product.NewSqlCategoryStore(store),
 		categoryTranslation:           product.NewSqlCategoryTranslationStore(store),
 		channel:                       channel.NewSqlChannelStore(store),
 		checkout:                      checkout.NewSqlCheckoutStore(store),
 		checkoutLine:                  checkout.NewSqlCheckoutLineStore(store),
+		checkoutRecovery:              checkout.NewSqlCheckoutRecoveryStore(store),
 		clusterDiscovery:              cluster.NewSqlClusterDiscoveryStore(store),
 		collection:                    product.NewSqlCollectionStore(store),
 		collectionChannelListing:      product.NewSqlCollectionChannelListingStore(store),
@@ -198,6 +204,7 @@ func (store *SqlStore) setupStores() {
 		product:                       product.NewSqlProductStore(store),
 		productChannelListing:         product.NewSqlProductChannelListingStore(store),
 		productMedia:                  product.NewSqlProductMediaStore(store),
+		productRecommendation:         product.NewSqlProductRecommendationStore(store),
 		productTranslation:            product.NewSqlProductTranslationStore(store),
 		productType:                   product.NewSqlProductTypeStore(store),
 		productVariant:                product.NewSqlProductVariantStore(store),
@@ -212,6 +219,7 @@ func (store *SqlStore) setupStores() {
 		shippingZone:                  shipping.NewSqlShippingZoneStore(store),
 		shopStaff:                     shop.NewSqlShopStaffStore(store),
 		shopTranslation:               shop.NewSqlShopTranslationStore(store),
+		slugRedirect:                  product.NewSqlSlugRedirectStore(store),
 		staffNotificationRecipient:    account.NewSqlStaffNotificationRecipientStore(store),
 		status:                        account.NewSqlStatusStore(store),
 		stock:                         warehouse.NewSqlStockStore(store),
@@ -287,6 +295,10 @@ func (ss *SqlStore) Audit() store.AuditStore {
 	return ss.stores.audit
 }
 
+func (ss *SqlStore) BackInStockSubscription() store.BackInStockSubscriptionStore {
+	return ss.stores.backInStockSubscription
+}
+
 func (ss *SqlStore) Category() store.CategoryStore {
 	return ss.stores.category
 }
@@ -307,6 +319,10 @@ func (ss *SqlStore) CheckoutLine() store.CheckoutLineStore {
 	return ss.stores.checkoutLine
 }
 
+func (ss *SqlStore) CheckoutRecovery() store.CheckoutRecoveryStore {
+	return ss.stores.checkoutRecovery
+}
+
 func (ss *SqlStore) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return ss.stores.clusterDiscovery
 }
@@ -487,6 +503,10 @@ func (ss *SqlStore) ProductMedia() store.ProductMediaStore {
 	return ss.stores.productMedia
 }
 
+func (ss *SqlStore) ProductRecommendation() store.ProductRecommendationStore {
+	return ss.stores.productRecommendation
+}
+
 func (ss *SqlStore) ProductTranslation() store.ProductTranslationStore {
 	return ss.stores.productTranslation
 }
@@ -543,6 +563,10 @@ func (ss *SqlStore) ShopTranslation() store.ShopTranslationStore {
 	return ss.stores.shopTranslation
 }
 
+func (ss *SqlStore) SlugRedirect() store.SlugRedirectStore {
+	return ss.stores.slugRedirect
+}
+
 func (ss *SqlStore) StaffNotificationRecipient() store.StaffNotificationRecipientStore {
 	return ss.stores.staffNotificationRecipient
 }
@@ -0,0 +1,46 @@
+package warehouse
+
+import (
+	"github.com/sitename/sitename/model"
+	"github.com/sitename/sitename/model_helper"
+	"github.com/sitename/sitename/store"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+type SqlBackInStockSubscriptionStore struct {
+	store.Store
+}
+
+func NewSqlBackInStockSubscriptionStore(s store.Store) store.BackInStockSubscriptionStore {
+	return &SqlBackInStockSubscriptionStore{s}
+}
+
+func (ss *SqlBackInStockSubscriptionStore) Save(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, error) {
+	model_helper.BackInStockSubscriptionPreSave(&subscription)
+	if err := model_helper.BackInStockSubscriptionIsValid(subscription); err != nil {
+		return nil, err
+	}
+
+	err := subscription.Insert(ss.GetMaster(), boil.Infer())
+	if err != nil {
+		if ss.IsUniqueConstraintError(err, []string{"back_in_stock_subscriptions_product_variant_id_channel_id_email"}) {
+			return nil, store.NewErrInvalidInput(model.TableNames.BackInStockSubscriptions, "ProductVariantID/ChannelID/Email", "duplicate")
+		}
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (ss *SqlBackInStockSubscriptionStore) FilterByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error) {
+	return model.BackInStockSubscriptions(options.Conditions...).All(ss.GetReplica())
+}
+
+func (ss *SqlBackInStockSubscriptionStore) Delete(transaction boil.ContextTransactor, ids []string) error {
+	if transaction == nil {
+		transaction = ss.GetMaster()
+	}
+
+	_, err := model.BackInStockSubscriptions(model.BackInStockSubscriptionWhere.ID.IN(ids)).DeleteAll(transaction)
+	return err
+}
@@ -80,10 +80,8 @@ func (ss *SqlStockStore) commonFilterForChannelQuery(options model_helper.StockF
 		Select(`(1) AS "a"`).
 		Prefix("EXISTS (").
 		From(model.TableNames.Channels).
-		Where(squirrel.Eq{
-			model.ChannelTableColumns.ID: model.ShippingZoneChannelTableColumns.ChannelID,
-			model.ChannelTableColumns.ID: options.ChannelID,
-		}).
+		Where(model.ChannelTableColumns.ID + " = " + model.ShippingZoneChannelTableColumns.ChannelID).
+		Where(squirrel.Eq{model.ChannelTableColumns.ID: options.ChannelID}).
 		Limit(1).
 		Suffix(")")
 
@@ -92,9 +90,7 @@ func (ss *SqlStockStore) commonFilterForChannelQuery(options model_helper.StockF
 		Prefix("EXISTS (").
 		From(model.TableNames.ShippingZoneChannels).
 		Where(channelQuery).
-		Where(squirrel.Eq{
-			model.ShippingZoneChannelTableColumns.ShippingZoneID: model.WarehouseShippingZoneTableColumns.ShippingZoneID,
-		}).
+		Where(model.ShippingZoneChannelTableColumns.ShippingZoneID + " = " + model.WarehouseShippingZoneTableColumns.ShippingZoneID).
 		Limit(1).
 		Suffix(")")
 
@@ -103,9 +99,7 @@ func (ss *SqlStockStore) commonFilterForChannelQuery(options model_helper.StockF
 		Prefix("EXISTS (").
 		From(model.TableNames.WarehouseShippingZones).
 		Where(shippingZoneChannelQuery).
-		Where(squirrel.Eq{
-			model.WarehouseShippingZoneTableColumns.WarehouseID: model.StockTableColumns.WarehouseID,
-		}).
+		Where(model.WarehouseShippingZoneTableColumns.WarehouseID + " = " + model.StockTableColumns.WarehouseID).
 		Limit(1).
 		Suffix(")")
 }
@@ -133,6 +127,13 @@ func (ss *SqlStockStore) FilterForChannel(options model_helper.StockFilterForCha
 			Args:   args,
 		},
 	}
+	if options.Conditions != nil {
+		query, args, err := options.Conditions.ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "FilterForChannel_ToSql")
+		}
+		conds = append(conds, qm.Where(query, args...))
+	}
 
 	return model.Stocks(conds...).All(ss.GetReplica())
 }
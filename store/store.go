@@ -63,6 +63,7 @@ type Store interface {
 	Channel() ChannelStore                                             // channel
 	Checkout() CheckoutStore                                           // checkout
 	CheckoutLine() CheckoutLineStore                                   //
+	CheckoutRecovery() CheckoutRecoveryStore                           //
 	CsvExportEvent() CsvExportEventStore                               // csv
 	CsvExportFile() CsvExportFileStore                                 //
 	DiscountVoucher() DiscountVoucherStore                             // discount
@@ -95,6 +96,7 @@ type Store interface {
 	ProductType() ProductTypeStore                                     //
 	Product() ProductStore                                             //
 	ProductTranslation() ProductTranslationStore                       //
+	ProductRecommendation() ProductRecommendationStore                 //
 	ProductChannelListing() ProductChannelListingStore                 //
 	ProductVariant() ProductVariantStore                               //
 	ProductVariantTranslation() ProductVariantTranslationStore         //
@@ -106,6 +108,7 @@ type Store interface {
 	Collection() CollectionStore                                       //
 	CollectionChannelListing() CollectionChannelListingStore           //
 	CollectionTranslation() CollectionTranslationStore                 //
+	SlugRedirect() SlugRedirectStore                                   //
 	ShippingMethodTranslation() ShippingMethodTranslationStore         // shipping
 	ShippingMethodChannelListing() ShippingMethodChannelListingStore   //
 	ShippingMethodPostalCodeRule() ShippingMethodPostalCodeRuleStore   //
@@ -115,6 +118,7 @@ type Store interface {
 	Stock() StockStore                                                 //
 	Allocation() AllocationStore                                       //
 	PreorderAllocation() PreorderAllocationStore                       //
+	BackInStockSubscription() BackInStockSubscriptionStore             //
 	Wishlist() WishlistStore                                           // wishlist
 	WishlistItem() WishlistItemStore                                   //
 	PluginConfiguration() PluginConfigurationStore                     // plugin
@@ -226,9 +230,10 @@ type (
 		GetByOption(option model_helper.AttributePageFilterOption) (*model.AttributePage, error)
 	}
 	AssignedProductAttributeValueStore interface {
-		Save(assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) // Save inserts given instance into database then returns it with an error
-		Get(assignedProductAttrValueID string) (*model.AssignedProductAttributeValue, error)                                       // Get try finding an instance with given id then returns the value with an error
-		SelectForSort(assignmentID string) (model.AssignedProductAttributeValueSlice, model.AttributeValueSlice, error)            // SelectForSort finds all `*AssignedProductAttributeValue` and related `*AttributeValues` with given `assignmentID`, then returns them with an error.
+		Save(tx boil.ContextTransactor, assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) // Save inserts given instance into database then returns it with an error
+		Delete(tx boil.ContextTransactor, ids []string) (int64, error)
+		Get(assignedProductAttrValueID string) (*model.AssignedProductAttributeValue, error)                            // Get try finding an instance with given id then returns the value with an error
+		SelectForSort(assignmentID string) (model.AssignedProductAttributeValueSlice, model.AttributeValueSlice, error) // SelectForSort finds all `*AssignedProductAttributeValue` and related `*AttributeValues` with given `assignmentID`, then returns them with an error.
 		FilterByOptions(options model_helper.AssignedProductAttributeValueFilterOptions) (model.AssignedProductAttributeValueSlice, error)
 	}
 	AssignedPageAttributeStore interface {
@@ -237,14 +242,15 @@ type (
 		FilterByOptions(options model_helper.AssignedPageAttributeFilterOption) (model.AssignedPageAttributeSlice, error) // GetByOption try to find an assigned page model with given option. If nothing found, creats new instance with that option and returns such value with an error
 	}
 	AssignedProductAttributeStore interface {
-		Save(assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error)
-		GetWithOption(option model_helper.AssignedProductAttributeFilterOption) (*model.AssignedProductAttribute, error) // GetWithOption try finding an `AssignedProductAttribute` with given `option`. If nothing found, it creates new instance then returns it with an error
+		Save(tx boil.ContextTransactor, assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) // Save inserts given assigned product attribute into database
+		GetWithOption(option model_helper.AssignedProductAttributeFilterOption) (*model.AssignedProductAttribute, error)                  // GetWithOption try finding an `AssignedProductAttribute` with given `option`. If nothing found, it creates new instance then returns it with an error
 		FilterByOptions(options model_helper.AssignedProductAttributeFilterOption) (model.AssignedProductAttributeSlice, error)
 	}
 	CustomProductAttributeStore interface {
 		Upsert(tx boil.ContextTransactor, record model.CustomProductAttribute) (*model.CustomProductAttribute, error)
 		Delete(tx boil.ContextTransactor, ids []string) (int64, error)
 		FilterByOptions(options model_helper.CustomProductAttributeFilterOptions) (model.CustomProductAttributeSlice, error)
+		AssignVariantValues(tx boil.ContextTransactor, productID, variantID string, inputs []*model_helper.CustomProductAttributeValuesInput) error // AssignVariantValues replaces values of given custom attributes assigned to the variant. Missing attributes and values are created
 	}
 )
 
@@ -314,6 +320,11 @@ type (
 		FilterByOption(options model_helper.PreorderAllocationFilterOption) (model.PreorderAllocationSlice, error)                  // FilterByOption finds and returns a list of preorder allocations filtered using given options
 		Delete(tx boil.ContextTransactor, ids []string) error                                                                       // Delete deletes preorder-allocations by given ids
 	}
+	BackInStockSubscriptionStore interface {
+		Save(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, error)                               // Save inserts given subscription into database then returns it
+		FilterByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error) // FilterByOptions finds and returns subscriptions filtered using given options
+		Delete(tx boil.ContextTransactor, ids []string) error                                                                  // Delete deletes subscriptions with given ids
+	}
 )
 
 type (
@@ -437,9 +448,24 @@ type (
 		PublishedWithVariants(channelIdOrSlug string) squirrel.SelectBuilder                                                                                            // PublishedWithVariants finds and returns products.
 		VisibleToUserProductsQuery(channelIdOrSlug string, userHasOneOfProductpermissions bool) squirrel.SelectBuilder                                                  // FilterVisibleToUserProduct finds and returns all products that are visible to requesting user.
 		SelectForUpdateDiscountedPricesOfCatalogues(tx boil.ContextTransactor, productIDs, categoryIDs, collectionIDs, variantIDs []string) (model.ProductSlice, error) // SelectForUpdateDiscountedPricesOfCatalogues finds and returns product based on given ids lists.
-		AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder                                                               // AdvancedFilterQueryBuilder advancedly finds products, filtered using given options
+		AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error)                                                      // AdvancedFilterQueryBuilder advancedly finds products, filtered using given options
 		FilterByQuery(query squirrel.SelectBuilder) (model.ProductSlice, error)                                                                                         // FilterByQuery finds and returns products with given query, limit, createdAtGt
 		CountByCategoryIDs(categoryIDs []string) ([]*model_helper.ProductCountByCategoryID, error)
+		GetProductsBatchForIndexing(startTime, endTime int64, limit int) ([]*model_helper.ProductForIndexing, error) // GetProductsBatchForIndexing returns data for indexing of products created in given time range, ordered by creation time
+		GetForIndexing(productIDs []string) ([]*model_helper.ProductForIndexing, error)                              // GetForIndexing returns data for indexing of given products
+		CategoryInteractionsOfUser(userID string) ([]*model_helper.ProductCategoryInteraction, error)                // CategoryInteractionsOfUser counts products of each category the user ordered or added to wishlist
+		Feed(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error)                       // Feed finds products visible in given channel, ranked by their feed scores descending
+	}
+	ProductRecommendationStore interface {
+		CoPurchases(channelID string, since int64, minCount int) ([]*model_helper.ProductCoPurchase, error)                                  // CoPurchases counts orders of given channel, created since given time, containing both products of each pair. Pairs bought together less than minCount times are skipped
+		ReplaceForChannel(tx boil.ContextTransactor, channelID string, recommendations model.ProductRecommendationSlice) error               // ReplaceForChannel deletes all recommendations of given channel then inserts given ones
+		RecommendedProducts(options model_helper.RecommendedProductsOptions) ([]*model_helper.RecommendedProduct, error)                     // RecommendedProducts finds products recommended for a product, visible and in stock in given channel, ordered by scores descending
+		ProductsForRecommendations(channelID string, now int64, afterID string, limit int) ([]*model_helper.ProductForRecommendation, error) // ProductsForRecommendations finds at most limit products listed in given channel with ids greater than afterID, ordered by ids
+	}
+	SlugRedirectStore interface {
+		RecordSlugChange(tx boil.ContextTransactor, objectType model_helper.SeoObjectType, objectID, newSlug string) error // RecordSlugChange keeps current slug of given object as a redirect to it when newSlug differs. It must be called before the object is updated
+		GetByOldSlug(objectType model_helper.SeoObjectType, oldSlug string) (*model.SlugRedirect, error)                   // GetByOldSlug finds the redirect from given old slug of given object type
+		FilterByOptions(options model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error)
 	}
 )
 
@@ -451,6 +477,7 @@ type (
 		FilterByOption(option model_helper.PaymentFilterOptions) (model.PaymentSlice, error)                              // FilterByOption finds and returns a list of payments that satisfy given option
 		UpdatePaymentsOfCheckout(tx boil.ContextTransactor, checkoutToken string, option model_helper.PaymentPatch) error // UpdatePaymentsOfCheckout updates payments of given model
 		PaymentOwnedByUser(userID, paymentID string) (bool, error)
+		AnonymizeByOrders(tx boil.ContextTransactor, orderIDs []string, email string) error // AnonymizeByOrders replaces billing email and clears billing details of payments of given orders
 	}
 	PaymentTransactionStore interface {
 		Upsert(tx boil.ContextTransactor, paymentTransaction model.PaymentTransaction) (*model.PaymentTransaction, error) // Save inserts new model transaction into database
@@ -476,17 +503,21 @@ type (
 		Upsert(tx boil.ContextTransactor, orderLine model.OrderLine) (*model.OrderLine, error)   // Upsert depends on given orderLine's Id to decide to update or save it
 		Get(id string) (*model.OrderLine, error)                                                 // Get returns a order line with id of given id
 		FilterbyOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, error) // FilterbyOption finds and returns order lines by given option
+		Delete(tx boil.ContextTransactor, orderLineIDs []string) error                           // Delete deletes order lines with given ids
 	}
 	OrderStore interface {
 		Delete(tx boil.ContextTransactor, ids []string) (int64, error)
 		Get(id string) (*model.Order, error)                                                         // Get find order in database with given id
 		FilterByOption(option model_helper.OrderFilterOption) (model_helper.CustomOrderSlice, error) // FilterByOption returns a list of orders, filtered by given option
 		BulkUpsert(tx boil.ContextTransactor, orders model.OrderSlice) (model.OrderSlice, error)
+		CountByUserIDs(userIDs []string) ([]*model_helper.OrderCountByUserID, error)  // CountByUserIDs counts orders placed by each of given users
+		AnonymizeByUser(tx boil.ContextTransactor, userID string, email string) error // AnonymizeByUser replaces email and clears customer note of orders of given user, keeping totals
 	}
 	OrderEventStore interface {
-		// Save(tx boil.ContextTransactor, orderEvent *model.OrderEvent) (*model.OrderEvent, error) // Save inserts given order event into database then returns it
-		// Get(orderEventID string) (*model.OrderEvent, error)                                      // Get finds order event with given id then returns it
-		// FilterByOptions(options *model.OrderEventFilterOptions) ([]*model.OrderEvent, error)
+		Save(tx boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, error)      // Save inserts given order event into database then returns it
+		FilterByOptions(options model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error) // FilterByOptions finds and returns order events filtered using given options
+		CountBefore(endTime int64) (int64, error)                                                    // CountBefore counts order events created before given time
+		PermanentDeleteBatch(endTime int64, limit int64) (int64, error)                              // PermanentDeleteBatch deletes up to limit order events created before given time
 	}
 	FulfillmentLineStore interface {
 		Upsert(fulfillmentLine model.FulfillmentLine) (*model.FulfillmentLine, error)
@@ -585,7 +616,7 @@ type (
 		Delete(tx boil.ContextTransactor, ids []string) error
 	}
 	DiscountVoucherStore interface {
-		Upsert(voucher model.Voucher) (*model.Voucher, error)                                                    // Upsert saves or updates given voucher then returns it with an error
+		Upsert(tx boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, error)                         // Upsert saves or updates given voucher then returns it with an error
 		Get(id string) (*model.Voucher, error)                                                                   // Get finds a voucher with given id, then returns it with an error
 		FilterVouchersByOption(option model_helper.VoucherFilterOption) (model_helper.CustomVoucherSlice, error) // FilterVouchersByOption finds vouchers bases on given option.
 		ExpiredVouchers(date timemodule.Time) (model.VoucherSlice, error)                                        // ExpiredVouchers finds and returns vouchers that are expired before given date
@@ -593,7 +624,7 @@ type (
 	}
 	VoucherCustomerStore interface {
 		Save(voucherCustomer model.VoucherCustomer) (*model.VoucherCustomer, error)                           // Save inserts given voucher customer instance into database ands returns it
-		Delete(ids []string) error                                                                            // DeleteInBulk deletes given voucher-customers with given id
+		Delete(tx boil.ContextTransactor, ids []string) error                                                 // DeleteInBulk deletes given voucher-customers with given id
 		GetByOption(options model_helper.VoucherCustomerFilterOption) (*model.VoucherCustomer, error)         // GetByOption finds and returns a voucher customer with given options
 		FilterByOptions(options model_helper.VoucherCustomerFilterOption) (model.VoucherCustomerSlice, error) // FilterByOptions finds and returns a slice of voucher customers by given options
 	}
@@ -606,8 +637,11 @@ type (
 		FilterByOption(options model_helper.ExportEventFilterOption) ([]*model.ExportEvent, error) // FilterByOption finds and returns a list of export events filtered using given option
 	}
 	CsvExportFileStore interface {
-		Save(file model.ExportFile) (*model.ExportFile, error) // Save inserts given export file into database then returns it
-		Get(id string) (*model.ExportFile, error)              // Get finds and returns an export file found using given id
+		Upsert(file model.ExportFile) (*model.ExportFile, error) // Upsert inserts or updates given export file then returns it
+		Get(id string) (*model.ExportFile, error)                // Get finds and returns an export file found using given id
+		FilterByOptions(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, error)
+		Delete(tx boil.ContextTransactor, ids []string) error // Delete deletes export files with given ids along with their events
+		CountBefore(endTime int64) (int64, error)             // CountBefore counts export files created before given time
 	}
 )
 
@@ -629,6 +663,11 @@ type (
 		Delete(tx boil.ContextTransactor, ids []string) error                                                      // DeleteCheckoutsByOption deletes model row(s) from database, filtered using given option.  It returns an error indicating if the operation was performed successfully.
 		CountCheckouts(options model_helper.CheckoutFilterOptions) (int64, error)
 	}
+	CheckoutRecoveryStore interface {
+		Upsert(tx boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, error)      // Upsert inserts or updates given recovery then returns it
+		FilterByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error) // FilterByOptions finds and returns recoveries filtered using given options
+		Delete(tx boil.ContextTransactor, ids []string) error                                                    // Delete deletes recoveries with given ids
+	}
 )
 
 // channel
@@ -662,6 +701,8 @@ type AuditStore interface {
 	Save(audit model.Audit) error
 	Get(userID string, offset int, limit int) (model.AuditSlice, error)
 	PermanentDeleteByUser(userID string) error
+	CountBefore(endTime int64) (int64, error)
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 }
 
 type TermsOfServiceStore interface {
@@ -718,6 +759,7 @@ type (
 		Get(addressID string) (*model.Address, error)                         // Get returns an Address with given addressID is exist
 		DeleteAddresses(tx boil.ContextTransactor, addressIDs []string) error // DeleteAddress deletes given address and returns an error
 		FilterByOption(option model_helper.AddressFilterOptions) (model.AddressSlice, error)
+		Anonymize(tx boil.ContextTransactor, addressIDs []string) error // Anonymize clears personal details of given addresses, without validating them
 	}
 	UserStore interface {
 		ClearCaches()
@@ -731,7 +773,8 @@ type (
 		ResetAuthDataToEmailForUsers(service string, userIDs []string, includeDeleted bool, dryRun bool) (int, error)
 		UpdateMfaSecret(userID, secret string) error
 		UpdateMfaActive(userID string, active bool) error
-		InvalidateProfileCacheForUser(userID string) // InvalidateProfileCacheForUser
+		Anonymize(tx boil.ContextTransactor, userID, email string) error // Anonymize replaces personal details of given user with anonymous values, clears its credentials and deactivates it
+		InvalidateProfileCacheForUser(userID string)                     // InvalidateProfileCacheForUser
 		GetForLogin(loginID string, allowSignInWithUsername, allowSignInWithEmail bool) (*model.User, error)
 		VerifyEmail(userID, email string) (string, error) // VerifyEmail set EmailVerified model of user to true
 		GetEtagForAllProfiles() string
@@ -762,10 +805,12 @@ type (
 		GetByToken(token string) (*model.Token, error)
 		Cleanup() error
 		GetAllTokensByType(tokenType model_helper.TokenType) (model.TokenSlice, error)
+		CountBefore(endTime int64) (int64, error)
+		PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	}
 	UserAccessTokenStore interface {
 		Save(token model.UserAccessToken) (*model.UserAccessToken, error)
-		DeleteAllForUser(userID string) error
+		DeleteAllForUser(tx boil.ContextTransactor, userID string) error // DeleteAllForUser deletes access tokens of given user and their sessions, in a new transaction when tx is nil
 		Delete(tokenID string) error
 		Get(tokenID string) (*model.UserAccessToken, error)
 		GetAll(conds model_helper.UserAccessTokenFilterOptions) (model.UserAccessTokenSlice, error)
@@ -779,6 +824,8 @@ type (
 		Get(id string) (*model.CustomerEvent, error)
 		Count() (int64, error)
 		FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error)
+		CountBefore(endTime int64) (int64, error)
+		PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	}
 	StaffNotificationRecipientStore interface {
 		Save(notificationRecipient model.StaffNotificationRecipient) (*model.StaffNotificationRecipient, error)
@@ -797,7 +844,7 @@ type (
 		UpdateExpiredNotify(sessionid string, notified bool) error
 		Remove(sessionIDOrToken string) error
 		RemoveAllSessions() error
-		PermanentDeleteSessionsByUser(userID string) error
+		PermanentDeleteSessionsByUser(tx boil.ContextTransactor, userID string) error
 		UpdateExpiresAt(sessionID string, time int64) error
 		UpdateLastActivityAt(sessionID string, time int64) error                    // UpdateLastActivityAt
 		UpdateRoles(userID string, roles string) (string, error)                    // UpdateRoles updates roles for all sessions that have userId of given userID,
@@ -805,6 +852,8 @@ type (
 		UpdateProps(session model.Session) error                                    // UpdateProps update session's props
 		AnalyticsSessionCount() (int64, error)                                      // AnalyticsSessionCount counts numbers of sessions
 		Cleanup(expiryTime int64, batchSize int64)                                  // Cleanup is called periodicly to remove sessions that are expired
+		CountExpiredBefore(endTime int64) (int64, error)                            // CountExpiredBefore counts sessions that expired before given time
+		PermanentDeleteExpiredBatch(endTime int64, limit int64) (int64, error)      // PermanentDeleteExpiredBatch deletes up to limit sessions that expired before given time
 	}
 )
 
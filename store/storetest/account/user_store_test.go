@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"testing"
 
 	"github.com/sitename/sitename/model"
@@ -8,6 +9,7 @@ import (
 	"github.com/sitename/sitename/store"
 	"github.com/sitename/sitename/store/storetest"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 func TestUserStore(t *testing.T) {
@@ -23,6 +25,7 @@ func TestUserStore(t *testing.T) {
 		}
 
 		t.Run("IsEmpty", func(t *testing.T) { testIsEmpty(t, ss) })
+		t.Run("Anonymize", func(t *testing.T) { testAnonymize(t, ss) })
 	})
 }
 
@@ -50,3 +53,74 @@ func testIsEmpty(t *testing.T, ss store.Store) {
 	require.NoError(t, err)
 	require.True(t, empty)
 }
+
+func testAnonymize(t *testing.T, ss store.Store) {
+	user, err := ss.User().Save(model.User{
+		Email:     storetest.MakeEmail(),
+		Username:  "anonymize" + model_helper.NewId(),
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Password:  "password",
+		IsActive:  true,
+	})
+	require.NoError(t, err)
+	defer ss.User().PermanentDelete(user.ID)
+
+	_, err = ss.Session().Save(model.Session{UserID: user.ID, Token: model_helper.NewId()})
+	require.NoError(t, err)
+	_, err = ss.UserAccessToken().Save(model.UserAccessToken{UserID: user.ID, Token: model_helper.NewId(), IsActive: true})
+	require.NoError(t, err)
+
+	erase := func(tx boil.ContextTransactor) {
+		require.NoError(t, ss.User().Anonymize(tx, user.ID, "anonymized@example.com"))
+		require.NoError(t, ss.Session().PermanentDeleteSessionsByUser(tx, user.ID))
+		require.NoError(t, ss.UserAccessToken().DeleteAllForUser(tx, user.ID))
+	}
+
+	t.Run("rolled back", func(t *testing.T) {
+		tx, err := ss.GetMaster().BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+		erase(tx)
+		require.NoError(t, tx.Rollback())
+
+		saved, err := ss.User().Get(context.Background(), user.ID)
+		require.NoError(t, err)
+		require.Equal(t, user.Email, saved.Email)
+		require.True(t, saved.IsActive)
+
+		sessions, err := ss.Session().GetSessions(user.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		tokens, err := ss.UserAccessToken().GetAll(model_helper.UserAccessTokenFilterOptions{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(model.UserAccessTokenWhere.UserID.EQ(user.ID)),
+		})
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+	})
+
+	t.Run("committed", func(t *testing.T) {
+		tx, err := ss.GetMaster().BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+		erase(tx)
+		require.NoError(t, tx.Commit())
+
+		saved, err := ss.User().Get(context.Background(), user.ID)
+		require.NoError(t, err)
+		require.Equal(t, "anonymized@example.com", saved.Email)
+		require.Equal(t, "deleted-"+user.ID, saved.Username)
+		require.Empty(t, saved.FirstName)
+		require.Empty(t, saved.LastName)
+		require.Empty(t, saved.Password)
+		require.False(t, saved.IsActive)
+		require.NotZero(t, saved.DeleteAt)
+
+		sessions, err := ss.Session().GetSessions(user.ID)
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+		tokens, err := ss.UserAccessToken().GetAll(model_helper.UserAccessTokenFilterOptions{
+			CommonQueryOptions: model_helper.NewCommonQueryOptions(model.UserAccessTokenWhere.UserID.EQ(user.ID)),
+		})
+		require.NoError(t, err)
+		require.Empty(t, tokens)
+	})
+}
@@ -18,6 +18,20 @@ type AddressStore struct {
 	mock.Mock
 }
 
+// Anonymize provides a mock function with given fields: tx, addressIDs
+func (_m *AddressStore) Anonymize(tx boil.ContextTransactor, addressIDs []string) error {
+	ret := _m.Called(tx, addressIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) error); ok {
+		r0 = rf(tx, addressIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAddresses provides a mock function with given fields: tx, addressIDs
 func (_m *AddressStore) DeleteAddresses(tx boil.ContextTransactor, addressIDs []string) error {
 	ret := _m.Called(tx, addressIDs)
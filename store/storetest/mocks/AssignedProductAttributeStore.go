@@ -5,9 +5,12 @@
 package mocks
 
 import (
-	model "github.com/sitename/sitename/model"
 	mock "github.com/stretchr/testify/mock"
 
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
 	model_helper "github.com/sitename/sitename/model_helper"
 )
 
@@ -68,25 +71,25 @@ func (_m *AssignedProductAttributeStore) GetWithOption(option model_helper.Assig
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: assignedProductAttribute
-func (_m *AssignedProductAttributeStore) Save(assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
-	ret := _m.Called(assignedProductAttribute)
+// Save provides a mock function with given fields: tx, assignedProductAttribute
+func (_m *AssignedProductAttributeStore) Save(tx boil.ContextTransactor, assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
+	ret := _m.Called(tx, assignedProductAttribute)
 
 	var r0 *model.AssignedProductAttribute
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.AssignedProductAttribute) (*model.AssignedProductAttribute, error)); ok {
-		return rf(assignedProductAttribute)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.AssignedProductAttribute) (*model.AssignedProductAttribute, error)); ok {
+		return rf(tx, assignedProductAttribute)
 	}
-	if rf, ok := ret.Get(0).(func(model.AssignedProductAttribute) *model.AssignedProductAttribute); ok {
-		r0 = rf(assignedProductAttribute)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.AssignedProductAttribute) *model.AssignedProductAttribute); ok {
+		r0 = rf(tx, assignedProductAttribute)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.AssignedProductAttribute)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(model.AssignedProductAttribute) error); ok {
-		r1 = rf(assignedProductAttribute)
+	if rf, ok := ret.Get(1).(func(boil.ContextTransactor, model.AssignedProductAttribute) error); ok {
+		r1 = rf(tx, assignedProductAttribute)
 	} else {
 		r1 = ret.Error(1)
 	}
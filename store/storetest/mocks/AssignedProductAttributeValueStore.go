@@ -5,9 +5,12 @@
 package mocks
 
 import (
-	model "github.com/sitename/sitename/model"
 	mock "github.com/stretchr/testify/mock"
 
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
 	model_helper "github.com/sitename/sitename/model_helper"
 )
 
@@ -16,6 +19,30 @@ type AssignedProductAttributeValueStore struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: tx, ids
+func (_m *AssignedProductAttributeValueStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+	ret := _m.Called(tx, ids)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) (int64, error)); ok {
+		return rf(tx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) int64); ok {
+		r0 = rf(tx, ids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(boil.ContextTransactor, []string) error); ok {
+		r1 = rf(tx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FilterByOptions provides a mock function with given fields: options
 func (_m *AssignedProductAttributeValueStore) FilterByOptions(options model_helper.AssignedProductAttributeValueFilterOptions) (model.AssignedProductAttributeValueSlice, error) {
 	ret := _m.Called(options)
@@ -68,25 +95,25 @@ func (_m *AssignedProductAttributeValueStore) Get(assignedProductAttrValueID str
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: assignedProductAttrValues
-func (_m *AssignedProductAttributeValueStore) Save(assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
-	ret := _m.Called(assignedProductAttrValues)
+// Save provides a mock function with given fields: tx, assignedProductAttrValues
+func (_m *AssignedProductAttributeValueStore) Save(tx boil.ContextTransactor, assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
+	ret := _m.Called(tx, assignedProductAttrValues)
 
 	var r0 model.AssignedProductAttributeValueSlice
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error)); ok {
-		return rf(assignedProductAttrValues)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error)); ok {
+		return rf(tx, assignedProductAttrValues)
 	}
-	if rf, ok := ret.Get(0).(func(model.AssignedProductAttributeValueSlice) model.AssignedProductAttributeValueSlice); ok {
-		r0 = rf(assignedProductAttrValues)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.AssignedProductAttributeValueSlice) model.AssignedProductAttributeValueSlice); ok {
+		r0 = rf(tx, assignedProductAttrValues)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(model.AssignedProductAttributeValueSlice)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(model.AssignedProductAttributeValueSlice) error); ok {
-		r1 = rf(assignedProductAttrValues)
+	if rf, ok := ret.Get(1).(func(boil.ContextTransactor, model.AssignedProductAttributeValueSlice) error); ok {
+		r1 = rf(tx, assignedProductAttrValues)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -14,6 +14,30 @@ type AuditStore struct {
 	mock.Mock
 }
 
+// CountBefore provides a mock function with given fields: endTime
+func (_m *AuditStore) CountBefore(endTime int64) (int64, error) {
+	ret := _m.Called(endTime)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(endTime)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(endTime)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: userID, offset, limit
 func (_m *AuditStore) Get(userID string, offset int, limit int) (model.AuditSlice, error) {
 	ret := _m.Called(userID, offset, limit)
@@ -40,6 +64,30 @@ func (_m *AuditStore) Get(userID string, offset int, limit int) (model.AuditSlic
 	return r0, r1
 }
 
+// PermanentDeleteBatch provides a mock function with given fields: endTime, limit
+func (_m *AuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(endTime, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByUser provides a mock function with given fields: userID
 func (_m *AuditStore) PermanentDeleteByUser(userID string) error {
 	ret := _m.Called(userID)
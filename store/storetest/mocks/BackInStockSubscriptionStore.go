@@ -0,0 +1,101 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
+	model_helper "github.com/sitename/sitename/model_helper"
+)
+
+// BackInStockSubscriptionStore is an autogenerated mock type for the BackInStockSubscriptionStore type
+type BackInStockSubscriptionStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: tx, ids
+func (_m *BackInStockSubscriptionStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	ret := _m.Called(tx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) error); ok {
+		r0 = rf(tx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilterByOptions provides a mock function with given fields: options
+func (_m *BackInStockSubscriptionStore) FilterByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error) {
+	ret := _m.Called(options)
+
+	var r0 model.BackInStockSubscriptionSlice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.BackInStockSubscriptionFilterOptions) model.BackInStockSubscriptionSlice); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.BackInStockSubscriptionSlice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.BackInStockSubscriptionFilterOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: subscription
+func (_m *BackInStockSubscriptionStore) Save(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, error) {
+	ret := _m.Called(subscription)
+
+	var r0 *model.BackInStockSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.BackInStockSubscription) (*model.BackInStockSubscription, error)); ok {
+		return rf(subscription)
+	}
+	if rf, ok := ret.Get(0).(func(model.BackInStockSubscription) *model.BackInStockSubscription); ok {
+		r0 = rf(subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BackInStockSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.BackInStockSubscription) error); ok {
+		r1 = rf(subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBackInStockSubscriptionStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBackInStockSubscriptionStore creates a new instance of BackInStockSubscriptionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBackInStockSubscriptionStore(t mockConstructorTestingTNewBackInStockSubscriptionStore) *BackInStockSubscriptionStore {
+	mock := &BackInStockSubscriptionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
+	model_helper "github.com/sitename/sitename/model_helper"
+)
+
+// CheckoutRecoveryStore is an autogenerated mock type for the CheckoutRecoveryStore type
+type CheckoutRecoveryStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: tx, ids
+func (_m *CheckoutRecoveryStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	ret := _m.Called(tx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) error); ok {
+		r0 = rf(tx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilterByOptions provides a mock function with given fields: options
+func (_m *CheckoutRecoveryStore) FilterByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error) {
+	ret := _m.Called(options)
+
+	var r0 model.CheckoutRecoverySlice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.CheckoutRecoveryFilterOptions) model.CheckoutRecoverySlice); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.CheckoutRecoverySlice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.CheckoutRecoveryFilterOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: tx, recovery
+func (_m *CheckoutRecoveryStore) Upsert(tx boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, error) {
+	ret := _m.Called(tx, recovery)
+
+	var r0 *model.CheckoutRecovery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.CheckoutRecovery) (*model.CheckoutRecovery, error)); ok {
+		return rf(tx, recovery)
+	}
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.CheckoutRecovery) *model.CheckoutRecovery); ok {
+		r0 = rf(tx, recovery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CheckoutRecovery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(boil.ContextTransactor, model.CheckoutRecovery) error); ok {
+		r1 = rf(tx, recovery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewCheckoutRecoveryStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCheckoutRecoveryStore creates a new instance of CheckoutRecoveryStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCheckoutRecoveryStore(t mockConstructorTestingTNewCheckoutRecoveryStore) *CheckoutRecoveryStore {
+	mock := &CheckoutRecoveryStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
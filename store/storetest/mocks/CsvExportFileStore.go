@@ -5,8 +5,12 @@
 package mocks
 
 import (
-	model "github.com/sitename/sitename/model"
 	mock "github.com/stretchr/testify/mock"
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
+	model_helper "github.com/sitename/sitename/model_helper"
 )
 
 // CsvExportFileStore is an autogenerated mock type for the CsvExportFileStore type
@@ -14,6 +18,70 @@ type CsvExportFileStore struct {
 	mock.Mock
 }
 
+// CountBefore provides a mock function with given fields: endTime
+func (_m *CsvExportFileStore) CountBefore(endTime int64) (int64, error) {
+	ret := _m.Called(endTime)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(endTime)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(endTime)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: tx, ids
+func (_m *CsvExportFileStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	ret := _m.Called(tx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) error); ok {
+		r0 = rf(tx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilterByOptions provides a mock function with given fields: options
+func (_m *CsvExportFileStore) FilterByOptions(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, error) {
+	ret := _m.Called(options)
+
+	var r0 model.ExportFileSlice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.ExportFileFilterOption) (model.ExportFileSlice, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.ExportFileFilterOption) model.ExportFileSlice); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ExportFileSlice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.ExportFileFilterOption) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: id
 func (_m *CsvExportFileStore) Get(id string) (*model.ExportFile, error) {
 	ret := _m.Called(id)
@@ -40,8 +108,8 @@ func (_m *CsvExportFileStore) Get(id string) (*model.ExportFile, error) {
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: file
-func (_m *CsvExportFileStore) Save(file model.ExportFile) (*model.ExportFile, error) {
+// Upsert provides a mock function with given fields: file
+func (_m *CsvExportFileStore) Upsert(file model.ExportFile) (*model.ExportFile, error) {
 	ret := _m.Called(file)
 
 	var r0 *model.ExportFile
@@ -18,6 +18,20 @@ type CustomProductAttributeStore struct {
 	mock.Mock
 }
 
+// AssignVariantValues provides a mock function with given fields: tx, productID, variantID, inputs
+func (_m *CustomProductAttributeStore) AssignVariantValues(tx boil.ContextTransactor, productID string, variantID string, inputs []*model_helper.CustomProductAttributeValuesInput) error {
+	ret := _m.Called(tx, productID, variantID, inputs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, string, string, []*model_helper.CustomProductAttributeValuesInput) error); ok {
+		r0 = rf(tx, productID, variantID, inputs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: tx, ids
 func (_m *CustomProductAttributeStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	ret := _m.Called(tx, ids)
@@ -42,6 +42,30 @@ func (_m *CustomerEventStore) Count() (int64, error) {
 	return r0, r1
 }
 
+// CountBefore provides a mock function with given fields: endTime
+func (_m *CustomerEventStore) CountBefore(endTime int64) (int64, error) {
+	ret := _m.Called(endTime)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(endTime)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(endTime)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FilterByOptions provides a mock function with given fields: options
 func (_m *CustomerEventStore) FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error) {
 	ret := _m.Called(options)
@@ -94,6 +118,30 @@ func (_m *CustomerEventStore) Get(id string) (*model.CustomerEvent, error) {
 	return r0, r1
 }
 
+// PermanentDeleteBatch provides a mock function with given fields: endTime, limit
+func (_m *CustomerEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(endTime, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Upsert provides a mock function with given fields: tx, customemrEvent
 func (_m *CustomerEventStore) Upsert(tx boil.ContextTransactor, customemrEvent model.CustomerEvent) (*model.CustomerEvent, error) {
 	ret := _m.Called(tx, customemrEvent)
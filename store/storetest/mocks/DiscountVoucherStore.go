@@ -122,25 +122,25 @@ func (_m *DiscountVoucherStore) Get(id string) (*model.Voucher, error) {
 	return r0, r1
 }
 
-// Upsert provides a mock function with given fields: voucher
-func (_m *DiscountVoucherStore) Upsert(voucher model.Voucher) (*model.Voucher, error) {
-	ret := _m.Called(voucher)
+// Upsert provides a mock function with given fields: tx, voucher
+func (_m *DiscountVoucherStore) Upsert(tx boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, error) {
+	ret := _m.Called(tx, voucher)
 
 	var r0 *model.Voucher
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.Voucher) (*model.Voucher, error)); ok {
-		return rf(voucher)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.Voucher) (*model.Voucher, error)); ok {
+		return rf(tx, voucher)
 	}
-	if rf, ok := ret.Get(0).(func(model.Voucher) *model.Voucher); ok {
-		r0 = rf(voucher)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.Voucher) *model.Voucher); ok {
+		r0 = rf(tx, voucher)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Voucher)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(model.Voucher) error); ok {
-		r1 = rf(voucher)
+	if rf, ok := ret.Get(1).(func(boil.ContextTransactor, model.Voucher) error); ok {
+		r1 = rf(tx, voucher)
 	} else {
 		r1 = ret.Error(1)
 	}
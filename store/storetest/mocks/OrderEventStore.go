@@ -4,13 +4,121 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
+	model_helper "github.com/sitename/sitename/model_helper"
+)
 
 // OrderEventStore is an autogenerated mock type for the OrderEventStore type
 type OrderEventStore struct {
 	mock.Mock
 }
 
+// CountBefore provides a mock function with given fields: endTime
+func (_m *OrderEventStore) CountBefore(endTime int64) (int64, error) {
+	ret := _m.Called(endTime)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(endTime)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(endTime)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterByOptions provides a mock function with given fields: options
+func (_m *OrderEventStore) FilterByOptions(options model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error) {
+	ret := _m.Called(options)
+
+	var r0 model.OrderEventSlice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.OrderEventFilterOptions) model.OrderEventSlice); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.OrderEventSlice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.OrderEventFilterOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteBatch provides a mock function with given fields: endTime, limit
+func (_m *OrderEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(endTime, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: tx, orderEvent
+func (_m *OrderEventStore) Save(tx boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, error) {
+	ret := _m.Called(tx, orderEvent)
+
+	var r0 *model.OrderEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.OrderEvent) (*model.OrderEvent, error)); ok {
+		return rf(tx, orderEvent)
+	}
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model.OrderEvent) *model.OrderEvent); ok {
+		r0 = rf(tx, orderEvent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrderEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(boil.ContextTransactor, model.OrderEvent) error); ok {
+		r1 = rf(tx, orderEvent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewOrderEventStore interface {
 	mock.TestingT
 	Cleanup(func())
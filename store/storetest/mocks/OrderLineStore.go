@@ -18,6 +18,20 @@ type OrderLineStore struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: tx, orderLineIDs
+func (_m *OrderLineStore) Delete(tx boil.ContextTransactor, orderLineIDs []string) error {
+	ret := _m.Called(tx, orderLineIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) error); ok {
+		r0 = rf(tx, orderLineIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FilterbyOption provides a mock function with given fields: option
 func (_m *OrderLineStore) FilterbyOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, error) {
 	ret := _m.Called(option)
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	mock "github.com/stretchr/testify/mock"
+
 	boil "github.com/volatiletech/sqlboiler/v4/boil"
 
 	model "github.com/sitename/sitename/model"
@@ -18,6 +19,20 @@ type OrderStore struct {
 	mock.Mock
 }
 
+// AnonymizeByUser provides a mock function with given fields: tx, userID, email
+func (_m *OrderStore) AnonymizeByUser(tx boil.ContextTransactor, userID string, email string) error {
+	ret := _m.Called(tx, userID, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, string, string) error); ok {
+		r0 = rf(tx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BulkUpsert provides a mock function with given fields: tx, orders
 func (_m *OrderStore) BulkUpsert(tx boil.ContextTransactor, orders model.OrderSlice) (model.OrderSlice, error) {
 	ret := _m.Called(tx, orders)
@@ -44,6 +59,32 @@ func (_m *OrderStore) BulkUpsert(tx boil.ContextTransactor, orders model.OrderSl
 	return r0, r1
 }
 
+// CountByUserIDs provides a mock function with given fields: userIDs
+func (_m *OrderStore) CountByUserIDs(userIDs []string) ([]*model_helper.OrderCountByUserID, error) {
+	ret := _m.Called(userIDs)
+
+	var r0 []*model_helper.OrderCountByUserID
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*model_helper.OrderCountByUserID, error)); ok {
+		return rf(userIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*model_helper.OrderCountByUserID); ok {
+		r0 = rf(userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model_helper.OrderCountByUserID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: tx, ids
 func (_m *OrderStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	ret := _m.Called(tx, ids)
@@ -18,6 +18,20 @@ type PaymentStore struct {
 	mock.Mock
 }
 
+// AnonymizeByOrders provides a mock function with given fields: tx, orderIDs, email
+func (_m *PaymentStore) AnonymizeByOrders(tx boil.ContextTransactor, orderIDs []string, email string) error {
+	ret := _m.Called(tx, orderIDs, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string, string) error); ok {
+		r0 = rf(tx, orderIDs, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CancelActivePaymentsOfCheckout provides a mock function with given fields: checkoutToken
 func (_m *PaymentStore) CancelActivePaymentsOfCheckout(checkoutToken string) error {
 	ret := _m.Called(checkoutToken)
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	mock "github.com/stretchr/testify/mock"
+
 	boil "github.com/volatiletech/sqlboiler/v4/boil"
 
 	model "github.com/sitename/sitename/model"
@@ -21,17 +22,53 @@ type ProductStore struct {
 }
 
 // AdvancedFilterQueryBuilder provides a mock function with given fields: input
-func (_m *ProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder {
+func (_m *ProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error) {
 	ret := _m.Called(input)
 
 	var r0 squirrel.SelectBuilder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error)); ok {
+		return rf(input)
+	}
 	if rf, ok := ret.Get(0).(func(model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder); ok {
 		r0 = rf(input)
 	} else {
 		r0 = ret.Get(0).(squirrel.SelectBuilder)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(model_helper.ExportProductsFilterOptions) error); ok {
+		r1 = rf(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CategoryInteractionsOfUser provides a mock function with given fields: userID
+func (_m *ProductStore) CategoryInteractionsOfUser(userID string) ([]*model_helper.ProductCategoryInteraction, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*model_helper.ProductCategoryInteraction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model_helper.ProductCategoryInteraction, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model_helper.ProductCategoryInteraction); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model_helper.ProductCategoryInteraction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // CountByCategoryIDs provides a mock function with given fields: categoryIDs
@@ -60,6 +97,32 @@ func (_m *ProductStore) CountByCategoryIDs(categoryIDs []string) ([]*model_helpe
 	return r0, r1
 }
 
+// Feed provides a mock function with given fields: options
+func (_m *ProductStore) Feed(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error) {
+	ret := _m.Called(options)
+
+	var r0 []*model_helper.ProductFeedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.ProductFeedOptions) []*model_helper.ProductFeedItem); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model_helper.ProductFeedItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.ProductFeedOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FilterByOption provides a mock function with given fields: option
 func (_m *ProductStore) FilterByOption(option model_helper.ProductFilterOption) (model.ProductSlice, error) {
 	ret := _m.Called(option)
@@ -112,6 +175,58 @@ func (_m *ProductStore) FilterByQuery(query squirrel.SelectBuilder) (model.Produ
 	return r0, r1
 }
 
+// GetForIndexing provides a mock function with given fields: productIDs
+func (_m *ProductStore) GetForIndexing(productIDs []string) ([]*model_helper.ProductForIndexing, error) {
+	ret := _m.Called(productIDs)
+
+	var r0 []*model_helper.ProductForIndexing
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*model_helper.ProductForIndexing, error)); ok {
+		return rf(productIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*model_helper.ProductForIndexing); ok {
+		r0 = rf(productIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model_helper.ProductForIndexing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(productIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductsBatchForIndexing provides a mock function with given fields: startTime, endTime, limit
+func (_m *ProductStore) GetProductsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model_helper.ProductForIndexing, error) {
+	ret := _m.Called(startTime, endTime, limit)
+
+	var r0 []*model_helper.ProductForIndexing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int) ([]*model_helper.ProductForIndexing, error)); ok {
+		return rf(startTime, endTime, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, int) []*model_helper.ProductForIndexing); ok {
+		r0 = rf(startTime, endTime, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model_helper.ProductForIndexing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(startTime, endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NotPublishedProducts provides a mock function with given fields: channelID
 func (_m *ProductStore) NotPublishedProducts(channelID string) (model.ProductSlice, error) {
 	ret := _m.Called(channelID)
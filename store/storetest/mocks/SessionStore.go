@@ -7,7 +7,10 @@ package mocks
 import (
 	context "context"
 
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
 	model "github.com/sitename/sitename/model"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -45,6 +48,30 @@ func (_m *SessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	_m.Called(expiryTime, batchSize)
 }
 
+// CountExpiredBefore provides a mock function with given fields: endTime
+func (_m *SessionStore) CountExpiredBefore(endTime int64) (int64, error) {
+	ret := _m.Called(endTime)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(endTime)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(endTime)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, sessionIDOrToken
 func (_m *SessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
 	ret := _m.Called(ctx, sessionIDOrToken)
@@ -149,13 +176,37 @@ func (_m *SessionStore) GetSessionsWithActiveDeviceIds(userID string) (model.Ses
 	return r0, r1
 }
 
-// PermanentDeleteSessionsByUser provides a mock function with given fields: userID
-func (_m *SessionStore) PermanentDeleteSessionsByUser(userID string) error {
-	ret := _m.Called(userID)
+// PermanentDeleteExpiredBatch provides a mock function with given fields: endTime, limit
+func (_m *SessionStore) PermanentDeleteExpiredBatch(endTime int64, limit int64) (int64, error) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(endTime, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteSessionsByUser provides a mock function with given fields: tx, userID
+func (_m *SessionStore) PermanentDeleteSessionsByUser(tx boil.ContextTransactor, userID string) error {
+	ret := _m.Called(tx, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, string) error); ok {
+		r0 = rf(tx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.23.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
+	model "github.com/sitename/sitename/model"
+
+	model_helper "github.com/sitename/sitename/model_helper"
+)
+
+// SlugRedirectStore is an autogenerated mock type for the SlugRedirectStore type
+type SlugRedirectStore struct {
+	mock.Mock
+}
+
+// FilterByOptions provides a mock function with given fields: options
+func (_m *SlugRedirectStore) FilterByOptions(options model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error) {
+	ret := _m.Called(options)
+
+	var r0 model.SlugRedirectSlice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.SlugRedirectFilterOptions) model.SlugRedirectSlice); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.SlugRedirectSlice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.SlugRedirectFilterOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByOldSlug provides a mock function with given fields: objectType, oldSlug
+func (_m *SlugRedirectStore) GetByOldSlug(objectType model_helper.SeoObjectType, oldSlug string) (*model.SlugRedirect, error) {
+	ret := _m.Called(objectType, oldSlug)
+
+	var r0 *model.SlugRedirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model_helper.SeoObjectType, string) (*model.SlugRedirect, error)); ok {
+		return rf(objectType, oldSlug)
+	}
+	if rf, ok := ret.Get(0).(func(model_helper.SeoObjectType, string) *model.SlugRedirect); ok {
+		r0 = rf(objectType, oldSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SlugRedirect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model_helper.SeoObjectType, string) error); ok {
+		r1 = rf(objectType, oldSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordSlugChange provides a mock function with given fields: tx, objectType, objectID, newSlug
+func (_m *SlugRedirectStore) RecordSlugChange(tx boil.ContextTransactor, objectType model_helper.SeoObjectType, objectID string, newSlug string) error {
+	ret := _m.Called(tx, objectType, objectID, newSlug)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, model_helper.SeoObjectType, string, string) error); ok {
+		r0 = rf(tx, objectType, objectID, newSlug)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSlugRedirectStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSlugRedirectStore creates a new instance of SlugRedirectStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSlugRedirectStore(t mockConstructorTestingTNewSlugRedirectStore) *SlugRedirectStore {
+	mock := &SlugRedirectStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -247,6 +247,22 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// BackInStockSubscription provides a mock function with given fields:
+func (_m *Store) BackInStockSubscription() store.BackInStockSubscriptionStore {
+	ret := _m.Called()
+
+	var r0 store.BackInStockSubscriptionStore
+	if rf, ok := ret.Get(0).(func() store.BackInStockSubscriptionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.BackInStockSubscriptionStore)
+		}
+	}
+
+	return r0
+}
+
 // Category provides a mock function with given fields:
 func (_m *Store) Category() store.CategoryStore {
 	ret := _m.Called()
@@ -343,6 +359,22 @@ func (_m *Store) CheckoutLine() store.CheckoutLineStore {
 	return r0
 }
 
+// CheckoutRecovery provides a mock function with given fields:
+func (_m *Store) CheckoutRecovery() store.CheckoutRecoveryStore {
+	ret := _m.Called()
+
+	var r0 store.CheckoutRecoveryStore
+	if rf, ok := ret.Get(0).(func() store.CheckoutRecoveryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.CheckoutRecoveryStore)
+		}
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *Store) Close() {
 	_m.Called()
@@ -1210,6 +1242,22 @@ func (_m *Store) ProductMedia() store.ProductMediaStore {
 	return r0
 }
 
+// ProductRecommendation provides a mock function with given fields:
+func (_m *Store) ProductRecommendation() store.ProductRecommendationStore {
+	ret := _m.Called()
+
+	var r0 store.ProductRecommendationStore
+	if rf, ok := ret.Get(0).(func() store.ProductRecommendationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ProductRecommendationStore)
+		}
+	}
+
+	return r0
+}
+
 // ProductTranslation provides a mock function with given fields:
 func (_m *Store) ProductTranslation() store.ProductTranslationStore {
 	ret := _m.Called()
@@ -1467,6 +1515,22 @@ func (_m *Store) ShopTranslation() store.ShopTranslationStore {
 	return r0
 }
 
+// SlugRedirect provides a mock function with given fields:
+func (_m *Store) SlugRedirect() store.SlugRedirectStore {
+	ret := _m.Called()
+
+	var r0 store.SlugRedirectStore
+	if rf, ok := ret.Get(0).(func() store.SlugRedirectStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SlugRedirectStore)
+		}
+	}
+
+	return r0
+}
+
 // StaffNotificationRecipient provides a mock function with given fields:
 func (_m *Store) StaffNotificationRecipient() store.StaffNotificationRecipientStore {
 	ret := _m.Called()
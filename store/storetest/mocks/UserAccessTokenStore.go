@@ -6,8 +6,11 @@ package mocks
 
 import (
 	model "github.com/sitename/sitename/model"
+
 	mock "github.com/stretchr/testify/mock"
 
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
 	model_helper "github.com/sitename/sitename/model_helper"
 )
 
@@ -30,13 +33,13 @@ func (_m *UserAccessTokenStore) Delete(tokenID string) error {
 	return r0
 }
 
-// DeleteAllForUser provides a mock function with given fields: userID
-func (_m *UserAccessTokenStore) DeleteAllForUser(userID string) error {
-	ret := _m.Called(userID)
+// DeleteAllForUser provides a mock function with given fields: tx, userID
+func (_m *UserAccessTokenStore) DeleteAllForUser(tx boil.ContextTransactor, userID string) error {
+	ret := _m.Called(tx, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, string) error); ok {
+		r0 = rf(tx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
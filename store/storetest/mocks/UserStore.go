@@ -7,7 +7,10 @@ package mocks
 import (
 	context "context"
 
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
 	model "github.com/sitename/sitename/model"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model_helper "github.com/sitename/sitename/model_helper"
@@ -164,6 +167,20 @@ func (_m *UserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 	return r0, r1
 }
 
+// Anonymize provides a mock function with given fields: tx, userID, email
+func (_m *UserStore) Anonymize(tx boil.ContextTransactor, userID string, email string) error {
+	ret := _m.Called(tx, userID, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, string, string) error); ok {
+		r0 = rf(tx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ClearAllCustomRoleAssignments provides a mock function with given fields:
 func (_m *UserStore) ClearAllCustomRoleAssignments() error {
 	ret := _m.Called()
@@ -6,8 +6,11 @@ package mocks
 
 import (
 	model "github.com/sitename/sitename/model"
+
 	mock "github.com/stretchr/testify/mock"
 
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+
 	model_helper "github.com/sitename/sitename/model_helper"
 )
 
@@ -16,13 +19,13 @@ type VoucherCustomerStore struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ids
-func (_m *VoucherCustomerStore) Delete(ids []string) error {
-	ret := _m.Called(ids)
+// Delete provides a mock function with given fields: tx, ids
+func (_m *VoucherCustomerStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	ret := _m.Called(tx, ids)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(ids)
+	if rf, ok := ret.Get(0).(func(boil.ContextTransactor, []string) error); ok {
+		r0 = rf(tx, ids)
 	} else {
 		r0 = ret.Error(0)
 	}
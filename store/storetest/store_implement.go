@@ -126,6 +126,10 @@ func (*Store) Audit() store.AuditStore {
 	panic("unimplemented")
 }
 
+func (*Store) BackInStockSubscription() store.BackInStockSubscriptionStore {
+	panic("unimplemented")
+}
+
 func (*Store) Category() store.CategoryStore {
 	panic("unimplemented")
 }
@@ -150,6 +154,10 @@ func (*Store) CheckoutLine() store.CheckoutLineStore {
 	panic("unimplemented")
 }
 
+func (*Store) CheckoutRecovery() store.CheckoutRecoveryStore {
+	panic("unimplemented")
+}
+
 func (*Store) Close() {}
 
 func (*Store) ClusterDiscovery() store.ClusterDiscoveryStore {
@@ -385,6 +393,11 @@ func (*Store) ProductMedia() store.ProductMediaStore {
 	panic("unimplemented")
 }
 
+// ProductRecommendation implements store.Store.
+func (*Store) ProductRecommendation() store.ProductRecommendationStore {
+	panic("unimplemented")
+}
+
 // ProductTranslation implements store.Store.
 func (*Store) ProductTranslation() store.ProductTranslationStore {
 	panic("unimplemented")
@@ -453,6 +466,11 @@ func (*Store) ShopTranslation() store.ShopTranslationStore {
 	panic("unimplemented")
 }
 
+// SlugRedirect implements store.Store.
+func (*Store) SlugRedirect() store.SlugRedirectStore {
+	panic("unimplemented")
+}
+
 // StaffNotificationRecipient implements store.Store.
 func (*Store) StaffNotificationRecipient() store.StaffNotificationRecipientStore {
 	panic("unimplemented")
@@ -34,11 +34,13 @@ type TimerLayer struct {
 	AttributeValueStore                store.AttributeValueStore
 	AttributeValueTranslationStore     store.AttributeValueTranslationStore
 	AuditStore                         store.AuditStore
+	BackInStockSubscriptionStore       store.BackInStockSubscriptionStore
 	CategoryStore                      store.CategoryStore
 	CategoryTranslationStore           store.CategoryTranslationStore
 	ChannelStore                       store.ChannelStore
 	CheckoutStore                      store.CheckoutStore
 	CheckoutLineStore                  store.CheckoutLineStore
+	CheckoutRecoveryStore              store.CheckoutRecoveryStore
 	ClusterDiscoveryStore              store.ClusterDiscoveryStore
 	CollectionStore                    store.CollectionStore
 	CollectionChannelListingStore      store.CollectionChannelListingStore
@@ -84,6 +86,7 @@ type TimerLayer struct {
 	ProductStore                       store.ProductStore
 	ProductChannelListingStore         store.ProductChannelListingStore
 	ProductMediaStore                  store.ProductMediaStore
+	ProductRecommendationStore         store.ProductRecommendationStore
 	ProductTranslationStore            store.ProductTranslationStore
 	ProductTypeStore                   store.ProductTypeStore
 	ProductVariantStore                store.ProductVariantStore
@@ -98,6 +101,7 @@ type TimerLayer struct {
 	ShippingZoneStore                  store.ShippingZoneStore
 	ShopStaffStore                     store.ShopStaffStore
 	ShopTranslationStore               store.ShopTranslationStore
+	SlugRedirectStore                  store.SlugRedirectStore
 	StaffNotificationRecipientStore    store.StaffNotificationRecipientStore
 	StatusStore                        store.StatusStore
 	StockStore                         store.StockStore
@@ -172,6 +176,10 @@ func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) BackInStockSubscription() store.BackInStockSubscriptionStore {
+	return s.BackInStockSubscriptionStore
+}
+
 func (s *TimerLayer) Category() store.CategoryStore {
 	return s.CategoryStore
 }
@@ -192,6 +200,10 @@ func (s *TimerLayer) CheckoutLine() store.CheckoutLineStore {
 	return s.CheckoutLineStore
 }
 
+func (s *TimerLayer) CheckoutRecovery() store.CheckoutRecoveryStore {
+	return s.CheckoutRecoveryStore
+}
+
 func (s *TimerLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -372,6 +384,10 @@ func (s *TimerLayer) ProductMedia() store.ProductMediaStore {
 	return s.ProductMediaStore
 }
 
+func (s *TimerLayer) ProductRecommendation() store.ProductRecommendationStore {
+	return s.ProductRecommendationStore
+}
+
 func (s *TimerLayer) ProductTranslation() store.ProductTranslationStore {
 	return s.ProductTranslationStore
 }
@@ -428,6 +444,10 @@ func (s *TimerLayer) ShopTranslation() store.ShopTranslationStore {
 	return s.ShopTranslationStore
 }
 
+func (s *TimerLayer) SlugRedirect() store.SlugRedirectStore {
+	return s.SlugRedirectStore
+}
+
 func (s *TimerLayer) StaffNotificationRecipient() store.StaffNotificationRecipientStore {
 	return s.StaffNotificationRecipientStore
 }
@@ -562,6 +582,11 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerBackInStockSubscriptionStore struct {
+	store.BackInStockSubscriptionStore
+	Root *TimerLayer
+}
+
 type TimerLayerCategoryStore struct {
 	store.CategoryStore
 	Root *TimerLayer
@@ -587,6 +612,11 @@ type TimerLayerCheckoutLineStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerCheckoutRecoveryStore struct {
+	store.CheckoutRecoveryStore
+	Root *TimerLayer
+}
+
 type TimerLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *TimerLayer
@@ -812,6 +842,11 @@ type TimerLayerProductMediaStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerProductRecommendationStore struct {
+	store.ProductRecommendationStore
+	Root *TimerLayer
+}
+
 type TimerLayerProductTranslationStore struct {
 	store.ProductTranslationStore
 	Root *TimerLayer
@@ -882,6 +917,11 @@ type TimerLayerShopTranslationStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerSlugRedirectStore struct {
+	store.SlugRedirectStore
+	Root *TimerLayer
+}
+
 type TimerLayerStaffNotificationRecipientStore struct {
 	store.StaffNotificationRecipientStore
 	Root *TimerLayer
@@ -962,6 +1002,22 @@ type TimerLayerWishlistItemStore struct {
 	Root *TimerLayer
 }
 
+func (s *TimerLayerAddressStore) Anonymize(tx boil.ContextTransactor, addressIDs []string) error {
+	start := timemodule.Now()
+
+	err := s.AddressStore.Anonymize(tx, addressIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AddressStore.Anonymize", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerAddressStore) DeleteAddresses(tx boil.ContextTransactor, addressIDs []string) error {
 	start := timemodule.Now()
 
@@ -1234,10 +1290,10 @@ func (s *TimerLayerAssignedProductAttributeStore) GetWithOption(option model_hel
 	return result, err
 }
 
-func (s *TimerLayerAssignedProductAttributeStore) Save(assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
+func (s *TimerLayerAssignedProductAttributeStore) Save(tx boil.ContextTransactor, assignedProductAttribute model.AssignedProductAttribute) (*model.AssignedProductAttribute, error) {
 	start := timemodule.Now()
 
-	result, err := s.AssignedProductAttributeStore.Save(assignedProductAttribute)
+	result, err := s.AssignedProductAttributeStore.Save(tx, assignedProductAttribute)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1250,6 +1306,22 @@ func (s *TimerLayerAssignedProductAttributeStore) Save(assignedProductAttribute
 	return result, err
 }
 
+func (s *TimerLayerAssignedProductAttributeValueStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.AssignedProductAttributeValueStore.Delete(tx, ids)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AssignedProductAttributeValueStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerAssignedProductAttributeValueStore) FilterByOptions(options model_helper.AssignedProductAttributeValueFilterOptions) (model.AssignedProductAttributeValueSlice, error) {
 	start := timemodule.Now()
 
@@ -1282,10 +1354,10 @@ func (s *TimerLayerAssignedProductAttributeValueStore) Get(assignedProductAttrVa
 	return result, err
 }
 
-func (s *TimerLayerAssignedProductAttributeValueStore) Save(assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
+func (s *TimerLayerAssignedProductAttributeValueStore) Save(tx boil.ContextTransactor, assignedProductAttrValues model.AssignedProductAttributeValueSlice) (model.AssignedProductAttributeValueSlice, error) {
 	start := timemodule.Now()
 
-	result, err := s.AssignedProductAttributeValueStore.Save(assignedProductAttrValues)
+	result, err := s.AssignedProductAttributeValueStore.Save(tx, assignedProductAttrValues)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1538,6 +1610,22 @@ func (s *TimerLayerAttributeValueStore) Upsert(tx boil.ContextTransactor, values
 	return result, err
 }
 
+func (s *TimerLayerAuditStore) CountBefore(endTime int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.AuditStore.CountBefore(endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.CountBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerAuditStore) Get(userID string, offset int, limit int) (model.AuditSlice, error) {
 	start := timemodule.Now()
 
@@ -1554,6 +1642,22 @@ func (s *TimerLayerAuditStore) Get(userID string, offset int, limit int) (model.
 	return result, err
 }
 
+func (s *TimerLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.AuditStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerAuditStore) PermanentDeleteByUser(userID string) error {
 	start := timemodule.Now()
 
@@ -1586,6 +1690,54 @@ func (s *TimerLayerAuditStore) Save(audit model.Audit) error {
 	return err
 }
 
+func (s *TimerLayerBackInStockSubscriptionStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	start := timemodule.Now()
+
+	err := s.BackInStockSubscriptionStore.Delete(tx, ids)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BackInStockSubscriptionStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerBackInStockSubscriptionStore) FilterByOptions(options model_helper.BackInStockSubscriptionFilterOptions) (model.BackInStockSubscriptionSlice, error) {
+	start := timemodule.Now()
+
+	result, err := s.BackInStockSubscriptionStore.FilterByOptions(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BackInStockSubscriptionStore.FilterByOptions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerBackInStockSubscriptionStore) Save(subscription model.BackInStockSubscription) (*model.BackInStockSubscription, error) {
+	start := timemodule.Now()
+
+	result, err := s.BackInStockSubscriptionStore.Save(subscription)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("BackInStockSubscriptionStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerCategoryStore) FilterByOption(option model_helper.CategoryFilterOption) (model.CategorySlice, error) {
 	start := timemodule.Now()
 
@@ -1874,6 +2026,54 @@ func (s *TimerLayerCheckoutLineStore) Upsert(checkoutLines model.CheckoutLineSli
 	return result, err
 }
 
+func (s *TimerLayerCheckoutRecoveryStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	start := timemodule.Now()
+
+	err := s.CheckoutRecoveryStore.Delete(tx, ids)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CheckoutRecoveryStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerCheckoutRecoveryStore) FilterByOptions(options model_helper.CheckoutRecoveryFilterOptions) (model.CheckoutRecoverySlice, error) {
+	start := timemodule.Now()
+
+	result, err := s.CheckoutRecoveryStore.FilterByOptions(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CheckoutRecoveryStore.FilterByOptions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerCheckoutRecoveryStore) Upsert(tx boil.ContextTransactor, recovery model.CheckoutRecovery) (*model.CheckoutRecovery, error) {
+	start := timemodule.Now()
+
+	result, err := s.CheckoutRecoveryStore.Upsert(tx, recovery)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CheckoutRecoveryStore.Upsert", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 	start := timemodule.Now()
 
@@ -2194,6 +2394,54 @@ func (s *TimerLayerCsvExportEventStore) Save(event model.ExportEvent) (*model.Ex
 	return result, err
 }
 
+func (s *TimerLayerCsvExportFileStore) CountBefore(endTime int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.CsvExportFileStore.CountBefore(endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CsvExportFileStore.CountBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerCsvExportFileStore) Delete(tx boil.ContextTransactor, ids []string) error {
+	start := timemodule.Now()
+
+	err := s.CsvExportFileStore.Delete(tx, ids)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CsvExportFileStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerCsvExportFileStore) FilterByOptions(options model_helper.ExportFileFilterOption) (model.ExportFileSlice, error) {
+	start := timemodule.Now()
+
+	result, err := s.CsvExportFileStore.FilterByOptions(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CsvExportFileStore.FilterByOptions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerCsvExportFileStore) Get(id string) (*model.ExportFile, error) {
 	start := timemodule.Now()
 
@@ -2210,10 +2458,10 @@ func (s *TimerLayerCsvExportFileStore) Get(id string) (*model.ExportFile, error)
 	return result, err
 }
 
-func (s *TimerLayerCsvExportFileStore) Save(file model.ExportFile) (*model.ExportFile, error) {
+func (s *TimerLayerCsvExportFileStore) Upsert(file model.ExportFile) (*model.ExportFile, error) {
 	start := timemodule.Now()
 
-	result, err := s.CsvExportFileStore.Save(file)
+	result, err := s.CsvExportFileStore.Upsert(file)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2221,11 +2469,27 @@ func (s *TimerLayerCsvExportFileStore) Save(file model.ExportFile) (*model.Expor
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CsvExportFileStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CsvExportFileStore.Upsert", success, elapsed)
 	}
 	return result, err
 }
 
+func (s *TimerLayerCustomProductAttributeStore) AssignVariantValues(tx boil.ContextTransactor, productID string, variantID string, inputs []*model_helper.CustomProductAttributeValuesInput) error {
+	start := timemodule.Now()
+
+	err := s.CustomProductAttributeStore.AssignVariantValues(tx, productID, variantID, inputs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomProductAttributeStore.AssignVariantValues", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerCustomProductAttributeStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	start := timemodule.Now()
 
@@ -2290,6 +2554,22 @@ func (s *TimerLayerCustomerEventStore) Count() (int64, error) {
 	return result, err
 }
 
+func (s *TimerLayerCustomerEventStore) CountBefore(endTime int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.CustomerEventStore.CountBefore(endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomerEventStore.CountBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerCustomerEventStore) FilterByOptions(options model_helper.CustomerEventFilterOptions) (model.CustomerEventSlice, error) {
 	start := timemodule.Now()
 
@@ -2322,6 +2602,22 @@ func (s *TimerLayerCustomerEventStore) Get(id string) (*model.CustomerEvent, err
 	return result, err
 }
 
+func (s *TimerLayerCustomerEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.CustomerEventStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("CustomerEventStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerCustomerEventStore) Upsert(tx boil.ContextTransactor, customemrEvent model.CustomerEvent) (*model.CustomerEvent, error) {
 	start := timemodule.Now()
 
@@ -2674,10 +2970,10 @@ func (s *TimerLayerDiscountVoucherStore) Get(id string) (*model.Voucher, error)
 	return result, err
 }
 
-func (s *TimerLayerDiscountVoucherStore) Upsert(voucher model.Voucher) (*model.Voucher, error) {
+func (s *TimerLayerDiscountVoucherStore) Upsert(tx boil.ContextTransactor, voucher model.Voucher) (*model.Voucher, error) {
 	start := timemodule.Now()
 
-	result, err := s.DiscountVoucherStore.Upsert(voucher)
+	result, err := s.DiscountVoucherStore.Upsert(tx, voucher)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3472,6 +3768,22 @@ func (s *TimerLayerOpenExchangeRateStore) GetAll() (model.OpenExchangeRateSlice,
 	return result, err
 }
 
+func (s *TimerLayerOrderStore) AnonymizeByUser(tx boil.ContextTransactor, userID string, email string) error {
+	start := timemodule.Now()
+
+	err := s.OrderStore.AnonymizeByUser(tx, userID, email)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderStore.AnonymizeByUser", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerOrderStore) BulkUpsert(tx boil.ContextTransactor, orders model.OrderSlice) (model.OrderSlice, error) {
 	start := timemodule.Now()
 
@@ -3488,6 +3800,22 @@ func (s *TimerLayerOrderStore) BulkUpsert(tx boil.ContextTransactor, orders mode
 	return result, err
 }
 
+func (s *TimerLayerOrderStore) CountByUserIDs(userIDs []string) ([]*model_helper.OrderCountByUserID, error) {
+	start := timemodule.Now()
+
+	result, err := s.OrderStore.CountByUserIDs(userIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderStore.CountByUserIDs", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerOrderStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	start := timemodule.Now()
 
@@ -3600,10 +3928,90 @@ func (s *TimerLayerOrderDiscountStore) Upsert(tx boil.ContextTransactor, orderDi
 	return result, err
 }
 
-func (s *TimerLayerOrderLineStore) FilterbyOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, error) {
+func (s *TimerLayerOrderEventStore) CountBefore(endTime int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.OrderLineStore.FilterbyOption(option)
+	result, err := s.OrderEventStore.CountBefore(endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderEventStore.CountBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOrderEventStore) FilterByOptions(options model_helper.OrderEventFilterOptions) (model.OrderEventSlice, error) {
+	start := timemodule.Now()
+
+	result, err := s.OrderEventStore.FilterByOptions(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderEventStore.FilterByOptions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOrderEventStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.OrderEventStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderEventStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOrderEventStore) Save(tx boil.ContextTransactor, orderEvent model.OrderEvent) (*model.OrderEvent, error) {
+	start := timemodule.Now()
+
+	result, err := s.OrderEventStore.Save(tx, orderEvent)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderEventStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOrderLineStore) Delete(tx boil.ContextTransactor, orderLineIDs []string) error {
+	start := timemodule.Now()
+
+	err := s.OrderLineStore.Delete(tx, orderLineIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OrderLineStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerOrderLineStore) FilterbyOption(option model_helper.OrderLineFilterOptions) (model.OrderLineSlice, error) {
+	start := timemodule.Now()
+
+	result, err := s.OrderLineStore.FilterbyOption(option)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3664,6 +4072,22 @@ func (s *TimerLayerPageStore) FilterByOptions(options model_helper.PageFilterOpt
 	return result, err
 }
 
+func (s *TimerLayerPaymentStore) AnonymizeByOrders(tx boil.ContextTransactor, orderIDs []string, email string) error {
+	start := timemodule.Now()
+
+	err := s.PaymentStore.AnonymizeByOrders(tx, orderIDs, email)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PaymentStore.AnonymizeByOrders", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPaymentStore) CancelActivePaymentsOfCheckout(checkoutToken string) error {
 	start := timemodule.Now()
 
@@ -4175,20 +4599,36 @@ func (s *TimerLayerPreorderAllocationStore) Upsert(tx boil.ContextTransactor, pr
 	return result, err
 }
 
-func (s *TimerLayerProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) squirrel.SelectBuilder {
+func (s *TimerLayerProductStore) AdvancedFilterQueryBuilder(input model_helper.ExportProductsFilterOptions) (squirrel.SelectBuilder, error) {
 	start := timemodule.Now()
 
-	result := s.ProductStore.AdvancedFilterQueryBuilder(input)
+	result, err := s.ProductStore.AdvancedFilterQueryBuilder(input)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ProductStore.AdvancedFilterQueryBuilder", success, elapsed)
 	}
-	return result
+	return result, err
+}
+
+func (s *TimerLayerProductStore) CategoryInteractionsOfUser(userID string) ([]*model_helper.ProductCategoryInteraction, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductStore.CategoryInteractionsOfUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductStore.CategoryInteractionsOfUser", success, elapsed)
+	}
+	return result, err
 }
 
 func (s *TimerLayerProductStore) CountByCategoryIDs(categoryIDs []string) ([]*model_helper.ProductCountByCategoryID, error) {
@@ -4207,6 +4647,22 @@ func (s *TimerLayerProductStore) CountByCategoryIDs(categoryIDs []string) ([]*mo
 	return result, err
 }
 
+func (s *TimerLayerProductStore) Feed(options model_helper.ProductFeedOptions) ([]*model_helper.ProductFeedItem, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductStore.Feed(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductStore.Feed", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerProductStore) FilterByOption(option model_helper.ProductFilterOption) (model.ProductSlice, error) {
 	start := timemodule.Now()
 
@@ -4239,6 +4695,38 @@ func (s *TimerLayerProductStore) FilterByQuery(query squirrel.SelectBuilder) (mo
 	return result, err
 }
 
+func (s *TimerLayerProductStore) GetForIndexing(productIDs []string) ([]*model_helper.ProductForIndexing, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductStore.GetForIndexing(productIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductStore.GetForIndexing", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerProductStore) GetProductsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model_helper.ProductForIndexing, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductStore.GetProductsBatchForIndexing(startTime, endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductStore.GetProductsBatchForIndexing", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerProductStore) NotPublishedProducts(channelID string) (model.ProductSlice, error) {
 	start := timemodule.Now()
 
@@ -4447,6 +4935,70 @@ func (s *TimerLayerProductMediaStore) Upsert(tx boil.ContextTransactor, medias m
 	return result, err
 }
 
+func (s *TimerLayerProductRecommendationStore) CoPurchases(channelID string, since int64, minCount int) ([]*model_helper.ProductCoPurchase, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductRecommendationStore.CoPurchases(channelID, since, minCount)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductRecommendationStore.CoPurchases", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerProductRecommendationStore) ProductsForRecommendations(channelID string, now int64, afterID string, limit int) ([]*model_helper.ProductForRecommendation, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductRecommendationStore.ProductsForRecommendations(channelID, now, afterID, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductRecommendationStore.ProductsForRecommendations", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerProductRecommendationStore) RecommendedProducts(options model_helper.RecommendedProductsOptions) ([]*model_helper.RecommendedProduct, error) {
+	start := timemodule.Now()
+
+	result, err := s.ProductRecommendationStore.RecommendedProducts(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductRecommendationStore.RecommendedProducts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerProductRecommendationStore) ReplaceForChannel(tx boil.ContextTransactor, channelID string, recommendations model.ProductRecommendationSlice) error {
+	start := timemodule.Now()
+
+	err := s.ProductRecommendationStore.ReplaceForChannel(tx, channelID, recommendations)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductRecommendationStore.ReplaceForChannel", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerProductVariantStore) Delete(tx boil.ContextTransactor, ids []string) (int64, error) {
 	start := timemodule.Now()
 
@@ -4750,6 +5302,22 @@ func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	}
 }
 
+func (s *TimerLayerSessionStore) CountExpiredBefore(endTime int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.CountExpiredBefore(endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.CountExpiredBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
 	start := timemodule.Now()
 
@@ -4814,10 +5382,26 @@ func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(userID string) error {
+func (s *TimerLayerSessionStore) PermanentDeleteExpiredBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	err := s.SessionStore.PermanentDeleteSessionsByUser(userID)
+	result, err := s.SessionStore.PermanentDeleteExpiredBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteExpiredBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(tx boil.ContextTransactor, userID string) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.PermanentDeleteSessionsByUser(tx, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5342,6 +5926,54 @@ func (s *TimerLayerShopTranslationStore) Upsert(translation model.ShopTranslatio
 	return result, err
 }
 
+func (s *TimerLayerSlugRedirectStore) FilterByOptions(options model_helper.SlugRedirectFilterOptions) (model.SlugRedirectSlice, error) {
+	start := timemodule.Now()
+
+	result, err := s.SlugRedirectStore.FilterByOptions(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SlugRedirectStore.FilterByOptions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSlugRedirectStore) GetByOldSlug(objectType model_helper.SeoObjectType, oldSlug string) (*model.SlugRedirect, error) {
+	start := timemodule.Now()
+
+	result, err := s.SlugRedirectStore.GetByOldSlug(objectType, oldSlug)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SlugRedirectStore.GetByOldSlug", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSlugRedirectStore) RecordSlugChange(tx boil.ContextTransactor, objectType model_helper.SeoObjectType, objectID string, newSlug string) error {
+	start := timemodule.Now()
+
+	err := s.SlugRedirectStore.RecordSlugChange(tx, objectType, objectID, newSlug)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SlugRedirectStore.RecordSlugChange", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerStaffNotificationRecipientStore) FilterByOptions(options model_helper.StaffNotificationRecipientFilterOptions) (model.StaffNotificationRecipientSlice, error) {
 	start := timemodule.Now()
 
@@ -5822,6 +6454,22 @@ func (s *TimerLayerTokenStore) Cleanup() error {
 	return err
 }
 
+func (s *TimerLayerTokenStore) CountBefore(endTime int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.TokenStore.CountBefore(endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.CountBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTokenStore) Delete(token string) error {
 	start := timemodule.Now()
 
@@ -5870,6 +6518,22 @@ func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 	return result, err
 }
 
+func (s *TimerLayerTokenStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.TokenStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTokenStore) Save(token model.Token) (*model.Token, error) {
 	start := timemodule.Now()
 
@@ -6046,6 +6710,22 @@ func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 	return result, err
 }
 
+func (s *TimerLayerUserStore) Anonymize(tx boil.ContextTransactor, userID string, email string) error {
+	start := timemodule.Now()
+
+	err := s.UserStore.Anonymize(tx, userID, email)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Anonymize", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserStore) ClearAllCustomRoleAssignments() error {
 	start := timemodule.Now()
 
@@ -6524,10 +7204,10 @@ func (s *TimerLayerUserAccessTokenStore) Delete(tokenID string) error {
 	return err
 }
 
-func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(tx boil.ContextTransactor, userID string) error {
 	start := timemodule.Now()
 
-	err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+	err := s.UserAccessTokenStore.DeleteAllForUser(tx, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6748,10 +7428,10 @@ func (s *TimerLayerVoucherChannelListingStore) Upsert(tx boil.ContextTransactor,
 	return result, err
 }
 
-func (s *TimerLayerVoucherCustomerStore) Delete(ids []string) error {
+func (s *TimerLayerVoucherCustomerStore) Delete(tx boil.ContextTransactor, ids []string) error {
 	start := timemodule.Now()
 
-	err := s.VoucherCustomerStore.Delete(ids)
+	err := s.VoucherCustomerStore.Delete(tx, ids)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7160,11 +7840,13 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.AttributeValueStore = &TimerLayerAttributeValueStore{AttributeValueStore: childStore.AttributeValue(), Root: &newStore}
 	newStore.AttributeValueTranslationStore = &TimerLayerAttributeValueTranslationStore{AttributeValueTranslationStore: childStore.AttributeValueTranslation(), Root: &newStore}
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.BackInStockSubscriptionStore = &TimerLayerBackInStockSubscriptionStore{BackInStockSubscriptionStore: childStore.BackInStockSubscription(), Root: &newStore}
 	newStore.CategoryStore = &TimerLayerCategoryStore{CategoryStore: childStore.Category(), Root: &newStore}
 	newStore.CategoryTranslationStore = &TimerLayerCategoryTranslationStore{CategoryTranslationStore: childStore.CategoryTranslation(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.CheckoutStore = &TimerLayerCheckoutStore{CheckoutStore: childStore.Checkout(), Root: &newStore}
 	newStore.CheckoutLineStore = &TimerLayerCheckoutLineStore{CheckoutLineStore: childStore.CheckoutLine(), Root: &newStore}
+	newStore.CheckoutRecoveryStore = &TimerLayerCheckoutRecoveryStore{CheckoutRecoveryStore: childStore.CheckoutRecovery(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CollectionStore = &TimerLayerCollectionStore{CollectionStore: childStore.Collection(), Root: &newStore}
 	newStore.CollectionChannelListingStore = &TimerLayerCollectionChannelListingStore{CollectionChannelListingStore: childStore.CollectionChannelListing(), Root: &newStore}
@@ -7210,6 +7892,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.ProductStore = &TimerLayerProductStore{ProductStore: childStore.Product(), Root: &newStore}
 	newStore.ProductChannelListingStore = &TimerLayerProductChannelListingStore{ProductChannelListingStore: childStore.ProductChannelListing(), Root: &newStore}
 	newStore.ProductMediaStore = &TimerLayerProductMediaStore{ProductMediaStore: childStore.ProductMedia(), Root: &newStore}
+	newStore.ProductRecommendationStore = &TimerLayerProductRecommendationStore{ProductRecommendationStore: childStore.ProductRecommendation(), Root: &newStore}
 	newStore.ProductTranslationStore = &TimerLayerProductTranslationStore{ProductTranslationStore: childStore.ProductTranslation(), Root: &newStore}
 	newStore.ProductTypeStore = &TimerLayerProductTypeStore{ProductTypeStore: childStore.ProductType(), Root: &newStore}
 	newStore.ProductVariantStore = &TimerLayerProductVariantStore{ProductVariantStore: childStore.ProductVariant(), Root: &newStore}
@@ -7224,6 +7907,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.ShippingZoneStore = &TimerLayerShippingZoneStore{ShippingZoneStore: childStore.ShippingZone(), Root: &newStore}
 	newStore.ShopStaffStore = &TimerLayerShopStaffStore{ShopStaffStore: childStore.ShopStaff(), Root: &newStore}
 	newStore.ShopTranslationStore = &TimerLayerShopTranslationStore{ShopTranslationStore: childStore.ShopTranslation(), Root: &newStore}
+	newStore.SlugRedirectStore = &TimerLayerSlugRedirectStore{SlugRedirectStore: childStore.SlugRedirect(), Root: &newStore}
 	newStore.StaffNotificationRecipientStore = &TimerLayerStaffNotificationRecipientStore{StaffNotificationRecipientStore: childStore.StaffNotificationRecipient(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.StockStore = &TimerLayerStockStore{StockStore: childStore.Stock(), Root: &newStore}
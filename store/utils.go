@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/mattermost/squirrel"
 	"github.com/sitename/sitename/modules/util"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 var escapeLikeSearchChar = []string{
@@ -174,6 +176,15 @@ func ExtractModelFieldNames(model any) util.AnyArray[string] {
 	return res
 }
 
+// BatchCondition selects up to limit rows of given table matching given where clause. Postgres has no
+// DELETE ... LIMIT, so ids of the rows are selected by a sub query.
+func BatchCondition(table, idColumn, where string, limit int64, args ...any) qm.QueryMod {
+	return qm.Where(
+		fmt.Sprintf("%[2]s IN (SELECT %[2]s FROM %[1]s WHERE %[3]s LIMIT ?)", table, idColumn, where),
+		append(args, limit)...,
+	)
+}
+
 type ContextRunner interface {
 	boil.ContextTransactor
 	BeginTx(context.Context, *sql.TxOptions) (ContextRunner, error)
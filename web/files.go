@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sitename/sitename/app/file"
+	"github.com/sitename/sitename/model_helper"
+)
+
+// InitFiles registers the endpoint serving presigned urls of the local file backend, which lets clients
+// download and upload files without an api session, and the endpoint publicly serving product images.
+// It must run before InitStatic, which catches all other paths.
+func (w *Web) InitFiles() {
+	w.MainRouter.Handle(file.SignedFilesURLPath+"/{path:.+}", w.NewHandler(getSignedFile)).Methods(http.MethodGet, http.MethodHead)
+	w.MainRouter.Handle(file.SignedFilesURLPath+"/{path:.+}", w.NewHandler(putSignedFile)).Methods(http.MethodPut)
+	w.MainRouter.Handle(model_helper.ProductMediaURLPath+"/{path:products/.+}", w.NewHandler(getProductMediaFile)).Methods(http.MethodGet, http.MethodHead)
+}
+
+// getProductMediaFile serves product images and their size variants, which are public like the products themselves
+func getProductMediaFile(c *Context, w http.ResponseWriter, r *http.Request) {
+	path := mux.Vars(r)["path"]
+	if !model_helper.IsProductMediaImagePath(path) {
+		c.SetInvalidUrlParam("path")
+		return
+	}
+
+	reader, appErr := c.App.Srv().File.FileReader(path)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	defer reader.Close()
+
+	modTime, appErr := c.App.Srv().File.FileModTime(path)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "max-age=3600, public")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, path, modTime, reader)
+}
+
+func getSignedFile(c *Context, w http.ResponseWriter, r *http.Request) {
+	path := mux.Vars(r)["path"]
+	if appErr := c.App.Srv().File.VerifyLocalSignedURL(http.MethodGet, path, r.URL.Query()); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	reader, appErr := c.App.Srv().File.FileReader(path)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	defer reader.Close()
+
+	modTime, appErr := c.App.Srv().File.FileModTime(path)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, path, modTime, reader)
+}
+
+func putSignedFile(c *Context, w http.ResponseWriter, r *http.Request) {
+	path := mux.Vars(r)["path"]
+	if appErr := c.App.Srv().File.VerifyLocalSignedURL(http.MethodPut, path, r.URL.Query()); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, *c.App.Config().FileSettings.MaxFileSize)
+	if _, appErr := c.App.Srv().File.WriteFile(body, path); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sitename/sitename/model_helper"
+)
+
+// relayStateRedirectTo is the relay state property holding the path users are sent to once signed in
+const relayStateRedirectTo = "redirect_to"
+
+// InitSaml registers SAML sign in routes. Users start SP-initiated sign ins on the login route, identity providers
+// post their responses to the same route, which also receives IdP-initiated sign ins.
+func (w *Web) InitSaml() {
+	w.MainRouter.Handle("/login/sso/saml", w.NewHandler(loginWithSaml)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/login/sso/saml", w.NewHandler(completeSaml)).Methods(http.MethodPost)
+	w.MainRouter.Handle("/login/sso/saml/metadata", w.NewHandler(samlMetadata)).Methods(http.MethodGet)
+}
+
+func loginWithSaml(c *Context, w http.ResponseWriter, r *http.Request) {
+	samlInterface := c.App.Saml()
+	if samlInterface == nil {
+		c.Err = model_helper.NewAppError("loginWithSaml", "api.user.saml.not_available.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	relayState := ""
+	if redirectTo := r.URL.Query().Get(relayStateRedirectTo); redirectTo != "" {
+		relayState = base64.StdEncoding.EncodeToString([]byte(model_helper.MapToJson(map[string]string{
+			relayStateRedirectTo: redirectTo,
+		})))
+	}
+
+	data, appErr := samlInterface.BuildRequest(relayState)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	http.Redirect(w, r, data.URL, http.StatusFound)
+}
+
+func completeSaml(c *Context, w http.ResponseWriter, r *http.Request) {
+	samlInterface := c.App.Saml()
+	if samlInterface == nil {
+		c.Err = model_helper.NewAppError("completeSaml", "api.user.saml.not_available.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	// IdP-initiated sign ins come without relay state
+	relayProps := map[string]string{}
+	if relayState := r.FormValue("RelayState"); relayState != "" {
+		data, err := base64.StdEncoding.DecodeString(relayState)
+		if err != nil {
+			c.Err = model_helper.NewAppError("completeSaml", "api.user.saml.invalid_relay_state.app_error", nil, err.Error(), http.StatusBadRequest)
+			return
+		}
+		relayProps = model_helper.MapFromJson(strings.NewReader(string(data)))
+	}
+
+	user, appErr := samlInterface.DoLogin(c.AppContext, r.FormValue("SAMLResponse"), relayProps)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if appErr := c.App.Srv().Account.CheckUserAllAuthenticationCriteria(*user, ""); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if appErr := c.App.Srv().Account.DoLogin(c.AppContext, w, r, *user, "", false, false, true); appErr != nil {
+		c.Err = appErr
+		return
+	}
+	c.App.Srv().Account.AttachSessionCookies(c.AppContext, w, r)
+
+	http.Redirect(w, r, fullyQualifiedRedirectURL(c.GetSiteURLHeader(), relayProps[relayStateRedirectTo]), http.StatusFound)
+}
+
+func samlMetadata(c *Context, w http.ResponseWriter, r *http.Request) {
+	samlInterface := c.App.Saml()
+	if samlInterface == nil {
+		c.Err = model_helper.NewAppError("samlMetadata", "api.user.saml.not_available.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	metadata, appErr := samlInterface.GetMetadata()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write([]byte(metadata))
+}
+
+// fullyQualifiedRedirectURL prefixes given path with the site URL. Absolute URLs are ignored, so sign ins can't
+// redirect users to other sites.
+func fullyQualifiedRedirectURL(siteURL, target string) string {
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" || strings.HasPrefix(target, "//") {
+		return siteURL
+	}
+	if target != "" && target[0] != '/' {
+		target = "/" + target
+	}
+	return siteURL + target
+}
@@ -0,0 +1,80 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sitename/sitename/model_helper"
+)
+
+// InitSeo registers sitemaps of channels' storefronts and permanent redirects from old slugs of
+// products, categories and collections. It must run before InitStatic, which catches all other paths.
+func (w *Web) InitSeo() {
+	w.MainRouter.Handle("/sitemaps/{channel_slug:[A-Za-z0-9_-]+}.xml", w.NewHandler(sitemapIndex)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/sitemaps/{channel_slug:[A-Za-z0-9_-]+}/{number:[0-9]+}.xml", w.NewHandler(sitemap)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/{channel_slug}/{segment:products|categories|collections}/{slug}/", w.NewStaticHandler(slugRedirect)).Methods(http.MethodGet)
+}
+
+func sitemapIndex(c *Context, w http.ResponseWriter, r *http.Request) {
+	data, appErr := c.App.Srv().Seo.SitemapIndex(mux.Vars(r)["channel_slug"])
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeSitemapXML(w, data)
+}
+
+func sitemap(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	number, err := strconv.Atoi(vars["number"])
+	if err != nil {
+		c.SetInvalidUrlParam("number")
+		return
+	}
+
+	data, appErr := c.App.Srv().Seo.Sitemap(vars["channel_slug"], number)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeSitemapXML(w, data)
+}
+
+func writeSitemapXML(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "max-age=3600, public")
+	w.Write(data)
+}
+
+// slugRedirect permanently redirects storefront paths using old slugs of renamed objects to their current paths.
+// Other paths are served as usual.
+func slugRedirect(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	objectType, _ := model_helper.SeoObjectTypeFromPathSegment(vars["segment"])
+	currentSlug, appErr := c.App.Srv().Seo.ResolveSlugRedirect(objectType, vars["slug"])
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		c.Err = appErr
+		return
+	}
+
+	if appErr == nil && currentSlug != vars["slug"] {
+		subpath, _ := model_helper.GetSubpathFromConfig(c.App.Config())
+		target := model_helper.StorefrontPath(vars["channel_slug"], objectType, currentSlug)
+		if subpath != "/" {
+			target = subpath + target
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+
+	if *c.App.Config().ServiceSettings.WebserverMode == "disabled" {
+		Handle404(c.App, w, r)
+		return
+	}
+	root(c, w, r)
+}
@@ -31,7 +31,9 @@ func New(srv *app.Server) *Web {
 
 	// web.InitOAuth()
 	// web.InitWebhooks()
-	// web.InitSaml()
+	web.InitSaml()
+	web.InitSeo()
+	web.InitFiles()
 	web.InitStatic()
 
 	return web